@@ -0,0 +1,45 @@
+// Command idlgen writes typed Go bindings for a program IDL. It is run through go generate, see
+// pkg/idl/bindings/generate.go:
+//
+//	idlgen -idl data/IDL/raydium_amm_idl.json -pkg raydiumamm -out pkg/idl/bindings/raydiumamm/idl_gen.go
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"corvus_bot/pkg/idl"
+	"corvus_bot/pkg/idl/codegen"
+)
+
+func main() {
+	idlPath := flag.String("idl", "", "path of the IDL JSON file")
+	pkg := flag.String("pkg", "", "name of the generated package")
+	out := flag.String("out", "", "path of the generated Go file")
+	subset := flag.Bool("subset", false, "the IDL is a hand-written subset of the program's IDL")
+	flag.Parse()
+
+	if *idlPath == "" || *pkg == "" || *out == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	program, err := idl.Load(*idlPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := codegen.Generate(program, codegen.Options{Package: *pkg, Source: filepath.Base(*idlPath), Subset: *subset})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatalf("failed to create output directory: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("failed to write bindings: %v", err)
+	}
+}
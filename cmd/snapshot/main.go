@@ -0,0 +1,247 @@
+// Command snapshot captures mainnet accounts at one slot, and optionally a transaction, into a JSON file
+// utils.LoadSnapshot reads, so that decoders and simulators can be replayed against real state. For example,
+// the SOL-USDC AMM v4 pool with its vaults and mints:
+//
+//	snapshot -accounts 58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2,DQyrAcCrDXQ7NeoqGgDCZwBvWDcYmFCjSb9JtteuvPpz,HLmqeL62xR1QoZ1HKKbXRrdN1p3phKpxRMb2VVopvBBz,So11111111111111111111111111111111111111112,EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v -out amm_sol_usdc.json
+//
+// With -next-tx, the command waits for the first successful transaction touching the account in a later
+// slot than the accounts were read at, so that the transaction executed against the recorded state.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"corvus_bot/pkg/meteora"
+	"corvus_bot/pkg/raydium/pool/clmm"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+const (
+	// maxAccounts is the getMultipleAccounts limit: a snapshot is read in a single request to share one slot
+	maxAccounts = 100
+
+	// tickArrayPoolOffset and binArrayPairOffset locate the pool a CLMM tick array or a DLMM bin array belongs to
+	tickArrayPoolOffset = 8
+	binArrayPairOffset  = 24
+)
+
+func main() {
+	endpoint := flag.String("rpc", rpc.MainNetBeta_RPC, "RPC endpoint")
+	accounts := flag.String("accounts", "", "comma separated accounts to capture")
+	clmmPool := flag.String("clmm", "", "Raydium CLMM pool to capture with its AmmConfig, vaults, mints, bitmap extension and tick arrays")
+	dlmmPair := flag.String("dlmm", "", "Meteora DLMM pair to capture with its mints, reserves and bin arrays")
+	signature := flag.String("tx", "", "transaction to capture")
+	nextTx := flag.String("next-tx", "", "capture the first transaction touching this account after the accounts were read")
+	out := flag.String("out", "", "path of the snapshot")
+	flag.Parse()
+
+	if *out == "" || (*signature != "" && *nextTx != "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	client := rpc.New(*endpoint)
+
+	addresses, err := parseAddresses(*accounts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *clmmPool != "" {
+		related, err := clmmAccounts(ctx, client, solana.MustPublicKeyFromBase58(*clmmPool))
+		if err != nil {
+			log.Fatal(err)
+		}
+		addresses = append(addresses, related...)
+	}
+	if *dlmmPair != "" {
+		related, err := dlmmAccounts(ctx, client, solana.MustPublicKeyFromBase58(*dlmmPair))
+		if err != nil {
+			log.Fatal(err)
+		}
+		addresses = append(addresses, related...)
+	}
+
+	snapshot := &utils.Snapshot{Source: "snapshot " + strings.Join(os.Args[1:], " ")}
+	if len(addresses) > 0 {
+		if err := readAccounts(ctx, client, snapshot, addresses); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	switch {
+	case *signature != "":
+		snapshot.Transaction, err = fetchTransaction(ctx, client, solana.MustSignatureFromBase58(*signature))
+	case *nextTx != "":
+		snapshot.Transaction, err = waitNextTransaction(ctx, client, solana.MustPublicKeyFromBase58(*nextTx), snapshot.Slot)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatalf("failed to create output directory: %v", err)
+	}
+	if err := snapshot.Save(*out); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Captured %d accounts at slot %d to %s", len(snapshot.Accounts), snapshot.Slot, *out)
+}
+
+func parseAddresses(list string) ([]solana.PublicKey, error) {
+	var addresses []solana.PublicKey
+	for _, field := range strings.Split(list, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		address, err := solana.PublicKeyFromBase58(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account %q: %w", field, err)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// readAccounts reads every account in one request, so that they all reflect the same slot
+func readAccounts(ctx context.Context, client *rpc.Client, snapshot *utils.Snapshot, addresses []solana.PublicKey) error {
+	if len(addresses) > maxAccounts {
+		return fmt.Errorf("%d accounts do not fit in one getMultipleAccounts request of %d", len(addresses), maxAccounts)
+	}
+	result, err := client.GetMultipleAccountsWithOpts(ctx, addresses, &rpc.GetMultipleAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch accounts: %w", err)
+	}
+	snapshot.Slot = result.Context.Slot
+	for k, account := range result.Value {
+		if account == nil {
+			log.Printf("Account %s does not exist at slot %d", addresses[k], snapshot.Slot)
+			continue
+		}
+		snapshot.Accounts = append(snapshot.Accounts, utils.SnapshotAccount{
+			Address:  addresses[k],
+			Owner:    account.Owner,
+			Lamports: account.Lamports,
+			Data:     account.Data.GetBinary(),
+		})
+	}
+	return nil
+}
+
+// clmmAccounts lists the accounts a CLMM swap reads: the pool, its AmmConfig, vaults and mints, the bitmap
+// extension and every tick array of the pool
+func clmmAccounts(ctx context.Context, client *rpc.Client, pool solana.PublicKey) ([]solana.PublicKey, error) {
+	account, err := client.GetAccountInfo(ctx, pool)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pool %s: %w", pool, err)
+	}
+	state, err := clmm.DecodePoolState(account.Value.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+	programID := account.Value.Owner
+	extension, err := clmm.FindTickArrayBitmapExtensionAddress(programID, pool)
+	if err != nil {
+		return nil, err
+	}
+	tickArrays, err := ownedAccounts(ctx, client, programID, pool, tickArrayPoolOffset, clmm.TickArrayStateSize)
+	if err != nil {
+		return nil, err
+	}
+	accounts := []solana.PublicKey{pool, state.AmmConfig, state.TokenVault0, state.TokenVault1, state.TokenMint0, state.TokenMint1, extension}
+	return append(accounts, tickArrays...), nil
+}
+
+// dlmmAccounts lists the accounts a DLMM swap reads: the pair, its mints and reserves and every bin array
+// of the pair
+func dlmmAccounts(ctx context.Context, client *rpc.Client, pair solana.PublicKey) ([]solana.PublicKey, error) {
+	account, err := client.GetAccountInfo(ctx, pair)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lb pair %s: %w", pair, err)
+	}
+	state, err := meteora.DecodeLbPair(account.Value.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+	binArrays, err := ownedAccounts(ctx, client, account.Value.Owner, pair, binArrayPairOffset, 0)
+	if err != nil {
+		return nil, err
+	}
+	accounts := []solana.PublicKey{pair, state.TokenXMint, state.TokenYMint, state.ReserveX, state.ReserveY}
+	return append(accounts, binArrays...), nil
+}
+
+// ownedAccounts lists the accounts of programID holding parent at offset, of the given size when not zero
+func ownedAccounts(ctx context.Context, client *rpc.Client, programID, parent solana.PublicKey, offset uint64, size uint64) ([]solana.PublicKey, error) {
+	filters := []rpc.RPCFilter{{Memcmp: &rpc.RPCFilterMemcmp{Offset: offset, Bytes: parent.Bytes()}}}
+	if size > 0 {
+		filters = append(filters, rpc.RPCFilter{DataSize: size})
+	}
+	zero := uint64(0)
+	result, err := client.GetProgramAccountsWithOpts(ctx, programID, &rpc.GetProgramAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentConfirmed,
+		Filters:    filters,
+		DataSlice:  &rpc.DataSlice{Offset: &zero, Length: &zero},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the accounts of %s: %w", parent, err)
+	}
+	addresses := make([]solana.PublicKey, len(result))
+	for k, account := range result {
+		addresses[k] = account.Pubkey
+	}
+	return addresses, nil
+}
+
+func fetchTransaction(ctx context.Context, client *rpc.Client, signature solana.Signature) (*rpc.GetTransactionResult, error) {
+	return utils.FetchTransaction(ctx, &utils.RealRPCClient{Client: client}, signature, utils.DefaultFetchAttempts, utils.DefaultFetchDelay)
+}
+
+// waitNextTransaction waits for the first successful transaction touching account after slot. Signatures
+// come newest first, so the last one after slot is the earliest.
+func waitNextTransaction(ctx context.Context, client *rpc.Client, account solana.PublicKey, slot uint64) (*rpc.GetTransactionResult, error) {
+	for {
+		signatures, err := client.GetSignaturesForAddressWithOpts(ctx, account, &rpc.GetSignaturesForAddressOpts{
+			Commitment: rpc.CommitmentConfirmed,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch signatures of %s: %w", account, err)
+		}
+		if len(signatures) > 0 && signatures[len(signatures)-1].Slot > slot {
+			return nil, fmt.Errorf("too many transactions touched %s since slot %d to find the first one", account, slot)
+		}
+
+		var next *rpc.TransactionSignature
+		for _, signature := range signatures {
+			if signature.Slot <= slot {
+				break
+			}
+			if signature.Err == nil {
+				next = signature
+			}
+		}
+		if next != nil {
+			return fetchTransaction(ctx, client, next.Signature)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/viper"
+)
+
+const (
+	// EnvPrefix is prepended to every environment variable override, e.g. CORVUS_RPC_CONNECTION
+	EnvPrefix = "CORVUS"
+
+	// DefaultConfigFile is used when LoadConfig is called with an empty path
+	DefaultConfigFile = "config.yaml"
+
+	// DefaultProfile is the profile used when none is selected by argument, env or file
+	DefaultProfile = ProfileMainnet
+
+	// Mainnet defaults. These mirror amm.DefaultAMMProgramID and amm.WSOLMint; the amm
+	// package depends on config (through utils) so the values cannot be imported from there.
+	DefaultRPCConnection        = "https://api.mainnet-beta.solana.com"
+	DefaultWSConnection         = "wss://api.mainnet-beta.solana.com"
+	DefaultRaydiumAMMProgramID  = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
+	DefaultRaydiumCLMMProgramID = "CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK"
+	DefaultPumpFunProgramID     = "6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"
+	DefaultMoonshotProgramID    = "MoonCVVNZFSYkqNXP6bxHLPL6QQJiMagDL3qcqUQTrG"
+	DefaultOrcaProgramID        = "whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc"
+	DefaultMeteoraDLMMProgramID = "LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo"
+	DefaultJupiterProgramID     = "JUP6LkbZbjS1jKAapdfBTk7Cf8AFuJ6a4d3FSbhKaJ4"
+	DefaultWSOLAddress          = "So11111111111111111111111111111111111111112"
+	DefaultAMMPoolsPath         = "./data/mainnet/amm_pools.json"
+	DefaultCLMMPoolsPath        = "./data/mainnet/clmm_pools.json"
+)
+
+// Config holds the runtime configuration shared by every package of the bot.
+// The endpoint, program ID, storage and database fields hold the values of the active profile.
+type Config struct {
+	Profile              string         `mapstructure:"profile"`
+	RPCConnection        string         `mapstructure:"rpc_connection"`
+	WSConnection         string         `mapstructure:"ws_connection"`
+	PrivateKey           string         `mapstructure:"private_key"`
+	RaydiumAMMProgramID  string         `mapstructure:"raydium_amm_program_id"`
+	RaydiumCLMMProgramID string         `mapstructure:"raydium_clmm_program_id"`
+	PumpFunProgramID     string         `mapstructure:"pumpfun_program_id"`
+	MoonshotProgramID    string         `mapstructure:"moonshot_program_id"`
+	OrcaProgramID        string         `mapstructure:"orca_program_id"`
+	MeteoraDLMMProgramID string         `mapstructure:"meteora_dlmm_program_id"`
+	JupiterProgramID     string         `mapstructure:"jupiter_program_id"`
+	WSOLAddress          string         `mapstructure:"wsol_address"`
+	AMMPoolsPath         string         `mapstructure:"amm_pools_path"`
+	CLMMPoolsPath        string         `mapstructure:"clmm_pools_path"`
+	Testing              bool           `mapstructure:"testing"`
+	Database             DatabaseConfig `mapstructure:"database"`
+}
+
+// DatabaseConfig holds the database connection settings
+type DatabaseConfig struct {
+	CorvusGoDb string `mapstructure:"corvus_go_db"` // PostgreSQL DSN
+}
+
+// LoadConfig reads the YAML file at path, applies CORVUS_* environment overrides on top of it,
+// fills in mainnet defaults for anything left unset and validates the result.
+// An empty path looks for config.yaml in the working directory and tolerates it being absent.
+// The profile is taken from CORVUS_PROFILE or the file's profile key.
+func LoadConfig(path string) (*Config, error) {
+	return LoadConfigWithProfile(path, "")
+}
+
+// LoadConfigWithProfile works like LoadConfig but selects the named profile, typically from a
+// -profile command line flag. An empty name falls back to CORVUS_PROFILE, the file, then mainnet.
+//
+// Values are layered, lowest first: built-in defaults, the built-in profile, top-level keys of
+// the file, the file's profiles.<name> section, and finally environment variables.
+func LoadConfigWithProfile(path, profile string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	if path == "" {
+		v.SetConfigFile(DefaultConfigFile)
+		if err := v.ReadInConfig(); err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("failed to read config file %s: %w", DefaultConfigFile, err)
+		}
+	} else {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	}
+
+	if err := applyProfile(v, profile); err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks every endpoint and public key field and reports all problems at once
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := validateURL(c.RPCConnection, "http", "https"); err != nil {
+		errs = append(errs, fmt.Errorf("rpc_connection: %w", err))
+	}
+	if err := validateURL(c.WSConnection, "ws", "wss"); err != nil {
+		errs = append(errs, fmt.Errorf("ws_connection: %w", err))
+	}
+
+	keys := []struct {
+		name  string
+		value string
+	}{
+		{"raydium_amm_program_id", c.RaydiumAMMProgramID},
+		{"raydium_clmm_program_id", c.RaydiumCLMMProgramID},
+		{"pumpfun_program_id", c.PumpFunProgramID},
+		{"moonshot_program_id", c.MoonshotProgramID},
+		{"orca_program_id", c.OrcaProgramID},
+		{"meteora_dlmm_program_id", c.MeteoraDLMMProgramID},
+		{"jupiter_program_id", c.JupiterProgramID},
+		{"wsol_address", c.WSOLAddress},
+	}
+	for _, key := range keys {
+		if err := validatePublicKey(key.value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key.name, err))
+		}
+	}
+
+	if c.AMMPoolsPath == "" {
+		errs = append(errs, fmt.Errorf("amm_pools_path: must not be empty"))
+	}
+	if c.CLMMPoolsPath == "" {
+		errs = append(errs, fmt.Errorf("clmm_pools_path: must not be empty"))
+	}
+
+	// The private key is optional for read-only usage, but must decode when present
+	if c.PrivateKey != "" {
+		if _, err := solana.PrivateKeyFromBase58(c.PrivateKey); err != nil {
+			errs = append(errs, fmt.Errorf("private_key: invalid base58 private key"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func setDefaults(v *viper.Viper) {
+	// Every key needs a default so AutomaticEnv can see it during Unmarshal
+	v.SetDefault("profile", "")
+	v.SetDefault("private_key", "")
+	v.SetDefault("wsol_address", DefaultWSOLAddress)
+	v.SetDefault("testing", false)
+	v.SetDefault("database.corvus_go_db", "")
+	for key, value := range builtinProfiles[DefaultProfile].settings() {
+		v.SetDefault(key, value)
+	}
+}
+
+// applyProfile resolves the active profile and layers its values into v
+func applyProfile(v *viper.Viper, name string) error {
+	if name == "" {
+		name = v.GetString("profile")
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	builtin, hasBuiltin := builtinProfiles[name]
+	fileProfile := v.Sub("profiles." + name)
+	if !hasBuiltin && fileProfile == nil {
+		return fmt.Errorf("unknown config profile %q", name)
+	}
+
+	// Built-in values only replace defaults, so top-level keys in the file still win
+	if hasBuiltin {
+		for key, value := range builtin.settings() {
+			v.SetDefault(key, value)
+		}
+	}
+
+	// The file's own profile section overrides its top-level keys; env vars still win over both
+	if fileProfile != nil {
+		if err := v.MergeConfigMap(fileProfile.AllSettings()); err != nil {
+			return fmt.Errorf("failed to apply profile %q: %w", name, err)
+		}
+	}
+
+	v.Set("profile", name)
+	return nil
+}
+
+func validateURL(raw string, schemes ...string) error {
+	if raw == "" {
+		return fmt.Errorf("must not be empty")
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", raw, err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", raw)
+	}
+
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid URL %q: scheme must be one of %s", raw, strings.Join(schemes, ", "))
+}
+
+func validatePublicKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if _, err := solana.PublicKeyFromBase58(key); err != nil {
+		return fmt.Errorf("invalid public key %q: %w", key, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var notFound viper.ConfigFileNotFoundError
+	if errors.As(err, &notFound) {
+		return true
+	}
+	// SetConfigFile reports a missing file as a plain *fs.PathError
+	return errors.Is(err, fs.ErrNotExist)
+}
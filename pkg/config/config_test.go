@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigFromFile(t *testing.T) {
+	cfg, err := LoadConfig("config.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultRPCConnection, cfg.RPCConnection)
+	assert.Equal(t, DefaultWSConnection, cfg.WSConnection)
+	assert.Equal(t, DefaultRaydiumAMMProgramID, cfg.RaydiumAMMProgramID)
+	assert.NotEmpty(t, cfg.Database.CorvusGoDb)
+}
+
+func TestLoadConfigDefaultsAndEnvOverride(t *testing.T) {
+	// Only the RPC endpoint is set in the file, everything else comes from defaults or env
+	path := writeConfig(t, "rpc_connection: \"http://127.0.0.1:8899\"\n")
+
+	t.Setenv("CORVUS_WS_CONNECTION", "ws://127.0.0.1:8900")
+	t.Setenv("CORVUS_DATABASE_CORVUS_GO_DB", "host=db")
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://127.0.0.1:8899", cfg.RPCConnection)
+	assert.Equal(t, "ws://127.0.0.1:8900", cfg.WSConnection)
+	assert.Equal(t, "host=db", cfg.Database.CorvusGoDb)
+	assert.Equal(t, DefaultRaydiumAMMProgramID, cfg.RaydiumAMMProgramID)
+	assert.Equal(t, DefaultRaydiumCLMMProgramID, cfg.RaydiumCLMMProgramID)
+	assert.Equal(t, DefaultWSOLAddress, cfg.WSOLAddress)
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	path := writeConfig(t, `
+rpc_connection: "ftp://example.com"
+ws_connection: "wss://example.com"
+raydium_amm_program_id: "not-a-key"
+private_key: "bogus"
+`)
+
+	_, err := LoadConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rpc_connection")
+	assert.Contains(t, err.Error(), "raydium_amm_program_id")
+	assert.Contains(t, err.Error(), "private_key")
+	assert.NotContains(t, err.Error(), "ws_connection")
+}
+
+// writeConfig writes a temporary YAML config file and returns its path
+func writeConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func TestBuiltinProfilesKeepPoolFilesApart(t *testing.T) {
+	seen := make(map[string]string)
+	for name, profile := range builtinProfiles {
+		for _, path := range []string{profile.AMMPoolsPath, profile.CLMMPoolsPath} {
+			assert.NotContains(t, path, "testdata", "profile %s writes to the test fixtures", name)
+			other, ok := seen[path]
+			assert.False(t, ok, "profiles %s and %s share %s", name, other, path)
+			seen[path] = name
+		}
+	}
+}
+
+func TestLoadConfigProfiles(t *testing.T) {
+	path := writeConfig(t, `
+profile: "devnet"
+profiles:
+  devnet:
+    database:
+      corvus_go_db: "host=devnet-db"
+  staging:
+    rpc_connection: "https://staging.example.com"
+    ws_connection: "wss://staging.example.com"
+`)
+
+	// Profile from the file, built-in values filled in around the file's overrides
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	devnet, _ := BuiltinProfile(ProfileDevnet)
+	assert.Equal(t, ProfileDevnet, cfg.Profile)
+	assert.Equal(t, devnet.RPCConnection, cfg.RPCConnection)
+	assert.Equal(t, devnet.RaydiumAMMProgramID, cfg.RaydiumAMMProgramID)
+	assert.Equal(t, devnet.AMMPoolsPath, cfg.AMMPoolsPath)
+	assert.Equal(t, "host=devnet-db", cfg.Database.CorvusGoDb)
+
+	// Env var selects a profile only declared in the file
+	t.Setenv("CORVUS_PROFILE", "staging")
+	cfg, err = LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", cfg.Profile)
+	assert.Equal(t, "https://staging.example.com", cfg.RPCConnection)
+	assert.Equal(t, DefaultRaydiumAMMProgramID, cfg.RaydiumAMMProgramID)
+
+	// Explicit profile argument wins, env still overrides individual values
+	t.Setenv("CORVUS_RPC_CONNECTION", "http://10.0.0.1:8899")
+	cfg, err = LoadConfigWithProfile(path, ProfileLocalnet)
+	require.NoError(t, err)
+	assert.Equal(t, ProfileLocalnet, cfg.Profile)
+	assert.Equal(t, "http://10.0.0.1:8899", cfg.RPCConnection)
+	assert.Equal(t, "ws://127.0.0.1:8900", cfg.WSConnection)
+	assert.Equal(t, cfg.AMMPoolsPath, cfg.ActiveProfile().AMMPoolsPath)
+
+	_, err = LoadConfigWithProfile(path, "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+package config
+
+// Built-in profile names
+const (
+	ProfileMainnet  = "mainnet"
+	ProfileDevnet   = "devnet"
+	ProfileLocalnet = "localnet"
+)
+
+// Profile bundles the settings that change between clusters.
+// Profiles can also be declared or overridden under the profiles key of config.yaml.
+// The pool files cache the pools fetched on the cluster; they are created on first use and written to, so
+// every profile needs its own.
+type Profile struct {
+	RPCConnection        string         `mapstructure:"rpc_connection"`
+	WSConnection         string         `mapstructure:"ws_connection"`
+	RaydiumAMMProgramID  string         `mapstructure:"raydium_amm_program_id"`
+	RaydiumCLMMProgramID string         `mapstructure:"raydium_clmm_program_id"`
+	PumpFunProgramID     string         `mapstructure:"pumpfun_program_id"`
+	MoonshotProgramID    string         `mapstructure:"moonshot_program_id"`
+	OrcaProgramID        string         `mapstructure:"orca_program_id"`
+	MeteoraDLMMProgramID string         `mapstructure:"meteora_dlmm_program_id"`
+	JupiterProgramID     string         `mapstructure:"jupiter_program_id"`
+	AMMPoolsPath         string         `mapstructure:"amm_pools_path"`
+	CLMMPoolsPath        string         `mapstructure:"clmm_pools_path"`
+	Database             DatabaseConfig `mapstructure:"database"`
+}
+
+// builtinProfiles holds the defaults for the clusters the team switches between
+var builtinProfiles = map[string]Profile{
+	ProfileMainnet: {
+		RPCConnection:        DefaultRPCConnection,
+		WSConnection:         DefaultWSConnection,
+		RaydiumAMMProgramID:  DefaultRaydiumAMMProgramID,
+		RaydiumCLMMProgramID: DefaultRaydiumCLMMProgramID,
+		PumpFunProgramID:     DefaultPumpFunProgramID,
+		MoonshotProgramID:    DefaultMoonshotProgramID,
+		OrcaProgramID:        DefaultOrcaProgramID,
+		MeteoraDLMMProgramID: DefaultMeteoraDLMMProgramID,
+		JupiterProgramID:     DefaultJupiterProgramID,
+		AMMPoolsPath:         DefaultAMMPoolsPath,
+		CLMMPoolsPath:        DefaultCLMMPoolsPath,
+	},
+	ProfileDevnet: {
+		RPCConnection:        "https://api.devnet.solana.com",
+		WSConnection:         "wss://api.devnet.solana.com",
+		RaydiumAMMProgramID:  "HWy1jotHpo6UqeQxx49dpYYdQB8wj9Qk9MdxwjLvDHB8",
+		RaydiumCLMMProgramID: "devi51mZmdwUJGU9hjN27vEz64Gps7uUefqxg27EAtH",
+		PumpFunProgramID:     DefaultPumpFunProgramID,
+		MoonshotProgramID:    DefaultMoonshotProgramID,
+		OrcaProgramID:        DefaultOrcaProgramID,
+		MeteoraDLMMProgramID: DefaultMeteoraDLMMProgramID,
+		JupiterProgramID:     DefaultJupiterProgramID,
+		AMMPoolsPath:         "./data/devnet/amm_pools.json",
+		CLMMPoolsPath:        "./data/devnet/clmm_pools.json",
+	},
+	ProfileLocalnet: {
+		// A local test validator with the mainnet programs cloned in
+		RPCConnection:        "http://127.0.0.1:8899",
+		WSConnection:         "ws://127.0.0.1:8900",
+		RaydiumAMMProgramID:  DefaultRaydiumAMMProgramID,
+		RaydiumCLMMProgramID: DefaultRaydiumCLMMProgramID,
+		PumpFunProgramID:     DefaultPumpFunProgramID,
+		MoonshotProgramID:    DefaultMoonshotProgramID,
+		OrcaProgramID:        DefaultOrcaProgramID,
+		MeteoraDLMMProgramID: DefaultMeteoraDLMMProgramID,
+		JupiterProgramID:     DefaultJupiterProgramID,
+		AMMPoolsPath:         "./data/localnet/amm_pools.json",
+		CLMMPoolsPath:        "./data/localnet/clmm_pools.json",
+	},
+}
+
+// BuiltinProfile returns the built-in profile with the given name
+func BuiltinProfile(name string) (Profile, bool) {
+	p, ok := builtinProfiles[name]
+	return p, ok
+}
+
+// ActiveProfile returns the profile values the config was resolved with
+func (c *Config) ActiveProfile() Profile {
+	return Profile{
+		RPCConnection:        c.RPCConnection,
+		WSConnection:         c.WSConnection,
+		RaydiumAMMProgramID:  c.RaydiumAMMProgramID,
+		RaydiumCLMMProgramID: c.RaydiumCLMMProgramID,
+		PumpFunProgramID:     c.PumpFunProgramID,
+		MoonshotProgramID:    c.MoonshotProgramID,
+		OrcaProgramID:        c.OrcaProgramID,
+		MeteoraDLMMProgramID: c.MeteoraDLMMProgramID,
+		JupiterProgramID:     c.JupiterProgramID,
+		AMMPoolsPath:         c.AMMPoolsPath,
+		CLMMPoolsPath:        c.CLMMPoolsPath,
+		Database:             c.Database,
+	}
+}
+
+// settings flattens the non-empty profile fields into viper keys
+func (p Profile) settings() map[string]interface{} {
+	values := map[string]string{
+		"rpc_connection":          p.RPCConnection,
+		"ws_connection":           p.WSConnection,
+		"raydium_amm_program_id":  p.RaydiumAMMProgramID,
+		"raydium_clmm_program_id": p.RaydiumCLMMProgramID,
+		"pumpfun_program_id":      p.PumpFunProgramID,
+		"moonshot_program_id":     p.MoonshotProgramID,
+		"orca_program_id":         p.OrcaProgramID,
+		"meteora_dlmm_program_id": p.MeteoraDLMMProgramID,
+		"jupiter_program_id":      p.JupiterProgramID,
+		"amm_pools_path":          p.AMMPoolsPath,
+		"clmm_pools_path":         p.CLMMPoolsPath,
+		"database.corvus_go_db":   p.Database.CorvusGoDb,
+	}
+
+	settings := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	return settings
+}
@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 
 	"corvus_bot/pkg/config"
@@ -34,8 +35,12 @@ func ConnectDatabase(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
-// NewDatabase creates a new database connection and initializes the schema
+// NewDatabase creates a new database connection for the active config profile and initializes the schema
 func NewDatabase(cfg *config.Config) (*Database, error) {
+	if cfg.Database.CorvusGoDb == "" {
+		return nil, fmt.Errorf("no database DSN configured for profile %q", cfg.Profile)
+	}
+
 	newLogger := logger.New(
 		log.New(os.Stdout, "\r\n", log.LstdFlags),
 		logger.Config{
@@ -107,7 +112,7 @@ func (db *Database) InitializeEnums() error {
 			'RAYDIUM', 'JUPITER', 'METEORA', 'MOONSHOT', 'PUMPFUN', 'ORCA'
 		);
 		CREATE TYPE pool_type AS ENUM (
-			'AMM', 'CLMM', 'WHIRLPOOL', 'BONDING_CURVE'
+			'AMM', 'CLMM', 'WHIRLPOOL', 'BONDING_CURVE', 'DLMM'
 		);
 		CREATE TYPE asset_type AS ENUM (
 			'FUNGIBLE', 'NON_FUNGIBLE', 'COMPRESSED'
@@ -221,6 +226,52 @@ func (db *Database) CreateMigration(migration *models.Migration) error {
 	return db.Create(migration).Error
 }
 
+// MigrationRecord groups the rows written when a token moves from one pool to another
+type MigrationRecord struct {
+	Asset        *models.Asset // Created when not known yet
+	SourcePool   *models.Pool  // Created when not known yet, its status is set to PoolStatusMigrated
+	TargetPool   *models.Pool  // Created when not known yet
+	Migration    *models.Migration
+	Relationship *models.PoolRelationship
+}
+
+// RecordMigration writes a migration, the relationship between its pools and the source pool status in one
+// transaction. A migration already recorded for the target pool is left as is.
+func (db *Database) RecordMigration(record *MigrationRecord) error {
+	return db.WithTx(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.Migration{}).
+			Where("target_pool_id = ? AND type = ?", record.Migration.TargetPoolID, record.Migration.Type).
+			Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to look up migration: %w", err)
+		}
+		if existing > 0 {
+			return nil
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record.Asset).Error; err != nil {
+			return fmt.Errorf("failed to create asset %s: %w", record.Asset.ID, err)
+		}
+		for _, pool := range []*models.Pool{record.SourcePool, record.TargetPool} {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(pool).Error; err != nil {
+				return fmt.Errorf("failed to create pool %s: %w", pool.ID, err)
+			}
+		}
+		if err := tx.Model(&models.Pool{}).Where("id = ?", record.SourcePool.ID).
+			Updates(map[string]interface{}{"status": models.PoolStatusMigrated, "last_updated": time.Now().UTC()}).Error; err != nil {
+			return fmt.Errorf("failed to mark pool %s as migrated: %w", record.SourcePool.ID, err)
+		}
+
+		if err := tx.Create(record.Migration).Error; err != nil {
+			return fmt.Errorf("failed to create migration: %w", err)
+		}
+		if err := tx.Create(record.Relationship).Error; err != nil {
+			return fmt.Errorf("failed to create pool relationship: %w", err)
+		}
+		return nil
+	})
+}
+
 func (db *Database) GetMigrationsByAsset(assetID string) ([]models.Migration, error) {
 	var migrations []models.Migration
 	err := db.Where("source_asset_id = ? OR target_asset_id = ?", assetID, assetID).
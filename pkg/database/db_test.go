@@ -0,0 +1,126 @@
+package database
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/database/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDatabase opens an in-memory SQLite database with the tables of the models. The PostgreSQL
+// extensions, enums and hypertables of InitializeSchema are left out.
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	// Every connection to :memory: opens its own database
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	database := &Database{db}
+	require.NoError(t, database.AutoMigrate(
+		&models.Asset{},
+		&models.Pool{},
+		&models.PoolRelationship{},
+		&models.Migration{},
+	))
+	return database
+}
+
+func testMigrationRecord(mint, curve, pool string) *MigrationRecord {
+	base := models.BaseModel{LastUpdated: time.Date(2024, 11, 28, 0, 3, 25, 0, time.UTC)}
+	record := &MigrationRecord{
+		Asset:      &models.Asset{},
+		SourcePool: &models.Pool{},
+		TargetPool: &models.Pool{},
+		Migration: &models.Migration{
+			BaseModel:     base,
+			SourceAssetID: mint,
+			TargetAssetID: mint,
+			SourcePoolID:  curve,
+			TargetPoolID:  pool,
+			Type:          models.MigrationTypeMoonshotToRaydium,
+			Status:        models.MigrationStatusCompleted,
+			TxSignature:   "pool-signature",
+			Slot:          304027105,
+		},
+		Relationship: &models.PoolRelationship{
+			BaseModel:      base,
+			SourcePoolID:   curve,
+			TargetPoolID:   pool,
+			RelationType:   models.RelationTypeMigration,
+			SourceProtocol: models.ProtocolMoonshot,
+			TargetProtocol: models.ProtocolRaydium,
+			Metadata: models.JSONMap{
+				"migrate_signature":   "migrate-signature",
+				"collateral_migrated": uint64(18_446_744_073_709_551_000),
+			},
+		},
+	}
+	record.Asset.BaseModel = base
+	record.Asset.ID = mint
+	record.Asset.Address = mint
+	record.Asset.Mint = mint
+	record.Asset.Decimals = 9
+	record.SourcePool.BaseModel = base
+	record.SourcePool.ID = curve
+	record.SourcePool.Protocol = models.ProtocolMoonshot
+	record.SourcePool.Status = models.PoolStatusActive
+	record.TargetPool.BaseModel = base
+	record.TargetPool.ID = pool
+	record.TargetPool.Protocol = models.ProtocolRaydium
+	record.TargetPool.Status = models.PoolStatusActive
+	return record
+}
+
+func TestRecordMigrationRoundTrip(t *testing.T) {
+	db := newTestDatabase(t)
+	require.NoError(t, db.RecordMigration(testMigrationRecord("mint", "curve", "pool")))
+
+	source, err := db.GetPoolByID("curve")
+	require.NoError(t, err)
+	assert.Equal(t, models.PoolStatusMigrated, source.Status)
+	target, err := db.GetPoolByID("pool")
+	require.NoError(t, err)
+	assert.Equal(t, models.PoolStatusActive, target.Status)
+
+	migrations, err := db.GetMigrationsByAsset("mint")
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	assert.Equal(t, "pool", migrations[0].TargetPoolID)
+	assert.Equal(t, uint64(304027105), migrations[0].Slot)
+
+	var relationship models.PoolRelationship
+	require.NoError(t, db.Where("target_pool_id = ?", "pool").First(&relationship).Error)
+	assert.Equal(t, models.RelationTypeMigration, relationship.RelationType)
+	assert.Equal(t, "migrate-signature", relationship.Metadata["migrate_signature"])
+	assert.Equal(t, json.Number("18446744073709551000"), relationship.Metadata["collateral_migrated"], "u64 amounts keep their precision")
+
+	// Recording the same pool again is a no-op, even with rows that already exist
+	require.NoError(t, db.RecordMigration(testMigrationRecord("mint", "curve", "pool")))
+	var count int64
+	require.NoError(t, db.Model(&models.Migration{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	require.NoError(t, db.Model(&models.PoolRelationship{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestJSONMapNull(t *testing.T) {
+	value, err := models.JSONMap(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	metadata := models.JSONMap{"stale": true}
+	require.NoError(t, metadata.Scan(nil))
+	assert.Nil(t, metadata)
+	assert.Error(t, metadata.Scan(42))
+}
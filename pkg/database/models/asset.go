@@ -2,19 +2,19 @@ package models
 
 type Asset struct {
 	BaseAsset
-	Content     Content      `gorm:"type:jsonb"`
-	Authorities []Authority  `gorm:"type:jsonb"`
-	Compression *Compression `gorm:"type:jsonb"`
-	Grouping    []Grouping   `gorm:"type:jsonb"`
-	Royalty     *Royalty     `gorm:"type:jsonb"`
-	Creators    []Creator    `gorm:"type:jsonb"`
-	Ownership   Ownership    `gorm:"type:jsonb"`
-	TokenInfo   TokenInfo    `gorm:"type:jsonb"`
-	MarketData  MarketData   `gorm:"type:jsonb"`
+	Content     Content      `gorm:"type:jsonb;serializer:json"`
+	Authorities []Authority  `gorm:"type:jsonb;serializer:json"`
+	Compression *Compression `gorm:"type:jsonb;serializer:json"`
+	Grouping    []Grouping   `gorm:"type:jsonb;serializer:json"`
+	Royalty     *Royalty     `gorm:"type:jsonb;serializer:json"`
+	Creators    []Creator    `gorm:"type:jsonb;serializer:json"`
+	Ownership   Ownership    `gorm:"type:jsonb;serializer:json"`
+	TokenInfo   TokenInfo    `gorm:"type:jsonb;serializer:json"`
+	MarketData  MarketData   `gorm:"type:jsonb;serializer:json"`
 
 	// Token specific data
-	TokenData *TokenData `gorm:"type:jsonb"`
-	NFTData   *NFTData   `gorm:"type:jsonb"`
+	TokenData *TokenData `gorm:"type:jsonb;serializer:json"`
+	NFTData   *NFTData   `gorm:"type:jsonb;serializer:json"`
 
 	// Relationships
 	Pools   []Pool        `gorm:"many2many:asset_pools;"`
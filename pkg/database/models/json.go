@@ -0,0 +1,49 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONMap is a JSON object column. Numbers are read back as json.Number, so that u64 amounts above 2^53
+// keep their precision.
+type JSONMap map[string]interface{}
+
+// Value encodes the map as JSON, or NULL when it is nil
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(map[string]interface{}(m))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON column: %w", err)
+	}
+	return string(data), nil
+}
+
+// Scan decodes a JSON column, which drivers return as text or bytes
+func (m *JSONMap) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", value)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded map[string]interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return fmt.Errorf("failed to decode JSON column: %w", err)
+	}
+	*m = decoded
+	return nil
+}
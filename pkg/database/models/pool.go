@@ -3,8 +3,8 @@ package models
 // Pool represents a unified pool model for all DEX types
 type Pool struct {
 	BasePool
-	Config      PoolConfig  `gorm:"type:jsonb"`
-	MarketState MarketState `gorm:"type:jsonb"`
+	Config      PoolConfig  `gorm:"type:jsonb;serializer:json"`
+	MarketState MarketState `gorm:"type:jsonb;serializer:json"`
 	Authority   string      `gorm:"type:varchar(64)"`
 	BaseVault   string      `gorm:"type:varchar(64)"`
 	QuoteVault  string      `gorm:"type:varchar(64)"`
@@ -12,11 +12,11 @@ type Pool struct {
 	LpVault     *string     `gorm:"type:varchar(64)"`
 
 	// Protocol-specific extensions
-	RaydiumData   *RaydiumPoolData `gorm:"type:jsonb"`
-	WhirlpoolData *WhirlpoolData   `gorm:"type:jsonb"`
-	JupiterData   *JupiterPoolData `gorm:"type:jsonb"`
-	MeteoraData   *MeteoraPoolData `gorm:"type:jsonb"`
-	PumpFunData   *PumpFunPoolData `gorm:"type:jsonb"`
+	RaydiumData   *RaydiumPoolData `gorm:"type:jsonb;serializer:json"`
+	WhirlpoolData *WhirlpoolData   `gorm:"type:jsonb;serializer:json"`
+	JupiterData   *JupiterPoolData `gorm:"type:jsonb;serializer:json"`
+	MeteoraData   *MeteoraPoolData `gorm:"type:jsonb;serializer:json"`
+	PumpFunData   *PumpFunPoolData `gorm:"type:jsonb;serializer:json"`
 
 	// Relationships
 	Assets  []Asset      `gorm:"many2many:asset_pools;"`
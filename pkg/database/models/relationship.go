@@ -6,7 +6,7 @@ type AssetRelationship struct {
 	TargetID     string       `gorm:"type:varchar(64);index"`
 	RelationType RelationType `gorm:"type:varchar(32)"`
 	Protocol     Protocol     `gorm:"type:varchar(20)"`
-	Metadata     interface{}  `gorm:"type:jsonb"`
+	Metadata     JSONMap      `gorm:"type:jsonb"`
 
 	Source Asset `gorm:"foreignKey:SourceID"`
 	Target Asset `gorm:"foreignKey:TargetID"`
@@ -19,7 +19,7 @@ type PoolRelationship struct {
 	RelationType   RelationType `gorm:"type:varchar(32)"`
 	SourceProtocol Protocol     `gorm:"type:varchar(20)"`
 	TargetProtocol Protocol     `gorm:"type:varchar(20)"`
-	Metadata       interface{}  `gorm:"type:jsonb"`
+	Metadata       JSONMap      `gorm:"type:jsonb"`
 
 	SourcePool Pool `gorm:"foreignKey:SourcePoolID"`
 	TargetPool Pool `gorm:"foreignKey:TargetPoolID"`
@@ -22,6 +22,7 @@ const (
 	PoolTypeCLMM         PoolType = "CLMM"
 	PoolTypeWhirlpool    PoolType = "WHIRLPOOL"
 	PoolTypeBondingCurve PoolType = "BONDING_CURVE"
+	PoolTypeDLMM         PoolType = "DLMM"
 
 	// Asset Types
 	AssetTypeFungible    AssetType = "FUNGIBLE"
@@ -49,3 +50,12 @@ const (
 	RelationTypeWrap      RelationType = "WRAP"
 	RelationTypeVersion   RelationType = "VERSION"
 )
+
+const (
+	// Migration Types
+	MigrationTypePumpToRaydium     = "PUMP_TO_RAYDIUM"
+	MigrationTypeMoonshotToRaydium = "MOONSHOT_TO_RAYDIUM"
+
+	// Migration Statuses
+	MigrationStatusCompleted = "COMPLETED"
+)
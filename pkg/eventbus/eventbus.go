@@ -0,0 +1,274 @@
+// Package eventbus is an in-process publish/subscribe bus. Each topic carries one event type to any number of
+// subscribers, each with its own buffer, and decides what happens when a subscriber falls behind.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrClosed is returned when publishing on a closed topic
+var ErrClosed = errors.New("topic is closed")
+
+// Backpressure tells a topic what to do when a subscriber buffer is full
+type Backpressure int
+
+const (
+	// Block waits until the subscriber has room, so no event is lost
+	Block Backpressure = iota
+
+	// DropOldest discards the oldest buffered event to make room for the new one
+	DropOldest
+)
+
+func (b Backpressure) String() string {
+	switch b {
+	case Block:
+		return "block"
+	case DropOldest:
+		return "drop-oldest"
+	default:
+		return fmt.Sprintf("Backpressure(%d)", int(b))
+	}
+}
+
+// TopicStats is a snapshot of the counters of a topic
+type TopicStats struct {
+	Name        string
+	Policy      Backpressure
+	Subscribers int
+	Published   uint64
+	Dropped     uint64
+}
+
+// topic is the type independent part of Topic the bus keeps track of
+type topic interface {
+	stats() TopicStats
+	close()
+}
+
+// Bus holds the topics of the process by name
+type Bus struct {
+	mu     sync.Mutex
+	topics map[string]topic
+}
+
+// New creates an empty bus
+func New() *Bus {
+	return &Bus{topics: make(map[string]topic)}
+}
+
+// Register returns the topic with the name, creating it with the policy on first use, so publishers and
+// subscribers can find it independently. It fails when the name is registered for another event type or
+// with another policy.
+func Register[T any](bus *Bus, name string, policy Backpressure) (*Topic[T], error) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if existing, ok := bus.topics[name]; ok {
+		typed, ok := existing.(*Topic[T])
+		if !ok {
+			return nil, fmt.Errorf("topic %s is registered for another event type", name)
+		}
+		if typed.policy != policy {
+			return nil, fmt.Errorf("topic %s is registered with policy %s, not %s", name, typed.policy, policy)
+		}
+		return typed, nil
+	}
+
+	t := &Topic[T]{name: name, policy: policy, closing: make(chan struct{})}
+	bus.topics[name] = t
+	return t, nil
+}
+
+// Stats returns the counters of every topic, sorted by name
+func (b *Bus) Stats() []TopicStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := make([]TopicStats, 0, len(b.topics))
+	for _, t := range b.topics {
+		stats = append(stats, t.stats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// Close closes every topic, which closes the channels of their subscribers
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, t := range b.topics {
+		t.close()
+	}
+}
+
+// Topic is a named stream of events of type T
+type Topic[T any] struct {
+	name      string
+	policy    Backpressure
+	published atomic.Uint64
+	dropped   atomic.Uint64
+	closing   chan struct{}
+	once      sync.Once
+
+	mu     sync.RWMutex
+	subs   []*Subscription[T]
+	closed bool
+}
+
+// Name returns the name the topic was registered with
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscribe adds a subscriber buffering up to buffer events. Subscribers only receive events published after
+// they subscribed.
+func (t *Topic[T]) Subscribe(buffer int) *Subscription[T] {
+	sub := &Subscription[T]{
+		topic: t,
+		ch:    make(chan T, buffer),
+		done:  make(chan struct{}),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		sub.stop()
+		close(sub.ch)
+		return sub
+	}
+	t.subs = append(t.subs, sub)
+	return sub
+}
+
+// Publish delivers the event to every subscriber according to the topic policy. With Block it returns the
+// context error if a subscriber does not make room before the context is done.
+func (t *Topic[T]) Publish(ctx context.Context, event T) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.closed {
+		return ErrClosed
+	}
+	t.published.Add(1)
+
+	for _, sub := range t.subs {
+		if t.policy == DropOldest {
+			sub.sendDropOldest(event)
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		case <-sub.done:
+		case <-t.closing:
+			return ErrClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Stats returns the counters of the topic
+func (t *Topic[T]) Stats() TopicStats {
+	return t.stats()
+}
+
+func (t *Topic[T]) stats() TopicStats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return TopicStats{
+		Name:        t.name,
+		Policy:      t.policy,
+		Subscribers: len(t.subs),
+		Published:   t.published.Load(),
+		Dropped:     t.dropped.Load(),
+	}
+}
+
+func (t *Topic[T]) close() {
+	// Releases blocked publishers before the write lock is taken
+	t.once.Do(func() { close(t.closing) })
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for _, sub := range t.subs {
+		sub.stop()
+		close(sub.ch)
+	}
+	t.subs = nil
+}
+
+func (t *Topic[T]) remove(sub *Subscription[T]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, s := range t.subs {
+		if s == sub {
+			t.subs = append(t.subs[:i], t.subs[i+1:]...)
+			close(sub.ch)
+			return
+		}
+	}
+}
+
+// Subscription receives the events of a topic
+type Subscription[T any] struct {
+	topic   *Topic[T]
+	ch      chan T
+	done    chan struct{}
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+// C returns the channel events are delivered on. It is closed on Unsubscribe or when the topic is closed.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Dropped returns how many events this subscriber lost to the drop-oldest policy
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops the deliveries and closes the channel
+func (s *Subscription[T]) Unsubscribe() {
+	s.stop()
+	s.topic.remove(s)
+}
+
+func (s *Subscription[T]) stop() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// sendDropOldest enqueues the event, discarding the oldest buffered events until it fits
+func (s *Subscription[T]) sendDropOldest(event T) {
+	for {
+		select {
+		case s.ch <- event:
+			return
+		case <-s.done:
+			return
+		default:
+		}
+		select {
+		case <-s.ch:
+			s.dropped.Add(1)
+			s.topic.dropped.Add(1)
+		default:
+			// An unbuffered subscriber is not reading: the new event is the one dropped
+			if cap(s.ch) == 0 {
+				s.dropped.Add(1)
+				s.topic.dropped.Add(1)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/eventbus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterReturnsSameTopic(t *testing.T) {
+	bus := eventbus.New()
+
+	pools, err := eventbus.Register[string](bus, "pools", eventbus.Block)
+	require.NoError(t, err)
+	again, err := eventbus.Register[string](bus, "pools", eventbus.Block)
+	require.NoError(t, err)
+	assert.Same(t, pools, again)
+
+	_, err = eventbus.Register[string](bus, "pools", eventbus.DropOldest)
+	assert.ErrorContains(t, err, "policy block, not drop-oldest")
+
+	_, err = eventbus.Register[int](bus, "pools", eventbus.Block)
+	assert.ErrorContains(t, err, "another event type")
+}
+
+func TestPublishFansOutToSubscribers(t *testing.T) {
+	bus := eventbus.New()
+	topic, err := eventbus.Register[int](bus, "swaps", eventbus.Block)
+	require.NoError(t, err)
+
+	first := topic.Subscribe(4)
+	second := topic.Subscribe(4)
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, topic.Publish(context.Background(), i))
+	}
+
+	for _, sub := range []*eventbus.Subscription[int]{first, second} {
+		assert.Equal(t, 1, <-sub.C())
+		assert.Equal(t, 2, <-sub.C())
+		assert.Equal(t, 3, <-sub.C())
+	}
+
+	second.Unsubscribe()
+	_, open := <-second.C()
+	assert.False(t, open)
+	assert.Equal(t, 1, topic.Stats().Subscribers)
+	assert.Equal(t, uint64(3), topic.Stats().Published)
+}
+
+func TestBlockWaitsForSlowSubscriber(t *testing.T) {
+	bus := eventbus.New()
+	topic, err := eventbus.Register[int](bus, "pools", eventbus.Block)
+	require.NoError(t, err)
+	sub := topic.Subscribe(1)
+
+	require.NoError(t, topic.Publish(context.Background(), 1))
+
+	published := make(chan error)
+	go func() { published <- topic.Publish(context.Background(), 2) }()
+	select {
+	case <-published:
+		t.Fatal("publish did not wait for room in the subscriber buffer")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	assert.Equal(t, 1, <-sub.C())
+	require.NoError(t, <-published)
+	assert.Equal(t, 2, <-sub.C())
+	assert.Zero(t, topic.Stats().Dropped)
+
+	// A full buffer gives up with the context
+	require.NoError(t, topic.Publish(context.Background(), 3))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, topic.Publish(ctx, 4), context.DeadlineExceeded)
+}
+
+func TestDropOldestKeepsLatestEvents(t *testing.T) {
+	bus := eventbus.New()
+	topic, err := eventbus.Register[int](bus, "swaps", eventbus.DropOldest)
+	require.NoError(t, err)
+	slow := topic.Subscribe(2)
+	fast := topic.Subscribe(10)
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, topic.Publish(context.Background(), i))
+	}
+
+	assert.Equal(t, 4, <-slow.C())
+	assert.Equal(t, 5, <-slow.C())
+	assert.Equal(t, uint64(3), slow.Dropped())
+	assert.Zero(t, fast.Dropped())
+	assert.Len(t, fast.C(), 5)
+
+	stats := bus.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, eventbus.TopicStats{
+		Name: "swaps", Policy: eventbus.DropOldest, Subscribers: 2, Published: 5, Dropped: 3,
+	}, stats[0])
+}
+
+func TestCloseReleasesBlockedPublisher(t *testing.T) {
+	bus := eventbus.New()
+	topic, err := eventbus.Register[int](bus, "pools", eventbus.Block)
+	require.NoError(t, err)
+	sub := topic.Subscribe(0)
+
+	published := make(chan error)
+	go func() { published <- topic.Publish(context.Background(), 1) }()
+	time.Sleep(10 * time.Millisecond)
+
+	bus.Close()
+	assert.ErrorIs(t, <-published, eventbus.ErrClosed)
+	_, open := <-sub.C()
+	assert.False(t, open)
+	assert.ErrorIs(t, topic.Publish(context.Background(), 2), eventbus.ErrClosed)
+}
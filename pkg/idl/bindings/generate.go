@@ -0,0 +1,15 @@
+// Package bindings groups the typed Go bindings generated from the IDLs in data/IDL, one package per program.
+// Run go generate in this directory after updating an IDL.
+//
+// The IDLs in data/IDL are the ones the programs publish, unmodified. Those in data/IDL/subset are written by
+// hand with only the instructions, accounts and events this repository uses, for programs whose IDL is not
+// vendored; their bindings are generated with -subset and miss the rest of the program.
+package bindings
+
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/raydium_amm_idl.json -pkg raydiumamm -out raydiumamm/idl_gen.go
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/raydium_clmm_idl.json -pkg raydiumclmm -out raydiumclmm/idl_gen.go
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/jupiter_idl.json -pkg jupiter -out jupiter/idl_gen.go
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/pumpfun_idl.json -pkg pumpfun -out pumpfun/idl_gen.go
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/moonshot_idl.json -pkg moonshot -out moonshot/idl_gen.go
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/subset/whirlpool_subset_idl.json -subset -pkg whirlpool -out whirlpool/idl_gen.go
+//go:generate go run ../../../cmd/idlgen -idl ../../../data/IDL/subset/meteora_dlmm_subset_idl.json -subset -pkg meteoradlmm -out meteoradlmm/idl_gen.go
@@ -0,0 +1,3271 @@
+// Code generated by idlgen from jupiter_idl.json. DO NOT EDIT.
+
+// Package jupiter holds typed bindings for the jupiter program, generated from its IDL.
+package jupiter
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// AddLiquidity is the AddLiquidity type of the jupiter IDL
+type AddLiquidity struct {
+	TokenAmountIn      uint64
+	MinLpAmountOut     uint64
+	TokenAmountPreSwap *uint64 `bin:"optional"`
+}
+
+// RemoveLiquidity is the RemoveLiquidity type of the jupiter IDL
+type RemoveLiquidity struct {
+	LpAmountIn   uint64
+	MinAmountOut uint64
+}
+
+// AmountWithSlippage is the AmountWithSlippage type of the jupiter IDL
+type AmountWithSlippage struct {
+	Amount      uint64
+	SlippageBps uint16
+}
+
+// RoutePlanStep is the RoutePlanStep type of the jupiter IDL
+type RoutePlanStep struct {
+	Swap        Swap
+	Percent     uint8
+	InputIndex  uint8
+	OutputIndex uint8
+}
+
+// Side is the Side enum of the jupiter IDL
+type Side uint8
+
+const (
+	SideBid Side = iota
+	SideAsk
+)
+
+// Swap is the Swap enum of the jupiter IDL
+type Swap struct {
+	Enum                             bin.BorshEnum `borsh_enum:"true"`
+	Saber                            bin.EmptyVariant
+	SaberAddDecimalsDeposit          bin.EmptyVariant
+	SaberAddDecimalsWithdraw         bin.EmptyVariant
+	TokenSwap                        bin.EmptyVariant
+	Sencha                           bin.EmptyVariant
+	Step                             bin.EmptyVariant
+	Cropper                          bin.EmptyVariant
+	Raydium                          bin.EmptyVariant
+	Crema                            SwapCremaVariant
+	Lifinity                         bin.EmptyVariant
+	Mercurial                        bin.EmptyVariant
+	Cykura                           bin.EmptyVariant
+	Serum                            SwapSerumVariant
+	MarinadeDeposit                  bin.EmptyVariant
+	MarinadeUnstake                  bin.EmptyVariant
+	Aldrin                           SwapAldrinVariant
+	AldrinV2                         SwapAldrinV2Variant
+	Whirlpool                        SwapWhirlpoolVariant
+	Invariant                        SwapInvariantVariant
+	Meteora                          bin.EmptyVariant
+	GooseFX                          bin.EmptyVariant
+	DeltaFi                          SwapDeltaFiVariant
+	Balansol                         bin.EmptyVariant
+	MarcoPolo                        SwapMarcoPoloVariant
+	Dradex                           SwapDradexVariant
+	LifinityV2                       bin.EmptyVariant
+	RaydiumClmm                      bin.EmptyVariant
+	Openbook                         SwapOpenbookVariant
+	Phoenix                          SwapPhoenixVariant
+	Symmetry                         SwapSymmetryVariant
+	TokenSwapV2                      bin.EmptyVariant
+	HeliumTreasuryManagementRedeemV0 bin.EmptyVariant
+	StakeDexStakeWrappedSol          bin.EmptyVariant
+	StakeDexSwapViaStake             SwapStakeDexSwapViaStakeVariant
+	GooseFXV2                        bin.EmptyVariant
+	Perps                            bin.EmptyVariant
+	PerpsAddLiquidity                bin.EmptyVariant
+	PerpsRemoveLiquidity             bin.EmptyVariant
+	MeteoraDlmm                      bin.EmptyVariant
+}
+
+const (
+	SwapSaber bin.BorshEnum = iota
+	SwapSaberAddDecimalsDeposit
+	SwapSaberAddDecimalsWithdraw
+	SwapTokenSwap
+	SwapSencha
+	SwapStep
+	SwapCropper
+	SwapRaydium
+	SwapCrema
+	SwapLifinity
+	SwapMercurial
+	SwapCykura
+	SwapSerum
+	SwapMarinadeDeposit
+	SwapMarinadeUnstake
+	SwapAldrin
+	SwapAldrinV2
+	SwapWhirlpool
+	SwapInvariant
+	SwapMeteora
+	SwapGooseFX
+	SwapDeltaFi
+	SwapBalansol
+	SwapMarcoPolo
+	SwapDradex
+	SwapLifinityV2
+	SwapRaydiumClmm
+	SwapOpenbook
+	SwapPhoenix
+	SwapSymmetry
+	SwapTokenSwapV2
+	SwapHeliumTreasuryManagementRedeemV0
+	SwapStakeDexStakeWrappedSol
+	SwapStakeDexSwapViaStake
+	SwapGooseFXV2
+	SwapPerps
+	SwapPerpsAddLiquidity
+	SwapPerpsRemoveLiquidity
+	SwapMeteoraDlmm
+)
+
+// SwapCremaVariant holds the fields of the Crema variant of Swap
+type SwapCremaVariant struct {
+	AToB bool
+}
+
+// SwapSerumVariant holds the fields of the Serum variant of Swap
+type SwapSerumVariant struct {
+	Side Side
+}
+
+// SwapAldrinVariant holds the fields of the Aldrin variant of Swap
+type SwapAldrinVariant struct {
+	Side Side
+}
+
+// SwapAldrinV2Variant holds the fields of the AldrinV2 variant of Swap
+type SwapAldrinV2Variant struct {
+	Side Side
+}
+
+// SwapWhirlpoolVariant holds the fields of the Whirlpool variant of Swap
+type SwapWhirlpoolVariant struct {
+	AToB bool
+}
+
+// SwapInvariantVariant holds the fields of the Invariant variant of Swap
+type SwapInvariantVariant struct {
+	XToY bool
+}
+
+// SwapDeltaFiVariant holds the fields of the DeltaFi variant of Swap
+type SwapDeltaFiVariant struct {
+	Stable bool
+}
+
+// SwapMarcoPoloVariant holds the fields of the MarcoPolo variant of Swap
+type SwapMarcoPoloVariant struct {
+	XToY bool
+}
+
+// SwapDradexVariant holds the fields of the Dradex variant of Swap
+type SwapDradexVariant struct {
+	Side Side
+}
+
+// SwapOpenbookVariant holds the fields of the Openbook variant of Swap
+type SwapOpenbookVariant struct {
+	Side Side
+}
+
+// SwapPhoenixVariant holds the fields of the Phoenix variant of Swap
+type SwapPhoenixVariant struct {
+	Side Side
+}
+
+// SwapSymmetryVariant holds the fields of the Symmetry variant of Swap
+type SwapSymmetryVariant struct {
+	FromTokenId uint64
+	ToTokenId   uint64
+}
+
+// SwapStakeDexSwapViaStakeVariant holds the fields of the StakeDexSwapViaStake variant of Swap
+type SwapStakeDexSwapViaStakeVariant struct {
+	BridgeStakeSeed uint32
+}
+
+// TokenLedger is the TokenLedger account of the jupiter IDL, without its discriminator
+type TokenLedger struct {
+	TokenAccount solana.PublicKey
+	Amount       uint64
+}
+
+// TokenLedgerDiscriminator prefixes every TokenLedger account
+var TokenLedgerDiscriminator = []byte{156, 247, 9, 188, 54, 108, 85, 77}
+
+// DecodeTokenLedger decodes the raw data of a TokenLedger account
+func DecodeTokenLedger(data []byte) (*TokenLedger, error) {
+	var account TokenLedger
+	if err := decode(data, TokenLedgerDiscriminator, "TokenLedger", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// SwapEvent is the SwapEvent event of the jupiter IDL
+type SwapEvent struct {
+	Amm          solana.PublicKey
+	InputMint    solana.PublicKey
+	InputAmount  uint64
+	OutputMint   solana.PublicKey
+	OutputAmount uint64
+}
+
+// SwapEventDiscriminator prefixes every SwapEvent event
+var SwapEventDiscriminator = []byte{64, 198, 205, 232, 38, 8, 113, 226}
+
+// DecodeSwapEvent decodes a SwapEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeSwapEvent(data []byte) (*SwapEvent, error) {
+	var event SwapEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), SwapEventDiscriminator, "SwapEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// FeeEvent is the FeeEvent event of the jupiter IDL
+type FeeEvent struct {
+	Account solana.PublicKey
+	Mint    solana.PublicKey
+	Amount  uint64
+}
+
+// FeeEventDiscriminator prefixes every FeeEvent event
+var FeeEventDiscriminator = []byte{73, 79, 78, 127, 184, 213, 13, 220}
+
+// DecodeFeeEvent decodes a FeeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeFeeEvent(data []byte) (*FeeEvent, error) {
+	var event FeeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), FeeEventDiscriminator, "FeeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// RouteInstructionDiscriminator prefixes the data of every route instruction
+var RouteInstructionDiscriminator = []byte{229, 23, 203, 151, 122, 227, 173, 42}
+
+// RouteArgs holds the arguments of the route instruction
+// route_plan Topologically sorted trade DAG
+type RouteArgs struct {
+	RoutePlan       []RoutePlanStep
+	InAmount        uint64
+	QuotedOutAmount uint64
+	SlippageBps     uint16
+	PlatformFeeBps  uint8
+}
+
+// RouteAccounts lists the accounts of the route instruction in IDL order
+type RouteAccounts struct {
+	TokenProgram                solana.PublicKey
+	UserTransferAuthority       solana.PublicKey // signer
+	UserSourceTokenAccount      solana.PublicKey
+	UserDestinationTokenAccount solana.PublicKey
+	DestinationTokenAccount     solana.PublicKey // optional
+	DestinationMint             solana.PublicKey
+	PlatformFeeAccount          solana.PublicKey // writable, optional
+	EventAuthority              solana.PublicKey
+	Program                     solana.PublicKey
+}
+
+// NewRouteInstruction builds the route instruction. Remaining accounts are appended after the IDL ones,
+// and optional accounts left empty are replaced by the program ID as Anchor expects
+func NewRouteInstruction(programID solana.PublicKey, args RouteArgs, accounts RouteAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(RouteInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode route args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, true),
+		solana.NewAccountMeta(accounts.UserSourceTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.UserDestinationTokenAccount, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.DestinationTokenAccount, programID), false, false),
+		solana.NewAccountMeta(accounts.DestinationMint, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.PlatformFeeAccount, programID), true, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeRouteArgs decodes the data of the route instruction
+func DecodeRouteArgs(data []byte) (*RouteArgs, error) {
+	var args RouteArgs
+	if err := decode(data, RouteInstructionDiscriminator, "route", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeRouteAccounts maps the account keys of the route instruction by IDL order, ignoring remaining accounts
+func DecodeRouteAccounts(keys []solana.PublicKey) (*RouteAccounts, error) {
+	if len(keys) < 9 {
+		return nil, fmt.Errorf("route needs 9 accounts, got %d", len(keys))
+	}
+	return &RouteAccounts{
+		TokenProgram:                keys[0],
+		UserTransferAuthority:       keys[1],
+		UserSourceTokenAccount:      keys[2],
+		UserDestinationTokenAccount: keys[3],
+		DestinationTokenAccount:     keys[4],
+		DestinationMint:             keys[5],
+		PlatformFeeAccount:          keys[6],
+		EventAuthority:              keys[7],
+		Program:                     keys[8],
+	}, nil
+}
+
+// RouteWithTokenLedgerInstructionDiscriminator prefixes the data of every routeWithTokenLedger instruction
+var RouteWithTokenLedgerInstructionDiscriminator = []byte{150, 86, 71, 116, 167, 93, 14, 104}
+
+// RouteWithTokenLedgerArgs holds the arguments of the routeWithTokenLedger instruction
+type RouteWithTokenLedgerArgs struct {
+	RoutePlan       []RoutePlanStep
+	QuotedOutAmount uint64
+	SlippageBps     uint16
+	PlatformFeeBps  uint8
+}
+
+// RouteWithTokenLedgerAccounts lists the accounts of the routeWithTokenLedger instruction in IDL order
+type RouteWithTokenLedgerAccounts struct {
+	TokenProgram                solana.PublicKey
+	UserTransferAuthority       solana.PublicKey // signer
+	UserSourceTokenAccount      solana.PublicKey
+	UserDestinationTokenAccount solana.PublicKey
+	DestinationTokenAccount     solana.PublicKey // optional
+	DestinationMint             solana.PublicKey
+	PlatformFeeAccount          solana.PublicKey // writable, optional
+	TokenLedger                 solana.PublicKey
+	EventAuthority              solana.PublicKey
+	Program                     solana.PublicKey
+}
+
+// NewRouteWithTokenLedgerInstruction builds the routeWithTokenLedger instruction. Remaining accounts are appended after the IDL ones,
+// and optional accounts left empty are replaced by the program ID as Anchor expects
+func NewRouteWithTokenLedgerInstruction(programID solana.PublicKey, args RouteWithTokenLedgerArgs, accounts RouteWithTokenLedgerAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(RouteWithTokenLedgerInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode routeWithTokenLedger args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, true),
+		solana.NewAccountMeta(accounts.UserSourceTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.UserDestinationTokenAccount, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.DestinationTokenAccount, programID), false, false),
+		solana.NewAccountMeta(accounts.DestinationMint, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.PlatformFeeAccount, programID), true, false),
+		solana.NewAccountMeta(accounts.TokenLedger, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeRouteWithTokenLedgerArgs decodes the data of the routeWithTokenLedger instruction
+func DecodeRouteWithTokenLedgerArgs(data []byte) (*RouteWithTokenLedgerArgs, error) {
+	var args RouteWithTokenLedgerArgs
+	if err := decode(data, RouteWithTokenLedgerInstructionDiscriminator, "routeWithTokenLedger", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeRouteWithTokenLedgerAccounts maps the account keys of the routeWithTokenLedger instruction by IDL order, ignoring remaining accounts
+func DecodeRouteWithTokenLedgerAccounts(keys []solana.PublicKey) (*RouteWithTokenLedgerAccounts, error) {
+	if len(keys) < 10 {
+		return nil, fmt.Errorf("routeWithTokenLedger needs 10 accounts, got %d", len(keys))
+	}
+	return &RouteWithTokenLedgerAccounts{
+		TokenProgram:                keys[0],
+		UserTransferAuthority:       keys[1],
+		UserSourceTokenAccount:      keys[2],
+		UserDestinationTokenAccount: keys[3],
+		DestinationTokenAccount:     keys[4],
+		DestinationMint:             keys[5],
+		PlatformFeeAccount:          keys[6],
+		TokenLedger:                 keys[7],
+		EventAuthority:              keys[8],
+		Program:                     keys[9],
+	}, nil
+}
+
+// SharedAccountsRouteInstructionDiscriminator prefixes the data of every sharedAccountsRoute instruction
+var SharedAccountsRouteInstructionDiscriminator = []byte{193, 32, 155, 51, 65, 214, 156, 129}
+
+// SharedAccountsRouteArgs holds the arguments of the sharedAccountsRoute instruction
+// Route by using program owned token accounts and open orders accounts.
+type SharedAccountsRouteArgs struct {
+	Id              uint8
+	RoutePlan       []RoutePlanStep
+	InAmount        uint64
+	QuotedOutAmount uint64
+	SlippageBps     uint16
+	PlatformFeeBps  uint8
+}
+
+// SharedAccountsRouteAccounts lists the accounts of the sharedAccountsRoute instruction in IDL order
+type SharedAccountsRouteAccounts struct {
+	TokenProgram                   solana.PublicKey
+	ProgramAuthority               solana.PublicKey
+	UserTransferAuthority          solana.PublicKey // signer
+	SourceTokenAccount             solana.PublicKey // writable
+	ProgramSourceTokenAccount      solana.PublicKey // writable
+	ProgramDestinationTokenAccount solana.PublicKey // writable
+	DestinationTokenAccount        solana.PublicKey // writable
+	SourceMint                     solana.PublicKey
+	DestinationMint                solana.PublicKey
+	PlatformFeeAccount             solana.PublicKey // writable, optional
+	Token2022Program               solana.PublicKey // optional
+	EventAuthority                 solana.PublicKey
+	Program                        solana.PublicKey
+}
+
+// NewSharedAccountsRouteInstruction builds the sharedAccountsRoute instruction. Remaining accounts are appended after the IDL ones,
+// and optional accounts left empty are replaced by the program ID as Anchor expects
+func NewSharedAccountsRouteInstruction(programID solana.PublicKey, args SharedAccountsRouteArgs, accounts SharedAccountsRouteAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SharedAccountsRouteInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sharedAccountsRoute args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.ProgramAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, true),
+		solana.NewAccountMeta(accounts.SourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ProgramSourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ProgramDestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SourceMint, false, false),
+		solana.NewAccountMeta(accounts.DestinationMint, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.PlatformFeeAccount, programID), true, false),
+		solana.NewAccountMeta(optionalAccount(accounts.Token2022Program, programID), false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSharedAccountsRouteArgs decodes the data of the sharedAccountsRoute instruction
+func DecodeSharedAccountsRouteArgs(data []byte) (*SharedAccountsRouteArgs, error) {
+	var args SharedAccountsRouteArgs
+	if err := decode(data, SharedAccountsRouteInstructionDiscriminator, "sharedAccountsRoute", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSharedAccountsRouteAccounts maps the account keys of the sharedAccountsRoute instruction by IDL order, ignoring remaining accounts
+func DecodeSharedAccountsRouteAccounts(keys []solana.PublicKey) (*SharedAccountsRouteAccounts, error) {
+	if len(keys) < 13 {
+		return nil, fmt.Errorf("sharedAccountsRoute needs 13 accounts, got %d", len(keys))
+	}
+	return &SharedAccountsRouteAccounts{
+		TokenProgram:                   keys[0],
+		ProgramAuthority:               keys[1],
+		UserTransferAuthority:          keys[2],
+		SourceTokenAccount:             keys[3],
+		ProgramSourceTokenAccount:      keys[4],
+		ProgramDestinationTokenAccount: keys[5],
+		DestinationTokenAccount:        keys[6],
+		SourceMint:                     keys[7],
+		DestinationMint:                keys[8],
+		PlatformFeeAccount:             keys[9],
+		Token2022Program:               keys[10],
+		EventAuthority:                 keys[11],
+		Program:                        keys[12],
+	}, nil
+}
+
+// SharedAccountsRouteWithTokenLedgerInstructionDiscriminator prefixes the data of every sharedAccountsRouteWithTokenLedger instruction
+var SharedAccountsRouteWithTokenLedgerInstructionDiscriminator = []byte{230, 121, 143, 80, 119, 159, 106, 170}
+
+// SharedAccountsRouteWithTokenLedgerArgs holds the arguments of the sharedAccountsRouteWithTokenLedger instruction
+type SharedAccountsRouteWithTokenLedgerArgs struct {
+	Id              uint8
+	RoutePlan       []RoutePlanStep
+	QuotedOutAmount uint64
+	SlippageBps     uint16
+	PlatformFeeBps  uint8
+}
+
+// SharedAccountsRouteWithTokenLedgerAccounts lists the accounts of the sharedAccountsRouteWithTokenLedger instruction in IDL order
+type SharedAccountsRouteWithTokenLedgerAccounts struct {
+	TokenProgram                   solana.PublicKey
+	ProgramAuthority               solana.PublicKey
+	UserTransferAuthority          solana.PublicKey // signer
+	SourceTokenAccount             solana.PublicKey // writable
+	ProgramSourceTokenAccount      solana.PublicKey // writable
+	ProgramDestinationTokenAccount solana.PublicKey // writable
+	DestinationTokenAccount        solana.PublicKey // writable
+	SourceMint                     solana.PublicKey
+	DestinationMint                solana.PublicKey
+	PlatformFeeAccount             solana.PublicKey // writable, optional
+	Token2022Program               solana.PublicKey // optional
+	TokenLedger                    solana.PublicKey
+	EventAuthority                 solana.PublicKey
+	Program                        solana.PublicKey
+}
+
+// NewSharedAccountsRouteWithTokenLedgerInstruction builds the sharedAccountsRouteWithTokenLedger instruction. Remaining accounts are appended after the IDL ones,
+// and optional accounts left empty are replaced by the program ID as Anchor expects
+func NewSharedAccountsRouteWithTokenLedgerInstruction(programID solana.PublicKey, args SharedAccountsRouteWithTokenLedgerArgs, accounts SharedAccountsRouteWithTokenLedgerAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SharedAccountsRouteWithTokenLedgerInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sharedAccountsRouteWithTokenLedger args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.ProgramAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, true),
+		solana.NewAccountMeta(accounts.SourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ProgramSourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ProgramDestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SourceMint, false, false),
+		solana.NewAccountMeta(accounts.DestinationMint, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.PlatformFeeAccount, programID), true, false),
+		solana.NewAccountMeta(optionalAccount(accounts.Token2022Program, programID), false, false),
+		solana.NewAccountMeta(accounts.TokenLedger, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSharedAccountsRouteWithTokenLedgerArgs decodes the data of the sharedAccountsRouteWithTokenLedger instruction
+func DecodeSharedAccountsRouteWithTokenLedgerArgs(data []byte) (*SharedAccountsRouteWithTokenLedgerArgs, error) {
+	var args SharedAccountsRouteWithTokenLedgerArgs
+	if err := decode(data, SharedAccountsRouteWithTokenLedgerInstructionDiscriminator, "sharedAccountsRouteWithTokenLedger", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSharedAccountsRouteWithTokenLedgerAccounts maps the account keys of the sharedAccountsRouteWithTokenLedger instruction by IDL order, ignoring remaining accounts
+func DecodeSharedAccountsRouteWithTokenLedgerAccounts(keys []solana.PublicKey) (*SharedAccountsRouteWithTokenLedgerAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("sharedAccountsRouteWithTokenLedger needs 14 accounts, got %d", len(keys))
+	}
+	return &SharedAccountsRouteWithTokenLedgerAccounts{
+		TokenProgram:                   keys[0],
+		ProgramAuthority:               keys[1],
+		UserTransferAuthority:          keys[2],
+		SourceTokenAccount:             keys[3],
+		ProgramSourceTokenAccount:      keys[4],
+		ProgramDestinationTokenAccount: keys[5],
+		DestinationTokenAccount:        keys[6],
+		SourceMint:                     keys[7],
+		DestinationMint:                keys[8],
+		PlatformFeeAccount:             keys[9],
+		Token2022Program:               keys[10],
+		TokenLedger:                    keys[11],
+		EventAuthority:                 keys[12],
+		Program:                        keys[13],
+	}, nil
+}
+
+// SharedAccountsExactOutRouteInstructionDiscriminator prefixes the data of every sharedAccountsExactOutRoute instruction
+var SharedAccountsExactOutRouteInstructionDiscriminator = []byte{176, 209, 105, 168, 154, 125, 69, 62}
+
+// SharedAccountsExactOutRouteArgs holds the arguments of the sharedAccountsExactOutRoute instruction
+// Route by using program owned token accounts and open orders accounts.
+type SharedAccountsExactOutRouteArgs struct {
+	Id             uint8
+	RoutePlan      []RoutePlanStep
+	OutAmount      uint64
+	QuotedInAmount uint64
+	SlippageBps    uint16
+	PlatformFeeBps uint8
+}
+
+// SharedAccountsExactOutRouteAccounts lists the accounts of the sharedAccountsExactOutRoute instruction in IDL order
+type SharedAccountsExactOutRouteAccounts struct {
+	TokenProgram                   solana.PublicKey
+	ProgramAuthority               solana.PublicKey
+	UserTransferAuthority          solana.PublicKey // signer
+	SourceTokenAccount             solana.PublicKey // writable
+	ProgramSourceTokenAccount      solana.PublicKey // writable
+	ProgramDestinationTokenAccount solana.PublicKey // writable
+	DestinationTokenAccount        solana.PublicKey // writable
+	SourceMint                     solana.PublicKey
+	DestinationMint                solana.PublicKey
+	PlatformFeeAccount             solana.PublicKey // writable, optional
+	Token2022Program               solana.PublicKey // optional
+	EventAuthority                 solana.PublicKey
+	Program                        solana.PublicKey
+}
+
+// NewSharedAccountsExactOutRouteInstruction builds the sharedAccountsExactOutRoute instruction. Remaining accounts are appended after the IDL ones,
+// and optional accounts left empty are replaced by the program ID as Anchor expects
+func NewSharedAccountsExactOutRouteInstruction(programID solana.PublicKey, args SharedAccountsExactOutRouteArgs, accounts SharedAccountsExactOutRouteAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SharedAccountsExactOutRouteInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sharedAccountsExactOutRoute args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.ProgramAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, true),
+		solana.NewAccountMeta(accounts.SourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ProgramSourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ProgramDestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SourceMint, false, false),
+		solana.NewAccountMeta(accounts.DestinationMint, false, false),
+		solana.NewAccountMeta(optionalAccount(accounts.PlatformFeeAccount, programID), true, false),
+		solana.NewAccountMeta(optionalAccount(accounts.Token2022Program, programID), false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSharedAccountsExactOutRouteArgs decodes the data of the sharedAccountsExactOutRoute instruction
+func DecodeSharedAccountsExactOutRouteArgs(data []byte) (*SharedAccountsExactOutRouteArgs, error) {
+	var args SharedAccountsExactOutRouteArgs
+	if err := decode(data, SharedAccountsExactOutRouteInstructionDiscriminator, "sharedAccountsExactOutRoute", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSharedAccountsExactOutRouteAccounts maps the account keys of the sharedAccountsExactOutRoute instruction by IDL order, ignoring remaining accounts
+func DecodeSharedAccountsExactOutRouteAccounts(keys []solana.PublicKey) (*SharedAccountsExactOutRouteAccounts, error) {
+	if len(keys) < 13 {
+		return nil, fmt.Errorf("sharedAccountsExactOutRoute needs 13 accounts, got %d", len(keys))
+	}
+	return &SharedAccountsExactOutRouteAccounts{
+		TokenProgram:                   keys[0],
+		ProgramAuthority:               keys[1],
+		UserTransferAuthority:          keys[2],
+		SourceTokenAccount:             keys[3],
+		ProgramSourceTokenAccount:      keys[4],
+		ProgramDestinationTokenAccount: keys[5],
+		DestinationTokenAccount:        keys[6],
+		SourceMint:                     keys[7],
+		DestinationMint:                keys[8],
+		PlatformFeeAccount:             keys[9],
+		Token2022Program:               keys[10],
+		EventAuthority:                 keys[11],
+		Program:                        keys[12],
+	}, nil
+}
+
+// SetTokenLedgerInstructionDiscriminator prefixes the data of every setTokenLedger instruction
+var SetTokenLedgerInstructionDiscriminator = []byte{228, 85, 185, 112, 78, 79, 77, 2}
+
+// SetTokenLedgerAccounts lists the accounts of the setTokenLedger instruction in IDL order
+type SetTokenLedgerAccounts struct {
+	TokenLedger  solana.PublicKey // writable
+	TokenAccount solana.PublicKey
+}
+
+// NewSetTokenLedgerInstruction builds the setTokenLedger instruction. Remaining accounts are appended after the IDL ones
+func NewSetTokenLedgerInstruction(programID solana.PublicKey, accounts SetTokenLedgerAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SetTokenLedgerInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setTokenLedger args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenLedger, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSetTokenLedgerAccounts maps the account keys of the setTokenLedger instruction by IDL order, ignoring remaining accounts
+func DecodeSetTokenLedgerAccounts(keys []solana.PublicKey) (*SetTokenLedgerAccounts, error) {
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("setTokenLedger needs 2 accounts, got %d", len(keys))
+	}
+	return &SetTokenLedgerAccounts{
+		TokenLedger:  keys[0],
+		TokenAccount: keys[1],
+	}, nil
+}
+
+// CreateOpenOrdersInstructionDiscriminator prefixes the data of every createOpenOrders instruction
+var CreateOpenOrdersInstructionDiscriminator = []byte{229, 194, 212, 172, 8, 10, 134, 147}
+
+// CreateOpenOrdersAccounts lists the accounts of the createOpenOrders instruction in IDL order
+type CreateOpenOrdersAccounts struct {
+	OpenOrders    solana.PublicKey // writable
+	Payer         solana.PublicKey // writable, signer
+	DexProgram    solana.PublicKey
+	SystemProgram solana.PublicKey
+	Rent          solana.PublicKey
+	Market        solana.PublicKey
+}
+
+// NewCreateOpenOrdersInstruction builds the createOpenOrders instruction. Remaining accounts are appended after the IDL ones
+func NewCreateOpenOrdersInstruction(programID solana.PublicKey, accounts CreateOpenOrdersAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateOpenOrdersInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createOpenOrders args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.OpenOrders, true, false),
+		solana.NewAccountMeta(accounts.Payer, true, true),
+		solana.NewAccountMeta(accounts.DexProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.Market, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateOpenOrdersAccounts maps the account keys of the createOpenOrders instruction by IDL order, ignoring remaining accounts
+func DecodeCreateOpenOrdersAccounts(keys []solana.PublicKey) (*CreateOpenOrdersAccounts, error) {
+	if len(keys) < 6 {
+		return nil, fmt.Errorf("createOpenOrders needs 6 accounts, got %d", len(keys))
+	}
+	return &CreateOpenOrdersAccounts{
+		OpenOrders:    keys[0],
+		Payer:         keys[1],
+		DexProgram:    keys[2],
+		SystemProgram: keys[3],
+		Rent:          keys[4],
+		Market:        keys[5],
+	}, nil
+}
+
+// CreateProgramOpenOrdersInstructionDiscriminator prefixes the data of every createProgramOpenOrders instruction
+var CreateProgramOpenOrdersInstructionDiscriminator = []byte{28, 226, 32, 148, 188, 136, 113, 171}
+
+// CreateProgramOpenOrdersArgs holds the arguments of the createProgramOpenOrders instruction
+type CreateProgramOpenOrdersArgs struct {
+	Id uint8
+}
+
+// CreateProgramOpenOrdersAccounts lists the accounts of the createProgramOpenOrders instruction in IDL order
+type CreateProgramOpenOrdersAccounts struct {
+	OpenOrders       solana.PublicKey // writable
+	Payer            solana.PublicKey // writable, signer
+	ProgramAuthority solana.PublicKey
+	DexProgram       solana.PublicKey
+	SystemProgram    solana.PublicKey
+	Rent             solana.PublicKey
+	Market           solana.PublicKey
+}
+
+// NewCreateProgramOpenOrdersInstruction builds the createProgramOpenOrders instruction. Remaining accounts are appended after the IDL ones
+func NewCreateProgramOpenOrdersInstruction(programID solana.PublicKey, args CreateProgramOpenOrdersArgs, accounts CreateProgramOpenOrdersAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateProgramOpenOrdersInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createProgramOpenOrders args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.OpenOrders, true, false),
+		solana.NewAccountMeta(accounts.Payer, true, true),
+		solana.NewAccountMeta(accounts.ProgramAuthority, false, false),
+		solana.NewAccountMeta(accounts.DexProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.Market, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateProgramOpenOrdersArgs decodes the data of the createProgramOpenOrders instruction
+func DecodeCreateProgramOpenOrdersArgs(data []byte) (*CreateProgramOpenOrdersArgs, error) {
+	var args CreateProgramOpenOrdersArgs
+	if err := decode(data, CreateProgramOpenOrdersInstructionDiscriminator, "createProgramOpenOrders", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCreateProgramOpenOrdersAccounts maps the account keys of the createProgramOpenOrders instruction by IDL order, ignoring remaining accounts
+func DecodeCreateProgramOpenOrdersAccounts(keys []solana.PublicKey) (*CreateProgramOpenOrdersAccounts, error) {
+	if len(keys) < 7 {
+		return nil, fmt.Errorf("createProgramOpenOrders needs 7 accounts, got %d", len(keys))
+	}
+	return &CreateProgramOpenOrdersAccounts{
+		OpenOrders:       keys[0],
+		Payer:            keys[1],
+		ProgramAuthority: keys[2],
+		DexProgram:       keys[3],
+		SystemProgram:    keys[4],
+		Rent:             keys[5],
+		Market:           keys[6],
+	}, nil
+}
+
+// CreateTokenLedgerInstructionDiscriminator prefixes the data of every createTokenLedger instruction
+var CreateTokenLedgerInstructionDiscriminator = []byte{232, 242, 197, 253, 240, 143, 129, 52}
+
+// CreateTokenLedgerAccounts lists the accounts of the createTokenLedger instruction in IDL order
+type CreateTokenLedgerAccounts struct {
+	TokenLedger   solana.PublicKey // writable, signer
+	Payer         solana.PublicKey // writable, signer
+	SystemProgram solana.PublicKey
+}
+
+// NewCreateTokenLedgerInstruction builds the createTokenLedger instruction. Remaining accounts are appended after the IDL ones
+func NewCreateTokenLedgerInstruction(programID solana.PublicKey, accounts CreateTokenLedgerAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateTokenLedgerInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createTokenLedger args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenLedger, true, true),
+		solana.NewAccountMeta(accounts.Payer, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateTokenLedgerAccounts maps the account keys of the createTokenLedger instruction by IDL order, ignoring remaining accounts
+func DecodeCreateTokenLedgerAccounts(keys []solana.PublicKey) (*CreateTokenLedgerAccounts, error) {
+	if len(keys) < 3 {
+		return nil, fmt.Errorf("createTokenLedger needs 3 accounts, got %d", len(keys))
+	}
+	return &CreateTokenLedgerAccounts{
+		TokenLedger:   keys[0],
+		Payer:         keys[1],
+		SystemProgram: keys[2],
+	}, nil
+}
+
+// MercurialSwapInstructionDiscriminator prefixes the data of every mercurialSwap instruction
+var MercurialSwapInstructionDiscriminator = []byte{2, 5, 77, 173, 197, 0, 7, 157}
+
+// MercurialSwapAccounts lists the accounts of the mercurialSwap instruction in IDL order
+type MercurialSwapAccounts struct {
+	SwapProgram             solana.PublicKey
+	SwapState               solana.PublicKey
+	TokenProgram            solana.PublicKey
+	PoolAuthority           solana.PublicKey
+	UserTransferAuthority   solana.PublicKey
+	SourceTokenAccount      solana.PublicKey // writable
+	DestinationTokenAccount solana.PublicKey // writable
+}
+
+// NewMercurialSwapInstruction builds the mercurialSwap instruction. Remaining accounts are appended after the IDL ones
+func NewMercurialSwapInstruction(programID solana.PublicKey, accounts MercurialSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MercurialSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode mercurialSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.SwapState, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.PoolAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.SourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DestinationTokenAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMercurialSwapAccounts maps the account keys of the mercurialSwap instruction by IDL order, ignoring remaining accounts
+func DecodeMercurialSwapAccounts(keys []solana.PublicKey) (*MercurialSwapAccounts, error) {
+	if len(keys) < 7 {
+		return nil, fmt.Errorf("mercurialSwap needs 7 accounts, got %d", len(keys))
+	}
+	return &MercurialSwapAccounts{
+		SwapProgram:             keys[0],
+		SwapState:               keys[1],
+		TokenProgram:            keys[2],
+		PoolAuthority:           keys[3],
+		UserTransferAuthority:   keys[4],
+		SourceTokenAccount:      keys[5],
+		DestinationTokenAccount: keys[6],
+	}, nil
+}
+
+// CykuraSwapInstructionDiscriminator prefixes the data of every cykuraSwap instruction
+var CykuraSwapInstructionDiscriminator = []byte{38, 241, 21, 107, 120, 59, 184, 249}
+
+// CykuraSwapAccounts lists the accounts of the cykuraSwap instruction in IDL order
+type CykuraSwapAccounts struct {
+	SwapProgram          solana.PublicKey
+	Signer               solana.PublicKey
+	FactoryState         solana.PublicKey
+	PoolState            solana.PublicKey // writable
+	InputTokenAccount    solana.PublicKey // writable
+	OutputTokenAccount   solana.PublicKey // writable
+	InputVault           solana.PublicKey // writable
+	OutputVault          solana.PublicKey // writable
+	LastObservationState solana.PublicKey // writable
+	CoreProgram          solana.PublicKey
+	TokenProgram         solana.PublicKey
+}
+
+// NewCykuraSwapInstruction builds the cykuraSwap instruction. Remaining accounts are appended after the IDL ones
+func NewCykuraSwapInstruction(programID solana.PublicKey, accounts CykuraSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CykuraSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cykuraSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Signer, false, false),
+		solana.NewAccountMeta(accounts.FactoryState, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.InputVault, true, false),
+		solana.NewAccountMeta(accounts.OutputVault, true, false),
+		solana.NewAccountMeta(accounts.LastObservationState, true, false),
+		solana.NewAccountMeta(accounts.CoreProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCykuraSwapAccounts maps the account keys of the cykuraSwap instruction by IDL order, ignoring remaining accounts
+func DecodeCykuraSwapAccounts(keys []solana.PublicKey) (*CykuraSwapAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("cykuraSwap needs 11 accounts, got %d", len(keys))
+	}
+	return &CykuraSwapAccounts{
+		SwapProgram:          keys[0],
+		Signer:               keys[1],
+		FactoryState:         keys[2],
+		PoolState:            keys[3],
+		InputTokenAccount:    keys[4],
+		OutputTokenAccount:   keys[5],
+		InputVault:           keys[6],
+		OutputVault:          keys[7],
+		LastObservationState: keys[8],
+		CoreProgram:          keys[9],
+		TokenProgram:         keys[10],
+	}, nil
+}
+
+// SerumSwapInstructionDiscriminator prefixes the data of every serumSwap instruction
+var SerumSwapInstructionDiscriminator = []byte{88, 183, 70, 249, 214, 118, 82, 210}
+
+// SerumSwapAccounts lists the accounts of the serumSwap instruction in IDL order
+type SerumSwapAccounts struct {
+	MarketMarket           solana.PublicKey // writable
+	MarketOpenOrders       solana.PublicKey // writable
+	MarketRequestQueue     solana.PublicKey // writable
+	MarketEventQueue       solana.PublicKey // writable
+	MarketBids             solana.PublicKey // writable
+	MarketAsks             solana.PublicKey // writable
+	MarketCoinVault        solana.PublicKey // writable
+	MarketPcVault          solana.PublicKey // writable
+	MarketVaultSigner      solana.PublicKey
+	Authority              solana.PublicKey
+	OrderPayerTokenAccount solana.PublicKey // writable
+	CoinWallet             solana.PublicKey // writable
+	PcWallet               solana.PublicKey // writable
+	DexProgram             solana.PublicKey
+	TokenProgram           solana.PublicKey
+	Rent                   solana.PublicKey
+}
+
+// NewSerumSwapInstruction builds the serumSwap instruction. Remaining accounts are appended after the IDL ones
+func NewSerumSwapInstruction(programID solana.PublicKey, accounts SerumSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SerumSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode serumSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.MarketMarket, true, false),
+		solana.NewAccountMeta(accounts.MarketOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.MarketRequestQueue, true, false),
+		solana.NewAccountMeta(accounts.MarketEventQueue, true, false),
+		solana.NewAccountMeta(accounts.MarketBids, true, false),
+		solana.NewAccountMeta(accounts.MarketAsks, true, false),
+		solana.NewAccountMeta(accounts.MarketCoinVault, true, false),
+		solana.NewAccountMeta(accounts.MarketPcVault, true, false),
+		solana.NewAccountMeta(accounts.MarketVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.OrderPayerTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.CoinWallet, true, false),
+		solana.NewAccountMeta(accounts.PcWallet, true, false),
+		solana.NewAccountMeta(accounts.DexProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSerumSwapAccounts maps the account keys of the serumSwap instruction by IDL order, ignoring remaining accounts
+func DecodeSerumSwapAccounts(keys []solana.PublicKey) (*SerumSwapAccounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("serumSwap needs 16 accounts, got %d", len(keys))
+	}
+	return &SerumSwapAccounts{
+		MarketMarket:           keys[0],
+		MarketOpenOrders:       keys[1],
+		MarketRequestQueue:     keys[2],
+		MarketEventQueue:       keys[3],
+		MarketBids:             keys[4],
+		MarketAsks:             keys[5],
+		MarketCoinVault:        keys[6],
+		MarketPcVault:          keys[7],
+		MarketVaultSigner:      keys[8],
+		Authority:              keys[9],
+		OrderPayerTokenAccount: keys[10],
+		CoinWallet:             keys[11],
+		PcWallet:               keys[12],
+		DexProgram:             keys[13],
+		TokenProgram:           keys[14],
+		Rent:                   keys[15],
+	}, nil
+}
+
+// SaberSwapInstructionDiscriminator prefixes the data of every saberSwap instruction
+var SaberSwapInstructionDiscriminator = []byte{64, 62, 98, 226, 52, 74, 37, 178}
+
+// SaberSwapAccounts lists the accounts of the saberSwap instruction in IDL order
+type SaberSwapAccounts struct {
+	SwapProgram        solana.PublicKey
+	TokenProgram       solana.PublicKey
+	Swap               solana.PublicKey
+	SwapAuthority      solana.PublicKey
+	UserAuthority      solana.PublicKey
+	InputUserAccount   solana.PublicKey // writable
+	InputTokenAccount  solana.PublicKey // writable
+	OutputUserAccount  solana.PublicKey // writable
+	OutputTokenAccount solana.PublicKey // writable
+	FeesTokenAccount   solana.PublicKey // writable
+}
+
+// NewSaberSwapInstruction builds the saberSwap instruction. Remaining accounts are appended after the IDL ones
+func NewSaberSwapInstruction(programID solana.PublicKey, accounts SaberSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SaberSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode saberSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Swap, false, false),
+		solana.NewAccountMeta(accounts.SwapAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserAuthority, false, false),
+		solana.NewAccountMeta(accounts.InputUserAccount, true, false),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputUserAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.FeesTokenAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSaberSwapAccounts maps the account keys of the saberSwap instruction by IDL order, ignoring remaining accounts
+func DecodeSaberSwapAccounts(keys []solana.PublicKey) (*SaberSwapAccounts, error) {
+	if len(keys) < 10 {
+		return nil, fmt.Errorf("saberSwap needs 10 accounts, got %d", len(keys))
+	}
+	return &SaberSwapAccounts{
+		SwapProgram:        keys[0],
+		TokenProgram:       keys[1],
+		Swap:               keys[2],
+		SwapAuthority:      keys[3],
+		UserAuthority:      keys[4],
+		InputUserAccount:   keys[5],
+		InputTokenAccount:  keys[6],
+		OutputUserAccount:  keys[7],
+		OutputTokenAccount: keys[8],
+		FeesTokenAccount:   keys[9],
+	}, nil
+}
+
+// SaberAddDecimalsInstructionDiscriminator prefixes the data of every saberAddDecimals instruction
+var SaberAddDecimalsInstructionDiscriminator = []byte{36, 53, 231, 184, 7, 181, 5, 238}
+
+// SaberAddDecimalsAccounts lists the accounts of the saberAddDecimals instruction in IDL order
+type SaberAddDecimalsAccounts struct {
+	AddDecimalsProgram      solana.PublicKey
+	Wrapper                 solana.PublicKey
+	WrapperMint             solana.PublicKey // writable
+	WrapperUnderlyingTokens solana.PublicKey // writable
+	Owner                   solana.PublicKey
+	UserUnderlyingTokens    solana.PublicKey // writable
+	UserWrappedTokens       solana.PublicKey // writable
+	TokenProgram            solana.PublicKey
+}
+
+// NewSaberAddDecimalsInstruction builds the saberAddDecimals instruction. Remaining accounts are appended after the IDL ones
+func NewSaberAddDecimalsInstruction(programID solana.PublicKey, accounts SaberAddDecimalsAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SaberAddDecimalsInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode saberAddDecimals args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.AddDecimalsProgram, false, false),
+		solana.NewAccountMeta(accounts.Wrapper, false, false),
+		solana.NewAccountMeta(accounts.WrapperMint, true, false),
+		solana.NewAccountMeta(accounts.WrapperUnderlyingTokens, true, false),
+		solana.NewAccountMeta(accounts.Owner, false, false),
+		solana.NewAccountMeta(accounts.UserUnderlyingTokens, true, false),
+		solana.NewAccountMeta(accounts.UserWrappedTokens, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSaberAddDecimalsAccounts maps the account keys of the saberAddDecimals instruction by IDL order, ignoring remaining accounts
+func DecodeSaberAddDecimalsAccounts(keys []solana.PublicKey) (*SaberAddDecimalsAccounts, error) {
+	if len(keys) < 8 {
+		return nil, fmt.Errorf("saberAddDecimals needs 8 accounts, got %d", len(keys))
+	}
+	return &SaberAddDecimalsAccounts{
+		AddDecimalsProgram:      keys[0],
+		Wrapper:                 keys[1],
+		WrapperMint:             keys[2],
+		WrapperUnderlyingTokens: keys[3],
+		Owner:                   keys[4],
+		UserUnderlyingTokens:    keys[5],
+		UserWrappedTokens:       keys[6],
+		TokenProgram:            keys[7],
+	}, nil
+}
+
+// TokenSwapInstructionDiscriminator prefixes the data of every tokenSwap instruction
+var TokenSwapInstructionDiscriminator = []byte{187, 192, 118, 212, 62, 109, 28, 213}
+
+// TokenSwapAccounts lists the accounts of the tokenSwap instruction in IDL order
+type TokenSwapAccounts struct {
+	TokenSwapProgram      solana.PublicKey
+	TokenProgram          solana.PublicKey
+	Swap                  solana.PublicKey
+	Authority             solana.PublicKey
+	UserTransferAuthority solana.PublicKey
+	Source                solana.PublicKey // writable
+	SwapSource            solana.PublicKey // writable
+	SwapDestination       solana.PublicKey // writable
+	Destination           solana.PublicKey // writable
+	PoolMint              solana.PublicKey // writable
+	PoolFee               solana.PublicKey // writable
+}
+
+// NewTokenSwapInstruction builds the tokenSwap instruction. Remaining accounts are appended after the IDL ones
+func NewTokenSwapInstruction(programID solana.PublicKey, accounts TokenSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(TokenSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tokenSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenSwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Swap, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Source, true, false),
+		solana.NewAccountMeta(accounts.SwapSource, true, false),
+		solana.NewAccountMeta(accounts.SwapDestination, true, false),
+		solana.NewAccountMeta(accounts.Destination, true, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.PoolFee, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeTokenSwapAccounts maps the account keys of the tokenSwap instruction by IDL order, ignoring remaining accounts
+func DecodeTokenSwapAccounts(keys []solana.PublicKey) (*TokenSwapAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("tokenSwap needs 11 accounts, got %d", len(keys))
+	}
+	return &TokenSwapAccounts{
+		TokenSwapProgram:      keys[0],
+		TokenProgram:          keys[1],
+		Swap:                  keys[2],
+		Authority:             keys[3],
+		UserTransferAuthority: keys[4],
+		Source:                keys[5],
+		SwapSource:            keys[6],
+		SwapDestination:       keys[7],
+		Destination:           keys[8],
+		PoolMint:              keys[9],
+		PoolFee:               keys[10],
+	}, nil
+}
+
+// TokenSwapV2InstructionDiscriminator prefixes the data of every tokenSwapV2 instruction
+var TokenSwapV2InstructionDiscriminator = []byte{51, 48, 145, 115, 123, 95, 71, 138}
+
+// TokenSwapV2Accounts lists the accounts of the tokenSwapV2 instruction in IDL order
+type TokenSwapV2Accounts struct {
+	SwapProgram             solana.PublicKey
+	Swap                    solana.PublicKey
+	Authority               solana.PublicKey
+	UserTransferAuthority   solana.PublicKey
+	Source                  solana.PublicKey // writable
+	SwapSource              solana.PublicKey // writable
+	SwapDestination         solana.PublicKey // writable
+	Destination             solana.PublicKey // writable
+	PoolMint                solana.PublicKey // writable
+	PoolFee                 solana.PublicKey // writable
+	SourceMint              solana.PublicKey
+	DestinationMint         solana.PublicKey
+	SourceTokenProgram      solana.PublicKey
+	DestinationTokenProgram solana.PublicKey
+	PoolTokenProgram        solana.PublicKey
+}
+
+// NewTokenSwapV2Instruction builds the tokenSwapV2 instruction. Remaining accounts are appended after the IDL ones
+func NewTokenSwapV2Instruction(programID solana.PublicKey, accounts TokenSwapV2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(TokenSwapV2InstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tokenSwapV2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Swap, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Source, true, false),
+		solana.NewAccountMeta(accounts.SwapSource, true, false),
+		solana.NewAccountMeta(accounts.SwapDestination, true, false),
+		solana.NewAccountMeta(accounts.Destination, true, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.PoolFee, true, false),
+		solana.NewAccountMeta(accounts.SourceMint, false, false),
+		solana.NewAccountMeta(accounts.DestinationMint, false, false),
+		solana.NewAccountMeta(accounts.SourceTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.DestinationTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.PoolTokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeTokenSwapV2Accounts maps the account keys of the tokenSwapV2 instruction by IDL order, ignoring remaining accounts
+func DecodeTokenSwapV2Accounts(keys []solana.PublicKey) (*TokenSwapV2Accounts, error) {
+	if len(keys) < 15 {
+		return nil, fmt.Errorf("tokenSwapV2 needs 15 accounts, got %d", len(keys))
+	}
+	return &TokenSwapV2Accounts{
+		SwapProgram:             keys[0],
+		Swap:                    keys[1],
+		Authority:               keys[2],
+		UserTransferAuthority:   keys[3],
+		Source:                  keys[4],
+		SwapSource:              keys[5],
+		SwapDestination:         keys[6],
+		Destination:             keys[7],
+		PoolMint:                keys[8],
+		PoolFee:                 keys[9],
+		SourceMint:              keys[10],
+		DestinationMint:         keys[11],
+		SourceTokenProgram:      keys[12],
+		DestinationTokenProgram: keys[13],
+		PoolTokenProgram:        keys[14],
+	}, nil
+}
+
+// SenchaSwapInstructionDiscriminator prefixes the data of every senchaSwap instruction
+var SenchaSwapInstructionDiscriminator = []byte{25, 50, 7, 21, 207, 248, 230, 194}
+
+// SenchaSwapAccounts lists the accounts of the senchaSwap instruction in IDL order
+type SenchaSwapAccounts struct {
+	SwapProgram        solana.PublicKey
+	TokenProgram       solana.PublicKey
+	Swap               solana.PublicKey // writable
+	UserAuthority      solana.PublicKey
+	InputUserAccount   solana.PublicKey // writable
+	InputTokenAccount  solana.PublicKey // writable
+	InputFeesAccount   solana.PublicKey // writable
+	OutputUserAccount  solana.PublicKey // writable
+	OutputTokenAccount solana.PublicKey // writable
+	OutputFeesAccount  solana.PublicKey // writable
+}
+
+// NewSenchaSwapInstruction builds the senchaSwap instruction. Remaining accounts are appended after the IDL ones
+func NewSenchaSwapInstruction(programID solana.PublicKey, accounts SenchaSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SenchaSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode senchaSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Swap, true, false),
+		solana.NewAccountMeta(accounts.UserAuthority, false, false),
+		solana.NewAccountMeta(accounts.InputUserAccount, true, false),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.InputFeesAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputUserAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputFeesAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSenchaSwapAccounts maps the account keys of the senchaSwap instruction by IDL order, ignoring remaining accounts
+func DecodeSenchaSwapAccounts(keys []solana.PublicKey) (*SenchaSwapAccounts, error) {
+	if len(keys) < 10 {
+		return nil, fmt.Errorf("senchaSwap needs 10 accounts, got %d", len(keys))
+	}
+	return &SenchaSwapAccounts{
+		SwapProgram:        keys[0],
+		TokenProgram:       keys[1],
+		Swap:               keys[2],
+		UserAuthority:      keys[3],
+		InputUserAccount:   keys[4],
+		InputTokenAccount:  keys[5],
+		InputFeesAccount:   keys[6],
+		OutputUserAccount:  keys[7],
+		OutputTokenAccount: keys[8],
+		OutputFeesAccount:  keys[9],
+	}, nil
+}
+
+// StepSwapInstructionDiscriminator prefixes the data of every stepSwap instruction
+var StepSwapInstructionDiscriminator = []byte{155, 56, 208, 198, 27, 61, 149, 233}
+
+// StepSwapAccounts lists the accounts of the stepSwap instruction in IDL order
+type StepSwapAccounts struct {
+	TokenSwapProgram      solana.PublicKey
+	TokenProgram          solana.PublicKey
+	Swap                  solana.PublicKey
+	Authority             solana.PublicKey
+	UserTransferAuthority solana.PublicKey
+	Source                solana.PublicKey // writable
+	SwapSource            solana.PublicKey // writable
+	SwapDestination       solana.PublicKey // writable
+	Destination           solana.PublicKey // writable
+	PoolMint              solana.PublicKey // writable
+	PoolFee               solana.PublicKey // writable
+}
+
+// NewStepSwapInstruction builds the stepSwap instruction. Remaining accounts are appended after the IDL ones
+func NewStepSwapInstruction(programID solana.PublicKey, accounts StepSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(StepSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode stepSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenSwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Swap, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Source, true, false),
+		solana.NewAccountMeta(accounts.SwapSource, true, false),
+		solana.NewAccountMeta(accounts.SwapDestination, true, false),
+		solana.NewAccountMeta(accounts.Destination, true, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.PoolFee, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeStepSwapAccounts maps the account keys of the stepSwap instruction by IDL order, ignoring remaining accounts
+func DecodeStepSwapAccounts(keys []solana.PublicKey) (*StepSwapAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("stepSwap needs 11 accounts, got %d", len(keys))
+	}
+	return &StepSwapAccounts{
+		TokenSwapProgram:      keys[0],
+		TokenProgram:          keys[1],
+		Swap:                  keys[2],
+		Authority:             keys[3],
+		UserTransferAuthority: keys[4],
+		Source:                keys[5],
+		SwapSource:            keys[6],
+		SwapDestination:       keys[7],
+		Destination:           keys[8],
+		PoolMint:              keys[9],
+		PoolFee:               keys[10],
+	}, nil
+}
+
+// CropperSwapInstructionDiscriminator prefixes the data of every cropperSwap instruction
+var CropperSwapInstructionDiscriminator = []byte{230, 216, 47, 182, 165, 117, 210, 103}
+
+// CropperSwapAccounts lists the accounts of the cropperSwap instruction in IDL order
+type CropperSwapAccounts struct {
+	TokenSwapProgram      solana.PublicKey
+	TokenProgram          solana.PublicKey
+	Swap                  solana.PublicKey
+	SwapState             solana.PublicKey
+	Authority             solana.PublicKey
+	UserTransferAuthority solana.PublicKey
+	Source                solana.PublicKey // writable
+	SwapSource            solana.PublicKey // writable
+	SwapDestination       solana.PublicKey // writable
+	Destination           solana.PublicKey // writable
+	PoolMint              solana.PublicKey // writable
+	PoolFee               solana.PublicKey // writable
+}
+
+// NewCropperSwapInstruction builds the cropperSwap instruction. Remaining accounts are appended after the IDL ones
+func NewCropperSwapInstruction(programID solana.PublicKey, accounts CropperSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CropperSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cropperSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenSwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Swap, false, false),
+		solana.NewAccountMeta(accounts.SwapState, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Source, true, false),
+		solana.NewAccountMeta(accounts.SwapSource, true, false),
+		solana.NewAccountMeta(accounts.SwapDestination, true, false),
+		solana.NewAccountMeta(accounts.Destination, true, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.PoolFee, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCropperSwapAccounts maps the account keys of the cropperSwap instruction by IDL order, ignoring remaining accounts
+func DecodeCropperSwapAccounts(keys []solana.PublicKey) (*CropperSwapAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("cropperSwap needs 12 accounts, got %d", len(keys))
+	}
+	return &CropperSwapAccounts{
+		TokenSwapProgram:      keys[0],
+		TokenProgram:          keys[1],
+		Swap:                  keys[2],
+		SwapState:             keys[3],
+		Authority:             keys[4],
+		UserTransferAuthority: keys[5],
+		Source:                keys[6],
+		SwapSource:            keys[7],
+		SwapDestination:       keys[8],
+		Destination:           keys[9],
+		PoolMint:              keys[10],
+		PoolFee:               keys[11],
+	}, nil
+}
+
+// RaydiumSwapInstructionDiscriminator prefixes the data of every raydiumSwap instruction
+var RaydiumSwapInstructionDiscriminator = []byte{177, 173, 42, 240, 184, 4, 124, 81}
+
+// RaydiumSwapAccounts lists the accounts of the raydiumSwap instruction in IDL order
+type RaydiumSwapAccounts struct {
+	SwapProgram                 solana.PublicKey
+	TokenProgram                solana.PublicKey
+	AmmId                       solana.PublicKey // writable
+	AmmAuthority                solana.PublicKey
+	AmmOpenOrders               solana.PublicKey // writable
+	PoolCoinTokenAccount        solana.PublicKey // writable
+	PoolPcTokenAccount          solana.PublicKey // writable
+	SerumProgramId              solana.PublicKey
+	SerumMarket                 solana.PublicKey // writable
+	SerumBids                   solana.PublicKey // writable
+	SerumAsks                   solana.PublicKey // writable
+	SerumEventQueue             solana.PublicKey // writable
+	SerumCoinVaultAccount       solana.PublicKey // writable
+	SerumPcVaultAccount         solana.PublicKey // writable
+	SerumVaultSigner            solana.PublicKey
+	UserSourceTokenAccount      solana.PublicKey // writable
+	UserDestinationTokenAccount solana.PublicKey // writable
+	UserSourceOwner             solana.PublicKey
+}
+
+// NewRaydiumSwapInstruction builds the raydiumSwap instruction. Remaining accounts are appended after the IDL ones
+func NewRaydiumSwapInstruction(programID solana.PublicKey, accounts RaydiumSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(RaydiumSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode raydiumSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AmmId, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumProgramId, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.UserSourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserDestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserSourceOwner, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeRaydiumSwapAccounts maps the account keys of the raydiumSwap instruction by IDL order, ignoring remaining accounts
+func DecodeRaydiumSwapAccounts(keys []solana.PublicKey) (*RaydiumSwapAccounts, error) {
+	if len(keys) < 18 {
+		return nil, fmt.Errorf("raydiumSwap needs 18 accounts, got %d", len(keys))
+	}
+	return &RaydiumSwapAccounts{
+		SwapProgram:                 keys[0],
+		TokenProgram:                keys[1],
+		AmmId:                       keys[2],
+		AmmAuthority:                keys[3],
+		AmmOpenOrders:               keys[4],
+		PoolCoinTokenAccount:        keys[5],
+		PoolPcTokenAccount:          keys[6],
+		SerumProgramId:              keys[7],
+		SerumMarket:                 keys[8],
+		SerumBids:                   keys[9],
+		SerumAsks:                   keys[10],
+		SerumEventQueue:             keys[11],
+		SerumCoinVaultAccount:       keys[12],
+		SerumPcVaultAccount:         keys[13],
+		SerumVaultSigner:            keys[14],
+		UserSourceTokenAccount:      keys[15],
+		UserDestinationTokenAccount: keys[16],
+		UserSourceOwner:             keys[17],
+	}, nil
+}
+
+// CremaSwapInstructionDiscriminator prefixes the data of every cremaSwap instruction
+var CremaSwapInstructionDiscriminator = []byte{169, 220, 41, 250, 35, 190, 133, 198}
+
+// CremaSwapAccounts lists the accounts of the cremaSwap instruction in IDL order
+type CremaSwapAccounts struct {
+	SwapProgram  solana.PublicKey
+	ClmmConfig   solana.PublicKey
+	Clmmpool     solana.PublicKey // writable
+	TokenA       solana.PublicKey
+	TokenB       solana.PublicKey
+	AccountA     solana.PublicKey // writable
+	AccountB     solana.PublicKey // writable
+	TokenAVault  solana.PublicKey // writable
+	TokenBVault  solana.PublicKey // writable
+	TickArrayMap solana.PublicKey // writable
+	Owner        solana.PublicKey
+	Partner      solana.PublicKey
+	PartnerAtaA  solana.PublicKey // writable
+	PartnerAtaB  solana.PublicKey // writable
+	TokenProgram solana.PublicKey
+}
+
+// NewCremaSwapInstruction builds the cremaSwap instruction. Remaining accounts are appended after the IDL ones
+func NewCremaSwapInstruction(programID solana.PublicKey, accounts CremaSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CremaSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cremaSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.ClmmConfig, false, false),
+		solana.NewAccountMeta(accounts.Clmmpool, true, false),
+		solana.NewAccountMeta(accounts.TokenA, false, false),
+		solana.NewAccountMeta(accounts.TokenB, false, false),
+		solana.NewAccountMeta(accounts.AccountA, true, false),
+		solana.NewAccountMeta(accounts.AccountB, true, false),
+		solana.NewAccountMeta(accounts.TokenAVault, true, false),
+		solana.NewAccountMeta(accounts.TokenBVault, true, false),
+		solana.NewAccountMeta(accounts.TickArrayMap, true, false),
+		solana.NewAccountMeta(accounts.Owner, false, false),
+		solana.NewAccountMeta(accounts.Partner, false, false),
+		solana.NewAccountMeta(accounts.PartnerAtaA, true, false),
+		solana.NewAccountMeta(accounts.PartnerAtaB, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCremaSwapAccounts maps the account keys of the cremaSwap instruction by IDL order, ignoring remaining accounts
+func DecodeCremaSwapAccounts(keys []solana.PublicKey) (*CremaSwapAccounts, error) {
+	if len(keys) < 15 {
+		return nil, fmt.Errorf("cremaSwap needs 15 accounts, got %d", len(keys))
+	}
+	return &CremaSwapAccounts{
+		SwapProgram:  keys[0],
+		ClmmConfig:   keys[1],
+		Clmmpool:     keys[2],
+		TokenA:       keys[3],
+		TokenB:       keys[4],
+		AccountA:     keys[5],
+		AccountB:     keys[6],
+		TokenAVault:  keys[7],
+		TokenBVault:  keys[8],
+		TickArrayMap: keys[9],
+		Owner:        keys[10],
+		Partner:      keys[11],
+		PartnerAtaA:  keys[12],
+		PartnerAtaB:  keys[13],
+		TokenProgram: keys[14],
+	}, nil
+}
+
+// LifinitySwapInstructionDiscriminator prefixes the data of every lifinitySwap instruction
+var LifinitySwapInstructionDiscriminator = []byte{23, 96, 165, 33, 90, 214, 96, 153}
+
+// LifinitySwapAccounts lists the accounts of the lifinitySwap instruction in IDL order
+type LifinitySwapAccounts struct {
+	SwapProgram           solana.PublicKey
+	Authority             solana.PublicKey
+	Amm                   solana.PublicKey
+	UserTransferAuthority solana.PublicKey
+	SourceInfo            solana.PublicKey // writable
+	DestinationInfo       solana.PublicKey // writable
+	SwapSource            solana.PublicKey // writable
+	SwapDestination       solana.PublicKey // writable
+	PoolMint              solana.PublicKey // writable
+	FeeAccount            solana.PublicKey // writable
+	TokenProgram          solana.PublicKey
+	PythAccount           solana.PublicKey
+	PythPcAccount         solana.PublicKey
+	ConfigAccount         solana.PublicKey // writable
+}
+
+// NewLifinitySwapInstruction builds the lifinitySwap instruction. Remaining accounts are appended after the IDL ones
+func NewLifinitySwapInstruction(programID solana.PublicKey, accounts LifinitySwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(LifinitySwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lifinitySwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.Amm, false, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.SourceInfo, true, false),
+		solana.NewAccountMeta(accounts.DestinationInfo, true, false),
+		solana.NewAccountMeta(accounts.SwapSource, true, false),
+		solana.NewAccountMeta(accounts.SwapDestination, true, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.FeeAccount, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.PythAccount, false, false),
+		solana.NewAccountMeta(accounts.PythPcAccount, false, false),
+		solana.NewAccountMeta(accounts.ConfigAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeLifinitySwapAccounts maps the account keys of the lifinitySwap instruction by IDL order, ignoring remaining accounts
+func DecodeLifinitySwapAccounts(keys []solana.PublicKey) (*LifinitySwapAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("lifinitySwap needs 14 accounts, got %d", len(keys))
+	}
+	return &LifinitySwapAccounts{
+		SwapProgram:           keys[0],
+		Authority:             keys[1],
+		Amm:                   keys[2],
+		UserTransferAuthority: keys[3],
+		SourceInfo:            keys[4],
+		DestinationInfo:       keys[5],
+		SwapSource:            keys[6],
+		SwapDestination:       keys[7],
+		PoolMint:              keys[8],
+		FeeAccount:            keys[9],
+		TokenProgram:          keys[10],
+		PythAccount:           keys[11],
+		PythPcAccount:         keys[12],
+		ConfigAccount:         keys[13],
+	}, nil
+}
+
+// MarinadeDepositInstructionDiscriminator prefixes the data of every marinadeDeposit instruction
+var MarinadeDepositInstructionDiscriminator = []byte{62, 236, 248, 28, 222, 232, 182, 73}
+
+// MarinadeDepositAccounts lists the accounts of the marinadeDeposit instruction in IDL order
+type MarinadeDepositAccounts struct {
+	MarinadeFinanceProgram  solana.PublicKey
+	State                   solana.PublicKey // writable
+	MsolMint                solana.PublicKey // writable
+	LiqPoolSolLegPda        solana.PublicKey // writable
+	LiqPoolMsolLeg          solana.PublicKey // writable
+	LiqPoolMsolLegAuthority solana.PublicKey
+	ReservePda              solana.PublicKey // writable
+	TransferFrom            solana.PublicKey // writable
+	MintTo                  solana.PublicKey // writable
+	MsolMintAuthority       solana.PublicKey
+	SystemProgram           solana.PublicKey
+	TokenProgram            solana.PublicKey
+	UserWsolTokenAccount    solana.PublicKey // writable
+	TempWsolTokenAccount    solana.PublicKey // writable
+	UserTransferAuthority   solana.PublicKey
+	Payer                   solana.PublicKey // writable
+	WsolMint                solana.PublicKey
+	Rent                    solana.PublicKey
+}
+
+// NewMarinadeDepositInstruction builds the marinadeDeposit instruction. Remaining accounts are appended after the IDL ones
+func NewMarinadeDepositInstruction(programID solana.PublicKey, accounts MarinadeDepositAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MarinadeDepositInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode marinadeDeposit args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.MarinadeFinanceProgram, false, false),
+		solana.NewAccountMeta(accounts.State, true, false),
+		solana.NewAccountMeta(accounts.MsolMint, true, false),
+		solana.NewAccountMeta(accounts.LiqPoolSolLegPda, true, false),
+		solana.NewAccountMeta(accounts.LiqPoolMsolLeg, true, false),
+		solana.NewAccountMeta(accounts.LiqPoolMsolLegAuthority, false, false),
+		solana.NewAccountMeta(accounts.ReservePda, true, false),
+		solana.NewAccountMeta(accounts.TransferFrom, true, false),
+		solana.NewAccountMeta(accounts.MintTo, true, false),
+		solana.NewAccountMeta(accounts.MsolMintAuthority, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.UserWsolTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.TempWsolTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Payer, true, false),
+		solana.NewAccountMeta(accounts.WsolMint, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMarinadeDepositAccounts maps the account keys of the marinadeDeposit instruction by IDL order, ignoring remaining accounts
+func DecodeMarinadeDepositAccounts(keys []solana.PublicKey) (*MarinadeDepositAccounts, error) {
+	if len(keys) < 18 {
+		return nil, fmt.Errorf("marinadeDeposit needs 18 accounts, got %d", len(keys))
+	}
+	return &MarinadeDepositAccounts{
+		MarinadeFinanceProgram:  keys[0],
+		State:                   keys[1],
+		MsolMint:                keys[2],
+		LiqPoolSolLegPda:        keys[3],
+		LiqPoolMsolLeg:          keys[4],
+		LiqPoolMsolLegAuthority: keys[5],
+		ReservePda:              keys[6],
+		TransferFrom:            keys[7],
+		MintTo:                  keys[8],
+		MsolMintAuthority:       keys[9],
+		SystemProgram:           keys[10],
+		TokenProgram:            keys[11],
+		UserWsolTokenAccount:    keys[12],
+		TempWsolTokenAccount:    keys[13],
+		UserTransferAuthority:   keys[14],
+		Payer:                   keys[15],
+		WsolMint:                keys[16],
+		Rent:                    keys[17],
+	}, nil
+}
+
+// MarinadeUnstakeInstructionDiscriminator prefixes the data of every marinadeUnstake instruction
+var MarinadeUnstakeInstructionDiscriminator = []byte{41, 120, 15, 0, 113, 219, 42, 1}
+
+// MarinadeUnstakeAccounts lists the accounts of the marinadeUnstake instruction in IDL order
+type MarinadeUnstakeAccounts struct {
+	MarinadeFinanceProgram solana.PublicKey
+	State                  solana.PublicKey // writable
+	MsolMint               solana.PublicKey // writable
+	LiqPoolSolLegPda       solana.PublicKey // writable
+	LiqPoolMsolLeg         solana.PublicKey // writable
+	TreasuryMsolAccount    solana.PublicKey // writable
+	GetMsolFrom            solana.PublicKey // writable
+	GetMsolFromAuthority   solana.PublicKey
+	TransferSolTo          solana.PublicKey // writable
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	UserWsolTokenAccount   solana.PublicKey // writable
+}
+
+// NewMarinadeUnstakeInstruction builds the marinadeUnstake instruction. Remaining accounts are appended after the IDL ones
+func NewMarinadeUnstakeInstruction(programID solana.PublicKey, accounts MarinadeUnstakeAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MarinadeUnstakeInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode marinadeUnstake args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.MarinadeFinanceProgram, false, false),
+		solana.NewAccountMeta(accounts.State, true, false),
+		solana.NewAccountMeta(accounts.MsolMint, true, false),
+		solana.NewAccountMeta(accounts.LiqPoolSolLegPda, true, false),
+		solana.NewAccountMeta(accounts.LiqPoolMsolLeg, true, false),
+		solana.NewAccountMeta(accounts.TreasuryMsolAccount, true, false),
+		solana.NewAccountMeta(accounts.GetMsolFrom, true, false),
+		solana.NewAccountMeta(accounts.GetMsolFromAuthority, false, false),
+		solana.NewAccountMeta(accounts.TransferSolTo, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.UserWsolTokenAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMarinadeUnstakeAccounts maps the account keys of the marinadeUnstake instruction by IDL order, ignoring remaining accounts
+func DecodeMarinadeUnstakeAccounts(keys []solana.PublicKey) (*MarinadeUnstakeAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("marinadeUnstake needs 12 accounts, got %d", len(keys))
+	}
+	return &MarinadeUnstakeAccounts{
+		MarinadeFinanceProgram: keys[0],
+		State:                  keys[1],
+		MsolMint:               keys[2],
+		LiqPoolSolLegPda:       keys[3],
+		LiqPoolMsolLeg:         keys[4],
+		TreasuryMsolAccount:    keys[5],
+		GetMsolFrom:            keys[6],
+		GetMsolFromAuthority:   keys[7],
+		TransferSolTo:          keys[8],
+		SystemProgram:          keys[9],
+		TokenProgram:           keys[10],
+		UserWsolTokenAccount:   keys[11],
+	}, nil
+}
+
+// AldrinSwapInstructionDiscriminator prefixes the data of every aldrinSwap instruction
+var AldrinSwapInstructionDiscriminator = []byte{251, 232, 119, 166, 225, 185, 169, 161}
+
+// AldrinSwapAccounts lists the accounts of the aldrinSwap instruction in IDL order
+type AldrinSwapAccounts struct {
+	SwapProgram           solana.PublicKey
+	Pool                  solana.PublicKey
+	PoolSigner            solana.PublicKey
+	PoolMint              solana.PublicKey // writable
+	BaseTokenVault        solana.PublicKey // writable
+	QuoteTokenVault       solana.PublicKey // writable
+	FeePoolTokenAccount   solana.PublicKey // writable
+	WalletAuthority       solana.PublicKey
+	UserBaseTokenAccount  solana.PublicKey // writable
+	UserQuoteTokenAccount solana.PublicKey // writable
+	TokenProgram          solana.PublicKey
+}
+
+// NewAldrinSwapInstruction builds the aldrinSwap instruction. Remaining accounts are appended after the IDL ones
+func NewAldrinSwapInstruction(programID solana.PublicKey, accounts AldrinSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(AldrinSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode aldrinSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Pool, false, false),
+		solana.NewAccountMeta(accounts.PoolSigner, false, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.BaseTokenVault, true, false),
+		solana.NewAccountMeta(accounts.QuoteTokenVault, true, false),
+		solana.NewAccountMeta(accounts.FeePoolTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.WalletAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserBaseTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserQuoteTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeAldrinSwapAccounts maps the account keys of the aldrinSwap instruction by IDL order, ignoring remaining accounts
+func DecodeAldrinSwapAccounts(keys []solana.PublicKey) (*AldrinSwapAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("aldrinSwap needs 11 accounts, got %d", len(keys))
+	}
+	return &AldrinSwapAccounts{
+		SwapProgram:           keys[0],
+		Pool:                  keys[1],
+		PoolSigner:            keys[2],
+		PoolMint:              keys[3],
+		BaseTokenVault:        keys[4],
+		QuoteTokenVault:       keys[5],
+		FeePoolTokenAccount:   keys[6],
+		WalletAuthority:       keys[7],
+		UserBaseTokenAccount:  keys[8],
+		UserQuoteTokenAccount: keys[9],
+		TokenProgram:          keys[10],
+	}, nil
+}
+
+// AldrinV2SwapInstructionDiscriminator prefixes the data of every aldrinV2Swap instruction
+var AldrinV2SwapInstructionDiscriminator = []byte{190, 166, 89, 139, 33, 152, 16, 10}
+
+// AldrinV2SwapAccounts lists the accounts of the aldrinV2Swap instruction in IDL order
+type AldrinV2SwapAccounts struct {
+	SwapProgram           solana.PublicKey
+	Pool                  solana.PublicKey
+	PoolSigner            solana.PublicKey
+	PoolMint              solana.PublicKey // writable
+	BaseTokenVault        solana.PublicKey // writable
+	QuoteTokenVault       solana.PublicKey // writable
+	FeePoolTokenAccount   solana.PublicKey // writable
+	WalletAuthority       solana.PublicKey
+	UserBaseTokenAccount  solana.PublicKey // writable
+	UserQuoteTokenAccount solana.PublicKey // writable
+	Curve                 solana.PublicKey
+	TokenProgram          solana.PublicKey
+}
+
+// NewAldrinV2SwapInstruction builds the aldrinV2Swap instruction. Remaining accounts are appended after the IDL ones
+func NewAldrinV2SwapInstruction(programID solana.PublicKey, accounts AldrinV2SwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(AldrinV2SwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode aldrinV2Swap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Pool, false, false),
+		solana.NewAccountMeta(accounts.PoolSigner, false, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.BaseTokenVault, true, false),
+		solana.NewAccountMeta(accounts.QuoteTokenVault, true, false),
+		solana.NewAccountMeta(accounts.FeePoolTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.WalletAuthority, false, false),
+		solana.NewAccountMeta(accounts.UserBaseTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserQuoteTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.Curve, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeAldrinV2SwapAccounts maps the account keys of the aldrinV2Swap instruction by IDL order, ignoring remaining accounts
+func DecodeAldrinV2SwapAccounts(keys []solana.PublicKey) (*AldrinV2SwapAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("aldrinV2Swap needs 12 accounts, got %d", len(keys))
+	}
+	return &AldrinV2SwapAccounts{
+		SwapProgram:           keys[0],
+		Pool:                  keys[1],
+		PoolSigner:            keys[2],
+		PoolMint:              keys[3],
+		BaseTokenVault:        keys[4],
+		QuoteTokenVault:       keys[5],
+		FeePoolTokenAccount:   keys[6],
+		WalletAuthority:       keys[7],
+		UserBaseTokenAccount:  keys[8],
+		UserQuoteTokenAccount: keys[9],
+		Curve:                 keys[10],
+		TokenProgram:          keys[11],
+	}, nil
+}
+
+// WhirlpoolSwapInstructionDiscriminator prefixes the data of every whirlpoolSwap instruction
+var WhirlpoolSwapInstructionDiscriminator = []byte{123, 229, 184, 63, 12, 0, 92, 145}
+
+// WhirlpoolSwapAccounts lists the accounts of the whirlpoolSwap instruction in IDL order
+type WhirlpoolSwapAccounts struct {
+	SwapProgram        solana.PublicKey
+	TokenProgram       solana.PublicKey
+	TokenAuthority     solana.PublicKey
+	Whirlpool          solana.PublicKey // writable
+	TokenOwnerAccountA solana.PublicKey // writable
+	TokenVaultA        solana.PublicKey // writable
+	TokenOwnerAccountB solana.PublicKey // writable
+	TokenVaultB        solana.PublicKey // writable
+	TickArray0         solana.PublicKey // writable
+	TickArray1         solana.PublicKey // writable
+	TickArray2         solana.PublicKey // writable
+	// Oracle is currently unused and will be enabled on subsequent updates
+	Oracle solana.PublicKey
+}
+
+// NewWhirlpoolSwapInstruction builds the whirlpoolSwap instruction. Remaining accounts are appended after the IDL ones
+func NewWhirlpoolSwapInstruction(programID solana.PublicKey, accounts WhirlpoolSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(WhirlpoolSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode whirlpoolSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenAuthority, false, false),
+		solana.NewAccountMeta(accounts.Whirlpool, true, false),
+		solana.NewAccountMeta(accounts.TokenOwnerAccountA, true, false),
+		solana.NewAccountMeta(accounts.TokenVaultA, true, false),
+		solana.NewAccountMeta(accounts.TokenOwnerAccountB, true, false),
+		solana.NewAccountMeta(accounts.TokenVaultB, true, false),
+		solana.NewAccountMeta(accounts.TickArray0, true, false),
+		solana.NewAccountMeta(accounts.TickArray1, true, false),
+		solana.NewAccountMeta(accounts.TickArray2, true, false),
+		solana.NewAccountMeta(accounts.Oracle, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeWhirlpoolSwapAccounts maps the account keys of the whirlpoolSwap instruction by IDL order, ignoring remaining accounts
+func DecodeWhirlpoolSwapAccounts(keys []solana.PublicKey) (*WhirlpoolSwapAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("whirlpoolSwap needs 12 accounts, got %d", len(keys))
+	}
+	return &WhirlpoolSwapAccounts{
+		SwapProgram:        keys[0],
+		TokenProgram:       keys[1],
+		TokenAuthority:     keys[2],
+		Whirlpool:          keys[3],
+		TokenOwnerAccountA: keys[4],
+		TokenVaultA:        keys[5],
+		TokenOwnerAccountB: keys[6],
+		TokenVaultB:        keys[7],
+		TickArray0:         keys[8],
+		TickArray1:         keys[9],
+		TickArray2:         keys[10],
+		Oracle:             keys[11],
+	}, nil
+}
+
+// InvariantSwapInstructionDiscriminator prefixes the data of every invariantSwap instruction
+var InvariantSwapInstructionDiscriminator = []byte{187, 193, 40, 121, 47, 73, 144, 177}
+
+// InvariantSwapAccounts lists the accounts of the invariantSwap instruction in IDL order
+type InvariantSwapAccounts struct {
+	SwapProgram      solana.PublicKey
+	State            solana.PublicKey
+	Pool             solana.PublicKey // writable
+	Tickmap          solana.PublicKey // writable
+	AccountX         solana.PublicKey // writable
+	AccountY         solana.PublicKey // writable
+	ReserveX         solana.PublicKey // writable
+	ReserveY         solana.PublicKey // writable
+	Owner            solana.PublicKey
+	ProgramAuthority solana.PublicKey
+	TokenProgram     solana.PublicKey
+}
+
+// NewInvariantSwapInstruction builds the invariantSwap instruction. Remaining accounts are appended after the IDL ones
+func NewInvariantSwapInstruction(programID solana.PublicKey, accounts InvariantSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(InvariantSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode invariantSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.State, false, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.Tickmap, true, false),
+		solana.NewAccountMeta(accounts.AccountX, true, false),
+		solana.NewAccountMeta(accounts.AccountY, true, false),
+		solana.NewAccountMeta(accounts.ReserveX, true, false),
+		solana.NewAccountMeta(accounts.ReserveY, true, false),
+		solana.NewAccountMeta(accounts.Owner, false, false),
+		solana.NewAccountMeta(accounts.ProgramAuthority, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeInvariantSwapAccounts maps the account keys of the invariantSwap instruction by IDL order, ignoring remaining accounts
+func DecodeInvariantSwapAccounts(keys []solana.PublicKey) (*InvariantSwapAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("invariantSwap needs 11 accounts, got %d", len(keys))
+	}
+	return &InvariantSwapAccounts{
+		SwapProgram:      keys[0],
+		State:            keys[1],
+		Pool:             keys[2],
+		Tickmap:          keys[3],
+		AccountX:         keys[4],
+		AccountY:         keys[5],
+		ReserveX:         keys[6],
+		ReserveY:         keys[7],
+		Owner:            keys[8],
+		ProgramAuthority: keys[9],
+		TokenProgram:     keys[10],
+	}, nil
+}
+
+// MeteoraSwapInstructionDiscriminator prefixes the data of every meteoraSwap instruction
+var MeteoraSwapInstructionDiscriminator = []byte{127, 125, 226, 12, 81, 24, 204, 35}
+
+// MeteoraSwapAccounts lists the accounts of the meteoraSwap instruction in IDL order
+type MeteoraSwapAccounts struct {
+	SwapProgram          solana.PublicKey
+	Pool                 solana.PublicKey // writable
+	UserSourceToken      solana.PublicKey // writable
+	UserDestinationToken solana.PublicKey // writable
+	AVault               solana.PublicKey // writable
+	BVault               solana.PublicKey // writable
+	ATokenVault          solana.PublicKey // writable
+	BTokenVault          solana.PublicKey // writable
+	AVaultLpMint         solana.PublicKey // writable
+	BVaultLpMint         solana.PublicKey // writable
+	AVaultLp             solana.PublicKey // writable
+	BVaultLp             solana.PublicKey // writable
+	AdminTokenFee        solana.PublicKey // writable
+	User                 solana.PublicKey
+	VaultProgram         solana.PublicKey
+	TokenProgram         solana.PublicKey
+}
+
+// NewMeteoraSwapInstruction builds the meteoraSwap instruction. Remaining accounts are appended after the IDL ones
+func NewMeteoraSwapInstruction(programID solana.PublicKey, accounts MeteoraSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MeteoraSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode meteoraSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.UserSourceToken, true, false),
+		solana.NewAccountMeta(accounts.UserDestinationToken, true, false),
+		solana.NewAccountMeta(accounts.AVault, true, false),
+		solana.NewAccountMeta(accounts.BVault, true, false),
+		solana.NewAccountMeta(accounts.ATokenVault, true, false),
+		solana.NewAccountMeta(accounts.BTokenVault, true, false),
+		solana.NewAccountMeta(accounts.AVaultLpMint, true, false),
+		solana.NewAccountMeta(accounts.BVaultLpMint, true, false),
+		solana.NewAccountMeta(accounts.AVaultLp, true, false),
+		solana.NewAccountMeta(accounts.BVaultLp, true, false),
+		solana.NewAccountMeta(accounts.AdminTokenFee, true, false),
+		solana.NewAccountMeta(accounts.User, false, false),
+		solana.NewAccountMeta(accounts.VaultProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMeteoraSwapAccounts maps the account keys of the meteoraSwap instruction by IDL order, ignoring remaining accounts
+func DecodeMeteoraSwapAccounts(keys []solana.PublicKey) (*MeteoraSwapAccounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("meteoraSwap needs 16 accounts, got %d", len(keys))
+	}
+	return &MeteoraSwapAccounts{
+		SwapProgram:          keys[0],
+		Pool:                 keys[1],
+		UserSourceToken:      keys[2],
+		UserDestinationToken: keys[3],
+		AVault:               keys[4],
+		BVault:               keys[5],
+		ATokenVault:          keys[6],
+		BTokenVault:          keys[7],
+		AVaultLpMint:         keys[8],
+		BVaultLpMint:         keys[9],
+		AVaultLp:             keys[10],
+		BVaultLp:             keys[11],
+		AdminTokenFee:        keys[12],
+		User:                 keys[13],
+		VaultProgram:         keys[14],
+		TokenProgram:         keys[15],
+	}, nil
+}
+
+// GoosefxSwapInstructionDiscriminator prefixes the data of every goosefxSwap instruction
+var GoosefxSwapInstructionDiscriminator = []byte{222, 136, 46, 123, 189, 125, 124, 122}
+
+// GoosefxSwapAccounts lists the accounts of the goosefxSwap instruction in IDL order
+type GoosefxSwapAccounts struct {
+	SwapProgram              solana.PublicKey
+	Controller               solana.PublicKey
+	Pair                     solana.PublicKey // writable
+	SslIn                    solana.PublicKey // writable
+	SslOut                   solana.PublicKey // writable
+	LiabilityVaultIn         solana.PublicKey // writable
+	SwappedLiabilityVaultIn  solana.PublicKey // writable
+	LiabilityVaultOut        solana.PublicKey // writable
+	SwappedLiabilityVaultOut solana.PublicKey // writable
+	UserInAta                solana.PublicKey // writable
+	UserOutAta               solana.PublicKey // writable
+	FeeCollectorAta          solana.PublicKey // writable
+	UserWallet               solana.PublicKey
+	FeeCollector             solana.PublicKey
+	TokenProgram             solana.PublicKey
+}
+
+// NewGoosefxSwapInstruction builds the goosefxSwap instruction. Remaining accounts are appended after the IDL ones
+func NewGoosefxSwapInstruction(programID solana.PublicKey, accounts GoosefxSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(GoosefxSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode goosefxSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Controller, false, false),
+		solana.NewAccountMeta(accounts.Pair, true, false),
+		solana.NewAccountMeta(accounts.SslIn, true, false),
+		solana.NewAccountMeta(accounts.SslOut, true, false),
+		solana.NewAccountMeta(accounts.LiabilityVaultIn, true, false),
+		solana.NewAccountMeta(accounts.SwappedLiabilityVaultIn, true, false),
+		solana.NewAccountMeta(accounts.LiabilityVaultOut, true, false),
+		solana.NewAccountMeta(accounts.SwappedLiabilityVaultOut, true, false),
+		solana.NewAccountMeta(accounts.UserInAta, true, false),
+		solana.NewAccountMeta(accounts.UserOutAta, true, false),
+		solana.NewAccountMeta(accounts.FeeCollectorAta, true, false),
+		solana.NewAccountMeta(accounts.UserWallet, false, false),
+		solana.NewAccountMeta(accounts.FeeCollector, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeGoosefxSwapAccounts maps the account keys of the goosefxSwap instruction by IDL order, ignoring remaining accounts
+func DecodeGoosefxSwapAccounts(keys []solana.PublicKey) (*GoosefxSwapAccounts, error) {
+	if len(keys) < 15 {
+		return nil, fmt.Errorf("goosefxSwap needs 15 accounts, got %d", len(keys))
+	}
+	return &GoosefxSwapAccounts{
+		SwapProgram:              keys[0],
+		Controller:               keys[1],
+		Pair:                     keys[2],
+		SslIn:                    keys[3],
+		SslOut:                   keys[4],
+		LiabilityVaultIn:         keys[5],
+		SwappedLiabilityVaultIn:  keys[6],
+		LiabilityVaultOut:        keys[7],
+		SwappedLiabilityVaultOut: keys[8],
+		UserInAta:                keys[9],
+		UserOutAta:               keys[10],
+		FeeCollectorAta:          keys[11],
+		UserWallet:               keys[12],
+		FeeCollector:             keys[13],
+		TokenProgram:             keys[14],
+	}, nil
+}
+
+// DeltafiSwapInstructionDiscriminator prefixes the data of every deltafiSwap instruction
+var DeltafiSwapInstructionDiscriminator = []byte{132, 230, 102, 120, 205, 9, 237, 190}
+
+// DeltafiSwapAccounts lists the accounts of the deltafiSwap instruction in IDL order
+type DeltafiSwapAccounts struct {
+	SwapProgram           solana.PublicKey
+	MarketConfig          solana.PublicKey
+	SwapInfo              solana.PublicKey // writable
+	UserSourceToken       solana.PublicKey // writable
+	UserDestinationToken  solana.PublicKey // writable
+	SwapSourceToken       solana.PublicKey // writable
+	SwapDestinationToken  solana.PublicKey // writable
+	DeltafiUser           solana.PublicKey // writable
+	AdminDestinationToken solana.PublicKey // writable
+	PythPriceBase         solana.PublicKey
+	PythPriceQuote        solana.PublicKey
+	UserAuthority         solana.PublicKey
+	TokenProgram          solana.PublicKey
+}
+
+// NewDeltafiSwapInstruction builds the deltafiSwap instruction. Remaining accounts are appended after the IDL ones
+func NewDeltafiSwapInstruction(programID solana.PublicKey, accounts DeltafiSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(DeltafiSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deltafiSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.MarketConfig, false, false),
+		solana.NewAccountMeta(accounts.SwapInfo, true, false),
+		solana.NewAccountMeta(accounts.UserSourceToken, true, false),
+		solana.NewAccountMeta(accounts.UserDestinationToken, true, false),
+		solana.NewAccountMeta(accounts.SwapSourceToken, true, false),
+		solana.NewAccountMeta(accounts.SwapDestinationToken, true, false),
+		solana.NewAccountMeta(accounts.DeltafiUser, true, false),
+		solana.NewAccountMeta(accounts.AdminDestinationToken, true, false),
+		solana.NewAccountMeta(accounts.PythPriceBase, false, false),
+		solana.NewAccountMeta(accounts.PythPriceQuote, false, false),
+		solana.NewAccountMeta(accounts.UserAuthority, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeDeltafiSwapAccounts maps the account keys of the deltafiSwap instruction by IDL order, ignoring remaining accounts
+func DecodeDeltafiSwapAccounts(keys []solana.PublicKey) (*DeltafiSwapAccounts, error) {
+	if len(keys) < 13 {
+		return nil, fmt.Errorf("deltafiSwap needs 13 accounts, got %d", len(keys))
+	}
+	return &DeltafiSwapAccounts{
+		SwapProgram:           keys[0],
+		MarketConfig:          keys[1],
+		SwapInfo:              keys[2],
+		UserSourceToken:       keys[3],
+		UserDestinationToken:  keys[4],
+		SwapSourceToken:       keys[5],
+		SwapDestinationToken:  keys[6],
+		DeltafiUser:           keys[7],
+		AdminDestinationToken: keys[8],
+		PythPriceBase:         keys[9],
+		PythPriceQuote:        keys[10],
+		UserAuthority:         keys[11],
+		TokenProgram:          keys[12],
+	}, nil
+}
+
+// BalansolSwapInstructionDiscriminator prefixes the data of every balansolSwap instruction
+var BalansolSwapInstructionDiscriminator = []byte{137, 109, 253, 253, 70, 109, 11, 100}
+
+// BalansolSwapAccounts lists the accounts of the balansolSwap instruction in IDL order
+type BalansolSwapAccounts struct {
+	SwapProgram               solana.PublicKey
+	Authority                 solana.PublicKey // writable
+	Pool                      solana.PublicKey // writable
+	TaxMan                    solana.PublicKey // writable
+	BidMint                   solana.PublicKey
+	Treasurer                 solana.PublicKey
+	SrcTreasury               solana.PublicKey // writable
+	SrcAssociatedTokenAccount solana.PublicKey // writable
+	AskMint                   solana.PublicKey
+	DstTreasury               solana.PublicKey // writable
+	DstAssociatedTokenAccount solana.PublicKey // writable
+	DstTokenAccountTaxman     solana.PublicKey // writable
+	SystemProgram             solana.PublicKey
+	TokenProgram              solana.PublicKey
+	AssociatedTokenProgram    solana.PublicKey
+	Rent                      solana.PublicKey
+}
+
+// NewBalansolSwapInstruction builds the balansolSwap instruction. Remaining accounts are appended after the IDL ones
+func NewBalansolSwapInstruction(programID solana.PublicKey, accounts BalansolSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(BalansolSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode balansolSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Authority, true, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.TaxMan, true, false),
+		solana.NewAccountMeta(accounts.BidMint, false, false),
+		solana.NewAccountMeta(accounts.Treasurer, false, false),
+		solana.NewAccountMeta(accounts.SrcTreasury, true, false),
+		solana.NewAccountMeta(accounts.SrcAssociatedTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.AskMint, false, false),
+		solana.NewAccountMeta(accounts.DstTreasury, true, false),
+		solana.NewAccountMeta(accounts.DstAssociatedTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DstTokenAccountTaxman, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeBalansolSwapAccounts maps the account keys of the balansolSwap instruction by IDL order, ignoring remaining accounts
+func DecodeBalansolSwapAccounts(keys []solana.PublicKey) (*BalansolSwapAccounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("balansolSwap needs 16 accounts, got %d", len(keys))
+	}
+	return &BalansolSwapAccounts{
+		SwapProgram:               keys[0],
+		Authority:                 keys[1],
+		Pool:                      keys[2],
+		TaxMan:                    keys[3],
+		BidMint:                   keys[4],
+		Treasurer:                 keys[5],
+		SrcTreasury:               keys[6],
+		SrcAssociatedTokenAccount: keys[7],
+		AskMint:                   keys[8],
+		DstTreasury:               keys[9],
+		DstAssociatedTokenAccount: keys[10],
+		DstTokenAccountTaxman:     keys[11],
+		SystemProgram:             keys[12],
+		TokenProgram:              keys[13],
+		AssociatedTokenProgram:    keys[14],
+		Rent:                      keys[15],
+	}, nil
+}
+
+// MarcoPoloSwapInstructionDiscriminator prefixes the data of every marcoPoloSwap instruction
+var MarcoPoloSwapInstructionDiscriminator = []byte{241, 147, 94, 15, 58, 108, 179, 68}
+
+// MarcoPoloSwapAccounts lists the accounts of the marcoPoloSwap instruction in IDL order
+type MarcoPoloSwapAccounts struct {
+	SwapProgram            solana.PublicKey
+	State                  solana.PublicKey
+	Pool                   solana.PublicKey // writable
+	TokenX                 solana.PublicKey
+	TokenY                 solana.PublicKey
+	PoolXAccount           solana.PublicKey // writable
+	PoolYAccount           solana.PublicKey // writable
+	SwapperXAccount        solana.PublicKey // writable
+	SwapperYAccount        solana.PublicKey // writable
+	Swapper                solana.PublicKey // writable
+	ReferrerXAccount       solana.PublicKey // writable
+	ReferrerYAccount       solana.PublicKey // writable
+	Referrer               solana.PublicKey // writable
+	ProgramAuthority       solana.PublicKey
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	Rent                   solana.PublicKey
+}
+
+// NewMarcoPoloSwapInstruction builds the marcoPoloSwap instruction. Remaining accounts are appended after the IDL ones
+func NewMarcoPoloSwapInstruction(programID solana.PublicKey, accounts MarcoPoloSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MarcoPoloSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode marcoPoloSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.State, false, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.TokenX, false, false),
+		solana.NewAccountMeta(accounts.TokenY, false, false),
+		solana.NewAccountMeta(accounts.PoolXAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolYAccount, true, false),
+		solana.NewAccountMeta(accounts.SwapperXAccount, true, false),
+		solana.NewAccountMeta(accounts.SwapperYAccount, true, false),
+		solana.NewAccountMeta(accounts.Swapper, true, false),
+		solana.NewAccountMeta(accounts.ReferrerXAccount, true, false),
+		solana.NewAccountMeta(accounts.ReferrerYAccount, true, false),
+		solana.NewAccountMeta(accounts.Referrer, true, false),
+		solana.NewAccountMeta(accounts.ProgramAuthority, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMarcoPoloSwapAccounts maps the account keys of the marcoPoloSwap instruction by IDL order, ignoring remaining accounts
+func DecodeMarcoPoloSwapAccounts(keys []solana.PublicKey) (*MarcoPoloSwapAccounts, error) {
+	if len(keys) < 18 {
+		return nil, fmt.Errorf("marcoPoloSwap needs 18 accounts, got %d", len(keys))
+	}
+	return &MarcoPoloSwapAccounts{
+		SwapProgram:            keys[0],
+		State:                  keys[1],
+		Pool:                   keys[2],
+		TokenX:                 keys[3],
+		TokenY:                 keys[4],
+		PoolXAccount:           keys[5],
+		PoolYAccount:           keys[6],
+		SwapperXAccount:        keys[7],
+		SwapperYAccount:        keys[8],
+		Swapper:                keys[9],
+		ReferrerXAccount:       keys[10],
+		ReferrerYAccount:       keys[11],
+		Referrer:               keys[12],
+		ProgramAuthority:       keys[13],
+		SystemProgram:          keys[14],
+		TokenProgram:           keys[15],
+		AssociatedTokenProgram: keys[16],
+		Rent:                   keys[17],
+	}, nil
+}
+
+// DradexSwapInstructionDiscriminator prefixes the data of every dradexSwap instruction
+var DradexSwapInstructionDiscriminator = []byte{34, 146, 160, 38, 51, 85, 58, 151}
+
+// DradexSwapAccounts lists the accounts of the dradexSwap instruction in IDL order
+type DradexSwapAccounts struct {
+	SwapProgram   solana.PublicKey
+	Pair          solana.PublicKey // writable
+	Market        solana.PublicKey // writable
+	EventQueue    solana.PublicKey // writable
+	DexUser       solana.PublicKey
+	MarketUser    solana.PublicKey // writable
+	Bids          solana.PublicKey // writable
+	Asks          solana.PublicKey // writable
+	T0Vault       solana.PublicKey // writable
+	T1Vault       solana.PublicKey // writable
+	T0User        solana.PublicKey // writable
+	T1User        solana.PublicKey // writable
+	Master        solana.PublicKey
+	Signer        solana.PublicKey // writable
+	SystemProgram solana.PublicKey
+	TokenProgram  solana.PublicKey
+	Logger        solana.PublicKey
+}
+
+// NewDradexSwapInstruction builds the dradexSwap instruction. Remaining accounts are appended after the IDL ones
+func NewDradexSwapInstruction(programID solana.PublicKey, accounts DradexSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(DradexSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dradexSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Pair, true, false),
+		solana.NewAccountMeta(accounts.Market, true, false),
+		solana.NewAccountMeta(accounts.EventQueue, true, false),
+		solana.NewAccountMeta(accounts.DexUser, false, false),
+		solana.NewAccountMeta(accounts.MarketUser, true, false),
+		solana.NewAccountMeta(accounts.Bids, true, false),
+		solana.NewAccountMeta(accounts.Asks, true, false),
+		solana.NewAccountMeta(accounts.T0Vault, true, false),
+		solana.NewAccountMeta(accounts.T1Vault, true, false),
+		solana.NewAccountMeta(accounts.T0User, true, false),
+		solana.NewAccountMeta(accounts.T1User, true, false),
+		solana.NewAccountMeta(accounts.Master, false, false),
+		solana.NewAccountMeta(accounts.Signer, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Logger, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeDradexSwapAccounts maps the account keys of the dradexSwap instruction by IDL order, ignoring remaining accounts
+func DecodeDradexSwapAccounts(keys []solana.PublicKey) (*DradexSwapAccounts, error) {
+	if len(keys) < 17 {
+		return nil, fmt.Errorf("dradexSwap needs 17 accounts, got %d", len(keys))
+	}
+	return &DradexSwapAccounts{
+		SwapProgram:   keys[0],
+		Pair:          keys[1],
+		Market:        keys[2],
+		EventQueue:    keys[3],
+		DexUser:       keys[4],
+		MarketUser:    keys[5],
+		Bids:          keys[6],
+		Asks:          keys[7],
+		T0Vault:       keys[8],
+		T1Vault:       keys[9],
+		T0User:        keys[10],
+		T1User:        keys[11],
+		Master:        keys[12],
+		Signer:        keys[13],
+		SystemProgram: keys[14],
+		TokenProgram:  keys[15],
+		Logger:        keys[16],
+	}, nil
+}
+
+// LifinityV2SwapInstructionDiscriminator prefixes the data of every lifinityV2Swap instruction
+var LifinityV2SwapInstructionDiscriminator = []byte{19, 152, 195, 245, 187, 144, 74, 227}
+
+// LifinityV2SwapAccounts lists the accounts of the lifinityV2Swap instruction in IDL order
+type LifinityV2SwapAccounts struct {
+	SwapProgram           solana.PublicKey
+	Authority             solana.PublicKey
+	Amm                   solana.PublicKey // writable
+	UserTransferAuthority solana.PublicKey
+	SourceInfo            solana.PublicKey // writable
+	DestinationInfo       solana.PublicKey // writable
+	SwapSource            solana.PublicKey // writable
+	SwapDestination       solana.PublicKey // writable
+	PoolMint              solana.PublicKey // writable
+	FeeAccount            solana.PublicKey // writable
+	TokenProgram          solana.PublicKey
+	OracleMainAccount     solana.PublicKey
+	OracleSubAccount      solana.PublicKey
+	OraclePcAccount       solana.PublicKey
+}
+
+// NewLifinityV2SwapInstruction builds the lifinityV2Swap instruction. Remaining accounts are appended after the IDL ones
+func NewLifinityV2SwapInstruction(programID solana.PublicKey, accounts LifinityV2SwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(LifinityV2SwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lifinityV2Swap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Authority, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.UserTransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.SourceInfo, true, false),
+		solana.NewAccountMeta(accounts.DestinationInfo, true, false),
+		solana.NewAccountMeta(accounts.SwapSource, true, false),
+		solana.NewAccountMeta(accounts.SwapDestination, true, false),
+		solana.NewAccountMeta(accounts.PoolMint, true, false),
+		solana.NewAccountMeta(accounts.FeeAccount, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.OracleMainAccount, false, false),
+		solana.NewAccountMeta(accounts.OracleSubAccount, false, false),
+		solana.NewAccountMeta(accounts.OraclePcAccount, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeLifinityV2SwapAccounts maps the account keys of the lifinityV2Swap instruction by IDL order, ignoring remaining accounts
+func DecodeLifinityV2SwapAccounts(keys []solana.PublicKey) (*LifinityV2SwapAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("lifinityV2Swap needs 14 accounts, got %d", len(keys))
+	}
+	return &LifinityV2SwapAccounts{
+		SwapProgram:           keys[0],
+		Authority:             keys[1],
+		Amm:                   keys[2],
+		UserTransferAuthority: keys[3],
+		SourceInfo:            keys[4],
+		DestinationInfo:       keys[5],
+		SwapSource:            keys[6],
+		SwapDestination:       keys[7],
+		PoolMint:              keys[8],
+		FeeAccount:            keys[9],
+		TokenProgram:          keys[10],
+		OracleMainAccount:     keys[11],
+		OracleSubAccount:      keys[12],
+		OraclePcAccount:       keys[13],
+	}, nil
+}
+
+// RaydiumClmmSwapInstructionDiscriminator prefixes the data of every raydiumClmmSwap instruction
+var RaydiumClmmSwapInstructionDiscriminator = []byte{47, 184, 213, 193, 35, 210, 87, 4}
+
+// RaydiumClmmSwapAccounts lists the accounts of the raydiumClmmSwap instruction in IDL order
+type RaydiumClmmSwapAccounts struct {
+	SwapProgram        solana.PublicKey
+	Payer              solana.PublicKey
+	AmmConfig          solana.PublicKey
+	PoolState          solana.PublicKey // writable
+	InputTokenAccount  solana.PublicKey // writable
+	OutputTokenAccount solana.PublicKey // writable
+	InputVault         solana.PublicKey // writable
+	OutputVault        solana.PublicKey // writable
+	ObservationState   solana.PublicKey // writable
+	TokenProgram       solana.PublicKey
+	TickArray          solana.PublicKey // writable
+}
+
+// NewRaydiumClmmSwapInstruction builds the raydiumClmmSwap instruction. Remaining accounts are appended after the IDL ones
+func NewRaydiumClmmSwapInstruction(programID solana.PublicKey, accounts RaydiumClmmSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(RaydiumClmmSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode raydiumClmmSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Payer, false, false),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.InputVault, true, false),
+		solana.NewAccountMeta(accounts.OutputVault, true, false),
+		solana.NewAccountMeta(accounts.ObservationState, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TickArray, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeRaydiumClmmSwapAccounts maps the account keys of the raydiumClmmSwap instruction by IDL order, ignoring remaining accounts
+func DecodeRaydiumClmmSwapAccounts(keys []solana.PublicKey) (*RaydiumClmmSwapAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("raydiumClmmSwap needs 11 accounts, got %d", len(keys))
+	}
+	return &RaydiumClmmSwapAccounts{
+		SwapProgram:        keys[0],
+		Payer:              keys[1],
+		AmmConfig:          keys[2],
+		PoolState:          keys[3],
+		InputTokenAccount:  keys[4],
+		OutputTokenAccount: keys[5],
+		InputVault:         keys[6],
+		OutputVault:        keys[7],
+		ObservationState:   keys[8],
+		TokenProgram:       keys[9],
+		TickArray:          keys[10],
+	}, nil
+}
+
+// PhoenixSwapInstructionDiscriminator prefixes the data of every phoenixSwap instruction
+var PhoenixSwapInstructionDiscriminator = []byte{99, 66, 223, 95, 236, 131, 26, 140}
+
+// PhoenixSwapAccounts lists the accounts of the phoenixSwap instruction in IDL order
+type PhoenixSwapAccounts struct {
+	SwapProgram  solana.PublicKey
+	LogAuthority solana.PublicKey
+	Market       solana.PublicKey // writable
+	Trader       solana.PublicKey
+	BaseAccount  solana.PublicKey // writable
+	QuoteAccount solana.PublicKey // writable
+	BaseVault    solana.PublicKey // writable
+	QuoteVault   solana.PublicKey // writable
+	TokenProgram solana.PublicKey
+}
+
+// NewPhoenixSwapInstruction builds the phoenixSwap instruction. Remaining accounts are appended after the IDL ones
+func NewPhoenixSwapInstruction(programID solana.PublicKey, accounts PhoenixSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(PhoenixSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode phoenixSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.LogAuthority, false, false),
+		solana.NewAccountMeta(accounts.Market, true, false),
+		solana.NewAccountMeta(accounts.Trader, false, false),
+		solana.NewAccountMeta(accounts.BaseAccount, true, false),
+		solana.NewAccountMeta(accounts.QuoteAccount, true, false),
+		solana.NewAccountMeta(accounts.BaseVault, true, false),
+		solana.NewAccountMeta(accounts.QuoteVault, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodePhoenixSwapAccounts maps the account keys of the phoenixSwap instruction by IDL order, ignoring remaining accounts
+func DecodePhoenixSwapAccounts(keys []solana.PublicKey) (*PhoenixSwapAccounts, error) {
+	if len(keys) < 9 {
+		return nil, fmt.Errorf("phoenixSwap needs 9 accounts, got %d", len(keys))
+	}
+	return &PhoenixSwapAccounts{
+		SwapProgram:  keys[0],
+		LogAuthority: keys[1],
+		Market:       keys[2],
+		Trader:       keys[3],
+		BaseAccount:  keys[4],
+		QuoteAccount: keys[5],
+		BaseVault:    keys[6],
+		QuoteVault:   keys[7],
+		TokenProgram: keys[8],
+	}, nil
+}
+
+// SymmetrySwapInstructionDiscriminator prefixes the data of every symmetrySwap instruction
+var SymmetrySwapInstructionDiscriminator = []byte{17, 114, 237, 234, 154, 12, 185, 116}
+
+// SymmetrySwapAccounts lists the accounts of the symmetrySwap instruction in IDL order
+type SymmetrySwapAccounts struct {
+	SwapProgram           solana.PublicKey
+	Buyer                 solana.PublicKey
+	FundState             solana.PublicKey // writable
+	PdaAccount            solana.PublicKey
+	PdaFromTokenAccount   solana.PublicKey // writable
+	BuyerFromTokenAccount solana.PublicKey // writable
+	PdaToTokenAccount     solana.PublicKey // writable
+	BuyerToTokenAccount   solana.PublicKey // writable
+	SwapFeeAccount        solana.PublicKey // writable
+	HostFeeAccount        solana.PublicKey // writable
+	ManagerFeeAccount     solana.PublicKey // writable
+	TokenList             solana.PublicKey
+	PrismData             solana.PublicKey
+	TokenProgram          solana.PublicKey
+}
+
+// NewSymmetrySwapInstruction builds the symmetrySwap instruction. Remaining accounts are appended after the IDL ones
+func NewSymmetrySwapInstruction(programID solana.PublicKey, accounts SymmetrySwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SymmetrySwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode symmetrySwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Buyer, false, false),
+		solana.NewAccountMeta(accounts.FundState, true, false),
+		solana.NewAccountMeta(accounts.PdaAccount, false, false),
+		solana.NewAccountMeta(accounts.PdaFromTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.BuyerFromTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PdaToTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.BuyerToTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SwapFeeAccount, true, false),
+		solana.NewAccountMeta(accounts.HostFeeAccount, true, false),
+		solana.NewAccountMeta(accounts.ManagerFeeAccount, true, false),
+		solana.NewAccountMeta(accounts.TokenList, false, false),
+		solana.NewAccountMeta(accounts.PrismData, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSymmetrySwapAccounts maps the account keys of the symmetrySwap instruction by IDL order, ignoring remaining accounts
+func DecodeSymmetrySwapAccounts(keys []solana.PublicKey) (*SymmetrySwapAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("symmetrySwap needs 14 accounts, got %d", len(keys))
+	}
+	return &SymmetrySwapAccounts{
+		SwapProgram:           keys[0],
+		Buyer:                 keys[1],
+		FundState:             keys[2],
+		PdaAccount:            keys[3],
+		PdaFromTokenAccount:   keys[4],
+		BuyerFromTokenAccount: keys[5],
+		PdaToTokenAccount:     keys[6],
+		BuyerToTokenAccount:   keys[7],
+		SwapFeeAccount:        keys[8],
+		HostFeeAccount:        keys[9],
+		ManagerFeeAccount:     keys[10],
+		TokenList:             keys[11],
+		PrismData:             keys[12],
+		TokenProgram:          keys[13],
+	}, nil
+}
+
+// HeliumTreasuryManagementRedeemV0InstructionDiscriminator prefixes the data of every heliumTreasuryManagementRedeemV0 instruction
+var HeliumTreasuryManagementRedeemV0InstructionDiscriminator = []byte{163, 159, 163, 25, 243, 161, 108, 74}
+
+// HeliumTreasuryManagementRedeemV0Accounts lists the accounts of the heliumTreasuryManagementRedeemV0 instruction in IDL order
+type HeliumTreasuryManagementRedeemV0Accounts struct {
+	SwapProgram           solana.PublicKey
+	TreasuryManagement    solana.PublicKey
+	TreasuryMint          solana.PublicKey
+	SupplyMint            solana.PublicKey // writable
+	Treasury              solana.PublicKey // writable
+	CircuitBreaker        solana.PublicKey // writable
+	From                  solana.PublicKey // writable
+	To                    solana.PublicKey // writable
+	Owner                 solana.PublicKey
+	CircuitBreakerProgram solana.PublicKey
+	TokenProgram          solana.PublicKey
+}
+
+// NewHeliumTreasuryManagementRedeemV0Instruction builds the heliumTreasuryManagementRedeemV0 instruction. Remaining accounts are appended after the IDL ones
+func NewHeliumTreasuryManagementRedeemV0Instruction(programID solana.PublicKey, accounts HeliumTreasuryManagementRedeemV0Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(HeliumTreasuryManagementRedeemV0InstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode heliumTreasuryManagementRedeemV0 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.TreasuryManagement, false, false),
+		solana.NewAccountMeta(accounts.TreasuryMint, false, false),
+		solana.NewAccountMeta(accounts.SupplyMint, true, false),
+		solana.NewAccountMeta(accounts.Treasury, true, false),
+		solana.NewAccountMeta(accounts.CircuitBreaker, true, false),
+		solana.NewAccountMeta(accounts.From, true, false),
+		solana.NewAccountMeta(accounts.To, true, false),
+		solana.NewAccountMeta(accounts.Owner, false, false),
+		solana.NewAccountMeta(accounts.CircuitBreakerProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeHeliumTreasuryManagementRedeemV0Accounts maps the account keys of the heliumTreasuryManagementRedeemV0 instruction by IDL order, ignoring remaining accounts
+func DecodeHeliumTreasuryManagementRedeemV0Accounts(keys []solana.PublicKey) (*HeliumTreasuryManagementRedeemV0Accounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("heliumTreasuryManagementRedeemV0 needs 11 accounts, got %d", len(keys))
+	}
+	return &HeliumTreasuryManagementRedeemV0Accounts{
+		SwapProgram:           keys[0],
+		TreasuryManagement:    keys[1],
+		TreasuryMint:          keys[2],
+		SupplyMint:            keys[3],
+		Treasury:              keys[4],
+		CircuitBreaker:        keys[5],
+		From:                  keys[6],
+		To:                    keys[7],
+		Owner:                 keys[8],
+		CircuitBreakerProgram: keys[9],
+		TokenProgram:          keys[10],
+	}, nil
+}
+
+// GoosefxV2SwapInstructionDiscriminator prefixes the data of every goosefxV2Swap instruction
+var GoosefxV2SwapInstructionDiscriminator = []byte{178, 108, 208, 137, 154, 194, 168, 213}
+
+// GoosefxV2SwapAccounts lists the accounts of the goosefxV2Swap instruction in IDL order
+type GoosefxV2SwapAccounts struct {
+	SwapProgram             solana.PublicKey
+	Pair                    solana.PublicKey // writable
+	PoolRegistry            solana.PublicKey // writable
+	UserWallet              solana.PublicKey
+	SslPoolInSigner         solana.PublicKey
+	SslPoolOutSigner        solana.PublicKey
+	UserAtaIn               solana.PublicKey // writable
+	UserAtaOut              solana.PublicKey // writable
+	SslOutMainVault         solana.PublicKey // writable
+	SslOutSecondaryVault    solana.PublicKey // writable
+	SslInMainVault          solana.PublicKey // writable
+	SslInSecondaryVault     solana.PublicKey // writable
+	SslOutFeeVault          solana.PublicKey // writable
+	FeeDestination          solana.PublicKey // writable
+	OutputTokenPriceHistory solana.PublicKey // writable
+	OutputTokenOracle       solana.PublicKey
+	InputTokenPriceHistory  solana.PublicKey // writable
+	InputTokenOracle        solana.PublicKey
+	EventEmitter            solana.PublicKey // writable
+	TokenProgram            solana.PublicKey
+}
+
+// NewGoosefxV2SwapInstruction builds the goosefxV2Swap instruction. Remaining accounts are appended after the IDL ones
+func NewGoosefxV2SwapInstruction(programID solana.PublicKey, accounts GoosefxV2SwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(GoosefxV2SwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode goosefxV2Swap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Pair, true, false),
+		solana.NewAccountMeta(accounts.PoolRegistry, true, false),
+		solana.NewAccountMeta(accounts.UserWallet, false, false),
+		solana.NewAccountMeta(accounts.SslPoolInSigner, false, false),
+		solana.NewAccountMeta(accounts.SslPoolOutSigner, false, false),
+		solana.NewAccountMeta(accounts.UserAtaIn, true, false),
+		solana.NewAccountMeta(accounts.UserAtaOut, true, false),
+		solana.NewAccountMeta(accounts.SslOutMainVault, true, false),
+		solana.NewAccountMeta(accounts.SslOutSecondaryVault, true, false),
+		solana.NewAccountMeta(accounts.SslInMainVault, true, false),
+		solana.NewAccountMeta(accounts.SslInSecondaryVault, true, false),
+		solana.NewAccountMeta(accounts.SslOutFeeVault, true, false),
+		solana.NewAccountMeta(accounts.FeeDestination, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenPriceHistory, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenOracle, false, false),
+		solana.NewAccountMeta(accounts.InputTokenPriceHistory, true, false),
+		solana.NewAccountMeta(accounts.InputTokenOracle, false, false),
+		solana.NewAccountMeta(accounts.EventEmitter, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeGoosefxV2SwapAccounts maps the account keys of the goosefxV2Swap instruction by IDL order, ignoring remaining accounts
+func DecodeGoosefxV2SwapAccounts(keys []solana.PublicKey) (*GoosefxV2SwapAccounts, error) {
+	if len(keys) < 20 {
+		return nil, fmt.Errorf("goosefxV2Swap needs 20 accounts, got %d", len(keys))
+	}
+	return &GoosefxV2SwapAccounts{
+		SwapProgram:             keys[0],
+		Pair:                    keys[1],
+		PoolRegistry:            keys[2],
+		UserWallet:              keys[3],
+		SslPoolInSigner:         keys[4],
+		SslPoolOutSigner:        keys[5],
+		UserAtaIn:               keys[6],
+		UserAtaOut:              keys[7],
+		SslOutMainVault:         keys[8],
+		SslOutSecondaryVault:    keys[9],
+		SslInMainVault:          keys[10],
+		SslInSecondaryVault:     keys[11],
+		SslOutFeeVault:          keys[12],
+		FeeDestination:          keys[13],
+		OutputTokenPriceHistory: keys[14],
+		OutputTokenOracle:       keys[15],
+		InputTokenPriceHistory:  keys[16],
+		InputTokenOracle:        keys[17],
+		EventEmitter:            keys[18],
+		TokenProgram:            keys[19],
+	}, nil
+}
+
+// PerpsSwapInstructionDiscriminator prefixes the data of every perpsSwap instruction
+var PerpsSwapInstructionDiscriminator = []byte{147, 22, 108, 178, 110, 18, 171, 34}
+
+// PerpsSwapAccounts lists the accounts of the perpsSwap instruction in IDL order
+type PerpsSwapAccounts struct {
+	SwapProgram                    solana.PublicKey
+	Owner                          solana.PublicKey // writable
+	FundingAccount                 solana.PublicKey // writable
+	ReceivingAccount               solana.PublicKey // writable
+	TransferAuthority              solana.PublicKey
+	Perpetuals                     solana.PublicKey
+	Pool                           solana.PublicKey // writable
+	ReceivingCustody               solana.PublicKey // writable
+	ReceivingCustodyOracleAccount  solana.PublicKey
+	ReceivingCustodyTokenAccount   solana.PublicKey // writable
+	DispensingCustody              solana.PublicKey // writable
+	DispensingCustodyOracleAccount solana.PublicKey
+	DispensingCustodyTokenAccount  solana.PublicKey // writable
+	TokenProgram                   solana.PublicKey
+	EventAuthority                 solana.PublicKey
+	Program                        solana.PublicKey
+}
+
+// NewPerpsSwapInstruction builds the perpsSwap instruction. Remaining accounts are appended after the IDL ones
+func NewPerpsSwapInstruction(programID solana.PublicKey, accounts PerpsSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(PerpsSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode perpsSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Owner, true, false),
+		solana.NewAccountMeta(accounts.FundingAccount, true, false),
+		solana.NewAccountMeta(accounts.ReceivingAccount, true, false),
+		solana.NewAccountMeta(accounts.TransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Perpetuals, false, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.ReceivingCustody, true, false),
+		solana.NewAccountMeta(accounts.ReceivingCustodyOracleAccount, false, false),
+		solana.NewAccountMeta(accounts.ReceivingCustodyTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DispensingCustody, true, false),
+		solana.NewAccountMeta(accounts.DispensingCustodyOracleAccount, false, false),
+		solana.NewAccountMeta(accounts.DispensingCustodyTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodePerpsSwapAccounts maps the account keys of the perpsSwap instruction by IDL order, ignoring remaining accounts
+func DecodePerpsSwapAccounts(keys []solana.PublicKey) (*PerpsSwapAccounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("perpsSwap needs 16 accounts, got %d", len(keys))
+	}
+	return &PerpsSwapAccounts{
+		SwapProgram:                    keys[0],
+		Owner:                          keys[1],
+		FundingAccount:                 keys[2],
+		ReceivingAccount:               keys[3],
+		TransferAuthority:              keys[4],
+		Perpetuals:                     keys[5],
+		Pool:                           keys[6],
+		ReceivingCustody:               keys[7],
+		ReceivingCustodyOracleAccount:  keys[8],
+		ReceivingCustodyTokenAccount:   keys[9],
+		DispensingCustody:              keys[10],
+		DispensingCustodyOracleAccount: keys[11],
+		DispensingCustodyTokenAccount:  keys[12],
+		TokenProgram:                   keys[13],
+		EventAuthority:                 keys[14],
+		Program:                        keys[15],
+	}, nil
+}
+
+// PerpsAddLiquidityInstructionDiscriminator prefixes the data of every perpsAddLiquidity instruction
+var PerpsAddLiquidityInstructionDiscriminator = []byte{170, 238, 222, 214, 245, 202, 108, 155}
+
+// PerpsAddLiquidityAccounts lists the accounts of the perpsAddLiquidity instruction in IDL order
+type PerpsAddLiquidityAccounts struct {
+	SwapProgram               solana.PublicKey
+	Owner                     solana.PublicKey // writable
+	FundingOrReceivingAccount solana.PublicKey // writable
+	LpTokenAccount            solana.PublicKey // writable
+	TransferAuthority         solana.PublicKey
+	Perpetuals                solana.PublicKey
+	Pool                      solana.PublicKey // writable
+	Custody                   solana.PublicKey // writable
+	CustodyOracleAccount      solana.PublicKey
+	CustodyTokenAccount       solana.PublicKey // writable
+	LpTokenMint               solana.PublicKey // writable
+	TokenProgram              solana.PublicKey
+	EventAuthority            solana.PublicKey
+	Program                   solana.PublicKey
+}
+
+// NewPerpsAddLiquidityInstruction builds the perpsAddLiquidity instruction. Remaining accounts are appended after the IDL ones
+func NewPerpsAddLiquidityInstruction(programID solana.PublicKey, accounts PerpsAddLiquidityAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(PerpsAddLiquidityInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode perpsAddLiquidity args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Owner, true, false),
+		solana.NewAccountMeta(accounts.FundingOrReceivingAccount, true, false),
+		solana.NewAccountMeta(accounts.LpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.TransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Perpetuals, false, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.Custody, true, false),
+		solana.NewAccountMeta(accounts.CustodyOracleAccount, false, false),
+		solana.NewAccountMeta(accounts.CustodyTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.LpTokenMint, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodePerpsAddLiquidityAccounts maps the account keys of the perpsAddLiquidity instruction by IDL order, ignoring remaining accounts
+func DecodePerpsAddLiquidityAccounts(keys []solana.PublicKey) (*PerpsAddLiquidityAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("perpsAddLiquidity needs 14 accounts, got %d", len(keys))
+	}
+	return &PerpsAddLiquidityAccounts{
+		SwapProgram:               keys[0],
+		Owner:                     keys[1],
+		FundingOrReceivingAccount: keys[2],
+		LpTokenAccount:            keys[3],
+		TransferAuthority:         keys[4],
+		Perpetuals:                keys[5],
+		Pool:                      keys[6],
+		Custody:                   keys[7],
+		CustodyOracleAccount:      keys[8],
+		CustodyTokenAccount:       keys[9],
+		LpTokenMint:               keys[10],
+		TokenProgram:              keys[11],
+		EventAuthority:            keys[12],
+		Program:                   keys[13],
+	}, nil
+}
+
+// PerpsRemoveLiquidityInstructionDiscriminator prefixes the data of every perpsRemoveLiquidity instruction
+var PerpsRemoveLiquidityInstructionDiscriminator = []byte{79, 211, 232, 140, 8, 78, 220, 34}
+
+// PerpsRemoveLiquidityAccounts lists the accounts of the perpsRemoveLiquidity instruction in IDL order
+type PerpsRemoveLiquidityAccounts struct {
+	SwapProgram               solana.PublicKey
+	Owner                     solana.PublicKey // writable
+	FundingOrReceivingAccount solana.PublicKey // writable
+	LpTokenAccount            solana.PublicKey // writable
+	TransferAuthority         solana.PublicKey
+	Perpetuals                solana.PublicKey
+	Pool                      solana.PublicKey // writable
+	Custody                   solana.PublicKey // writable
+	CustodyOracleAccount      solana.PublicKey
+	CustodyTokenAccount       solana.PublicKey // writable
+	LpTokenMint               solana.PublicKey // writable
+	TokenProgram              solana.PublicKey
+	EventAuthority            solana.PublicKey
+	Program                   solana.PublicKey
+}
+
+// NewPerpsRemoveLiquidityInstruction builds the perpsRemoveLiquidity instruction. Remaining accounts are appended after the IDL ones
+func NewPerpsRemoveLiquidityInstruction(programID solana.PublicKey, accounts PerpsRemoveLiquidityAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(PerpsRemoveLiquidityInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode perpsRemoveLiquidity args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.Owner, true, false),
+		solana.NewAccountMeta(accounts.FundingOrReceivingAccount, true, false),
+		solana.NewAccountMeta(accounts.LpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.TransferAuthority, false, false),
+		solana.NewAccountMeta(accounts.Perpetuals, false, false),
+		solana.NewAccountMeta(accounts.Pool, true, false),
+		solana.NewAccountMeta(accounts.Custody, true, false),
+		solana.NewAccountMeta(accounts.CustodyOracleAccount, false, false),
+		solana.NewAccountMeta(accounts.CustodyTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.LpTokenMint, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodePerpsRemoveLiquidityAccounts maps the account keys of the perpsRemoveLiquidity instruction by IDL order, ignoring remaining accounts
+func DecodePerpsRemoveLiquidityAccounts(keys []solana.PublicKey) (*PerpsRemoveLiquidityAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("perpsRemoveLiquidity needs 14 accounts, got %d", len(keys))
+	}
+	return &PerpsRemoveLiquidityAccounts{
+		SwapProgram:               keys[0],
+		Owner:                     keys[1],
+		FundingOrReceivingAccount: keys[2],
+		LpTokenAccount:            keys[3],
+		TransferAuthority:         keys[4],
+		Perpetuals:                keys[5],
+		Pool:                      keys[6],
+		Custody:                   keys[7],
+		CustodyOracleAccount:      keys[8],
+		CustodyTokenAccount:       keys[9],
+		LpTokenMint:               keys[10],
+		TokenProgram:              keys[11],
+		EventAuthority:            keys[12],
+		Program:                   keys[13],
+	}, nil
+}
+
+// MeteoraDlmmSwapInstructionDiscriminator prefixes the data of every meteoraDlmmSwap instruction
+var MeteoraDlmmSwapInstructionDiscriminator = []byte{127, 64, 37, 138, 173, 243, 207, 84}
+
+// MeteoraDlmmSwapAccounts lists the accounts of the meteoraDlmmSwap instruction in IDL order
+type MeteoraDlmmSwapAccounts struct {
+	SwapProgram             solana.PublicKey
+	LbPair                  solana.PublicKey // writable
+	BinArrayBitmapExtension solana.PublicKey
+	ReserveX                solana.PublicKey // writable
+	ReserveY                solana.PublicKey // writable
+	UserTokenIn             solana.PublicKey // writable
+	UserTokenOut            solana.PublicKey // writable
+	TokenXMint              solana.PublicKey
+	TokenYMint              solana.PublicKey
+	Oracle                  solana.PublicKey // writable
+	HostFeeIn               solana.PublicKey
+	User                    solana.PublicKey
+	TokenXProgram           solana.PublicKey
+	TokenYProgram           solana.PublicKey
+	EventAuthority          solana.PublicKey
+	Program                 solana.PublicKey
+}
+
+// NewMeteoraDlmmSwapInstruction builds the meteoraDlmmSwap instruction. Remaining accounts are appended after the IDL ones
+func NewMeteoraDlmmSwapInstruction(programID solana.PublicKey, accounts MeteoraDlmmSwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MeteoraDlmmSwapInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode meteoraDlmmSwap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.SwapProgram, false, false),
+		solana.NewAccountMeta(accounts.LbPair, true, false),
+		solana.NewAccountMeta(accounts.BinArrayBitmapExtension, false, false),
+		solana.NewAccountMeta(accounts.ReserveX, true, false),
+		solana.NewAccountMeta(accounts.ReserveY, true, false),
+		solana.NewAccountMeta(accounts.UserTokenIn, true, false),
+		solana.NewAccountMeta(accounts.UserTokenOut, true, false),
+		solana.NewAccountMeta(accounts.TokenXMint, false, false),
+		solana.NewAccountMeta(accounts.TokenYMint, false, false),
+		solana.NewAccountMeta(accounts.Oracle, true, false),
+		solana.NewAccountMeta(accounts.HostFeeIn, false, false),
+		solana.NewAccountMeta(accounts.User, false, false),
+		solana.NewAccountMeta(accounts.TokenXProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenYProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMeteoraDlmmSwapAccounts maps the account keys of the meteoraDlmmSwap instruction by IDL order, ignoring remaining accounts
+func DecodeMeteoraDlmmSwapAccounts(keys []solana.PublicKey) (*MeteoraDlmmSwapAccounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("meteoraDlmmSwap needs 16 accounts, got %d", len(keys))
+	}
+	return &MeteoraDlmmSwapAccounts{
+		SwapProgram:             keys[0],
+		LbPair:                  keys[1],
+		BinArrayBitmapExtension: keys[2],
+		ReserveX:                keys[3],
+		ReserveY:                keys[4],
+		UserTokenIn:             keys[5],
+		UserTokenOut:            keys[6],
+		TokenXMint:              keys[7],
+		TokenYMint:              keys[8],
+		Oracle:                  keys[9],
+		HostFeeIn:               keys[10],
+		User:                    keys[11],
+		TokenXProgram:           keys[12],
+		TokenYProgram:           keys[13],
+		EventAuthority:          keys[14],
+		Program:                 keys[15],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
@@ -0,0 +1,268 @@
+// Code generated by idlgen from meteora_dlmm_subset_idl.json. DO NOT EDIT.
+
+// Package meteoradlmm holds typed bindings for the lb_clmm program, generated from a hand-written subset of its
+// IDL. Instructions, accounts, types and events missing from the subset have no bindings.
+package meteoradlmm
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// ProgramID is the address of the lb_clmm program recorded in the IDL
+var ProgramID = solana.MustPublicKeyFromBase58("LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo")
+
+// StaticParameters is the StaticParameters type of the lb_clmm IDL
+// Parameter that set by the protocol
+type StaticParameters struct {
+	BaseFactor               uint16
+	FilterPeriod             uint16
+	DecayPeriod              uint16
+	ReductionFactor          uint16
+	VariableFeeControl       uint32
+	MaxVolatilityAccumulator uint32
+	MinBinId                 int32
+	MaxBinId                 int32
+	ProtocolShare            uint16
+	BaseFeePowerFactor       uint8
+	Padding                  [5]uint8
+}
+
+// VariableParameters is the VariableParameters type of the lb_clmm IDL
+// Parameters that changes based on dynamic of the market
+type VariableParameters struct {
+	VolatilityAccumulator uint32
+	VolatilityReference   uint32
+	IndexReference        int32
+	Padding               [4]uint8
+	LastUpdateTimestamp   int64
+	Padding1              [8]uint8
+}
+
+// ProtocolFee is the ProtocolFee type of the lb_clmm IDL
+type ProtocolFee struct {
+	AmountX uint64
+	AmountY uint64
+}
+
+// RewardInfo is the RewardInfo type of the lb_clmm IDL
+// Stores the state relevant for tracking liquidity mining rewards
+type RewardInfo struct {
+	Mint                                      solana.PublicKey
+	Vault                                     solana.PublicKey
+	Funder                                    solana.PublicKey
+	RewardDuration                            uint64
+	RewardDurationEnd                         uint64
+	RewardRate                                bin.Uint128
+	LastUpdateTime                            uint64
+	CumulativeSecondsWithEmptyLiquidityReward uint64
+}
+
+// Bin is the Bin type of the lb_clmm IDL
+type Bin struct {
+	AmountX                  uint64
+	AmountY                  uint64
+	Price                    bin.Uint128
+	LiquiditySupply          bin.Uint128
+	RewardPerTokenStored     [2]bin.Uint128
+	FeeAmountXPerTokenStored bin.Uint128
+	FeeAmountYPerTokenStored bin.Uint128
+	AmountXIn                bin.Uint128
+	AmountYIn                bin.Uint128
+}
+
+// LbPair is the LbPair account of the lb_clmm IDL, without its discriminator
+type LbPair struct {
+	Parameters               StaticParameters
+	VParameters              VariableParameters
+	BumpSeed                 [1]uint8
+	BinStepSeed              [2]uint8
+	PairType                 uint8
+	ActiveId                 int32
+	BinStep                  uint16
+	Status                   uint8
+	RequireBaseFactorSeed    uint8
+	BaseFactorSeed           [2]uint8
+	ActivationType           uint8
+	CreatorPoolOnOffControl  uint8
+	TokenXMint               solana.PublicKey
+	TokenYMint               solana.PublicKey
+	ReserveX                 solana.PublicKey
+	ReserveY                 solana.PublicKey
+	ProtocolFee              ProtocolFee
+	Padding1                 [32]uint8
+	RewardInfos              [2]RewardInfo
+	Oracle                   solana.PublicKey
+	BinArrayBitmap           [16]uint64
+	LastUpdatedAt            int64
+	Padding2                 [32]uint8
+	PreActivationSwapAddress solana.PublicKey
+	BaseKey                  solana.PublicKey
+	ActivationPoint          uint64
+	PreActivationDuration    uint64
+	Padding3                 [8]uint8
+	Padding4                 uint64
+	Creator                  solana.PublicKey
+	TokenMintXProgramFlag    uint8
+	TokenMintYProgramFlag    uint8
+	Reserved                 [22]uint8
+}
+
+// LbPairDiscriminator prefixes every LbPair account
+var LbPairDiscriminator = []byte{33, 11, 49, 98, 181, 101, 177, 13}
+
+// DecodeLbPair decodes the raw data of a LbPair account
+func DecodeLbPair(data []byte) (*LbPair, error) {
+	var account LbPair
+	if err := decode(data, LbPairDiscriminator, "LbPair", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// BinArray is the BinArray account of the lb_clmm IDL, without its discriminator
+// An account to contain a range of bin. For example: Bin 100 <-> 200.
+type BinArray struct {
+	Index   int64
+	Version uint8
+	Padding [7]uint8
+	LbPair  solana.PublicKey
+	Bins    [70]Bin
+}
+
+// BinArrayDiscriminator prefixes every BinArray account
+var BinArrayDiscriminator = []byte{92, 142, 92, 220, 5, 148, 70, 181}
+
+// DecodeBinArray decodes the raw data of a BinArray account
+func DecodeBinArray(data []byte) (*BinArray, error) {
+	var account BinArray
+	if err := decode(data, BinArrayDiscriminator, "BinArray", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// SwapInstructionDiscriminator prefixes the data of every swap instruction
+var SwapInstructionDiscriminator = []byte{248, 198, 158, 145, 225, 117, 135, 200}
+
+// SwapArgs holds the arguments of the swap instruction
+type SwapArgs struct {
+	AmountIn     uint64
+	MinAmountOut uint64
+}
+
+// SwapAccounts lists the accounts of the swap instruction in IDL order
+type SwapAccounts struct {
+	LbPair                  solana.PublicKey // writable
+	BinArrayBitmapExtension solana.PublicKey // optional
+	ReserveX                solana.PublicKey // writable
+	ReserveY                solana.PublicKey // writable
+	UserTokenIn             solana.PublicKey // writable
+	UserTokenOut            solana.PublicKey // writable
+	TokenXMint              solana.PublicKey
+	TokenYMint              solana.PublicKey
+	Oracle                  solana.PublicKey // writable
+	HostFeeIn               solana.PublicKey // writable, optional
+	User                    solana.PublicKey // signer
+	TokenXProgram           solana.PublicKey
+	TokenYProgram           solana.PublicKey
+	EventAuthority          solana.PublicKey
+	Program                 solana.PublicKey
+}
+
+// NewSwapInstruction builds the swap instruction. Remaining accounts are appended after the IDL ones,
+// and optional accounts left empty are replaced by the program ID as Anchor expects
+func NewSwapInstruction(programID solana.PublicKey, args SwapArgs, accounts SwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.LbPair, true, false),
+		solana.NewAccountMeta(optionalAccount(accounts.BinArrayBitmapExtension, programID), false, false),
+		solana.NewAccountMeta(accounts.ReserveX, true, false),
+		solana.NewAccountMeta(accounts.ReserveY, true, false),
+		solana.NewAccountMeta(accounts.UserTokenIn, true, false),
+		solana.NewAccountMeta(accounts.UserTokenOut, true, false),
+		solana.NewAccountMeta(accounts.TokenXMint, false, false),
+		solana.NewAccountMeta(accounts.TokenYMint, false, false),
+		solana.NewAccountMeta(accounts.Oracle, true, false),
+		solana.NewAccountMeta(optionalAccount(accounts.HostFeeIn, programID), true, false),
+		solana.NewAccountMeta(accounts.User, false, true),
+		solana.NewAccountMeta(accounts.TokenXProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenYProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapArgs decodes the data of the swap instruction
+func DecodeSwapArgs(data []byte) (*SwapArgs, error) {
+	var args SwapArgs
+	if err := decode(data, SwapInstructionDiscriminator, "swap", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapAccounts maps the account keys of the swap instruction by IDL order, ignoring remaining accounts
+func DecodeSwapAccounts(keys []solana.PublicKey) (*SwapAccounts, error) {
+	if len(keys) < 15 {
+		return nil, fmt.Errorf("swap needs 15 accounts, got %d", len(keys))
+	}
+	return &SwapAccounts{
+		LbPair:                  keys[0],
+		BinArrayBitmapExtension: keys[1],
+		ReserveX:                keys[2],
+		ReserveY:                keys[3],
+		UserTokenIn:             keys[4],
+		UserTokenOut:            keys[5],
+		TokenXMint:              keys[6],
+		TokenYMint:              keys[7],
+		Oracle:                  keys[8],
+		HostFeeIn:               keys[9],
+		User:                    keys[10],
+		TokenXProgram:           keys[11],
+		TokenYProgram:           keys[12],
+		EventAuthority:          keys[13],
+		Program:                 keys[14],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
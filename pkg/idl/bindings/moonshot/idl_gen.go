@@ -0,0 +1,610 @@
+// Code generated by idlgen from moonshot_idl.json. DO NOT EDIT.
+
+// Package moonshot holds typed bindings for the token_launchpad program, generated from its IDL.
+package moonshot
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// TokenMintParams is the TokenMintParams type of the token_launchpad IDL
+type TokenMintParams struct {
+	Name               string
+	Symbol             string
+	Uri                string
+	Decimals           uint8
+	CollateralCurrency uint8
+	Amount             uint64
+	CurveType          uint8
+}
+
+// TradeParams is the TradeParams type of the token_launchpad IDL
+type TradeParams struct {
+	Amount           uint64
+	CollateralAmount uint64
+	SlippageBps      uint64
+}
+
+// ConfigParams is the ConfigParams type of the token_launchpad IDL
+type ConfigParams struct {
+	MigrationAuthority        *solana.PublicKey `bin:"optional"`
+	BackendAuthority          *solana.PublicKey `bin:"optional"`
+	ConfigAuthority           *solana.PublicKey `bin:"optional"`
+	HelioFee                  *solana.PublicKey `bin:"optional"`
+	DexFee                    *solana.PublicKey `bin:"optional"`
+	FeeBps                    *uint16           `bin:"optional"`
+	DexFeeShare               *uint8            `bin:"optional"`
+	MigrationFee              *uint64           `bin:"optional"`
+	MarketcapThreshold        *uint64           `bin:"optional"`
+	MarketcapCurrency         *uint8            `bin:"optional"`
+	MinSupportedDecimalPlaces *uint8            `bin:"optional"`
+	MaxSupportedDecimalPlaces *uint8            `bin:"optional"`
+	MinSupportedTokenSupply   *uint64           `bin:"optional"`
+	MaxSupportedTokenSupply   *uint64           `bin:"optional"`
+	CoefB                     *uint32           `bin:"optional"`
+}
+
+// Currency is the Currency enum of the token_launchpad IDL
+type Currency uint8
+
+const (
+	CurrencySol Currency = iota
+)
+
+// CurveType is the CurveType enum of the token_launchpad IDL
+type CurveType uint8
+
+const (
+	CurveTypeLinearV1 CurveType = iota
+)
+
+// TradeType is the TradeType enum of the token_launchpad IDL
+type TradeType uint8
+
+const (
+	TradeTypeBuy TradeType = iota
+	TradeTypeSell
+)
+
+// ConfigAccount is the ConfigAccount account of the token_launchpad IDL, without its discriminator
+type ConfigAccount struct {
+	MigrationAuthority        solana.PublicKey
+	BackendAuthority          solana.PublicKey
+	ConfigAuthority           solana.PublicKey
+	HelioFee                  solana.PublicKey
+	DexFee                    solana.PublicKey
+	FeeBps                    uint16
+	DexFeeShare               uint8
+	MigrationFee              uint64
+	MarketcapThreshold        uint64
+	MarketcapCurrency         Currency
+	MinSupportedDecimalPlaces uint8
+	MaxSupportedDecimalPlaces uint8
+	MinSupportedTokenSupply   uint64
+	MaxSupportedTokenSupply   uint64
+	Bump                      uint8
+	CoefB                     uint32
+}
+
+// ConfigAccountDiscriminator prefixes every ConfigAccount account
+var ConfigAccountDiscriminator = []byte{189, 255, 97, 70, 186, 189, 24, 102}
+
+// DecodeConfigAccount decodes the raw data of a ConfigAccount account
+func DecodeConfigAccount(data []byte) (*ConfigAccount, error) {
+	var account ConfigAccount
+	if err := decode(data, ConfigAccountDiscriminator, "ConfigAccount", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CurveAccount is the CurveAccount account of the token_launchpad IDL, without its discriminator
+type CurveAccount struct {
+	TotalSupply        uint64
+	CurveAmount        uint64
+	Mint               solana.PublicKey
+	Decimals           uint8
+	CollateralCurrency Currency
+	CurveType          CurveType
+	MarketcapThreshold uint64
+	MarketcapCurrency  Currency
+	MigrationFee       uint64
+	CoefB              uint32
+	Bump               uint8
+}
+
+// CurveAccountDiscriminator prefixes every CurveAccount account
+var CurveAccountDiscriminator = []byte{8, 91, 83, 28, 132, 216, 248, 22}
+
+// DecodeCurveAccount decodes the raw data of a CurveAccount account
+func DecodeCurveAccount(data []byte) (*CurveAccount, error) {
+	var account CurveAccount
+	if err := decode(data, CurveAccountDiscriminator, "CurveAccount", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// TradeEvent is the TradeEvent event of the token_launchpad IDL
+type TradeEvent struct {
+	Amount           uint64
+	CollateralAmount uint64
+	DexFee           uint64
+	HelioFee         uint64
+	Allocation       uint64
+	Curve            solana.PublicKey
+	CostToken        solana.PublicKey
+	Sender           solana.PublicKey
+	Type             TradeType
+	Label            string
+}
+
+// TradeEventDiscriminator prefixes every TradeEvent event
+var TradeEventDiscriminator = []byte{189, 219, 127, 211, 78, 230, 97, 238}
+
+// DecodeTradeEvent decodes a TradeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeTradeEvent(data []byte) (*TradeEvent, error) {
+	var event TradeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), TradeEventDiscriminator, "TradeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// MigrationEvent is the MigrationEvent event of the token_launchpad IDL
+type MigrationEvent struct {
+	TokensMigrated     uint64
+	TokensBurned       uint64
+	CollateralMigrated uint64
+	Fee                uint64
+	Label              string
+}
+
+// MigrationEventDiscriminator prefixes every MigrationEvent event
+var MigrationEventDiscriminator = []byte{255, 202, 76, 147, 91, 231, 73, 22}
+
+// DecodeMigrationEvent decodes a MigrationEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeMigrationEvent(data []byte) (*MigrationEvent, error) {
+	var event MigrationEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), MigrationEventDiscriminator, "MigrationEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// TokenMintInstructionDiscriminator prefixes the data of every tokenMint instruction
+var TokenMintInstructionDiscriminator = []byte{3, 44, 164, 184, 123, 13, 245, 179}
+
+// TokenMintArgs holds the arguments of the tokenMint instruction
+type TokenMintArgs struct {
+	MintParams TokenMintParams
+}
+
+// TokenMintAccounts lists the accounts of the tokenMint instruction in IDL order
+type TokenMintAccounts struct {
+	Sender           solana.PublicKey // writable, signer
+	BackendAuthority solana.PublicKey // signer
+	CurveAccount     solana.PublicKey // writable
+	Mint             solana.PublicKey // writable, signer
+	// Type validating that the account is owned by the System Program = uninitialized
+	// seeds should ensure that the address is correct
+	MintMetadata           solana.PublicKey // writable
+	CurveTokenAccount      solana.PublicKey // writable
+	ConfigAccount          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	MplTokenMetadata       solana.PublicKey
+	SystemProgram          solana.PublicKey
+}
+
+// NewTokenMintInstruction builds the tokenMint instruction. Remaining accounts are appended after the IDL ones
+func NewTokenMintInstruction(programID solana.PublicKey, args TokenMintArgs, accounts TokenMintAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(TokenMintInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tokenMint args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Sender, true, true),
+		solana.NewAccountMeta(accounts.BackendAuthority, false, true),
+		solana.NewAccountMeta(accounts.CurveAccount, true, false),
+		solana.NewAccountMeta(accounts.Mint, true, true),
+		solana.NewAccountMeta(accounts.MintMetadata, true, false),
+		solana.NewAccountMeta(accounts.CurveTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.ConfigAccount, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.MplTokenMetadata, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeTokenMintArgs decodes the data of the tokenMint instruction
+func DecodeTokenMintArgs(data []byte) (*TokenMintArgs, error) {
+	var args TokenMintArgs
+	if err := decode(data, TokenMintInstructionDiscriminator, "tokenMint", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeTokenMintAccounts maps the account keys of the tokenMint instruction by IDL order, ignoring remaining accounts
+func DecodeTokenMintAccounts(keys []solana.PublicKey) (*TokenMintAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("tokenMint needs 11 accounts, got %d", len(keys))
+	}
+	return &TokenMintAccounts{
+		Sender:                 keys[0],
+		BackendAuthority:       keys[1],
+		CurveAccount:           keys[2],
+		Mint:                   keys[3],
+		MintMetadata:           keys[4],
+		CurveTokenAccount:      keys[5],
+		ConfigAccount:          keys[6],
+		TokenProgram:           keys[7],
+		AssociatedTokenProgram: keys[8],
+		MplTokenMetadata:       keys[9],
+		SystemProgram:          keys[10],
+	}, nil
+}
+
+// BuyInstructionDiscriminator prefixes the data of every buy instruction
+var BuyInstructionDiscriminator = []byte{102, 6, 61, 18, 1, 218, 235, 234}
+
+// BuyArgs holds the arguments of the buy instruction
+type BuyArgs struct {
+	Data TradeParams
+}
+
+// BuyAccounts lists the accounts of the buy instruction in IDL order
+type BuyAccounts struct {
+	Sender                 solana.PublicKey // writable, signer
+	BackendAuthority       solana.PublicKey // signer
+	SenderTokenAccount     solana.PublicKey // writable
+	CurveAccount           solana.PublicKey // writable
+	CurveTokenAccount      solana.PublicKey // writable
+	DexFee                 solana.PublicKey // writable
+	HelioFee               solana.PublicKey // writable
+	Mint                   solana.PublicKey
+	ConfigAccount          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	SystemProgram          solana.PublicKey
+}
+
+// NewBuyInstruction builds the buy instruction. Remaining accounts are appended after the IDL ones
+func NewBuyInstruction(programID solana.PublicKey, args BuyArgs, accounts BuyAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(BuyInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode buy args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Sender, true, true),
+		solana.NewAccountMeta(accounts.BackendAuthority, false, true),
+		solana.NewAccountMeta(accounts.SenderTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.CurveAccount, true, false),
+		solana.NewAccountMeta(accounts.CurveTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DexFee, true, false),
+		solana.NewAccountMeta(accounts.HelioFee, true, false),
+		solana.NewAccountMeta(accounts.Mint, false, false),
+		solana.NewAccountMeta(accounts.ConfigAccount, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeBuyArgs decodes the data of the buy instruction
+func DecodeBuyArgs(data []byte) (*BuyArgs, error) {
+	var args BuyArgs
+	if err := decode(data, BuyInstructionDiscriminator, "buy", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeBuyAccounts maps the account keys of the buy instruction by IDL order, ignoring remaining accounts
+func DecodeBuyAccounts(keys []solana.PublicKey) (*BuyAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("buy needs 12 accounts, got %d", len(keys))
+	}
+	return &BuyAccounts{
+		Sender:                 keys[0],
+		BackendAuthority:       keys[1],
+		SenderTokenAccount:     keys[2],
+		CurveAccount:           keys[3],
+		CurveTokenAccount:      keys[4],
+		DexFee:                 keys[5],
+		HelioFee:               keys[6],
+		Mint:                   keys[7],
+		ConfigAccount:          keys[8],
+		TokenProgram:           keys[9],
+		AssociatedTokenProgram: keys[10],
+		SystemProgram:          keys[11],
+	}, nil
+}
+
+// SellInstructionDiscriminator prefixes the data of every sell instruction
+var SellInstructionDiscriminator = []byte{51, 230, 133, 164, 1, 127, 131, 173}
+
+// SellArgs holds the arguments of the sell instruction
+type SellArgs struct {
+	Data TradeParams
+}
+
+// SellAccounts lists the accounts of the sell instruction in IDL order
+type SellAccounts struct {
+	Sender                 solana.PublicKey // writable, signer
+	BackendAuthority       solana.PublicKey // signer
+	SenderTokenAccount     solana.PublicKey // writable
+	CurveAccount           solana.PublicKey // writable
+	CurveTokenAccount      solana.PublicKey // writable
+	DexFee                 solana.PublicKey // writable
+	HelioFee               solana.PublicKey // writable
+	Mint                   solana.PublicKey
+	ConfigAccount          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	SystemProgram          solana.PublicKey
+}
+
+// NewSellInstruction builds the sell instruction. Remaining accounts are appended after the IDL ones
+func NewSellInstruction(programID solana.PublicKey, args SellArgs, accounts SellAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SellInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sell args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Sender, true, true),
+		solana.NewAccountMeta(accounts.BackendAuthority, false, true),
+		solana.NewAccountMeta(accounts.SenderTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.CurveAccount, true, false),
+		solana.NewAccountMeta(accounts.CurveTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.DexFee, true, false),
+		solana.NewAccountMeta(accounts.HelioFee, true, false),
+		solana.NewAccountMeta(accounts.Mint, false, false),
+		solana.NewAccountMeta(accounts.ConfigAccount, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSellArgs decodes the data of the sell instruction
+func DecodeSellArgs(data []byte) (*SellArgs, error) {
+	var args SellArgs
+	if err := decode(data, SellInstructionDiscriminator, "sell", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSellAccounts maps the account keys of the sell instruction by IDL order, ignoring remaining accounts
+func DecodeSellAccounts(keys []solana.PublicKey) (*SellAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("sell needs 12 accounts, got %d", len(keys))
+	}
+	return &SellAccounts{
+		Sender:                 keys[0],
+		BackendAuthority:       keys[1],
+		SenderTokenAccount:     keys[2],
+		CurveAccount:           keys[3],
+		CurveTokenAccount:      keys[4],
+		DexFee:                 keys[5],
+		HelioFee:               keys[6],
+		Mint:                   keys[7],
+		ConfigAccount:          keys[8],
+		TokenProgram:           keys[9],
+		AssociatedTokenProgram: keys[10],
+		SystemProgram:          keys[11],
+	}, nil
+}
+
+// MigrateFundsInstructionDiscriminator prefixes the data of every migrateFunds instruction
+var MigrateFundsInstructionDiscriminator = []byte{42, 229, 10, 231, 189, 62, 193, 174}
+
+// MigrateFundsAccounts lists the accounts of the migrateFunds instruction in IDL order
+type MigrateFundsAccounts struct {
+	// BE Authority
+	BackendAuthority solana.PublicKey // signer
+	// Migration Authority
+	// Owner and Payer over Token Accounts, needs to be mutable
+	MigrationAuthority solana.PublicKey // writable, signer
+	// Curve Account
+	// The account is closed after this instruction
+	CurveAccount solana.PublicKey // writable
+	// Curve Token Account
+	// The account is closed after this instruction
+	CurveTokenAccount solana.PublicKey // writable
+	// Authority token Account
+	// Init on demand
+	MigrationAuthorityTokenAccount solana.PublicKey // writable
+	// InterfaceAccount: checks program ownership + deserialize into Mint
+	Mint                   solana.PublicKey // writable
+	ConfigAccount          solana.PublicKey
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+}
+
+// NewMigrateFundsInstruction builds the migrateFunds instruction. Remaining accounts are appended after the IDL ones
+func NewMigrateFundsInstruction(programID solana.PublicKey, accounts MigrateFundsAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MigrateFundsInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrateFunds args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.BackendAuthority, false, true),
+		solana.NewAccountMeta(accounts.MigrationAuthority, true, true),
+		solana.NewAccountMeta(accounts.CurveAccount, true, false),
+		solana.NewAccountMeta(accounts.CurveTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.MigrationAuthorityTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.Mint, true, false),
+		solana.NewAccountMeta(accounts.ConfigAccount, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMigrateFundsAccounts maps the account keys of the migrateFunds instruction by IDL order, ignoring remaining accounts
+func DecodeMigrateFundsAccounts(keys []solana.PublicKey) (*MigrateFundsAccounts, error) {
+	if len(keys) < 10 {
+		return nil, fmt.Errorf("migrateFunds needs 10 accounts, got %d", len(keys))
+	}
+	return &MigrateFundsAccounts{
+		BackendAuthority:               keys[0],
+		MigrationAuthority:             keys[1],
+		CurveAccount:                   keys[2],
+		CurveTokenAccount:              keys[3],
+		MigrationAuthorityTokenAccount: keys[4],
+		Mint:                           keys[5],
+		ConfigAccount:                  keys[6],
+		SystemProgram:                  keys[7],
+		TokenProgram:                   keys[8],
+		AssociatedTokenProgram:         keys[9],
+	}, nil
+}
+
+// ConfigInitInstructionDiscriminator prefixes the data of every configInit instruction
+var ConfigInitInstructionDiscriminator = []byte{13, 236, 164, 173, 106, 253, 164, 185}
+
+// ConfigInitArgs holds the arguments of the configInit instruction
+type ConfigInitArgs struct {
+	Data ConfigParams
+}
+
+// ConfigInitAccounts lists the accounts of the configInit instruction in IDL order
+type ConfigInitAccounts struct {
+	ConfigAuthority solana.PublicKey // writable, signer
+	ConfigAccount   solana.PublicKey // writable
+	SystemProgram   solana.PublicKey
+}
+
+// NewConfigInitInstruction builds the configInit instruction. Remaining accounts are appended after the IDL ones
+func NewConfigInitInstruction(programID solana.PublicKey, args ConfigInitArgs, accounts ConfigInitAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(ConfigInitInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configInit args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.ConfigAuthority, true, true),
+		solana.NewAccountMeta(accounts.ConfigAccount, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeConfigInitArgs decodes the data of the configInit instruction
+func DecodeConfigInitArgs(data []byte) (*ConfigInitArgs, error) {
+	var args ConfigInitArgs
+	if err := decode(data, ConfigInitInstructionDiscriminator, "configInit", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeConfigInitAccounts maps the account keys of the configInit instruction by IDL order, ignoring remaining accounts
+func DecodeConfigInitAccounts(keys []solana.PublicKey) (*ConfigInitAccounts, error) {
+	if len(keys) < 3 {
+		return nil, fmt.Errorf("configInit needs 3 accounts, got %d", len(keys))
+	}
+	return &ConfigInitAccounts{
+		ConfigAuthority: keys[0],
+		ConfigAccount:   keys[1],
+		SystemProgram:   keys[2],
+	}, nil
+}
+
+// ConfigUpdateInstructionDiscriminator prefixes the data of every configUpdate instruction
+var ConfigUpdateInstructionDiscriminator = []byte{80, 37, 109, 136, 82, 135, 89, 241}
+
+// ConfigUpdateArgs holds the arguments of the configUpdate instruction
+type ConfigUpdateArgs struct {
+	Data ConfigParams
+}
+
+// ConfigUpdateAccounts lists the accounts of the configUpdate instruction in IDL order
+type ConfigUpdateAccounts struct {
+	ConfigAuthority solana.PublicKey // signer
+	ConfigAccount   solana.PublicKey // writable
+}
+
+// NewConfigUpdateInstruction builds the configUpdate instruction. Remaining accounts are appended after the IDL ones
+func NewConfigUpdateInstruction(programID solana.PublicKey, args ConfigUpdateArgs, accounts ConfigUpdateAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(ConfigUpdateInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configUpdate args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.ConfigAuthority, false, true),
+		solana.NewAccountMeta(accounts.ConfigAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeConfigUpdateArgs decodes the data of the configUpdate instruction
+func DecodeConfigUpdateArgs(data []byte) (*ConfigUpdateArgs, error) {
+	var args ConfigUpdateArgs
+	if err := decode(data, ConfigUpdateInstructionDiscriminator, "configUpdate", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeConfigUpdateAccounts maps the account keys of the configUpdate instruction by IDL order, ignoring remaining accounts
+func DecodeConfigUpdateAccounts(keys []solana.PublicKey) (*ConfigUpdateAccounts, error) {
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("configUpdate needs 2 accounts, got %d", len(keys))
+	}
+	return &ConfigUpdateAccounts{
+		ConfigAuthority: keys[0],
+		ConfigAccount:   keys[1],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
@@ -0,0 +1,590 @@
+// Code generated by idlgen from pumpfun_idl.json. DO NOT EDIT.
+
+// Package pumpfun holds typed bindings for the pump program, generated from its IDL.
+package pumpfun
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// ProgramID is the address of the pump program recorded in the IDL
+var ProgramID = solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+
+// Global is the Global account of the pump IDL, without its discriminator
+type Global struct {
+	Initialized                 bool
+	Authority                   solana.PublicKey
+	FeeRecipient                solana.PublicKey
+	InitialVirtualTokenReserves uint64
+	InitialVirtualSolReserves   uint64
+	InitialRealTokenReserves    uint64
+	TokenTotalSupply            uint64
+	FeeBasisPoints              uint64
+}
+
+// GlobalDiscriminator prefixes every Global account
+var GlobalDiscriminator = []byte{167, 232, 232, 177, 200, 108, 114, 127}
+
+// DecodeGlobal decodes the raw data of a Global account
+func DecodeGlobal(data []byte) (*Global, error) {
+	var account Global
+	if err := decode(data, GlobalDiscriminator, "Global", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// BondingCurve is the BondingCurve account of the pump IDL, without its discriminator
+type BondingCurve struct {
+	VirtualTokenReserves uint64
+	VirtualSolReserves   uint64
+	RealTokenReserves    uint64
+	RealSolReserves      uint64
+	TokenTotalSupply     uint64
+	Complete             bool
+}
+
+// BondingCurveDiscriminator prefixes every BondingCurve account
+var BondingCurveDiscriminator = []byte{23, 183, 248, 55, 96, 216, 172, 96}
+
+// DecodeBondingCurve decodes the raw data of a BondingCurve account
+func DecodeBondingCurve(data []byte) (*BondingCurve, error) {
+	var account BondingCurve
+	if err := decode(data, BondingCurveDiscriminator, "BondingCurve", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CreateEvent is the CreateEvent event of the pump IDL
+type CreateEvent struct {
+	Name         string
+	Symbol       string
+	Uri          string
+	Mint         solana.PublicKey
+	BondingCurve solana.PublicKey
+	User         solana.PublicKey
+}
+
+// CreateEventDiscriminator prefixes every CreateEvent event
+var CreateEventDiscriminator = []byte{27, 114, 169, 77, 222, 235, 99, 118}
+
+// DecodeCreateEvent decodes a CreateEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeCreateEvent(data []byte) (*CreateEvent, error) {
+	var event CreateEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), CreateEventDiscriminator, "CreateEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// TradeEvent is the TradeEvent event of the pump IDL
+type TradeEvent struct {
+	Mint                 solana.PublicKey
+	SolAmount            uint64
+	TokenAmount          uint64
+	IsBuy                bool
+	User                 solana.PublicKey
+	Timestamp            int64
+	VirtualSolReserves   uint64
+	VirtualTokenReserves uint64
+}
+
+// TradeEventDiscriminator prefixes every TradeEvent event
+var TradeEventDiscriminator = []byte{189, 219, 127, 211, 78, 230, 97, 238}
+
+// DecodeTradeEvent decodes a TradeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeTradeEvent(data []byte) (*TradeEvent, error) {
+	var event TradeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), TradeEventDiscriminator, "TradeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CompleteEvent is the CompleteEvent event of the pump IDL
+type CompleteEvent struct {
+	User         solana.PublicKey
+	Mint         solana.PublicKey
+	BondingCurve solana.PublicKey
+	Timestamp    int64
+}
+
+// CompleteEventDiscriminator prefixes every CompleteEvent event
+var CompleteEventDiscriminator = []byte{95, 114, 97, 156, 212, 46, 152, 8}
+
+// DecodeCompleteEvent decodes a CompleteEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeCompleteEvent(data []byte) (*CompleteEvent, error) {
+	var event CompleteEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), CompleteEventDiscriminator, "CompleteEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// SetParamsEvent is the SetParamsEvent event of the pump IDL
+type SetParamsEvent struct {
+	FeeRecipient                solana.PublicKey
+	InitialVirtualTokenReserves uint64
+	InitialVirtualSolReserves   uint64
+	InitialRealTokenReserves    uint64
+	TokenTotalSupply            uint64
+	FeeBasisPoints              uint64
+}
+
+// SetParamsEventDiscriminator prefixes every SetParamsEvent event
+var SetParamsEventDiscriminator = []byte{223, 195, 159, 246, 62, 48, 143, 131}
+
+// DecodeSetParamsEvent decodes a SetParamsEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeSetParamsEvent(data []byte) (*SetParamsEvent, error) {
+	var event SetParamsEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), SetParamsEventDiscriminator, "SetParamsEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// InitializeInstructionDiscriminator prefixes the data of every initialize instruction
+var InitializeInstructionDiscriminator = []byte{175, 175, 109, 31, 13, 152, 155, 237}
+
+// InitializeAccounts lists the accounts of the initialize instruction in IDL order
+type InitializeAccounts struct {
+	Global        solana.PublicKey // writable
+	User          solana.PublicKey // writable, signer
+	SystemProgram solana.PublicKey
+}
+
+// NewInitializeInstruction builds the initialize instruction. Remaining accounts are appended after the IDL ones
+func NewInitializeInstruction(programID solana.PublicKey, accounts InitializeAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(InitializeInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode initialize args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Global, true, false),
+		solana.NewAccountMeta(accounts.User, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeInitializeAccounts maps the account keys of the initialize instruction by IDL order, ignoring remaining accounts
+func DecodeInitializeAccounts(keys []solana.PublicKey) (*InitializeAccounts, error) {
+	if len(keys) < 3 {
+		return nil, fmt.Errorf("initialize needs 3 accounts, got %d", len(keys))
+	}
+	return &InitializeAccounts{
+		Global:        keys[0],
+		User:          keys[1],
+		SystemProgram: keys[2],
+	}, nil
+}
+
+// SetParamsInstructionDiscriminator prefixes the data of every setParams instruction
+var SetParamsInstructionDiscriminator = []byte{27, 234, 178, 52, 147, 2, 187, 141}
+
+// SetParamsArgs holds the arguments of the setParams instruction
+// Sets the global state parameters.
+type SetParamsArgs struct {
+	FeeRecipient                solana.PublicKey
+	InitialVirtualTokenReserves uint64
+	InitialVirtualSolReserves   uint64
+	InitialRealTokenReserves    uint64
+	TokenTotalSupply            uint64
+	FeeBasisPoints              uint64
+}
+
+// SetParamsAccounts lists the accounts of the setParams instruction in IDL order
+type SetParamsAccounts struct {
+	Global         solana.PublicKey // writable
+	User           solana.PublicKey // writable, signer
+	SystemProgram  solana.PublicKey
+	EventAuthority solana.PublicKey
+	Program        solana.PublicKey
+}
+
+// NewSetParamsInstruction builds the setParams instruction. Remaining accounts are appended after the IDL ones
+func NewSetParamsInstruction(programID solana.PublicKey, args SetParamsArgs, accounts SetParamsAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SetParamsInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setParams args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Global, true, false),
+		solana.NewAccountMeta(accounts.User, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSetParamsArgs decodes the data of the setParams instruction
+func DecodeSetParamsArgs(data []byte) (*SetParamsArgs, error) {
+	var args SetParamsArgs
+	if err := decode(data, SetParamsInstructionDiscriminator, "setParams", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSetParamsAccounts maps the account keys of the setParams instruction by IDL order, ignoring remaining accounts
+func DecodeSetParamsAccounts(keys []solana.PublicKey) (*SetParamsAccounts, error) {
+	if len(keys) < 5 {
+		return nil, fmt.Errorf("setParams needs 5 accounts, got %d", len(keys))
+	}
+	return &SetParamsAccounts{
+		Global:         keys[0],
+		User:           keys[1],
+		SystemProgram:  keys[2],
+		EventAuthority: keys[3],
+		Program:        keys[4],
+	}, nil
+}
+
+// CreateInstructionDiscriminator prefixes the data of every create instruction
+var CreateInstructionDiscriminator = []byte{24, 30, 200, 40, 5, 28, 7, 119}
+
+// CreateArgs holds the arguments of the create instruction
+// Creates a new coin and bonding curve.
+type CreateArgs struct {
+	Name   string
+	Symbol string
+	Uri    string
+}
+
+// CreateAccounts lists the accounts of the create instruction in IDL order
+type CreateAccounts struct {
+	Mint                   solana.PublicKey // writable, signer
+	MintAuthority          solana.PublicKey
+	BondingCurve           solana.PublicKey // writable
+	AssociatedBondingCurve solana.PublicKey // writable
+	Global                 solana.PublicKey
+	MplTokenMetadata       solana.PublicKey
+	Metadata               solana.PublicKey // writable
+	User                   solana.PublicKey // writable, signer
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	Rent                   solana.PublicKey
+	EventAuthority         solana.PublicKey
+	Program                solana.PublicKey
+}
+
+// NewCreateInstruction builds the create instruction. Remaining accounts are appended after the IDL ones
+func NewCreateInstruction(programID solana.PublicKey, args CreateArgs, accounts CreateAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode create args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Mint, true, true),
+		solana.NewAccountMeta(accounts.MintAuthority, false, false),
+		solana.NewAccountMeta(accounts.BondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedBondingCurve, true, false),
+		solana.NewAccountMeta(accounts.Global, false, false),
+		solana.NewAccountMeta(accounts.MplTokenMetadata, false, false),
+		solana.NewAccountMeta(accounts.Metadata, true, false),
+		solana.NewAccountMeta(accounts.User, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateArgs decodes the data of the create instruction
+func DecodeCreateArgs(data []byte) (*CreateArgs, error) {
+	var args CreateArgs
+	if err := decode(data, CreateInstructionDiscriminator, "create", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCreateAccounts maps the account keys of the create instruction by IDL order, ignoring remaining accounts
+func DecodeCreateAccounts(keys []solana.PublicKey) (*CreateAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("create needs 14 accounts, got %d", len(keys))
+	}
+	return &CreateAccounts{
+		Mint:                   keys[0],
+		MintAuthority:          keys[1],
+		BondingCurve:           keys[2],
+		AssociatedBondingCurve: keys[3],
+		Global:                 keys[4],
+		MplTokenMetadata:       keys[5],
+		Metadata:               keys[6],
+		User:                   keys[7],
+		SystemProgram:          keys[8],
+		TokenProgram:           keys[9],
+		AssociatedTokenProgram: keys[10],
+		Rent:                   keys[11],
+		EventAuthority:         keys[12],
+		Program:                keys[13],
+	}, nil
+}
+
+// BuyInstructionDiscriminator prefixes the data of every buy instruction
+var BuyInstructionDiscriminator = []byte{102, 6, 61, 18, 1, 218, 235, 234}
+
+// BuyArgs holds the arguments of the buy instruction
+// Buys tokens from a bonding curve.
+type BuyArgs struct {
+	Amount     uint64
+	MaxSolCost uint64
+}
+
+// BuyAccounts lists the accounts of the buy instruction in IDL order
+type BuyAccounts struct {
+	Global                 solana.PublicKey
+	FeeRecipient           solana.PublicKey // writable
+	Mint                   solana.PublicKey
+	BondingCurve           solana.PublicKey // writable
+	AssociatedBondingCurve solana.PublicKey // writable
+	AssociatedUser         solana.PublicKey // writable
+	User                   solana.PublicKey // writable, signer
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	Rent                   solana.PublicKey
+	EventAuthority         solana.PublicKey
+	Program                solana.PublicKey
+}
+
+// NewBuyInstruction builds the buy instruction. Remaining accounts are appended after the IDL ones
+func NewBuyInstruction(programID solana.PublicKey, args BuyArgs, accounts BuyAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(BuyInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode buy args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Global, false, false),
+		solana.NewAccountMeta(accounts.FeeRecipient, true, false),
+		solana.NewAccountMeta(accounts.Mint, false, false),
+		solana.NewAccountMeta(accounts.BondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedBondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedUser, true, false),
+		solana.NewAccountMeta(accounts.User, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeBuyArgs decodes the data of the buy instruction
+func DecodeBuyArgs(data []byte) (*BuyArgs, error) {
+	var args BuyArgs
+	if err := decode(data, BuyInstructionDiscriminator, "buy", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeBuyAccounts maps the account keys of the buy instruction by IDL order, ignoring remaining accounts
+func DecodeBuyAccounts(keys []solana.PublicKey) (*BuyAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("buy needs 12 accounts, got %d", len(keys))
+	}
+	return &BuyAccounts{
+		Global:                 keys[0],
+		FeeRecipient:           keys[1],
+		Mint:                   keys[2],
+		BondingCurve:           keys[3],
+		AssociatedBondingCurve: keys[4],
+		AssociatedUser:         keys[5],
+		User:                   keys[6],
+		SystemProgram:          keys[7],
+		TokenProgram:           keys[8],
+		Rent:                   keys[9],
+		EventAuthority:         keys[10],
+		Program:                keys[11],
+	}, nil
+}
+
+// SellInstructionDiscriminator prefixes the data of every sell instruction
+var SellInstructionDiscriminator = []byte{51, 230, 133, 164, 1, 127, 131, 173}
+
+// SellArgs holds the arguments of the sell instruction
+// Sells tokens into a bonding curve.
+type SellArgs struct {
+	Amount       uint64
+	MinSolOutput uint64
+}
+
+// SellAccounts lists the accounts of the sell instruction in IDL order
+type SellAccounts struct {
+	Global                 solana.PublicKey
+	FeeRecipient           solana.PublicKey // writable
+	Mint                   solana.PublicKey
+	BondingCurve           solana.PublicKey // writable
+	AssociatedBondingCurve solana.PublicKey // writable
+	AssociatedUser         solana.PublicKey // writable
+	User                   solana.PublicKey // writable, signer
+	SystemProgram          solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	TokenProgram           solana.PublicKey
+	EventAuthority         solana.PublicKey
+	Program                solana.PublicKey
+}
+
+// NewSellInstruction builds the sell instruction. Remaining accounts are appended after the IDL ones
+func NewSellInstruction(programID solana.PublicKey, args SellArgs, accounts SellAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SellInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sell args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Global, false, false),
+		solana.NewAccountMeta(accounts.FeeRecipient, true, false),
+		solana.NewAccountMeta(accounts.Mint, false, false),
+		solana.NewAccountMeta(accounts.BondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedBondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedUser, true, false),
+		solana.NewAccountMeta(accounts.User, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSellArgs decodes the data of the sell instruction
+func DecodeSellArgs(data []byte) (*SellArgs, error) {
+	var args SellArgs
+	if err := decode(data, SellInstructionDiscriminator, "sell", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSellAccounts maps the account keys of the sell instruction by IDL order, ignoring remaining accounts
+func DecodeSellAccounts(keys []solana.PublicKey) (*SellAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("sell needs 12 accounts, got %d", len(keys))
+	}
+	return &SellAccounts{
+		Global:                 keys[0],
+		FeeRecipient:           keys[1],
+		Mint:                   keys[2],
+		BondingCurve:           keys[3],
+		AssociatedBondingCurve: keys[4],
+		AssociatedUser:         keys[5],
+		User:                   keys[6],
+		SystemProgram:          keys[7],
+		AssociatedTokenProgram: keys[8],
+		TokenProgram:           keys[9],
+		EventAuthority:         keys[10],
+		Program:                keys[11],
+	}, nil
+}
+
+// WithdrawInstructionDiscriminator prefixes the data of every withdraw instruction
+var WithdrawInstructionDiscriminator = []byte{183, 18, 70, 156, 148, 109, 161, 34}
+
+// WithdrawAccounts lists the accounts of the withdraw instruction in IDL order
+type WithdrawAccounts struct {
+	Global                 solana.PublicKey
+	Mint                   solana.PublicKey
+	BondingCurve           solana.PublicKey // writable
+	AssociatedBondingCurve solana.PublicKey // writable
+	AssociatedUser         solana.PublicKey // writable
+	User                   solana.PublicKey // writable, signer
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	Rent                   solana.PublicKey
+	EventAuthority         solana.PublicKey
+	Program                solana.PublicKey
+}
+
+// NewWithdrawInstruction builds the withdraw instruction. Remaining accounts are appended after the IDL ones
+func NewWithdrawInstruction(programID solana.PublicKey, accounts WithdrawAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(WithdrawInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode withdraw args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Global, false, false),
+		solana.NewAccountMeta(accounts.Mint, false, false),
+		solana.NewAccountMeta(accounts.BondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedBondingCurve, true, false),
+		solana.NewAccountMeta(accounts.AssociatedUser, true, false),
+		solana.NewAccountMeta(accounts.User, true, true),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.EventAuthority, false, false),
+		solana.NewAccountMeta(accounts.Program, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeWithdrawAccounts maps the account keys of the withdraw instruction by IDL order, ignoring remaining accounts
+func DecodeWithdrawAccounts(keys []solana.PublicKey) (*WithdrawAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("withdraw needs 11 accounts, got %d", len(keys))
+	}
+	return &WithdrawAccounts{
+		Global:                 keys[0],
+		Mint:                   keys[1],
+		BondingCurve:           keys[2],
+		AssociatedBondingCurve: keys[3],
+		AssociatedUser:         keys[4],
+		User:                   keys[5],
+		SystemProgram:          keys[6],
+		TokenProgram:           keys[7],
+		Rent:                   keys[8],
+		EventAuthority:         keys[9],
+		Program:                keys[10],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
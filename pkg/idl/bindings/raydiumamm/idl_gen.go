@@ -0,0 +1,1588 @@
+// Code generated by idlgen from raydium_amm_idl.json. DO NOT EDIT.
+
+// Package raydiumamm holds typed bindings for the raydium_amm program, generated from its IDL.
+package raydiumamm
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// WithdrawDestToken is the WithdrawDestToken type of the raydium_amm IDL
+type WithdrawDestToken struct {
+	WithdrawAmount uint64
+	CoinAmount     uint64
+	PcAmount       uint64
+	DestTokenCoin  solana.PublicKey
+	DestTokenPc    solana.PublicKey
+}
+
+// WithdrawQueue is the WithdrawQueue type of the raydium_amm IDL
+type WithdrawQueue struct {
+	Owner [4]uint64
+	Head  uint64
+	Count uint64
+	Buf   [64]WithdrawDestToken
+}
+
+// TargetOrder is the TargetOrder type of the raydium_amm IDL
+type TargetOrder struct {
+	Price uint64
+	Vol   uint64
+}
+
+// OutPutData is the OutPutData type of the raydium_amm IDL
+type OutPutData struct {
+	NeedTakePnlCoin     uint64
+	NeedTakePnlPc       uint64
+	TotalPnlPc          uint64
+	TotalPnlCoin        uint64
+	PoolOpenTime        uint64
+	PunishPcAmount      uint64
+	PunishCoinAmount    uint64
+	OrderbookToInitTime uint64
+	SwapCoinInAmount    bin.Uint128
+	SwapPcOutAmount     bin.Uint128
+	SwapTakePcFee       uint64
+	SwapPcInAmount      bin.Uint128
+	SwapCoinOutAmount   bin.Uint128
+	SwapTakeCoinFee     uint64
+}
+
+// AmmConfig is the AmmConfig type of the raydium_amm IDL
+type AmmConfig struct {
+	PnlOwner    solana.PublicKey
+	CancelOwner solana.PublicKey
+	Pending1    [28]uint64
+	Pending2    [32]uint64
+}
+
+// LastOrderDistance is the LastOrderDistance type of the raydium_amm IDL
+type LastOrderDistance struct {
+	LastOrderNumerator   uint64
+	LastOrderDenominator uint64
+}
+
+// NeedTake is the NeedTake type of the raydium_amm IDL
+type NeedTake struct {
+	NeedTakePc   uint64
+	NeedTakeCoin uint64
+}
+
+// SwapInstructionBaseIn is the SwapInstructionBaseIn type of the raydium_amm IDL
+type SwapInstructionBaseIn struct {
+	AmountIn         uint64
+	MinimumAmountOut uint64
+}
+
+// SwapInstructionBaseOut is the SwapInstructionBaseOut type of the raydium_amm IDL
+type SwapInstructionBaseOut struct {
+	MaxAmountIn uint64
+	AmountOut   uint64
+}
+
+// TargetOrders is the TargetOrders account of the raydium_amm IDL, without its discriminator
+type TargetOrders struct {
+	Owner                [4]uint64
+	BuyOrders            [50]TargetOrder
+	Padding1             [8]uint64
+	TargetX              bin.Uint128
+	TargetY              bin.Uint128
+	PlanXBuy             bin.Uint128
+	PlanYBuy             bin.Uint128
+	PlanXSell            bin.Uint128
+	PlanYSell            bin.Uint128
+	PlacedX              bin.Uint128
+	PlacedY              bin.Uint128
+	CalcPnlX             bin.Uint128
+	CalcPnlY             bin.Uint128
+	SellOrders           [50]TargetOrder
+	Padding2             [6]uint64
+	ReplaceBuyClientId   [10]uint64
+	ReplaceSellClientId  [10]uint64
+	LastOrderNumerator   uint64
+	LastOrderDenominator uint64
+	PlanOrdersCur        uint64
+	PlaceOrdersCur       uint64
+	ValidBuyOrderNum     uint64
+	ValidSellOrderNum    uint64
+	Padding3             [10]uint64
+	FreeSlotBits         bin.Uint128
+}
+
+// TargetOrdersDiscriminator is empty: accounts of native programs carry no discriminator
+var TargetOrdersDiscriminator = []byte{}
+
+// DecodeTargetOrders decodes the raw data of a TargetOrders account
+func DecodeTargetOrders(data []byte) (*TargetOrders, error) {
+	var account TargetOrders
+	if err := decode(data, TargetOrdersDiscriminator, "TargetOrders", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Fees is the Fees account of the raydium_amm IDL, without its discriminator
+type Fees struct {
+	MinSeparateNumerator   uint64
+	MinSeparateDenominator uint64
+	TradeFeeNumerator      uint64
+	TradeFeeDenominator    uint64
+	PnlNumerator           uint64
+	PnlDenominator         uint64
+	SwapFeeNumerator       uint64
+	SwapFeeDenominator     uint64
+}
+
+// FeesDiscriminator is empty: accounts of native programs carry no discriminator
+var FeesDiscriminator = []byte{}
+
+// DecodeFees decodes the raw data of a Fees account
+func DecodeFees(data []byte) (*Fees, error) {
+	var account Fees
+	if err := decode(data, FeesDiscriminator, "Fees", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// AmmInfo is the AmmInfo account of the raydium_amm IDL, without its discriminator
+type AmmInfo struct {
+	Status             uint64
+	Nonce              uint64
+	OrderNum           uint64
+	Depth              uint64
+	CoinDecimals       uint64
+	PcDecimals         uint64
+	State              uint64
+	ResetFlag          uint64
+	MinSize            uint64
+	VolMaxCutRatio     uint64
+	AmountWave         uint64
+	CoinLotSize        uint64
+	PcLotSize          uint64
+	MinPriceMultiplier uint64
+	MaxPriceMultiplier uint64
+	SysDecimalValue    uint64
+	Fees               Fees
+	OutPut             OutPutData
+	TokenCoin          solana.PublicKey
+	TokenPc            solana.PublicKey
+	CoinMint           solana.PublicKey
+	PcMint             solana.PublicKey
+	LpMint             solana.PublicKey
+	OpenOrders         solana.PublicKey
+	Market             solana.PublicKey
+	SerumDex           solana.PublicKey
+	TargetOrders       solana.PublicKey
+	WithdrawQueue      solana.PublicKey
+	TokenTempLp        solana.PublicKey
+	AmmOwner           solana.PublicKey
+	LpAmount           uint64
+	ClientOrderId      uint64
+	Padding            [2]uint64
+}
+
+// AmmInfoDiscriminator is empty: accounts of native programs carry no discriminator
+var AmmInfoDiscriminator = []byte{}
+
+// DecodeAmmInfo decodes the raw data of a AmmInfo account
+func DecodeAmmInfo(data []byte) (*AmmInfo, error) {
+	var account AmmInfo
+	if err := decode(data, AmmInfoDiscriminator, "AmmInfo", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// InitializeInstructionDiscriminator prefixes the data of every initialize instruction
+var InitializeInstructionDiscriminator = []byte{0}
+
+// InitializeArgs holds the arguments of the initialize instruction
+type InitializeArgs struct {
+	Nonce    uint8
+	OpenTime uint64
+}
+
+// InitializeAccounts lists the accounts of the initialize instruction in IDL order
+type InitializeAccounts struct {
+	TokenProgram            solana.PublicKey
+	SystemProgram           solana.PublicKey
+	Rent                    solana.PublicKey
+	Amm                     solana.PublicKey // writable
+	AmmAuthority            solana.PublicKey
+	AmmOpenOrders           solana.PublicKey // writable
+	LpMintAddress           solana.PublicKey // writable
+	CoinMintAddress         solana.PublicKey
+	PcMintAddress           solana.PublicKey
+	PoolCoinTokenAccount    solana.PublicKey
+	PoolPcTokenAccount      solana.PublicKey
+	PoolWithdrawQueue       solana.PublicKey // writable
+	PoolTargetOrdersAccount solana.PublicKey // writable
+	UserLpTokenAccount      solana.PublicKey // writable
+	PoolTempLpTokenAccount  solana.PublicKey
+	SerumProgram            solana.PublicKey
+	SerumMarket             solana.PublicKey
+	UserWallet              solana.PublicKey // writable, signer
+}
+
+// NewInitializeInstruction builds the initialize instruction. Remaining accounts are appended after the IDL ones
+func NewInitializeInstruction(programID solana.PublicKey, args InitializeArgs, accounts InitializeAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(InitializeInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode initialize args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.LpMintAddress, true, false),
+		solana.NewAccountMeta(accounts.CoinMintAddress, false, false),
+		solana.NewAccountMeta(accounts.PcMintAddress, false, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.PoolWithdrawQueue, true, false),
+		solana.NewAccountMeta(accounts.PoolTargetOrdersAccount, true, false),
+		solana.NewAccountMeta(accounts.UserLpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolTempLpTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, false, false),
+		solana.NewAccountMeta(accounts.UserWallet, true, true),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeInitializeArgs decodes the data of the initialize instruction
+func DecodeInitializeArgs(data []byte) (*InitializeArgs, error) {
+	var args InitializeArgs
+	if err := decode(data, InitializeInstructionDiscriminator, "initialize", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeInitializeAccounts maps the account keys of the initialize instruction by IDL order, ignoring remaining accounts
+func DecodeInitializeAccounts(keys []solana.PublicKey) (*InitializeAccounts, error) {
+	if len(keys) < 18 {
+		return nil, fmt.Errorf("initialize needs 18 accounts, got %d", len(keys))
+	}
+	return &InitializeAccounts{
+		TokenProgram:            keys[0],
+		SystemProgram:           keys[1],
+		Rent:                    keys[2],
+		Amm:                     keys[3],
+		AmmAuthority:            keys[4],
+		AmmOpenOrders:           keys[5],
+		LpMintAddress:           keys[6],
+		CoinMintAddress:         keys[7],
+		PcMintAddress:           keys[8],
+		PoolCoinTokenAccount:    keys[9],
+		PoolPcTokenAccount:      keys[10],
+		PoolWithdrawQueue:       keys[11],
+		PoolTargetOrdersAccount: keys[12],
+		UserLpTokenAccount:      keys[13],
+		PoolTempLpTokenAccount:  keys[14],
+		SerumProgram:            keys[15],
+		SerumMarket:             keys[16],
+		UserWallet:              keys[17],
+	}, nil
+}
+
+// Initialize2InstructionDiscriminator prefixes the data of every initialize2 instruction
+var Initialize2InstructionDiscriminator = []byte{1}
+
+// Initialize2Args holds the arguments of the initialize2 instruction
+type Initialize2Args struct {
+	Nonce          uint8
+	OpenTime       uint64
+	InitPcAmount   uint64
+	InitCoinAmount uint64
+}
+
+// Initialize2Accounts lists the accounts of the initialize2 instruction in IDL order
+type Initialize2Accounts struct {
+	TokenProgram              solana.PublicKey
+	SplAssociatedTokenAccount solana.PublicKey
+	SystemProgram             solana.PublicKey
+	Rent                      solana.PublicKey
+	Amm                       solana.PublicKey // writable
+	AmmAuthority              solana.PublicKey
+	AmmOpenOrders             solana.PublicKey // writable
+	LpMint                    solana.PublicKey // writable
+	CoinMint                  solana.PublicKey
+	PcMint                    solana.PublicKey
+	PoolCoinTokenAccount      solana.PublicKey // writable
+	PoolPcTokenAccount        solana.PublicKey // writable
+	PoolWithdrawQueue         solana.PublicKey // writable
+	AmmTargetOrders           solana.PublicKey // writable
+	PoolTempLp                solana.PublicKey // writable
+	SerumProgram              solana.PublicKey
+	SerumMarket               solana.PublicKey
+	UserWallet                solana.PublicKey // writable, signer
+	UserTokenCoin             solana.PublicKey // writable
+	UserTokenPc               solana.PublicKey // writable
+	UserLpTokenAccount        solana.PublicKey // writable
+}
+
+// NewInitialize2Instruction builds the initialize2 instruction. Remaining accounts are appended after the IDL ones
+func NewInitialize2Instruction(programID solana.PublicKey, args Initialize2Args, accounts Initialize2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(Initialize2InstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode initialize2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SplAssociatedTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.LpMint, true, false),
+		solana.NewAccountMeta(accounts.CoinMint, false, false),
+		solana.NewAccountMeta(accounts.PcMint, false, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolWithdrawQueue, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolTempLp, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, false, false),
+		solana.NewAccountMeta(accounts.UserWallet, true, true),
+		solana.NewAccountMeta(accounts.UserTokenCoin, true, false),
+		solana.NewAccountMeta(accounts.UserTokenPc, true, false),
+		solana.NewAccountMeta(accounts.UserLpTokenAccount, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeInitialize2Args decodes the data of the initialize2 instruction
+func DecodeInitialize2Args(data []byte) (*Initialize2Args, error) {
+	var args Initialize2Args
+	if err := decode(data, Initialize2InstructionDiscriminator, "initialize2", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeInitialize2Accounts maps the account keys of the initialize2 instruction by IDL order, ignoring remaining accounts
+func DecodeInitialize2Accounts(keys []solana.PublicKey) (*Initialize2Accounts, error) {
+	if len(keys) < 21 {
+		return nil, fmt.Errorf("initialize2 needs 21 accounts, got %d", len(keys))
+	}
+	return &Initialize2Accounts{
+		TokenProgram:              keys[0],
+		SplAssociatedTokenAccount: keys[1],
+		SystemProgram:             keys[2],
+		Rent:                      keys[3],
+		Amm:                       keys[4],
+		AmmAuthority:              keys[5],
+		AmmOpenOrders:             keys[6],
+		LpMint:                    keys[7],
+		CoinMint:                  keys[8],
+		PcMint:                    keys[9],
+		PoolCoinTokenAccount:      keys[10],
+		PoolPcTokenAccount:        keys[11],
+		PoolWithdrawQueue:         keys[12],
+		AmmTargetOrders:           keys[13],
+		PoolTempLp:                keys[14],
+		SerumProgram:              keys[15],
+		SerumMarket:               keys[16],
+		UserWallet:                keys[17],
+		UserTokenCoin:             keys[18],
+		UserTokenPc:               keys[19],
+		UserLpTokenAccount:        keys[20],
+	}, nil
+}
+
+// MonitorStepInstructionDiscriminator prefixes the data of every monitorStep instruction
+var MonitorStepInstructionDiscriminator = []byte{2}
+
+// MonitorStepArgs holds the arguments of the monitorStep instruction
+type MonitorStepArgs struct {
+	PlanOrderLimit   uint16
+	PlaceOrderLimit  uint16
+	CancelOrderLimit uint16
+}
+
+// MonitorStepAccounts lists the accounts of the monitorStep instruction in IDL order
+type MonitorStepAccounts struct {
+	TokenProgram          solana.PublicKey
+	Rent                  solana.PublicKey
+	Clock                 solana.PublicKey
+	Amm                   solana.PublicKey // writable
+	AmmAuthority          solana.PublicKey
+	AmmOpenOrders         solana.PublicKey // writable
+	AmmTargetOrders       solana.PublicKey // writable
+	PoolCoinTokenAccount  solana.PublicKey // writable
+	PoolPcTokenAccount    solana.PublicKey // writable
+	PoolWithdrawQueue     solana.PublicKey // writable
+	SerumProgram          solana.PublicKey
+	SerumMarket           solana.PublicKey // writable
+	SerumCoinVaultAccount solana.PublicKey // writable
+	SerumPcVaultAccount   solana.PublicKey // writable
+	SerumVaultSigner      solana.PublicKey
+	SerumReqQ             solana.PublicKey // writable
+	SerumEventQ           solana.PublicKey // writable
+	SerumBids             solana.PublicKey // writable
+	SerumAsks             solana.PublicKey // writable
+}
+
+// NewMonitorStepInstruction builds the monitorStep instruction. Remaining accounts are appended after the IDL ones
+func NewMonitorStepInstruction(programID solana.PublicKey, args MonitorStepArgs, accounts MonitorStepAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MonitorStepInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode monitorStep args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.Clock, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolWithdrawQueue, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.SerumReqQ, true, false),
+		solana.NewAccountMeta(accounts.SerumEventQ, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMonitorStepArgs decodes the data of the monitorStep instruction
+func DecodeMonitorStepArgs(data []byte) (*MonitorStepArgs, error) {
+	var args MonitorStepArgs
+	if err := decode(data, MonitorStepInstructionDiscriminator, "monitorStep", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeMonitorStepAccounts maps the account keys of the monitorStep instruction by IDL order, ignoring remaining accounts
+func DecodeMonitorStepAccounts(keys []solana.PublicKey) (*MonitorStepAccounts, error) {
+	if len(keys) < 19 {
+		return nil, fmt.Errorf("monitorStep needs 19 accounts, got %d", len(keys))
+	}
+	return &MonitorStepAccounts{
+		TokenProgram:          keys[0],
+		Rent:                  keys[1],
+		Clock:                 keys[2],
+		Amm:                   keys[3],
+		AmmAuthority:          keys[4],
+		AmmOpenOrders:         keys[5],
+		AmmTargetOrders:       keys[6],
+		PoolCoinTokenAccount:  keys[7],
+		PoolPcTokenAccount:    keys[8],
+		PoolWithdrawQueue:     keys[9],
+		SerumProgram:          keys[10],
+		SerumMarket:           keys[11],
+		SerumCoinVaultAccount: keys[12],
+		SerumPcVaultAccount:   keys[13],
+		SerumVaultSigner:      keys[14],
+		SerumReqQ:             keys[15],
+		SerumEventQ:           keys[16],
+		SerumBids:             keys[17],
+		SerumAsks:             keys[18],
+	}, nil
+}
+
+// DepositInstructionDiscriminator prefixes the data of every deposit instruction
+var DepositInstructionDiscriminator = []byte{3}
+
+// DepositArgs holds the arguments of the deposit instruction
+type DepositArgs struct {
+	MaxCoinAmount uint64
+	MaxPcAmount   uint64
+	BaseSide      uint64
+}
+
+// DepositAccounts lists the accounts of the deposit instruction in IDL order
+type DepositAccounts struct {
+	TokenProgram         solana.PublicKey
+	Amm                  solana.PublicKey // writable
+	AmmAuthority         solana.PublicKey
+	AmmOpenOrders        solana.PublicKey
+	AmmTargetOrders      solana.PublicKey // writable
+	LpMintAddress        solana.PublicKey // writable
+	PoolCoinTokenAccount solana.PublicKey // writable
+	PoolPcTokenAccount   solana.PublicKey // writable
+	SerumMarket          solana.PublicKey
+	UserCoinTokenAccount solana.PublicKey // writable
+	UserPcTokenAccount   solana.PublicKey // writable
+	UserLpTokenAccount   solana.PublicKey // writable
+	UserOwner            solana.PublicKey // signer
+	SerumEventQueue      solana.PublicKey
+}
+
+// NewDepositInstruction builds the deposit instruction. Remaining accounts are appended after the IDL ones
+func NewDepositInstruction(programID solana.PublicKey, args DepositArgs, accounts DepositAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(DepositInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deposit args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, false, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.LpMintAddress, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumMarket, false, false),
+		solana.NewAccountMeta(accounts.UserCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserLpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserOwner, false, true),
+		solana.NewAccountMeta(accounts.SerumEventQueue, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeDepositArgs decodes the data of the deposit instruction
+func DecodeDepositArgs(data []byte) (*DepositArgs, error) {
+	var args DepositArgs
+	if err := decode(data, DepositInstructionDiscriminator, "deposit", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeDepositAccounts maps the account keys of the deposit instruction by IDL order, ignoring remaining accounts
+func DecodeDepositAccounts(keys []solana.PublicKey) (*DepositAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("deposit needs 14 accounts, got %d", len(keys))
+	}
+	return &DepositAccounts{
+		TokenProgram:         keys[0],
+		Amm:                  keys[1],
+		AmmAuthority:         keys[2],
+		AmmOpenOrders:        keys[3],
+		AmmTargetOrders:      keys[4],
+		LpMintAddress:        keys[5],
+		PoolCoinTokenAccount: keys[6],
+		PoolPcTokenAccount:   keys[7],
+		SerumMarket:          keys[8],
+		UserCoinTokenAccount: keys[9],
+		UserPcTokenAccount:   keys[10],
+		UserLpTokenAccount:   keys[11],
+		UserOwner:            keys[12],
+		SerumEventQueue:      keys[13],
+	}, nil
+}
+
+// WithdrawInstructionDiscriminator prefixes the data of every withdraw instruction
+var WithdrawInstructionDiscriminator = []byte{4}
+
+// WithdrawArgs holds the arguments of the withdraw instruction
+type WithdrawArgs struct {
+	Amount uint64
+}
+
+// WithdrawAccounts lists the accounts of the withdraw instruction in IDL order
+type WithdrawAccounts struct {
+	TokenProgram           solana.PublicKey
+	Amm                    solana.PublicKey // writable
+	AmmAuthority           solana.PublicKey
+	AmmOpenOrders          solana.PublicKey // writable
+	AmmTargetOrders        solana.PublicKey // writable
+	LpMintAddress          solana.PublicKey // writable
+	PoolCoinTokenAccount   solana.PublicKey // writable
+	PoolPcTokenAccount     solana.PublicKey // writable
+	PoolWithdrawQueue      solana.PublicKey // writable
+	PoolTempLpTokenAccount solana.PublicKey // writable
+	SerumProgram           solana.PublicKey
+	SerumMarket            solana.PublicKey // writable
+	SerumCoinVaultAccount  solana.PublicKey // writable
+	SerumPcVaultAccount    solana.PublicKey // writable
+	SerumVaultSigner       solana.PublicKey
+	UserLpTokenAccount     solana.PublicKey // writable
+	UerCoinTokenAccount    solana.PublicKey // writable
+	UerPcTokenAccount      solana.PublicKey // writable
+	UserOwner              solana.PublicKey // signer
+	SerumEventQ            solana.PublicKey // writable
+	SerumBids              solana.PublicKey // writable
+	SerumAsks              solana.PublicKey // writable
+}
+
+// NewWithdrawInstruction builds the withdraw instruction. Remaining accounts are appended after the IDL ones
+func NewWithdrawInstruction(programID solana.PublicKey, args WithdrawArgs, accounts WithdrawAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(WithdrawInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode withdraw args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.LpMintAddress, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolWithdrawQueue, true, false),
+		solana.NewAccountMeta(accounts.PoolTempLpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.UserLpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UerCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UerPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserOwner, false, true),
+		solana.NewAccountMeta(accounts.SerumEventQ, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeWithdrawArgs decodes the data of the withdraw instruction
+func DecodeWithdrawArgs(data []byte) (*WithdrawArgs, error) {
+	var args WithdrawArgs
+	if err := decode(data, WithdrawInstructionDiscriminator, "withdraw", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeWithdrawAccounts maps the account keys of the withdraw instruction by IDL order, ignoring remaining accounts
+func DecodeWithdrawAccounts(keys []solana.PublicKey) (*WithdrawAccounts, error) {
+	if len(keys) < 22 {
+		return nil, fmt.Errorf("withdraw needs 22 accounts, got %d", len(keys))
+	}
+	return &WithdrawAccounts{
+		TokenProgram:           keys[0],
+		Amm:                    keys[1],
+		AmmAuthority:           keys[2],
+		AmmOpenOrders:          keys[3],
+		AmmTargetOrders:        keys[4],
+		LpMintAddress:          keys[5],
+		PoolCoinTokenAccount:   keys[6],
+		PoolPcTokenAccount:     keys[7],
+		PoolWithdrawQueue:      keys[8],
+		PoolTempLpTokenAccount: keys[9],
+		SerumProgram:           keys[10],
+		SerumMarket:            keys[11],
+		SerumCoinVaultAccount:  keys[12],
+		SerumPcVaultAccount:    keys[13],
+		SerumVaultSigner:       keys[14],
+		UserLpTokenAccount:     keys[15],
+		UerCoinTokenAccount:    keys[16],
+		UerPcTokenAccount:      keys[17],
+		UserOwner:              keys[18],
+		SerumEventQ:            keys[19],
+		SerumBids:              keys[20],
+		SerumAsks:              keys[21],
+	}, nil
+}
+
+// MigrateToOpenBookInstructionDiscriminator prefixes the data of every migrateToOpenBook instruction
+var MigrateToOpenBookInstructionDiscriminator = []byte{5}
+
+// MigrateToOpenBookAccounts lists the accounts of the migrateToOpenBook instruction in IDL order
+type MigrateToOpenBookAccounts struct {
+	TokenProgram     solana.PublicKey
+	SystemProgram    solana.PublicKey
+	Rent             solana.PublicKey
+	Amm              solana.PublicKey // writable
+	AmmAuthority     solana.PublicKey
+	AmmOpenOrders    solana.PublicKey // writable
+	AmmTokenCoin     solana.PublicKey // writable
+	AmmTokenPc       solana.PublicKey // writable
+	AmmTargetOrders  solana.PublicKey // writable
+	SerumProgram     solana.PublicKey
+	SerumMarket      solana.PublicKey // writable
+	SerumBids        solana.PublicKey // writable
+	SerumAsks        solana.PublicKey // writable
+	SerumEventQueue  solana.PublicKey // writable
+	SerumCoinVault   solana.PublicKey // writable
+	SerumPcVault     solana.PublicKey // writable
+	SerumVaultSigner solana.PublicKey
+	NewAmmOpenOrders solana.PublicKey // writable
+	NewSerumProgram  solana.PublicKey
+	NewSerumMarket   solana.PublicKey
+	Admin            solana.PublicKey // writable, signer
+}
+
+// NewMigrateToOpenBookInstruction builds the migrateToOpenBook instruction. Remaining accounts are appended after the IDL ones
+func NewMigrateToOpenBookInstruction(programID solana.PublicKey, accounts MigrateToOpenBookAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(MigrateToOpenBookInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode migrateToOpenBook args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTokenCoin, true, false),
+		solana.NewAccountMeta(accounts.AmmTokenPc, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVault, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVault, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.NewAmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.NewSerumProgram, false, false),
+		solana.NewAccountMeta(accounts.NewSerumMarket, false, false),
+		solana.NewAccountMeta(accounts.Admin, true, true),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeMigrateToOpenBookAccounts maps the account keys of the migrateToOpenBook instruction by IDL order, ignoring remaining accounts
+func DecodeMigrateToOpenBookAccounts(keys []solana.PublicKey) (*MigrateToOpenBookAccounts, error) {
+	if len(keys) < 21 {
+		return nil, fmt.Errorf("migrateToOpenBook needs 21 accounts, got %d", len(keys))
+	}
+	return &MigrateToOpenBookAccounts{
+		TokenProgram:     keys[0],
+		SystemProgram:    keys[1],
+		Rent:             keys[2],
+		Amm:              keys[3],
+		AmmAuthority:     keys[4],
+		AmmOpenOrders:    keys[5],
+		AmmTokenCoin:     keys[6],
+		AmmTokenPc:       keys[7],
+		AmmTargetOrders:  keys[8],
+		SerumProgram:     keys[9],
+		SerumMarket:      keys[10],
+		SerumBids:        keys[11],
+		SerumAsks:        keys[12],
+		SerumEventQueue:  keys[13],
+		SerumCoinVault:   keys[14],
+		SerumPcVault:     keys[15],
+		SerumVaultSigner: keys[16],
+		NewAmmOpenOrders: keys[17],
+		NewSerumProgram:  keys[18],
+		NewSerumMarket:   keys[19],
+		Admin:            keys[20],
+	}, nil
+}
+
+// SetParamsInstructionDiscriminator prefixes the data of every setParams instruction
+var SetParamsInstructionDiscriminator = []byte{6}
+
+// SetParamsArgs holds the arguments of the setParams instruction
+type SetParamsArgs struct {
+	Param             uint8
+	Value             *uint64            `bin:"optional"`
+	NewPubkey         *solana.PublicKey  `bin:"optional"`
+	Fees              *Fees              `bin:"optional"`
+	LastOrderDistance *LastOrderDistance `bin:"optional"`
+	NeedTakeAmounts   *NeedTake          `bin:"optional"`
+}
+
+// SetParamsAccounts lists the accounts of the setParams instruction in IDL order
+type SetParamsAccounts struct {
+	TokenProgram     solana.PublicKey
+	Amm              solana.PublicKey // writable
+	AmmAuthority     solana.PublicKey
+	AmmOpenOrders    solana.PublicKey // writable
+	AmmTargetOrders  solana.PublicKey // writable
+	AmmCoinVault     solana.PublicKey // writable
+	AmmPcVault       solana.PublicKey // writable
+	SerumProgram     solana.PublicKey
+	SerumMarket      solana.PublicKey // writable
+	SerumCoinVault   solana.PublicKey // writable
+	SerumPcVault     solana.PublicKey // writable
+	SerumVaultSigner solana.PublicKey
+	SerumEventQueue  solana.PublicKey // writable
+	SerumBids        solana.PublicKey // writable
+	SerumAsks        solana.PublicKey // writable
+	AmmAdminAccount  solana.PublicKey // signer
+}
+
+// NewSetParamsInstruction builds the setParams instruction. Remaining accounts are appended after the IDL ones
+func NewSetParamsInstruction(programID solana.PublicKey, args SetParamsArgs, accounts SetParamsAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SetParamsInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setParams args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmCoinVault, true, false),
+		solana.NewAccountMeta(accounts.AmmPcVault, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVault, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVault, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+		solana.NewAccountMeta(accounts.AmmAdminAccount, false, true),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSetParamsArgs decodes the data of the setParams instruction
+func DecodeSetParamsArgs(data []byte) (*SetParamsArgs, error) {
+	var args SetParamsArgs
+	if err := decode(data, SetParamsInstructionDiscriminator, "setParams", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSetParamsAccounts maps the account keys of the setParams instruction by IDL order, ignoring remaining accounts
+func DecodeSetParamsAccounts(keys []solana.PublicKey) (*SetParamsAccounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("setParams needs 16 accounts, got %d", len(keys))
+	}
+	return &SetParamsAccounts{
+		TokenProgram:     keys[0],
+		Amm:              keys[1],
+		AmmAuthority:     keys[2],
+		AmmOpenOrders:    keys[3],
+		AmmTargetOrders:  keys[4],
+		AmmCoinVault:     keys[5],
+		AmmPcVault:       keys[6],
+		SerumProgram:     keys[7],
+		SerumMarket:      keys[8],
+		SerumCoinVault:   keys[9],
+		SerumPcVault:     keys[10],
+		SerumVaultSigner: keys[11],
+		SerumEventQueue:  keys[12],
+		SerumBids:        keys[13],
+		SerumAsks:        keys[14],
+		AmmAdminAccount:  keys[15],
+	}, nil
+}
+
+// WithdrawPnlInstructionDiscriminator prefixes the data of every withdrawPnl instruction
+var WithdrawPnlInstructionDiscriminator = []byte{7}
+
+// WithdrawPnlAccounts lists the accounts of the withdrawPnl instruction in IDL order
+type WithdrawPnlAccounts struct {
+	TokenProgram          solana.PublicKey
+	Amm                   solana.PublicKey // writable
+	AmmConfig             solana.PublicKey
+	AmmAuthority          solana.PublicKey
+	AmmOpenOrders         solana.PublicKey // writable
+	PoolCoinTokenAccount  solana.PublicKey // writable
+	PoolPcTokenAccount    solana.PublicKey // writable
+	CoinPnlTokenAccount   solana.PublicKey // writable
+	PcPnlTokenAccount     solana.PublicKey // writable
+	PnlOwnerAccount       solana.PublicKey // signer
+	AmmTargetOrders       solana.PublicKey // writable
+	SerumProgram          solana.PublicKey
+	SerumMarket           solana.PublicKey // writable
+	SerumEventQueue       solana.PublicKey
+	SerumCoinVaultAccount solana.PublicKey // writable
+	SerumPcVaultAccount   solana.PublicKey // writable
+	SerumVaultSigner      solana.PublicKey
+}
+
+// NewWithdrawPnlInstruction builds the withdrawPnl instruction. Remaining accounts are appended after the IDL ones
+func NewWithdrawPnlInstruction(programID solana.PublicKey, accounts WithdrawPnlAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(WithdrawPnlInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode withdrawPnl args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.CoinPnlTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PcPnlTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PnlOwnerAccount, false, true),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, false, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeWithdrawPnlAccounts maps the account keys of the withdrawPnl instruction by IDL order, ignoring remaining accounts
+func DecodeWithdrawPnlAccounts(keys []solana.PublicKey) (*WithdrawPnlAccounts, error) {
+	if len(keys) < 17 {
+		return nil, fmt.Errorf("withdrawPnl needs 17 accounts, got %d", len(keys))
+	}
+	return &WithdrawPnlAccounts{
+		TokenProgram:          keys[0],
+		Amm:                   keys[1],
+		AmmConfig:             keys[2],
+		AmmAuthority:          keys[3],
+		AmmOpenOrders:         keys[4],
+		PoolCoinTokenAccount:  keys[5],
+		PoolPcTokenAccount:    keys[6],
+		CoinPnlTokenAccount:   keys[7],
+		PcPnlTokenAccount:     keys[8],
+		PnlOwnerAccount:       keys[9],
+		AmmTargetOrders:       keys[10],
+		SerumProgram:          keys[11],
+		SerumMarket:           keys[12],
+		SerumEventQueue:       keys[13],
+		SerumCoinVaultAccount: keys[14],
+		SerumPcVaultAccount:   keys[15],
+		SerumVaultSigner:      keys[16],
+	}, nil
+}
+
+// WithdrawSrmInstructionDiscriminator prefixes the data of every withdrawSrm instruction
+var WithdrawSrmInstructionDiscriminator = []byte{8}
+
+// WithdrawSrmArgs holds the arguments of the withdrawSrm instruction
+type WithdrawSrmArgs struct {
+	Amount uint64
+}
+
+// WithdrawSrmAccounts lists the accounts of the withdrawSrm instruction in IDL order
+type WithdrawSrmAccounts struct {
+	TokenProgram    solana.PublicKey
+	Amm             solana.PublicKey
+	AmmOwnerAccount solana.PublicKey // signer
+	AmmAuthority    solana.PublicKey
+	SrmToken        solana.PublicKey // writable
+	DestSrmToken    solana.PublicKey // writable
+}
+
+// NewWithdrawSrmInstruction builds the withdrawSrm instruction. Remaining accounts are appended after the IDL ones
+func NewWithdrawSrmInstruction(programID solana.PublicKey, args WithdrawSrmArgs, accounts WithdrawSrmAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(WithdrawSrmInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode withdrawSrm args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, false, false),
+		solana.NewAccountMeta(accounts.AmmOwnerAccount, false, true),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.SrmToken, true, false),
+		solana.NewAccountMeta(accounts.DestSrmToken, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeWithdrawSrmArgs decodes the data of the withdrawSrm instruction
+func DecodeWithdrawSrmArgs(data []byte) (*WithdrawSrmArgs, error) {
+	var args WithdrawSrmArgs
+	if err := decode(data, WithdrawSrmInstructionDiscriminator, "withdrawSrm", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeWithdrawSrmAccounts maps the account keys of the withdrawSrm instruction by IDL order, ignoring remaining accounts
+func DecodeWithdrawSrmAccounts(keys []solana.PublicKey) (*WithdrawSrmAccounts, error) {
+	if len(keys) < 6 {
+		return nil, fmt.Errorf("withdrawSrm needs 6 accounts, got %d", len(keys))
+	}
+	return &WithdrawSrmAccounts{
+		TokenProgram:    keys[0],
+		Amm:             keys[1],
+		AmmOwnerAccount: keys[2],
+		AmmAuthority:    keys[3],
+		SrmToken:        keys[4],
+		DestSrmToken:    keys[5],
+	}, nil
+}
+
+// SwapBaseInInstructionDiscriminator prefixes the data of every swapBaseIn instruction
+var SwapBaseInInstructionDiscriminator = []byte{9}
+
+// SwapBaseInArgs holds the arguments of the swapBaseIn instruction
+type SwapBaseInArgs struct {
+	AmountIn         uint64
+	MinimumAmountOut uint64
+}
+
+// SwapBaseInAccounts lists the accounts of the swapBaseIn instruction in IDL order
+type SwapBaseInAccounts struct {
+	TokenProgram               solana.PublicKey
+	Amm                        solana.PublicKey // writable
+	AmmAuthority               solana.PublicKey
+	AmmOpenOrders              solana.PublicKey // writable
+	AmmTargetOrders            solana.PublicKey // writable
+	PoolCoinTokenAccount       solana.PublicKey // writable
+	PoolPcTokenAccount         solana.PublicKey // writable
+	SerumProgram               solana.PublicKey
+	SerumMarket                solana.PublicKey // writable
+	SerumBids                  solana.PublicKey // writable
+	SerumAsks                  solana.PublicKey // writable
+	SerumEventQueue            solana.PublicKey // writable
+	SerumCoinVaultAccount      solana.PublicKey // writable
+	SerumPcVaultAccount        solana.PublicKey // writable
+	SerumVaultSigner           solana.PublicKey
+	UerSourceTokenAccount      solana.PublicKey // writable
+	UerDestinationTokenAccount solana.PublicKey // writable
+	UserSourceOwner            solana.PublicKey // signer
+}
+
+// NewSwapBaseInInstruction builds the swapBaseIn instruction. Remaining accounts are appended after the IDL ones
+func NewSwapBaseInInstruction(programID solana.PublicKey, args SwapBaseInArgs, accounts SwapBaseInAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapBaseInInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swapBaseIn args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.UerSourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UerDestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserSourceOwner, false, true),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapBaseInArgs decodes the data of the swapBaseIn instruction
+func DecodeSwapBaseInArgs(data []byte) (*SwapBaseInArgs, error) {
+	var args SwapBaseInArgs
+	if err := decode(data, SwapBaseInInstructionDiscriminator, "swapBaseIn", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapBaseInAccounts maps the account keys of the swapBaseIn instruction by IDL order, ignoring remaining accounts
+func DecodeSwapBaseInAccounts(keys []solana.PublicKey) (*SwapBaseInAccounts, error) {
+	if len(keys) < 18 {
+		return nil, fmt.Errorf("swapBaseIn needs 18 accounts, got %d", len(keys))
+	}
+	return &SwapBaseInAccounts{
+		TokenProgram:               keys[0],
+		Amm:                        keys[1],
+		AmmAuthority:               keys[2],
+		AmmOpenOrders:              keys[3],
+		AmmTargetOrders:            keys[4],
+		PoolCoinTokenAccount:       keys[5],
+		PoolPcTokenAccount:         keys[6],
+		SerumProgram:               keys[7],
+		SerumMarket:                keys[8],
+		SerumBids:                  keys[9],
+		SerumAsks:                  keys[10],
+		SerumEventQueue:            keys[11],
+		SerumCoinVaultAccount:      keys[12],
+		SerumPcVaultAccount:        keys[13],
+		SerumVaultSigner:           keys[14],
+		UerSourceTokenAccount:      keys[15],
+		UerDestinationTokenAccount: keys[16],
+		UserSourceOwner:            keys[17],
+	}, nil
+}
+
+// PreInitializeInstructionDiscriminator prefixes the data of every preInitialize instruction
+var PreInitializeInstructionDiscriminator = []byte{10}
+
+// PreInitializeArgs holds the arguments of the preInitialize instruction
+type PreInitializeArgs struct {
+	Nonce uint8
+}
+
+// PreInitializeAccounts lists the accounts of the preInitialize instruction in IDL order
+type PreInitializeAccounts struct {
+	TokenProgram           solana.PublicKey
+	SystemProgram          solana.PublicKey
+	Rent                   solana.PublicKey
+	AmmTargetOrders        solana.PublicKey // writable
+	PoolWithdrawQueue      solana.PublicKey // writable
+	AmmAuthority           solana.PublicKey
+	LpMintAddress          solana.PublicKey // writable
+	CoinMintAddress        solana.PublicKey
+	PcMintAddress          solana.PublicKey
+	PoolCoinTokenAccount   solana.PublicKey // writable
+	PoolPcTokenAccount     solana.PublicKey // writable
+	PoolTempLpTokenAccount solana.PublicKey // writable
+	SerumMarket            solana.PublicKey
+	UserWallet             solana.PublicKey // writable, signer
+}
+
+// NewPreInitializeInstruction builds the preInitialize instruction. Remaining accounts are appended after the IDL ones
+func NewPreInitializeInstruction(programID solana.PublicKey, args PreInitializeArgs, accounts PreInitializeAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(PreInitializeInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode preInitialize args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolWithdrawQueue, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.LpMintAddress, true, false),
+		solana.NewAccountMeta(accounts.CoinMintAddress, false, false),
+		solana.NewAccountMeta(accounts.PcMintAddress, false, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolTempLpTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumMarket, false, false),
+		solana.NewAccountMeta(accounts.UserWallet, true, true),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodePreInitializeArgs decodes the data of the preInitialize instruction
+func DecodePreInitializeArgs(data []byte) (*PreInitializeArgs, error) {
+	var args PreInitializeArgs
+	if err := decode(data, PreInitializeInstructionDiscriminator, "preInitialize", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodePreInitializeAccounts maps the account keys of the preInitialize instruction by IDL order, ignoring remaining accounts
+func DecodePreInitializeAccounts(keys []solana.PublicKey) (*PreInitializeAccounts, error) {
+	if len(keys) < 14 {
+		return nil, fmt.Errorf("preInitialize needs 14 accounts, got %d", len(keys))
+	}
+	return &PreInitializeAccounts{
+		TokenProgram:           keys[0],
+		SystemProgram:          keys[1],
+		Rent:                   keys[2],
+		AmmTargetOrders:        keys[3],
+		PoolWithdrawQueue:      keys[4],
+		AmmAuthority:           keys[5],
+		LpMintAddress:          keys[6],
+		CoinMintAddress:        keys[7],
+		PcMintAddress:          keys[8],
+		PoolCoinTokenAccount:   keys[9],
+		PoolPcTokenAccount:     keys[10],
+		PoolTempLpTokenAccount: keys[11],
+		SerumMarket:            keys[12],
+		UserWallet:             keys[13],
+	}, nil
+}
+
+// SwapBaseOutInstructionDiscriminator prefixes the data of every swapBaseOut instruction
+var SwapBaseOutInstructionDiscriminator = []byte{11}
+
+// SwapBaseOutArgs holds the arguments of the swapBaseOut instruction
+type SwapBaseOutArgs struct {
+	MaxAmountIn uint64
+	AmountOut   uint64
+}
+
+// SwapBaseOutAccounts lists the accounts of the swapBaseOut instruction in IDL order
+type SwapBaseOutAccounts struct {
+	TokenProgram               solana.PublicKey
+	Amm                        solana.PublicKey // writable
+	AmmAuthority               solana.PublicKey
+	AmmOpenOrders              solana.PublicKey // writable
+	AmmTargetOrders            solana.PublicKey // writable
+	PoolCoinTokenAccount       solana.PublicKey // writable
+	PoolPcTokenAccount         solana.PublicKey // writable
+	SerumProgram               solana.PublicKey
+	SerumMarket                solana.PublicKey // writable
+	SerumBids                  solana.PublicKey // writable
+	SerumAsks                  solana.PublicKey // writable
+	SerumEventQueue            solana.PublicKey // writable
+	SerumCoinVaultAccount      solana.PublicKey // writable
+	SerumPcVaultAccount        solana.PublicKey // writable
+	SerumVaultSigner           solana.PublicKey
+	UerSourceTokenAccount      solana.PublicKey // writable
+	UerDestinationTokenAccount solana.PublicKey // writable
+	UserSourceOwner            solana.PublicKey // signer
+}
+
+// NewSwapBaseOutInstruction builds the swapBaseOut instruction. Remaining accounts are appended after the IDL ones
+func NewSwapBaseOutInstruction(programID solana.PublicKey, args SwapBaseOutArgs, accounts SwapBaseOutAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapBaseOutInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swapBaseOut args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, true, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.UerSourceTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UerDestinationTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.UserSourceOwner, false, true),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapBaseOutArgs decodes the data of the swapBaseOut instruction
+func DecodeSwapBaseOutArgs(data []byte) (*SwapBaseOutArgs, error) {
+	var args SwapBaseOutArgs
+	if err := decode(data, SwapBaseOutInstructionDiscriminator, "swapBaseOut", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapBaseOutAccounts maps the account keys of the swapBaseOut instruction by IDL order, ignoring remaining accounts
+func DecodeSwapBaseOutAccounts(keys []solana.PublicKey) (*SwapBaseOutAccounts, error) {
+	if len(keys) < 18 {
+		return nil, fmt.Errorf("swapBaseOut needs 18 accounts, got %d", len(keys))
+	}
+	return &SwapBaseOutAccounts{
+		TokenProgram:               keys[0],
+		Amm:                        keys[1],
+		AmmAuthority:               keys[2],
+		AmmOpenOrders:              keys[3],
+		AmmTargetOrders:            keys[4],
+		PoolCoinTokenAccount:       keys[5],
+		PoolPcTokenAccount:         keys[6],
+		SerumProgram:               keys[7],
+		SerumMarket:                keys[8],
+		SerumBids:                  keys[9],
+		SerumAsks:                  keys[10],
+		SerumEventQueue:            keys[11],
+		SerumCoinVaultAccount:      keys[12],
+		SerumPcVaultAccount:        keys[13],
+		SerumVaultSigner:           keys[14],
+		UerSourceTokenAccount:      keys[15],
+		UerDestinationTokenAccount: keys[16],
+		UserSourceOwner:            keys[17],
+	}, nil
+}
+
+// SimulateInfoInstructionDiscriminator prefixes the data of every simulateInfo instruction
+var SimulateInfoInstructionDiscriminator = []byte{12}
+
+// SimulateInfoArgs holds the arguments of the simulateInfo instruction
+type SimulateInfoArgs struct {
+	Param            uint8
+	SwapBaseInValue  *SwapInstructionBaseIn  `bin:"optional"`
+	SwapBaseOutValue *SwapInstructionBaseOut `bin:"optional"`
+}
+
+// SimulateInfoAccounts lists the accounts of the simulateInfo instruction in IDL order
+type SimulateInfoAccounts struct {
+	Amm                  solana.PublicKey
+	AmmAuthority         solana.PublicKey
+	AmmOpenOrders        solana.PublicKey
+	PoolCoinTokenAccount solana.PublicKey
+	PoolPcTokenAccount   solana.PublicKey
+	LpMintAddress        solana.PublicKey
+	SerumMarket          solana.PublicKey
+	SerumEventQueue      solana.PublicKey
+}
+
+// NewSimulateInfoInstruction builds the simulateInfo instruction. Remaining accounts are appended after the IDL ones
+func NewSimulateInfoInstruction(programID solana.PublicKey, args SimulateInfoArgs, accounts SimulateInfoAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SimulateInfoInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode simulateInfo args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Amm, false, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, false, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, false, false),
+		solana.NewAccountMeta(accounts.LpMintAddress, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, false, false),
+		solana.NewAccountMeta(accounts.SerumEventQueue, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSimulateInfoArgs decodes the data of the simulateInfo instruction
+func DecodeSimulateInfoArgs(data []byte) (*SimulateInfoArgs, error) {
+	var args SimulateInfoArgs
+	if err := decode(data, SimulateInfoInstructionDiscriminator, "simulateInfo", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSimulateInfoAccounts maps the account keys of the simulateInfo instruction by IDL order, ignoring remaining accounts
+func DecodeSimulateInfoAccounts(keys []solana.PublicKey) (*SimulateInfoAccounts, error) {
+	if len(keys) < 8 {
+		return nil, fmt.Errorf("simulateInfo needs 8 accounts, got %d", len(keys))
+	}
+	return &SimulateInfoAccounts{
+		Amm:                  keys[0],
+		AmmAuthority:         keys[1],
+		AmmOpenOrders:        keys[2],
+		PoolCoinTokenAccount: keys[3],
+		PoolPcTokenAccount:   keys[4],
+		LpMintAddress:        keys[5],
+		SerumMarket:          keys[6],
+		SerumEventQueue:      keys[7],
+	}, nil
+}
+
+// AdminCancelOrdersInstructionDiscriminator prefixes the data of every adminCancelOrders instruction
+var AdminCancelOrdersInstructionDiscriminator = []byte{13}
+
+// AdminCancelOrdersArgs holds the arguments of the adminCancelOrders instruction
+type AdminCancelOrdersArgs struct {
+	Limit uint16
+}
+
+// AdminCancelOrdersAccounts lists the accounts of the adminCancelOrders instruction in IDL order
+type AdminCancelOrdersAccounts struct {
+	TokenProgram          solana.PublicKey
+	Amm                   solana.PublicKey
+	AmmAuthority          solana.PublicKey
+	AmmOpenOrders         solana.PublicKey // writable
+	AmmTargetOrders       solana.PublicKey // writable
+	PoolCoinTokenAccount  solana.PublicKey // writable
+	PoolPcTokenAccount    solana.PublicKey // writable
+	AmmOwnerAccount       solana.PublicKey // signer
+	AmmConfig             solana.PublicKey // writable
+	SerumProgram          solana.PublicKey
+	SerumMarket           solana.PublicKey // writable
+	SerumCoinVaultAccount solana.PublicKey // writable
+	SerumPcVaultAccount   solana.PublicKey // writable
+	SerumVaultSigner      solana.PublicKey
+	SerumEventQ           solana.PublicKey // writable
+	SerumBids             solana.PublicKey // writable
+	SerumAsks             solana.PublicKey // writable
+}
+
+// NewAdminCancelOrdersInstruction builds the adminCancelOrders instruction. Remaining accounts are appended after the IDL ones
+func NewAdminCancelOrdersInstruction(programID solana.PublicKey, args AdminCancelOrdersArgs, accounts AdminCancelOrdersAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(AdminCancelOrdersInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode adminCancelOrders args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.Amm, false, false),
+		solana.NewAccountMeta(accounts.AmmAuthority, false, false),
+		solana.NewAccountMeta(accounts.AmmOpenOrders, true, false),
+		solana.NewAccountMeta(accounts.AmmTargetOrders, true, false),
+		solana.NewAccountMeta(accounts.PoolCoinTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolPcTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.AmmOwnerAccount, false, true),
+		solana.NewAccountMeta(accounts.AmmConfig, true, false),
+		solana.NewAccountMeta(accounts.SerumProgram, false, false),
+		solana.NewAccountMeta(accounts.SerumMarket, true, false),
+		solana.NewAccountMeta(accounts.SerumCoinVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumPcVaultAccount, true, false),
+		solana.NewAccountMeta(accounts.SerumVaultSigner, false, false),
+		solana.NewAccountMeta(accounts.SerumEventQ, true, false),
+		solana.NewAccountMeta(accounts.SerumBids, true, false),
+		solana.NewAccountMeta(accounts.SerumAsks, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeAdminCancelOrdersArgs decodes the data of the adminCancelOrders instruction
+func DecodeAdminCancelOrdersArgs(data []byte) (*AdminCancelOrdersArgs, error) {
+	var args AdminCancelOrdersArgs
+	if err := decode(data, AdminCancelOrdersInstructionDiscriminator, "adminCancelOrders", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeAdminCancelOrdersAccounts maps the account keys of the adminCancelOrders instruction by IDL order, ignoring remaining accounts
+func DecodeAdminCancelOrdersAccounts(keys []solana.PublicKey) (*AdminCancelOrdersAccounts, error) {
+	if len(keys) < 17 {
+		return nil, fmt.Errorf("adminCancelOrders needs 17 accounts, got %d", len(keys))
+	}
+	return &AdminCancelOrdersAccounts{
+		TokenProgram:          keys[0],
+		Amm:                   keys[1],
+		AmmAuthority:          keys[2],
+		AmmOpenOrders:         keys[3],
+		AmmTargetOrders:       keys[4],
+		PoolCoinTokenAccount:  keys[5],
+		PoolPcTokenAccount:    keys[6],
+		AmmOwnerAccount:       keys[7],
+		AmmConfig:             keys[8],
+		SerumProgram:          keys[9],
+		SerumMarket:           keys[10],
+		SerumCoinVaultAccount: keys[11],
+		SerumPcVaultAccount:   keys[12],
+		SerumVaultSigner:      keys[13],
+		SerumEventQ:           keys[14],
+		SerumBids:             keys[15],
+		SerumAsks:             keys[16],
+	}, nil
+}
+
+// CreateConfigAccountInstructionDiscriminator prefixes the data of every createConfigAccount instruction
+var CreateConfigAccountInstructionDiscriminator = []byte{14}
+
+// CreateConfigAccountAccounts lists the accounts of the createConfigAccount instruction in IDL order
+type CreateConfigAccountAccounts struct {
+	Admin         solana.PublicKey // writable, signer
+	AmmConfig     solana.PublicKey // writable
+	Owner         solana.PublicKey
+	SystemProgram solana.PublicKey
+	Rent          solana.PublicKey
+}
+
+// NewCreateConfigAccountInstruction builds the createConfigAccount instruction. Remaining accounts are appended after the IDL ones
+func NewCreateConfigAccountInstruction(programID solana.PublicKey, accounts CreateConfigAccountAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateConfigAccountInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createConfigAccount args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Admin, true, true),
+		solana.NewAccountMeta(accounts.AmmConfig, true, false),
+		solana.NewAccountMeta(accounts.Owner, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateConfigAccountAccounts maps the account keys of the createConfigAccount instruction by IDL order, ignoring remaining accounts
+func DecodeCreateConfigAccountAccounts(keys []solana.PublicKey) (*CreateConfigAccountAccounts, error) {
+	if len(keys) < 5 {
+		return nil, fmt.Errorf("createConfigAccount needs 5 accounts, got %d", len(keys))
+	}
+	return &CreateConfigAccountAccounts{
+		Admin:         keys[0],
+		AmmConfig:     keys[1],
+		Owner:         keys[2],
+		SystemProgram: keys[3],
+		Rent:          keys[4],
+	}, nil
+}
+
+// UpdateConfigAccountInstructionDiscriminator prefixes the data of every updateConfigAccount instruction
+var UpdateConfigAccountInstructionDiscriminator = []byte{15}
+
+// UpdateConfigAccountArgs holds the arguments of the updateConfigAccount instruction
+type UpdateConfigAccountArgs struct {
+	Param uint8
+	Owner solana.PublicKey
+}
+
+// UpdateConfigAccountAccounts lists the accounts of the updateConfigAccount instruction in IDL order
+type UpdateConfigAccountAccounts struct {
+	Admin     solana.PublicKey // signer
+	AmmConfig solana.PublicKey // writable
+}
+
+// NewUpdateConfigAccountInstruction builds the updateConfigAccount instruction. Remaining accounts are appended after the IDL ones
+func NewUpdateConfigAccountInstruction(programID solana.PublicKey, args UpdateConfigAccountArgs, accounts UpdateConfigAccountAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(UpdateConfigAccountInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode updateConfigAccount args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Admin, false, true),
+		solana.NewAccountMeta(accounts.AmmConfig, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeUpdateConfigAccountArgs decodes the data of the updateConfigAccount instruction
+func DecodeUpdateConfigAccountArgs(data []byte) (*UpdateConfigAccountArgs, error) {
+	var args UpdateConfigAccountArgs
+	if err := decode(data, UpdateConfigAccountInstructionDiscriminator, "updateConfigAccount", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeUpdateConfigAccountAccounts maps the account keys of the updateConfigAccount instruction by IDL order, ignoring remaining accounts
+func DecodeUpdateConfigAccountAccounts(keys []solana.PublicKey) (*UpdateConfigAccountAccounts, error) {
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("updateConfigAccount needs 2 accounts, got %d", len(keys))
+	}
+	return &UpdateConfigAccountAccounts{
+		Admin:     keys[0],
+		AmmConfig: keys[1],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
@@ -0,0 +1,2180 @@
+// Code generated by idlgen from raydium_clmm_idl.json. DO NOT EDIT.
+
+// Package raydiumclmm holds typed bindings for the amm_v3 program, generated from its IDL.
+package raydiumclmm
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// InitializeRewardParam is the InitializeRewardParam type of the amm_v3 IDL
+type InitializeRewardParam struct {
+	OpenTime              uint64
+	EndTime               uint64
+	EmissionsPerSecondX64 bin.Uint128
+}
+
+// Observation is the Observation type of the amm_v3 IDL
+type Observation struct {
+	BlockTimestamp uint32
+	TickCumulative int64
+	Padding        [4]uint64
+}
+
+// PositionRewardInfo is the PositionRewardInfo type of the amm_v3 IDL
+type PositionRewardInfo struct {
+	GrowthInsideLastX64 bin.Uint128
+	RewardAmountOwed    uint64
+}
+
+// RewardInfo is the RewardInfo type of the amm_v3 IDL
+type RewardInfo struct {
+	RewardState           uint8
+	OpenTime              uint64
+	EndTime               uint64
+	LastUpdateTime        uint64
+	EmissionsPerSecondX64 bin.Uint128
+	RewardTotalEmissioned uint64
+	RewardClaimed         uint64
+	TokenMint             solana.PublicKey
+	TokenVault            solana.PublicKey
+	Authority             solana.PublicKey
+	RewardGrowthGlobalX64 bin.Uint128
+}
+
+// TickState is the TickState type of the amm_v3 IDL
+type TickState struct {
+	Tick                    int32
+	LiquidityNet            bin.Int128
+	LiquidityGross          bin.Uint128
+	FeeGrowthOutside0X64    bin.Uint128
+	FeeGrowthOutside1X64    bin.Uint128
+	RewardGrowthsOutsideX64 [3]bin.Uint128
+	Padding                 [13]uint32
+}
+
+// PoolStatusBitIndex is the PoolStatusBitIndex enum of the amm_v3 IDL
+type PoolStatusBitIndex uint8
+
+const (
+	PoolStatusBitIndexOpenPositionOrIncreaseLiquidity PoolStatusBitIndex = iota
+	PoolStatusBitIndexDecreaseLiquidity
+	PoolStatusBitIndexCollectFee
+	PoolStatusBitIndexCollectReward
+	PoolStatusBitIndexSwap
+)
+
+// PoolStatusBitFlag is the PoolStatusBitFlag enum of the amm_v3 IDL
+type PoolStatusBitFlag uint8
+
+const (
+	PoolStatusBitFlagEnable PoolStatusBitFlag = iota
+	PoolStatusBitFlagDisable
+)
+
+// RewardState is the RewardState enum of the amm_v3 IDL
+type RewardState uint8
+
+const (
+	RewardStateUninitialized RewardState = iota
+	RewardStateInitialized
+	RewardStateOpening
+	RewardStateEnded
+)
+
+// TickArryBitmap is the TickArryBitmap alias of the amm_v3 IDL
+type TickArryBitmap [8]uint64
+
+// AmmConfig is the AmmConfig account of the amm_v3 IDL, without its discriminator
+type AmmConfig struct {
+	Bump            uint8
+	Index           uint16
+	Owner           solana.PublicKey
+	ProtocolFeeRate uint32
+	TradeFeeRate    uint32
+	TickSpacing     uint16
+	FundFeeRate     uint32
+	PaddingU32      uint32
+	FundOwner       solana.PublicKey
+	Padding         [3]uint64
+}
+
+// AmmConfigDiscriminator prefixes every AmmConfig account
+var AmmConfigDiscriminator = []byte{218, 244, 33, 104, 203, 203, 43, 111}
+
+// DecodeAmmConfig decodes the raw data of a AmmConfig account
+func DecodeAmmConfig(data []byte) (*AmmConfig, error) {
+	var account AmmConfig
+	if err := decode(data, AmmConfigDiscriminator, "AmmConfig", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// OperationState is the OperationState account of the amm_v3 IDL, without its discriminator
+type OperationState struct {
+	Bump            uint8
+	OperationOwners [10]solana.PublicKey
+	WhitelistMints  [100]solana.PublicKey
+}
+
+// OperationStateDiscriminator prefixes every OperationState account
+var OperationStateDiscriminator = []byte{19, 236, 58, 237, 81, 222, 183, 252}
+
+// DecodeOperationState decodes the raw data of a OperationState account
+func DecodeOperationState(data []byte) (*OperationState, error) {
+	var account OperationState
+	if err := decode(data, OperationStateDiscriminator, "OperationState", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// ObservationState is the ObservationState account of the amm_v3 IDL, without its discriminator
+type ObservationState struct {
+	Initialized      bool
+	RecentEpoch      uint64
+	ObservationIndex uint16
+	PoolId           solana.PublicKey
+	Observations     [100]Observation
+	Padding          [4]uint64
+}
+
+// ObservationStateDiscriminator prefixes every ObservationState account
+var ObservationStateDiscriminator = []byte{122, 174, 197, 53, 129, 9, 165, 132}
+
+// DecodeObservationState decodes the raw data of a ObservationState account
+func DecodeObservationState(data []byte) (*ObservationState, error) {
+	var account ObservationState
+	if err := decode(data, ObservationStateDiscriminator, "ObservationState", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// PersonalPositionState is the PersonalPositionState account of the amm_v3 IDL, without its discriminator
+type PersonalPositionState struct {
+	Bump                    uint8
+	NftMint                 solana.PublicKey
+	PoolId                  solana.PublicKey
+	TickLowerIndex          int32
+	TickUpperIndex          int32
+	Liquidity               bin.Uint128
+	FeeGrowthInside0LastX64 bin.Uint128
+	FeeGrowthInside1LastX64 bin.Uint128
+	TokenFeesOwed0          uint64
+	TokenFeesOwed1          uint64
+	RewardInfos             [3]PositionRewardInfo
+	RecentEpoch             uint64
+	Padding                 [7]uint64
+}
+
+// PersonalPositionStateDiscriminator prefixes every PersonalPositionState account
+var PersonalPositionStateDiscriminator = []byte{70, 111, 150, 126, 230, 15, 25, 117}
+
+// DecodePersonalPositionState decodes the raw data of a PersonalPositionState account
+func DecodePersonalPositionState(data []byte) (*PersonalPositionState, error) {
+	var account PersonalPositionState
+	if err := decode(data, PersonalPositionStateDiscriminator, "PersonalPositionState", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// PoolState is the PoolState account of the amm_v3 IDL, without its discriminator
+type PoolState struct {
+	Bump                   [1]uint8
+	AmmConfig              solana.PublicKey
+	Owner                  solana.PublicKey
+	TokenMint0             solana.PublicKey
+	TokenMint1             solana.PublicKey
+	TokenVault0            solana.PublicKey
+	TokenVault1            solana.PublicKey
+	ObservationKey         solana.PublicKey
+	MintDecimals0          uint8
+	MintDecimals1          uint8
+	TickSpacing            uint16
+	Liquidity              bin.Uint128
+	SqrtPriceX64           bin.Uint128
+	TickCurrent            int32
+	Padding3               uint16
+	Padding4               uint16
+	FeeGrowthGlobal0X64    bin.Uint128
+	FeeGrowthGlobal1X64    bin.Uint128
+	ProtocolFeesToken0     uint64
+	ProtocolFeesToken1     uint64
+	SwapInAmountToken0     bin.Uint128
+	SwapOutAmountToken1    bin.Uint128
+	SwapInAmountToken1     bin.Uint128
+	SwapOutAmountToken0    bin.Uint128
+	Status                 uint8
+	Padding                [7]uint8
+	RewardInfos            [3]RewardInfo
+	TickArrayBitmap        [16]uint64
+	TotalFeesToken0        uint64
+	TotalFeesClaimedToken0 uint64
+	TotalFeesToken1        uint64
+	TotalFeesClaimedToken1 uint64
+	FundFeesToken0         uint64
+	FundFeesToken1         uint64
+	OpenTime               uint64
+	RecentEpoch            uint64
+	Padding1               [24]uint64
+	Padding2               [32]uint64
+}
+
+// PoolStateDiscriminator prefixes every PoolState account
+var PoolStateDiscriminator = []byte{247, 237, 227, 245, 215, 195, 222, 70}
+
+// DecodePoolState decodes the raw data of a PoolState account
+func DecodePoolState(data []byte) (*PoolState, error) {
+	var account PoolState
+	if err := decode(data, PoolStateDiscriminator, "PoolState", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// ProtocolPositionState is the ProtocolPositionState account of the amm_v3 IDL, without its discriminator
+type ProtocolPositionState struct {
+	Bump                    uint8
+	PoolId                  solana.PublicKey
+	TickLowerIndex          int32
+	TickUpperIndex          int32
+	Liquidity               bin.Uint128
+	FeeGrowthInside0LastX64 bin.Uint128
+	FeeGrowthInside1LastX64 bin.Uint128
+	TokenFeesOwed0          uint64
+	TokenFeesOwed1          uint64
+	RewardGrowthInside      [3]bin.Uint128
+	RecentEpoch             uint64
+	Padding                 [7]uint64
+}
+
+// ProtocolPositionStateDiscriminator prefixes every ProtocolPositionState account
+var ProtocolPositionStateDiscriminator = []byte{100, 226, 145, 99, 146, 218, 160, 106}
+
+// DecodeProtocolPositionState decodes the raw data of a ProtocolPositionState account
+func DecodeProtocolPositionState(data []byte) (*ProtocolPositionState, error) {
+	var account ProtocolPositionState
+	if err := decode(data, ProtocolPositionStateDiscriminator, "ProtocolPositionState", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// TickArrayState is the TickArrayState account of the amm_v3 IDL, without its discriminator
+type TickArrayState struct {
+	PoolId               solana.PublicKey
+	StartTickIndex       int32
+	Ticks                [60]TickState
+	InitializedTickCount uint8
+	RecentEpoch          uint64
+	Padding              [107]uint8
+}
+
+// TickArrayStateDiscriminator prefixes every TickArrayState account
+var TickArrayStateDiscriminator = []byte{192, 155, 85, 205, 49, 249, 129, 42}
+
+// DecodeTickArrayState decodes the raw data of a TickArrayState account
+func DecodeTickArrayState(data []byte) (*TickArrayState, error) {
+	var account TickArrayState
+	if err := decode(data, TickArrayStateDiscriminator, "TickArrayState", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// TickArrayBitmapExtension is the TickArrayBitmapExtension account of the amm_v3 IDL, without its discriminator
+type TickArrayBitmapExtension struct {
+	PoolId                  solana.PublicKey
+	PositiveTickArrayBitmap [14][8]uint64
+	NegativeTickArrayBitmap [14][8]uint64
+}
+
+// TickArrayBitmapExtensionDiscriminator prefixes every TickArrayBitmapExtension account
+var TickArrayBitmapExtensionDiscriminator = []byte{60, 150, 36, 219, 97, 128, 139, 153}
+
+// DecodeTickArrayBitmapExtension decodes the raw data of a TickArrayBitmapExtension account
+func DecodeTickArrayBitmapExtension(data []byte) (*TickArrayBitmapExtension, error) {
+	var account TickArrayBitmapExtension
+	if err := decode(data, TickArrayBitmapExtensionDiscriminator, "TickArrayBitmapExtension", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// ConfigChangeEvent is the ConfigChangeEvent event of the amm_v3 IDL
+type ConfigChangeEvent struct {
+	Index           uint16
+	Owner           solana.PublicKey
+	ProtocolFeeRate uint32
+	TradeFeeRate    uint32
+	TickSpacing     uint16
+	FundFeeRate     uint32
+	FundOwner       solana.PublicKey
+}
+
+// ConfigChangeEventDiscriminator prefixes every ConfigChangeEvent event
+var ConfigChangeEventDiscriminator = []byte{247, 189, 7, 119, 106, 112, 95, 151}
+
+// DecodeConfigChangeEvent decodes a ConfigChangeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeConfigChangeEvent(data []byte) (*ConfigChangeEvent, error) {
+	var event ConfigChangeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), ConfigChangeEventDiscriminator, "ConfigChangeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CreatePersonalPositionEvent is the CreatePersonalPositionEvent event of the amm_v3 IDL
+type CreatePersonalPositionEvent struct {
+	PoolState                 solana.PublicKey
+	Minter                    solana.PublicKey
+	NftOwner                  solana.PublicKey
+	TickLowerIndex            int32
+	TickUpperIndex            int32
+	Liquidity                 bin.Uint128
+	DepositAmount0            uint64
+	DepositAmount1            uint64
+	DepositAmount0TransferFee uint64
+	DepositAmount1TransferFee uint64
+}
+
+// CreatePersonalPositionEventDiscriminator prefixes every CreatePersonalPositionEvent event
+var CreatePersonalPositionEventDiscriminator = []byte{100, 30, 87, 249, 196, 223, 154, 206}
+
+// DecodeCreatePersonalPositionEvent decodes a CreatePersonalPositionEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeCreatePersonalPositionEvent(data []byte) (*CreatePersonalPositionEvent, error) {
+	var event CreatePersonalPositionEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), CreatePersonalPositionEventDiscriminator, "CreatePersonalPositionEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// IncreaseLiquidityEvent is the IncreaseLiquidityEvent event of the amm_v3 IDL
+type IncreaseLiquidityEvent struct {
+	PositionNftMint    solana.PublicKey
+	Liquidity          bin.Uint128
+	Amount0            uint64
+	Amount1            uint64
+	Amount0TransferFee uint64
+	Amount1TransferFee uint64
+}
+
+// IncreaseLiquidityEventDiscriminator prefixes every IncreaseLiquidityEvent event
+var IncreaseLiquidityEventDiscriminator = []byte{49, 79, 105, 212, 32, 34, 30, 84}
+
+// DecodeIncreaseLiquidityEvent decodes a IncreaseLiquidityEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeIncreaseLiquidityEvent(data []byte) (*IncreaseLiquidityEvent, error) {
+	var event IncreaseLiquidityEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), IncreaseLiquidityEventDiscriminator, "IncreaseLiquidityEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// DecreaseLiquidityEvent is the DecreaseLiquidityEvent event of the amm_v3 IDL
+type DecreaseLiquidityEvent struct {
+	PositionNftMint solana.PublicKey
+	Liquidity       bin.Uint128
+	DecreaseAmount0 uint64
+	DecreaseAmount1 uint64
+	FeeAmount0      uint64
+	FeeAmount1      uint64
+	RewardAmounts   [3]uint64
+	TransferFee0    uint64
+	TransferFee1    uint64
+}
+
+// DecreaseLiquidityEventDiscriminator prefixes every DecreaseLiquidityEvent event
+var DecreaseLiquidityEventDiscriminator = []byte{58, 222, 86, 58, 68, 50, 85, 56}
+
+// DecodeDecreaseLiquidityEvent decodes a DecreaseLiquidityEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeDecreaseLiquidityEvent(data []byte) (*DecreaseLiquidityEvent, error) {
+	var event DecreaseLiquidityEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), DecreaseLiquidityEventDiscriminator, "DecreaseLiquidityEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// LiquidityCalculateEvent is the LiquidityCalculateEvent event of the amm_v3 IDL
+type LiquidityCalculateEvent struct {
+	PoolLiquidity    bin.Uint128
+	PoolSqrtPriceX64 bin.Uint128
+	PoolTick         int32
+	CalcAmount0      uint64
+	CalcAmount1      uint64
+	TradeFeeOwed0    uint64
+	TradeFeeOwed1    uint64
+	TransferFee0     uint64
+	TransferFee1     uint64
+}
+
+// LiquidityCalculateEventDiscriminator prefixes every LiquidityCalculateEvent event
+var LiquidityCalculateEventDiscriminator = []byte{237, 112, 148, 230, 57, 84, 180, 162}
+
+// DecodeLiquidityCalculateEvent decodes a LiquidityCalculateEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeLiquidityCalculateEvent(data []byte) (*LiquidityCalculateEvent, error) {
+	var event LiquidityCalculateEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), LiquidityCalculateEventDiscriminator, "LiquidityCalculateEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CollectPersonalFeeEvent is the CollectPersonalFeeEvent event of the amm_v3 IDL
+type CollectPersonalFeeEvent struct {
+	PositionNftMint        solana.PublicKey
+	RecipientTokenAccount0 solana.PublicKey
+	RecipientTokenAccount1 solana.PublicKey
+	Amount0                uint64
+	Amount1                uint64
+}
+
+// CollectPersonalFeeEventDiscriminator prefixes every CollectPersonalFeeEvent event
+var CollectPersonalFeeEventDiscriminator = []byte{166, 174, 105, 192, 81, 161, 83, 105}
+
+// DecodeCollectPersonalFeeEvent decodes a CollectPersonalFeeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeCollectPersonalFeeEvent(data []byte) (*CollectPersonalFeeEvent, error) {
+	var event CollectPersonalFeeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), CollectPersonalFeeEventDiscriminator, "CollectPersonalFeeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// UpdateRewardInfosEvent is the UpdateRewardInfosEvent event of the amm_v3 IDL
+type UpdateRewardInfosEvent struct {
+	RewardGrowthGlobalX64 [3]bin.Uint128
+}
+
+// UpdateRewardInfosEventDiscriminator prefixes every UpdateRewardInfosEvent event
+var UpdateRewardInfosEventDiscriminator = []byte{109, 127, 186, 78, 114, 65, 37, 236}
+
+// DecodeUpdateRewardInfosEvent decodes a UpdateRewardInfosEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeUpdateRewardInfosEvent(data []byte) (*UpdateRewardInfosEvent, error) {
+	var event UpdateRewardInfosEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), UpdateRewardInfosEventDiscriminator, "UpdateRewardInfosEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// PoolCreatedEvent is the PoolCreatedEvent event of the amm_v3 IDL
+type PoolCreatedEvent struct {
+	TokenMint0   solana.PublicKey
+	TokenMint1   solana.PublicKey
+	TickSpacing  uint16
+	PoolState    solana.PublicKey
+	SqrtPriceX64 bin.Uint128
+	Tick         int32
+	TokenVault0  solana.PublicKey
+	TokenVault1  solana.PublicKey
+}
+
+// PoolCreatedEventDiscriminator prefixes every PoolCreatedEvent event
+var PoolCreatedEventDiscriminator = []byte{25, 94, 75, 47, 112, 99, 53, 63}
+
+// DecodePoolCreatedEvent decodes a PoolCreatedEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodePoolCreatedEvent(data []byte) (*PoolCreatedEvent, error) {
+	var event PoolCreatedEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), PoolCreatedEventDiscriminator, "PoolCreatedEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CollectProtocolFeeEvent is the CollectProtocolFeeEvent event of the amm_v3 IDL
+type CollectProtocolFeeEvent struct {
+	PoolState              solana.PublicKey
+	RecipientTokenAccount0 solana.PublicKey
+	RecipientTokenAccount1 solana.PublicKey
+	Amount0                uint64
+	Amount1                uint64
+}
+
+// CollectProtocolFeeEventDiscriminator prefixes every CollectProtocolFeeEvent event
+var CollectProtocolFeeEventDiscriminator = []byte{206, 87, 17, 79, 45, 41, 213, 61}
+
+// DecodeCollectProtocolFeeEvent decodes a CollectProtocolFeeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeCollectProtocolFeeEvent(data []byte) (*CollectProtocolFeeEvent, error) {
+	var event CollectProtocolFeeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), CollectProtocolFeeEventDiscriminator, "CollectProtocolFeeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// SwapEvent is the SwapEvent event of the amm_v3 IDL
+type SwapEvent struct {
+	PoolState     solana.PublicKey
+	Sender        solana.PublicKey
+	TokenAccount0 solana.PublicKey
+	TokenAccount1 solana.PublicKey
+	Amount0       uint64
+	TransferFee0  uint64
+	Amount1       uint64
+	TransferFee1  uint64
+	ZeroForOne    bool
+	SqrtPriceX64  bin.Uint128
+	Liquidity     bin.Uint128
+	Tick          int32
+}
+
+// SwapEventDiscriminator prefixes every SwapEvent event
+var SwapEventDiscriminator = []byte{64, 198, 205, 232, 38, 8, 113, 226}
+
+// DecodeSwapEvent decodes a SwapEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeSwapEvent(data []byte) (*SwapEvent, error) {
+	var event SwapEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), SwapEventDiscriminator, "SwapEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// LiquidityChangeEvent is the LiquidityChangeEvent event of the amm_v3 IDL
+type LiquidityChangeEvent struct {
+	PoolState       solana.PublicKey
+	Tick            int32
+	TickLower       int32
+	TickUpper       int32
+	LiquidityBefore bin.Uint128
+	LiquidityAfter  bin.Uint128
+}
+
+// LiquidityChangeEventDiscriminator prefixes every LiquidityChangeEvent event
+var LiquidityChangeEventDiscriminator = []byte{126, 240, 175, 206, 158, 88, 153, 107}
+
+// DecodeLiquidityChangeEvent decodes a LiquidityChangeEvent event from "Program data:" log bytes or emit_cpi instruction data
+func DecodeLiquidityChangeEvent(data []byte) (*LiquidityChangeEvent, error) {
+	var event LiquidityChangeEvent
+	if err := decode(bytes.TrimPrefix(data, eventInstructionTag), LiquidityChangeEventDiscriminator, "LiquidityChangeEvent", &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CreateAmmConfigInstructionDiscriminator prefixes the data of every createAmmConfig instruction
+var CreateAmmConfigInstructionDiscriminator = []byte{137, 52, 237, 212, 215, 117, 108, 104}
+
+// CreateAmmConfigArgs holds the arguments of the createAmmConfig instruction
+type CreateAmmConfigArgs struct {
+	Index           uint16
+	TickSpacing     uint16
+	TradeFeeRate    uint32
+	ProtocolFeeRate uint32
+	FundFeeRate     uint32
+}
+
+// CreateAmmConfigAccounts lists the accounts of the createAmmConfig instruction in IDL order
+type CreateAmmConfigAccounts struct {
+	Owner         solana.PublicKey // writable, signer
+	AmmConfig     solana.PublicKey // writable
+	SystemProgram solana.PublicKey
+}
+
+// NewCreateAmmConfigInstruction builds the createAmmConfig instruction. Remaining accounts are appended after the IDL ones
+func NewCreateAmmConfigInstruction(programID solana.PublicKey, args CreateAmmConfigArgs, accounts CreateAmmConfigAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateAmmConfigInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createAmmConfig args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Owner, true, true),
+		solana.NewAccountMeta(accounts.AmmConfig, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateAmmConfigArgs decodes the data of the createAmmConfig instruction
+func DecodeCreateAmmConfigArgs(data []byte) (*CreateAmmConfigArgs, error) {
+	var args CreateAmmConfigArgs
+	if err := decode(data, CreateAmmConfigInstructionDiscriminator, "createAmmConfig", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCreateAmmConfigAccounts maps the account keys of the createAmmConfig instruction by IDL order, ignoring remaining accounts
+func DecodeCreateAmmConfigAccounts(keys []solana.PublicKey) (*CreateAmmConfigAccounts, error) {
+	if len(keys) < 3 {
+		return nil, fmt.Errorf("createAmmConfig needs 3 accounts, got %d", len(keys))
+	}
+	return &CreateAmmConfigAccounts{
+		Owner:         keys[0],
+		AmmConfig:     keys[1],
+		SystemProgram: keys[2],
+	}, nil
+}
+
+// UpdateAmmConfigInstructionDiscriminator prefixes the data of every updateAmmConfig instruction
+var UpdateAmmConfigInstructionDiscriminator = []byte{49, 60, 174, 136, 154, 28, 116, 200}
+
+// UpdateAmmConfigArgs holds the arguments of the updateAmmConfig instruction
+type UpdateAmmConfigArgs struct {
+	Param uint8
+	Value uint32
+}
+
+// UpdateAmmConfigAccounts lists the accounts of the updateAmmConfig instruction in IDL order
+type UpdateAmmConfigAccounts struct {
+	Owner     solana.PublicKey // signer
+	AmmConfig solana.PublicKey // writable
+}
+
+// NewUpdateAmmConfigInstruction builds the updateAmmConfig instruction. Remaining accounts are appended after the IDL ones
+func NewUpdateAmmConfigInstruction(programID solana.PublicKey, args UpdateAmmConfigArgs, accounts UpdateAmmConfigAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(UpdateAmmConfigInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode updateAmmConfig args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Owner, false, true),
+		solana.NewAccountMeta(accounts.AmmConfig, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeUpdateAmmConfigArgs decodes the data of the updateAmmConfig instruction
+func DecodeUpdateAmmConfigArgs(data []byte) (*UpdateAmmConfigArgs, error) {
+	var args UpdateAmmConfigArgs
+	if err := decode(data, UpdateAmmConfigInstructionDiscriminator, "updateAmmConfig", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeUpdateAmmConfigAccounts maps the account keys of the updateAmmConfig instruction by IDL order, ignoring remaining accounts
+func DecodeUpdateAmmConfigAccounts(keys []solana.PublicKey) (*UpdateAmmConfigAccounts, error) {
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("updateAmmConfig needs 2 accounts, got %d", len(keys))
+	}
+	return &UpdateAmmConfigAccounts{
+		Owner:     keys[0],
+		AmmConfig: keys[1],
+	}, nil
+}
+
+// CreatePoolInstructionDiscriminator prefixes the data of every createPool instruction
+var CreatePoolInstructionDiscriminator = []byte{233, 146, 209, 142, 207, 104, 64, 188}
+
+// CreatePoolArgs holds the arguments of the createPool instruction
+type CreatePoolArgs struct {
+	SqrtPriceX64 bin.Uint128
+	OpenTime     uint64
+}
+
+// CreatePoolAccounts lists the accounts of the createPool instruction in IDL order
+type CreatePoolAccounts struct {
+	PoolCreator      solana.PublicKey // writable, signer
+	AmmConfig        solana.PublicKey
+	PoolState        solana.PublicKey // writable
+	TokenMint0       solana.PublicKey
+	TokenMint1       solana.PublicKey
+	TokenVault0      solana.PublicKey // writable
+	TokenVault1      solana.PublicKey // writable
+	ObservationState solana.PublicKey // writable
+	TickArrayBitmap  solana.PublicKey // writable
+	TokenProgram0    solana.PublicKey
+	TokenProgram1    solana.PublicKey
+	SystemProgram    solana.PublicKey
+	Rent             solana.PublicKey
+}
+
+// NewCreatePoolInstruction builds the createPool instruction. Remaining accounts are appended after the IDL ones
+func NewCreatePoolInstruction(programID solana.PublicKey, args CreatePoolArgs, accounts CreatePoolAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreatePoolInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createPool args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.PoolCreator, true, true),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.TokenMint0, false, false),
+		solana.NewAccountMeta(accounts.TokenMint1, false, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.ObservationState, true, false),
+		solana.NewAccountMeta(accounts.TickArrayBitmap, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram0, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram1, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreatePoolArgs decodes the data of the createPool instruction
+func DecodeCreatePoolArgs(data []byte) (*CreatePoolArgs, error) {
+	var args CreatePoolArgs
+	if err := decode(data, CreatePoolInstructionDiscriminator, "createPool", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCreatePoolAccounts maps the account keys of the createPool instruction by IDL order, ignoring remaining accounts
+func DecodeCreatePoolAccounts(keys []solana.PublicKey) (*CreatePoolAccounts, error) {
+	if len(keys) < 13 {
+		return nil, fmt.Errorf("createPool needs 13 accounts, got %d", len(keys))
+	}
+	return &CreatePoolAccounts{
+		PoolCreator:      keys[0],
+		AmmConfig:        keys[1],
+		PoolState:        keys[2],
+		TokenMint0:       keys[3],
+		TokenMint1:       keys[4],
+		TokenVault0:      keys[5],
+		TokenVault1:      keys[6],
+		ObservationState: keys[7],
+		TickArrayBitmap:  keys[8],
+		TokenProgram0:    keys[9],
+		TokenProgram1:    keys[10],
+		SystemProgram:    keys[11],
+		Rent:             keys[12],
+	}, nil
+}
+
+// UpdatePoolStatusInstructionDiscriminator prefixes the data of every updatePoolStatus instruction
+var UpdatePoolStatusInstructionDiscriminator = []byte{130, 87, 108, 6, 46, 224, 117, 123}
+
+// UpdatePoolStatusArgs holds the arguments of the updatePoolStatus instruction
+type UpdatePoolStatusArgs struct {
+	Status uint8
+}
+
+// UpdatePoolStatusAccounts lists the accounts of the updatePoolStatus instruction in IDL order
+type UpdatePoolStatusAccounts struct {
+	Authority solana.PublicKey // signer
+	PoolState solana.PublicKey // writable
+}
+
+// NewUpdatePoolStatusInstruction builds the updatePoolStatus instruction. Remaining accounts are appended after the IDL ones
+func NewUpdatePoolStatusInstruction(programID solana.PublicKey, args UpdatePoolStatusArgs, accounts UpdatePoolStatusAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(UpdatePoolStatusInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode updatePoolStatus args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Authority, false, true),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeUpdatePoolStatusArgs decodes the data of the updatePoolStatus instruction
+func DecodeUpdatePoolStatusArgs(data []byte) (*UpdatePoolStatusArgs, error) {
+	var args UpdatePoolStatusArgs
+	if err := decode(data, UpdatePoolStatusInstructionDiscriminator, "updatePoolStatus", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeUpdatePoolStatusAccounts maps the account keys of the updatePoolStatus instruction by IDL order, ignoring remaining accounts
+func DecodeUpdatePoolStatusAccounts(keys []solana.PublicKey) (*UpdatePoolStatusAccounts, error) {
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("updatePoolStatus needs 2 accounts, got %d", len(keys))
+	}
+	return &UpdatePoolStatusAccounts{
+		Authority: keys[0],
+		PoolState: keys[1],
+	}, nil
+}
+
+// CreateOperationAccountInstructionDiscriminator prefixes the data of every createOperationAccount instruction
+var CreateOperationAccountInstructionDiscriminator = []byte{63, 87, 148, 33, 109, 35, 8, 104}
+
+// CreateOperationAccountAccounts lists the accounts of the createOperationAccount instruction in IDL order
+type CreateOperationAccountAccounts struct {
+	Owner          solana.PublicKey // writable, signer
+	OperationState solana.PublicKey // writable
+	SystemProgram  solana.PublicKey
+}
+
+// NewCreateOperationAccountInstruction builds the createOperationAccount instruction. Remaining accounts are appended after the IDL ones
+func NewCreateOperationAccountInstruction(programID solana.PublicKey, accounts CreateOperationAccountAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CreateOperationAccountInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode createOperationAccount args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Owner, true, true),
+		solana.NewAccountMeta(accounts.OperationState, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCreateOperationAccountAccounts maps the account keys of the createOperationAccount instruction by IDL order, ignoring remaining accounts
+func DecodeCreateOperationAccountAccounts(keys []solana.PublicKey) (*CreateOperationAccountAccounts, error) {
+	if len(keys) < 3 {
+		return nil, fmt.Errorf("createOperationAccount needs 3 accounts, got %d", len(keys))
+	}
+	return &CreateOperationAccountAccounts{
+		Owner:          keys[0],
+		OperationState: keys[1],
+		SystemProgram:  keys[2],
+	}, nil
+}
+
+// UpdateOperationAccountInstructionDiscriminator prefixes the data of every updateOperationAccount instruction
+var UpdateOperationAccountInstructionDiscriminator = []byte{127, 70, 119, 40, 188, 227, 61, 7}
+
+// UpdateOperationAccountArgs holds the arguments of the updateOperationAccount instruction
+type UpdateOperationAccountArgs struct {
+	Param uint8
+	Keys  []solana.PublicKey
+}
+
+// UpdateOperationAccountAccounts lists the accounts of the updateOperationAccount instruction in IDL order
+type UpdateOperationAccountAccounts struct {
+	Owner          solana.PublicKey // signer
+	OperationState solana.PublicKey // writable
+	SystemProgram  solana.PublicKey
+}
+
+// NewUpdateOperationAccountInstruction builds the updateOperationAccount instruction. Remaining accounts are appended after the IDL ones
+func NewUpdateOperationAccountInstruction(programID solana.PublicKey, args UpdateOperationAccountArgs, accounts UpdateOperationAccountAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(UpdateOperationAccountInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode updateOperationAccount args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Owner, false, true),
+		solana.NewAccountMeta(accounts.OperationState, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeUpdateOperationAccountArgs decodes the data of the updateOperationAccount instruction
+func DecodeUpdateOperationAccountArgs(data []byte) (*UpdateOperationAccountArgs, error) {
+	var args UpdateOperationAccountArgs
+	if err := decode(data, UpdateOperationAccountInstructionDiscriminator, "updateOperationAccount", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeUpdateOperationAccountAccounts maps the account keys of the updateOperationAccount instruction by IDL order, ignoring remaining accounts
+func DecodeUpdateOperationAccountAccounts(keys []solana.PublicKey) (*UpdateOperationAccountAccounts, error) {
+	if len(keys) < 3 {
+		return nil, fmt.Errorf("updateOperationAccount needs 3 accounts, got %d", len(keys))
+	}
+	return &UpdateOperationAccountAccounts{
+		Owner:          keys[0],
+		OperationState: keys[1],
+		SystemProgram:  keys[2],
+	}, nil
+}
+
+// TransferRewardOwnerInstructionDiscriminator prefixes the data of every transferRewardOwner instruction
+var TransferRewardOwnerInstructionDiscriminator = []byte{7, 22, 12, 83, 242, 43, 48, 121}
+
+// TransferRewardOwnerArgs holds the arguments of the transferRewardOwner instruction
+type TransferRewardOwnerArgs struct {
+	NewOwner solana.PublicKey
+}
+
+// TransferRewardOwnerAccounts lists the accounts of the transferRewardOwner instruction in IDL order
+type TransferRewardOwnerAccounts struct {
+	Authority solana.PublicKey // signer
+	PoolState solana.PublicKey // writable
+}
+
+// NewTransferRewardOwnerInstruction builds the transferRewardOwner instruction. Remaining accounts are appended after the IDL ones
+func NewTransferRewardOwnerInstruction(programID solana.PublicKey, args TransferRewardOwnerArgs, accounts TransferRewardOwnerAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(TransferRewardOwnerInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transferRewardOwner args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Authority, false, true),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeTransferRewardOwnerArgs decodes the data of the transferRewardOwner instruction
+func DecodeTransferRewardOwnerArgs(data []byte) (*TransferRewardOwnerArgs, error) {
+	var args TransferRewardOwnerArgs
+	if err := decode(data, TransferRewardOwnerInstructionDiscriminator, "transferRewardOwner", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeTransferRewardOwnerAccounts maps the account keys of the transferRewardOwner instruction by IDL order, ignoring remaining accounts
+func DecodeTransferRewardOwnerAccounts(keys []solana.PublicKey) (*TransferRewardOwnerAccounts, error) {
+	if len(keys) < 2 {
+		return nil, fmt.Errorf("transferRewardOwner needs 2 accounts, got %d", len(keys))
+	}
+	return &TransferRewardOwnerAccounts{
+		Authority: keys[0],
+		PoolState: keys[1],
+	}, nil
+}
+
+// InitializeRewardInstructionDiscriminator prefixes the data of every initializeReward instruction
+var InitializeRewardInstructionDiscriminator = []byte{95, 135, 192, 196, 242, 129, 230, 68}
+
+// InitializeRewardArgs holds the arguments of the initializeReward instruction
+type InitializeRewardArgs struct {
+	Param InitializeRewardParam
+}
+
+// InitializeRewardAccounts lists the accounts of the initializeReward instruction in IDL order
+type InitializeRewardAccounts struct {
+	RewardFunder       solana.PublicKey // writable, signer
+	FunderTokenAccount solana.PublicKey // writable
+	AmmConfig          solana.PublicKey
+	PoolState          solana.PublicKey // writable
+	OperationState     solana.PublicKey
+	RewardTokenMint    solana.PublicKey
+	RewardTokenVault   solana.PublicKey // writable
+	RewardTokenProgram solana.PublicKey
+	SystemProgram      solana.PublicKey
+	Rent               solana.PublicKey
+}
+
+// NewInitializeRewardInstruction builds the initializeReward instruction. Remaining accounts are appended after the IDL ones
+func NewInitializeRewardInstruction(programID solana.PublicKey, args InitializeRewardArgs, accounts InitializeRewardAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(InitializeRewardInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode initializeReward args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.RewardFunder, true, true),
+		solana.NewAccountMeta(accounts.FunderTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.OperationState, false, false),
+		solana.NewAccountMeta(accounts.RewardTokenMint, false, false),
+		solana.NewAccountMeta(accounts.RewardTokenVault, true, false),
+		solana.NewAccountMeta(accounts.RewardTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeInitializeRewardArgs decodes the data of the initializeReward instruction
+func DecodeInitializeRewardArgs(data []byte) (*InitializeRewardArgs, error) {
+	var args InitializeRewardArgs
+	if err := decode(data, InitializeRewardInstructionDiscriminator, "initializeReward", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeInitializeRewardAccounts maps the account keys of the initializeReward instruction by IDL order, ignoring remaining accounts
+func DecodeInitializeRewardAccounts(keys []solana.PublicKey) (*InitializeRewardAccounts, error) {
+	if len(keys) < 10 {
+		return nil, fmt.Errorf("initializeReward needs 10 accounts, got %d", len(keys))
+	}
+	return &InitializeRewardAccounts{
+		RewardFunder:       keys[0],
+		FunderTokenAccount: keys[1],
+		AmmConfig:          keys[2],
+		PoolState:          keys[3],
+		OperationState:     keys[4],
+		RewardTokenMint:    keys[5],
+		RewardTokenVault:   keys[6],
+		RewardTokenProgram: keys[7],
+		SystemProgram:      keys[8],
+		Rent:               keys[9],
+	}, nil
+}
+
+// CollectRemainingRewardsInstructionDiscriminator prefixes the data of every collectRemainingRewards instruction
+var CollectRemainingRewardsInstructionDiscriminator = []byte{18, 237, 166, 197, 34, 16, 213, 144}
+
+// CollectRemainingRewardsArgs holds the arguments of the collectRemainingRewards instruction
+type CollectRemainingRewardsArgs struct {
+	RewardIndex uint8
+}
+
+// CollectRemainingRewardsAccounts lists the accounts of the collectRemainingRewards instruction in IDL order
+type CollectRemainingRewardsAccounts struct {
+	RewardFunder       solana.PublicKey // signer
+	FunderTokenAccount solana.PublicKey // writable
+	PoolState          solana.PublicKey // writable
+	RewardTokenVault   solana.PublicKey
+	RewardVaultMint    solana.PublicKey
+	TokenProgram       solana.PublicKey
+	TokenProgram2022   solana.PublicKey
+	MemoProgram        solana.PublicKey
+}
+
+// NewCollectRemainingRewardsInstruction builds the collectRemainingRewards instruction. Remaining accounts are appended after the IDL ones
+func NewCollectRemainingRewardsInstruction(programID solana.PublicKey, args CollectRemainingRewardsArgs, accounts CollectRemainingRewardsAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CollectRemainingRewardsInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collectRemainingRewards args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.RewardFunder, false, true),
+		solana.NewAccountMeta(accounts.FunderTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.RewardTokenVault, false, false),
+		solana.NewAccountMeta(accounts.RewardVaultMint, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+		solana.NewAccountMeta(accounts.MemoProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCollectRemainingRewardsArgs decodes the data of the collectRemainingRewards instruction
+func DecodeCollectRemainingRewardsArgs(data []byte) (*CollectRemainingRewardsArgs, error) {
+	var args CollectRemainingRewardsArgs
+	if err := decode(data, CollectRemainingRewardsInstructionDiscriminator, "collectRemainingRewards", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCollectRemainingRewardsAccounts maps the account keys of the collectRemainingRewards instruction by IDL order, ignoring remaining accounts
+func DecodeCollectRemainingRewardsAccounts(keys []solana.PublicKey) (*CollectRemainingRewardsAccounts, error) {
+	if len(keys) < 8 {
+		return nil, fmt.Errorf("collectRemainingRewards needs 8 accounts, got %d", len(keys))
+	}
+	return &CollectRemainingRewardsAccounts{
+		RewardFunder:       keys[0],
+		FunderTokenAccount: keys[1],
+		PoolState:          keys[2],
+		RewardTokenVault:   keys[3],
+		RewardVaultMint:    keys[4],
+		TokenProgram:       keys[5],
+		TokenProgram2022:   keys[6],
+		MemoProgram:        keys[7],
+	}, nil
+}
+
+// UpdateRewardInfosInstructionDiscriminator prefixes the data of every updateRewardInfos instruction
+var UpdateRewardInfosInstructionDiscriminator = []byte{163, 172, 224, 52, 11, 154, 106, 223}
+
+// UpdateRewardInfosAccounts lists the accounts of the updateRewardInfos instruction in IDL order
+type UpdateRewardInfosAccounts struct {
+	PoolState solana.PublicKey // writable
+}
+
+// NewUpdateRewardInfosInstruction builds the updateRewardInfos instruction. Remaining accounts are appended after the IDL ones
+func NewUpdateRewardInfosInstruction(programID solana.PublicKey, accounts UpdateRewardInfosAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(UpdateRewardInfosInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode updateRewardInfos args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeUpdateRewardInfosAccounts maps the account keys of the updateRewardInfos instruction by IDL order, ignoring remaining accounts
+func DecodeUpdateRewardInfosAccounts(keys []solana.PublicKey) (*UpdateRewardInfosAccounts, error) {
+	if len(keys) < 1 {
+		return nil, fmt.Errorf("updateRewardInfos needs 1 accounts, got %d", len(keys))
+	}
+	return &UpdateRewardInfosAccounts{
+		PoolState: keys[0],
+	}, nil
+}
+
+// SetRewardParamsInstructionDiscriminator prefixes the data of every setRewardParams instruction
+var SetRewardParamsInstructionDiscriminator = []byte{112, 52, 167, 75, 32, 201, 211, 137}
+
+// SetRewardParamsArgs holds the arguments of the setRewardParams instruction
+type SetRewardParamsArgs struct {
+	RewardIndex           uint8
+	EmissionsPerSecondX64 bin.Uint128
+	OpenTime              uint64
+	EndTime               uint64
+}
+
+// SetRewardParamsAccounts lists the accounts of the setRewardParams instruction in IDL order
+type SetRewardParamsAccounts struct {
+	Authority        solana.PublicKey // signer
+	AmmConfig        solana.PublicKey
+	PoolState        solana.PublicKey // writable
+	OperationState   solana.PublicKey
+	TokenProgram     solana.PublicKey
+	TokenProgram2022 solana.PublicKey
+}
+
+// NewSetRewardParamsInstruction builds the setRewardParams instruction. Remaining accounts are appended after the IDL ones
+func NewSetRewardParamsInstruction(programID solana.PublicKey, args SetRewardParamsArgs, accounts SetRewardParamsAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SetRewardParamsInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode setRewardParams args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Authority, false, true),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.OperationState, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSetRewardParamsArgs decodes the data of the setRewardParams instruction
+func DecodeSetRewardParamsArgs(data []byte) (*SetRewardParamsArgs, error) {
+	var args SetRewardParamsArgs
+	if err := decode(data, SetRewardParamsInstructionDiscriminator, "setRewardParams", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSetRewardParamsAccounts maps the account keys of the setRewardParams instruction by IDL order, ignoring remaining accounts
+func DecodeSetRewardParamsAccounts(keys []solana.PublicKey) (*SetRewardParamsAccounts, error) {
+	if len(keys) < 6 {
+		return nil, fmt.Errorf("setRewardParams needs 6 accounts, got %d", len(keys))
+	}
+	return &SetRewardParamsAccounts{
+		Authority:        keys[0],
+		AmmConfig:        keys[1],
+		PoolState:        keys[2],
+		OperationState:   keys[3],
+		TokenProgram:     keys[4],
+		TokenProgram2022: keys[5],
+	}, nil
+}
+
+// CollectProtocolFeeInstructionDiscriminator prefixes the data of every collectProtocolFee instruction
+var CollectProtocolFeeInstructionDiscriminator = []byte{136, 136, 252, 221, 194, 66, 126, 89}
+
+// CollectProtocolFeeArgs holds the arguments of the collectProtocolFee instruction
+type CollectProtocolFeeArgs struct {
+	Amount0Requested uint64
+	Amount1Requested uint64
+}
+
+// CollectProtocolFeeAccounts lists the accounts of the collectProtocolFee instruction in IDL order
+type CollectProtocolFeeAccounts struct {
+	Owner                  solana.PublicKey // signer
+	PoolState              solana.PublicKey // writable
+	AmmConfig              solana.PublicKey
+	TokenVault0            solana.PublicKey // writable
+	TokenVault1            solana.PublicKey // writable
+	Vault0Mint             solana.PublicKey
+	Vault1Mint             solana.PublicKey
+	RecipientTokenAccount0 solana.PublicKey // writable
+	RecipientTokenAccount1 solana.PublicKey // writable
+	TokenProgram           solana.PublicKey
+	TokenProgram2022       solana.PublicKey
+}
+
+// NewCollectProtocolFeeInstruction builds the collectProtocolFee instruction. Remaining accounts are appended after the IDL ones
+func NewCollectProtocolFeeInstruction(programID solana.PublicKey, args CollectProtocolFeeArgs, accounts CollectProtocolFeeAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CollectProtocolFeeInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collectProtocolFee args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Owner, false, true),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.Vault0Mint, false, false),
+		solana.NewAccountMeta(accounts.Vault1Mint, false, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCollectProtocolFeeArgs decodes the data of the collectProtocolFee instruction
+func DecodeCollectProtocolFeeArgs(data []byte) (*CollectProtocolFeeArgs, error) {
+	var args CollectProtocolFeeArgs
+	if err := decode(data, CollectProtocolFeeInstructionDiscriminator, "collectProtocolFee", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCollectProtocolFeeAccounts maps the account keys of the collectProtocolFee instruction by IDL order, ignoring remaining accounts
+func DecodeCollectProtocolFeeAccounts(keys []solana.PublicKey) (*CollectProtocolFeeAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("collectProtocolFee needs 11 accounts, got %d", len(keys))
+	}
+	return &CollectProtocolFeeAccounts{
+		Owner:                  keys[0],
+		PoolState:              keys[1],
+		AmmConfig:              keys[2],
+		TokenVault0:            keys[3],
+		TokenVault1:            keys[4],
+		Vault0Mint:             keys[5],
+		Vault1Mint:             keys[6],
+		RecipientTokenAccount0: keys[7],
+		RecipientTokenAccount1: keys[8],
+		TokenProgram:           keys[9],
+		TokenProgram2022:       keys[10],
+	}, nil
+}
+
+// CollectFundFeeInstructionDiscriminator prefixes the data of every collectFundFee instruction
+var CollectFundFeeInstructionDiscriminator = []byte{167, 138, 78, 149, 223, 194, 6, 126}
+
+// CollectFundFeeArgs holds the arguments of the collectFundFee instruction
+type CollectFundFeeArgs struct {
+	Amount0Requested uint64
+	Amount1Requested uint64
+}
+
+// CollectFundFeeAccounts lists the accounts of the collectFundFee instruction in IDL order
+type CollectFundFeeAccounts struct {
+	Owner                  solana.PublicKey // signer
+	PoolState              solana.PublicKey // writable
+	AmmConfig              solana.PublicKey
+	TokenVault0            solana.PublicKey // writable
+	TokenVault1            solana.PublicKey // writable
+	Vault0Mint             solana.PublicKey
+	Vault1Mint             solana.PublicKey
+	RecipientTokenAccount0 solana.PublicKey // writable
+	RecipientTokenAccount1 solana.PublicKey // writable
+	TokenProgram           solana.PublicKey
+	TokenProgram2022       solana.PublicKey
+}
+
+// NewCollectFundFeeInstruction builds the collectFundFee instruction. Remaining accounts are appended after the IDL ones
+func NewCollectFundFeeInstruction(programID solana.PublicKey, args CollectFundFeeArgs, accounts CollectFundFeeAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(CollectFundFeeInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode collectFundFee args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Owner, false, true),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.Vault0Mint, false, false),
+		solana.NewAccountMeta(accounts.Vault1Mint, false, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeCollectFundFeeArgs decodes the data of the collectFundFee instruction
+func DecodeCollectFundFeeArgs(data []byte) (*CollectFundFeeArgs, error) {
+	var args CollectFundFeeArgs
+	if err := decode(data, CollectFundFeeInstructionDiscriminator, "collectFundFee", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeCollectFundFeeAccounts maps the account keys of the collectFundFee instruction by IDL order, ignoring remaining accounts
+func DecodeCollectFundFeeAccounts(keys []solana.PublicKey) (*CollectFundFeeAccounts, error) {
+	if len(keys) < 11 {
+		return nil, fmt.Errorf("collectFundFee needs 11 accounts, got %d", len(keys))
+	}
+	return &CollectFundFeeAccounts{
+		Owner:                  keys[0],
+		PoolState:              keys[1],
+		AmmConfig:              keys[2],
+		TokenVault0:            keys[3],
+		TokenVault1:            keys[4],
+		Vault0Mint:             keys[5],
+		Vault1Mint:             keys[6],
+		RecipientTokenAccount0: keys[7],
+		RecipientTokenAccount1: keys[8],
+		TokenProgram:           keys[9],
+		TokenProgram2022:       keys[10],
+	}, nil
+}
+
+// OpenPositionInstructionDiscriminator prefixes the data of every openPosition instruction
+var OpenPositionInstructionDiscriminator = []byte{135, 128, 47, 77, 15, 152, 240, 49}
+
+// OpenPositionArgs holds the arguments of the openPosition instruction
+type OpenPositionArgs struct {
+	TickLowerIndex           int32
+	TickUpperIndex           int32
+	TickArrayLowerStartIndex int32
+	TickArrayUpperStartIndex int32
+	Liquidity                bin.Uint128
+	Amount0Max               uint64
+	Amount1Max               uint64
+}
+
+// OpenPositionAccounts lists the accounts of the openPosition instruction in IDL order
+type OpenPositionAccounts struct {
+	Payer                  solana.PublicKey // writable, signer
+	PositionNftOwner       solana.PublicKey
+	PositionNftMint        solana.PublicKey // writable, signer
+	PositionNftAccount     solana.PublicKey // writable
+	MetadataAccount        solana.PublicKey // writable
+	PoolState              solana.PublicKey // writable
+	ProtocolPosition       solana.PublicKey // writable
+	TickArrayLower         solana.PublicKey // writable
+	TickArrayUpper         solana.PublicKey // writable
+	PersonalPosition       solana.PublicKey // writable
+	TokenAccount0          solana.PublicKey // writable
+	TokenAccount1          solana.PublicKey // writable
+	TokenVault0            solana.PublicKey // writable
+	TokenVault1            solana.PublicKey // writable
+	Rent                   solana.PublicKey
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	MetadataProgram        solana.PublicKey
+}
+
+// NewOpenPositionInstruction builds the openPosition instruction. Remaining accounts are appended after the IDL ones
+func NewOpenPositionInstruction(programID solana.PublicKey, args OpenPositionArgs, accounts OpenPositionAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(OpenPositionInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openPosition args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Payer, true, true),
+		solana.NewAccountMeta(accounts.PositionNftOwner, false, false),
+		solana.NewAccountMeta(accounts.PositionNftMint, true, true),
+		solana.NewAccountMeta(accounts.PositionNftAccount, true, false),
+		solana.NewAccountMeta(accounts.MetadataAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.ProtocolPosition, true, false),
+		solana.NewAccountMeta(accounts.TickArrayLower, true, false),
+		solana.NewAccountMeta(accounts.TickArrayUpper, true, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.MetadataProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeOpenPositionArgs decodes the data of the openPosition instruction
+func DecodeOpenPositionArgs(data []byte) (*OpenPositionArgs, error) {
+	var args OpenPositionArgs
+	if err := decode(data, OpenPositionInstructionDiscriminator, "openPosition", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeOpenPositionAccounts maps the account keys of the openPosition instruction by IDL order, ignoring remaining accounts
+func DecodeOpenPositionAccounts(keys []solana.PublicKey) (*OpenPositionAccounts, error) {
+	if len(keys) < 19 {
+		return nil, fmt.Errorf("openPosition needs 19 accounts, got %d", len(keys))
+	}
+	return &OpenPositionAccounts{
+		Payer:                  keys[0],
+		PositionNftOwner:       keys[1],
+		PositionNftMint:        keys[2],
+		PositionNftAccount:     keys[3],
+		MetadataAccount:        keys[4],
+		PoolState:              keys[5],
+		ProtocolPosition:       keys[6],
+		TickArrayLower:         keys[7],
+		TickArrayUpper:         keys[8],
+		PersonalPosition:       keys[9],
+		TokenAccount0:          keys[10],
+		TokenAccount1:          keys[11],
+		TokenVault0:            keys[12],
+		TokenVault1:            keys[13],
+		Rent:                   keys[14],
+		SystemProgram:          keys[15],
+		TokenProgram:           keys[16],
+		AssociatedTokenProgram: keys[17],
+		MetadataProgram:        keys[18],
+	}, nil
+}
+
+// OpenPositionV2InstructionDiscriminator prefixes the data of every openPositionV2 instruction
+var OpenPositionV2InstructionDiscriminator = []byte{77, 184, 74, 214, 112, 86, 241, 199}
+
+// OpenPositionV2Args holds the arguments of the openPositionV2 instruction
+type OpenPositionV2Args struct {
+	TickLowerIndex           int32
+	TickUpperIndex           int32
+	TickArrayLowerStartIndex int32
+	TickArrayUpperStartIndex int32
+	Liquidity                bin.Uint128
+	Amount0Max               uint64
+	Amount1Max               uint64
+	WithMatedata             bool
+	BaseFlag                 *bool `bin:"optional"`
+}
+
+// OpenPositionV2Accounts lists the accounts of the openPositionV2 instruction in IDL order
+type OpenPositionV2Accounts struct {
+	Payer                  solana.PublicKey // writable, signer
+	PositionNftOwner       solana.PublicKey
+	PositionNftMint        solana.PublicKey // writable, signer
+	PositionNftAccount     solana.PublicKey // writable
+	MetadataAccount        solana.PublicKey // writable
+	PoolState              solana.PublicKey // writable
+	ProtocolPosition       solana.PublicKey // writable
+	TickArrayLower         solana.PublicKey // writable
+	TickArrayUpper         solana.PublicKey // writable
+	PersonalPosition       solana.PublicKey // writable
+	TokenAccount0          solana.PublicKey // writable
+	TokenAccount1          solana.PublicKey // writable
+	TokenVault0            solana.PublicKey // writable
+	TokenVault1            solana.PublicKey // writable
+	Rent                   solana.PublicKey
+	SystemProgram          solana.PublicKey
+	TokenProgram           solana.PublicKey
+	AssociatedTokenProgram solana.PublicKey
+	MetadataProgram        solana.PublicKey
+	TokenProgram2022       solana.PublicKey
+	Vault0Mint             solana.PublicKey
+	Vault1Mint             solana.PublicKey
+}
+
+// NewOpenPositionV2Instruction builds the openPositionV2 instruction. Remaining accounts are appended after the IDL ones
+func NewOpenPositionV2Instruction(programID solana.PublicKey, args OpenPositionV2Args, accounts OpenPositionV2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(OpenPositionV2InstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openPositionV2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Payer, true, true),
+		solana.NewAccountMeta(accounts.PositionNftOwner, false, false),
+		solana.NewAccountMeta(accounts.PositionNftMint, true, true),
+		solana.NewAccountMeta(accounts.PositionNftAccount, true, false),
+		solana.NewAccountMeta(accounts.MetadataAccount, true, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.ProtocolPosition, true, false),
+		solana.NewAccountMeta(accounts.TickArrayLower, true, false),
+		solana.NewAccountMeta(accounts.TickArrayUpper, true, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.Rent, false, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.AssociatedTokenProgram, false, false),
+		solana.NewAccountMeta(accounts.MetadataProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+		solana.NewAccountMeta(accounts.Vault0Mint, false, false),
+		solana.NewAccountMeta(accounts.Vault1Mint, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeOpenPositionV2Args decodes the data of the openPositionV2 instruction
+func DecodeOpenPositionV2Args(data []byte) (*OpenPositionV2Args, error) {
+	var args OpenPositionV2Args
+	if err := decode(data, OpenPositionV2InstructionDiscriminator, "openPositionV2", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeOpenPositionV2Accounts maps the account keys of the openPositionV2 instruction by IDL order, ignoring remaining accounts
+func DecodeOpenPositionV2Accounts(keys []solana.PublicKey) (*OpenPositionV2Accounts, error) {
+	if len(keys) < 22 {
+		return nil, fmt.Errorf("openPositionV2 needs 22 accounts, got %d", len(keys))
+	}
+	return &OpenPositionV2Accounts{
+		Payer:                  keys[0],
+		PositionNftOwner:       keys[1],
+		PositionNftMint:        keys[2],
+		PositionNftAccount:     keys[3],
+		MetadataAccount:        keys[4],
+		PoolState:              keys[5],
+		ProtocolPosition:       keys[6],
+		TickArrayLower:         keys[7],
+		TickArrayUpper:         keys[8],
+		PersonalPosition:       keys[9],
+		TokenAccount0:          keys[10],
+		TokenAccount1:          keys[11],
+		TokenVault0:            keys[12],
+		TokenVault1:            keys[13],
+		Rent:                   keys[14],
+		SystemProgram:          keys[15],
+		TokenProgram:           keys[16],
+		AssociatedTokenProgram: keys[17],
+		MetadataProgram:        keys[18],
+		TokenProgram2022:       keys[19],
+		Vault0Mint:             keys[20],
+		Vault1Mint:             keys[21],
+	}, nil
+}
+
+// ClosePositionInstructionDiscriminator prefixes the data of every closePosition instruction
+var ClosePositionInstructionDiscriminator = []byte{123, 134, 81, 0, 49, 68, 98, 98}
+
+// ClosePositionAccounts lists the accounts of the closePosition instruction in IDL order
+type ClosePositionAccounts struct {
+	NftOwner           solana.PublicKey // writable, signer
+	PositionNftMint    solana.PublicKey // writable
+	PositionNftAccount solana.PublicKey // writable
+	PersonalPosition   solana.PublicKey // writable
+	SystemProgram      solana.PublicKey
+	TokenProgram       solana.PublicKey
+}
+
+// NewClosePositionInstruction builds the closePosition instruction. Remaining accounts are appended after the IDL ones
+func NewClosePositionInstruction(programID solana.PublicKey, accounts ClosePositionAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(ClosePositionInstructionDiscriminator, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode closePosition args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.NftOwner, true, true),
+		solana.NewAccountMeta(accounts.PositionNftMint, true, false),
+		solana.NewAccountMeta(accounts.PositionNftAccount, true, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.SystemProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeClosePositionAccounts maps the account keys of the closePosition instruction by IDL order, ignoring remaining accounts
+func DecodeClosePositionAccounts(keys []solana.PublicKey) (*ClosePositionAccounts, error) {
+	if len(keys) < 6 {
+		return nil, fmt.Errorf("closePosition needs 6 accounts, got %d", len(keys))
+	}
+	return &ClosePositionAccounts{
+		NftOwner:           keys[0],
+		PositionNftMint:    keys[1],
+		PositionNftAccount: keys[2],
+		PersonalPosition:   keys[3],
+		SystemProgram:      keys[4],
+		TokenProgram:       keys[5],
+	}, nil
+}
+
+// IncreaseLiquidityInstructionDiscriminator prefixes the data of every increaseLiquidity instruction
+var IncreaseLiquidityInstructionDiscriminator = []byte{46, 156, 243, 118, 13, 205, 251, 178}
+
+// IncreaseLiquidityArgs holds the arguments of the increaseLiquidity instruction
+type IncreaseLiquidityArgs struct {
+	Liquidity  bin.Uint128
+	Amount0Max uint64
+	Amount1Max uint64
+}
+
+// IncreaseLiquidityAccounts lists the accounts of the increaseLiquidity instruction in IDL order
+type IncreaseLiquidityAccounts struct {
+	NftOwner         solana.PublicKey // signer
+	NftAccount       solana.PublicKey
+	PoolState        solana.PublicKey // writable
+	ProtocolPosition solana.PublicKey // writable
+	PersonalPosition solana.PublicKey // writable
+	TickArrayLower   solana.PublicKey // writable
+	TickArrayUpper   solana.PublicKey // writable
+	TokenAccount0    solana.PublicKey // writable
+	TokenAccount1    solana.PublicKey // writable
+	TokenVault0      solana.PublicKey // writable
+	TokenVault1      solana.PublicKey // writable
+	TokenProgram     solana.PublicKey
+}
+
+// NewIncreaseLiquidityInstruction builds the increaseLiquidity instruction. Remaining accounts are appended after the IDL ones
+func NewIncreaseLiquidityInstruction(programID solana.PublicKey, args IncreaseLiquidityArgs, accounts IncreaseLiquidityAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(IncreaseLiquidityInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode increaseLiquidity args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.NftOwner, false, true),
+		solana.NewAccountMeta(accounts.NftAccount, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.ProtocolPosition, true, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.TickArrayLower, true, false),
+		solana.NewAccountMeta(accounts.TickArrayUpper, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeIncreaseLiquidityArgs decodes the data of the increaseLiquidity instruction
+func DecodeIncreaseLiquidityArgs(data []byte) (*IncreaseLiquidityArgs, error) {
+	var args IncreaseLiquidityArgs
+	if err := decode(data, IncreaseLiquidityInstructionDiscriminator, "increaseLiquidity", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeIncreaseLiquidityAccounts maps the account keys of the increaseLiquidity instruction by IDL order, ignoring remaining accounts
+func DecodeIncreaseLiquidityAccounts(keys []solana.PublicKey) (*IncreaseLiquidityAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("increaseLiquidity needs 12 accounts, got %d", len(keys))
+	}
+	return &IncreaseLiquidityAccounts{
+		NftOwner:         keys[0],
+		NftAccount:       keys[1],
+		PoolState:        keys[2],
+		ProtocolPosition: keys[3],
+		PersonalPosition: keys[4],
+		TickArrayLower:   keys[5],
+		TickArrayUpper:   keys[6],
+		TokenAccount0:    keys[7],
+		TokenAccount1:    keys[8],
+		TokenVault0:      keys[9],
+		TokenVault1:      keys[10],
+		TokenProgram:     keys[11],
+	}, nil
+}
+
+// IncreaseLiquidityV2InstructionDiscriminator prefixes the data of every increaseLiquidityV2 instruction
+var IncreaseLiquidityV2InstructionDiscriminator = []byte{133, 29, 89, 223, 69, 238, 176, 10}
+
+// IncreaseLiquidityV2Args holds the arguments of the increaseLiquidityV2 instruction
+type IncreaseLiquidityV2Args struct {
+	Liquidity  bin.Uint128
+	Amount0Max uint64
+	Amount1Max uint64
+	BaseFlag   *bool `bin:"optional"`
+}
+
+// IncreaseLiquidityV2Accounts lists the accounts of the increaseLiquidityV2 instruction in IDL order
+type IncreaseLiquidityV2Accounts struct {
+	NftOwner         solana.PublicKey // signer
+	NftAccount       solana.PublicKey
+	PoolState        solana.PublicKey // writable
+	ProtocolPosition solana.PublicKey // writable
+	PersonalPosition solana.PublicKey // writable
+	TickArrayLower   solana.PublicKey // writable
+	TickArrayUpper   solana.PublicKey // writable
+	TokenAccount0    solana.PublicKey // writable
+	TokenAccount1    solana.PublicKey // writable
+	TokenVault0      solana.PublicKey // writable
+	TokenVault1      solana.PublicKey // writable
+	TokenProgram     solana.PublicKey
+	TokenProgram2022 solana.PublicKey
+	Vault0Mint       solana.PublicKey
+	Vault1Mint       solana.PublicKey
+}
+
+// NewIncreaseLiquidityV2Instruction builds the increaseLiquidityV2 instruction. Remaining accounts are appended after the IDL ones
+func NewIncreaseLiquidityV2Instruction(programID solana.PublicKey, args IncreaseLiquidityV2Args, accounts IncreaseLiquidityV2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(IncreaseLiquidityV2InstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode increaseLiquidityV2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.NftOwner, false, true),
+		solana.NewAccountMeta(accounts.NftAccount, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.ProtocolPosition, true, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.TickArrayLower, true, false),
+		solana.NewAccountMeta(accounts.TickArrayUpper, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.TokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+		solana.NewAccountMeta(accounts.Vault0Mint, false, false),
+		solana.NewAccountMeta(accounts.Vault1Mint, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeIncreaseLiquidityV2Args decodes the data of the increaseLiquidityV2 instruction
+func DecodeIncreaseLiquidityV2Args(data []byte) (*IncreaseLiquidityV2Args, error) {
+	var args IncreaseLiquidityV2Args
+	if err := decode(data, IncreaseLiquidityV2InstructionDiscriminator, "increaseLiquidityV2", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeIncreaseLiquidityV2Accounts maps the account keys of the increaseLiquidityV2 instruction by IDL order, ignoring remaining accounts
+func DecodeIncreaseLiquidityV2Accounts(keys []solana.PublicKey) (*IncreaseLiquidityV2Accounts, error) {
+	if len(keys) < 15 {
+		return nil, fmt.Errorf("increaseLiquidityV2 needs 15 accounts, got %d", len(keys))
+	}
+	return &IncreaseLiquidityV2Accounts{
+		NftOwner:         keys[0],
+		NftAccount:       keys[1],
+		PoolState:        keys[2],
+		ProtocolPosition: keys[3],
+		PersonalPosition: keys[4],
+		TickArrayLower:   keys[5],
+		TickArrayUpper:   keys[6],
+		TokenAccount0:    keys[7],
+		TokenAccount1:    keys[8],
+		TokenVault0:      keys[9],
+		TokenVault1:      keys[10],
+		TokenProgram:     keys[11],
+		TokenProgram2022: keys[12],
+		Vault0Mint:       keys[13],
+		Vault1Mint:       keys[14],
+	}, nil
+}
+
+// DecreaseLiquidityInstructionDiscriminator prefixes the data of every decreaseLiquidity instruction
+var DecreaseLiquidityInstructionDiscriminator = []byte{160, 38, 208, 111, 104, 91, 44, 1}
+
+// DecreaseLiquidityArgs holds the arguments of the decreaseLiquidity instruction
+type DecreaseLiquidityArgs struct {
+	Liquidity  bin.Uint128
+	Amount0Min uint64
+	Amount1Min uint64
+}
+
+// DecreaseLiquidityAccounts lists the accounts of the decreaseLiquidity instruction in IDL order
+type DecreaseLiquidityAccounts struct {
+	NftOwner               solana.PublicKey // signer
+	NftAccount             solana.PublicKey
+	PersonalPosition       solana.PublicKey // writable
+	PoolState              solana.PublicKey // writable
+	ProtocolPosition       solana.PublicKey // writable
+	TokenVault0            solana.PublicKey // writable
+	TokenVault1            solana.PublicKey // writable
+	TickArrayLower         solana.PublicKey // writable
+	TickArrayUpper         solana.PublicKey // writable
+	RecipientTokenAccount0 solana.PublicKey // writable
+	RecipientTokenAccount1 solana.PublicKey // writable
+	TokenProgram           solana.PublicKey
+}
+
+// NewDecreaseLiquidityInstruction builds the decreaseLiquidity instruction. Remaining accounts are appended after the IDL ones
+func NewDecreaseLiquidityInstruction(programID solana.PublicKey, args DecreaseLiquidityArgs, accounts DecreaseLiquidityAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(DecreaseLiquidityInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode decreaseLiquidity args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.NftOwner, false, true),
+		solana.NewAccountMeta(accounts.NftAccount, false, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.ProtocolPosition, true, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.TickArrayLower, true, false),
+		solana.NewAccountMeta(accounts.TickArrayUpper, true, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeDecreaseLiquidityArgs decodes the data of the decreaseLiquidity instruction
+func DecodeDecreaseLiquidityArgs(data []byte) (*DecreaseLiquidityArgs, error) {
+	var args DecreaseLiquidityArgs
+	if err := decode(data, DecreaseLiquidityInstructionDiscriminator, "decreaseLiquidity", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeDecreaseLiquidityAccounts maps the account keys of the decreaseLiquidity instruction by IDL order, ignoring remaining accounts
+func DecodeDecreaseLiquidityAccounts(keys []solana.PublicKey) (*DecreaseLiquidityAccounts, error) {
+	if len(keys) < 12 {
+		return nil, fmt.Errorf("decreaseLiquidity needs 12 accounts, got %d", len(keys))
+	}
+	return &DecreaseLiquidityAccounts{
+		NftOwner:               keys[0],
+		NftAccount:             keys[1],
+		PersonalPosition:       keys[2],
+		PoolState:              keys[3],
+		ProtocolPosition:       keys[4],
+		TokenVault0:            keys[5],
+		TokenVault1:            keys[6],
+		TickArrayLower:         keys[7],
+		TickArrayUpper:         keys[8],
+		RecipientTokenAccount0: keys[9],
+		RecipientTokenAccount1: keys[10],
+		TokenProgram:           keys[11],
+	}, nil
+}
+
+// DecreaseLiquidityV2InstructionDiscriminator prefixes the data of every decreaseLiquidityV2 instruction
+var DecreaseLiquidityV2InstructionDiscriminator = []byte{58, 127, 188, 62, 79, 82, 196, 96}
+
+// DecreaseLiquidityV2Args holds the arguments of the decreaseLiquidityV2 instruction
+type DecreaseLiquidityV2Args struct {
+	Liquidity  bin.Uint128
+	Amount0Min uint64
+	Amount1Min uint64
+}
+
+// DecreaseLiquidityV2Accounts lists the accounts of the decreaseLiquidityV2 instruction in IDL order
+type DecreaseLiquidityV2Accounts struct {
+	NftOwner               solana.PublicKey // signer
+	NftAccount             solana.PublicKey
+	PersonalPosition       solana.PublicKey // writable
+	PoolState              solana.PublicKey // writable
+	ProtocolPosition       solana.PublicKey // writable
+	TokenVault0            solana.PublicKey // writable
+	TokenVault1            solana.PublicKey // writable
+	TickArrayLower         solana.PublicKey // writable
+	TickArrayUpper         solana.PublicKey // writable
+	RecipientTokenAccount0 solana.PublicKey // writable
+	RecipientTokenAccount1 solana.PublicKey // writable
+	TokenProgram           solana.PublicKey
+	TokenProgram2022       solana.PublicKey
+	MemoProgram            solana.PublicKey
+	Vault0Mint             solana.PublicKey
+	Vault1Mint             solana.PublicKey
+}
+
+// NewDecreaseLiquidityV2Instruction builds the decreaseLiquidityV2 instruction. Remaining accounts are appended after the IDL ones
+func NewDecreaseLiquidityV2Instruction(programID solana.PublicKey, args DecreaseLiquidityV2Args, accounts DecreaseLiquidityV2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(DecreaseLiquidityV2InstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode decreaseLiquidityV2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.NftOwner, false, true),
+		solana.NewAccountMeta(accounts.NftAccount, false, false),
+		solana.NewAccountMeta(accounts.PersonalPosition, true, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.ProtocolPosition, true, false),
+		solana.NewAccountMeta(accounts.TokenVault0, true, false),
+		solana.NewAccountMeta(accounts.TokenVault1, true, false),
+		solana.NewAccountMeta(accounts.TickArrayLower, true, false),
+		solana.NewAccountMeta(accounts.TickArrayUpper, true, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount0, true, false),
+		solana.NewAccountMeta(accounts.RecipientTokenAccount1, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+		solana.NewAccountMeta(accounts.MemoProgram, false, false),
+		solana.NewAccountMeta(accounts.Vault0Mint, false, false),
+		solana.NewAccountMeta(accounts.Vault1Mint, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeDecreaseLiquidityV2Args decodes the data of the decreaseLiquidityV2 instruction
+func DecodeDecreaseLiquidityV2Args(data []byte) (*DecreaseLiquidityV2Args, error) {
+	var args DecreaseLiquidityV2Args
+	if err := decode(data, DecreaseLiquidityV2InstructionDiscriminator, "decreaseLiquidityV2", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeDecreaseLiquidityV2Accounts maps the account keys of the decreaseLiquidityV2 instruction by IDL order, ignoring remaining accounts
+func DecodeDecreaseLiquidityV2Accounts(keys []solana.PublicKey) (*DecreaseLiquidityV2Accounts, error) {
+	if len(keys) < 16 {
+		return nil, fmt.Errorf("decreaseLiquidityV2 needs 16 accounts, got %d", len(keys))
+	}
+	return &DecreaseLiquidityV2Accounts{
+		NftOwner:               keys[0],
+		NftAccount:             keys[1],
+		PersonalPosition:       keys[2],
+		PoolState:              keys[3],
+		ProtocolPosition:       keys[4],
+		TokenVault0:            keys[5],
+		TokenVault1:            keys[6],
+		TickArrayLower:         keys[7],
+		TickArrayUpper:         keys[8],
+		RecipientTokenAccount0: keys[9],
+		RecipientTokenAccount1: keys[10],
+		TokenProgram:           keys[11],
+		TokenProgram2022:       keys[12],
+		MemoProgram:            keys[13],
+		Vault0Mint:             keys[14],
+		Vault1Mint:             keys[15],
+	}, nil
+}
+
+// SwapInstructionDiscriminator prefixes the data of every swap instruction
+var SwapInstructionDiscriminator = []byte{248, 198, 158, 145, 225, 117, 135, 200}
+
+// SwapArgs holds the arguments of the swap instruction
+type SwapArgs struct {
+	Amount               uint64
+	OtherAmountThreshold uint64
+	SqrtPriceLimitX64    bin.Uint128
+	IsBaseInput          bool
+}
+
+// SwapAccounts lists the accounts of the swap instruction in IDL order
+type SwapAccounts struct {
+	Payer              solana.PublicKey // signer
+	AmmConfig          solana.PublicKey
+	PoolState          solana.PublicKey // writable
+	InputTokenAccount  solana.PublicKey // writable
+	OutputTokenAccount solana.PublicKey // writable
+	InputVault         solana.PublicKey // writable
+	OutputVault        solana.PublicKey // writable
+	ObservationState   solana.PublicKey // writable
+	TokenProgram       solana.PublicKey
+	TickArray          solana.PublicKey // writable
+}
+
+// NewSwapInstruction builds the swap instruction. Remaining accounts are appended after the IDL ones
+func NewSwapInstruction(programID solana.PublicKey, args SwapArgs, accounts SwapAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swap args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Payer, false, true),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.InputVault, true, false),
+		solana.NewAccountMeta(accounts.OutputVault, true, false),
+		solana.NewAccountMeta(accounts.ObservationState, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TickArray, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapArgs decodes the data of the swap instruction
+func DecodeSwapArgs(data []byte) (*SwapArgs, error) {
+	var args SwapArgs
+	if err := decode(data, SwapInstructionDiscriminator, "swap", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapAccounts maps the account keys of the swap instruction by IDL order, ignoring remaining accounts
+func DecodeSwapAccounts(keys []solana.PublicKey) (*SwapAccounts, error) {
+	if len(keys) < 10 {
+		return nil, fmt.Errorf("swap needs 10 accounts, got %d", len(keys))
+	}
+	return &SwapAccounts{
+		Payer:              keys[0],
+		AmmConfig:          keys[1],
+		PoolState:          keys[2],
+		InputTokenAccount:  keys[3],
+		OutputTokenAccount: keys[4],
+		InputVault:         keys[5],
+		OutputVault:        keys[6],
+		ObservationState:   keys[7],
+		TokenProgram:       keys[8],
+		TickArray:          keys[9],
+	}, nil
+}
+
+// SwapV2InstructionDiscriminator prefixes the data of every swapV2 instruction
+var SwapV2InstructionDiscriminator = []byte{43, 4, 237, 11, 26, 201, 30, 98}
+
+// SwapV2Args holds the arguments of the swapV2 instruction
+type SwapV2Args struct {
+	Amount               uint64
+	OtherAmountThreshold uint64
+	SqrtPriceLimitX64    bin.Uint128
+	IsBaseInput          bool
+}
+
+// SwapV2Accounts lists the accounts of the swapV2 instruction in IDL order
+type SwapV2Accounts struct {
+	Payer              solana.PublicKey // signer
+	AmmConfig          solana.PublicKey
+	PoolState          solana.PublicKey // writable
+	InputTokenAccount  solana.PublicKey // writable
+	OutputTokenAccount solana.PublicKey // writable
+	InputVault         solana.PublicKey // writable
+	OutputVault        solana.PublicKey // writable
+	ObservationState   solana.PublicKey // writable
+	TokenProgram       solana.PublicKey
+	TokenProgram2022   solana.PublicKey
+	MemoProgram        solana.PublicKey
+	InputVaultMint     solana.PublicKey
+	OutputVaultMint    solana.PublicKey
+}
+
+// NewSwapV2Instruction builds the swapV2 instruction. Remaining accounts are appended after the IDL ones
+func NewSwapV2Instruction(programID solana.PublicKey, args SwapV2Args, accounts SwapV2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapV2InstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swapV2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Payer, false, true),
+		solana.NewAccountMeta(accounts.AmmConfig, false, false),
+		solana.NewAccountMeta(accounts.PoolState, true, false),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.OutputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.InputVault, true, false),
+		solana.NewAccountMeta(accounts.OutputVault, true, false),
+		solana.NewAccountMeta(accounts.ObservationState, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+		solana.NewAccountMeta(accounts.MemoProgram, false, false),
+		solana.NewAccountMeta(accounts.InputVaultMint, false, false),
+		solana.NewAccountMeta(accounts.OutputVaultMint, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapV2Args decodes the data of the swapV2 instruction
+func DecodeSwapV2Args(data []byte) (*SwapV2Args, error) {
+	var args SwapV2Args
+	if err := decode(data, SwapV2InstructionDiscriminator, "swapV2", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapV2Accounts maps the account keys of the swapV2 instruction by IDL order, ignoring remaining accounts
+func DecodeSwapV2Accounts(keys []solana.PublicKey) (*SwapV2Accounts, error) {
+	if len(keys) < 13 {
+		return nil, fmt.Errorf("swapV2 needs 13 accounts, got %d", len(keys))
+	}
+	return &SwapV2Accounts{
+		Payer:              keys[0],
+		AmmConfig:          keys[1],
+		PoolState:          keys[2],
+		InputTokenAccount:  keys[3],
+		OutputTokenAccount: keys[4],
+		InputVault:         keys[5],
+		OutputVault:        keys[6],
+		ObservationState:   keys[7],
+		TokenProgram:       keys[8],
+		TokenProgram2022:   keys[9],
+		MemoProgram:        keys[10],
+		InputVaultMint:     keys[11],
+		OutputVaultMint:    keys[12],
+	}, nil
+}
+
+// SwapRouterBaseInInstructionDiscriminator prefixes the data of every swapRouterBaseIn instruction
+var SwapRouterBaseInInstructionDiscriminator = []byte{69, 125, 115, 218, 245, 186, 242, 196}
+
+// SwapRouterBaseInArgs holds the arguments of the swapRouterBaseIn instruction
+type SwapRouterBaseInArgs struct {
+	AmountIn         uint64
+	AmountOutMinimum uint64
+}
+
+// SwapRouterBaseInAccounts lists the accounts of the swapRouterBaseIn instruction in IDL order
+type SwapRouterBaseInAccounts struct {
+	Payer             solana.PublicKey // signer
+	InputTokenAccount solana.PublicKey // writable
+	InputTokenMint    solana.PublicKey // writable
+	TokenProgram      solana.PublicKey
+	TokenProgram2022  solana.PublicKey
+	MemoProgram       solana.PublicKey
+}
+
+// NewSwapRouterBaseInInstruction builds the swapRouterBaseIn instruction. Remaining accounts are appended after the IDL ones
+func NewSwapRouterBaseInInstruction(programID solana.PublicKey, args SwapRouterBaseInArgs, accounts SwapRouterBaseInAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapRouterBaseInInstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swapRouterBaseIn args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.Payer, false, true),
+		solana.NewAccountMeta(accounts.InputTokenAccount, true, false),
+		solana.NewAccountMeta(accounts.InputTokenMint, true, false),
+		solana.NewAccountMeta(accounts.TokenProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenProgram2022, false, false),
+		solana.NewAccountMeta(accounts.MemoProgram, false, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapRouterBaseInArgs decodes the data of the swapRouterBaseIn instruction
+func DecodeSwapRouterBaseInArgs(data []byte) (*SwapRouterBaseInArgs, error) {
+	var args SwapRouterBaseInArgs
+	if err := decode(data, SwapRouterBaseInInstructionDiscriminator, "swapRouterBaseIn", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapRouterBaseInAccounts maps the account keys of the swapRouterBaseIn instruction by IDL order, ignoring remaining accounts
+func DecodeSwapRouterBaseInAccounts(keys []solana.PublicKey) (*SwapRouterBaseInAccounts, error) {
+	if len(keys) < 6 {
+		return nil, fmt.Errorf("swapRouterBaseIn needs 6 accounts, got %d", len(keys))
+	}
+	return &SwapRouterBaseInAccounts{
+		Payer:             keys[0],
+		InputTokenAccount: keys[1],
+		InputTokenMint:    keys[2],
+		TokenProgram:      keys[3],
+		TokenProgram2022:  keys[4],
+		MemoProgram:       keys[5],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
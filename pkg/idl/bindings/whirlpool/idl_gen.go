@@ -0,0 +1,241 @@
+// Code generated by idlgen from whirlpool_subset_idl.json. DO NOT EDIT.
+
+// Package whirlpool holds typed bindings for the whirlpool program, generated from a hand-written subset of its
+// IDL. Instructions, accounts, types and events missing from the subset have no bindings.
+package whirlpool
+
+import (
+	"bytes"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// ProgramID is the address of the whirlpool program recorded in the IDL
+var ProgramID = solana.MustPublicKeyFromBase58("whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc")
+
+// WhirlpoolRewardInfo is the WhirlpoolRewardInfo type of the whirlpool IDL
+// Stores the state relevant for tracking liquidity mining rewards at the `Whirlpool` level.
+type WhirlpoolRewardInfo struct {
+	Mint                  solana.PublicKey
+	Vault                 solana.PublicKey
+	Authority             solana.PublicKey
+	EmissionsPerSecondX64 bin.Uint128
+	GrowthGlobalX64       bin.Uint128
+}
+
+// Tick is the Tick type of the whirlpool IDL
+type Tick struct {
+	Initialized          bool
+	LiquidityNet         bin.Int128
+	LiquidityGross       bin.Uint128
+	FeeGrowthOutsideA    bin.Uint128
+	FeeGrowthOutsideB    bin.Uint128
+	RewardGrowthsOutside [3]bin.Uint128
+}
+
+// RemainingAccountsInfo is the RemainingAccountsInfo type of the whirlpool IDL
+type RemainingAccountsInfo struct {
+	Slices []RemainingAccountsSlice
+}
+
+// RemainingAccountsSlice is the RemainingAccountsSlice type of the whirlpool IDL
+type RemainingAccountsSlice struct {
+	AccountsType AccountsType
+	Length       uint8
+}
+
+// AccountsType is the AccountsType enum of the whirlpool IDL
+type AccountsType uint8
+
+const (
+	AccountsTypeTransferHookA AccountsType = iota
+	AccountsTypeTransferHookB
+	AccountsTypeTransferHookReward
+	AccountsTypeTransferHookInput
+	AccountsTypeTransferHookIntermediate
+	AccountsTypeTransferHookOutput
+	AccountsTypeSupplementalTickArrays
+	AccountsTypeSupplementalTickArraysOne
+	AccountsTypeSupplementalTickArraysTwo
+)
+
+// Whirlpool is the Whirlpool account of the whirlpool IDL, without its discriminator
+type Whirlpool struct {
+	WhirlpoolsConfig           solana.PublicKey
+	WhirlpoolBump              [1]uint8
+	TickSpacing                uint16
+	TickSpacingSeed            [2]uint8
+	FeeRate                    uint16
+	ProtocolFeeRate            uint16
+	Liquidity                  bin.Uint128
+	SqrtPrice                  bin.Uint128
+	TickCurrentIndex           int32
+	ProtocolFeeOwedA           uint64
+	ProtocolFeeOwedB           uint64
+	TokenMintA                 solana.PublicKey
+	TokenVaultA                solana.PublicKey
+	FeeGrowthGlobalA           bin.Uint128
+	TokenMintB                 solana.PublicKey
+	TokenVaultB                solana.PublicKey
+	FeeGrowthGlobalB           bin.Uint128
+	RewardLastUpdatedTimestamp uint64
+	RewardInfos                [3]WhirlpoolRewardInfo
+}
+
+// WhirlpoolDiscriminator prefixes every Whirlpool account
+var WhirlpoolDiscriminator = []byte{63, 149, 209, 12, 225, 128, 99, 9}
+
+// DecodeWhirlpool decodes the raw data of a Whirlpool account
+func DecodeWhirlpool(data []byte) (*Whirlpool, error) {
+	var account Whirlpool
+	if err := decode(data, WhirlpoolDiscriminator, "Whirlpool", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// TickArray is the TickArray account of the whirlpool IDL, without its discriminator
+type TickArray struct {
+	StartTickIndex int32
+	Ticks          [88]Tick
+	Whirlpool      solana.PublicKey
+}
+
+// TickArrayDiscriminator prefixes every TickArray account
+var TickArrayDiscriminator = []byte{69, 97, 189, 190, 110, 7, 66, 187}
+
+// DecodeTickArray decodes the raw data of a TickArray account
+func DecodeTickArray(data []byte) (*TickArray, error) {
+	var account TickArray
+	if err := decode(data, TickArrayDiscriminator, "TickArray", &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// SwapV2InstructionDiscriminator prefixes the data of every swapV2 instruction
+var SwapV2InstructionDiscriminator = []byte{43, 4, 237, 11, 26, 201, 30, 98}
+
+// SwapV2Args holds the arguments of the swapV2 instruction
+type SwapV2Args struct {
+	Amount                 uint64
+	OtherAmountThreshold   uint64
+	SqrtPriceLimit         bin.Uint128
+	AmountSpecifiedIsInput bool
+	AToB                   bool
+	RemainingAccountsInfo  *RemainingAccountsInfo `bin:"optional"`
+}
+
+// SwapV2Accounts lists the accounts of the swapV2 instruction in IDL order
+type SwapV2Accounts struct {
+	TokenProgramA      solana.PublicKey
+	TokenProgramB      solana.PublicKey
+	MemoProgram        solana.PublicKey
+	TokenAuthority     solana.PublicKey // signer
+	Whirlpool          solana.PublicKey // writable
+	TokenMintA         solana.PublicKey
+	TokenMintB         solana.PublicKey
+	TokenOwnerAccountA solana.PublicKey // writable
+	TokenVaultA        solana.PublicKey // writable
+	TokenOwnerAccountB solana.PublicKey // writable
+	TokenVaultB        solana.PublicKey // writable
+	TickArray0         solana.PublicKey // writable
+	TickArray1         solana.PublicKey // writable
+	TickArray2         solana.PublicKey // writable
+	Oracle             solana.PublicKey // writable
+}
+
+// NewSwapV2Instruction builds the swapV2 instruction. Remaining accounts are appended after the IDL ones
+func NewSwapV2Instruction(programID solana.PublicKey, args SwapV2Args, accounts SwapV2Accounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {
+	data, err := encode(SwapV2InstructionDiscriminator, &args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode swapV2 args: %w", err)
+	}
+
+	metas := solana.AccountMetaSlice{
+		solana.NewAccountMeta(accounts.TokenProgramA, false, false),
+		solana.NewAccountMeta(accounts.TokenProgramB, false, false),
+		solana.NewAccountMeta(accounts.MemoProgram, false, false),
+		solana.NewAccountMeta(accounts.TokenAuthority, false, true),
+		solana.NewAccountMeta(accounts.Whirlpool, true, false),
+		solana.NewAccountMeta(accounts.TokenMintA, false, false),
+		solana.NewAccountMeta(accounts.TokenMintB, false, false),
+		solana.NewAccountMeta(accounts.TokenOwnerAccountA, true, false),
+		solana.NewAccountMeta(accounts.TokenVaultA, true, false),
+		solana.NewAccountMeta(accounts.TokenOwnerAccountB, true, false),
+		solana.NewAccountMeta(accounts.TokenVaultB, true, false),
+		solana.NewAccountMeta(accounts.TickArray0, true, false),
+		solana.NewAccountMeta(accounts.TickArray1, true, false),
+		solana.NewAccountMeta(accounts.TickArray2, true, false),
+		solana.NewAccountMeta(accounts.Oracle, true, false),
+	}
+	return solana.NewInstruction(programID, append(metas, remaining...), data), nil
+}
+
+// DecodeSwapV2Args decodes the data of the swapV2 instruction
+func DecodeSwapV2Args(data []byte) (*SwapV2Args, error) {
+	var args SwapV2Args
+	if err := decode(data, SwapV2InstructionDiscriminator, "swapV2", &args); err != nil {
+		return nil, err
+	}
+	return &args, nil
+}
+
+// DecodeSwapV2Accounts maps the account keys of the swapV2 instruction by IDL order, ignoring remaining accounts
+func DecodeSwapV2Accounts(keys []solana.PublicKey) (*SwapV2Accounts, error) {
+	if len(keys) < 15 {
+		return nil, fmt.Errorf("swapV2 needs 15 accounts, got %d", len(keys))
+	}
+	return &SwapV2Accounts{
+		TokenProgramA:      keys[0],
+		TokenProgramB:      keys[1],
+		MemoProgram:        keys[2],
+		TokenAuthority:     keys[3],
+		Whirlpool:          keys[4],
+		TokenMintA:         keys[5],
+		TokenMintB:         keys[6],
+		TokenOwnerAccountA: keys[7],
+		TokenVaultA:        keys[8],
+		TokenOwnerAccountB: keys[9],
+		TokenVaultB:        keys[10],
+		TickArray0:         keys[11],
+		TickArray1:         keys[12],
+		TickArray2:         keys[13],
+		Oracle:             keys[14],
+	}, nil
+}
+
+// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var eventInstructionTag = []byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// decode checks that data starts with the discriminator and Borsh decodes the rest into v
+func decode(data, discriminator []byte, name string, v interface{}) error {
+	if !bytes.HasPrefix(data, discriminator) {
+		return fmt.Errorf("data is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// encode Borsh encodes v after the discriminator
+func encode(discriminator []byte, v interface{}) ([]byte, error) {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	if v != nil {
+		if err := bin.NewBorshEncoder(buf).Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// optionalAccount passes the program ID in place of an omitted optional account
+func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {
+	if key.IsZero() {
+		return programID
+	}
+	return key
+}
@@ -0,0 +1,560 @@
+// Package codegen renders typed Go bindings for a program IDL: structs for its types, accounts and events,
+// account and event decoders, and builders and decoders for its instructions. cmd/idlgen runs it through
+// go generate for the IDLs bundled in data/IDL.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"unicode"
+
+	"corvus_bot/pkg/idl"
+)
+
+// Options configures the generated file
+type Options struct {
+	// Package is the name of the generated Go package
+	Package string
+
+	// Source is the IDL file name recorded in the generated header
+	Source string
+
+	// Subset marks an IDL written by hand with only part of the program, so the package doc says so
+	Subset bool
+}
+
+// Generate returns the gofmt'ed Go source of the bindings for the IDL
+func Generate(program *idl.IDL, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	g := &generator{
+		program:  program,
+		typeDefs: make(map[string]*idl.TypeDef),
+		declared: make(map[string]bool),
+	}
+	for k := range program.Accounts {
+		g.typeDefs[program.Accounts[k].Name] = &program.Accounts[k]
+	}
+	for k := range program.Types {
+		g.typeDefs[program.Types[k].Name] = &program.Types[k]
+	}
+
+	if err := g.file(opts); err != nil {
+		return nil, fmt.Errorf("failed to generate bindings for IDL %s: %w", program.Name, err)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format bindings for IDL %s: %w", program.Name, err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	program  *idl.IDL
+	typeDefs map[string]*idl.TypeDef
+	declared map[string]bool
+	buf      bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// declare reserves a package level identifier, failing on collisions between IDL names
+func (g *generator) declare(name string) error {
+	if g.declared[name] {
+		return fmt.Errorf("identifier %s is declared twice", name)
+	}
+	g.declared[name] = true
+	return nil
+}
+
+func (g *generator) file(opts Options) error {
+	source := opts.Source
+	if source == "" {
+		source = g.program.Name + " IDL"
+	}
+	g.printf("// Code generated by idlgen from %s. DO NOT EDIT.\n\n", source)
+	if opts.Subset {
+		g.printf("// Package %s holds typed bindings for the %s program, generated from a hand-written subset of its\n", opts.Package, g.program.Name)
+		g.printf("// IDL. Instructions, accounts, types and events missing from the subset have no bindings.\n")
+	} else {
+		g.printf("// Package %s holds typed bindings for the %s program, generated from its IDL.\n", opts.Package, g.program.Name)
+	}
+	g.printf("package %s\n\n", opts.Package)
+	g.printf("import (\n\"bytes\"\n\"fmt\"\n\nbin \"github.com/gagliardetto/binary\"\n\"github.com/gagliardetto/solana-go\"\n)\n\n")
+
+	if g.program.Metadata != nil && g.program.Metadata.Address != "" {
+		if err := g.declare("ProgramID"); err != nil {
+			return err
+		}
+		g.printf("// ProgramID is the address of the %s program recorded in the IDL\n", g.program.Name)
+		g.printf("var ProgramID = solana.MustPublicKeyFromBase58(%q)\n\n", g.program.Metadata.Address)
+	}
+
+	for k := range g.program.Types {
+		if err := g.typeDef(&g.program.Types[k]); err != nil {
+			return err
+		}
+	}
+	for k := range g.program.Accounts {
+		if err := g.account(&g.program.Accounts[k]); err != nil {
+			return err
+		}
+	}
+	for k := range g.program.Events {
+		if err := g.event(&g.program.Events[k]); err != nil {
+			return err
+		}
+	}
+	for k := range g.program.Instructions {
+		if err := g.instruction(&g.program.Instructions[k]); err != nil {
+			return err
+		}
+	}
+	g.helpers()
+	return nil
+}
+
+func (g *generator) typeDef(def *idl.TypeDef) error {
+	name := GoName(def.Name)
+	if err := g.declare(name); err != nil {
+		return err
+	}
+	switch def.Type.Kind {
+	case "struct":
+		g.printf("// %s is the %s type of the %s IDL\n", name, def.Name, g.program.Name)
+		g.docs(def.Docs)
+		return g.structType(name, def.Type.Fields)
+	case "enum":
+		return g.enumType(name, def)
+	case "alias":
+		if def.Type.Value == nil {
+			return fmt.Errorf("alias %s has no value", def.Name)
+		}
+		typ, err := g.goType(*def.Type.Value)
+		if err != nil {
+			return fmt.Errorf("alias %s: %w", def.Name, err)
+		}
+		g.printf("// %s is the %s alias of the %s IDL\n", name, def.Name, g.program.Name)
+		g.docs(def.Docs)
+		g.printf("type %s %s\n\n", name, typ)
+		return nil
+	}
+	return fmt.Errorf("type %s has unsupported kind %q", def.Name, def.Type.Kind)
+}
+
+// enumType renders fieldless enums as uint8 constants, and enums with fields as Borsh complex enums
+func (g *generator) enumType(name string, def *idl.TypeDef) error {
+	complex := false
+	for _, variant := range def.Type.Variants {
+		if len(variant.Fields) > 0 {
+			complex = true
+		}
+	}
+
+	g.printf("// %s is the %s enum of the %s IDL\n", name, def.Name, g.program.Name)
+	g.docs(def.Docs)
+	if !complex {
+		g.printf("type %s uint8\n\n", name)
+		g.printf("const (\n")
+		for k, variant := range def.Type.Variants {
+			constant := name + GoName(variant.Name)
+			if err := g.declare(constant); err != nil {
+				return err
+			}
+			if k == 0 {
+				g.printf("%s %s = iota\n", constant, name)
+			} else {
+				g.printf("%s\n", constant)
+			}
+		}
+		g.printf(")\n\n")
+		return nil
+	}
+
+	fields := map[string]bool{"Enum": true}
+	g.printf("type %s struct {\nEnum bin.BorshEnum `borsh_enum:\"true\"`\n", name)
+	for _, variant := range def.Type.Variants {
+		field := GoName(variant.Name)
+		if fields[field] {
+			return fmt.Errorf("enum %s has a duplicate variant %s", def.Name, variant.Name)
+		}
+		fields[field] = true
+		if len(variant.Fields) == 0 {
+			g.printf("%s bin.EmptyVariant\n", field)
+		} else {
+			g.printf("%s %s%sVariant\n", field, name, field)
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("const (\n")
+	for k, variant := range def.Type.Variants {
+		constant := name + GoName(variant.Name)
+		if err := g.declare(constant); err != nil {
+			return err
+		}
+		if k == 0 {
+			g.printf("%s bin.BorshEnum = iota\n", constant)
+		} else {
+			g.printf("%s\n", constant)
+		}
+	}
+	g.printf(")\n\n")
+
+	for _, variant := range def.Type.Variants {
+		if len(variant.Fields) == 0 {
+			continue
+		}
+		variantName := name + GoName(variant.Name) + "Variant"
+		if err := g.declare(variantName); err != nil {
+			return err
+		}
+		// Tuple variants have unnamed fields
+		fields := make([]idl.Field, len(variant.Fields))
+		for k, field := range variant.Fields {
+			if field.Name == "" {
+				field.Name = fmt.Sprintf("field%d", k)
+			}
+			fields[k] = field
+		}
+		g.printf("// %s holds the fields of the %s variant of %s\n", variantName, variant.Name, name)
+		if err := g.structType(variantName, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) account(def *idl.TypeDef) error {
+	name := GoName(def.Name)
+	if def.Type.Kind != "struct" {
+		return fmt.Errorf("account %s is not a struct", def.Name)
+	}
+	for _, identifier := range []string{name, name + "Discriminator", "Decode" + name} {
+		if err := g.declare(identifier); err != nil {
+			return err
+		}
+	}
+
+	g.printf("// %s is the %s account of the %s IDL, without its discriminator\n", name, def.Name, g.program.Name)
+	g.docs(def.Docs)
+	if err := g.structType(name, def.Type.Fields); err != nil {
+		return err
+	}
+
+	if g.program.Layout == idl.NativeLayout {
+		g.printf("// %sDiscriminator is empty: accounts of native programs carry no discriminator\n", name)
+	} else {
+		g.printf("// %sDiscriminator prefixes every %s account\n", name, def.Name)
+	}
+	g.printf("var %sDiscriminator = %s\n\n", name, byteSlice(def.Discriminator()))
+
+	g.printf("// Decode%s decodes the raw data of a %s account\n", name, def.Name)
+	g.printf("func Decode%s(data []byte) (*%s, error) {\n", name, name)
+	g.printf("var account %s\n", name)
+	g.printf("if err := decode(data, %sDiscriminator, %q, &account); err != nil {\nreturn nil, err\n}\n", name, def.Name)
+	g.printf("return &account, nil\n}\n\n")
+	return nil
+}
+
+func (g *generator) event(event *idl.Event) error {
+	name := GoName(event.Name)
+	for _, identifier := range []string{name, name + "Discriminator", "Decode" + name} {
+		if err := g.declare(identifier); err != nil {
+			return err
+		}
+	}
+
+	g.printf("// %s is the %s event of the %s IDL\n", name, event.Name, g.program.Name)
+	if err := g.structType(name, event.Fields); err != nil {
+		return err
+	}
+
+	g.printf("// %sDiscriminator prefixes every %s event\n", name, event.Name)
+	g.printf("var %sDiscriminator = %s\n\n", name, byteSlice(event.Discriminator()))
+
+	g.printf("// Decode%s decodes a %s event from \"Program data:\" log bytes or emit_cpi instruction data\n", name, event.Name)
+	g.printf("func Decode%s(data []byte) (*%s, error) {\n", name, name)
+	g.printf("var event %s\n", name)
+	g.printf("if err := decode(bytes.TrimPrefix(data, eventInstructionTag), %sDiscriminator, %q, &event); err != nil {\nreturn nil, err\n}\n", name, event.Name)
+	g.printf("return &event, nil\n}\n\n")
+	return nil
+}
+
+// accountField is an instruction account flattened out of its nested groups
+type accountField struct {
+	idl.AccountItem
+	path string
+}
+
+func flattenAccounts(items []idl.AccountItem, prefix string) []accountField {
+	var fields []accountField
+	for _, item := range items {
+		if len(item.Accounts) > 0 {
+			fields = append(fields, flattenAccounts(item.Accounts, prefix+item.Name+"_")...)
+			continue
+		}
+		fields = append(fields, accountField{AccountItem: item, path: prefix + item.Name})
+	}
+	return fields
+}
+
+func (g *generator) instruction(ix *idl.Instruction) error {
+	name := GoName(ix.Name)
+	hasArgs := len(ix.Args) > 0
+	identifiers := []string{name + "Accounts", name + "InstructionDiscriminator", "New" + name + "Instruction", "Decode" + name + "Accounts"}
+	if hasArgs {
+		identifiers = append(identifiers, name+"Args", "Decode"+name+"Args")
+	}
+	for _, identifier := range identifiers {
+		if err := g.declare(identifier); err != nil {
+			return err
+		}
+	}
+
+	accounts := flattenAccounts(ix.Accounts, "")
+	seen := make(map[string]bool)
+	for _, account := range accounts {
+		field := GoName(account.path)
+		if seen[field] {
+			return fmt.Errorf("instruction %s has a duplicate account %s", ix.Name, account.path)
+		}
+		seen[field] = true
+	}
+
+	g.printf("// %sInstructionDiscriminator prefixes the data of every %s instruction\n", name, ix.Name)
+	g.printf("var %sInstructionDiscriminator = %s\n\n", name, byteSlice(ix.Discriminator()))
+
+	if hasArgs {
+		g.printf("// %sArgs holds the arguments of the %s instruction\n", name, ix.Name)
+		g.docs(ix.Docs)
+		if err := g.structType(name+"Args", ix.Args); err != nil {
+			return err
+		}
+	}
+
+	g.printf("// %sAccounts lists the accounts of the %s instruction in IDL order\n", name, ix.Name)
+	g.printf("type %sAccounts struct {\n", name)
+	for _, account := range accounts {
+		g.docs(account.Docs)
+		var flags []string
+		if account.IsMut {
+			flags = append(flags, "writable")
+		}
+		if account.IsSigner {
+			flags = append(flags, "signer")
+		}
+		if account.IsOptional {
+			flags = append(flags, "optional")
+		}
+		if len(flags) > 0 {
+			g.printf("%s solana.PublicKey // %s\n", GoName(account.path), strings.Join(flags, ", "))
+		} else {
+			g.printf("%s solana.PublicKey\n", GoName(account.path))
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("// New%sInstruction builds the %s instruction. Remaining accounts are appended after the IDL ones", name, ix.Name)
+	optional := false
+	for _, account := range accounts {
+		optional = optional || account.IsOptional
+	}
+	if optional {
+		g.printf(",\n// and optional accounts left empty are replaced by the program ID as Anchor expects")
+	}
+	g.printf("\n")
+	if hasArgs {
+		g.printf("func New%sInstruction(programID solana.PublicKey, args %sArgs, accounts %sAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {\n", name, name, name)
+		g.printf("data, err := encode(%sInstructionDiscriminator, &args)\n", name)
+	} else {
+		g.printf("func New%sInstruction(programID solana.PublicKey, accounts %sAccounts, remaining ...*solana.AccountMeta) (solana.Instruction, error) {\n", name, name)
+		g.printf("data, err := encode(%sInstructionDiscriminator, nil)\n", name)
+	}
+	g.printf("if err != nil {\nreturn nil, fmt.Errorf(\"failed to encode %s args: %%w\", err)\n}\n\n", ix.Name)
+	g.printf("metas := solana.AccountMetaSlice{\n")
+	for _, account := range accounts {
+		key := "accounts." + GoName(account.path)
+		if account.IsOptional {
+			key = "optionalAccount(" + key + ", programID)"
+		}
+		g.printf("solana.NewAccountMeta(%s, %t, %t),\n", key, account.IsMut, account.IsSigner)
+	}
+	g.printf("}\n")
+	g.printf("return solana.NewInstruction(programID, append(metas, remaining...), data), nil\n}\n\n")
+
+	if hasArgs {
+		g.printf("// Decode%sArgs decodes the data of the %s instruction\n", name, ix.Name)
+		g.printf("func Decode%sArgs(data []byte) (*%sArgs, error) {\n", name, name)
+		g.printf("var args %sArgs\n", name)
+		g.printf("if err := decode(data, %sInstructionDiscriminator, %q, &args); err != nil {\nreturn nil, err\n}\n", name, ix.Name)
+		g.printf("return &args, nil\n}\n\n")
+	}
+
+	g.printf("// Decode%sAccounts maps the account keys of the %s instruction by IDL order, ignoring remaining accounts\n", name, ix.Name)
+	g.printf("func Decode%sAccounts(keys []solana.PublicKey) (*%sAccounts, error) {\n", name, name)
+	if len(accounts) > 0 {
+		g.printf("if len(keys) < %d {\n", len(accounts))
+		g.printf("return nil, fmt.Errorf(\"%s needs %d accounts, got %%d\", len(keys))\n}\n", ix.Name, len(accounts))
+	}
+	g.printf("return &%sAccounts{\n", name)
+	for k, account := range accounts {
+		g.printf("%s: keys[%d],\n", GoName(account.path), k)
+	}
+	g.printf("}, nil\n}\n\n")
+	return nil
+}
+
+func (g *generator) helpers() {
+	g.printf("// eventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event\n")
+	g.printf("var eventInstructionTag = %s\n\n", byteSlice(idl.EventInstructionTag[:]))
+
+	g.printf("// decode checks that data starts with the discriminator and Borsh decodes the rest into v\n")
+	g.printf("func decode(data, discriminator []byte, name string, v interface{}) error {\n")
+	g.printf("if !bytes.HasPrefix(data, discriminator) {\nreturn fmt.Errorf(\"data is not a %%s: discriminator mismatch\", name)\n}\n")
+	g.printf("if err := bin.NewBorshDecoder(data[len(discriminator):]).Decode(v); err != nil {\n")
+	g.printf("return fmt.Errorf(\"failed to decode %%s: %%w\", name, err)\n}\nreturn nil\n}\n\n")
+
+	g.printf("// encode Borsh encodes v after the discriminator\n")
+	g.printf("func encode(discriminator []byte, v interface{}) ([]byte, error) {\n")
+	g.printf("buf := bytes.NewBuffer(append([]byte(nil), discriminator...))\n")
+	g.printf("if v != nil {\nif err := bin.NewBorshEncoder(buf).Encode(v); err != nil {\nreturn nil, err\n}\n}\n")
+	g.printf("return buf.Bytes(), nil\n}\n\n")
+
+	g.printf("// optionalAccount passes the program ID in place of an omitted optional account\n")
+	g.printf("func optionalAccount(key, programID solana.PublicKey) solana.PublicKey {\n")
+	g.printf("if key.IsZero() {\nreturn programID\n}\nreturn key\n}\n")
+}
+
+func (g *generator) structType(name string, fields []idl.Field) error {
+	seen := make(map[string]bool)
+	g.printf("type %s struct {\n", name)
+	for _, field := range fields {
+		fieldName := GoName(field.Name)
+		if seen[fieldName] {
+			return fmt.Errorf("%s has a duplicate field %s", name, field.Name)
+		}
+		seen[fieldName] = true
+
+		typ, err := g.goType(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s of %s: %w", field.Name, name, err)
+		}
+		g.docs(field.Docs)
+		switch {
+		case field.Type.Option != nil:
+			g.printf("%s %s `bin:\"optional\"`\n", fieldName, typ)
+		case field.Type.COption != nil:
+			g.printf("%s %s `bin:\"coption\"`\n", fieldName, typ)
+		default:
+			g.printf("%s %s\n", fieldName, typ)
+		}
+	}
+	g.printf("}\n\n")
+	return nil
+}
+
+var primitives = map[string]string{
+	"bool":      "bool",
+	"u8":        "uint8",
+	"i8":        "int8",
+	"u16":       "uint16",
+	"i16":       "int16",
+	"u32":       "uint32",
+	"i32":       "int32",
+	"u64":       "uint64",
+	"i64":       "int64",
+	"u128":      "bin.Uint128",
+	"i128":      "bin.Int128",
+	"f32":       "float32",
+	"f64":       "float64",
+	"string":    "string",
+	"bytes":     "[]byte",
+	"publicKey": "solana.PublicKey",
+	"pubkey":    "solana.PublicKey",
+}
+
+// goType maps an IDL type to its Go type. Options are only supported as struct fields, where the
+// binary package reads the presence flag from the field tag.
+func (g *generator) goType(t idl.Type) (string, error) {
+	switch {
+	case t.Option != nil, t.COption != nil:
+		inner := t.Option
+		if inner == nil {
+			inner = t.COption
+		}
+		if inner.Option != nil || inner.COption != nil {
+			return "", fmt.Errorf("unsupported nested option %s", t)
+		}
+		typ, err := g.goType(*inner)
+		if err != nil {
+			return "", err
+		}
+		return "*" + typ, nil
+	case t.Vec != nil:
+		if t.Vec.Option != nil || t.Vec.COption != nil {
+			return "", fmt.Errorf("unsupported option element in %s", t)
+		}
+		typ, err := g.goType(*t.Vec)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + typ, nil
+	case t.Array != nil:
+		if t.Array.Option != nil || t.Array.COption != nil {
+			return "", fmt.Errorf("unsupported option element in %s", t)
+		}
+		typ, err := g.goType(*t.Array)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("[%d]%s", t.ArrayLen, typ), nil
+	case t.Defined != "":
+		if _, ok := g.typeDefs[t.Defined]; !ok {
+			return "", fmt.Errorf("undefined type %s", t.Defined)
+		}
+		return GoName(t.Defined), nil
+	}
+
+	typ, ok := primitives[t.Primitive]
+	if !ok {
+		return "", fmt.Errorf("unsupported type %q", t.Primitive)
+	}
+	return typ, nil
+}
+
+func (g *generator) docs(lines []string) {
+	for _, line := range lines {
+		g.printf("// %s\n", strings.TrimSpace(line))
+	}
+}
+
+// GoName turns an IDL name in camel or snake case into an exported Go identifier, e.g. open_time to OpenTime
+func GoName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// byteSlice renders bytes as a Go []byte literal
+func byteSlice(data []byte) string {
+	parts := make([]string, len(data))
+	for k, b := range data {
+		parts[k] = fmt.Sprint(b)
+	}
+	return "[]byte{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,280 @@
+package codegen_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"corvus_bot/pkg/idl"
+	"corvus_bot/pkg/idl/bindings/jupiter"
+	"corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/idl/bindings/raydiumamm"
+	"corvus_bot/pkg/idl/bindings/raydiumclmm"
+	"corvus_bot/pkg/idl/codegen"
+	"corvus_bot/pkg/raydium/pool/amm"
+	"corvus_bot/pkg/raydium/pool/clmm"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bindingPackages maps every bundled IDL, relative to data/IDL, to the package generated from it in
+// pkg/idl/bindings. IDLs under subset are hand-written subsets of the program's IDL.
+var bindingPackages = map[string]string{
+	"raydium_amm_idl.json":                "raydiumamm",
+	"raydium_clmm_idl.json":               "raydiumclmm",
+	"jupiter_idl.json":                    "jupiter",
+	"pumpfun_idl.json":                    "pumpfun",
+	"moonshot_idl.json":                   "moonshot",
+	"subset/whirlpool_subset_idl.json":    "whirlpool",
+	"subset/meteora_dlmm_subset_idl.json": "meteoradlmm",
+}
+
+func loadIDL(t *testing.T, name string) *idl.IDL {
+	t.Helper()
+	program, err := idl.Load(filepath.Join("..", "..", "..", "data", "IDL", name))
+	require.NoError(t, err)
+	return program
+}
+
+// loadFixtureData returns the base64 account data found at the JSON path of a data/testdata fixture
+func loadFixtureData(t *testing.T, file string, path ...string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("..", "..", "..", "data", "testdata", file))
+	require.NoError(t, err)
+
+	var node map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw, &node))
+	for _, key := range path {
+		require.NoError(t, json.Unmarshal(node[key], &node))
+	}
+	var encoded string
+	require.NoError(t, json.Unmarshal(node["data"], &encoded))
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	return data
+}
+
+func TestGeneratedBindingsAreUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..", "..", "data", "IDL")
+	names, err := filepath.Glob(filepath.Join(root, "*.json"))
+	require.NoError(t, err)
+	subsets, err := filepath.Glob(filepath.Join(root, "subset", "*.json"))
+	require.NoError(t, err)
+
+	for _, path := range append(names, subsets...) {
+		name, err := filepath.Rel(root, path)
+		require.NoError(t, err)
+		name = filepath.ToSlash(name)
+		pkg, ok := bindingPackages[name]
+		require.True(t, ok, "no bindings package for %s, add it to pkg/idl/bindings/generate.go", name)
+
+		opts := codegen.Options{Package: pkg, Source: filepath.Base(name), Subset: filepath.Dir(name) == "subset"}
+		src, err := codegen.Generate(loadIDL(t, name), opts)
+		require.NoError(t, err, name)
+
+		committed, err := os.ReadFile(filepath.Join("..", "bindings", pkg, "idl_gen.go"))
+		require.NoError(t, err, name)
+		assert.True(t, bytes.Equal(committed, src), "bindings for %s are stale, run go generate in pkg/idl/bindings", name)
+	}
+}
+
+func TestGenerateRejectsUnknownTypes(t *testing.T) {
+	program, err := idl.Parse([]byte(`{
+		"version": "0.1.0",
+		"name": "broken",
+		"instructions": [{"name": "run", "accounts": [], "args": [{"name": "config", "type": {"defined": "Missing"}}]}]
+	}`))
+	require.NoError(t, err)
+
+	_, err = codegen.Generate(program, codegen.Options{Package: "broken"})
+	assert.ErrorContains(t, err, "undefined type Missing")
+
+	_, err = codegen.Generate(program, codegen.Options{})
+	assert.Error(t, err)
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "OpenTime", codegen.GoName("openTime"))
+	assert.Equal(t, "OpenTime", codegen.GoName("open_time"))
+	assert.Equal(t, "AToB", codegen.GoName("aToB"))
+	assert.Equal(t, "SwapV2", codegen.GoName("swapV2"))
+}
+
+func TestGeneratedAccountDecoders(t *testing.T) {
+	poolData := loadFixtureData(t, "clmm_pool_sol_usdc.json", "pool")
+	expectedPool, err := clmm.DecodePoolState(poolData)
+	require.NoError(t, err)
+
+	pool, err := raydiumclmm.DecodePoolState(poolData)
+	require.NoError(t, err)
+	assert.Equal(t, expectedPool.TokenMint0, pool.TokenMint0)
+	assert.Equal(t, expectedPool.TokenVault1, pool.TokenVault1)
+	assert.Equal(t, expectedPool.TickSpacing, pool.TickSpacing)
+	assert.Equal(t, expectedPool.TickCurrent, pool.TickCurrent)
+	assert.Equal(t, expectedPool.SqrtPriceX64, pool.SqrtPriceX64)
+	assert.Equal(t, expectedPool.RewardInfos[0].TokenMint, pool.RewardInfos[0].TokenMint)
+	assert.Equal(t, expectedPool.TickArrayBitmap, pool.TickArrayBitmap)
+
+	_, err = raydiumclmm.DecodeAmmConfig(poolData)
+	assert.ErrorContains(t, err, "discriminator mismatch")
+
+	// Native AMM accounts have no discriminator
+	infoData := loadFixtureData(t, "amm_info_sol_usdc.json")
+	expectedInfo, err := amm.DecodeAmmInfo(infoData)
+	require.NoError(t, err)
+
+	info, err := raydiumamm.DecodeAmmInfo(infoData)
+	require.NoError(t, err)
+	assert.Equal(t, expectedInfo.CoinMint, info.CoinMint)
+	assert.Equal(t, expectedInfo.PcMint, info.PcMint)
+	assert.Equal(t, expectedInfo.Fees.SwapFeeNumerator, info.Fees.SwapFeeNumerator)
+	assert.Equal(t, expectedInfo.OutPut.SwapCoinInAmount, info.OutPut.SwapCoinInAmount)
+}
+
+func TestGeneratedInstructionBuilder(t *testing.T) {
+	program := loadIDL(t, "raydium_amm_idl.json")
+	programID := solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+
+	accounts := raydiumamm.Initialize2Accounts{
+		TokenProgram:  solana.TokenProgramID,
+		SystemProgram: solana.SystemProgramID,
+		Amm:           solana.NewWallet().PublicKey(),
+		CoinMint:      solana.NewWallet().PublicKey(),
+		PcMint:        solana.WrappedSol,
+		UserWallet:    solana.NewWallet().PublicKey(),
+	}
+	args := raydiumamm.Initialize2Args{Nonce: 254, OpenTime: 1_700_000_000, InitPcAmount: 5_000_000_000, InitCoinAmount: 1_000_000}
+
+	ix, err := raydiumamm.NewInitialize2Instruction(programID, args, accounts)
+	require.NoError(t, err)
+	assert.Equal(t, programID, ix.ProgramID())
+
+	data, err := ix.Data()
+	require.NoError(t, err)
+	assert.Equal(t, raydiumamm.Initialize2InstructionDiscriminator, data[:1])
+	assert.Len(t, data, 1+1+8+8+8)
+
+	metas := ix.Accounts()
+	require.Len(t, metas, 21)
+	assert.True(t, metas[4].IsWritable)
+	assert.False(t, metas[8].IsWritable)
+	assert.True(t, metas[17].IsSigner)
+
+	// The runtime decoder reads back what the generated builder wrote
+	keys := make([]solana.PublicKey, len(metas))
+	for k, meta := range metas {
+		keys[k] = meta.PublicKey
+	}
+	decoded, err := program.DecodeInstruction(data, keys)
+	require.NoError(t, err)
+	assert.Equal(t, "initialize2", decoded.Name)
+	assert.Equal(t, uint8(254), decoded.Args["nonce"])
+	assert.Equal(t, uint64(5_000_000_000), decoded.Args["initPcAmount"])
+	assert.Equal(t, accounts.Amm, decoded.Accounts["amm"])
+	assert.Equal(t, accounts.UserWallet, decoded.Accounts["userWallet"])
+
+	decodedArgs, err := raydiumamm.DecodeInitialize2Args(data)
+	require.NoError(t, err)
+	assert.Equal(t, args, *decodedArgs)
+
+	decodedAccounts, err := raydiumamm.DecodeInitialize2Accounts(keys)
+	require.NoError(t, err)
+	assert.Equal(t, accounts, *decodedAccounts)
+
+	_, err = raydiumamm.DecodeInitialize2Accounts(keys[:15])
+	assert.ErrorContains(t, err, "needs 21 accounts")
+
+	_, err = raydiumamm.DecodeInitialize2Args(append([]byte{9}, data[1:]...))
+	assert.ErrorContains(t, err, "discriminator mismatch")
+}
+
+func TestGeneratedComplexEnumsAndOptionalAccounts(t *testing.T) {
+	program := loadIDL(t, "jupiter_idl.json")
+	programID := solana.NewWallet().PublicKey()
+
+	args := jupiter.RouteArgs{
+		RoutePlan: []jupiter.RoutePlanStep{
+			{Swap: jupiter.Swap{Enum: jupiter.SwapRaydium}, Percent: 100, InputIndex: 0, OutputIndex: 1},
+			{Swap: jupiter.Swap{Enum: jupiter.SwapSerum, Serum: jupiter.SwapSerumVariant{Side: jupiter.SideAsk}}, Percent: 50, InputIndex: 1, OutputIndex: 2},
+		},
+		InAmount:        1_000_000,
+		QuotedOutAmount: 990_000,
+		SlippageBps:     50,
+	}
+	accounts := jupiter.RouteAccounts{
+		TokenProgram:          solana.TokenProgramID,
+		UserTransferAuthority: solana.NewWallet().PublicKey(),
+		DestinationMint:       solana.WrappedSol,
+	}
+	remaining := solana.NewAccountMeta(solana.NewWallet().PublicKey(), true, false)
+
+	ix, err := jupiter.NewRouteInstruction(programID, args, accounts, remaining)
+	require.NoError(t, err)
+
+	// Omitted optional accounts are passed as the program ID
+	metas := ix.Accounts()
+	require.Len(t, metas, 10)
+	assert.Equal(t, programID, metas[4].PublicKey)
+	assert.Equal(t, programID, metas[6].PublicKey)
+	assert.Equal(t, remaining, metas[9])
+
+	// Same layout as the hand-encoded route of the runtime decoder tests
+	expected := new(bytes.Buffer)
+	expected.Write(jupiter.RouteInstructionDiscriminator)
+	enc := bin.NewBorshEncoder(expected)
+	require.NoError(t, enc.WriteUint32(2, binary.LittleEndian))
+	require.NoError(t, enc.WriteBytes([]byte{7, 100, 0, 1, 12, 1, 50, 1, 2}, false))
+	require.NoError(t, enc.WriteUint64(1_000_000, binary.LittleEndian))
+	require.NoError(t, enc.WriteUint64(990_000, binary.LittleEndian))
+	require.NoError(t, enc.WriteUint16(50, binary.LittleEndian))
+	require.NoError(t, enc.WriteUint8(0))
+
+	data, err := ix.Data()
+	require.NoError(t, err)
+	assert.Equal(t, expected.Bytes(), data)
+
+	decoded, err := program.DecodeInstruction(data, nil)
+	require.NoError(t, err)
+	second := decoded.Args["routePlan"].([]any)[1].(map[string]any)
+	assert.Equal(t, idl.EnumValue{Variant: "Serum", Fields: map[string]any{"side": idl.EnumValue{Variant: "Ask"}}}, second["swap"])
+
+	decodedArgs, err := jupiter.DecodeRouteArgs(data)
+	require.NoError(t, err)
+	assert.Equal(t, args, *decodedArgs)
+}
+
+func TestGeneratedEventDecoder(t *testing.T) {
+	event := pumpfun.TradeEvent{
+		Mint:                 solana.NewWallet().PublicKey(),
+		SolAmount:            1_500_000_000,
+		TokenAmount:          42_000_000,
+		IsBuy:                true,
+		User:                 solana.NewWallet().PublicKey(),
+		Timestamp:            1_700_000_000,
+		VirtualSolReserves:   30_000_000_000,
+		VirtualTokenReserves: 1_000_000_000_000_000,
+	}
+	buf := bytes.NewBuffer(append([]byte(nil), pumpfun.TradeEventDiscriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(&event))
+
+	decoded, err := pumpfun.DecodeTradeEvent(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, event, *decoded)
+
+	// emit_cpi! instruction data carries the event instruction tag first
+	decoded, err = pumpfun.DecodeTradeEvent(append(idl.EventInstructionTag[:], buf.Bytes()...))
+	require.NoError(t, err)
+	assert.Equal(t, event, *decoded)
+
+	_, err = pumpfun.DecodeCreateEvent(buf.Bytes())
+	assert.ErrorContains(t, err, "discriminator mismatch")
+
+	assert.Equal(t, solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"), pumpfun.ProgramID)
+}
@@ -0,0 +1,411 @@
+package idl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// EnumValue is a decoded enum: the variant name and, for variants carrying data, its fields.
+// Fields of tuple variants are keyed by their position, "0", "1", ...
+type EnumValue struct {
+	Variant string
+	Fields  map[string]any
+}
+
+// DecodedInstruction is an instruction decoded against the IDL
+type DecodedInstruction struct {
+	Name      string
+	Args      map[string]any
+	Accounts  map[string]solana.PublicKey // Keyed by IDL account name, nested groups as "group.name"
+	Remaining []solana.PublicKey          // Accounts beyond the ones declared by the IDL
+}
+
+// DecodedEvent is an event decoded against the IDL
+type DecodedEvent struct {
+	Name   string
+	Fields map[string]any
+}
+
+// DecodeAccount decodes account data of the named account type.
+// Anchor accounts must start with their discriminator; trailing bytes are ignored.
+func (i *IDL) DecodeAccount(name string, data []byte) (map[string]any, error) {
+	account, body, err := i.accountBody(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &decoder{idl: i, data: body}
+	value, err := d.decodeTypeDef(account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode account %s: %w", name, err)
+	}
+	return value.(map[string]any), nil
+}
+
+// DecodeAccountInto Borsh-decodes account data of the named account type into v, a struct mirroring the IDL layout
+func (i *IDL) DecodeAccountInto(name string, data []byte, v any) error {
+	_, body, err := i.accountBody(name, data)
+	if err != nil {
+		return err
+	}
+	if err := bin.NewBorshDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode account %s: %w", name, err)
+	}
+	return nil
+}
+
+// IdentifyAccount returns the name of the account type whose discriminator prefixes data.
+// Accounts of native programs carry no discriminator and cannot be identified.
+func (i *IDL) IdentifyAccount(data []byte) (string, error) {
+	if i.Layout == NativeLayout {
+		return "", fmt.Errorf("accounts of %s have no discriminator", i.Name)
+	}
+	if len(data) < DiscriminatorSize {
+		return "", fmt.Errorf("account data too short: %d bytes", len(data))
+	}
+	account, ok := i.accountsByDisc[string(data[:DiscriminatorSize])]
+	if !ok {
+		return "", fmt.Errorf("unknown account discriminator %v", data[:DiscriminatorSize])
+	}
+	return account.Name, nil
+}
+
+// DecodeInstruction identifies an instruction by its discriminator and decodes its arguments.
+// The accounts, when given, are matched to the IDL account names in order.
+func (i *IDL) DecodeInstruction(data []byte, accounts []solana.PublicKey) (*DecodedInstruction, error) {
+	instruction, err := i.identifyInstruction(data)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &decoder{idl: i, data: data[len(instruction.discriminator):]}
+	args, err := d.decodeFields(instruction.Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode instruction %s: %w", instruction.Name, err)
+	}
+
+	decoded := &DecodedInstruction{Name: instruction.Name, Args: args}
+	if accounts != nil {
+		names := instruction.AccountNames()
+		decoded.Accounts = make(map[string]solana.PublicKey, len(names))
+		for k, name := range names {
+			if k >= len(accounts) {
+				break
+			}
+			decoded.Accounts[name] = accounts[k]
+		}
+		if len(accounts) > len(names) {
+			decoded.Remaining = accounts[len(names):]
+		}
+	}
+	return decoded, nil
+}
+
+// DecodeInstructionInto checks that data is the named instruction and Borsh-decodes its arguments into v
+func (i *IDL) DecodeInstructionInto(name string, data []byte, v any) error {
+	instruction, err := i.Instruction(name)
+	if err != nil {
+		return err
+	}
+	if !hasPrefix(data, instruction.discriminator) {
+		return fmt.Errorf("data is not a %s instruction", name)
+	}
+	if err := bin.NewBorshDecoder(data[len(instruction.discriminator):]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode instruction %s: %w", name, err)
+	}
+	return nil
+}
+
+// DecodeEvent decodes event data as logged by emit! ("Program data:" logs, base64 decoded)
+// or as the data of the self-CPI instruction of emit_cpi!
+func (i *IDL) DecodeEvent(data []byte) (*DecodedEvent, error) {
+	event, body, err := i.eventBody(data)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &decoder{idl: i, data: body}
+	fields, err := d.decodeFields(event.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode event %s: %w", event.Name, err)
+	}
+	return &DecodedEvent{Name: event.Name, Fields: fields}, nil
+}
+
+// DecodeEventInto decodes event data like DecodeEvent, checking it is the named event, into v
+func (i *IDL) DecodeEventInto(name string, data []byte, v any) error {
+	event, body, err := i.eventBody(data)
+	if err != nil {
+		return err
+	}
+	if event.Name != name {
+		return fmt.Errorf("data is a %s event, not %s", event.Name, name)
+	}
+	if err := bin.NewBorshDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode event %s: %w", name, err)
+	}
+	return nil
+}
+
+func (i *IDL) accountBody(name string, data []byte) (*TypeDef, []byte, error) {
+	account, err := i.Account(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if i.Layout == NativeLayout {
+		return account, data, nil
+	}
+	if !hasPrefix(data, account.discriminator) {
+		return nil, nil, fmt.Errorf("account is not a %s: discriminator mismatch", name)
+	}
+	return account, data[DiscriminatorSize:], nil
+}
+
+func (i *IDL) identifyInstruction(data []byte) (*Instruction, error) {
+	size := DiscriminatorSize
+	if i.Layout == NativeLayout {
+		size = 1
+	}
+	if len(data) < size {
+		return nil, fmt.Errorf("instruction data too short: %d bytes", len(data))
+	}
+	instruction, ok := i.instructionsByDisc[string(data[:size])]
+	if !ok {
+		return nil, fmt.Errorf("unknown %s instruction discriminator %v", i.Name, data[:size])
+	}
+	return instruction, nil
+}
+
+func (i *IDL) eventBody(data []byte) (*Event, []byte, error) {
+	if hasPrefix(data, EventInstructionTag[:]) {
+		data = data[DiscriminatorSize:]
+	}
+	if len(data) < DiscriminatorSize {
+		return nil, nil, fmt.Errorf("event data too short: %d bytes", len(data))
+	}
+	event, ok := i.eventsByDisc[string(data[:DiscriminatorSize])]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown %s event discriminator %v", i.Name, data[:DiscriminatorSize])
+	}
+	return event, data[DiscriminatorSize:], nil
+}
+
+// decoder walks Borsh encoded data following IDL types
+type decoder struct {
+	idl  *IDL
+	data []byte
+	pos  int
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, fmt.Errorf("unexpected end of data at offset %d reading %d bytes", d.pos, n)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) decodeFields(fields []Field) (map[string]any, error) {
+	values := make(map[string]any, len(fields))
+	for k, field := range fields {
+		value, err := d.decode(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		name := field.Name
+		if name == "" {
+			name = fmt.Sprint(k)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+func (d *decoder) decodeTypeDef(def *TypeDef) (any, error) {
+	switch def.Type.Kind {
+	case "struct":
+		return d.decodeFields(def.Type.Fields)
+	case "enum":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if int(b[0]) >= len(def.Type.Variants) {
+			return nil, fmt.Errorf("invalid variant %d of enum %s", b[0], def.Name)
+		}
+		variant := def.Type.Variants[b[0]]
+		value := EnumValue{Variant: variant.Name}
+		if len(variant.Fields) > 0 {
+			if value.Fields, err = d.decodeFields(variant.Fields); err != nil {
+				return nil, fmt.Errorf("variant %s: %w", variant.Name, err)
+			}
+		}
+		return value, nil
+	case "alias":
+		if def.Type.Value == nil {
+			return nil, fmt.Errorf("alias %s has no value", def.Name)
+		}
+		return d.decode(*def.Type.Value)
+	}
+	return nil, fmt.Errorf("unsupported kind %q of type %s", def.Type.Kind, def.Name)
+}
+
+func (d *decoder) decode(t Type) (any, error) {
+	switch {
+	case t.Option != nil:
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] == 0 {
+			return nil, nil
+		}
+		return d.decode(*t.Option)
+	case t.COption != nil:
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		if binary.LittleEndian.Uint32(b) == 0 {
+			return nil, nil
+		}
+		return d.decode(*t.COption)
+	case t.Vec != nil:
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeSequence(*t.Vec, int(binary.LittleEndian.Uint32(b)))
+	case t.Array != nil:
+		return d.decodeSequence(*t.Array, t.ArrayLen)
+	case t.Defined != "":
+		def, ok := d.idl.typeDefs[t.Defined]
+		if !ok {
+			return nil, fmt.Errorf("undefined type %s", t.Defined)
+		}
+		return d.decodeTypeDef(def)
+	}
+	return d.decodePrimitive(t.Primitive)
+}
+
+// decodeSequence decodes vec and array elements; sequences of u8 are returned as []byte
+func (d *decoder) decodeSequence(elem Type, n int) (any, error) {
+	if elem.Primitive == "u8" {
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	}
+
+	// Every element takes at least one byte, which bounds corrupted lengths
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("sequence length %d exceeds remaining data", n)
+	}
+	values := make([]any, n)
+	for k := range values {
+		value, err := d.decode(elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", k, err)
+		}
+		values[k] = value
+	}
+	return values, nil
+}
+
+func (d *decoder) decodePrimitive(name string) (any, error) {
+	switch name {
+	case "bool":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "u8", "i8":
+		b, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if name == "i8" {
+			return int8(b[0]), nil
+		}
+		return b[0], nil
+	case "u16", "i16":
+		b, err := d.read(2)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint16(b)
+		if name == "i16" {
+			return int16(v), nil
+		}
+		return v, nil
+	case "u32", "i32", "f32":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint32(b)
+		switch name {
+		case "i32":
+			return int32(v), nil
+		case "f32":
+			return math.Float32frombits(v), nil
+		}
+		return v, nil
+	case "u64", "i64", "f64":
+		b, err := d.read(8)
+		if err != nil {
+			return nil, err
+		}
+		v := binary.LittleEndian.Uint64(b)
+		switch name {
+		case "i64":
+			return int64(v), nil
+		case "f64":
+			return math.Float64frombits(v), nil
+		}
+		return v, nil
+	case "u128", "i128":
+		b, err := d.read(16)
+		if err != nil {
+			return nil, err
+		}
+		// Little endian on the wire, big.Int wants big endian
+		be := make([]byte, 16)
+		for k := range b {
+			be[15-k] = b[k]
+		}
+		v := new(big.Int).SetBytes(be)
+		if name == "i128" && b[15]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), 128))
+		}
+		return v, nil
+	case "string", "bytes":
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := d.read(int(binary.LittleEndian.Uint32(b)))
+		if err != nil {
+			return nil, err
+		}
+		if name == "string" {
+			return string(payload), nil
+		}
+		return append([]byte(nil), payload...), nil
+	case "publicKey", "pubkey":
+		b, err := d.read(solana.PublicKeyLength)
+		if err != nil {
+			return nil, err
+		}
+		return solana.PublicKeyFromBytes(b), nil
+	}
+	return nil, fmt.Errorf("unsupported primitive type %q", name)
+}
@@ -0,0 +1,382 @@
+// Package idl loads Anchor style JSON IDLs, such as the ones bundled in data/IDL, and decodes
+// accounts, instructions and events by name without hand-written layouts.
+package idl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// DiscriminatorSize is the length of Anchor account, instruction and event discriminators
+const DiscriminatorSize = 8
+
+// Layout selects how accounts and instructions of a program are identified
+type Layout int
+
+const (
+	// AnchorLayout prefixes accounts, instructions and events with sha256 based discriminators
+	AnchorLayout Layout = iota
+
+	// NativeLayout tags instructions with their one byte index in the IDL and stores accounts without prefix,
+	// as the Raydium AMM v4 program does
+	NativeLayout
+)
+
+// nativePrograms lists the bundled IDLs describing programs that are not built with Anchor
+var nativePrograms = map[string]bool{
+	"raydium_amm": true,
+}
+
+// EventInstructionTag prefixes the self-CPI instruction used by Anchor's emit_cpi! to log an event
+var EventInstructionTag = [DiscriminatorSize]byte{228, 69, 165, 46, 81, 203, 154, 29}
+
+// IDL is a parsed program IDL in the legacy Anchor JSON format
+type IDL struct {
+	Version      string        `json:"version"`
+	Name         string        `json:"name"`
+	Instructions []Instruction `json:"instructions"`
+	Accounts     []TypeDef     `json:"accounts"`
+	Types        []TypeDef     `json:"types"`
+	Events       []Event       `json:"events"`
+	Errors       []ErrorCode   `json:"errors"`
+	Metadata     *Metadata     `json:"metadata,omitempty"`
+
+	Layout Layout `json:"-"`
+
+	typeDefs           map[string]*TypeDef
+	instructionsByDisc map[string]*Instruction
+	accountsByDisc     map[string]*TypeDef
+	eventsByDisc       map[string]*Event
+}
+
+// Metadata holds the optional metadata section of an IDL
+type Metadata struct {
+	Address string `json:"address"`
+}
+
+// Instruction describes an instruction, its accounts and its arguments
+type Instruction struct {
+	Name     string        `json:"name"`
+	Docs     []string      `json:"docs,omitempty"`
+	Accounts []AccountItem `json:"accounts"`
+	Args     []Field       `json:"args"`
+
+	discriminator []byte
+}
+
+// AccountItem is an account of an instruction, or a named group of accounts when Accounts is set
+type AccountItem struct {
+	Name       string        `json:"name"`
+	IsMut      bool          `json:"isMut"`
+	IsSigner   bool          `json:"isSigner"`
+	IsOptional bool          `json:"isOptional,omitempty"`
+	Docs       []string      `json:"docs,omitempty"`
+	Accounts   []AccountItem `json:"accounts,omitempty"`
+}
+
+// TypeDef is a named struct, enum or alias declared in the accounts or types section
+type TypeDef struct {
+	Name string      `json:"name"`
+	Docs []string    `json:"docs,omitempty"`
+	Type TypeDefBody `json:"type"`
+
+	discriminator []byte
+}
+
+// TypeDefBody is the body of a TypeDef: struct fields, enum variants or the aliased type
+type TypeDefBody struct {
+	Kind     string    `json:"kind"`
+	Fields   []Field   `json:"fields,omitempty"`
+	Variants []Variant `json:"variants,omitempty"`
+	Value    *Type     `json:"value,omitempty"`
+}
+
+// Variant is an enum variant; its fields are named, or unnamed for tuple variants
+type Variant struct {
+	Name   string  `json:"name"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// Event describes an event emitted by the program
+type Event struct {
+	Name   string  `json:"name"`
+	Fields []Field `json:"fields"`
+
+	discriminator []byte
+}
+
+// ErrorCode is a custom program error
+type ErrorCode struct {
+	Code int    `json:"code"`
+	Name string `json:"name"`
+	Msg  string `json:"msg,omitempty"`
+}
+
+// Field is a named and typed struct field, instruction argument or event field.
+// Fields of tuple enum variants have no name.
+type Field struct {
+	Name  string   `json:"name"`
+	Docs  []string `json:"docs,omitempty"`
+	Type  Type     `json:"type"`
+	Index bool     `json:"index,omitempty"`
+}
+
+// UnmarshalJSON accepts both named fields and the bare types of tuple enum variants
+func (f *Field) UnmarshalJSON(data []byte) error {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err == nil {
+		if _, named := probe["type"]; named {
+			type field Field
+			return json.Unmarshal(data, (*field)(f))
+		}
+	}
+	return json.Unmarshal(data, &f.Type)
+}
+
+// Type is an IDL type: a primitive name, or exactly one of the composite forms
+type Type struct {
+	Primitive string // bool, u8..u128, i8..i128, f32, f64, string, bytes, publicKey
+	Option    *Type
+	COption   *Type
+	Vec       *Type
+	Array     *Type
+	ArrayLen  int
+	Defined   string
+}
+
+// UnmarshalJSON parses "u64", {"option": T}, {"coption": T}, {"vec": T}, {"array": [T, n]} and {"defined": "Name"}
+func (t *Type) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Primitive); err == nil {
+		return nil
+	}
+
+	var composite struct {
+		Option  *Type             `json:"option"`
+		COption *Type             `json:"coption"`
+		Vec     *Type             `json:"vec"`
+		Array   []json.RawMessage `json:"array"`
+		Defined json.RawMessage   `json:"defined"`
+	}
+	if err := json.Unmarshal(data, &composite); err != nil {
+		return fmt.Errorf("invalid IDL type %s: %w", data, err)
+	}
+
+	switch {
+	case composite.Option != nil:
+		t.Option = composite.Option
+	case composite.COption != nil:
+		t.COption = composite.COption
+	case composite.Vec != nil:
+		t.Vec = composite.Vec
+	case composite.Array != nil:
+		if len(composite.Array) != 2 {
+			return fmt.Errorf("invalid IDL array type %s", data)
+		}
+		t.Array = new(Type)
+		if err := json.Unmarshal(composite.Array[0], t.Array); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(composite.Array[1], &t.ArrayLen); err != nil {
+			return fmt.Errorf("invalid IDL array length %s: %w", composite.Array[1], err)
+		}
+	case composite.Defined != nil:
+		// Newer IDLs wrap the name in an object
+		if err := json.Unmarshal(composite.Defined, &t.Defined); err != nil {
+			var named struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(composite.Defined, &named); err != nil {
+				return fmt.Errorf("invalid IDL defined type %s: %w", data, err)
+			}
+			t.Defined = named.Name
+		}
+	default:
+		return fmt.Errorf("unsupported IDL type %s", data)
+	}
+	return nil
+}
+
+// String renders the type the way it is written in Rust
+func (t Type) String() string {
+	switch {
+	case t.Option != nil:
+		return "Option<" + t.Option.String() + ">"
+	case t.COption != nil:
+		return "COption<" + t.COption.String() + ">"
+	case t.Vec != nil:
+		return "Vec<" + t.Vec.String() + ">"
+	case t.Array != nil:
+		return fmt.Sprintf("[%s; %d]", t.Array, t.ArrayLen)
+	case t.Defined != "":
+		return t.Defined
+	}
+	return t.Primitive
+}
+
+// Load reads and parses an IDL JSON file
+func Load(path string) (*IDL, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read IDL file: %w", err)
+	}
+	idl, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse IDL %s: %w", path, err)
+	}
+	return idl, nil
+}
+
+// Parse parses an IDL and indexes its types and discriminators
+func Parse(data []byte) (*IDL, error) {
+	var idl IDL
+	if err := json.Unmarshal(data, &idl); err != nil {
+		return nil, err
+	}
+	if nativePrograms[idl.Name] {
+		idl.Layout = NativeLayout
+	}
+	if err := idl.index(); err != nil {
+		return nil, err
+	}
+	return &idl, nil
+}
+
+func (i *IDL) index() error {
+	i.typeDefs = make(map[string]*TypeDef)
+	i.instructionsByDisc = make(map[string]*Instruction)
+	i.accountsByDisc = make(map[string]*TypeDef)
+	i.eventsByDisc = make(map[string]*Event)
+
+	// Legacy IDLs reference account structs as defined types too
+	for k := range i.Accounts {
+		account := &i.Accounts[k]
+		i.typeDefs[account.Name] = account
+		if i.Layout == AnchorLayout {
+			account.discriminator = Discriminator("account", account.Name)
+			i.accountsByDisc[string(account.discriminator)] = account
+		}
+	}
+	for k := range i.Types {
+		i.typeDefs[i.Types[k].Name] = &i.Types[k]
+	}
+
+	for k := range i.Instructions {
+		instruction := &i.Instructions[k]
+		if i.Layout == NativeLayout {
+			if k > 0xff {
+				return fmt.Errorf("instruction %s has no one byte tag", instruction.Name)
+			}
+			instruction.discriminator = []byte{byte(k)}
+		} else {
+			instruction.discriminator = Discriminator("global", SnakeCase(instruction.Name))
+		}
+		if other, ok := i.instructionsByDisc[string(instruction.discriminator)]; ok {
+			return fmt.Errorf("instructions %s and %s share a discriminator", other.Name, instruction.Name)
+		}
+		i.instructionsByDisc[string(instruction.discriminator)] = instruction
+	}
+
+	for k := range i.Events {
+		event := &i.Events[k]
+		event.discriminator = Discriminator("event", event.Name)
+		i.eventsByDisc[string(event.discriminator)] = event
+	}
+	return nil
+}
+
+// Instruction returns the instruction with the given name
+func (i *IDL) Instruction(name string) (*Instruction, error) {
+	for k := range i.Instructions {
+		if i.Instructions[k].Name == name {
+			return &i.Instructions[k], nil
+		}
+	}
+	return nil, fmt.Errorf("instruction %s not found in IDL %s", name, i.Name)
+}
+
+// Account returns the account type with the given name
+func (i *IDL) Account(name string) (*TypeDef, error) {
+	for k := range i.Accounts {
+		if i.Accounts[k].Name == name {
+			return &i.Accounts[k], nil
+		}
+	}
+	return nil, fmt.Errorf("account %s not found in IDL %s", name, i.Name)
+}
+
+// Event returns the event with the given name
+func (i *IDL) Event(name string) (*Event, error) {
+	for k := range i.Events {
+		if i.Events[k].Name == name {
+			return &i.Events[k], nil
+		}
+	}
+	return nil, fmt.Errorf("event %s not found in IDL %s", name, i.Name)
+}
+
+// Discriminator returns the bytes identifying the instruction: 8 bytes for Anchor programs,
+// the one byte tag for native ones
+func (ix *Instruction) Discriminator() []byte {
+	return ix.discriminator
+}
+
+// AccountNames returns the names of the instruction accounts in order, with nested groups flattened
+func (ix *Instruction) AccountNames() []string {
+	var names []string
+	var walk func(items []AccountItem, prefix string)
+	walk = func(items []AccountItem, prefix string) {
+		for _, item := range items {
+			if len(item.Accounts) > 0 {
+				walk(item.Accounts, prefix+item.Name+".")
+				continue
+			}
+			names = append(names, prefix+item.Name)
+		}
+	}
+	walk(ix.Accounts, "")
+	return names
+}
+
+// Discriminator returns the 8 byte account discriminator, nil for native programs
+func (t *TypeDef) Discriminator() []byte {
+	return t.discriminator
+}
+
+// Discriminator returns the 8 byte event discriminator
+func (e *Event) Discriminator() []byte {
+	return e.discriminator
+}
+
+// Discriminator computes an Anchor discriminator: sha256("<namespace>:<name>")[:8]
+func Discriminator(namespace, name string) []byte {
+	sum := sha256.Sum256([]byte(namespace + ":" + name))
+	return sum[:DiscriminatorSize]
+}
+
+// SnakeCase converts an IDL instruction name to the snake case Rust name hashed by Anchor, e.g. swapV2 to swap_v2
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for k, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := k > 0 && (unicode.IsLower(runes[k-1]) || unicode.IsDigit(runes[k-1]))
+			acronymEnd := k > 0 && unicode.IsUpper(runes[k-1]) && k+1 < len(runes) && unicode.IsLower(runes[k+1])
+			if prevLower || acronymEnd {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// hasPrefix reports whether data starts with the discriminator
+func hasPrefix(data, discriminator []byte) bool {
+	return len(data) >= len(discriminator) && bytes.Equal(data[:len(discriminator)], discriminator)
+}
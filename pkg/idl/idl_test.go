@@ -0,0 +1,289 @@
+package idl_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"corvus_bot/pkg/idl"
+	"corvus_bot/pkg/raydium/pool/amm"
+	"corvus_bot/pkg/raydium/pool/clmm"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadIDL(t *testing.T, name string) *idl.IDL {
+	t.Helper()
+	program, err := idl.Load(filepath.Join("..", "..", "data", "IDL", name))
+	require.NoError(t, err)
+	return program
+}
+
+// loadFixtureData returns the base64 account data found at the JSON path of a data/testdata fixture
+func loadFixtureData(t *testing.T, file string, path ...string) []byte {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("..", "..", "data", "testdata", file))
+	require.NoError(t, err)
+
+	var node map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw, &node))
+	for _, key := range path {
+		require.NoError(t, json.Unmarshal(node[key], &node))
+	}
+	var encoded string
+	require.NoError(t, json.Unmarshal(node["data"], &encoded))
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	return data
+}
+
+func TestLoadBundledIDLs(t *testing.T) {
+	for file, layout := range map[string]idl.Layout{
+		"raydium_amm_idl.json":  idl.NativeLayout,
+		"raydium_clmm_idl.json": idl.AnchorLayout,
+		"pumpfun_idl.json":      idl.AnchorLayout,
+		"moonshot_idl.json":     idl.AnchorLayout,
+		"jupiter_idl.json":      idl.AnchorLayout,
+	} {
+		program := loadIDL(t, file)
+		assert.Equal(t, layout, program.Layout, file)
+		assert.NotEmpty(t, program.Instructions, file)
+	}
+}
+
+func TestDiscriminators(t *testing.T) {
+	tests := []struct {
+		file, instruction string
+		expected          []byte
+	}{
+		{"raydium_clmm_idl.json", "swapV2", []byte{43, 4, 237, 11, 26, 201, 30, 98}},
+		{"raydium_clmm_idl.json", "openPositionV2", []byte{77, 184, 74, 214, 112, 86, 241, 199}},
+		{"pumpfun_idl.json", "buy", []byte{102, 6, 61, 18, 1, 218, 235, 234}},
+		{"pumpfun_idl.json", "sell", []byte{51, 230, 133, 164, 1, 127, 131, 173}},
+		{"jupiter_idl.json", "route", []byte{229, 23, 203, 151, 122, 227, 173, 42}},
+		{"jupiter_idl.json", "sharedAccountsRoute", []byte{193, 32, 155, 51, 65, 214, 156, 129}},
+		{"raydium_amm_idl.json", "swapBaseIn", []byte{amm.SwapBaseInInstruction}},
+		{"raydium_amm_idl.json", "swapBaseOut", []byte{amm.SwapBaseOutInstruction}},
+	}
+	for _, tt := range tests {
+		instruction, err := loadIDL(t, tt.file).Instruction(tt.instruction)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, instruction.Discriminator(), tt.instruction)
+	}
+
+	account, err := loadIDL(t, "raydium_clmm_idl.json").Account("PoolState")
+	require.NoError(t, err)
+	assert.Equal(t, clmm.PoolStateDiscriminator[:], account.Discriminator())
+}
+
+func TestSnakeCase(t *testing.T) {
+	assert.Equal(t, "swap_v2", idl.SnakeCase("swapV2"))
+	assert.Equal(t, "initialize2", idl.SnakeCase("initialize2"))
+	assert.Equal(t, "shared_accounts_exact_out_route", idl.SnakeCase("sharedAccountsExactOutRoute"))
+	assert.Equal(t, "amm_config", idl.SnakeCase("AMMConfig"))
+}
+
+func TestDecodeAnchorAccount(t *testing.T) {
+	program := loadIDL(t, "raydium_clmm_idl.json")
+	data := loadFixtureData(t, "clmm_pool_sol_usdc.json", "pool")
+
+	name, err := program.IdentifyAccount(data)
+	require.NoError(t, err)
+	assert.Equal(t, "PoolState", name)
+
+	expected, err := clmm.DecodePoolState(data)
+	require.NoError(t, err)
+
+	decoded, err := program.DecodeAccount("PoolState", data)
+	require.NoError(t, err)
+	assert.Equal(t, expected.TokenMint0, decoded["tokenMint0"])
+	assert.Equal(t, expected.TickSpacing, decoded["tickSpacing"])
+	assert.Equal(t, expected.TickCurrent, decoded["tickCurrent"])
+	assert.Equal(t, expected.SqrtPriceX64.BigInt(), decoded["sqrtPriceX64"])
+	assert.Equal(t, []byte{expected.Bump[0]}, decoded["bump"])
+
+	// Nested defined types and fixed arrays
+	rewards := decoded["rewardInfos"].([]any)
+	require.Len(t, rewards, clmm.RewardCount)
+	reward := rewards[0].(map[string]any)
+	assert.Equal(t, expected.RewardInfos[0].TokenMint, reward["tokenMint"])
+	assert.Equal(t, expected.RewardInfos[0].EmissionsPerSecondX64.BigInt(), reward["emissionsPerSecondX64"])
+	bitmap := decoded["tickArrayBitmap"].([]any)
+	assert.Equal(t, expected.TickArrayBitmap[7], bitmap[7])
+
+	// The typed path fills the package's own layout
+	var typed clmm.PoolState
+	require.NoError(t, program.DecodeAccountInto("PoolState", data, &typed))
+	assert.Equal(t, *expected, typed)
+
+	_, err = program.DecodeAccount("AmmConfig", data)
+	assert.ErrorContains(t, err, "discriminator mismatch")
+}
+
+func TestDecodeNativeAccount(t *testing.T) {
+	program := loadIDL(t, "raydium_amm_idl.json")
+	data := loadFixtureData(t, "amm_info_sol_usdc.json")
+
+	expected, err := amm.DecodeAmmInfo(data)
+	require.NoError(t, err)
+
+	decoded, err := program.DecodeAccount("AmmInfo", data)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Status, decoded["status"])
+	assert.Equal(t, expected.CoinMint, decoded["coinMint"])
+	assert.Equal(t, expected.PcMint, decoded["pcMint"])
+	assert.Equal(t, expected.Fees.SwapFeeNumerator, decoded["fees"].(map[string]any)["swapFeeNumerator"])
+	assert.Equal(t, expected.OutPut.SwapCoinInAmount.BigInt(), decoded["outPut"].(map[string]any)["swapCoinInAmount"])
+
+	_, err = program.IdentifyAccount(data)
+	assert.Error(t, err, "native accounts have no discriminator")
+}
+
+func TestDecodeInstruction(t *testing.T) {
+	pool := clmm.RaydiumClmmPool{
+		ID:            "8sLbNZoA1cfnvMJLPfp98ZLAnFSYCFApfJKMbiXNLwxj",
+		ProgramID:     "CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK",
+		MintA:         "So11111111111111111111111111111111111111112",
+		MintB:         "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+		VaultA:        "5ZrXUACAbF3bsxRmwAVmsA8AadVZEs5HcVSqrEL9ukXR",
+		VaultB:        "3eA1N7VTJcv2k8NhEA6LjcQGksLRUBhHEnRYBL4U1waK",
+		ObservationID: "4NDj5HjVUN9f8ZMWCcUJ6TAqZTayDQgBV9ZcyYvFF1RU",
+		AmmConfig:     clmm.ApiClmmConfigurationItem{ID: "GDdR1ZhWQUwUSL69TsvZjWg7FgL1hsA2azXwvNbx3pE8"},
+	}
+	payer, tickArray := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	limit := new(big.Int).Lsh(big.NewInt(5), 64)
+
+	ix, err := clmm.NewSwapV2Instruction(pool, payer, solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), clmm.SwapV2Params{
+		Amount:               1_000,
+		OtherAmountThreshold: 990,
+		SqrtPriceLimitX64:    limit,
+		IsBaseInput:          true,
+		ZeroForOne:           true,
+		TickArrays:           []solana.PublicKey{tickArray},
+	})
+	require.NoError(t, err)
+	data, err := ix.Data()
+	require.NoError(t, err)
+
+	var accounts []solana.PublicKey
+	for _, account := range ix.Accounts() {
+		accounts = append(accounts, account.PublicKey)
+	}
+
+	decoded, err := loadIDL(t, "raydium_clmm_idl.json").DecodeInstruction(data, accounts)
+	require.NoError(t, err)
+	assert.Equal(t, "swapV2", decoded.Name)
+	assert.Equal(t, uint64(1_000), decoded.Args["amount"])
+	assert.Equal(t, uint64(990), decoded.Args["otherAmountThreshold"])
+	assert.Equal(t, limit, decoded.Args["sqrtPriceLimitX64"])
+	assert.Equal(t, true, decoded.Args["isBaseInput"])
+	assert.Equal(t, payer, decoded.Accounts["payer"])
+	assert.Equal(t, pool.VaultA, decoded.Accounts["inputVault"].String())
+	assert.Equal(t, []solana.PublicKey{tickArray}, decoded.Remaining)
+
+	// Native one byte tags
+	data = make([]byte, 17)
+	data[0] = amm.SwapBaseOutInstruction
+	binary.LittleEndian.PutUint64(data[1:], 500)
+	binary.LittleEndian.PutUint64(data[9:], 42)
+	decoded, err = loadIDL(t, "raydium_amm_idl.json").DecodeInstruction(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "swapBaseOut", decoded.Name)
+	assert.Equal(t, uint64(500), decoded.Args["maxAmountIn"])
+	assert.Equal(t, uint64(42), decoded.Args["amountOut"])
+
+	_, err = loadIDL(t, "raydium_amm_idl.json").DecodeInstruction([]byte{200}, nil)
+	assert.Error(t, err)
+}
+
+func TestDecodeInstructionWithEnumsAndVecs(t *testing.T) {
+	program := loadIDL(t, "jupiter_idl.json")
+	route, err := program.Instruction("route")
+	require.NoError(t, err)
+
+	// Two route plan steps: a unit variant and a variant carrying a nested enum
+	buf := new(bytes.Buffer)
+	buf.Write(route.Discriminator())
+	enc := bin.NewBorshEncoder(buf)
+	require.NoError(t, enc.WriteUint32(2, binary.LittleEndian))
+	require.NoError(t, enc.WriteBytes([]byte{7, 100, 0, 1}, false))     // Raydium, 100%, input 0, output 1
+	require.NoError(t, enc.WriteBytes([]byte{12, 1, 50, 1, 2}, false))  // Serum { side: Ask }, 50%, input 1, output 2
+	require.NoError(t, enc.WriteUint64(1_000_000, binary.LittleEndian)) // inAmount
+	require.NoError(t, enc.WriteUint64(990_000, binary.LittleEndian))   // quotedOutAmount
+	require.NoError(t, enc.WriteUint16(50, binary.LittleEndian))        // slippageBps
+	require.NoError(t, enc.WriteUint8(0))                               // platformFeeBps
+
+	decoded, err := program.DecodeInstruction(buf.Bytes(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "route", decoded.Name)
+	assert.Equal(t, uint64(1_000_000), decoded.Args["inAmount"])
+	assert.Equal(t, uint16(50), decoded.Args["slippageBps"])
+
+	plan := decoded.Args["routePlan"].([]any)
+	require.Len(t, plan, 2)
+	first := plan[0].(map[string]any)
+	assert.Equal(t, idl.EnumValue{Variant: "Raydium"}, first["swap"])
+	assert.Equal(t, uint8(100), first["percent"])
+	second := plan[1].(map[string]any)
+	assert.Equal(t, idl.EnumValue{Variant: "Serum", Fields: map[string]any{"side": idl.EnumValue{Variant: "Ask"}}}, second["swap"])
+	assert.Equal(t, uint8(2), second["outputIndex"])
+
+	_, err = program.DecodeInstruction(buf.Bytes()[:20], nil)
+	assert.Error(t, err, "truncated data")
+}
+
+func TestDecodeEvent(t *testing.T) {
+	program := loadIDL(t, "pumpfun_idl.json")
+	event, err := program.Event("TradeEvent")
+	require.NoError(t, err)
+
+	mint, user := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	buf := new(bytes.Buffer)
+	buf.Write(event.Discriminator())
+	enc := bin.NewBorshEncoder(buf)
+	require.NoError(t, enc.WriteBytes(mint[:], false))
+	require.NoError(t, enc.WriteUint64(1_500_000_000, binary.LittleEndian))
+	require.NoError(t, enc.WriteUint64(42_000_000, binary.LittleEndian))
+	require.NoError(t, enc.WriteBool(true))
+	require.NoError(t, enc.WriteBytes(user[:], false))
+	require.NoError(t, enc.WriteInt64(-1, binary.LittleEndian))
+	require.NoError(t, enc.WriteUint64(30_000_000_000, binary.LittleEndian))
+	require.NoError(t, enc.WriteUint64(1_000_000_000_000_000, binary.LittleEndian))
+
+	decoded, err := program.DecodeEvent(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "TradeEvent", decoded.Name)
+	assert.Equal(t, mint, decoded.Fields["mint"])
+	assert.Equal(t, uint64(1_500_000_000), decoded.Fields["solAmount"])
+	assert.Equal(t, true, decoded.Fields["isBuy"])
+	assert.Equal(t, int64(-1), decoded.Fields["timestamp"])
+
+	// The same event logged through emit_cpi! is prefixed by the event instruction tag
+	decoded, err = program.DecodeEvent(append(idl.EventInstructionTag[:], buf.Bytes()...))
+	require.NoError(t, err)
+	assert.Equal(t, user, decoded.Fields["user"])
+
+	var typed struct {
+		Mint                 solana.PublicKey
+		SolAmount            uint64
+		TokenAmount          uint64
+		IsBuy                bool
+		User                 solana.PublicKey
+		Timestamp            int64
+		VirtualSolReserves   uint64
+		VirtualTokenReserves uint64
+	}
+	require.NoError(t, program.DecodeEventInto("TradeEvent", buf.Bytes(), &typed))
+	assert.Equal(t, uint64(42_000_000), typed.TokenAmount)
+	assert.Equal(t, uint64(1_000_000_000_000_000), typed.VirtualTokenReserves)
+
+	assert.Error(t, program.DecodeEventInto("CreateEvent", buf.Bytes(), &typed))
+}
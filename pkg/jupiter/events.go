@@ -0,0 +1,49 @@
+package jupiter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"corvus_bot/pkg/idl"
+	jupidl "corvus_bot/pkg/idl/bindings/jupiter"
+)
+
+type (
+	// SwapEvent reports one leg of a route: the AMM program it went through and the amounts it swapped
+	SwapEvent = jupidl.SwapEvent
+
+	// FeeEvent reports the platform fee a route took from its output
+	FeeEvent = jupidl.FeeEvent
+)
+
+// DecodeEvent decodes a SwapEvent or FeeEvent from the data of an emit_cpi instruction or the decoded
+// payload of a "Program data:" line. The returned name is empty for any other data.
+func DecodeEvent(data []byte) (string, interface{}, error) {
+	payload := bytes.TrimPrefix(data, idl.EventInstructionTag[:])
+
+	switch {
+	case bytes.HasPrefix(payload, jupidl.SwapEventDiscriminator):
+		event, err := jupidl.DecodeSwapEvent(payload)
+		return "SwapEvent", event, err
+	case bytes.HasPrefix(payload, jupidl.FeeEventDiscriminator):
+		event, err := jupidl.DecodeFeeEvent(payload)
+		return "FeeEvent", event, err
+	}
+	return "", nil, nil
+}
+
+// decodeLogEvent decodes the base64 payload of a "Program data:" line
+func decodeLogEvent(encoded string) (string, interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode program data base64: %w", err)
+	}
+	return DecodeEvent(data)
+}
+
+// isEventInstruction reports whether data is an emit_cpi self invocation rather than an instruction
+func isEventInstruction(data []byte) bool {
+	return bytes.HasPrefix(data, idl.EventInstructionTag[:])
+}
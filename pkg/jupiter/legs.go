@@ -0,0 +1,109 @@
+package jupiter
+
+import (
+	"bytes"
+
+	jupidl "corvus_bot/pkg/idl/bindings/jupiter"
+	"corvus_bot/pkg/idl/bindings/whirlpool"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// swapLabels names the variants of the Swap enum of a route plan step, in enum order
+var swapLabels = []string{
+	"Saber",
+	"SaberAddDecimalsDeposit",
+	"SaberAddDecimalsWithdraw",
+	"TokenSwap",
+	"Sencha",
+	"Step",
+	"Cropper",
+	"Raydium",
+	"Crema",
+	"Lifinity",
+	"Mercurial",
+	"Cykura",
+	"Serum",
+	"MarinadeDeposit",
+	"MarinadeUnstake",
+	"Aldrin",
+	"AldrinV2",
+	"Whirlpool",
+	"Invariant",
+	"Meteora",
+	"GooseFX",
+	"DeltaFi",
+	"Balansol",
+	"MarcoPolo",
+	"Dradex",
+	"LifinityV2",
+	"RaydiumClmm",
+	"Openbook",
+	"Phoenix",
+	"Symmetry",
+	"TokenSwapV2",
+	"HeliumTreasuryManagementRedeemV0",
+	"StakeDexStakeWrappedSol",
+	"StakeDexSwapViaStake",
+	"GooseFXV2",
+	"Perps",
+	"PerpsAddLiquidity",
+	"PerpsRemoveLiquidity",
+	"MeteoraDlmm",
+}
+
+// SwapLabel returns the name of the AMM a route plan step swaps through, e.g. "Raydium"
+func SwapLabel(swap jupidl.Swap) string {
+	if int(swap.Enum) < len(swapLabels) {
+		return swapLabels[swap.Enum]
+	}
+	return "Unknown"
+}
+
+// poolAccountIndex returns the position of the pool among the accounts of the AMM instruction a leg invoked,
+// for the AMMs whose pools we track
+func poolAccountIndex(swap bin.BorshEnum, data []byte) (int, bool) {
+	switch swap {
+	case jupidl.SwapRaydium:
+		// swapBaseIn and swapBaseOut of AMM v4 pass the token program, then the amm
+		return 1, true
+	case jupidl.SwapRaydiumClmm:
+		// swap and swapV2 pass the payer and the amm config before the pool state
+		return 2, true
+	case jupidl.SwapWhirlpool:
+		if bytes.HasPrefix(data, whirlpool.SwapV2InstructionDiscriminator) {
+			return 4, true
+		}
+		// swap passes the token program and the token authority before the whirlpool
+		return 2, true
+	case jupidl.SwapMeteoraDlmm:
+		return 0, true
+	}
+	return 0, false
+}
+
+// matchPools sets the pool of each leg from the instructions the route invoked. Legs execute in plan order,
+// so the AMM instruction of a leg is the first one of its program after the instruction of the previous leg.
+func matchPools(legs []Leg, plan []jupidl.RoutePlanStep, invoked []invocation) {
+	cursor := 0
+	for k := range legs {
+		for j := cursor; j < len(invoked); j++ {
+			if !invoked[j].program.Equals(legs[k].Amm) {
+				continue
+			}
+			cursor = j + 1
+			if index, ok := poolAccountIndex(plan[k].Swap.Enum, invoked[j].data); ok && index < len(invoked[j].accounts) {
+				legs[k].Pool = invoked[j].accounts[index]
+			}
+			break
+		}
+	}
+}
+
+// invocation is an instruction a route invoked, with its account indexes resolved
+type invocation struct {
+	program  solana.PublicKey
+	accounts []solana.PublicKey
+	data     []byte
+}
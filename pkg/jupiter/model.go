@@ -0,0 +1,41 @@
+package jupiter
+
+import (
+	"corvus_bot/pkg/database/models"
+)
+
+// PoolData maps the route as a whole to the Jupiter data of the database model, with the route instruction
+// as the route type
+func (r *Route) PoolData() *models.JupiterPoolData {
+	return &models.JupiterPoolData{
+		RouteType:      r.Instruction,
+		InputMint:      r.InputMint.String(),
+		OutputMint:     r.OutputMint.String(),
+		InAmount:       r.InAmount,
+		OutAmount:      r.OutAmount,
+		InputDecimals:  r.InputDecimals,
+		OutputDecimals: r.OutputDecimals,
+	}
+}
+
+// VolumeByPool attributes the legs of the route to the pools they swapped against, keyed by pool address.
+// Legs through AMMs whose pools we do not track are left out.
+func (r *Route) VolumeByPool() map[string][]*models.JupiterPoolData {
+	volume := make(map[string][]*models.JupiterPoolData)
+	for _, leg := range r.Legs {
+		if leg.Pool.IsZero() {
+			continue
+		}
+		pool := leg.Pool.String()
+		volume[pool] = append(volume[pool], &models.JupiterPoolData{
+			RouteType:      r.Instruction,
+			InputMint:      leg.InputMint.String(),
+			OutputMint:     leg.OutputMint.String(),
+			InAmount:       leg.InAmount,
+			OutAmount:      leg.OutAmount,
+			InputDecimals:  leg.InputDecimals,
+			OutputDecimals: leg.OutputDecimals,
+		})
+	}
+	return volume
+}
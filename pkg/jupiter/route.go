@@ -0,0 +1,397 @@
+// Package jupiter decodes Jupiter v6 aggregator transactions into routes: the route instruction, each leg
+// with the AMM and pool it swapped through, and the platform fee, for attributing volume to pools.
+package jupiter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	jupidl "corvus_bot/pkg/idl/bindings/jupiter"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// DefaultProgramID is the Jupiter aggregator v6 program on mainnet
+const DefaultProgramID = "JUP6LkbZbjS1jKAapdfBTk7Cf8AFuJ6a4d3FSbhKaJ4"
+
+var (
+	// ErrNoRoute is returned for transactions without a route instruction of the program
+	ErrNoRoute = errors.New("no route instruction found in transaction")
+
+	// ErrIncompleteRoute is returned when the swap events of a route do not cover its plan, as happens
+	// when the logs the events were read from were truncated
+	ErrIncompleteRoute = errors.New("route swap events do not match its plan")
+)
+
+// RoutePlanStep is one step of the plan of a route instruction
+type RoutePlanStep = jupidl.RoutePlanStep
+
+// Leg is one executed step of a route
+type Leg struct {
+	Label          string           // AMM of the plan step, e.g. "Raydium" or "Whirlpool"
+	Amm            solana.PublicKey // Program of the AMM
+	Pool           solana.PublicKey // Pool swapped against, zero when its AMM is not one we track
+	InputMint      solana.PublicKey
+	OutputMint     solana.PublicKey
+	InAmount       uint64
+	OutAmount      uint64
+	InputDecimals  uint8
+	OutputDecimals uint8
+	Percent        uint8 // Share of the input amount of the step the leg swapped
+	InputIndex     uint8 // Token index of the input in the plan, 0 being the route input
+	OutputIndex    uint8
+}
+
+// Route is a swap routed through the aggregator, as executed
+type Route struct {
+	Signature      string
+	Slot           uint64
+	BlockTime      int64
+	Instruction    string // Name of the route instruction, e.g. "sharedAccountsRoute"
+	User           solana.PublicKey
+	InputMint      solana.PublicKey
+	OutputMint     solana.PublicKey
+	InAmount       uint64 // Amount the legs took from the route input
+	OutAmount      uint64 // Amount the legs delivered to the route output, before the platform fee
+	InputDecimals  uint8
+	OutputDecimals uint8
+	QuotedAmount   uint64 // Quoted output amount, or quoted input amount for exact out routes
+	SlippageBps    uint16
+	PlatformFeeBps uint8
+	Legs           []Leg
+	Fee            *FeeEvent // nil when the route took no platform fee
+}
+
+// FetchRoutes fetches a confirmed transaction, retrying while the node has not seen it yet, and parses the
+// routes it executed
+func FetchRoutes(ctx context.Context, client utils.RPCClientInterface, programID solana.PublicKey, signature solana.Signature) ([]*Route, error) {
+	result, err := utils.FetchTransaction(ctx, client, signature, utils.DefaultFetchAttempts, utils.DefaultFetchDelay)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoutes(result, programID)
+}
+
+// ParseRoutes rebuilds the routes of a fetched transaction, called directly or through CPI. Legs are read
+// from the SwapEvent instructions each route emitted, or from the "Program data:" logs of transactions
+// that predate emit_cpi.
+func ParseRoutes(result *rpc.GetTransactionResult, programID solana.PublicKey) ([]*Route, error) {
+	tx, err := utils.DecodeTransaction(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// No client to read lookup tables with: the node must have resolved them in the metadata
+	keys, err := utils.TransactionKeys(context.Background(), nil, tx, result.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account keys: %w", err)
+	}
+
+	builders, err := collectRoutes(tx, result.Meta, keys, programID)
+	if err != nil {
+		return nil, err
+	}
+	if len(builders) == 0 {
+		return nil, ErrNoRoute
+	}
+	if err := assignLogEvents(builders, result.Meta.LogMessages, programID); err != nil {
+		return nil, err
+	}
+
+	var signature string
+	if len(tx.Signatures) > 0 {
+		signature = tx.Signatures[0].String()
+	}
+	decimals := tokenDecimals(result.Meta)
+	routes := make([]*Route, len(builders))
+	for k, builder := range builders {
+		route, err := builder.build(decimals)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", signature, err)
+		}
+		route.Signature = signature
+		route.Slot = result.Slot
+		if result.BlockTime != nil {
+			route.BlockTime = int64(*result.BlockTime)
+		}
+		routes[k] = route
+	}
+	return routes, nil
+}
+
+// routeBuilder gathers what a route instruction planned, the events it emitted and the instructions it invoked
+type routeBuilder struct {
+	route   *Route
+	plan    []RoutePlanStep
+	swaps   []*SwapEvent
+	fee     *FeeEvent
+	invoked []invocation
+}
+
+// collectRoutes walks the instructions in execution order, each top level instruction followed by the ones
+// it invoked, and groups the events and invocations that follow a route instruction under it
+func collectRoutes(tx *solana.Transaction, meta *rpc.TransactionMeta, keys solana.PublicKeySlice, programID solana.PublicKey) ([]*routeBuilder, error) {
+	var builders []*routeBuilder
+	var current *routeBuilder
+	for _, executed := range utils.ExecutedInstructions(tx, meta) {
+		// Instructions of other programs may call a route, but whatever follows a route at the top level is
+		// not part of it
+		if !executed.Inner {
+			current = nil
+		}
+
+		invoked, err := resolve(executed.Instruction, keys)
+		if err != nil {
+			return nil, err
+		}
+		if !invoked.program.Equals(programID) {
+			if current != nil {
+				current.invoked = append(current.invoked, invoked)
+			}
+			continue
+		}
+
+		if isEventInstruction(invoked.data) {
+			if current == nil {
+				continue
+			}
+			name, event, err := DecodeEvent(invoked.data)
+			if err != nil {
+				return nil, err
+			}
+			current.addEvent(name, event)
+			continue
+		}
+		route, plan, err := decodeRouteInstruction(invoked.data, invoked.accounts)
+		if err != nil {
+			return nil, err
+		}
+		if route != nil {
+			current = &routeBuilder{route: route, plan: plan}
+			builders = append(builders, current)
+		}
+	}
+	return builders, nil
+}
+
+// assignLogEvents hands the events logged with "Program data:" to the routes when no route emitted its events
+// through CPI. Each route emits one SwapEvent per plan step, followed by its FeeEvent.
+func assignLogEvents(builders []*routeBuilder, logs []string, programID solana.PublicKey) error {
+	for _, builder := range builders {
+		if len(builder.swaps) > 0 || builder.fee != nil {
+			return nil
+		}
+	}
+
+	next := 0
+	var last *routeBuilder
+	for _, data := range utils.ProgramData(logs, programID.String()) {
+		name, event, err := decodeLogEvent(data)
+		if err != nil {
+			return err
+		}
+		switch name {
+		case "SwapEvent":
+			for next < len(builders) && len(builders[next].swaps) == len(builders[next].plan) {
+				next++
+			}
+			if next == len(builders) {
+				continue
+			}
+			last = builders[next]
+			last.addEvent(name, event)
+		case "FeeEvent":
+			if last != nil {
+				last.addEvent(name, event)
+			}
+		}
+	}
+	return nil
+}
+
+func (b *routeBuilder) addEvent(name string, event interface{}) {
+	switch name {
+	case "SwapEvent":
+		b.swaps = append(b.swaps, event.(*SwapEvent))
+	case "FeeEvent":
+		b.fee = event.(*FeeEvent)
+	}
+}
+
+// build pairs the plan steps with the swap events and totals the route input and output
+func (b *routeBuilder) build(decimals map[solana.PublicKey]uint8) (*Route, error) {
+	route := b.route
+	if len(b.swaps) != len(b.plan) {
+		return nil, fmt.Errorf("%w: %s has %d steps and %d swap events", ErrIncompleteRoute, route.Instruction, len(b.plan), len(b.swaps))
+	}
+
+	route.Legs = make([]Leg, len(b.plan))
+	var outputIndex uint8
+	for k, step := range b.plan {
+		event := b.swaps[k]
+		route.Legs[k] = Leg{
+			Label:          SwapLabel(step.Swap),
+			Amm:            event.Amm,
+			InputMint:      event.InputMint,
+			OutputMint:     event.OutputMint,
+			InAmount:       event.InputAmount,
+			OutAmount:      event.OutputAmount,
+			InputDecimals:  decimals[event.InputMint],
+			OutputDecimals: decimals[event.OutputMint],
+			Percent:        step.Percent,
+			InputIndex:     step.InputIndex,
+			OutputIndex:    step.OutputIndex,
+		}
+		if step.OutputIndex > outputIndex {
+			outputIndex = step.OutputIndex
+		}
+	}
+	matchPools(route.Legs, b.plan, b.invoked)
+
+	// Token index 0 is the route input and the highest index its output, which split routes reach
+	// through several legs
+	for _, leg := range route.Legs {
+		if leg.InputIndex == 0 {
+			route.InAmount += leg.InAmount
+		}
+		if leg.OutputIndex == outputIndex {
+			route.OutAmount += leg.OutAmount
+		}
+	}
+	if route.InputMint.IsZero() && len(route.Legs) > 0 {
+		route.InputMint = route.Legs[0].InputMint
+	}
+	route.InputDecimals = decimals[route.InputMint]
+	route.OutputDecimals = decimals[route.OutputMint]
+	route.Fee = b.fee
+	return route, nil
+}
+
+// decodeRouteInstruction decodes the route instructions of the program. It returns a nil route for the
+// program's other instructions.
+func decodeRouteInstruction(data []byte, keys []solana.PublicKey) (*Route, []RoutePlanStep, error) {
+	switch {
+	case bytes.HasPrefix(data, jupidl.RouteInstructionDiscriminator):
+		args, err := jupidl.DecodeRouteArgs(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts, err := jupidl.DecodeRouteAccounts(keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Route{
+			Instruction:    "route",
+			User:           accounts.UserTransferAuthority,
+			OutputMint:     accounts.DestinationMint,
+			QuotedAmount:   args.QuotedOutAmount,
+			SlippageBps:    args.SlippageBps,
+			PlatformFeeBps: args.PlatformFeeBps,
+		}, args.RoutePlan, nil
+
+	case bytes.HasPrefix(data, jupidl.RouteWithTokenLedgerInstructionDiscriminator):
+		args, err := jupidl.DecodeRouteWithTokenLedgerArgs(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts, err := jupidl.DecodeRouteWithTokenLedgerAccounts(keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Route{
+			Instruction:    "routeWithTokenLedger",
+			User:           accounts.UserTransferAuthority,
+			OutputMint:     accounts.DestinationMint,
+			QuotedAmount:   args.QuotedOutAmount,
+			SlippageBps:    args.SlippageBps,
+			PlatformFeeBps: args.PlatformFeeBps,
+		}, args.RoutePlan, nil
+
+	case bytes.HasPrefix(data, jupidl.SharedAccountsRouteInstructionDiscriminator):
+		args, err := jupidl.DecodeSharedAccountsRouteArgs(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts, err := jupidl.DecodeSharedAccountsRouteAccounts(keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Route{
+			Instruction:    "sharedAccountsRoute",
+			User:           accounts.UserTransferAuthority,
+			InputMint:      accounts.SourceMint,
+			OutputMint:     accounts.DestinationMint,
+			QuotedAmount:   args.QuotedOutAmount,
+			SlippageBps:    args.SlippageBps,
+			PlatformFeeBps: args.PlatformFeeBps,
+		}, args.RoutePlan, nil
+
+	case bytes.HasPrefix(data, jupidl.SharedAccountsRouteWithTokenLedgerInstructionDiscriminator):
+		args, err := jupidl.DecodeSharedAccountsRouteWithTokenLedgerArgs(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts, err := jupidl.DecodeSharedAccountsRouteWithTokenLedgerAccounts(keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Route{
+			Instruction:    "sharedAccountsRouteWithTokenLedger",
+			User:           accounts.UserTransferAuthority,
+			InputMint:      accounts.SourceMint,
+			OutputMint:     accounts.DestinationMint,
+			QuotedAmount:   args.QuotedOutAmount,
+			SlippageBps:    args.SlippageBps,
+			PlatformFeeBps: args.PlatformFeeBps,
+		}, args.RoutePlan, nil
+
+	case bytes.HasPrefix(data, jupidl.SharedAccountsExactOutRouteInstructionDiscriminator):
+		args, err := jupidl.DecodeSharedAccountsExactOutRouteArgs(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts, err := jupidl.DecodeSharedAccountsExactOutRouteAccounts(keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &Route{
+			Instruction:    "sharedAccountsExactOutRoute",
+			User:           accounts.UserTransferAuthority,
+			InputMint:      accounts.SourceMint,
+			OutputMint:     accounts.DestinationMint,
+			QuotedAmount:   args.QuotedInAmount,
+			SlippageBps:    args.SlippageBps,
+			PlatformFeeBps: args.PlatformFeeBps,
+		}, args.RoutePlan, nil
+	}
+	return nil, nil, nil
+}
+
+// resolve maps the program and account indexes of an instruction to keys
+func resolve(instruction *solana.CompiledInstruction, keys solana.PublicKeySlice) (invocation, error) {
+	if int(instruction.ProgramIDIndex) >= len(keys) {
+		return invocation{}, fmt.Errorf("program index %d out of range of %d keys", instruction.ProgramIDIndex, len(keys))
+	}
+	accounts, err := utils.InstructionAccounts(instruction, keys)
+	if err != nil {
+		return invocation{}, err
+	}
+	return invocation{program: keys[instruction.ProgramIDIndex], accounts: accounts, data: instruction.Data}, nil
+}
+
+// tokenDecimals reads the decimals of the mints whose balances the transaction changed or held
+func tokenDecimals(meta *rpc.TransactionMeta) map[solana.PublicKey]uint8 {
+	decimals := make(map[solana.PublicKey]uint8)
+	for _, balances := range [][]rpc.TokenBalance{meta.PreTokenBalances, meta.PostTokenBalances} {
+		for _, balance := range balances {
+			if balance.UiTokenAmount != nil {
+				decimals[balance.Mint] = balance.UiTokenAmount.Decimals
+			}
+		}
+	}
+	return decimals
+}
@@ -0,0 +1,304 @@
+package jupiter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"corvus_bot/pkg/idl"
+	jupidl "corvus_bot/pkg/idl/bindings/jupiter"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testProgramID   = solana.MustPublicKeyFromBase58(DefaultProgramID)
+	raydiumProgram  = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	orcaProgram     = solana.MustPublicKeyFromBase58("whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc")
+	wsolMint        = solana.SolMint
+	usdcMint        = solana.MustPublicKeyFromBase58("EPjFWdzAUVC6QNJM8M7oRvZZ5v6EzzUZ88A2sDVvYY7")
+	memeMint        = solana.MustPublicKeyFromBase58("4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R")
+	raydiumPool     = solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2")
+	whirlpoolPool   = solana.MustPublicKeyFromBase58("HJPjoWUrhoZzkNfRpHuieeFk9WcZWjwy6PBjZ81ngndJ")
+	eventAuthority  = solana.MustPublicKeyFromBase58("D8cy77BBepLMngZx6ZukaTff5hCt1HrWyKk3Hnd9oitf")
+	sharedAuthority = solana.NewWallet().PublicKey()
+)
+
+// testLeg is a leg of a synthetic route: the AMM instruction it invokes and the event it emits
+type testLeg struct {
+	step    RoutePlanStep
+	program solana.PublicKey
+	data    []byte
+	pool    []solana.PublicKey // Accounts of the AMM instruction
+	event   SwapEvent
+}
+
+// twoHopRoute sells 1 SOL for the meme token on Raydium, then the meme token for USDC on a whirlpool
+func twoHopRoute() []testLeg {
+	return []testLeg{
+		{
+			step:    RoutePlanStep{Swap: jupidl.Swap{Enum: jupidl.SwapRaydium}, Percent: 100, InputIndex: 0, OutputIndex: 1},
+			program: raydiumProgram,
+			data:    []byte{9, 1, 2},
+			pool:    []solana.PublicKey{solana.TokenProgramID, raydiumPool, solana.NewWallet().PublicKey()},
+			event:   SwapEvent{Amm: raydiumProgram, InputMint: wsolMint, InputAmount: 1_000_000_000, OutputMint: memeMint, OutputAmount: 52_000_000},
+		},
+		{
+			step:    RoutePlanStep{Swap: jupidl.Swap{Enum: jupidl.SwapWhirlpool, Whirlpool: jupidl.SwapWhirlpoolVariant{AToB: true}}, Percent: 100, InputIndex: 1, OutputIndex: 2},
+			program: orcaProgram,
+			data:    []byte{248, 198, 158, 145, 225, 117, 135, 200},
+			pool:    []solana.PublicKey{solana.TokenProgramID, sharedAuthority, whirlpoolPool},
+			event:   SwapEvent{Amm: orcaProgram, InputMint: memeMint, InputAmount: 52_000_000, OutputMint: usdcMint, OutputAmount: 241_500_000},
+		},
+	}
+}
+
+func encodeEvent(t *testing.T, discriminator []byte, event interface{}) []byte {
+	t.Helper()
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(event))
+	return buf.Bytes()
+}
+
+// buildRouteTransaction returns the getTransaction result of a sharedAccountsRoute executing legs. Events are
+// emitted through self CPI unless logged is set, in which case they are written to the logs.
+func buildRouteTransaction(t *testing.T, user solana.PublicKey, legs []testLeg, fee *FeeEvent, logged bool) *rpc.GetTransactionResult {
+	t.Helper()
+
+	args := jupidl.SharedAccountsRouteArgs{InAmount: 1_000_000_000, QuotedOutAmount: 240_000_000, SlippageBps: 50, PlatformFeeBps: 20}
+	var remaining []*solana.AccountMeta
+	for _, leg := range legs {
+		args.RoutePlan = append(args.RoutePlan, leg.step)
+		remaining = append(remaining, solana.NewAccountMeta(leg.program, false, false))
+		for _, account := range leg.pool {
+			remaining = append(remaining, solana.NewAccountMeta(account, true, false))
+		}
+	}
+	routeIx, err := jupidl.NewSharedAccountsRouteInstruction(testProgramID, args, jupidl.SharedAccountsRouteAccounts{
+		TokenProgram:                   solana.TokenProgramID,
+		ProgramAuthority:               sharedAuthority,
+		UserTransferAuthority:          user,
+		SourceTokenAccount:             solana.NewWallet().PublicKey(),
+		ProgramSourceTokenAccount:      solana.NewWallet().PublicKey(),
+		ProgramDestinationTokenAccount: solana.NewWallet().PublicKey(),
+		DestinationTokenAccount:        solana.NewWallet().PublicKey(),
+		SourceMint:                     wsolMint,
+		DestinationMint:                usdcMint,
+		EventAuthority:                 eventAuthority,
+		Program:                        testProgramID,
+	}, remaining...)
+	require.NoError(t, err)
+
+	tx, err := solana.NewTransaction([]solana.Instruction{routeIx}, solana.Hash{}, solana.TransactionPayer(user))
+	require.NoError(t, err)
+	tx.Signatures = []solana.Signature{{7}}
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	indexOf := func(key solana.PublicKey) uint16 {
+		for k, candidate := range tx.Message.AccountKeys {
+			if candidate.Equals(key) {
+				return uint16(k)
+			}
+		}
+		t.Fatalf("key %s is not in the transaction", key)
+		return 0
+	}
+	eventInstruction := func(data []byte) solana.CompiledInstruction {
+		return solana.CompiledInstruction{
+			ProgramIDIndex: indexOf(testProgramID),
+			Accounts:       []uint16{indexOf(eventAuthority)},
+			Data:           append(append([]byte(nil), idl.EventInstructionTag[:]...), data...),
+		}
+	}
+
+	var inner []solana.CompiledInstruction
+	logs := []string{"Program " + DefaultProgramID + " invoke [1]", "Program log: Instruction: SharedAccountsRoute"}
+	for _, leg := range legs {
+		swap := solana.CompiledInstruction{ProgramIDIndex: indexOf(leg.program), Data: leg.data}
+		for _, account := range leg.pool {
+			swap.Accounts = append(swap.Accounts, indexOf(account))
+		}
+		inner = append(inner, swap)
+		logs = append(logs, "Program "+leg.program.String()+" invoke [2]", "Program "+leg.program.String()+" success")
+
+		data := encodeEvent(t, jupidl.SwapEventDiscriminator, leg.event)
+		if logged {
+			logs = append(logs, utils.ProgramDataPrefix+base64.StdEncoding.EncodeToString(data))
+		} else {
+			inner = append(inner, eventInstruction(data))
+		}
+	}
+	if fee != nil {
+		data := encodeEvent(t, jupidl.FeeEventDiscriminator, fee)
+		if logged {
+			logs = append(logs, utils.ProgramDataPrefix+base64.StdEncoding.EncodeToString(data))
+		} else {
+			inner = append(inner, eventInstruction(data))
+		}
+	}
+	logs = append(logs, "Program "+DefaultProgramID+" success")
+
+	balance := func(mint solana.PublicKey, decimals uint8) rpc.TokenBalance {
+		return rpc.TokenBalance{Mint: mint, UiTokenAmount: &rpc.UiTokenAmount{Decimals: decimals}}
+	}
+	meta := &rpc.TransactionMeta{
+		LogMessages:       logs,
+		InnerInstructions: []rpc.InnerInstruction{{Index: 0, Instructions: inner}},
+		PreTokenBalances:  []rpc.TokenBalance{balance(wsolMint, 9), balance(memeMint, 6)},
+		PostTokenBalances: []rpc.TokenBalance{balance(usdcMint, 6)},
+	}
+
+	var envelope rpc.TransactionResultEnvelope
+	encoded := fmt.Sprintf("[%q, \"base64\"]", base64.StdEncoding.EncodeToString(raw))
+	require.NoError(t, json.Unmarshal([]byte(encoded), &envelope))
+	blockTime := solana.UnixTimeSeconds(1732752205)
+	return &rpc.GetTransactionResult{Slot: 304027300, BlockTime: &blockTime, Transaction: &envelope, Meta: meta}
+}
+
+func TestParseRoutes(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+	fee := &FeeEvent{Account: solana.NewWallet().PublicKey(), Mint: usdcMint, Amount: 483_000}
+
+	for _, logged := range []bool{false, true} {
+		routes, err := ParseRoutes(buildRouteTransaction(t, user, twoHopRoute(), fee, logged), testProgramID)
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		route := routes[0]
+		assert.Equal(t, solana.Signature{7}.String(), route.Signature)
+		assert.Equal(t, uint64(304027300), route.Slot)
+		assert.Equal(t, int64(1732752205), route.BlockTime)
+		assert.Equal(t, "sharedAccountsRoute", route.Instruction)
+		assert.Equal(t, user, route.User)
+		assert.Equal(t, wsolMint, route.InputMint)
+		assert.Equal(t, usdcMint, route.OutputMint)
+		assert.Equal(t, uint64(1_000_000_000), route.InAmount)
+		assert.Equal(t, uint64(241_500_000), route.OutAmount)
+		assert.Equal(t, uint8(9), route.InputDecimals)
+		assert.Equal(t, uint8(6), route.OutputDecimals)
+		assert.Equal(t, uint64(240_000_000), route.QuotedAmount)
+		assert.Equal(t, uint16(50), route.SlippageBps)
+		assert.Equal(t, uint8(20), route.PlatformFeeBps)
+		assert.Equal(t, fee, route.Fee)
+
+		require.Len(t, route.Legs, 2)
+		raydium, orca := route.Legs[0], route.Legs[1]
+		assert.Equal(t, "Raydium", raydium.Label)
+		assert.Equal(t, raydiumProgram, raydium.Amm)
+		assert.Equal(t, raydiumPool, raydium.Pool)
+		assert.Equal(t, wsolMint, raydium.InputMint)
+		assert.Equal(t, memeMint, raydium.OutputMint)
+		assert.Equal(t, uint64(52_000_000), raydium.OutAmount)
+		assert.Equal(t, uint8(6), raydium.OutputDecimals)
+		assert.Equal(t, "Whirlpool", orca.Label)
+		assert.Equal(t, whirlpoolPool, orca.Pool)
+		assert.Equal(t, uint64(241_500_000), orca.OutAmount)
+		assert.Equal(t, uint8(1), orca.InputIndex)
+	}
+}
+
+func TestParseSplitRoute(t *testing.T) {
+	// Two Raydium pools each take half of the input and deliver to the output
+	otherPool := solana.NewWallet().PublicKey()
+	legs := twoHopRoute()[:1]
+	legs[0].step.Percent = 50
+	split := legs[0]
+	split.pool = []solana.PublicKey{solana.TokenProgramID, otherPool}
+	split.event.OutputAmount = 51_000_000
+	legs = append(legs, split)
+	for k := range legs {
+		legs[k].event.InputAmount = 500_000_000
+	}
+
+	routes, err := ParseRoutes(buildRouteTransaction(t, solana.NewWallet().PublicKey(), legs, nil, false), testProgramID)
+	require.NoError(t, err)
+	route := routes[0]
+	assert.Equal(t, uint64(1_000_000_000), route.InAmount)
+	assert.Equal(t, uint64(103_000_000), route.OutAmount)
+	assert.Nil(t, route.Fee)
+	assert.Equal(t, raydiumPool, route.Legs[0].Pool)
+	assert.Equal(t, otherPool, route.Legs[1].Pool, "second instruction of the same AMM")
+}
+
+func TestParseRoutesErrors(t *testing.T) {
+	user := solana.NewWallet().PublicKey()
+
+	// Logs truncated after the first swap event
+	result := buildRouteTransaction(t, user, twoHopRoute(), nil, true)
+	result.Meta.LogMessages = result.Meta.LogMessages[:5]
+	_, err := ParseRoutes(result, testProgramID)
+	assert.ErrorIs(t, err, ErrIncompleteRoute)
+
+	_, err = ParseRoutes(buildRouteTransaction(t, user, twoHopRoute(), nil, false), solana.NewWallet().PublicKey())
+	assert.ErrorIs(t, err, ErrNoRoute)
+
+	failed := buildRouteTransaction(t, user, twoHopRoute(), nil, false)
+	failed.Meta.Err = map[string]interface{}{"InstructionError": []interface{}{0, "Custom"}}
+	_, err = ParseRoutes(failed, testProgramID)
+	assert.ErrorContains(t, err, "transaction failed")
+}
+
+func TestRoutePoolData(t *testing.T) {
+	routes, err := ParseRoutes(buildRouteTransaction(t, solana.NewWallet().PublicKey(), twoHopRoute(), nil, false), testProgramID)
+	require.NoError(t, err)
+	route := routes[0]
+
+	data := route.PoolData()
+	assert.Equal(t, "sharedAccountsRoute", data.RouteType)
+	assert.Equal(t, wsolMint.String(), data.InputMint)
+	assert.Equal(t, usdcMint.String(), data.OutputMint)
+	assert.Equal(t, uint64(1_000_000_000), data.InAmount)
+	assert.Equal(t, uint64(241_500_000), data.OutAmount)
+	assert.Equal(t, uint8(9), data.InputDecimals)
+
+	volume := route.VolumeByPool()
+	require.Len(t, volume, 2)
+	require.Len(t, volume[raydiumPool.String()], 1)
+	leg := volume[raydiumPool.String()][0]
+	assert.Equal(t, wsolMint.String(), leg.InputMint)
+	assert.Equal(t, memeMint.String(), leg.OutputMint)
+	assert.Equal(t, uint64(1_000_000_000), leg.InAmount)
+	assert.Equal(t, uint64(52_000_000), leg.OutAmount)
+	assert.Equal(t, uint8(6), leg.OutputDecimals)
+	assert.Equal(t, uint64(241_500_000), volume[whirlpoolPool.String()][0].OutAmount)
+
+	// Legs on AMMs we do not track are not attributed
+	route.Legs[1].Pool = solana.PublicKey{}
+	assert.Len(t, route.VolumeByPool(), 1)
+}
+
+func TestFetchRoutes(t *testing.T) {
+	result := buildRouteTransaction(t, solana.NewWallet().PublicKey(), twoHopRoute(), nil, false)
+	client := &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			require.NotNil(t, opts.MaxSupportedTransactionVersion)
+			if signature != (solana.Signature{7}) {
+				return nil, rpc.ErrNotFound
+			}
+			return result, nil
+		},
+	}
+
+	routes, err := FetchRoutes(context.Background(), client, testProgramID, solana.Signature{7})
+	require.NoError(t, err)
+	assert.Len(t, routes[0].Legs, 2)
+
+	_, err = FetchRoutes(context.Background(), client, testProgramID, solana.Signature{8})
+	assert.ErrorIs(t, err, rpc.ErrNotFound)
+}
+
+func TestSwapLabel(t *testing.T) {
+	assert.Equal(t, "Saber", SwapLabel(jupidl.Swap{Enum: jupidl.SwapSaber}))
+	assert.Equal(t, "RaydiumClmm", SwapLabel(jupidl.Swap{Enum: jupidl.SwapRaydiumClmm}))
+	assert.Equal(t, "MeteoraDlmm", SwapLabel(jupidl.Swap{Enum: jupidl.SwapMeteoraDlmm}))
+	assert.Equal(t, "Unknown", SwapLabel(jupidl.Swap{Enum: jupidl.SwapMeteoraDlmm + 1}))
+}
@@ -0,0 +1,169 @@
+// File: /mnt/dev/go-bot/pkg/listeners/amm_pool_listener.go
+
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/raydium/parse"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// AMMPoolListener manages the WebSocket subscription to pool events
+type AMMPoolListener struct {
+	*programStream
+	pools     *eventbus.Topic[*parse.ParsedAMMPool]
+	swaps     *eventbus.Topic[*parse.SwapEvent]
+	liquidity *eventbus.Topic[*parse.LiquidityEvent]
+	config    *config.Config
+	parser    *parse.AMMParser
+}
+
+// NewAMMPoolListener creates a new listener instance for the active config profile, publishing on the bus
+func NewAMMPoolListener(cfg *config.Config, bus *eventbus.Bus) (*AMMPoolListener, error) {
+	programID, err := solana.PublicKeyFromBase58(cfg.RaydiumAMMProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID: %w", err)
+	}
+
+	client := utils.GetRPCClient(cfg)
+	parser, err := parse.NewAMMParser(cfg.RaydiumAMMProgramID, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parser: %w", err)
+	}
+
+	pools, err := AMMPoolsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+	swaps, err := AMMSwapsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := AMMLiquidityTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &AMMPoolListener{
+		pools:     pools,
+		swaps:     swaps,
+		liquidity: liquidity,
+		config:    cfg,
+		parser:    parser,
+	}
+	l.programStream = newProgramStream("Raydium AMM", cfg, programID, client, "raydium_pool_events.json", l.handleLogs)
+	return l, nil
+}
+
+// handleLogs parses one notification and publishes its events. Pool initializations are parsed from tx when
+// it was recorded, or from the fetched transaction, which is returned for recording.
+func (l *AMMPoolListener) handleLogs(ctx context.Context, resp *ws.LogResult, tx *rpc.GetTransactionResult) *rpc.GetTransactionResult {
+	if resp.Value.Err != nil {
+		log.Printf("Error in log response: %v", resp.Value.Err)
+		return nil
+	}
+
+	// Swaps and liquidity changes are fully described by their ray_log lines
+	events, err := parse.ParseRayLogs(resp)
+	if err != nil {
+		log.Printf("Error decoding ray_log: %v", err)
+	} else {
+		l.publishRayLogEvents(ctx, events)
+	}
+
+	// Parse pool initialization
+	if tx == nil {
+		if tx, err = l.parser.FetchPoolInit(ctx, resp); err != nil {
+			// Most notifications are swaps and other instructions, not pool creations
+			if !errors.Is(err, parse.ErrNoPoolInit) {
+				log.Printf("Error fetching pool initialization %s: %v", resp.Value.Signature, err)
+			}
+			return nil
+		}
+	}
+	pool, err := l.parser.ParseTransaction(ctx, tx)
+	if err != nil {
+		if !errors.Is(err, parse.ErrNoPoolInit) {
+			log.Printf("Error parsing pool initialization %s: %v", resp.Value.Signature, err)
+		}
+		return tx
+	}
+
+	// Pool births wait for slow subscribers rather than being dropped
+	log.Printf("New pool detected - ID: %s, Base: %s, Quote: %s",
+		pool.ID, pool.BaseMint, pool.QuoteMint)
+	if err := l.pools.Publish(ctx, pool); err != nil {
+		log.Printf("Error publishing pool %s: %v", pool.ID, err)
+	}
+	return tx
+}
+
+// publishRayLogEvents forwards decoded swaps and liquidity changes; slow subscribers lose the oldest ones
+func (l *AMMPoolListener) publishRayLogEvents(ctx context.Context, events *parse.RayLogEvents) {
+	for _, swap := range events.Swaps {
+		if err := l.swaps.Publish(ctx, swap); err != nil {
+			log.Printf("Error publishing swap for tx %s: %v", swap.Signature, err)
+		}
+	}
+	for _, change := range events.Liquidity {
+		if err := l.liquidity.Publish(ctx, change); err != nil {
+			log.Printf("Error publishing %s for tx %s: %v", change.Type, change.Signature, err)
+		}
+	}
+}
+
+// PoolTopic returns the topic of parsed pool events
+func (l *AMMPoolListener) PoolTopic() *eventbus.Topic[*parse.ParsedAMMPool] {
+	return l.pools
+}
+
+// SwapTopic returns the topic of swaps decoded from ray_log lines
+func (l *AMMPoolListener) SwapTopic() *eventbus.Topic[*parse.SwapEvent] {
+	return l.swaps
+}
+
+// LiquidityTopic returns the topic of deposits and withdrawals decoded from ray_log lines
+func (l *AMMPoolListener) LiquidityTopic() *eventbus.Topic[*parse.LiquidityEvent] {
+	return l.liquidity
+}
+
+// SaveRawLogs saves the raw log data to a file for analysis
+func (l *AMMPoolListener) SaveRawLogs(logValue *ws.LogResult) error {
+	logData := struct {
+		Timestamp time.Time `json:"timestamp"`
+		Signature string    `json:"signature"`
+		Slot      uint64    `json:"slot"`
+		Logs      []string  `json:"logs"`
+	}{
+		Timestamp: time.Now().UTC(),
+		Signature: logValue.Value.Signature.String(),
+		Slot:      logValue.Context.Slot,
+		Logs:      logValue.Value.Logs,
+	}
+
+	file, err := os.OpenFile("logs/raydium_raw_logs.json", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open raw logs file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	if err := encoder.Encode(logData); err != nil {
+		return fmt.Errorf("failed to write log data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/idl/bindings/raydiumclmm"
+	"corvus_bot/pkg/raydium/parse"
+	"corvus_bot/pkg/raydium/pool/clmm"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// CLMMPoolListener manages the WebSocket subscription to Raydium CLMM program events
+type CLMMPoolListener struct {
+	*programStream
+	events    *eventbus.Topic[*parse.CLMMEvent]
+	pools     *eventbus.Topic[*clmm.RaydiumClmmPool]
+	config    *config.Config
+	client    utils.RPCClientInterface
+	programID solana.PublicKey
+}
+
+// NewCLMMPoolListener creates a new listener instance for the active config profile, publishing on the bus
+func NewCLMMPoolListener(cfg *config.Config, bus *eventbus.Bus) (*CLMMPoolListener, error) {
+	programID, err := solana.PublicKeyFromBase58(cfg.RaydiumCLMMProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID: %w", err)
+	}
+
+	events, err := CLMMEventsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := CLMMPoolsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	client := utils.GetRPCClient(cfg)
+	l := &CLMMPoolListener{
+		events:    events,
+		pools:     pools,
+		config:    cfg,
+		client:    client,
+		programID: programID,
+	}
+	l.programStream = newProgramStream("Raydium CLMM", cfg, programID, client, "raydium_clmm_events.json", l.handleLogs)
+	return l, nil
+}
+
+// handleLogs decodes the events of one notification and publishes them. The events are read from the logs
+// alone, so no transaction is kept for the recording.
+func (l *CLMMPoolListener) handleLogs(ctx context.Context, resp *ws.LogResult, _ *rpc.GetTransactionResult) *rpc.GetTransactionResult {
+	if resp.Value.Err != nil {
+		// Failed transactions still log the events emitted before the error, but none of them took effect
+		return nil
+	}
+
+	events, err := parse.ParseCLMMEvents(resp, l.programID)
+	if err != nil {
+		log.Printf("Error decoding CLMM events: %v", err)
+		return nil
+	}
+	for _, event := range events {
+		if err := l.events.Publish(ctx, event); err != nil {
+			log.Printf("Error publishing %s for tx %s: %v", event.Name, event.Signature, err)
+		}
+		if created, ok := event.Event.(*raydiumclmm.PoolCreatedEvent); ok {
+			l.publishPool(ctx, created)
+		}
+	}
+	return nil
+}
+
+// publishPool resolves the pool announced by a PoolCreatedEvent and publishes it. The pool account may not be
+// readable yet at processed commitment, in which case the fields carried by the event are sent as is.
+func (l *CLMMPoolListener) publishPool(ctx context.Context, created *raydiumclmm.PoolCreatedEvent) {
+	pool, err := clmm.FetchClmmPoolByID(ctx, l.client, created.PoolState.String(), l.programID.String())
+	if err != nil {
+		log.Printf("Could not fetch new CLMM pool %s, using event data: %v", created.PoolState, err)
+		pool = parse.PoolFromCreatedEvent(created, l.programID.String())
+	}
+
+	log.Printf("New CLMM pool detected - ID: %s, MintA: %s, MintB: %s", pool.ID, pool.MintA, pool.MintB)
+	if err := l.pools.Publish(ctx, pool); err != nil {
+		log.Printf("Error publishing CLMM pool %s: %v", pool.ID, err)
+	}
+}
+
+// EventTopic returns the topic of decoded CLMM events
+func (l *CLMMPoolListener) EventTopic() *eventbus.Topic[*parse.CLMMEvent] {
+	return l.events
+}
+
+// PoolTopic returns the topic of newly created CLMM pools
+func (l *CLMMPoolListener) PoolTopic() *eventbus.Topic[*clmm.RaydiumClmmPool] {
+	return l.pools
+}
@@ -0,0 +1,112 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/moonshot"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// MoonshotListener manages the WebSocket subscription to Moonshot program events. MigrationEvents carry
+// neither the mint nor the curve, so the listener fetches the migrateFunds transaction before publishing
+// the migration.
+type MoonshotListener struct {
+	*programStream
+	client     utils.RPCClientInterface
+	events     *eventbus.Topic[*moonshot.Event]
+	migrations *eventbus.Topic[*moonshot.Migration]
+	config     *config.Config
+	programID  solana.PublicKey
+}
+
+// NewMoonshotListener creates a new listener instance for the active config profile, publishing on the bus
+func NewMoonshotListener(cfg *config.Config, bus *eventbus.Bus) (*MoonshotListener, error) {
+	programID, err := solana.PublicKeyFromBase58(cfg.MoonshotProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID: %w", err)
+	}
+
+	events, err := MoonshotEventsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := MoonshotMigrationsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	client := utils.GetRPCClient(cfg)
+	l := &MoonshotListener{
+		client:     client,
+		events:     events,
+		migrations: migrations,
+		config:     cfg,
+		programID:  programID,
+	}
+	l.programStream = newProgramStream("Moonshot", cfg, programID, client, "moonshot_events.json", l.handleLogs)
+	return l, nil
+}
+
+// handleLogs decodes the events of one notification and publishes them. Migrations are parsed from tx when
+// it was recorded, or from the fetched transaction, which is returned for recording.
+func (l *MoonshotListener) handleLogs(ctx context.Context, resp *ws.LogResult, tx *rpc.GetTransactionResult) *rpc.GetTransactionResult {
+	if resp.Value.Err != nil {
+		return nil
+	}
+
+	events, err := moonshot.ParseEvents(resp, l.programID)
+	if err != nil {
+		log.Printf("Error decoding Moonshot events: %v", err)
+		return nil
+	}
+	for _, event := range events {
+		if err := l.events.Publish(ctx, event); err != nil {
+			log.Printf("Error publishing %s for tx %s: %v", event.Name, event.Signature, err)
+		}
+		if _, ok := event.Event.(*moonshot.MigrationEvent); ok {
+			tx = l.publishMigration(ctx, resp.Value.Signature, tx)
+		}
+	}
+	return tx
+}
+
+// publishMigration reads the mint and curve of a migration from its transaction, fetched unless tx holds
+// it, and publishes it. It returns the transaction.
+func (l *MoonshotListener) publishMigration(ctx context.Context, signature solana.Signature, tx *rpc.GetTransactionResult) *rpc.GetTransactionResult {
+	if tx == nil {
+		var err error
+		tx, err = utils.FetchTransaction(ctx, l.client, signature, utils.DefaultFetchAttempts, utils.DefaultFetchDelay)
+		if err != nil {
+			log.Printf("Error fetching Moonshot migration %s: %v", signature, err)
+			return nil
+		}
+	}
+	migration, err := moonshot.ParseMigrateFunds(tx, l.programID)
+	if err != nil {
+		log.Printf("Error reading Moonshot migration %s: %v", signature, err)
+		return tx
+	}
+	log.Printf("Moonshot curve migrated - Mint: %s, Curve: %s", migration.Mint, migration.Curve)
+	if err := l.migrations.Publish(ctx, migration); err != nil {
+		log.Printf("Error publishing migration of %s: %v", migration.Mint, err)
+	}
+	return tx
+}
+
+// EventTopic returns the topic of decoded Moonshot events
+func (l *MoonshotListener) EventTopic() *eventbus.Topic[*moonshot.Event] {
+	return l.events
+}
+
+// MigrationTopic returns the topic of migrated curves
+func (l *MoonshotListener) MigrationTopic() *eventbus.Topic[*moonshot.Migration] {
+	return l.migrations
+}
@@ -0,0 +1,87 @@
+package listeners
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/pumpfun"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// PumpFunListener manages the WebSocket subscription to pump.fun program events
+type PumpFunListener struct {
+	*programStream
+	events      *eventbus.Topic[*pumpfun.Event]
+	completions *eventbus.Topic[*pumpfun.Event]
+	config      *config.Config
+	programID   solana.PublicKey
+}
+
+// NewPumpFunListener creates a new listener instance for the active config profile, publishing on the bus
+func NewPumpFunListener(cfg *config.Config, bus *eventbus.Bus) (*PumpFunListener, error) {
+	programID, err := solana.PublicKeyFromBase58(cfg.PumpFunProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID: %w", err)
+	}
+
+	events, err := PumpFunEventsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := PumpFunCompletionsTopic(bus)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &PumpFunListener{
+		events:      events,
+		completions: completions,
+		config:      cfg,
+		programID:   programID,
+	}
+	l.programStream = newProgramStream("pump.fun", cfg, programID, utils.GetRPCClient(cfg), "pumpfun_events.json", l.handleLogs)
+	return l, nil
+}
+
+// handleLogs decodes the events of one notification and publishes them. The events are read from the logs
+// alone, so no transaction is kept for the recording.
+func (l *PumpFunListener) handleLogs(ctx context.Context, resp *ws.LogResult, _ *rpc.GetTransactionResult) *rpc.GetTransactionResult {
+	if resp.Value.Err != nil {
+		return nil
+	}
+
+	events, err := pumpfun.ParseEvents(resp, l.programID)
+	if err != nil {
+		log.Printf("Error decoding pump.fun events: %v", err)
+		return nil
+	}
+	for _, event := range events {
+		if err := l.events.Publish(ctx, event); err != nil {
+			log.Printf("Error publishing %s for tx %s: %v", event.Name, event.Signature, err)
+		}
+		if complete, ok := event.Event.(*pumpfun.CompleteEvent); ok {
+			log.Printf("Bonding curve complete - Mint: %s, Curve: %s", complete.Mint, complete.BondingCurve)
+			if err := l.completions.Publish(ctx, event); err != nil {
+				log.Printf("Error publishing completion of %s: %v", complete.Mint, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EventTopic returns the topic of decoded pump.fun events
+func (l *PumpFunListener) EventTopic() *eventbus.Topic[*pumpfun.Event] {
+	return l.events
+}
+
+// CompletionTopic returns the topic of completed bonding curves
+func (l *PumpFunListener) CompletionTopic() *eventbus.Topic[*pumpfun.Event] {
+	return l.completions
+}
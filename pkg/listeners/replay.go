@@ -0,0 +1,139 @@
+package listeners
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// maxRecordSize bounds one JSONL line; transactions with many inner instructions log a lot
+const maxRecordSize = 16 * 1024 * 1024
+
+// recordedLog is one line of a recording. It reads both the files written by the listeners
+// ({"Timestamp", "Data", "Transaction"}) and by SaveRawLogs ({"timestamp", "signature", "slot", "logs"}).
+type recordedLog struct {
+	Timestamp   time.Time                 `json:"timestamp"`
+	Data        *ws.LogResult             `json:"data"`
+	Transaction *rpc.GetTransactionResult `json:"transaction"`
+	Signature   string                    `json:"signature"`
+	Slot        uint64                    `json:"slot"`
+	Logs        []string                  `json:"logs"`
+}
+
+// recordLog appends a notification to a listener recording, with the transaction the listener fetched to
+// handle it if any
+func recordLog(encoder *json.Encoder, resp *ws.LogResult, tx *rpc.GetTransactionResult) {
+	logEntry := struct {
+		Timestamp   time.Time
+		Data        *ws.LogResult
+		Transaction *rpc.GetTransactionResult `json:",omitempty"`
+	}{
+		Timestamp:   time.Now().UTC(),
+		Data:        resp,
+		Transaction: tx,
+	}
+	if err := encoder.Encode(logEntry); err != nil {
+		log.Printf("Error saving raw log: %v", err)
+	}
+}
+
+// replayHandler processes one recorded notification, with the transaction recorded along with it or nil
+type replayHandler func(ctx context.Context, logMsg *ws.LogResult, tx *rpc.GetTransactionResult)
+
+// ReplaySource feeds a recorded JSONL stream of notifications to a listener, in place of the live subscription.
+// Listeners read the transactions they need from the recording, and only fetch them with getTransaction for
+// recordings written before transactions were recorded, such as those of SaveRawLogs.
+type ReplaySource struct {
+	path  string
+	speed float64
+}
+
+// NewReplaySource replays the recording at path. A speed of 1 keeps the recorded pace, 10 replays ten times
+// faster and 0 or less replays without pauses.
+func NewReplaySource(path string, speed float64) *ReplaySource {
+	return &ReplaySource{path: path, speed: speed}
+}
+
+// Run hands every recorded notification to the handler in order, until the end of the recording or
+// the context is done
+func (r *ReplaySource) Run(ctx context.Context, handle replayHandler) error {
+	file, err := os.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+
+	return r.replay(ctx, file, handle)
+}
+
+func (r *ReplaySource) replay(ctx context.Context, reader io.Reader, handle replayHandler) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	var previous time.Time
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record recordedLog
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("line %d: failed to decode record: %w", line, err)
+		}
+		logMsg, err := record.logResult()
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if r.speed > 0 && !previous.IsZero() && record.Timestamp.After(previous) {
+			delay := time.Duration(float64(record.Timestamp.Sub(previous)) / r.speed)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if !record.Timestamp.IsZero() {
+			previous = record.Timestamp
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		handle(ctx, logMsg, record.Transaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read recording: %w", err)
+	}
+	return nil
+}
+
+// logResult returns the notification of the record, whichever format it was written in
+func (r *recordedLog) logResult() (*ws.LogResult, error) {
+	if r.Data != nil {
+		return r.Data, nil
+	}
+
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = r.Slot
+	logMsg.Value.Logs = r.Logs
+	if r.Signature != "" {
+		signature, err := solana.SignatureFromBase58(r.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		logMsg.Value.Signature = signature
+	}
+	return logMsg, nil
+}
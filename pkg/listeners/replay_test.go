@@ -0,0 +1,296 @@
+package listeners
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/eventbus"
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/idl/bindings/raydiumclmm"
+	"corvus_bot/pkg/moonshot"
+	"corvus_bot/pkg/pumpfun"
+	"corvus_bot/pkg/raydium/parse"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRecording writes records as JSONL, the way the listeners append them
+func writeRecording(t *testing.T, records ...interface{}) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "recording.json")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, record := range records {
+		require.NoError(t, encoder.Encode(record))
+	}
+	return path
+}
+
+// listenerEntry is the record format of recordLog for a notification without a transaction
+type listenerEntry struct {
+	Timestamp time.Time
+	Data      *ws.LogResult
+}
+
+// listenerRecord records a notification of signature with logs
+func listenerRecord(at time.Time, signature byte, logs ...string) listenerEntry {
+	logMsg := notification(signature, 304027100+uint64(signature))
+	logMsg.Value.Logs = logs
+	return listenerEntry{at, logMsg}
+}
+
+// encodeRecord Borsh encodes a record after an optional discriminator
+func encodeRecord(t *testing.T, prefix []byte, record interface{}) string {
+	t.Helper()
+	buf := bytes.NewBuffer(append([]byte(nil), prefix...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(record))
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func testConfig(t *testing.T, client *utils.MockRPCClient) *config.Config {
+	t.Helper()
+	utils.SetMockRPCClient(client)
+	t.Cleanup(func() { utils.SetMockRPCClient(nil) })
+	return &config.Config{
+		Testing:              true,
+		RaydiumAMMProgramID:  "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8",
+		RaydiumCLMMProgramID: config.DefaultRaydiumCLMMProgramID,
+		PumpFunProgramID:     config.DefaultPumpFunProgramID,
+		MoonshotProgramID:    config.DefaultMoonshotProgramID,
+	}
+}
+
+func TestAMMListenerReplay(t *testing.T) {
+	var fetched []solana.Signature
+	cfg := testConfig(t, &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			fetched = append(fetched, signature)
+			return nil, errors.New("offline")
+		},
+	})
+	bus := eventbus.New()
+	listener, err := NewAMMPoolListener(cfg, bus)
+	require.NoError(t, err)
+
+	swaps := listener.SwapTopic().Subscribe(10)
+	liquidity := listener.LiquidityTopic().Subscribe(10)
+
+	start := time.Date(2024, 11, 28, 0, 3, 25, 0, time.UTC)
+	swap := encodeRecord(t, nil, parse.SwapBaseInLog{
+		LogType: uint8(parse.RayLogSwapBaseIn), AmountIn: 1_000, Direction: uint64(parse.SwapCoinToPc),
+		PoolCoin: 1_000_000, PoolPc: 150_000, OutAmount: 149,
+	})
+	deposit := encodeRecord(t, nil, parse.DepositLog{
+		LogType: uint8(parse.RayLogDeposit), PoolCoin: 1_001_000, PoolPc: 149_851, PoolLp: 300_000,
+		DeductCoin: 10_000, DeductPc: 1_500, MintLp: 3_000,
+	})
+	path := writeRecording(t,
+		listenerRecord(start, 1, "Program log: ray_log: "+swap),
+		listenerRecord(start.Add(200*time.Millisecond), 2, "Program log: ray_log: "+deposit),
+	)
+
+	// The recorded 200ms gap is replayed ten times faster
+	began := time.Now()
+	require.NoError(t, listener.Replay(context.Background(), NewReplaySource(path, 10)))
+	assert.GreaterOrEqual(t, time.Since(began), 20*time.Millisecond)
+
+	require.Len(t, swaps.C(), 1)
+	replayed := <-swaps.C()
+	assert.Equal(t, solana.Signature{1}.String(), replayed.Signature)
+	assert.Equal(t, uint64(149), replayed.AmountOut)
+
+	require.Len(t, liquidity.C(), 1)
+	assert.Equal(t, parse.LiquidityDeposit, (<-liquidity.C()).Type)
+	assert.Empty(t, fetched, "notifications without initialize2 make no RPC call")
+}
+
+func TestAMMListenerReplaysRawLogs(t *testing.T) {
+	var fetched []solana.Signature
+	cfg := testConfig(t, &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			fetched = append(fetched, signature)
+			return nil, errors.New("offline")
+		},
+	})
+	listener, err := NewAMMPoolListener(cfg, eventbus.New())
+	require.NoError(t, err)
+
+	// The sample is in the SaveRawLogs format and holds a pool initialization
+	require.NoError(t, listener.Replay(context.Background(), NewReplaySource("raydium_filtered_logs.json", 0)))
+	require.Len(t, fetched, 1)
+	assert.Equal(t, "4Dutdh6kudij9PVEw4323LyJn9rUUgw5pamZbHPwRRjvEXkvtp4nuZUNT4bvPL6x7aiW31uDETiiZrwSo1NSDdBV", fetched[0].String())
+}
+
+func TestCLMMListenerReplay(t *testing.T) {
+	cfg := testConfig(t, &utils.MockRPCClient{})
+	listener, err := NewCLMMPoolListener(cfg, eventbus.New())
+	require.NoError(t, err)
+
+	events := listener.EventTopic().Subscribe(10)
+	pools := listener.PoolTopic().Subscribe(10)
+
+	created := raydiumclmm.PoolCreatedEvent{
+		TokenMint0:  solana.SolMint,
+		TokenMint1:  solana.NewWallet().PublicKey(),
+		TickSpacing: 10,
+		PoolState:   solana.NewWallet().PublicKey(),
+		TokenVault0: solana.NewWallet().PublicKey(),
+		TokenVault1: solana.NewWallet().PublicKey(),
+	}
+	program := config.DefaultRaydiumCLMMProgramID
+	path := writeRecording(t, listenerRecord(time.Now(), 3,
+		"Program "+program+" invoke [1]",
+		"Program data: "+encodeRecord(t, raydiumclmm.PoolCreatedEventDiscriminator, created),
+		"Program "+program+" success",
+	))
+
+	require.NoError(t, listener.Replay(context.Background(), NewReplaySource(path, 0)))
+
+	require.Len(t, events.C(), 1)
+	assert.Equal(t, "PoolCreatedEvent", (<-events.C()).Name)
+
+	// The pool account is not readable offline, so the event data is published
+	require.Len(t, pools.C(), 1)
+	pool := <-pools.C()
+	assert.Equal(t, created.PoolState.String(), pool.ID)
+	assert.Equal(t, 10, pool.AmmConfig.TickSpacing)
+}
+
+func TestPumpFunListenerReplay(t *testing.T) {
+	cfg := testConfig(t, &utils.MockRPCClient{})
+	listener, err := NewPumpFunListener(cfg, eventbus.New())
+	require.NoError(t, err)
+
+	events := listener.EventTopic().Subscribe(10)
+	completions := listener.CompletionTopic().Subscribe(10)
+
+	mint := solana.NewWallet().PublicKey()
+	trade := pumpfun.TradeEvent{Mint: mint, SolAmount: 85_000_000_000, TokenAmount: 1_000_000, IsBuy: true}
+	complete := pumpfun.CompleteEvent{Mint: mint, BondingCurve: solana.NewWallet().PublicKey()}
+	program := config.DefaultPumpFunProgramID
+	path := writeRecording(t, listenerRecord(time.Now(), 4,
+		"Program "+program+" invoke [1]",
+		"Program data: "+encodeRecord(t, pumpidl.TradeEventDiscriminator, trade),
+		"Program data: "+encodeRecord(t, pumpidl.CompleteEventDiscriminator, complete),
+		"Program "+program+" success",
+	))
+
+	require.NoError(t, listener.Replay(context.Background(), NewReplaySource(path, 0)))
+
+	require.Len(t, events.C(), 2)
+	assert.Equal(t, "TradeEvent", (<-events.C()).Name)
+	assert.Equal(t, "CompleteEvent", (<-events.C()).Name)
+
+	require.Len(t, completions.C(), 1)
+	assert.Equal(t, &complete, (<-completions.C()).Event)
+}
+
+func TestMoonshotListenerReplay(t *testing.T) {
+	program := config.DefaultMoonshotProgramID
+	accounts := moonidl.MigrateFundsAccounts{
+		BackendAuthority:   solana.NewWallet().PublicKey(),
+		MigrationAuthority: solana.NewWallet().PublicKey(),
+		CurveAccount:       solana.NewWallet().PublicKey(),
+		CurveTokenAccount:  solana.NewWallet().PublicKey(),
+		Mint:               solana.NewWallet().PublicKey(),
+		ConfigAccount:      solana.NewWallet().PublicKey(),
+	}
+	migrateIx, err := moonidl.NewMigrateFundsInstruction(solana.MustPublicKeyFromBase58(program), accounts)
+	require.NoError(t, err)
+	tx, err := solana.NewTransaction([]solana.Instruction{migrateIx}, solana.Hash{}, solana.TransactionPayer(accounts.MigrationAuthority))
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	var envelope rpc.TransactionResultEnvelope
+	require.NoError(t, json.Unmarshal([]byte(`["`+base64.StdEncoding.EncodeToString(raw)+`", "base64"]`), &envelope))
+
+	recorded := &rpc.GetTransactionResult{Slot: 304027105, Transaction: &envelope, Meta: &rpc.TransactionMeta{}}
+
+	var fetched []solana.Signature
+	cfg := testConfig(t, &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			fetched = append(fetched, signature)
+			return recorded, nil
+		},
+	})
+	listener, err := NewMoonshotListener(cfg, eventbus.New())
+	require.NoError(t, err)
+
+	events := listener.EventTopic().Subscribe(10)
+	migrations := listener.MigrationTopic().Subscribe(10)
+
+	trade := moonshot.TradeEvent{Amount: 1_000_000, CollateralAmount: 250_000, Curve: accounts.CurveAccount, Type: moonidl.TradeTypeBuy}
+	migration := moonshot.MigrationEvent{TokensMigrated: 200_000_000, CollateralMigrated: 500_000_000_000}
+	logMsg := listenerRecord(time.Now(), 5,
+		"Program "+program+" invoke [1]",
+		"Program data: "+encodeRecord(t, moonidl.TradeEventDiscriminator, trade),
+		"Program "+program+" success",
+		"Program "+program+" invoke [1]",
+		"Program data: "+encodeRecord(t, moonidl.MigrationEventDiscriminator, migration),
+		"Program "+program+" success",
+	).Data
+
+	// The live handler fetches the migrateFunds transaction and hands it back for the recording
+	var recording bytes.Buffer
+	migrateTx := listener.handleLogs(context.Background(), logMsg, nil)
+	require.Equal(t, recorded, migrateTx)
+	recordLog(json.NewEncoder(&recording), logMsg, migrateTx)
+	require.Len(t, fetched, 1)
+	require.Len(t, migrations.C(), 1)
+	<-migrations.C()
+	for len(events.C()) > 0 {
+		<-events.C()
+	}
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, os.WriteFile(path, recording.Bytes(), 0o644))
+	fetched = nil
+	require.NoError(t, listener.Replay(context.Background(), NewReplaySource(path, 0)))
+
+	require.Len(t, events.C(), 2)
+	assert.Equal(t, "TradeEvent", (<-events.C()).Name)
+	assert.Equal(t, "MigrationEvent", (<-events.C()).Name)
+
+	// The mint and curve come from the recorded migrateFunds transaction, without fetching it again
+	assert.Empty(t, fetched)
+	require.Len(t, migrations.C(), 1)
+	migrated := <-migrations.C()
+	assert.Equal(t, accounts.Mint, migrated.Mint)
+	assert.Equal(t, accounts.CurveAccount, migrated.Curve)
+	assert.Equal(t, uint64(304027105), migrated.Slot)
+}
+
+func TestReplaySourceErrors(t *testing.T) {
+	handle := func(context.Context, *ws.LogResult, *rpc.GetTransactionResult) {}
+
+	err := NewReplaySource(filepath.Join(t.TempDir(), "missing.json"), 0).Run(context.Background(), handle)
+	assert.ErrorContains(t, err, "failed to open recording")
+
+	path := filepath.Join(t.TempDir(), "broken.json")
+	require.NoError(t, os.WriteFile(path, []byte("{\"slot\": 1}\n\n{not json\n"), 0o644))
+	err = NewReplaySource(path, 0).Run(context.Background(), handle)
+	assert.ErrorContains(t, err, "line 3")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	path = writeRecording(t, listenerRecord(time.Now(), 1))
+	assert.ErrorIs(t, NewReplaySource(path, 1).Run(ctx, handle), context.Canceled)
+}
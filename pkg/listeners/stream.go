@@ -0,0 +1,84 @@
+// Package listeners subscribes to the logs of the programs the bot follows, Raydium as well as the launchpads
+// whose curves migrate to it, and publishes what they decode on the event bus.
+package listeners
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// logsDir holds the recordings of the listeners
+const logsDir = "logs"
+
+// txHandler decodes and publishes one notification. tx is the transaction recorded along with it, nil when
+// live; the handler returns the transaction it used, if any, so the recording keeps it.
+type txHandler func(ctx context.Context, logMsg *ws.LogResult, tx *rpc.GetTransactionResult) *rpc.GetTransactionResult
+
+// programStream is the loop every listener shares: it subscribes to the logs of a program, hands each
+// notification to the listener and appends the successful ones to a recording that Replay can read back
+type programStream struct {
+	name         string
+	recording    string
+	profile      string
+	subscription *logSubscription
+	handle       txHandler
+}
+
+// newProgramStream streams the logs of the program to handle, recording them in logs/<recording>
+func newProgramStream(name string, cfg *config.Config, programID solana.PublicKey, client utils.RPCClientInterface, recording string, handle txHandler) *programStream {
+	return &programStream{
+		name:         name,
+		recording:    recording,
+		profile:      cfg.Profile,
+		subscription: newLogSubscription(name+" listener", cfg.WSConnection, programID, client),
+		handle:       handle,
+	}
+}
+
+// Start begins listening for program events. Dropped connections are re-established and the transactions
+// sent meanwhile are backfilled; Start only returns once the context is cancelled or the listener closed.
+func (s *programStream) Start(ctx context.Context) error {
+	if err := os.MkdirAll(logsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create logs directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(logsDir, s.recording), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+
+	log.Printf("Subscribing to %s logs for program: %s (profile: %s)", s.name, s.subscription.programID, s.profile)
+
+	return s.subscription.Run(ctx, func(ctx context.Context, resp *ws.LogResult) {
+		tx := s.handle(ctx, resp, nil)
+		if resp.Value.Err == nil {
+			recordLog(encoder, resp, tx)
+		}
+	})
+}
+
+// Replay feeds a recorded stream through the same decoding and publishing as the live subscription
+func (s *programStream) Replay(ctx context.Context, source *ReplaySource) error {
+	return source.Run(ctx, func(ctx context.Context, resp *ws.LogResult, tx *rpc.GetTransactionResult) {
+		s.handle(ctx, resp, tx)
+	})
+}
+
+// Close closes the WebSocket connection and stops reconnecting
+func (s *programStream) Close() {
+	s.subscription.Close()
+}
@@ -0,0 +1,66 @@
+package listeners
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramStreamRecordsWhatItReplays(t *testing.T) {
+	t.Chdir(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failed := notification(2, 102)
+	failed.Value.Err = map[string]interface{}{"InstructionError": nil}
+	fetched := &rpc.GetTransactionResult{Slot: 101}
+
+	var replaying bool
+	var live, replayed []*rpc.GetTransactionResult
+	stream := newProgramStream("test", &config.Config{}, solana.SolMint, &utils.MockRPCClient{}, "test_events.json",
+		func(ctx context.Context, logMsg *ws.LogResult, tx *rpc.GetTransactionResult) *rpc.GetTransactionResult {
+			if replaying {
+				replayed = append(replayed, tx)
+				return tx
+			}
+			live = append(live, tx)
+			if len(live) == 3 {
+				cancel()
+			}
+			if logMsg.Value.Signature == (solana.Signature{1}) {
+				return fetched
+			}
+			return nil
+		})
+	stream.subscription.subscribe = func(ctx context.Context) (logStream, func(), error) {
+		return &fakeStream{results: []*ws.LogResult{notification(1, 101), failed, notification(3, 103)}, last: true}, func() {}, nil
+	}
+
+	done := make(chan error)
+	go func() { done <- stream.Start(ctx) }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream did not deliver the notifications")
+	}
+	assert.Equal(t, []*rpc.GetTransactionResult{nil, nil, nil}, live, "live notifications come without a transaction")
+
+	// The failed transaction is not recorded and the fetched one is handed back on replay
+	replaying = true
+	source := NewReplaySource(filepath.Join(logsDir, "test_events.json"), 0)
+	require.NoError(t, stream.Replay(context.Background(), source))
+	require.Len(t, replayed, 2)
+	assert.Equal(t, fetched.Slot, replayed[0].Slot)
+	assert.Nil(t, replayed[1])
+}
@@ -0,0 +1,364 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+const (
+	// defaultMinBackoff is the first pause before reconnecting; it doubles on every failed attempt
+	defaultMinBackoff = 500 * time.Millisecond
+
+	// defaultMaxBackoff caps the pause between two reconnection attempts
+	defaultMaxBackoff = 30 * time.Second
+
+	// defaultBackfillLimit bounds the transactions replayed after a reconnect, so a long outage does not
+	// stall the live stream
+	defaultBackfillLimit = 2000
+
+	// defaultBackfillSlots bounds how far back a backfill goes from the newest confirmed transaction, about
+	// ten minutes of slots
+	defaultBackfillSlots = 1500
+
+	// signaturesPageSize is the largest page getSignaturesForAddress returns
+	signaturesPageSize = 1000
+
+	// seenSignatures is how many recent signatures are remembered to drop the overlap between backfill and
+	// the live stream
+	seenSignatures = 10000
+)
+
+// logHandler processes one log notification, whether it arrived live or was backfilled
+type logHandler func(ctx context.Context, logMsg *ws.LogResult)
+
+// logStream is the part of ws.LogSubscription the subscription reads from
+type logStream interface {
+	Recv(ctx context.Context) (*ws.LogResult, error)
+	Unsubscribe()
+}
+
+// subscribeFunc opens a connection and subscribes to the program logs. The returned func closes the connection.
+type subscribeFunc func(ctx context.Context) (logStream, func(), error)
+
+// logSubscription keeps a logsSubscribe subscription alive across disconnects. It reconnects with exponential
+// backoff and, once resubscribed, backfills with getSignaturesForAddress the confirmed transactions it did
+// not process while the socket was down, so nothing is missed.
+type logSubscription struct {
+	name          string
+	programID     solana.PublicKey
+	subscribe     subscribeFunc
+	client        utils.RPCClientInterface
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	backfillLimit int
+	backfillSlots uint64
+
+	mu            sync.Mutex
+	closeConn     func()
+	closed        bool
+	lastSlot      uint64
+	lastSignature solana.Signature
+	// lastConfirmed is the newest confirmed transaction known processed. Live notifications arrive at
+	// processed commitment and may be dropped with their fork, so only signatures listed at confirmed
+	// commitment can bound a listing.
+	lastConfirmed solana.Signature
+	seen          *signatureSet
+}
+
+// newLogSubscription creates a subscription to the logs mentioning the program on the configured WebSocket endpoint
+func newLogSubscription(name, wsURL string, programID solana.PublicKey, client utils.RPCClientInterface) *logSubscription {
+	subscribe := func(ctx context.Context) (logStream, func(), error) {
+		wsClient, err := ws.Connect(ctx, wsURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
+		}
+		sub, err := wsClient.LogsSubscribeMentions(programID, rpc.CommitmentProcessed)
+		if err != nil {
+			wsClient.Close()
+			return nil, nil, fmt.Errorf("failed to subscribe to logs: %w", err)
+		}
+		return sub, wsClient.Close, nil
+	}
+	return &logSubscription{
+		name:          name,
+		programID:     programID,
+		subscribe:     subscribe,
+		client:        client,
+		minBackoff:    defaultMinBackoff,
+		maxBackoff:    defaultMaxBackoff,
+		backfillLimit: defaultBackfillLimit,
+		backfillSlots: defaultBackfillSlots,
+		seen:          newSignatureSet(seenSignatures),
+	}
+}
+
+// Run delivers notifications to the handler until the context is cancelled or the subscription is closed
+func (s *logSubscription) Run(ctx context.Context, handle logHandler) error {
+	backoff := s.minBackoff
+	for {
+		if s.isClosed() {
+			return nil
+		}
+
+		stream, closeConn, err := s.subscribe(ctx)
+		if err != nil {
+			log.Printf("%s: %v, retrying in %s", s.name, err, backoff)
+		} else {
+			s.setConn(closeConn)
+			backoff = s.minBackoff
+
+			lastSlot, _ := s.position()
+			if lastSlot > 0 {
+				if err := s.backfill(ctx, handle); err != nil {
+					log.Printf("%s: backfill after slot %d failed: %v", s.name, lastSlot, err)
+				}
+			}
+
+			err = s.consume(ctx, stream, handle)
+			stream.Unsubscribe()
+			// Close may already have closed the connection
+			if closeConn := s.takeConn(); closeConn != nil {
+				closeConn()
+			}
+			if ctx.Err() != nil || s.isClosed() {
+				return nil
+			}
+			log.Printf("%s: subscription dropped: %v, reconnecting in %s", s.name, err, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// Close closes the current connection and stops reconnecting
+func (s *logSubscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.closeConn != nil {
+		s.closeConn()
+		s.closeConn = nil
+	}
+}
+
+// consume reads the live stream until it fails
+func (s *logSubscription) consume(ctx context.Context, stream logStream, handle logHandler) error {
+	for {
+		logMsg, err := stream.Recv(ctx)
+		if err != nil {
+			return err
+		}
+		if logMsg == nil {
+			return errors.New("subscription channel closed")
+		}
+		s.dispatch(ctx, logMsg, handle)
+	}
+}
+
+// dispatch hands a notification to the handler once and advances the last processed slot
+func (s *logSubscription) dispatch(ctx context.Context, logMsg *ws.LogResult, handle logHandler) {
+	s.mu.Lock()
+	if !s.seen.add(logMsg.Value.Signature) {
+		s.mu.Unlock()
+		return
+	}
+	if logMsg.Context.Slot >= s.lastSlot {
+		s.lastSlot = logMsg.Context.Slot
+		s.lastSignature = logMsg.Value.Signature
+	}
+	s.mu.Unlock()
+
+	handle(ctx, logMsg)
+}
+
+// backfill replays, oldest first, the confirmed transactions of the program missed since the last processed
+// slot. Nothing is fetched when the newest confirmed transaction was already processed. Otherwise the listing
+// goes back to the last confirmed signature, the last processed slot or backfillSlots before the newest
+// transaction, whichever comes first, and only the signatures not processed yet are fetched.
+func (s *logSubscription) backfill(ctx context.Context, handle logHandler) error {
+	lastSlot, _ := s.position()
+
+	var missed []*rpc.TransactionSignature
+	var newest *rpc.TransactionSignature
+	limit := signaturesPageSize
+	opts := &rpc.GetSignaturesForAddressOpts{
+		Limit:      &limit,
+		Until:      s.confirmed(),
+		Commitment: rpc.CommitmentConfirmed,
+	}
+listing:
+	for len(missed) < s.backfillLimit {
+		page, err := s.client.GetSignaturesForAddressWithOpts(ctx, s.programID, opts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch signatures: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		if newest == nil {
+			newest = page[0]
+			if s.hasSeen(newest.Signature) {
+				s.setConfirmed(newest.Signature)
+				return nil
+			}
+		}
+		for _, signature := range page {
+			if signature.Slot < lastSlot || signature.Slot+s.backfillSlots < newest.Slot {
+				break listing
+			}
+			missed = append(missed, signature)
+		}
+		if len(page) < limit {
+			break
+		}
+		opts.Before = page[len(page)-1].Signature
+	}
+	if len(missed) > s.backfillLimit {
+		log.Printf("%s: %d transactions missed since slot %d, only the latest %d are backfilled",
+			s.name, len(missed), lastSlot, s.backfillLimit)
+		missed = missed[:s.backfillLimit]
+	}
+	if len(missed) == 0 {
+		return nil
+	}
+	log.Printf("%s: gap detected, backfilling %d transactions from slot %d to %d", s.name, len(missed), missed[len(missed)-1].Slot, newest.Slot)
+
+	maxVersion := uint64(0)
+	txOpts := &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentConfirmed,
+		MaxSupportedTransactionVersion: &maxVersion,
+	}
+	complete := true
+	for i := len(missed) - 1; i >= 0; i-- {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		signature := missed[i]
+		// Failed transactions are skipped by every handler, there is no need to fetch their logs
+		if signature.Err != nil || s.hasSeen(signature.Signature) {
+			continue
+		}
+
+		result, err := s.client.GetTransaction(ctx, signature.Signature, txOpts)
+		if err != nil {
+			log.Printf("%s: failed to fetch missed transaction %s: %v", s.name, signature.Signature, err)
+			complete = false
+			continue
+		}
+		if result == nil || result.Meta == nil {
+			continue
+		}
+
+		logMsg := &ws.LogResult{}
+		logMsg.Context.Slot = result.Slot
+		logMsg.Value.Signature = signature.Signature
+		logMsg.Value.Err = result.Meta.Err
+		logMsg.Value.Logs = result.Meta.LogMessages
+		s.dispatch(ctx, logMsg, handle)
+	}
+	// A transaction that could not be fetched is listed again by the next backfill
+	if complete {
+		s.setConfirmed(newest.Signature)
+	}
+	return nil
+}
+
+// position returns the slot and signature of the last processed notification
+func (s *logSubscription) position() (uint64, solana.Signature) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastSlot, s.lastSignature
+}
+
+// confirmed returns the newest confirmed signature known processed, zero before the first backfill
+func (s *logSubscription) confirmed() solana.Signature {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastConfirmed
+}
+
+func (s *logSubscription) setConfirmed(signature solana.Signature) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastConfirmed = signature
+}
+
+func (s *logSubscription) hasSeen(signature solana.Signature) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen.contains(signature)
+}
+
+func (s *logSubscription) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *logSubscription) setConn(closeConn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConn = closeConn
+}
+
+func (s *logSubscription) takeConn() func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	closeConn := s.closeConn
+	s.closeConn = nil
+	return closeConn
+}
+
+// signatureSet remembers the most recent signatures up to a fixed capacity
+type signatureSet struct {
+	index map[solana.Signature]struct{}
+	ring  []solana.Signature
+	next  int
+}
+
+func newSignatureSet(capacity int) *signatureSet {
+	return &signatureSet{
+		index: make(map[solana.Signature]struct{}, capacity),
+		ring:  make([]solana.Signature, 0, capacity),
+	}
+}
+
+func (s *signatureSet) contains(signature solana.Signature) bool {
+	_, ok := s.index[signature]
+	return ok
+}
+
+// add records the signature and reports whether it was new, evicting the oldest one when full
+func (s *signatureSet) add(signature solana.Signature) bool {
+	if s.contains(signature) {
+		return false
+	}
+	if len(s.ring) < cap(s.ring) {
+		s.ring = append(s.ring, signature)
+	} else {
+		delete(s.index, s.ring[s.next])
+		s.ring[s.next] = signature
+		s.next = (s.next + 1) % len(s.ring)
+	}
+	s.index[signature] = struct{}{}
+	return true
+}
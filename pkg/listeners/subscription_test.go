@@ -0,0 +1,209 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStream replays notifications and then fails, like a dropped socket, or blocks when it is the last one
+type fakeStream struct {
+	results []*ws.LogResult
+	last    bool
+	closed  chan struct{}
+}
+
+func (f *fakeStream) Recv(ctx context.Context) (*ws.LogResult, error) {
+	if len(f.results) > 0 {
+		next := f.results[0]
+		f.results = f.results[1:]
+		return next, nil
+	}
+	if f.last {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-f.closed:
+			return nil, ws.ErrSubscriptionClosed
+		}
+	}
+	return nil, errors.New("websocket: close 1006 (abnormal closure)")
+}
+
+func (f *fakeStream) Unsubscribe() {}
+
+func notification(signature byte, slot uint64) *ws.LogResult {
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = slot
+	logMsg.Value.Signature = solana.Signature{signature}
+	logMsg.Value.Logs = []string{"Program log: slot"}
+	return logMsg
+}
+
+func TestLogSubscriptionReconnectsAndBackfills(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var untilSeen solana.Signature
+	client := &utils.MockRPCClient{
+		MockGetSignaturesForAddress: func(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+			untilSeen = opts.Until
+			// Newest first, as the node returns them
+			return []*rpc.TransactionSignature{
+				{Signature: solana.Signature{4}, Slot: 104},
+				{Signature: solana.Signature{3}, Slot: 103, Err: map[string]interface{}{"InstructionError": nil}},
+				{Signature: solana.Signature{2}, Slot: 102},
+				{Signature: solana.Signature{1}, Slot: 101},
+				// Before the last processed slot, the listing stops here
+				{Signature: solana.Signature{9}, Slot: 99},
+			}, nil
+		},
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			return &rpc.GetTransactionResult{
+				Slot: 100 + uint64(signature[0]),
+				Meta: &rpc.TransactionMeta{LogMessages: []string{"Program log: backfilled"}},
+			}, nil
+		},
+	}
+
+	streams := []*fakeStream{
+		{results: []*ws.LogResult{notification(1, 101)}},
+		// The new subscription overlaps the backfill with signature 4
+		{results: []*ws.LogResult{notification(4, 104), notification(5, 105)}, last: true},
+	}
+	var connects int
+	sub := newLogSubscription("test", "", solana.SolMint, client)
+	sub.minBackoff = time.Millisecond
+	sub.subscribe = func(ctx context.Context) (logStream, func(), error) {
+		connects++
+		if connects == 2 {
+			return nil, nil, errors.New("dial tcp: connection refused")
+		}
+		stream := streams[0]
+		streams = streams[1:]
+		return stream, func() {}, nil
+	}
+
+	var mu sync.Mutex
+	var handled []solana.Signature
+	done := make(chan error)
+	go func() {
+		done <- sub.Run(ctx, func(ctx context.Context, logMsg *ws.LogResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, logMsg.Value.Signature)
+			if len(handled) == 4 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not deliver the backfilled notifications")
+	}
+
+	assert.Equal(t, 3, connects, "a failed dial is retried")
+	assert.True(t, untilSeen.IsZero(), "the processed signature 1 is not confirmed, the listing is bound by slot")
+	assert.Equal(t, solana.Signature{4}, sub.confirmed())
+	assert.Equal(t, []solana.Signature{{1}, {2}, {4}, {5}}, handled, "failed transactions are skipped and overlaps delivered once")
+
+	slot, signature := sub.position()
+	assert.Equal(t, uint64(105), slot)
+	assert.Equal(t, solana.Signature{5}, signature)
+}
+
+func TestLogSubscriptionBackfillsGapsOnly(t *testing.T) {
+	var listings []rpc.GetSignaturesForAddressOpts
+	var fetched []solana.Signature
+	listed := []*rpc.TransactionSignature{
+		{Signature: solana.Signature{7}, Slot: 3000},
+		{Signature: solana.Signature{6}, Slot: 2000},
+		// More than backfillSlots before the newest transaction
+		{Signature: solana.Signature{5}, Slot: 1000},
+	}
+	client := &utils.MockRPCClient{
+		MockGetSignaturesForAddress: func(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+			listings = append(listings, *opts)
+			return listed, nil
+		},
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			fetched = append(fetched, signature)
+			return &rpc.GetTransactionResult{Slot: 3000, Meta: &rpc.TransactionMeta{}}, nil
+		},
+	}
+	sub := newLogSubscription("test", "", solana.SolMint, client)
+	var handled []solana.Signature
+	handle := func(ctx context.Context, logMsg *ws.LogResult) {
+		handled = append(handled, logMsg.Value.Signature)
+	}
+	sub.dispatch(context.Background(), notification(1, 900), handle)
+
+	require.NoError(t, sub.backfill(context.Background(), handle))
+	assert.Equal(t, []solana.Signature{{6}, {7}}, fetched, "the backfill is bound to the last backfillSlots slots")
+	assert.Equal(t, solana.Signature{7}, sub.confirmed())
+
+	// The newest confirmed transaction was processed: there is no gap and nothing is fetched
+	fetched = nil
+	require.NoError(t, sub.backfill(context.Background(), handle))
+	assert.Empty(t, fetched)
+	require.Len(t, listings, 2)
+	assert.Equal(t, solana.Signature{7}, listings[1].Until, "the listing stops at the last confirmed signature")
+
+	// A live notification of a transaction listed afterwards is not handled twice
+	listed = append([]*rpc.TransactionSignature{{Signature: solana.Signature{8}, Slot: 3001}}, listed...)
+	require.NoError(t, sub.backfill(context.Background(), handle))
+	sub.dispatch(context.Background(), notification(8, 3001), handle)
+	assert.Equal(t, []solana.Signature{{1}, {6}, {7}, {8}}, handled)
+}
+
+func TestLogSubscriptionClose(t *testing.T) {
+	sub := newLogSubscription("test", "", solana.SolMint, &utils.MockRPCClient{})
+	stream := &fakeStream{last: true, closed: make(chan struct{})}
+	var connects int
+	sub.subscribe = func(ctx context.Context) (logStream, func(), error) {
+		connects++
+		return stream, func() { close(stream.closed) }, nil
+	}
+
+	done := make(chan error)
+	go func() { done <- sub.Run(context.Background(), func(context.Context, *ws.LogResult) {}) }()
+
+	require.Eventually(t, func() bool {
+		sub.mu.Lock()
+		defer sub.mu.Unlock()
+		return sub.closeConn != nil
+	}, time.Second, time.Millisecond)
+	sub.Close()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("closed subscription kept running")
+	}
+	assert.Equal(t, 1, connects, "a closed subscription does not reconnect")
+}
+
+func TestSignatureSetEvictsOldest(t *testing.T) {
+	set := newSignatureSet(2)
+	assert.True(t, set.add(solana.Signature{1}))
+	assert.True(t, set.add(solana.Signature{2}))
+	assert.False(t, set.add(solana.Signature{1}))
+	assert.True(t, set.add(solana.Signature{3}))
+	assert.False(t, set.contains(solana.Signature{1}))
+	assert.True(t, set.contains(solana.Signature{2}))
+	assert.True(t, set.contains(solana.Signature{3}))
+}
@@ -0,0 +1,71 @@
+package listeners
+
+import (
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/moonshot"
+	"corvus_bot/pkg/pumpfun"
+	"corvus_bot/pkg/raydium/parse"
+	"corvus_bot/pkg/raydium/pool/clmm"
+)
+
+// Names of the event bus topics the listeners publish on. Pool births block the listener rather than being
+// lost; the high volume streams keep the latest events when a subscriber falls behind.
+const (
+	TopicAMMPools     = "raydium.amm.pools"
+	TopicAMMSwaps     = "raydium.amm.swaps"
+	TopicAMMLiquidity = "raydium.amm.liquidity"
+	TopicCLMMEvents   = "raydium.clmm.events"
+	TopicCLMMPools    = "raydium.clmm.pools"
+
+	TopicPumpFunEvents      = "pumpfun.events"
+	TopicPumpFunCompletions = "pumpfun.completions"
+
+	TopicMoonshotEvents     = "moonshot.events"
+	TopicMoonshotMigrations = "moonshot.migrations"
+)
+
+// AMMPoolsTopic returns the topic of AMM pools parsed from initialize2
+func AMMPoolsTopic(bus *eventbus.Bus) (*eventbus.Topic[*parse.ParsedAMMPool], error) {
+	return eventbus.Register[*parse.ParsedAMMPool](bus, TopicAMMPools, eventbus.Block)
+}
+
+// AMMSwapsTopic returns the topic of AMM swaps decoded from ray_log lines
+func AMMSwapsTopic(bus *eventbus.Bus) (*eventbus.Topic[*parse.SwapEvent], error) {
+	return eventbus.Register[*parse.SwapEvent](bus, TopicAMMSwaps, eventbus.DropOldest)
+}
+
+// AMMLiquidityTopic returns the topic of AMM deposits and withdrawals decoded from ray_log lines
+func AMMLiquidityTopic(bus *eventbus.Bus) (*eventbus.Topic[*parse.LiquidityEvent], error) {
+	return eventbus.Register[*parse.LiquidityEvent](bus, TopicAMMLiquidity, eventbus.DropOldest)
+}
+
+// CLMMEventsTopic returns the topic of every event emitted by the CLMM program
+func CLMMEventsTopic(bus *eventbus.Bus) (*eventbus.Topic[*parse.CLMMEvent], error) {
+	return eventbus.Register[*parse.CLMMEvent](bus, TopicCLMMEvents, eventbus.DropOldest)
+}
+
+// CLMMPoolsTopic returns the topic of newly created CLMM pools
+func CLMMPoolsTopic(bus *eventbus.Bus) (*eventbus.Topic[*clmm.RaydiumClmmPool], error) {
+	return eventbus.Register[*clmm.RaydiumClmmPool](bus, TopicCLMMPools, eventbus.Block)
+}
+
+// PumpFunEventsTopic returns the topic of every create, trade and complete event emitted by pump.fun
+func PumpFunEventsTopic(bus *eventbus.Bus) (*eventbus.Topic[*pumpfun.Event], error) {
+	return eventbus.Register[*pumpfun.Event](bus, TopicPumpFunEvents, eventbus.DropOldest)
+}
+
+// PumpFunCompletionsTopic returns the topic of pump.fun CompleteEvents, the bonding curves ready to migrate
+func PumpFunCompletionsTopic(bus *eventbus.Bus) (*eventbus.Topic[*pumpfun.Event], error) {
+	return eventbus.Register[*pumpfun.Event](bus, TopicPumpFunCompletions, eventbus.Block)
+}
+
+// MoonshotEventsTopic returns the topic of every trade and migration event emitted by Moonshot
+func MoonshotEventsTopic(bus *eventbus.Bus) (*eventbus.Topic[*moonshot.Event], error) {
+	return eventbus.Register[*moonshot.Event](bus, TopicMoonshotEvents, eventbus.DropOldest)
+}
+
+// MoonshotMigrationsTopic returns the topic of Moonshot curves that migrated, with the mint and curve read
+// from their migrateFunds transaction
+func MoonshotMigrationsTopic(bus *eventbus.Bus) (*eventbus.Topic[*moonshot.Migration], error) {
+	return eventbus.Register[*moonshot.Migration](bus, TopicMoonshotMigrations, eventbus.Block)
+}
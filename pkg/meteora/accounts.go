@@ -0,0 +1,198 @@
+// Package meteora reads Meteora DLMM pairs and their bin arrays, quotes swaps bin by bin with the
+// program's fee model, builds swap instructions and maps pairs to the database model.
+package meteora
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"corvus_bot/pkg/idl/bindings/meteoradlmm"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultProgramID is the DLMM (lb_clmm) program on mainnet and devnet
+	DefaultProgramID = "LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo"
+
+	// BinArraySeed is the PDA seed of a bin array, followed by the pair and the array index as a little endian i64
+	BinArraySeed = "bin_array"
+
+	// EventAuthoritySeed is the PDA seed of the account the program emits its events through
+	EventAuthoritySeed = "__event_authority"
+
+	// MaxBinPerArray is the number of bins in a bin array
+	MaxBinPerArray = 70
+
+	// PairStatusEnabled is the LbPair status of a pair open to swaps
+	PairStatusEnabled = 0
+
+	// tokenProgram2022Flag marks a Token-2022 mint in LbPair.TokenMintXProgramFlag and TokenMintYProgramFlag
+	tokenProgram2022Flag = 1
+
+	// mintDecimalsOffset is the offset of the decimals in SPL Token and Token-2022 mint accounts
+	mintDecimalsOffset = 44
+
+	// tokenAmountOffset is the offset of the amount in SPL Token and Token-2022 token accounts
+	tokenAmountOffset = 64
+)
+
+type (
+	// LbPair is the state of a pair: its mints and reserves, fee parameters, active bin and bin array bitmap
+	LbPair = meteoradlmm.LbPair
+
+	// BinArray holds MaxBinPerArray consecutive bins of a pair, starting at Index * MaxBinPerArray
+	BinArray = meteoradlmm.BinArray
+
+	// Bin is the liquidity of both tokens at a single price
+	Bin = meteoradlmm.Bin
+)
+
+// Pool is a DLMM pair with the mint details and reserve balances the account does not record
+type Pool struct {
+	Address       solana.PublicKey
+	ProgramID     solana.PublicKey
+	State         *LbPair
+	DecimalsX     uint8
+	DecimalsY     uint8
+	TokenProgramX solana.PublicKey // Token or Token-2022, the owner of mint X
+	TokenProgramY solana.PublicKey
+	ReserveX      uint64 // Balance of the X reserve, in base units
+	ReserveY      uint64
+	ActiveBin     *Bin // Nil when the bin array of the active bin was never initialized
+}
+
+// DecodeLbPair decodes the data of an LbPair account
+func DecodeLbPair(data []byte) (*LbPair, error) {
+	return meteoradlmm.DecodeLbPair(data)
+}
+
+// DecodeBinArray decodes the data of a BinArray account
+func DecodeBinArray(data []byte) (*BinArray, error) {
+	return meteoradlmm.DecodeBinArray(data)
+}
+
+// FindBinArrayAddress derives the bin array of a pair with the given index
+func FindBinArrayAddress(programID, pair solana.PublicKey, index int64) (solana.PublicKey, error) {
+	indexBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(indexBytes, uint64(index))
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(BinArraySeed), pair.Bytes(), indexBytes}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive bin array address for %d: %w", index, err)
+	}
+	return address, nil
+}
+
+// FindEventAuthorityAddress derives the event authority every swap passes to the program
+func FindEventAuthorityAddress(programID solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(EventAuthoritySeed)}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive event authority address: %w", err)
+	}
+	return address, nil
+}
+
+// BinArrayIndex returns the index of the bin array holding binID
+func BinArrayIndex(binID int32) int64 {
+	index := int64(binID) / MaxBinPerArray
+	if binID < 0 && binID%MaxBinPerArray != 0 {
+		index--
+	}
+	return index
+}
+
+// FetchPool reads a pair, its two mints, its reserves and its active bin
+func FetchPool(ctx context.Context, client utils.RPCClientInterface, programID, address solana.PublicKey) (*Pool, error) {
+	accounts, err := utils.FetchAccounts(ctx, client, []solana.PublicKey{address})
+	if err != nil {
+		return nil, err
+	}
+	if accounts[0] == nil {
+		return nil, fmt.Errorf("lb pair %s not found", address)
+	}
+	if !accounts[0].Owner.Equals(programID) {
+		return nil, fmt.Errorf("lb pair %s is owned by %s, not %s", address, accounts[0].Owner, programID)
+	}
+	state, err := DecodeLbPair(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode lb pair %s: %w", address, err)
+	}
+
+	pool := &Pool{Address: address, ProgramID: programID, State: state}
+	if err := pool.refresh(ctx, client); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+// refresh reads the mints, the reserve balances and the active bin of the pair
+func (p *Pool) refresh(ctx context.Context, client utils.RPCClientInterface) error {
+	activeIndex := BinArrayIndex(p.State.ActiveId)
+	activeArray, err := FindBinArrayAddress(p.ProgramID, p.Address, activeIndex)
+	if err != nil {
+		return err
+	}
+	keys := []solana.PublicKey{p.State.TokenXMint, p.State.TokenYMint, p.State.ReserveX, p.State.ReserveY, activeArray}
+	accounts, err := utils.FetchAccounts(ctx, client, keys)
+	if err != nil {
+		return err
+	}
+	for k, account := range accounts[:4] {
+		if account == nil {
+			return fmt.Errorf("account %s of lb pair %s not found", keys[k], p.Address)
+		}
+	}
+
+	mintX, mintY := accounts[0].Data.GetBinary(), accounts[1].Data.GetBinary()
+	reserveX, reserveY := accounts[2].Data.GetBinary(), accounts[3].Data.GetBinary()
+	if len(mintX) <= mintDecimalsOffset || len(mintY) <= mintDecimalsOffset {
+		return fmt.Errorf("invalid mint data for lb pair %s", p.Address)
+	}
+	if len(reserveX) < tokenAmountOffset+8 || len(reserveY) < tokenAmountOffset+8 {
+		return fmt.Errorf("invalid reserve data for lb pair %s", p.Address)
+	}
+	p.DecimalsX, p.TokenProgramX = mintX[mintDecimalsOffset], accounts[0].Owner
+	p.DecimalsY, p.TokenProgramY = mintY[mintDecimalsOffset], accounts[1].Owner
+	p.ReserveX = binary.LittleEndian.Uint64(reserveX[tokenAmountOffset:])
+	p.ReserveY = binary.LittleEndian.Uint64(reserveY[tokenAmountOffset:])
+
+	p.ActiveBin = nil
+	if accounts[4] != nil {
+		array, err := DecodeBinArray(accounts[4].Data.GetBinary())
+		if err != nil {
+			return fmt.Errorf("failed to decode bin array %s: %w", activeArray, err)
+		}
+		p.ActiveBin = &array.Bins[p.State.ActiveId-int32(activeIndex*MaxBinPerArray)]
+	}
+	return nil
+}
+
+// FetchBinArrays reads the bin arrays of a pair with the given indexes. Arrays that were never initialized
+// are returned as nil.
+func FetchBinArrays(ctx context.Context, client utils.RPCClientInterface, programID, pair solana.PublicKey, indexes []int64) ([]*BinArray, error) {
+	addresses := make([]solana.PublicKey, len(indexes))
+	for k, index := range indexes {
+		address, err := FindBinArrayAddress(programID, pair, index)
+		if err != nil {
+			return nil, err
+		}
+		addresses[k] = address
+	}
+
+	accounts, err := utils.FetchAccounts(ctx, client, addresses)
+	if err != nil {
+		return nil, err
+	}
+	arrays := make([]*BinArray, len(accounts))
+	for k, account := range accounts {
+		if account == nil {
+			continue
+		}
+		if arrays[k], err = DecodeBinArray(account.Data.GetBinary()); err != nil {
+			return nil, fmt.Errorf("failed to decode bin array %s: %w", addresses[k], err)
+		}
+	}
+	return arrays, nil
+}
@@ -0,0 +1,153 @@
+package meteora
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const (
+	// BasisPointMax is the denominator of bin steps, protocol shares and reduction factors
+	BasisPointMax = 10_000
+
+	// FeePrecision is the denominator of fee rates: a rate of 10_000_000 is 1%
+	FeePrecision = 1_000_000_000
+
+	// MaxFeeRate caps the total fee rate of a swap at 10%
+	MaxFeeRate = 100_000_000
+
+	// MinBinID and MaxBinID bound the active bin of a pair
+	MinBinID int32 = -443636
+	MaxBinID int32 = 443636
+
+	// bitmapHalfRange is the number of bin arrays on each side of zero covered by LbPair.BinArrayBitmap
+	bitmapHalfRange = 512
+
+	// maxExponent bounds the bin IDs the program's pow accepts
+	maxExponent = 0x80000
+)
+
+var (
+	one64   = new(big.Int).Lsh(big.NewInt(1), 64)
+	maxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	bigOne  = big.NewInt(1)
+)
+
+// PriceAtBin returns (1 + binStep / BasisPointMax)^binID in Q64.64, rounded exactly like the program
+func PriceAtBin(binID int32, binStep uint16) (*big.Int, error) {
+	if binID == 0 {
+		return new(big.Int).Set(one64), nil
+	}
+	base := new(big.Int).Lsh(big.NewInt(int64(binStep)), 64)
+	base.Quo(base, big.NewInt(BasisPointMax)).Add(base, one64)
+
+	invert := binID < 0
+	exp := int64(binID)
+	if invert {
+		exp = -exp
+	}
+	if exp >= maxExponent {
+		return nil, fmt.Errorf("bin %d out of range", binID)
+	}
+
+	// Squaring a base below one cannot overflow, so bases above one are inverted first
+	squared := new(big.Int).Set(base)
+	if squared.Cmp(one64) >= 0 {
+		squared.Quo(maxU128, squared)
+		invert = !invert
+	}
+	result := new(big.Int).Set(one64)
+	for bit := int64(1); bit < maxExponent; bit <<= 1 {
+		if exp&bit != 0 {
+			result.Mul(result, squared).Rsh(result, 64)
+		}
+		squared.Mul(squared, squared).Rsh(squared, 64)
+	}
+	if result.Sign() == 0 {
+		return nil, fmt.Errorf("price of bin %d underflows", binID)
+	}
+	if invert {
+		result.Quo(maxU128, result)
+	}
+	if result.Cmp(maxU128) > 0 {
+		return nil, fmt.Errorf("price of bin %d overflows", binID)
+	}
+	return result, nil
+}
+
+// PriceX64ToPrice converts a Q64.64 bin price to the price of one whole token X in token Y
+func PriceX64ToPrice(priceX64 *big.Int, decimalsX, decimalsY uint8) float64 {
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(priceX64), new(big.Float).SetInt(one64)).Float64()
+	for k := uint8(0); k < decimalsX; k++ {
+		price *= 10
+	}
+	for k := uint8(0); k < decimalsY; k++ {
+		price /= 10
+	}
+	return price
+}
+
+// BaseFeeRate returns the fee rate charged whatever the volatility, in FeePrecision units
+func BaseFeeRate(pair *LbPair) uint64 {
+	rate := uint64(pair.Parameters.BaseFactor) * uint64(pair.BinStep) * 10
+	for k := uint8(0); k < pair.Parameters.BaseFeePowerFactor; k++ {
+		rate *= 10
+	}
+	return rate
+}
+
+// VariableFeeRate returns the volatility fee rate for the given volatility accumulator, in FeePrecision units
+func VariableFeeRate(pair *LbPair, volatilityAccumulator uint32) uint64 {
+	if pair.Parameters.VariableFeeControl == 0 {
+		return 0
+	}
+	v := new(big.Int).SetUint64(uint64(volatilityAccumulator) * uint64(pair.BinStep))
+	v.Mul(v, v).Mul(v, big.NewInt(int64(pair.Parameters.VariableFeeControl)))
+	// Scaled down to FeePrecision, rounding up
+	v.Add(v, big.NewInt(99_999_999_999)).Quo(v, big.NewInt(100_000_000_000))
+	return v.Uint64()
+}
+
+// TotalFeeRate returns the fee rate of a swap at the given volatility accumulator, capped at MaxFeeRate
+func TotalFeeRate(pair *LbPair, volatilityAccumulator uint32) uint64 {
+	rate := BaseFeeRate(pair) + VariableFeeRate(pair, volatilityAccumulator)
+	if rate > MaxFeeRate {
+		return MaxFeeRate
+	}
+	return rate
+}
+
+// feeOnAmount is the fee to add to an amount that excludes it, rounded up
+func feeOnAmount(amount *big.Int, feeRate uint64) *big.Int {
+	denominator := big.NewInt(int64(FeePrecision - feeRate))
+	return ceilDiv(new(big.Int).Mul(amount, new(big.Int).SetUint64(feeRate)), denominator)
+}
+
+// feeFromAmount is the fee included in an amount, rounded up
+func feeFromAmount(amountWithFee *big.Int, feeRate uint64) *big.Int {
+	return ceilDiv(new(big.Int).Mul(amountWithFee, new(big.Int).SetUint64(feeRate)), big.NewInt(FeePrecision))
+}
+
+// nextBinArrayWithLiquidity searches the pair's bitmap from bin array index, inclusive, towards lower
+// indexes when swapping X for Y and higher ones otherwise
+func nextBinArrayWithLiquidity(bitmap [16]uint64, index int64, swapForY bool) (int64, bool) {
+	for index >= -bitmapHalfRange && index < bitmapHalfRange {
+		offset := index + bitmapHalfRange
+		if bitmap[offset/64]&(1<<(offset%64)) != 0 {
+			return index, true
+		}
+		if swapForY {
+			index--
+		} else {
+			index++
+		}
+	}
+	return 0, false
+}
+
+func ceilDiv(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		quotient.Add(quotient, bigOne)
+	}
+	return quotient
+}
@@ -0,0 +1,87 @@
+package meteora
+
+import (
+	"fmt"
+
+	"corvus_bot/pkg/idl/bindings/meteoradlmm"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// NewSwapInstruction builds the swap matching the quote, trading from and to the user's associated token
+// accounts. The bin arrays of the quote are passed as remaining accounts; no host fee account is set.
+func NewSwapInstruction(pool *Pool, quote *SwapQuote, user solana.PublicKey) (solana.Instruction, error) {
+	if pool == nil || pool.State == nil {
+		return nil, fmt.Errorf("pool data is nil")
+	}
+	if quote == nil {
+		return nil, fmt.Errorf("quote is nil")
+	}
+	if len(quote.BinArrays) == 0 {
+		return nil, fmt.Errorf("swap needs at least one bin array")
+	}
+
+	tokenProgramX := tokenProgram(pool.TokenProgramX, pool.State.TokenMintXProgramFlag)
+	tokenProgramY := tokenProgram(pool.TokenProgramY, pool.State.TokenMintYProgramFlag)
+	userTokenX, err := associatedTokenAddress(user, pool.State.TokenXMint, tokenProgramX)
+	if err != nil {
+		return nil, err
+	}
+	userTokenY, err := associatedTokenAddress(user, pool.State.TokenYMint, tokenProgramY)
+	if err != nil {
+		return nil, err
+	}
+	userTokenIn, userTokenOut := userTokenX, userTokenY
+	if !quote.SwapForY {
+		userTokenIn, userTokenOut = userTokenY, userTokenX
+	}
+	eventAuthority, err := FindEventAuthorityAddress(pool.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := make([]*solana.AccountMeta, len(quote.BinArrays))
+	for k, binArray := range quote.BinArrays {
+		remaining[k] = solana.NewAccountMeta(binArray, true, false)
+	}
+	return meteoradlmm.NewSwapInstruction(pool.ProgramID,
+		meteoradlmm.SwapArgs{AmountIn: quote.AmountIn, MinAmountOut: quote.MinAmountOut},
+		meteoradlmm.SwapAccounts{
+			LbPair:         pool.Address,
+			ReserveX:       pool.State.ReserveX,
+			ReserveY:       pool.State.ReserveY,
+			UserTokenIn:    userTokenIn,
+			UserTokenOut:   userTokenOut,
+			TokenXMint:     pool.State.TokenXMint,
+			TokenYMint:     pool.State.TokenYMint,
+			Oracle:         pool.State.Oracle,
+			User:           user,
+			TokenXProgram:  tokenProgramX,
+			TokenYProgram:  tokenProgramY,
+			EventAuthority: eventAuthority,
+			Program:        pool.ProgramID,
+		},
+		remaining...)
+}
+
+// tokenProgram returns the owner of a mint, falling back to the program flag the pair records for it
+func tokenProgram(program solana.PublicKey, flag uint8) solana.PublicKey {
+	if !program.IsZero() {
+		return program
+	}
+	if flag == tokenProgram2022Flag {
+		return solana.Token2022ProgramID
+	}
+	return solana.TokenProgramID
+}
+
+func associatedTokenAddress(owner, mint, tokenProgram solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress(
+		[][]byte{owner[:], tokenProgram[:], mint[:]},
+		solana.SPLAssociatedTokenAccountProgramID,
+	)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive token account for mint %s: %w", mint, err)
+	}
+	return address, nil
+}
@@ -0,0 +1,149 @@
+package meteora
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/database/models"
+	"corvus_bot/pkg/idl/bindings/meteoradlmm"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSwapInstruction(t *testing.T) {
+	pool, arrays := newTestPool()
+	user := solana.NewWallet().PublicKey()
+	quote, err := QuoteWithState(pool, swapState(pool, arrays), pool.State.TokenXMint, 2_500_000_000, 50, testNow)
+	require.NoError(t, err)
+
+	instruction, err := NewSwapInstruction(pool, quote, user)
+	require.NoError(t, err)
+	assert.Equal(t, pool.ProgramID, instruction.ProgramID())
+
+	data, err := instruction.Data()
+	require.NoError(t, err)
+	args, err := meteoradlmm.DecodeSwapArgs(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2_500_000_000), args.AmountIn)
+	assert.Equal(t, quote.MinAmountOut, args.MinAmountOut)
+
+	metas := instruction.Accounts()
+	require.Len(t, metas, 15+len(quote.BinArrays))
+	keys := make([]solana.PublicKey, len(metas))
+	for k, meta := range metas {
+		keys[k] = meta.PublicKey
+	}
+	accounts, err := meteoradlmm.DecodeSwapAccounts(keys)
+	require.NoError(t, err)
+	userTokenX, _, err := solana.FindAssociatedTokenAddress(user, pool.State.TokenXMint)
+	require.NoError(t, err)
+	userTokenY, _, err := solana.FindAssociatedTokenAddress(user, pool.State.TokenYMint)
+	require.NoError(t, err)
+	eventAuthority, err := FindEventAuthorityAddress(pool.ProgramID)
+	require.NoError(t, err)
+
+	assert.Equal(t, pool.Address, accounts.LbPair)
+	assert.Equal(t, pool.ProgramID, accounts.BinArrayBitmapExtension, "omitted optional account")
+	assert.Equal(t, userTokenX, accounts.UserTokenIn)
+	assert.Equal(t, userTokenY, accounts.UserTokenOut)
+	assert.Equal(t, pool.State.Oracle, accounts.Oracle)
+	assert.Equal(t, pool.ProgramID, accounts.HostFeeIn)
+	assert.Equal(t, user, accounts.User)
+	assert.Equal(t, eventAuthority, accounts.EventAuthority)
+	assert.True(t, metas[10].IsSigner)
+	for k, binArray := range quote.BinArrays {
+		assert.Equal(t, binArray, metas[15+k].PublicKey)
+		assert.True(t, metas[15+k].IsWritable)
+	}
+
+	_, err = NewSwapInstruction(pool, &SwapQuote{}, user)
+	assert.Error(t, err, "no bin array")
+}
+
+func TestTokenProgramFallback(t *testing.T) {
+	assert.Equal(t, solana.TokenProgramID, tokenProgram(solana.PublicKey{}, 0))
+	assert.Equal(t, solana.Token2022ProgramID, tokenProgram(solana.PublicKey{}, tokenProgram2022Flag))
+	assert.Equal(t, solana.Token2022ProgramID, tokenProgram(solana.Token2022ProgramID, 0))
+}
+
+func TestFetchPool(t *testing.T) {
+	pool, arrays := newTestPool()
+	arrays[0].Bins[0].FeeAmountXPerTokenStored.Lo = 42
+	client := newTestClient(t, pool, arrays)
+
+	fetched, err := FetchPool(context.Background(), client, pool.ProgramID, pool.Address)
+	require.NoError(t, err)
+	assert.Equal(t, pool.State, fetched.State)
+	assert.Equal(t, uint8(9), fetched.DecimalsX)
+	assert.Equal(t, uint8(6), fetched.DecimalsY)
+	assert.Equal(t, solana.TokenProgramID, fetched.TokenProgramX)
+	assert.Equal(t, uint64(5_000_000_000), fetched.ReserveX)
+	assert.Equal(t, uint64(4_000_000_000), fetched.ReserveY)
+	require.NotNil(t, fetched.ActiveBin)
+	assert.Equal(t, uint64(1_000_000_000), fetched.ActiveBin.AmountX)
+
+	_, err = FetchPool(context.Background(), client, solana.NewWallet().PublicKey(), pool.Address)
+	assert.ErrorContains(t, err, "owned by")
+	_, err = FetchPool(context.Background(), client, pool.ProgramID, solana.NewWallet().PublicKey())
+	assert.ErrorContains(t, err, "not found")
+
+	model, err := fetched.Model()
+	require.NoError(t, err)
+	assert.Equal(t, "42", model.MeteoraData.FeeGrowthBase)
+	assert.Equal(t, "0", model.MeteoraData.FeeGrowthQuote)
+}
+
+func TestPoolModel(t *testing.T) {
+	pool, _ := newTestPool()
+
+	model, err := pool.Model()
+	require.NoError(t, err)
+	assert.Equal(t, pool.Address.String(), model.ID)
+	assert.Equal(t, models.ProtocolMeteora, model.Protocol)
+	assert.Equal(t, models.PoolTypeDLMM, model.Type)
+	assert.Equal(t, models.PoolStatusActive, model.Status)
+	assert.Equal(t, DefaultProgramID, model.ProgramID)
+	assert.Equal(t, pool.State.TokenXMint.String(), model.BaseMint)
+	assert.Equal(t, pool.State.TokenYMint.String(), model.QuoteMint)
+	assert.Equal(t, pool.State.ReserveX.String(), model.BaseVault)
+
+	assert.Equal(t, uint32(1_000_000), model.Config.FeeRate)
+	assert.Equal(t, uint16(10), *model.Config.TradeFeeBps)
+	assert.Equal(t, uint16(500), *model.Config.ProtocolFeeBps)
+	// Price 1 in base units is 1000 Y per X with 9 and 6 decimals
+	assert.InDelta(t, 1000, model.MarketState.Price, 1e-9)
+	assert.InDelta(t, 5, model.MarketState.BaseReserve, 1e-12)
+	assert.InDelta(t, 4000, model.MarketState.QuoteReserve, 1e-9)
+	assert.InDelta(t, 9000, model.MarketState.TVL, 1e-6)
+	assert.Equal(t, int64(0), *model.MarketState.CurrentTick)
+
+	require.NotNil(t, model.MeteoraData)
+	assert.True(t, model.MeteoraData.SwapEnabled)
+	assert.Equal(t, uint32(1_000_000), model.MeteoraData.LiquidityFee)
+	assert.Equal(t, uint32(500), model.MeteoraData.ProtocolFee)
+	assert.Empty(t, model.MeteoraData.FeeGrowthBase, "active bin not loaded")
+	assert.Equal(t, uint8(9), model.MeteoraData.BaseDecimals)
+	assert.Equal(t, uint8(6), model.MeteoraData.QuoteDecimals)
+
+	pool.State.Status = 1
+	model, err = pool.Model()
+	require.NoError(t, err)
+	assert.False(t, model.MeteoraData.SwapEnabled)
+	assert.Equal(t, models.PoolStatusInactive, model.Status)
+}
+
+func TestPoolMetric(t *testing.T) {
+	pool, _ := newTestPool()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	metric, err := pool.Metric(now)
+	require.NoError(t, err)
+	assert.Equal(t, pool.Address.String(), metric.PoolID)
+	assert.Equal(t, now, metric.Timestamp)
+	assert.InDelta(t, 1000, metric.Price, 1e-9)
+	assert.InDelta(t, 5, metric.BaseReserve, 1e-12)
+	assert.InDelta(t, 9000, metric.TVL, 1e-6)
+}
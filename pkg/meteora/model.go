@@ -0,0 +1,98 @@
+package meteora
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"corvus_bot/pkg/database/models"
+	"corvus_bot/pkg/utils"
+)
+
+// Model maps the pool to the database model. Token X is stored as the base and token Y as the quote, with
+// fee rates in FeePrecision units and the active bin as the current tick.
+func (p *Pool) Model() (*models.Pool, error) {
+	state := p.State
+	price, err := p.SpotPrice()
+	if err != nil {
+		return nil, err
+	}
+	baseReserve, quoteReserve := p.reserves()
+	baseFeeRate := BaseFeeRate(state)
+	tradeFeeBps := uint16(baseFeeRate / (FeePrecision / utils.BpsDenominator))
+	protocolFeeBps := state.Parameters.ProtocolShare
+	mintX, mintY := state.TokenXMint.String(), state.TokenYMint.String()
+	activeID := int64(state.ActiveId)
+
+	pool := &models.Pool{
+		Config: models.PoolConfig{
+			Decimals:       p.DecimalsX,
+			FeeRate:        uint32(baseFeeRate),
+			TradeFeeBps:    &tradeFeeBps,
+			ProtocolFeeBps: &protocolFeeBps,
+			TokenMintA:     &mintX,
+			TokenMintB:     &mintY,
+			SpotPrice:      &price,
+		},
+		MarketState: models.MarketState{
+			BaseReserve:  baseReserve,
+			QuoteReserve: quoteReserve,
+			Price:        price,
+			TVL:          baseReserve*price + quoteReserve,
+			CurrentTick:  &activeID,
+		},
+		BaseVault:  state.ReserveX.String(),
+		QuoteVault: state.ReserveY.String(),
+		MeteoraData: &models.MeteoraPoolData{
+			SwapEnabled:   state.Status == PairStatusEnabled,
+			LiquidityFee:  uint32(baseFeeRate),
+			ProtocolFee:   uint32(protocolFeeBps),
+			BaseDecimals:  p.DecimalsX,
+			QuoteDecimals: p.DecimalsY,
+		},
+	}
+	// Fee growth is tracked per bin: the fees earned per liquidity share of the active bin
+	if p.ActiveBin != nil {
+		pool.MeteoraData.FeeGrowthBase = p.ActiveBin.FeeAmountXPerTokenStored.BigInt().String()
+		pool.MeteoraData.FeeGrowthQuote = p.ActiveBin.FeeAmountYPerTokenStored.BigInt().String()
+	}
+	if state.Status != PairStatusEnabled {
+		pool.Status = models.PoolStatusInactive
+	} else {
+		pool.Status = models.PoolStatusActive
+	}
+	pool.LastUpdated = time.Now().UTC()
+	pool.ID = p.Address.String()
+	pool.Protocol = models.ProtocolMeteora
+	pool.Type = models.PoolTypeDLMM
+	pool.ProgramID = p.ProgramID.String()
+	pool.BaseMint = mintX
+	pool.QuoteMint = mintY
+	return pool, nil
+}
+
+// Metric returns a snapshot of the pool's reserves and price taken at now
+func (p *Pool) Metric(now time.Time) (*models.PoolMetric, error) {
+	price, err := p.SpotPrice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to price lb pair %s: %w", p.Address, err)
+	}
+	baseReserve, quoteReserve := p.reserves()
+
+	metric := &models.PoolMetric{
+		PoolID:       p.Address.String(),
+		BaseReserve:  baseReserve,
+		QuoteReserve: quoteReserve,
+		Price:        price,
+		TVL:          baseReserve*price + quoteReserve,
+	}
+	metric.BaseMetric.PoolID = metric.PoolID
+	metric.Timestamp = now.UTC()
+	metric.LastFetched = now.UTC()
+	return metric, nil
+}
+
+// reserves returns the reserve balances in whole tokens
+func (p *Pool) reserves() (float64, float64) {
+	return float64(p.ReserveX) / math.Pow10(int(p.DecimalsX)), float64(p.ReserveY) / math.Pow10(int(p.DecimalsY))
+}
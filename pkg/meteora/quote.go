@@ -0,0 +1,176 @@
+package meteora
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultBinArrayCount is the number of bin arrays with liquidity Quote loads in the swap direction
+	DefaultBinArrayCount = 4
+)
+
+// SwapQuote is the expected result of an exact input swap against the current pair state
+type SwapQuote struct {
+	InputMint     solana.PublicKey
+	OutputMint    solana.PublicKey
+	SwapForY      bool    // True when selling token X for token Y
+	AmountIn      uint64  // Input amount, including the fee
+	AmountOut     uint64  // Expected output amount
+	MinAmountOut  uint64  // AmountOut reduced by the slippage tolerance
+	Fee           uint64  // Fee paid, in input token units
+	ProtocolFee   uint64  // Part of Fee kept by the protocol
+	PriceImpact   float64 // Fraction of the active bin price lost to the trade size, e.g. 0.01 for 1%
+	ActiveIDAfter int32
+	SlippageBps   uint64
+
+	// BinArrays are the bin arrays the swap reads, passed as remaining accounts in traversal order
+	BinArrays []solana.PublicKey
+}
+
+// Quote returns the expected output of swapping exactly amountIn of inputMint, using the live pair state
+func Quote(ctx context.Context, client utils.RPCClientInterface, pool *Pool, inputMint solana.PublicKey, amountIn, slippageBps uint64) (*SwapQuote, error) {
+	swapForY, err := swapDirection(pool.State, inputMint)
+	if err != nil {
+		return nil, err
+	}
+	state, err := FetchSwapState(ctx, client, pool, swapForY, DefaultBinArrayCount)
+	if err != nil {
+		return nil, err
+	}
+	return QuoteWithState(pool, state, inputMint, amountIn, slippageBps, time.Now().Unix())
+}
+
+// FetchSwapState reloads the pair and up to count of its bin arrays with liquidity in the swap direction
+func FetchSwapState(ctx context.Context, client utils.RPCClientInterface, pool *Pool, swapForY bool, count int) (*SwapState, error) {
+	accounts, err := utils.FetchAccounts(ctx, client, []solana.PublicKey{pool.Address})
+	if err != nil {
+		return nil, err
+	}
+	if accounts[0] == nil {
+		return nil, fmt.Errorf("lb pair %s not found", pool.Address)
+	}
+	pair, err := DecodeLbPair(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode lb pair %s: %w", pool.Address, err)
+	}
+
+	indexes := SwapBinArrayIndexes(pair, swapForY, count)
+	if len(indexes) == 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	arrays, err := FetchBinArrays(ctx, client, pool.ProgramID, pool.Address, indexes)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapState{Pair: pair, BinArrays: arrays}, nil
+}
+
+// SwapBinArrayIndexes returns the indexes of the first count bin arrays with liquidity a swap from the
+// active bin traverses, according to the pair's bitmap
+func SwapBinArrayIndexes(pair *LbPair, swapForY bool, count int) []int64 {
+	var indexes []int64
+	index := BinArrayIndex(pair.ActiveId)
+	for len(indexes) < count {
+		next, ok := nextBinArrayWithLiquidity(pair.BinArrayBitmap, index, swapForY)
+		if !ok {
+			break
+		}
+		indexes = append(indexes, next)
+		if swapForY {
+			index = next - 1
+		} else {
+			index = next + 1
+		}
+	}
+	return indexes
+}
+
+// QuoteWithState quotes an exact input swap against an already loaded state at unix time now
+func QuoteWithState(pool *Pool, state *SwapState, inputMint solana.PublicKey, amountIn, slippageBps uint64, now int64) (*SwapQuote, error) {
+	if slippageBps > utils.BpsDenominator {
+		return nil, fmt.Errorf("invalid slippage: %d bps", slippageBps)
+	}
+	swapForY, err := swapDirection(state.Pair, inputMint)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := SimulateSwap(state, amountIn, swapForY, now)
+	if err != nil {
+		return nil, err
+	}
+	price, err := PriceAtBin(state.Pair.ActiveId, state.Pair.BinStep)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &SwapQuote{
+		InputMint:     inputMint,
+		OutputMint:    state.Pair.TokenYMint,
+		SwapForY:      swapForY,
+		AmountIn:      result.AmountIn,
+		AmountOut:     result.AmountOut,
+		MinAmountOut:  utils.ApplySlippageDown(result.AmountOut, slippageBps),
+		Fee:           result.Fee,
+		ProtocolFee:   result.ProtocolFee,
+		PriceImpact:   priceImpact(price, result, swapForY),
+		ActiveIDAfter: result.ActiveID,
+		SlippageBps:   slippageBps,
+	}
+	if !swapForY {
+		q.OutputMint = state.Pair.TokenXMint
+	}
+	for _, index := range result.BinArrays {
+		address, err := FindBinArrayAddress(pool.ProgramID, pool.Address, index)
+		if err != nil {
+			return nil, err
+		}
+		q.BinArrays = append(q.BinArrays, address)
+	}
+	return q, nil
+}
+
+// SpotPrice returns the price of one whole token X in token Y at the active bin
+func (p *Pool) SpotPrice() (float64, error) {
+	price, err := PriceAtBin(p.State.ActiveId, p.State.BinStep)
+	if err != nil {
+		return 0, err
+	}
+	return PriceX64ToPrice(price, p.DecimalsX, p.DecimalsY), nil
+}
+
+func swapDirection(pair *LbPair, inputMint solana.PublicKey) (bool, error) {
+	switch {
+	case inputMint.Equals(pair.TokenXMint):
+		return true, nil
+	case inputMint.Equals(pair.TokenYMint):
+		return false, nil
+	}
+	return false, fmt.Errorf("mint %s is not traded by the pair", inputMint)
+}
+
+// priceImpact compares the execution price, fees excluded, with the price of the active bin before the swap
+func priceImpact(priceX64 *big.Int, result *SwapResult, swapForY bool) float64 {
+	amountIn := result.AmountIn - result.Fee
+	if amountIn == 0 || result.AmountOut == 0 {
+		return 0
+	}
+
+	// Price of X in Y, in base units
+	spot, _ := new(big.Float).Quo(new(big.Float).SetInt(priceX64), new(big.Float).SetInt(one64)).Float64()
+	if !swapForY {
+		spot = 1 / spot
+	}
+	impact := 1 - float64(result.AmountOut)/float64(amountIn)/spot
+	if impact < 0 {
+		return 0
+	}
+	return impact
+}
@@ -0,0 +1,227 @@
+package meteora
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInsufficientLiquidity is returned when the pair runs out of liquidity before the input is spent
+	ErrInsufficientLiquidity = errors.New("insufficient liquidity for swap")
+
+	// ErrBinArrayNotLoaded is returned when a swap reaches a bin array it was not given. The program fails
+	// the same way, so more bin arrays have to be passed or the amount split.
+	ErrBinArrayNotLoaded = errors.New("swap reaches a bin array that was not loaded")
+)
+
+// SwapState is what a swap reads on-chain: the pair and the bin arrays with liquidity in the swap direction
+type SwapState struct {
+	Pair      *LbPair
+	BinArrays []*BinArray
+}
+
+// SwapResult is the outcome of a simulated exact input swap
+type SwapResult struct {
+	AmountIn    uint64 // Input consumed, including the fee
+	AmountOut   uint64
+	Fee         uint64  // Total fee, in input token units
+	ProtocolFee uint64  // Part of Fee kept by the protocol
+	ActiveID    int32   // Active bin after the swap
+	BinsCrossed int     // Bins emptied by the swap
+	BinArrays   []int64 // Indexes of the bin arrays the swap reads, in traversal order
+}
+
+// SimulateSwap replays the swap instruction of the DLMM program against state without modifying it.
+// swapForY sells token X for token Y. now is the unix time the volatility references are updated with.
+func SimulateSwap(state *SwapState, amountIn uint64, swapForY bool, now int64) (*SwapResult, error) {
+	if state == nil || state.Pair == nil {
+		return nil, fmt.Errorf("incomplete swap state")
+	}
+	if amountIn == 0 {
+		return nil, fmt.Errorf("swap amount must be greater than zero")
+	}
+	pair := state.Pair
+	if pair.Status != PairStatusEnabled {
+		return nil, fmt.Errorf("pair swaps are disabled")
+	}
+
+	arrays := make(map[int64]*BinArray, len(state.BinArrays))
+	for _, array := range state.BinArrays {
+		if array != nil {
+			arrays[array.Index] = array
+		}
+	}
+
+	volatilityReference, indexReference := updateReferences(pair, now)
+	activeID := pair.ActiveId
+
+	amountLeft := new(big.Int).SetUint64(amountIn)
+	amountOut := new(big.Int)
+	feeTotal := new(big.Int)
+	protocolFee := new(big.Int)
+	result := &SwapResult{}
+
+	for amountLeft.Sign() > 0 {
+		index, ok := nextBinArrayWithLiquidity(pair.BinArrayBitmap, BinArrayIndex(activeID), swapForY)
+		if !ok {
+			return nil, ErrInsufficientLiquidity
+		}
+		array, ok := arrays[index]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrBinArrayNotLoaded, index)
+		}
+		if len(result.BinArrays) == 0 || result.BinArrays[len(result.BinArrays)-1] != index {
+			result.BinArrays = append(result.BinArrays, index)
+		}
+
+		// Skip the empty arrays between the active bin and the array found
+		lower := int32(index * MaxBinPerArray)
+		upper := lower + MaxBinPerArray - 1
+		if activeID < lower || activeID > upper {
+			if swapForY {
+				activeID = upper
+			} else {
+				activeID = lower
+			}
+		}
+
+		for activeID >= lower && activeID <= upper {
+			feeRate := TotalFeeRate(pair, volatilityAccumulator(pair, volatilityReference, indexReference, activeID))
+
+			bin := &array.Bins[activeID-lower]
+			if binAmountOut(bin, swapForY).Sign() > 0 {
+				step, err := swapInBin(bin, activeID, pair, amountLeft, feeRate, swapForY)
+				if err != nil {
+					return nil, err
+				}
+				amountLeft.Sub(amountLeft, step.amountIn)
+				amountOut.Add(amountOut, step.amountOut)
+				feeTotal.Add(feeTotal, step.fee)
+				protocolFee.Add(protocolFee, step.protocolFee)
+				if step.emptied {
+					result.BinsCrossed++
+				}
+			}
+			if amountLeft.Sign() == 0 {
+				break
+			}
+
+			if swapForY {
+				activeID--
+			} else {
+				activeID++
+			}
+			if activeID < MinBinID || activeID > MaxBinID {
+				return nil, ErrInsufficientLiquidity
+			}
+		}
+	}
+
+	if !amountOut.IsUint64() {
+		return nil, fmt.Errorf("swap output exceeds u64")
+	}
+	result.AmountIn = amountIn
+	result.AmountOut = amountOut.Uint64()
+	result.Fee = feeTotal.Uint64()
+	result.ProtocolFee = protocolFee.Uint64()
+	result.ActiveID = activeID
+	return result, nil
+}
+
+// updateReferences decays the volatility of the pair by the time elapsed since its last swap, like
+// LbPair::update_references
+func updateReferences(pair *LbPair, now int64) (uint32, int32) {
+	params, v := pair.Parameters, pair.VParameters
+	volatilityReference, indexReference := v.VolatilityReference, v.IndexReference
+
+	elapsed := now - v.LastUpdateTimestamp
+	if elapsed >= int64(params.FilterPeriod) {
+		indexReference = pair.ActiveId
+		if elapsed < int64(params.DecayPeriod) {
+			volatilityReference = uint32(uint64(v.VolatilityAccumulator) * uint64(params.ReductionFactor) / BasisPointMax)
+		} else {
+			volatilityReference = 0
+		}
+	}
+	return volatilityReference, indexReference
+}
+
+// volatilityAccumulator is the volatility the fee of bin activeID is computed with
+func volatilityAccumulator(pair *LbPair, volatilityReference uint32, indexReference, activeID int32) uint32 {
+	delta := int64(indexReference) - int64(activeID)
+	if delta < 0 {
+		delta = -delta
+	}
+	accumulator := uint64(volatilityReference) + uint64(delta)*BasisPointMax
+	if max := uint64(pair.Parameters.MaxVolatilityAccumulator); accumulator > max {
+		return uint32(max)
+	}
+	return uint32(accumulator)
+}
+
+// binStep is the result of swapping within a single bin
+type binStep struct {
+	amountIn    *big.Int // Includes the fee
+	amountOut   *big.Int
+	fee         *big.Int
+	protocolFee *big.Int
+	emptied     bool
+}
+
+// swapInBin swaps at most amountIn at the constant price of the bin, like Bin::swap
+func swapInBin(bin *Bin, binID int32, pair *LbPair, amountIn *big.Int, feeRate uint64, swapForY bool) (*binStep, error) {
+	price := bin.Price.BigInt()
+	if price.Sign() == 0 {
+		var err error
+		if price, err = PriceAtBin(binID, pair.BinStep); err != nil {
+			return nil, err
+		}
+	}
+
+	maxAmountOut := binAmountOut(bin, swapForY)
+	maxAmountIn := maxAmountInForBin(maxAmountOut, price, swapForY)
+	maxFee := feeOnAmount(maxAmountIn, feeRate)
+	maxAmountIn.Add(maxAmountIn, maxFee)
+
+	step := &binStep{}
+	if amountIn.Cmp(maxAmountIn) >= 0 {
+		step.amountIn, step.amountOut, step.fee, step.emptied = maxAmountIn, maxAmountOut, maxFee, true
+	} else {
+		step.fee = feeFromAmount(amountIn, feeRate)
+		step.amountIn = new(big.Int).Set(amountIn)
+		step.amountOut = amountOutAtPrice(new(big.Int).Sub(amountIn, step.fee), price, swapForY)
+		if step.amountOut.Cmp(maxAmountOut) > 0 {
+			step.amountOut = maxAmountOut
+		}
+	}
+	step.protocolFee = new(big.Int).Mul(step.fee, big.NewInt(int64(pair.Parameters.ProtocolShare)))
+	step.protocolFee.Quo(step.protocolFee, big.NewInt(BasisPointMax))
+	return step, nil
+}
+
+// binAmountOut is the liquidity of the output token in the bin
+func binAmountOut(bin *Bin, swapForY bool) *big.Int {
+	if swapForY {
+		return new(big.Int).SetUint64(bin.AmountY)
+	}
+	return new(big.Int).SetUint64(bin.AmountX)
+}
+
+// maxAmountInForBin is the input, fee excluded, that buys the whole output liquidity of a bin, rounded up
+func maxAmountInForBin(amountOut, price *big.Int, swapForY bool) *big.Int {
+	if swapForY {
+		return ceilDiv(new(big.Int).Lsh(amountOut, 64), price)
+	}
+	return ceilDiv(new(big.Int).Mul(amountOut, price), one64)
+}
+
+// amountOutAtPrice converts an input, fee excluded, to the output token at the bin price, rounded down
+func amountOutAtPrice(amountIn, price *big.Int, swapForY bool) *big.Int {
+	if swapForY {
+		v := new(big.Int).Mul(amountIn, price)
+		return v.Rsh(v, 64)
+	}
+	v := new(big.Int).Lsh(amountIn, 64)
+	return v.Quo(v, price)
+}
@@ -0,0 +1,274 @@
+package meteora
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"corvus_bot/pkg/idl/bindings/meteoradlmm"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBinStep    = 10
+	testBaseFactor = 10_000 // 0.1% base fee with a 10 bps bin step
+	testNow        = 1_700_000_000
+)
+
+// newTestPool returns a pair at bin 0 with 1e9 of Y in each of bins -3 to 0, 1e9 of X in each of bins 0 to 3
+// and 150, and the bin arrays holding them
+func newTestPool() (*Pool, map[int64]*BinArray) {
+	address := solana.NewWallet().PublicKey()
+	state := &LbPair{
+		ActiveId:   0,
+		BinStep:    testBinStep,
+		TokenXMint: solana.NewWallet().PublicKey(),
+		TokenYMint: solana.NewWallet().PublicKey(),
+		ReserveX:   solana.NewWallet().PublicKey(),
+		ReserveY:   solana.NewWallet().PublicKey(),
+		Oracle:     solana.NewWallet().PublicKey(),
+	}
+	state.Parameters.BaseFactor = testBaseFactor
+	state.Parameters.FilterPeriod = 30
+	state.Parameters.DecayPeriod = 600
+	state.Parameters.ReductionFactor = 5000
+	state.Parameters.MaxVolatilityAccumulator = 350_000
+	state.Parameters.ProtocolShare = 500
+	state.VParameters.LastUpdateTimestamp = testNow - 3600
+
+	arrays := make(map[int64]*BinArray)
+	setBin := func(binID int32, amountX, amountY uint64) {
+		index := BinArrayIndex(binID)
+		if arrays[index] == nil {
+			arrays[index] = &BinArray{Index: index, LbPair: address}
+			offset := index + bitmapHalfRange
+			state.BinArrayBitmap[offset/64] |= 1 << (offset % 64)
+		}
+		entry := &arrays[index].Bins[binID-int32(index*MaxBinPerArray)]
+		entry.AmountX, entry.AmountY = amountX, amountY
+	}
+	for binID := int32(-3); binID <= 3; binID++ {
+		var amountX, amountY uint64
+		if binID >= 0 {
+			amountX = 1_000_000_000
+		}
+		if binID <= 0 {
+			amountY = 1_000_000_000
+		}
+		setBin(binID, amountX, amountY)
+	}
+	setBin(150, 1_000_000_000, 0)
+
+	pool := &Pool{
+		Address:       address,
+		ProgramID:     solana.MustPublicKeyFromBase58(DefaultProgramID),
+		State:         state,
+		DecimalsX:     9,
+		DecimalsY:     6,
+		TokenProgramX: solana.TokenProgramID,
+		TokenProgramY: solana.TokenProgramID,
+		ReserveX:      5_000_000_000,
+		ReserveY:      4_000_000_000,
+	}
+	return pool, arrays
+}
+
+func swapState(pool *Pool, arrays map[int64]*BinArray) *SwapState {
+	state := &SwapState{Pair: pool.State}
+	for _, array := range arrays {
+		state.BinArrays = append(state.BinArrays, array)
+	}
+	return state
+}
+
+func TestPriceAtBin(t *testing.T) {
+	price, err := PriceAtBin(0, testBinStep)
+	require.NoError(t, err)
+	assert.Equal(t, one64, price)
+
+	// Like the program, the power is computed below one, so precision drops for prices far from 1
+	for _, c := range []struct {
+		binID   int32
+		binStep uint16
+	}{{1, 10}, {-1, 10}, {100, 25}, {-1000, 25}, {1500, 100}, {-1500, 100}} {
+		price, err := PriceAtBin(c.binID, c.binStep)
+		require.NoError(t, err)
+		got, _ := new(big.Float).Quo(new(big.Float).SetInt(price), new(big.Float).SetInt(one64)).Float64()
+		want := math.Pow(1+float64(c.binStep)/BasisPointMax, float64(c.binID))
+		assert.InEpsilon(t, want, got, 1e-9, "bin %d with step %d", c.binID, c.binStep)
+	}
+
+	_, err = PriceAtBin(5000, 100)
+	assert.Error(t, err, "beyond Q64.64")
+	_, err = PriceAtBin(maxExponent, 1)
+	assert.Error(t, err)
+
+	assert.InDelta(t, 1000, PriceX64ToPrice(one64, 9, 6), 1e-9)
+}
+
+func TestFeeRates(t *testing.T) {
+	pool, _ := newTestPool()
+	pair := pool.State
+	assert.Equal(t, uint64(1_000_000), BaseFeeRate(pair))
+	assert.Equal(t, uint64(1_000_000), TotalFeeRate(pair, 10_000), "no variable fee without a fee control")
+
+	pair.Parameters.VariableFeeControl = 40_000
+	assert.Equal(t, uint64(4_000), VariableFeeRate(pair, 10_000))
+	assert.Equal(t, uint64(1_004_000), TotalFeeRate(pair, 10_000))
+	pair.Parameters.VariableFeeControl = math.MaxUint32
+	assert.Equal(t, uint64(MaxFeeRate), TotalFeeRate(pair, 350_000))
+
+	pair.VParameters.VolatilityAccumulator = 40_000
+	pair.VParameters.IndexReference = 7
+	reference, index := updateReferences(pair, pair.VParameters.LastUpdateTimestamp+10)
+	assert.Equal(t, uint32(0), reference, "within the filter period nothing changes")
+	assert.Equal(t, int32(7), index)
+	reference, index = updateReferences(pair, pair.VParameters.LastUpdateTimestamp+60)
+	assert.Equal(t, uint32(20_000), reference, "decayed by the reduction factor")
+	assert.Equal(t, pair.ActiveId, index)
+	reference, _ = updateReferences(pair, pair.VParameters.LastUpdateTimestamp+600)
+	assert.Equal(t, uint32(0), reference, "fully decayed")
+
+	assert.Equal(t, uint32(50_000), volatilityAccumulator(pair, 20_000, 0, -3))
+	assert.Equal(t, uint32(350_000), volatilityAccumulator(pair, 20_000, 0, 100))
+}
+
+func TestSimulateSwapWithinBin(t *testing.T) {
+	pool, arrays := newTestPool()
+
+	result, err := SimulateSwap(swapState(pool, arrays), 1_000_000, true, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000_000), result.AmountIn)
+	assert.Equal(t, uint64(1_000), result.Fee)
+	assert.Equal(t, uint64(50), result.ProtocolFee)
+	assert.Equal(t, uint64(999_000), result.AmountOut, "bin 0 trades at price 1")
+	assert.Equal(t, int32(0), result.ActiveID)
+	assert.Equal(t, 0, result.BinsCrossed)
+	assert.Equal(t, []int64{0}, result.BinArrays)
+}
+
+func TestSimulateSwapAcrossBins(t *testing.T) {
+	pool, arrays := newTestPool()
+
+	result, err := SimulateSwap(swapState(pool, arrays), 2_500_000_000, true, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.BinsCrossed)
+	assert.Equal(t, int32(-2), result.ActiveID)
+	assert.Equal(t, []int64{0, -1}, result.BinArrays)
+	assert.Greater(t, result.AmountOut, uint64(2_000_000_000))
+	assert.Less(t, result.AmountOut, uint64(2_500_000_000), "lower bins pay less Y per X")
+
+	// Buying X walks up through bins 1 to 3, then skips the empty arrays up to bin 150
+	result, err = SimulateSwap(swapState(pool, arrays), 4_500_000_000, false, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, int32(150), result.ActiveID)
+	assert.Equal(t, []int64{0, 2}, result.BinArrays)
+	assert.Equal(t, 4, result.BinsCrossed)
+
+	delete(arrays, 2)
+	_, err = SimulateSwap(swapState(pool, arrays), 4_500_000_000, false, testNow)
+	assert.ErrorIs(t, err, ErrBinArrayNotLoaded)
+
+	_, err = SimulateSwap(swapState(pool, arrays), 1_000_000_000_000, true, testNow)
+	assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+}
+
+func TestSimulateSwapVariableFee(t *testing.T) {
+	pool, arrays := newTestPool()
+	pool.State.Parameters.VariableFeeControl = 40_000
+
+	result, err := SimulateSwap(swapState(pool, arrays), 2_500_000_000, true, testNow)
+	require.NoError(t, err)
+	withoutVolatility, err := SimulateSwap(swapState(pool, arrays), 1_000_000, true, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000), withoutVolatility.Fee, "no volatility at the reference bin")
+
+	pool.State.Parameters.VariableFeeControl = 0
+	flat, err := SimulateSwap(swapState(pool, arrays), 2_500_000_000, true, testNow)
+	require.NoError(t, err)
+	assert.Greater(t, result.Fee, flat.Fee, "bins away from the reference pay the volatility fee")
+}
+
+func TestSwapBinArrayIndexes(t *testing.T) {
+	pool, _ := newTestPool()
+	assert.Equal(t, []int64{0, -1}, SwapBinArrayIndexes(pool.State, true, DefaultBinArrayCount))
+	assert.Equal(t, []int64{0, 2}, SwapBinArrayIndexes(pool.State, false, DefaultBinArrayCount))
+	assert.Equal(t, []int64{0}, SwapBinArrayIndexes(pool.State, false, 1))
+	assert.Equal(t, int64(-1), BinArrayIndex(-1))
+	assert.Equal(t, int64(-1), BinArrayIndex(-70))
+	assert.Equal(t, int64(-2), BinArrayIndex(-71))
+	assert.Equal(t, int64(1), BinArrayIndex(70))
+}
+
+func TestQuote(t *testing.T) {
+	pool, arrays := newTestPool()
+	client := newTestClient(t, pool, arrays)
+
+	quote, err := Quote(context.Background(), client, pool, pool.State.TokenYMint, 4_500_000_000, 100)
+	require.NoError(t, err)
+	assert.False(t, quote.SwapForY)
+	assert.Equal(t, pool.State.TokenXMint, quote.OutputMint)
+	assert.Equal(t, int32(150), quote.ActiveIDAfter)
+	assert.Equal(t, quote.AmountOut*9900/10000, quote.MinAmountOut)
+	assert.Greater(t, quote.PriceImpact, 0.0)
+	for k, index := range []int64{0, 2} {
+		address, err := FindBinArrayAddress(pool.ProgramID, pool.Address, index)
+		require.NoError(t, err)
+		assert.Equal(t, address, quote.BinArrays[k])
+	}
+
+	_, err = Quote(context.Background(), client, pool, solana.NewWallet().PublicKey(), 1_000, 100)
+	assert.Error(t, err, "mint not traded by the pair")
+}
+
+// newTestClient serves the pair, its bin arrays, mints and reserves
+func newTestClient(t *testing.T, pool *Pool, arrays map[int64]*BinArray) *utils.MockRPCClient {
+	accounts := map[solana.PublicKey]*rpc.Account{
+		pool.Address: programAccount(t, pool.ProgramID, meteoradlmm.LbPairDiscriminator, pool.State),
+	}
+	for index, array := range arrays {
+		address, err := FindBinArrayAddress(pool.ProgramID, pool.Address, index)
+		require.NoError(t, err)
+		accounts[address] = programAccount(t, pool.ProgramID, meteoradlmm.BinArrayDiscriminator, array)
+	}
+	for _, mint := range []struct {
+		address  solana.PublicKey
+		decimals uint8
+	}{{pool.State.TokenXMint, pool.DecimalsX}, {pool.State.TokenYMint, pool.DecimalsY}} {
+		data := make([]byte, 82)
+		data[mintDecimalsOffset] = mint.decimals
+		accounts[mint.address] = &rpc.Account{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(data)}
+	}
+	for _, reserve := range []struct {
+		address solana.PublicKey
+		amount  uint64
+	}{{pool.State.ReserveX, pool.ReserveX}, {pool.State.ReserveY, pool.ReserveY}} {
+		data := make([]byte, 165)
+		bin.LE.PutUint64(data[tokenAmountOffset:], reserve.amount)
+		accounts[reserve.address] = &rpc.Account{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(data)}
+	}
+
+	return &utils.MockRPCClient{
+		MockGetMultipleAccounts: func(ctx context.Context, keys []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+			result := &rpc.GetMultipleAccountsResult{Value: make([]*rpc.Account, len(keys))}
+			for k, key := range keys {
+				result.Value[k] = accounts[key]
+			}
+			return result, nil
+		},
+	}
+}
+
+func programAccount(t *testing.T, owner solana.PublicKey, discriminator []byte, account interface{}) *rpc.Account {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(account))
+	return &rpc.Account{Owner: owner, Data: rpc.DataBytesOrJSONFromBytes(buf.Bytes())}
+}
@@ -0,0 +1,381 @@
+// Package migration links the bonding curves that graduate from the pump.fun and Moonshot launchpads to the
+// Raydium pools they migrate to.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/database"
+	"corvus_bot/pkg/database/models"
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/listeners"
+	"corvus_bot/pkg/moonshot"
+	"corvus_bot/pkg/pumpfun"
+	"corvus_bot/pkg/raydium/parse"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// completionTTL bounds how long a completed bonding curve waits for its Raydium pool
+	completionTTL = 24 * time.Hour
+
+	// earlyPoolTTL bounds how long a pool waits for the CompleteEvent of its mint, when the two listeners
+	// deliver out of order after a reconnect
+	earlyPoolTTL = 10 * time.Minute
+
+	// maxEarlyPools bounds the pools waiting for their CompleteEvent, the oldest is forgotten first
+	maxEarlyPools = 1024
+
+	// subscriptionBuffer is the buffer of the tracker's event bus subscriptions
+	subscriptionBuffer = 256
+)
+
+// Store persists migrations. *database.Database implements it.
+type Store interface {
+	RecordMigration(record *database.MigrationRecord) error
+}
+
+// completion is a bonding curve that sold out and waits for its Raydium pool
+type completion struct {
+	mint          string
+	bondingCurve  string
+	protocol      models.Protocol
+	programID     string
+	migrationType string
+	decimals      uint8 // Zero when the launchpad does not fix them, read from the pool instead
+	metadata      models.JSONMap
+	seen          time.Time
+}
+
+// earlyPool is a Raydium pool seen before the CompleteEvent of one of its mints
+type earlyPool struct {
+	pool *parse.ParsedAMMPool
+	seen time.Time
+}
+
+// Tracker matches pump.fun CompleteEvents and Moonshot migrations with the Raydium initialize2 of the same
+// mint and records the migration, the relationship between the bonding curve and the AMM pool, and the
+// migrated curve status
+type Tracker struct {
+	store             Store
+	pumpProgramID     string
+	moonshotProgramID string
+
+	mu          sync.Mutex
+	completions map[string]*completion // By mint
+	pools       map[string]*earlyPool  // By mint
+	migrators   map[string]bool        // Wallets that create the pools of graduated curves
+	now         func() time.Time
+}
+
+// NewTracker creates a tracker for the active config profile writing to store
+func NewTracker(cfg *config.Config, store Store) *Tracker {
+	return &Tracker{
+		store:             store,
+		pumpProgramID:     cfg.PumpFunProgramID,
+		moonshotProgramID: cfg.MoonshotProgramID,
+		completions:       make(map[string]*completion),
+		pools:             make(map[string]*earlyPool),
+		migrators:         map[string]bool{pumpfun.MigrationAccount: true},
+		now:               time.Now,
+	}
+}
+
+// AddMigrator adds a wallet whose pools may be kept until the completion of their mint arrives, such as the
+// MigrationAuthority of the Moonshot ConfigAccount. Authorities of the Moonshot migrations seen are added.
+func (t *Tracker) AddMigrator(wallet string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.migrators[wallet] = true
+}
+
+// Run follows the pump.fun completions, the Moonshot migrations and the new Raydium AMM pools published on the
+// bus until the context is cancelled or the bus closed
+func (t *Tracker) Run(ctx context.Context, bus *eventbus.Bus) error {
+	completionTopic, err := listeners.PumpFunCompletionsTopic(bus)
+	if err != nil {
+		return err
+	}
+	moonshotTopic, err := listeners.MoonshotMigrationsTopic(bus)
+	if err != nil {
+		return err
+	}
+	poolTopic, err := listeners.AMMPoolsTopic(bus)
+	if err != nil {
+		return err
+	}
+
+	completions := completionTopic.Subscribe(subscriptionBuffer)
+	defer completions.Unsubscribe()
+	moonshotMigrations := moonshotTopic.Subscribe(subscriptionBuffer)
+	defer moonshotMigrations.Unsubscribe()
+	pools := poolTopic.Subscribe(subscriptionBuffer)
+	defer pools.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-completions.C():
+			if !ok {
+				return nil
+			}
+			if err := t.HandleEvent(event); err != nil {
+				log.Printf("Migration tracker: %v", err)
+			}
+		case migration, ok := <-moonshotMigrations.C():
+			if !ok {
+				return nil
+			}
+			if err := t.HandleMoonshotMigration(migration); err != nil {
+				log.Printf("Migration tracker: %v", err)
+			}
+		case pool, ok := <-pools.C():
+			if !ok {
+				return nil
+			}
+			if err := t.HandlePool(pool); err != nil {
+				log.Printf("Migration tracker: %v", err)
+			}
+		}
+	}
+}
+
+// HandleEvent remembers the bonding curve of a CompleteEvent until its pool is created. Other events are ignored.
+func (t *Tracker) HandleEvent(event *pumpfun.Event) error {
+	complete, ok := event.Event.(*pumpfun.CompleteEvent)
+	if !ok {
+		return nil
+	}
+
+	return t.handleCompletion(&completion{
+		mint:          complete.Mint.String(),
+		bondingCurve:  complete.BondingCurve.String(),
+		protocol:      models.ProtocolPumpFun,
+		programID:     t.pumpProgramID,
+		migrationType: models.MigrationTypePumpToRaydium,
+		decimals:      pumpfun.TokenDecimals,
+		metadata: models.JSONMap{
+			"complete_signature": event.Signature,
+			"complete_slot":      event.Slot,
+			"complete_timestamp": complete.Timestamp,
+		},
+		seen: t.now(),
+	})
+}
+
+// HandleMoonshotMigration remembers the curve of a Moonshot migration until its pool is created
+func (t *Tracker) HandleMoonshotMigration(migration *moonshot.Migration) error {
+	metadata := models.JSONMap{
+		"migrate_signature": migration.Signature,
+		"migrate_slot":      migration.Slot,
+		"migrate_timestamp": migration.BlockTime,
+	}
+	if migration.Event != nil {
+		metadata["tokens_migrated"] = migration.Event.TokensMigrated
+		metadata["collateral_migrated"] = migration.Event.CollateralMigrated
+	}
+	if !migration.Authority.IsZero() {
+		t.AddMigrator(migration.Authority.String())
+	}
+	return t.handleCompletion(&completion{
+		mint:          migration.Mint.String(),
+		bondingCurve:  migration.Curve.String(),
+		protocol:      models.ProtocolMoonshot,
+		programID:     t.moonshotProgramID,
+		migrationType: models.MigrationTypeMoonshotToRaydium,
+		metadata:      metadata,
+		seen:          t.now(),
+	})
+}
+
+// handleCompletion records the migration when the pool of the curve was already seen, and otherwise keeps
+// the curve until it is
+func (t *Tracker) handleCompletion(c *completion) error {
+	t.mu.Lock()
+	t.prune()
+	early, found := t.pools[c.mint]
+	if found {
+		delete(t.pools, c.mint)
+	} else {
+		t.completions[c.mint] = c
+	}
+	t.mu.Unlock()
+
+	if found {
+		return t.record(c, early.pool)
+	}
+	return nil
+}
+
+// HandlePool records the migration when one of the pool mints completed its bonding curve. A SOL pool created
+// by a migrator, whose mint was not seen completing, is kept a short while in case the completion arrives late.
+func (t *Tracker) HandlePool(pool *parse.ParsedAMMPool) error {
+	t.mu.Lock()
+	t.prune()
+	var matched *completion
+	for _, mint := range []string{pool.BaseMint, pool.QuoteMint} {
+		if c, ok := t.completions[mint]; ok {
+			matched = c
+			delete(t.completions, mint)
+			break
+		}
+	}
+	if matched == nil && t.migrators[pool.Creator] {
+		t.keepEarly(pool)
+	}
+	t.mu.Unlock()
+
+	if matched == nil {
+		return nil
+	}
+	return t.record(matched, pool)
+}
+
+// keepEarly keeps a pool paired with SOL by its other mint, evicting the oldest pool when the buffer is full.
+// It must be called with mu held.
+func (t *Tracker) keepEarly(pool *parse.ParsedAMMPool) {
+	var mint string
+	switch solana.SolMint.String() {
+	case pool.BaseMint:
+		mint = pool.QuoteMint
+	case pool.QuoteMint:
+		mint = pool.BaseMint
+	default:
+		return
+	}
+
+	if _, ok := t.pools[mint]; !ok && len(t.pools) >= maxEarlyPools {
+		var oldest string
+		for m, early := range t.pools {
+			if oldest == "" || early.seen.Before(t.pools[oldest].seen) {
+				oldest = m
+			}
+		}
+		delete(t.pools, oldest)
+	}
+	t.pools[mint] = &earlyPool{pool: pool, seen: t.now()}
+}
+
+// Pending returns the number of completed bonding curves waiting for their pool
+func (t *Tracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.completions)
+}
+
+// prune forgets the completions and pools that waited too long. It must be called with mu held.
+func (t *Tracker) prune() {
+	now := t.now()
+	for mint, c := range t.completions {
+		if now.Sub(c.seen) > completionTTL {
+			delete(t.completions, mint)
+		}
+	}
+	for mint, early := range t.pools {
+		if now.Sub(early.seen) > earlyPoolTTL {
+			delete(t.pools, mint)
+		}
+	}
+}
+
+// record writes the migration of the bonding curve to the pool
+func (t *Tracker) record(c *completion, pool *parse.ParsedAMMPool) error {
+	log.Printf("Migration detected - Mint: %s, Curve: %s -> Raydium pool: %s (tx %s)",
+		c.mint, c.bondingCurve, pool.ID, pool.Signature)
+
+	if err := t.store.RecordMigration(t.newRecord(c, pool)); err != nil {
+		return fmt.Errorf("failed to record migration of %s to pool %s: %w", c.mint, pool.ID, err)
+	}
+	return nil
+}
+
+func (t *Tracker) newRecord(c *completion, pool *parse.ParsedAMMPool) *database.MigrationRecord {
+	now := t.now().UTC()
+	base := models.BaseModel{LastUpdated: now}
+
+	asset := &models.Asset{}
+	asset.BaseModel = base
+	asset.ID = c.mint
+	asset.Address = c.mint
+	asset.Mint = c.mint
+	asset.Interface = models.InterfaceFungibleToken
+	asset.Type = models.AssetTypeFungible
+	asset.Status = models.AssetStatusActive
+	asset.Decimals = c.decimals
+	if asset.Decimals == 0 {
+		asset.Decimals = pool.QuoteDecimals
+		if pool.BaseMint == c.mint {
+			asset.Decimals = pool.BaseDecimals
+		}
+	}
+
+	sourcePool := &models.Pool{}
+	sourcePool.BaseModel = base
+	sourcePool.ID = c.bondingCurve
+	sourcePool.Protocol = c.protocol
+	sourcePool.Type = models.PoolTypeBondingCurve
+	sourcePool.Status = models.PoolStatusMigrated
+	sourcePool.ProgramID = c.programID
+	sourcePool.BaseMint = c.mint
+	sourcePool.QuoteMint = solana.SolMint.String()
+
+	targetPool := &models.Pool{
+		Authority:  pool.Authority,
+		BaseVault:  pool.BaseVault,
+		QuoteVault: pool.QuoteVault,
+		RaydiumData: &models.RaydiumPoolData{
+			OpenOrders:    pool.OpenOrders,
+			TargetOrders:  pool.TargetOrders,
+			WithdrawQueue: pool.WithdrawQueue,
+			BaseDecimal:   pool.BaseDecimals,
+			QuoteDecimal:  pool.QuoteDecimals,
+			LpDecimal:     pool.LPDecimals,
+		},
+	}
+	if pool.LPMint != "" {
+		lpMint := pool.LPMint
+		targetPool.LpMint = &lpMint
+	}
+	targetPool.BaseModel = base
+	targetPool.ID = pool.ID
+	targetPool.Protocol = models.ProtocolRaydium
+	targetPool.Type = models.PoolTypeAMM
+	targetPool.Status = models.PoolStatusActive
+	targetPool.ProgramID = pool.ProgramID
+	targetPool.BaseMint = pool.BaseMint
+	targetPool.QuoteMint = pool.QuoteMint
+	targetPool.Version = pool.Version
+
+	return &database.MigrationRecord{
+		Asset:      asset,
+		SourcePool: sourcePool,
+		TargetPool: targetPool,
+		Migration: &models.Migration{
+			BaseModel:     base,
+			SourceAssetID: c.mint,
+			TargetAssetID: c.mint,
+			SourcePoolID:  c.bondingCurve,
+			TargetPoolID:  pool.ID,
+			Type:          c.migrationType,
+			Status:        models.MigrationStatusCompleted,
+			TxSignature:   pool.Signature,
+			Slot:          pool.Slot,
+		},
+		Relationship: &models.PoolRelationship{
+			BaseModel:      base,
+			SourcePoolID:   c.bondingCurve,
+			TargetPoolID:   pool.ID,
+			RelationType:   models.RelationTypeMigration,
+			SourceProtocol: c.protocol,
+			TargetProtocol: models.ProtocolRaydium,
+			Metadata:       c.metadata,
+		},
+	}
+}
@@ -0,0 +1,257 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/config"
+	"corvus_bot/pkg/database"
+	"corvus_bot/pkg/database/models"
+	"corvus_bot/pkg/eventbus"
+	"corvus_bot/pkg/listeners"
+	"corvus_bot/pkg/moonshot"
+	"corvus_bot/pkg/pumpfun"
+	"corvus_bot/pkg/raydium/parse"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	mu      sync.Mutex
+	records []*database.MigrationRecord
+	err     error
+}
+
+func (s *fakeStore) RecordMigration(record *database.MigrationRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *fakeStore) recorded() []*database.MigrationRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*database.MigrationRecord(nil), s.records...)
+}
+
+func newTestTracker(store Store) *Tracker {
+	return NewTracker(&config.Config{
+		PumpFunProgramID:  config.DefaultPumpFunProgramID,
+		MoonshotProgramID: config.DefaultMoonshotProgramID,
+	}, store)
+}
+
+func completeEvent(mint, curve solana.PublicKey) *pumpfun.Event {
+	return &pumpfun.Event{
+		Signature: "complete-signature",
+		Slot:      304027100,
+		Name:      "CompleteEvent",
+		Event:     &pumpfun.CompleteEvent{Mint: mint, BondingCurve: curve, Timestamp: 1732752205},
+	}
+}
+
+func migratedPool(mint solana.PublicKey) *parse.ParsedAMMPool {
+	return &parse.ParsedAMMPool{
+		ID:            solana.NewWallet().PublicKey().String(),
+		ProgramID:     "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8",
+		Signature:     "initialize2-signature",
+		Slot:          304027900,
+		Version:       4,
+		BaseMint:      solana.SolMint.String(),
+		QuoteMint:     mint.String(),
+		LPMint:        solana.NewWallet().PublicKey().String(),
+		Creator:       pumpfun.MigrationAccount,
+		BaseDecimals:  9,
+		QuoteDecimals: 6,
+	}
+}
+
+func TestTrackerRecordsMigration(t *testing.T) {
+	store := &fakeStore{}
+	tracker := newTestTracker(store)
+	mint, curve := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	require.NoError(t, tracker.HandleEvent(completeEvent(mint, curve)))
+	assert.Equal(t, 1, tracker.Pending())
+
+	// Pools of other mints are not migrations
+	require.NoError(t, tracker.HandlePool(migratedPool(solana.NewWallet().PublicKey())))
+	assert.Empty(t, store.recorded())
+
+	pool := migratedPool(mint)
+	require.NoError(t, tracker.HandlePool(pool))
+	assert.Equal(t, 0, tracker.Pending())
+
+	records := store.recorded()
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, mint.String(), record.Asset.ID)
+	assert.Equal(t, curve.String(), record.SourcePool.ID)
+	assert.Equal(t, models.ProtocolPumpFun, record.SourcePool.Protocol)
+	assert.Equal(t, uint8(pumpfun.TokenDecimals), record.Asset.Decimals)
+	assert.Equal(t, models.PoolStatusMigrated, record.SourcePool.Status)
+	assert.Equal(t, pool.ID, record.TargetPool.ID)
+	assert.Equal(t, models.PoolTypeAMM, record.TargetPool.Type)
+	assert.Equal(t, pool.LPMint, *record.TargetPool.LpMint)
+
+	assert.Equal(t, models.MigrationTypePumpToRaydium, record.Migration.Type)
+	assert.Equal(t, curve.String(), record.Migration.SourcePoolID)
+	assert.Equal(t, pool.ID, record.Migration.TargetPoolID)
+	assert.Equal(t, "initialize2-signature", record.Migration.TxSignature)
+	assert.Equal(t, uint64(304027900), record.Migration.Slot)
+
+	assert.Equal(t, models.RelationTypeMigration, record.Relationship.RelationType)
+	assert.Equal(t, curve.String(), record.Relationship.SourcePoolID)
+	assert.Equal(t, pool.ID, record.Relationship.TargetPoolID)
+}
+
+func TestTrackerRecordsMoonshotMigration(t *testing.T) {
+	store := &fakeStore{}
+	tracker := newTestTracker(store)
+	mint, curve := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	require.NoError(t, tracker.HandleMoonshotMigration(&moonshot.Migration{
+		Signature: "migrate-signature",
+		Slot:      304027200,
+		BlockTime: 1732752205,
+		Mint:      mint,
+		Curve:     curve,
+		Event:     &moonshot.MigrationEvent{TokensMigrated: 200_000_000, CollateralMigrated: 500_000_000_000},
+	}))
+	assert.Equal(t, 1, tracker.Pending())
+
+	pool := migratedPool(mint)
+	pool.QuoteDecimals = 9
+	require.NoError(t, tracker.HandlePool(pool))
+
+	records := store.recorded()
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, uint8(9), record.Asset.Decimals, "read from the pool")
+	assert.Equal(t, curve.String(), record.SourcePool.ID)
+	assert.Equal(t, models.ProtocolMoonshot, record.SourcePool.Protocol)
+	assert.Equal(t, config.DefaultMoonshotProgramID, record.SourcePool.ProgramID)
+	assert.Equal(t, models.MigrationTypeMoonshotToRaydium, record.Migration.Type)
+	assert.Equal(t, pool.ID, record.Migration.TargetPoolID)
+	assert.Equal(t, models.ProtocolMoonshot, record.Relationship.SourceProtocol)
+	metadata := record.Relationship.Metadata
+	assert.Equal(t, "migrate-signature", metadata["migrate_signature"])
+	assert.Equal(t, uint64(500_000_000_000), metadata["collateral_migrated"])
+}
+
+func TestTrackerMatchesLateCompletion(t *testing.T) {
+	store := &fakeStore{}
+	tracker := newTestTracker(store)
+	mint, curve := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	require.NoError(t, tracker.HandlePool(migratedPool(mint)))
+	require.NoError(t, tracker.HandleEvent(completeEvent(mint, curve)))
+	require.Len(t, store.recorded(), 1)
+	assert.Equal(t, 0, tracker.Pending())
+
+	// Other events of the program are ignored
+	require.NoError(t, tracker.HandleEvent(&pumpfun.Event{Name: "TradeEvent", Event: &pumpfun.TradeEvent{Mint: mint}}))
+	assert.Equal(t, 0, tracker.Pending())
+}
+
+func TestTrackerKeepsOnlyMigratedPools(t *testing.T) {
+	store := &fakeStore{}
+	tracker := newTestTracker(store)
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	other := migratedPool(solana.NewWallet().PublicKey())
+	other.Creator = solana.NewWallet().PublicKey().String()
+	require.NoError(t, tracker.HandlePool(other))
+	unpaired := migratedPool(solana.NewWallet().PublicKey())
+	unpaired.BaseMint = solana.NewWallet().PublicKey().String()
+	require.NoError(t, tracker.HandlePool(unpaired))
+	assert.Empty(t, tracker.pools, "only SOL pools created by a migrator wait for their completion")
+
+	// The authority of a Moonshot migration becomes a migrator
+	authority := solana.NewWallet().PublicKey()
+	require.NoError(t, tracker.HandleMoonshotMigration(&moonshot.Migration{
+		Mint:      solana.NewWallet().PublicKey(),
+		Curve:     solana.NewWallet().PublicKey(),
+		Authority: authority,
+	}))
+	mint := solana.NewWallet().PublicKey()
+	pool := migratedPool(mint)
+	pool.Creator = authority.String()
+	require.NoError(t, tracker.HandlePool(pool))
+	assert.Contains(t, tracker.pools, mint.String())
+
+	for k := 1; k < maxEarlyPools; k++ {
+		now = now.Add(time.Millisecond)
+		require.NoError(t, tracker.HandlePool(migratedPool(solana.NewWallet().PublicKey())))
+	}
+	assert.Len(t, tracker.pools, maxEarlyPools)
+	now = now.Add(time.Millisecond)
+	require.NoError(t, tracker.HandlePool(migratedPool(solana.NewWallet().PublicKey())))
+	assert.Len(t, tracker.pools, maxEarlyPools)
+	assert.NotContains(t, tracker.pools, mint.String(), "the oldest pool is evicted")
+}
+
+func TestTrackerForgetsStaleEntries(t *testing.T) {
+	store := &fakeStore{}
+	tracker := newTestTracker(store)
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	stale, curve := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	require.NoError(t, tracker.HandleEvent(completeEvent(stale, curve)))
+	early := solana.NewWallet().PublicKey()
+	require.NoError(t, tracker.HandlePool(migratedPool(early)))
+
+	now = now.Add(completionTTL + time.Minute)
+	require.NoError(t, tracker.HandlePool(migratedPool(stale)))
+	require.NoError(t, tracker.HandleEvent(completeEvent(early, solana.NewWallet().PublicKey())))
+	assert.Empty(t, store.recorded())
+}
+
+func TestTrackerReportsStoreErrors(t *testing.T) {
+	tracker := newTestTracker(&fakeStore{err: errors.New("connection refused")})
+	mint := solana.NewWallet().PublicKey()
+
+	require.NoError(t, tracker.HandleEvent(completeEvent(mint, solana.NewWallet().PublicKey())))
+	assert.ErrorContains(t, tracker.HandlePool(migratedPool(mint)), "connection refused")
+}
+
+func TestTrackerRun(t *testing.T) {
+	store := &fakeStore{}
+	tracker := newTestTracker(store)
+	bus := eventbus.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- tracker.Run(ctx, bus) }()
+
+	completions, err := listeners.PumpFunCompletionsTopic(bus)
+	require.NoError(t, err)
+	pools, err := listeners.AMMPoolsTopic(bus)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return completions.Stats().Subscribers == 1 && pools.Stats().Subscribers == 1
+	}, time.Second, time.Millisecond)
+
+	mint := solana.NewWallet().PublicKey()
+	require.NoError(t, completions.Publish(ctx, completeEvent(mint, solana.NewWallet().PublicKey())))
+	require.Eventually(t, func() bool { return tracker.Pending() == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, pools.Publish(ctx, migratedPool(mint)))
+	require.Eventually(t, func() bool { return len(store.recorded()) == 1 }, time.Second, time.Millisecond)
+
+	bus.Close()
+	require.NoError(t, <-done)
+}
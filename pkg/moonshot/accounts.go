@@ -0,0 +1,92 @@
+// Package moonshot reads Moonshot token_launchpad curves, builds buy and sell instructions and parses the
+// program events. Its quotes are experimental: the curve formula they use has not been verified on chain.
+package moonshot
+
+import (
+	"context"
+	"fmt"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultProgramID is the Moonshot token_launchpad program on mainnet
+	DefaultProgramID = "MoonCVVNZFSYkqNXP6bxHLPL6QQJiMagDL3qcqUQTrG"
+
+	// ConfigAccountSeed is the PDA seed of the program ConfigAccount
+	ConfigAccountSeed = "config_account"
+
+	// CurveAccountSeed is the PDA seed of the curve of a mint, followed by the mint
+	CurveAccountSeed = "token"
+)
+
+// ConfigAccount holds the fee accounts, the fee rate and the migration threshold shared by every curve
+type ConfigAccount = moonidl.ConfigAccount
+
+// CurveAccount holds the supply of one token and the part of it still held by its curve
+type CurveAccount = moonidl.CurveAccount
+
+// DecodeConfigAccount decodes the data of the ConfigAccount
+func DecodeConfigAccount(data []byte) (*ConfigAccount, error) {
+	return moonidl.DecodeConfigAccount(data)
+}
+
+// DecodeCurveAccount decodes the data of a CurveAccount
+func DecodeCurveAccount(data []byte) (*CurveAccount, error) {
+	return moonidl.DecodeCurveAccount(data)
+}
+
+// FindConfigAddress derives the ConfigAccount of the program
+func FindConfigAddress(programID solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(ConfigAccountSeed)}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive config address: %w", err)
+	}
+	return address, nil
+}
+
+// FindCurveAddress derives the CurveAccount of a mint
+func FindCurveAddress(programID, mint solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(CurveAccountSeed), mint.Bytes()}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive curve address for mint %s: %w", mint, err)
+	}
+	return address, nil
+}
+
+// FetchConfig reads the ConfigAccount of the program
+func FetchConfig(ctx context.Context, client utils.RPCClientInterface, programID solana.PublicKey) (*ConfigAccount, error) {
+	address, err := FindConfigAddress(programID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := utils.FetchAccount(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+	config, err := DecodeConfigAccount(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode config account %s: %w", address, err)
+	}
+	return config, nil
+}
+
+// FetchCurve reads the curve of a mint. Curves are closed once migrated.
+func FetchCurve(ctx context.Context, client utils.RPCClientInterface, programID, mint solana.PublicKey) (*CurveAccount, error) {
+	address, err := FindCurveAddress(programID, mint)
+	if err != nil {
+		return nil, err
+	}
+	account, err := utils.FetchAccount(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := DecodeCurveAccount(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode curve account %s: %w", address, err)
+	}
+	return curve, nil
+}
@@ -0,0 +1,75 @@
+package moonshot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+type (
+	// TradeEvent reports a buy or sell along a curve and the fees paid
+	TradeEvent = moonidl.TradeEvent
+
+	// MigrationEvent reports that a curve moved its tokens and collateral out to be listed on a DEX.
+	// It carries neither the mint nor the curve, which ParseMigrateFunds reads from the transaction.
+	MigrationEvent = moonidl.MigrationEvent
+)
+
+// Event is an event emitted by the Moonshot program. Event holds *TradeEvent or *MigrationEvent.
+type Event struct {
+	Signature string
+	Slot      uint64
+	Name      string
+	Event     interface{}
+}
+
+// DecodeEvent decodes the base64 payload of a "Program data:" line by its discriminator.
+// The returned name is empty when the discriminator is not a trade or migration event.
+func DecodeEvent(encoded string) (string, interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode program data base64: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(data, moonidl.TradeEventDiscriminator):
+		event, err := moonidl.DecodeTradeEvent(data)
+		return "TradeEvent", event, err
+	case bytes.HasPrefix(data, moonidl.MigrationEventDiscriminator):
+		event, err := moonidl.DecodeMigrationEvent(data)
+		return "MigrationEvent", event, err
+	}
+	return "", nil, nil
+}
+
+// ParseEvents decodes the events the Moonshot program emitted in a log notification, in log order.
+// Only data logged while the program is on top of the invoke stack is decoded: pump.fun shares the
+// TradeEvent discriminator, so the program check is what tells the two apart.
+func ParseEvents(logMsg *ws.LogResult, programID solana.PublicKey) ([]*Event, error) {
+	signature := logMsg.Value.Signature.String()
+
+	var events []*Event
+	for _, data := range utils.ProgramData(logMsg.Value.Logs, programID.String()) {
+		name, event, err := DecodeEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", signature, err)
+		}
+		if name == "" {
+			continue
+		}
+		events = append(events, &Event{
+			Signature: signature,
+			Slot:      logMsg.Context.Slot,
+			Name:      name,
+			Event:     event,
+		})
+	}
+	return events, nil
+}
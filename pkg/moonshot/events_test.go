@@ -0,0 +1,192 @@
+package moonshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// programData encodes an event the way emit! logs it
+func programData(t *testing.T, discriminator []byte, event interface{}) string {
+	t.Helper()
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(event))
+	return utils.ProgramDataPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestParseEvents(t *testing.T) {
+	program := DefaultProgramID
+	pump := "6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"
+
+	trade := TradeEvent{
+		Amount:           1_000_000_000_000_000,
+		CollateralAmount: 251_250_000,
+		DexFee:           1_507_500,
+		HelioFee:         1_005_000,
+		Curve:            solana.NewWallet().PublicKey(),
+		CostToken:        solana.SolMint,
+		Sender:           solana.NewWallet().PublicKey(),
+		Type:             moonidl.TradeTypeBuy,
+		Label:            "moonshot",
+	}
+	migration := MigrationEvent{TokensMigrated: 200_000_000_000_000_000, CollateralMigrated: 500_000_000_000, Fee: 4_000_000_000, Label: "raydium"}
+
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = 304027100
+	logMsg.Value.Signature = solana.Signature{9}
+	logMsg.Value.Logs = []string{
+		// pump.fun shares the TradeEvent discriminator
+		"Program " + pump + " invoke [1]",
+		programData(t, pumpidl.TradeEventDiscriminator, pumpidl.TradeEvent{Mint: solana.NewWallet().PublicKey()}),
+		"Program " + pump + " success",
+		"Program " + program + " invoke [1]",
+		"Program log: Instruction: Buy",
+		programData(t, moonidl.TradeEventDiscriminator, trade),
+		"Program " + program + " success",
+		"Program " + program + " invoke [1]",
+		"Program log: Instruction: MigrateFunds",
+		programData(t, moonidl.MigrationEventDiscriminator, migration),
+		"Program " + program + " success",
+	}
+
+	events, err := ParseEvents(logMsg, solana.MustPublicKeyFromBase58(program))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "TradeEvent", events[0].Name)
+	assert.Equal(t, &trade, events[0].Event)
+	assert.Equal(t, "MigrationEvent", events[1].Name)
+	assert.Equal(t, &migration, events[1].Event)
+	assert.Equal(t, solana.Signature{9}.String(), events[1].Signature)
+	assert.Equal(t, uint64(304027100), events[1].Slot)
+
+	_, _, err = DecodeEvent("not base64!")
+	assert.Error(t, err)
+}
+
+// buildMigrateTransaction returns the getTransaction result of a migrateFunds, invoked through a
+// migration program when cpi is set
+func buildMigrateTransaction(t *testing.T, accounts moonidl.MigrateFundsAccounts, cpi bool, logs []string) *rpc.GetTransactionResult {
+	t.Helper()
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+
+	migrateIx, err := moonidl.NewMigrateFundsInstruction(programID, accounts)
+	require.NoError(t, err)
+	migrateData, err := migrateIx.Data()
+	require.NoError(t, err)
+
+	topLevel := migrateIx
+	if cpi {
+		metas := append(migrateIx.Accounts(), solana.NewAccountMeta(programID, false, false))
+		topLevel = solana.NewInstruction(solana.NewWallet().PublicKey(), metas, []byte{1})
+	}
+	tx, err := solana.NewTransaction([]solana.Instruction{topLevel}, solana.Hash{}, solana.TransactionPayer(accounts.MigrationAuthority))
+	require.NoError(t, err)
+	tx.Signatures = []solana.Signature{{3}}
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	meta := &rpc.TransactionMeta{LogMessages: logs}
+	if cpi {
+		indexOf := func(key solana.PublicKey) uint16 {
+			for k, candidate := range tx.Message.AccountKeys {
+				if candidate.Equals(key) {
+					return uint16(k)
+				}
+			}
+			t.Fatalf("key %s is not in the transaction", key)
+			return 0
+		}
+		inner := solana.CompiledInstruction{ProgramIDIndex: indexOf(programID), Data: migrateData}
+		for _, account := range migrateIx.Accounts() {
+			inner.Accounts = append(inner.Accounts, indexOf(account.PublicKey))
+		}
+		meta.InnerInstructions = []rpc.InnerInstruction{{Index: 0, Instructions: []solana.CompiledInstruction{inner}}}
+	}
+
+	var envelope rpc.TransactionResultEnvelope
+	encoded := fmt.Sprintf("[%q, \"base64\"]", base64.StdEncoding.EncodeToString(raw))
+	require.NoError(t, json.Unmarshal([]byte(encoded), &envelope))
+	blockTime := solana.UnixTimeSeconds(1732752205)
+	return &rpc.GetTransactionResult{Slot: 304027200, BlockTime: &blockTime, Transaction: &envelope, Meta: meta}
+}
+
+func testMigrateAccounts() moonidl.MigrateFundsAccounts {
+	return moonidl.MigrateFundsAccounts{
+		BackendAuthority:               solana.NewWallet().PublicKey(),
+		MigrationAuthority:             solana.NewWallet().PublicKey(),
+		CurveAccount:                   solana.NewWallet().PublicKey(),
+		CurveTokenAccount:              solana.NewWallet().PublicKey(),
+		MigrationAuthorityTokenAccount: solana.NewWallet().PublicKey(),
+		Mint:                           solana.NewWallet().PublicKey(),
+		ConfigAccount:                  solana.NewWallet().PublicKey(),
+		SystemProgram:                  solana.SystemProgramID,
+		TokenProgram:                   solana.TokenProgramID,
+		AssociatedTokenProgram:         solana.SPLAssociatedTokenAccountProgramID,
+	}
+}
+
+func TestParseMigrateFunds(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	event := MigrationEvent{TokensMigrated: 200_000_000_000_000_000, CollateralMigrated: 500_000_000_000, Label: "raydium"}
+	logs := []string{
+		"Program " + DefaultProgramID + " invoke [1]",
+		"Program log: Instruction: MigrateFunds",
+		programData(t, moonidl.MigrationEventDiscriminator, event),
+		"Program " + DefaultProgramID + " success",
+	}
+
+	for _, cpi := range []bool{false, true} {
+		accounts := testMigrateAccounts()
+		migration, err := ParseMigrateFunds(buildMigrateTransaction(t, accounts, cpi, logs), programID)
+		require.NoError(t, err)
+		assert.Equal(t, accounts.Mint, migration.Mint)
+		assert.Equal(t, accounts.CurveAccount, migration.Curve)
+		assert.Equal(t, accounts.MigrationAuthority, migration.Authority)
+		assert.Equal(t, solana.Signature{3}.String(), migration.Signature)
+		assert.Equal(t, uint64(304027200), migration.Slot)
+		assert.Equal(t, int64(1732752205), migration.BlockTime)
+		assert.Equal(t, &event, migration.Event)
+	}
+
+	// Transactions of other programs hold no migration
+	_, err := ParseMigrateFunds(buildMigrateTransaction(t, testMigrateAccounts(), false, nil), solana.NewWallet().PublicKey())
+	assert.ErrorIs(t, err, ErrNoMigration)
+}
+
+func TestFetchMigrationRetriesUntilConfirmed(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	accounts := testMigrateAccounts()
+	result := buildMigrateTransaction(t, accounts, false, nil)
+
+	calls := 0
+	client := &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			calls++
+			if calls == 1 {
+				return nil, rpc.ErrNotFound
+			}
+			return result, nil
+		},
+	}
+
+	migration, err := FetchMigration(context.Background(), client, programID, solana.Signature{3})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, accounts.Mint, migration.Mint)
+	assert.Nil(t, migration.Event, "no logs to read the event from")
+}
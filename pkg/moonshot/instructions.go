@@ -0,0 +1,100 @@
+package moonshot
+
+import (
+	"fmt"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// tradeAccounts holds the accounts shared by buy and sell, in IDL order
+type tradeAccounts struct {
+	senderTokenAccount solana.PublicKey
+	curveAccount       solana.PublicKey
+	curveTokenAccount  solana.PublicKey
+	configAccount      solana.PublicKey
+}
+
+// TradeParams are the amounts of a trade: the tokens traded, the collateral the sender expects to pay or
+// receive, fees excluded, and how far in bps the program may move from it
+type TradeParams = moonidl.TradeParams
+
+// NewBuyInstruction builds a buy of params.Amount tokens. The program rejects it when the collateral it
+// computes is more than params.SlippageBps away from params.CollateralAmount, which the caller sets from a
+// price it trusts: the experimental quotes of this package are not one. The fee accounts and the backend
+// authority come from the ConfigAccount; like the IDL, the instruction expects the backend authority to
+// co-sign the transaction.
+func NewBuyInstruction(programID solana.PublicKey, config *ConfigAccount, mint, sender solana.PublicKey, params TradeParams) (solana.Instruction, error) {
+	accounts, err := deriveTradeAccounts(programID, mint, sender)
+	if err != nil {
+		return nil, err
+	}
+	return moonidl.NewBuyInstruction(programID,
+		moonidl.BuyArgs{Data: params},
+		moonidl.BuyAccounts{
+			Sender:                 sender,
+			BackendAuthority:       config.BackendAuthority,
+			SenderTokenAccount:     accounts.senderTokenAccount,
+			CurveAccount:           accounts.curveAccount,
+			CurveTokenAccount:      accounts.curveTokenAccount,
+			DexFee:                 config.DexFee,
+			HelioFee:               config.HelioFee,
+			Mint:                   mint,
+			ConfigAccount:          accounts.configAccount,
+			TokenProgram:           solana.TokenProgramID,
+			AssociatedTokenProgram: solana.SPLAssociatedTokenAccountProgramID,
+			SystemProgram:          solana.SystemProgramID,
+		})
+}
+
+// NewSellInstruction builds a sale of params.Amount tokens, bounded like NewBuyInstruction and with the same
+// accounts
+func NewSellInstruction(programID solana.PublicKey, config *ConfigAccount, mint, sender solana.PublicKey, params TradeParams) (solana.Instruction, error) {
+	accounts, err := deriveTradeAccounts(programID, mint, sender)
+	if err != nil {
+		return nil, err
+	}
+	return moonidl.NewSellInstruction(programID,
+		moonidl.SellArgs{Data: params},
+		moonidl.SellAccounts{
+			Sender:                 sender,
+			BackendAuthority:       config.BackendAuthority,
+			SenderTokenAccount:     accounts.senderTokenAccount,
+			CurveAccount:           accounts.curveAccount,
+			CurveTokenAccount:      accounts.curveTokenAccount,
+			DexFee:                 config.DexFee,
+			HelioFee:               config.HelioFee,
+			Mint:                   mint,
+			ConfigAccount:          accounts.configAccount,
+			TokenProgram:           solana.TokenProgramID,
+			AssociatedTokenProgram: solana.SPLAssociatedTokenAccountProgramID,
+			SystemProgram:          solana.SystemProgramID,
+		})
+}
+
+func deriveTradeAccounts(programID, mint, sender solana.PublicKey) (*tradeAccounts, error) {
+	curveAccount, err := FindCurveAddress(programID, mint)
+	if err != nil {
+		return nil, err
+	}
+	curveTokenAccount, _, err := solana.FindAssociatedTokenAddress(curveAccount, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive curve token account: %w", err)
+	}
+	senderTokenAccount, _, err := solana.FindAssociatedTokenAddress(sender, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive sender token account: %w", err)
+	}
+	configAccount, err := FindConfigAddress(programID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tradeAccounts{
+		senderTokenAccount: senderTokenAccount,
+		curveAccount:       curveAccount,
+		curveTokenAccount:  curveTokenAccount,
+		configAccount:      configAccount,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package moonshot
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func instructionKeys(instruction solana.Instruction) []solana.PublicKey {
+	keys := make([]solana.PublicKey, 0, len(instruction.Accounts()))
+	for _, account := range instruction.Accounts() {
+		keys = append(keys, account.PublicKey)
+	}
+	return keys
+}
+
+func TestNewBuyInstruction(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	config, curve := newTestCurve()
+	config.BackendAuthority = solana.NewWallet().PublicKey()
+	sender := solana.NewWallet().PublicKey()
+
+	params := TradeParams{Amount: 1_000_000_000_000_000, CollateralAmount: 2_500_000_000, SlippageBps: 100}
+	instruction, err := NewBuyInstruction(programID, config, curve.Mint, sender, params)
+	require.NoError(t, err)
+	assert.Equal(t, programID, instruction.ProgramID())
+
+	data, err := instruction.Data()
+	require.NoError(t, err)
+	args, err := moonidl.DecodeBuyArgs(data)
+	require.NoError(t, err)
+	assert.Equal(t, params, args.Data)
+
+	accounts, err := moonidl.DecodeBuyAccounts(instructionKeys(instruction))
+	require.NoError(t, err)
+	curveAccount, err := FindCurveAddress(programID, curve.Mint)
+	require.NoError(t, err)
+	configAccount, err := FindConfigAddress(programID)
+	require.NoError(t, err)
+	curveTokenAccount, _, err := solana.FindAssociatedTokenAddress(curveAccount, curve.Mint)
+	require.NoError(t, err)
+	senderTokenAccount, _, err := solana.FindAssociatedTokenAddress(sender, curve.Mint)
+	require.NoError(t, err)
+
+	assert.Equal(t, sender, accounts.Sender)
+	assert.Equal(t, config.BackendAuthority, accounts.BackendAuthority)
+	assert.Equal(t, config.DexFee, accounts.DexFee)
+	assert.Equal(t, config.HelioFee, accounts.HelioFee)
+	assert.Equal(t, curveAccount, accounts.CurveAccount)
+	assert.Equal(t, curveTokenAccount, accounts.CurveTokenAccount)
+	assert.Equal(t, senderTokenAccount, accounts.SenderTokenAccount)
+	assert.Equal(t, configAccount, accounts.ConfigAccount)
+	assert.True(t, instruction.Accounts()[0].IsSigner, "the sender signs")
+}
+
+func TestNewSellInstruction(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	config, curve := newTestCurve()
+
+	params := TradeParams{Amount: 1_000_000_000_000_000, CollateralAmount: 2_400_000_000, SlippageBps: 50}
+	instruction, err := NewSellInstruction(programID, config, curve.Mint, solana.NewWallet().PublicKey(), params)
+	require.NoError(t, err)
+
+	data, err := instruction.Data()
+	require.NoError(t, err)
+	args, err := moonidl.DecodeSellArgs(data)
+	require.NoError(t, err)
+	assert.Equal(t, params, args.Data)
+}
+
+func TestFetchCurve(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	_, curve := newTestCurve()
+	address, err := FindCurveAddress(programID, curve.Mint)
+	require.NoError(t, err)
+
+	buf := bytes.NewBuffer(append([]byte(nil), moonidl.CurveAccountDiscriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(curve))
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			if !account.Equals(address) {
+				return &rpc.GetAccountInfoResult{}, nil
+			}
+			return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: programID, Data: rpc.DataBytesOrJSONFromBytes(buf.Bytes())}}, nil
+		},
+	}
+
+	fetched, err := FetchCurve(context.Background(), client, programID, curve.Mint)
+	require.NoError(t, err)
+	assert.Equal(t, curve, fetched)
+
+	_, err = FetchConfig(context.Background(), client, programID)
+	assert.ErrorContains(t, err, "no data found")
+}
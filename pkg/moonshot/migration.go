@@ -0,0 +1,90 @@
+package moonshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ErrNoMigration is returned for transactions without a migrateFunds instruction of the program
+var ErrNoMigration = errors.New("no migrateFunds instruction found in transaction")
+
+// Migration is a curve that graduated: its tokens and collateral left the program to seed a DEX pool
+type Migration struct {
+	Signature string
+	Slot      uint64
+	BlockTime int64
+	Mint      solana.PublicKey
+	Curve     solana.PublicKey
+	Authority solana.PublicKey // The migration authority, which creates the DEX pool
+	Event     *MigrationEvent  // nil when the logs were truncated
+}
+
+// FetchMigration fetches a confirmed transaction, retrying while the node has not seen it yet, and parses
+// the migration it performed
+func FetchMigration(ctx context.Context, client utils.RPCClientInterface, programID solana.PublicKey, signature solana.Signature) (*Migration, error) {
+	result, err := utils.FetchTransaction(ctx, client, signature, utils.DefaultFetchAttempts, utils.DefaultFetchDelay)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMigrateFunds(result, programID)
+}
+
+// ParseMigrateFunds reads the mint and curve of the migrateFunds instruction of a fetched transaction,
+// called directly or through CPI, along with the MigrationEvent of its logs
+func ParseMigrateFunds(result *rpc.GetTransactionResult, programID solana.PublicKey) (*Migration, error) {
+	tx, err := utils.DecodeTransaction(result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parsing runs without a client, so only lookup tables the metadata resolved are supported
+	keys, err := utils.TransactionKeys(context.Background(), nil, tx, result.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account keys: %w", err)
+	}
+
+	instruction := utils.FindInstruction(tx, result.Meta, keys, programID, moonidl.MigrateFundsInstructionDiscriminator)
+	if instruction == nil {
+		return nil, ErrNoMigration
+	}
+
+	accountKeys, err := utils.InstructionAccounts(instruction, keys)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := moonidl.DecodeMigrateFundsAccounts(accountKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	migration := &Migration{
+		Slot:      result.Slot,
+		Mint:      accounts.Mint,
+		Curve:     accounts.CurveAccount,
+		Authority: accounts.MigrationAuthority,
+	}
+	if len(tx.Signatures) > 0 {
+		migration.Signature = tx.Signatures[0].String()
+	}
+	if result.BlockTime != nil {
+		migration.BlockTime = int64(*result.BlockTime)
+	}
+	for _, data := range utils.ProgramData(result.Meta.LogMessages, programID.String()) {
+		name, event, err := DecodeEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", migration.Signature, err)
+		}
+		if name == "MigrationEvent" {
+			migration.Event = event.(*MigrationEvent)
+			break
+		}
+	}
+	return migration, nil
+}
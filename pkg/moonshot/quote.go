@@ -0,0 +1,187 @@
+package moonshot
+
+import (
+	"fmt"
+	"math/big"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	"corvus_bot/pkg/utils"
+)
+
+const (
+	// linearPriceScale divides CoefB: the LinearV1 marginal price of a whole token is CoefB·t / linearPriceScale
+	// lamports once t whole tokens have left the curve. Neither the IDL nor a published SDK documents the
+	// formula or the scale, and they have not been checked against on-chain trades.
+	linearPriceScale = 10_000_000
+)
+
+// Quote is the expected result of a trade along a curve.
+//
+// Experimental: quotes rest on the unverified LinearV1 formula of linearPriceScale. They estimate a trade but
+// must not set the collateral a trade instruction is bounded by.
+type Quote struct {
+	Type             moonidl.TradeType
+	TokenAmount      uint64  // Tokens bought or sold, in base units
+	CollateralAmount uint64  // Lamports paid into or out of the curve, fees excluded
+	DexFee           uint64  // Share of the fee paid to ConfigAccount.DexFee
+	HelioFee         uint64  // Rest of the fee, paid to ConfigAccount.HelioFee
+	PriceImpact      float64 // Fraction of the spot price the trade moves, e.g. 0.01 for 1%
+	SlippageBps      uint64  // Tolerance the program enforces against CollateralAmount
+}
+
+// Fee returns the total fee of the trade
+func (q *Quote) Fee() uint64 {
+	return q.DexFee + q.HelioFee
+}
+
+// QuoteBuy returns the collateral needed to buy tokenAmount tokens from the curve. Experimental, see Quote.
+func QuoteBuy(config *ConfigAccount, curve *CurveAccount, tokenAmount, slippageBps uint64) (*Quote, error) {
+	if err := checkTrade(curve, slippageBps); err != nil {
+		return nil, err
+	}
+	if tokenAmount == 0 {
+		return nil, fmt.Errorf("nothing to buy")
+	}
+	if tokenAmount > curve.CurveAmount {
+		return nil, fmt.Errorf("buy of %d tokens exceeds the %d left in the curve", tokenAmount, curve.CurveAmount)
+	}
+
+	sold := tokensSold(curve)
+	collateral, err := collateralBetween(curve, sold, sold+tokenAmount, true)
+	if err != nil {
+		return nil, err
+	}
+	return newQuote(config, moonidl.TradeTypeBuy, tokenAmount, collateral, sold, slippageBps), nil
+}
+
+// QuoteBuyWithCollateral returns the tokens bought by spending collateral lamports, fees included.
+// Experimental, see Quote.
+func QuoteBuyWithCollateral(config *ConfigAccount, curve *CurveAccount, collateral, slippageBps uint64) (*Quote, error) {
+	if err := checkTrade(curve, slippageBps); err != nil {
+		return nil, err
+	}
+
+	// Take the fees out of the budget, then solve B·((s+x)² - s²) / D = budget for x
+	budget := new(big.Int).Mul(u(collateral), big.NewInt(utils.BpsDenominator))
+	budget.Quo(budget, u(utils.BpsDenominator+uint64(config.FeeBps)))
+	sold := u(tokensSold(curve))
+	target := new(big.Int).Mul(budget, curveDenominator(curve))
+	target.Quo(target, u(uint64(curve.CoefB)))
+	target.Add(target, new(big.Int).Mul(sold, sold))
+	amount := new(big.Int).Sub(target.Sqrt(target), sold)
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("%d lamports buy no tokens", collateral)
+	}
+	if !amount.IsUint64() || amount.Uint64() > curve.CurveAmount {
+		amount = u(curve.CurveAmount)
+	}
+
+	return QuoteBuy(config, curve, amount.Uint64(), slippageBps)
+}
+
+// QuoteSell returns the collateral received for selling tokenAmount tokens back to the curve, before fees.
+// Experimental, see Quote.
+func QuoteSell(config *ConfigAccount, curve *CurveAccount, tokenAmount, slippageBps uint64) (*Quote, error) {
+	if err := checkTrade(curve, slippageBps); err != nil {
+		return nil, err
+	}
+	if tokenAmount == 0 {
+		return nil, fmt.Errorf("nothing to sell")
+	}
+
+	sold := tokensSold(curve)
+	if tokenAmount > sold {
+		return nil, fmt.Errorf("sell of %d tokens exceeds the %d sold by the curve", tokenAmount, sold)
+	}
+	collateral, err := collateralBetween(curve, sold-tokenAmount, sold, false)
+	if err != nil {
+		return nil, err
+	}
+	return newQuote(config, moonidl.TradeTypeSell, tokenAmount, collateral, sold, slippageBps), nil
+}
+
+// SpotPrice returns the marginal price of one whole token in SOL. Experimental, see Quote.
+func SpotPrice(curve *CurveAccount) float64 {
+	whole := float64(tokensSold(curve)) / pow10(curve.Decimals)
+	return float64(curve.CoefB) * whole / linearPriceScale / 1e9
+}
+
+func newQuote(config *ConfigAccount, tradeType moonidl.TradeType, tokenAmount, collateral, sold, slippageBps uint64) *Quote {
+	fee := new(big.Int).Mul(u(collateral), u(uint64(config.FeeBps)))
+	fee.Quo(fee, big.NewInt(utils.BpsDenominator))
+	dexFee := new(big.Int).Mul(fee, u(uint64(config.DexFeeShare)))
+	dexFee.Quo(dexFee, big.NewInt(100))
+
+	// The marginal price is proportional to the tokens sold
+	impact := 0.0
+	if sold > 0 {
+		impact = float64(tokenAmount) / float64(sold)
+	}
+	return &Quote{
+		Type:             tradeType,
+		TokenAmount:      tokenAmount,
+		CollateralAmount: collateral,
+		DexFee:           dexFee.Uint64(),
+		HelioFee:         fee.Uint64() - dexFee.Uint64(),
+		PriceImpact:      impact,
+		SlippageBps:      slippageBps,
+	}
+}
+
+func checkTrade(curve *CurveAccount, slippageBps uint64) error {
+	if curve.CurveType != moonidl.CurveTypeLinearV1 {
+		return fmt.Errorf("unsupported curve type %d", curve.CurveType)
+	}
+	if curve.CoefB == 0 {
+		return fmt.Errorf("curve has no price coefficient")
+	}
+	if slippageBps > utils.BpsDenominator {
+		return fmt.Errorf("invalid slippage: %d bps", slippageBps)
+	}
+	return nil
+}
+
+// tokensSold is the part of the supply that has left the curve
+func tokensSold(curve *CurveAccount) uint64 {
+	if curve.CurveAmount > curve.TotalSupply {
+		return 0
+	}
+	return curve.TotalSupply - curve.CurveAmount
+}
+
+// collateralBetween integrates the linear price from from to to tokens sold, in base units:
+// B·(to² - from²) / D lamports
+func collateralBetween(curve *CurveAccount, from, to uint64, roundUp bool) (uint64, error) {
+	area := new(big.Int).Mul(u(to), u(to))
+	area.Sub(area, new(big.Int).Mul(u(from), u(from)))
+	area.Mul(area, u(uint64(curve.CoefB)))
+
+	denominator := curveDenominator(curve)
+	collateral, remainder := new(big.Int).QuoRem(area, denominator, new(big.Int))
+	if roundUp && remainder.Sign() != 0 {
+		collateral.Add(collateral, big.NewInt(1))
+	}
+	if !collateral.IsUint64() {
+		return 0, fmt.Errorf("collateral overflows u64")
+	}
+	return collateral.Uint64(), nil
+}
+
+// curveDenominator is D = 2·linearPriceScale·10^(2·decimals), turning the integral over base units into lamports
+func curveDenominator(curve *CurveAccount) *big.Int {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(curve.Decimals)), nil)
+	denominator := new(big.Int).Mul(unit, unit)
+	return denominator.Mul(denominator, big.NewInt(2*linearPriceScale))
+}
+
+func pow10(decimals uint8) float64 {
+	v := 1.0
+	for k := uint8(0); k < decimals; k++ {
+		v *= 10
+	}
+	return v
+}
+
+func u(v uint64) *big.Int {
+	return new(big.Int).SetUint64(v)
+}
@@ -0,0 +1,97 @@
+package moonshot
+
+import (
+	"testing"
+
+	moonidl "corvus_bot/pkg/idl/bindings/moonshot"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCurve returns a LinearV1 curve of a 9 decimals token that sold 100M of its 1B tokens
+func newTestCurve() (*ConfigAccount, *CurveAccount) {
+	config := &ConfigAccount{
+		DexFee:      solana.NewWallet().PublicKey(),
+		HelioFee:    solana.NewWallet().PublicKey(),
+		FeeBps:      100,
+		DexFeeShare: 60,
+		CoefB:       25,
+	}
+	curve := &CurveAccount{
+		TotalSupply: 1_000_000_000_000_000_000,
+		CurveAmount: 900_000_000_000_000_000,
+		Mint:        solana.NewWallet().PublicKey(),
+		Decimals:    9,
+		CurveType:   moonidl.CurveTypeLinearV1,
+		CoefB:       25,
+	}
+	return config, curve
+}
+
+func TestQuoteBuy(t *testing.T) {
+	config, curve := newTestCurve()
+
+	quote, err := QuoteBuy(config, curve, 1_000_000_000_000_000, 100)
+	require.NoError(t, err)
+	assert.Equal(t, moonidl.TradeTypeBuy, quote.Type)
+	assert.Equal(t, uint64(1_000_000_000_000_000), quote.TokenAmount)
+	assert.Equal(t, uint64(251_250_000), quote.CollateralAmount)
+	assert.Equal(t, uint64(1_507_500), quote.DexFee)
+	assert.Equal(t, uint64(1_005_000), quote.HelioFee)
+	assert.Equal(t, uint64(2_512_500), quote.Fee())
+	assert.Equal(t, uint64(100), quote.SlippageBps)
+	assert.InDelta(t, 0.01, quote.PriceImpact, 1e-9)
+
+	_, err = QuoteBuy(config, curve, curve.CurveAmount+1, 100)
+	assert.Error(t, err, "more than the curve holds")
+	_, err = QuoteBuy(config, curve, 0, 100)
+	assert.Error(t, err)
+}
+
+func TestQuoteSell(t *testing.T) {
+	config, curve := newTestCurve()
+
+	quote, err := QuoteSell(config, curve, 1_000_000_000_000_000, 50)
+	require.NoError(t, err)
+	assert.Equal(t, moonidl.TradeTypeSell, quote.Type)
+	assert.Equal(t, uint64(248_750_000), quote.CollateralAmount, "below the buy of the same tokens")
+	assert.Equal(t, uint64(1_492_500), quote.DexFee)
+	assert.Equal(t, uint64(995_000), quote.HelioFee)
+
+	_, err = QuoteSell(config, curve, 100_000_000_000_000_001, 50)
+	assert.Error(t, err, "more than the curve sold")
+}
+
+func TestQuoteBuyWithCollateral(t *testing.T) {
+	config, curve := newTestCurve()
+
+	quote, err := QuoteBuyWithCollateral(config, curve, 1_000_000_000, 100)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3_884_932_532_104_962), quote.TokenAmount)
+	assert.Equal(t, uint64(990_099_009), quote.CollateralAmount)
+	assert.LessOrEqual(t, quote.CollateralAmount+quote.Fee(), uint64(1_000_000_000), "fees fit in the budget")
+
+	// A budget beyond the curve buys what is left
+	quote, err = QuoteBuyWithCollateral(config, curve, 1_000_000_000_000_000, 100)
+	require.NoError(t, err)
+	assert.Equal(t, curve.CurveAmount, quote.TokenAmount)
+}
+
+func TestQuoteChecksCurve(t *testing.T) {
+	config, curve := newTestCurve()
+
+	_, err := QuoteBuy(config, curve, 1, utils.BpsDenominator+1)
+	assert.Error(t, err, "slippage above 100%")
+
+	curve.CurveType = moonidl.CurveType(1)
+	_, err = QuoteBuy(config, curve, 1, 100)
+	assert.ErrorContains(t, err, "unsupported curve type")
+}
+
+func TestSpotPrice(t *testing.T) {
+	_, curve := newTestCurve()
+	assert.InDelta(t, 2.5e-7, SpotPrice(curve), 1e-15)
+}
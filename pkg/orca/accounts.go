@@ -0,0 +1,177 @@
+// Package orca reads Orca Whirlpool pools and their tick arrays, quotes swaps with the program's
+// concentrated liquidity math, builds swapV2 instructions and maps pools to the database model.
+package orca
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"corvus_bot/pkg/idl/bindings/whirlpool"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultProgramID is the Whirlpool program on mainnet and devnet
+	DefaultProgramID = "whirLbMiicVdio4qvUfM5KAg6Ct8VwpYzGff3uctyCc"
+
+	// TickArraySeed is the PDA seed of a tick array, followed by the pool and the start tick index in decimal
+	TickArraySeed = "tick_array"
+
+	// OracleSeed is the PDA seed of the oracle account of a pool, followed by the pool
+	OracleSeed = "oracle"
+
+	// TickArraySize is the number of ticks in a tick array
+	TickArraySize = 88
+
+	// mintDecimalsOffset is the offset of the decimals in SPL Token and Token-2022 mint accounts
+	mintDecimalsOffset = 44
+)
+
+type (
+	// Whirlpool is the state of a pool: its mints and vaults, fee rates, price and active liquidity
+	Whirlpool = whirlpool.Whirlpool
+
+	// TickArray holds TickArraySize consecutive ticks of a pool, starting at StartTickIndex
+	TickArray = whirlpool.TickArray
+
+	// Tick is the liquidity change and fee growth recorded at one initializable tick
+	Tick = whirlpool.Tick
+)
+
+// Pool is a Whirlpool with the mint details the account does not record
+type Pool struct {
+	Address       solana.PublicKey
+	ProgramID     solana.PublicKey
+	State         *Whirlpool
+	DecimalsA     uint8
+	DecimalsB     uint8
+	TokenProgramA solana.PublicKey // Token or Token-2022, the owner of mint A
+	TokenProgramB solana.PublicKey
+}
+
+// DecodeWhirlpool decodes the data of a Whirlpool account
+func DecodeWhirlpool(data []byte) (*Whirlpool, error) {
+	return whirlpool.DecodeWhirlpool(data)
+}
+
+// DecodeTickArray decodes the data of a TickArray account
+func DecodeTickArray(data []byte) (*TickArray, error) {
+	return whirlpool.DecodeTickArray(data)
+}
+
+// FindTickArrayAddress derives the tick array of a pool starting at startTickIndex
+func FindTickArrayAddress(programID, pool solana.PublicKey, startTickIndex int32) (solana.PublicKey, error) {
+	seeds := [][]byte{[]byte(TickArraySeed), pool.Bytes(), []byte(strconv.Itoa(int(startTickIndex)))}
+	address, _, err := solana.FindProgramAddress(seeds, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive tick array address for %d: %w", startTickIndex, err)
+	}
+	return address, nil
+}
+
+// FindOracleAddress derives the oracle account of a pool, which swapV2 expects even when it is not initialized
+func FindOracleAddress(programID, pool solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(OracleSeed), pool.Bytes()}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive oracle address: %w", err)
+	}
+	return address, nil
+}
+
+// FetchPool reads a Whirlpool and its two mints
+func FetchPool(ctx context.Context, client utils.RPCClientInterface, programID, address solana.PublicKey) (*Pool, error) {
+	accounts, err := utils.FetchAccounts(ctx, client, []solana.PublicKey{address})
+	if err != nil {
+		return nil, err
+	}
+	if accounts[0] == nil {
+		return nil, fmt.Errorf("whirlpool %s not found", address)
+	}
+	if !accounts[0].Owner.Equals(programID) {
+		return nil, fmt.Errorf("whirlpool %s is owned by %s, not %s", address, accounts[0].Owner, programID)
+	}
+	state, err := DecodeWhirlpool(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode whirlpool %s: %w", address, err)
+	}
+
+	mints, err := utils.FetchAccounts(ctx, client, []solana.PublicKey{state.TokenMintA, state.TokenMintB})
+	if err != nil {
+		return nil, err
+	}
+	pool := &Pool{Address: address, ProgramID: programID, State: state}
+	for k, mint := range mints {
+		if mint == nil || len(mint.Data.GetBinary()) <= mintDecimalsOffset {
+			return nil, fmt.Errorf("mint %d of whirlpool %s not found", k, address)
+		}
+		decimals := mint.Data.GetBinary()[mintDecimalsOffset]
+		if k == 0 {
+			pool.DecimalsA, pool.TokenProgramA = decimals, mint.Owner
+		} else {
+			pool.DecimalsB, pool.TokenProgramB = decimals, mint.Owner
+		}
+	}
+	return pool, nil
+}
+
+// FetchTickArrays reads the tick arrays of a pool starting at startIndexes. Arrays that were never
+// initialized are returned as nil.
+func FetchTickArrays(ctx context.Context, client utils.RPCClientInterface, programID, pool solana.PublicKey, startIndexes []int32) ([]*TickArray, error) {
+	addresses := make([]solana.PublicKey, len(startIndexes))
+	for k, startIndex := range startIndexes {
+		address, err := FindTickArrayAddress(programID, pool, startIndex)
+		if err != nil {
+			return nil, err
+		}
+		addresses[k] = address
+	}
+
+	accounts, err := utils.FetchAccounts(ctx, client, addresses)
+	if err != nil {
+		return nil, err
+	}
+	arrays := make([]*TickArray, len(accounts))
+	for k, account := range accounts {
+		if account == nil {
+			continue
+		}
+		if arrays[k], err = DecodeTickArray(account.Data.GetBinary()); err != nil {
+			return nil, fmt.Errorf("failed to decode tick array %s: %w", addresses[k], err)
+		}
+	}
+	return arrays, nil
+}
+
+// tickArrayStartIndex returns the start of the tick array holding tick
+func tickArrayStartIndex(tick int32, tickSpacing uint16) int32 {
+	ticksInArray := int32(tickSpacing) * TickArraySize
+	start := tick / ticksInArray
+	if tick < 0 && tick%ticksInArray != 0 {
+		start--
+	}
+	return start * ticksInArray
+}
+
+// SwapTickArrayStartIndexes returns the start indexes of the three tick arrays a swap from tickCurrent
+// traverses. Swaps from B to A start searching one tick spacing higher, like the program does.
+func SwapTickArrayStartIndexes(tickCurrent int32, tickSpacing uint16, aToB bool) []int32 {
+	ticksInArray := int32(tickSpacing) * TickArraySize
+	shift := int32(0)
+	if !aToB {
+		shift = int32(tickSpacing)
+	}
+	start := tickArrayStartIndex(tickCurrent+shift, tickSpacing)
+
+	indexes := make([]int32, 0, 3)
+	for k := int32(0); k < 3; k++ {
+		if aToB {
+			indexes = append(indexes, start-k*ticksInArray)
+		} else {
+			indexes = append(indexes, start+k*ticksInArray)
+		}
+	}
+	return indexes
+}
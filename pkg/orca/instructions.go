@@ -0,0 +1,100 @@
+package orca
+
+import (
+	"fmt"
+	"math/big"
+
+	"corvus_bot/pkg/idl/bindings/whirlpool"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// NewSwapV2Instruction builds the swapV2 matching the quote, trading from and to the owner's associated
+// token accounts. The price is only bounded by the quote's threshold.
+func NewSwapV2Instruction(pool *Pool, quote *SwapQuote, owner solana.PublicKey) (solana.Instruction, error) {
+	if pool == nil || pool.State == nil {
+		return nil, fmt.Errorf("pool data is nil")
+	}
+	if quote == nil {
+		return nil, fmt.Errorf("quote is nil")
+	}
+
+	tokenProgramA, tokenProgramB := tokenProgram(pool.TokenProgramA), tokenProgram(pool.TokenProgramB)
+	ownerAccountA, err := associatedTokenAddress(owner, pool.State.TokenMintA, tokenProgramA)
+	if err != nil {
+		return nil, err
+	}
+	ownerAccountB, err := associatedTokenAddress(owner, pool.State.TokenMintB, tokenProgramB)
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := FindOracleAddress(pool.ProgramID, pool.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	args := whirlpool.SwapV2Args{
+		OtherAmountThreshold:   quote.OtherAmountThreshold,
+		SqrtPriceLimit:         toU128(sqrtPriceLimitArg(quote.AToB)),
+		AmountSpecifiedIsInput: quote.IsInput,
+		AToB:                   quote.AToB,
+	}
+	if quote.IsInput {
+		args.Amount = quote.AmountIn
+	} else {
+		args.Amount = quote.AmountOut
+	}
+
+	return whirlpool.NewSwapV2Instruction(pool.ProgramID, args, whirlpool.SwapV2Accounts{
+		TokenProgramA:      tokenProgramA,
+		TokenProgramB:      tokenProgramB,
+		MemoProgram:        solana.MemoProgramID,
+		TokenAuthority:     owner,
+		Whirlpool:          pool.Address,
+		TokenMintA:         pool.State.TokenMintA,
+		TokenMintB:         pool.State.TokenMintB,
+		TokenOwnerAccountA: ownerAccountA,
+		TokenVaultA:        pool.State.TokenVaultA,
+		TokenOwnerAccountB: ownerAccountB,
+		TokenVaultB:        pool.State.TokenVaultB,
+		TickArray0:         quote.TickArrays[0],
+		TickArray1:         quote.TickArrays[1],
+		TickArray2:         quote.TickArrays[2],
+		Oracle:             oracle,
+	})
+}
+
+// sqrtPriceLimitArg is the end of the curve in the swap direction, which the program treats as no limit
+func sqrtPriceLimitArg(aToB bool) *big.Int {
+	if aToB {
+		return MinSqrtPriceX64
+	}
+	return MaxSqrtPriceX64
+}
+
+// tokenProgram defaults pools built without their mint owners to the original token program
+func tokenProgram(program solana.PublicKey) solana.PublicKey {
+	if program.IsZero() {
+		return solana.TokenProgramID
+	}
+	return program
+}
+
+func associatedTokenAddress(owner, mint, tokenProgram solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress(
+		[][]byte{owner[:], tokenProgram[:], mint[:]},
+		solana.SPLAssociatedTokenAccountProgramID,
+	)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive token account for mint %s: %w", mint, err)
+	}
+	return address, nil
+}
+
+// toU128 converts a sqrt price to the instruction's u128
+func toU128(v *big.Int) bin.Uint128 {
+	lo := new(big.Int).And(v, maxU64)
+	hi := new(big.Int).Rsh(v, 64)
+	return bin.Uint128{Lo: lo.Uint64(), Hi: hi.Uint64()}
+}
@@ -0,0 +1,125 @@
+package orca
+
+import (
+	"context"
+	"testing"
+
+	"corvus_bot/pkg/database/models"
+	"corvus_bot/pkg/idl/bindings/whirlpool"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSwapV2Instruction(t *testing.T) {
+	pool, arrays := newTestPool()
+	owner := solana.NewWallet().PublicKey()
+	quote, err := QuoteWithState(pool, swapState(pool, arrays, true), pool.State.TokenMintA, 1_000_000_000, 50, true)
+	require.NoError(t, err)
+
+	instruction, err := NewSwapV2Instruction(pool, quote, owner)
+	require.NoError(t, err)
+	assert.Equal(t, pool.ProgramID, instruction.ProgramID())
+
+	data, err := instruction.Data()
+	require.NoError(t, err)
+	args, err := whirlpool.DecodeSwapV2Args(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000_000_000), args.Amount)
+	assert.Equal(t, quote.OtherAmountThreshold, args.OtherAmountThreshold)
+	assert.Equal(t, MinSqrtPriceX64, args.SqrtPriceLimit.BigInt())
+	assert.True(t, args.AmountSpecifiedIsInput)
+	assert.True(t, args.AToB)
+	assert.Nil(t, args.RemainingAccountsInfo)
+
+	metas := instruction.Accounts()
+	keys := make([]solana.PublicKey, len(metas))
+	for k, meta := range metas {
+		keys[k] = meta.PublicKey
+	}
+	accounts, err := whirlpool.DecodeSwapV2Accounts(keys)
+	require.NoError(t, err)
+	ownerAccountA, _, err := solana.FindAssociatedTokenAddress(owner, pool.State.TokenMintA)
+	require.NoError(t, err)
+	oracle, err := FindOracleAddress(pool.ProgramID, pool.Address)
+	require.NoError(t, err)
+
+	assert.Equal(t, solana.TokenProgramID, accounts.TokenProgramA)
+	assert.Equal(t, solana.Token2022ProgramID, accounts.TokenProgramB)
+	assert.Equal(t, owner, accounts.TokenAuthority)
+	assert.Equal(t, pool.Address, accounts.Whirlpool)
+	assert.Equal(t, ownerAccountA, accounts.TokenOwnerAccountA)
+	assert.NotEqual(t, ownerAccountA, accounts.TokenOwnerAccountB)
+	assert.Equal(t, pool.State.TokenVaultB, accounts.TokenVaultB)
+	assert.Equal(t, quote.TickArrays[0], accounts.TickArray0)
+	assert.Equal(t, quote.TickArrays[2], accounts.TickArray2)
+	assert.Equal(t, oracle, accounts.Oracle)
+	assert.True(t, metas[3].IsSigner)
+
+	_, err = NewSwapV2Instruction(pool, nil, owner)
+	assert.Error(t, err)
+}
+
+func TestFetchPool(t *testing.T) {
+	pool, _ := newTestPool()
+	mintA := make([]byte, 82)
+	mintA[mintDecimalsOffset] = 9
+	mintB := make([]byte, 82)
+	mintB[mintDecimalsOffset] = 6
+	accounts := map[solana.PublicKey]*rpc.Account{
+		pool.Address:          {Owner: pool.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(encodeAccount(t, whirlpool.WhirlpoolDiscriminator, pool.State))},
+		pool.State.TokenMintA: {Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(mintA)},
+		pool.State.TokenMintB: {Owner: solana.Token2022ProgramID, Data: rpc.DataBytesOrJSONFromBytes(mintB)},
+	}
+	client := &utils.MockRPCClient{
+		MockGetMultipleAccounts: func(ctx context.Context, keys []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+			result := &rpc.GetMultipleAccountsResult{Value: make([]*rpc.Account, len(keys))}
+			for k, key := range keys {
+				result.Value[k] = accounts[key]
+			}
+			return result, nil
+		},
+	}
+
+	fetched, err := FetchPool(context.Background(), client, pool.ProgramID, pool.Address)
+	require.NoError(t, err)
+	assert.Equal(t, pool, fetched)
+
+	_, err = FetchPool(context.Background(), client, solana.NewWallet().PublicKey(), pool.Address)
+	assert.ErrorContains(t, err, "owned by")
+	_, err = FetchPool(context.Background(), client, pool.ProgramID, solana.NewWallet().PublicKey())
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestPoolModel(t *testing.T) {
+	pool, _ := newTestPool()
+
+	model := pool.Model()
+	assert.Equal(t, pool.Address.String(), model.ID)
+	assert.Equal(t, models.ProtocolOrca, model.Protocol)
+	assert.Equal(t, models.PoolTypeWhirlpool, model.Type)
+	assert.Equal(t, models.PoolStatusActive, model.Status)
+	assert.Equal(t, DefaultProgramID, model.ProgramID)
+	assert.Equal(t, pool.State.TokenMintA.String(), model.BaseMint)
+	assert.Equal(t, pool.State.TokenMintB.String(), model.QuoteMint)
+	assert.Equal(t, pool.State.TokenVaultA.String(), model.BaseVault)
+	assert.Equal(t, pool.State.TokenVaultB.String(), model.QuoteVault)
+
+	assert.Equal(t, uint32(testFeeRate), model.Config.FeeRate)
+	assert.Equal(t, uint16(30), *model.Config.TradeFeeBps)
+	assert.Equal(t, int32(testTickSpacing), *model.Config.TickSpacing)
+	// Price 1 in base units is 1000 B per A with 9 and 6 decimals
+	assert.InDelta(t, 1000, model.MarketState.Price, 1e-9)
+	assert.Equal(t, "18446744073709551616", *model.MarketState.SqrtPrice)
+	assert.Equal(t, int64(0), *model.MarketState.CurrentTick)
+	assert.Equal(t, "11000000000000", *model.MarketState.Liquidity)
+
+	require.NotNil(t, model.WhirlpoolData)
+	assert.Equal(t, pool.State.RewardInfos[0].Vault.String(), model.WhirlpoolData.RewardVaultA)
+	assert.Empty(t, model.WhirlpoolData.RewardVaultB, "reward slot never initialized")
+	assert.Equal(t, uint8(9), model.WhirlpoolData.TokenADecimals)
+	assert.Equal(t, uint8(6), model.WhirlpoolData.TokenBDecimals)
+}
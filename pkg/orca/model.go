@@ -0,0 +1,68 @@
+package orca
+
+import (
+	"time"
+
+	"corvus_bot/pkg/database/models"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Model maps the pool to the database model. Token A is stored as the base and token B as the quote, so
+// prices compare directly with Raydium CLMM pools of the same pair.
+func (p *Pool) Model() *models.Pool {
+	state := p.State
+	tickSpacing := int32(state.TickSpacing)
+	tradeFeeBps := state.FeeRate / 100
+	protocolFeeBps := state.ProtocolFeeRate
+	mintA, mintB := state.TokenMintA.String(), state.TokenMintB.String()
+	price := p.SpotPrice()
+	liquidity := state.Liquidity.BigInt().String()
+	tick := int64(state.TickCurrentIndex)
+	sqrtPrice := state.SqrtPrice.BigInt().String()
+
+	pool := &models.Pool{
+		Config: models.PoolConfig{
+			Decimals:       p.DecimalsA,
+			FeeRate:        uint32(state.FeeRate),
+			TickSpacing:    &tickSpacing,
+			TradeFeeBps:    &tradeFeeBps,
+			ProtocolFeeBps: &protocolFeeBps,
+			TokenMintA:     &mintA,
+			TokenMintB:     &mintB,
+			SpotPrice:      &price,
+		},
+		MarketState: models.MarketState{
+			Price:       price,
+			Liquidity:   &liquidity,
+			CurrentTick: &tick,
+			SqrtPrice:   &sqrtPrice,
+		},
+		BaseVault:  state.TokenVaultA.String(),
+		QuoteVault: state.TokenVaultB.String(),
+		WhirlpoolData: &models.WhirlpoolData{
+			RewardVaultA:   rewardVault(state.RewardInfos[0].Vault),
+			RewardVaultB:   rewardVault(state.RewardInfos[1].Vault),
+			Protocol:       "orca",
+			TokenADecimals: p.DecimalsA,
+			TokenBDecimals: p.DecimalsB,
+		},
+	}
+	pool.LastUpdated = time.Now().UTC()
+	pool.ID = p.Address.String()
+	pool.Protocol = models.ProtocolOrca
+	pool.Type = models.PoolTypeWhirlpool
+	pool.Status = models.PoolStatusActive
+	pool.ProgramID = p.ProgramID.String()
+	pool.BaseMint = mintA
+	pool.QuoteMint = mintB
+	return pool
+}
+
+// rewardVault leaves reward slots that were never initialized empty
+func rewardVault(vault solana.PublicKey) string {
+	if vault.IsZero() {
+		return ""
+	}
+	return vault.String()
+}
@@ -0,0 +1,163 @@
+package orca
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// SwapQuote is the expected result of a swap against the current pool state
+type SwapQuote struct {
+	InputMint            solana.PublicKey
+	OutputMint           solana.PublicKey
+	AToB                 bool    // True when swapping token A for token B
+	IsInput              bool    // True for exact input swaps
+	AmountIn             uint64  // Input amount, including the fee
+	AmountOut            uint64  // Expected output amount
+	OtherAmountThreshold uint64  // Minimum output for exact input swaps, maximum input for exact output swaps
+	Fee                  uint64  // Trade fee paid, in input token units
+	PriceImpact          float64 // Fraction of the spot price lost to the trade size, e.g. 0.01 for 1%
+	SqrtPriceX64After    *big.Int
+	TickAfter            int32
+	TicksCrossed         int
+	SlippageBps          uint64
+
+	// TickArrays are the three tick arrays swapV2 is given, in traversal order. Uninitialized ones are
+	// passed too: the program only reads as far as the swap goes.
+	TickArrays [3]solana.PublicKey
+}
+
+// Quote returns the expected output of swapping exactly amountIn of inputMint, using the live pool state
+func Quote(ctx context.Context, client utils.RPCClientInterface, pool *Pool, inputMint solana.PublicKey, amountIn, slippageBps uint64) (*SwapQuote, error) {
+	return quote(ctx, client, pool, inputMint, amountIn, slippageBps, true)
+}
+
+// QuoteExactOut returns the input needed to receive exactly amountOut, using the live pool state
+func QuoteExactOut(ctx context.Context, client utils.RPCClientInterface, pool *Pool, inputMint solana.PublicKey, amountOut, slippageBps uint64) (*SwapQuote, error) {
+	return quote(ctx, client, pool, inputMint, amountOut, slippageBps, false)
+}
+
+func quote(ctx context.Context, client utils.RPCClientInterface, pool *Pool, inputMint solana.PublicKey, amount, slippageBps uint64, isInput bool) (*SwapQuote, error) {
+	aToB, err := swapDirection(pool.State, inputMint)
+	if err != nil {
+		return nil, err
+	}
+	state, err := FetchSwapState(ctx, client, pool, aToB)
+	if err != nil {
+		return nil, err
+	}
+	return QuoteWithState(pool, state, inputMint, amount, slippageBps, isInput)
+}
+
+// FetchSwapState reloads the pool and the tick arrays a swap in the given direction traverses
+func FetchSwapState(ctx context.Context, client utils.RPCClientInterface, pool *Pool, aToB bool) (*SwapState, error) {
+	accounts, err := utils.FetchAccounts(ctx, client, []solana.PublicKey{pool.Address})
+	if err != nil {
+		return nil, err
+	}
+	if accounts[0] == nil {
+		return nil, fmt.Errorf("whirlpool %s not found", pool.Address)
+	}
+	whirlpool, err := DecodeWhirlpool(accounts[0].Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode whirlpool %s: %w", pool.Address, err)
+	}
+
+	startIndexes := SwapTickArrayStartIndexes(whirlpool.TickCurrentIndex, whirlpool.TickSpacing, aToB)
+	tickArrays, err := FetchTickArrays(ctx, client, pool.ProgramID, pool.Address, startIndexes)
+	if err != nil {
+		return nil, err
+	}
+	return &SwapState{Whirlpool: whirlpool, TickArrays: tickArrays}, nil
+}
+
+// QuoteWithState quotes a swap against an already loaded state. With isInput, amount is the exact input,
+// otherwise the exact output.
+func QuoteWithState(pool *Pool, state *SwapState, inputMint solana.PublicKey, amount, slippageBps uint64, isInput bool) (*SwapQuote, error) {
+	if slippageBps > utils.BpsDenominator {
+		return nil, fmt.Errorf("invalid slippage: %d bps", slippageBps)
+	}
+	aToB, err := swapDirection(state.Whirlpool, inputMint)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := SimulateSwap(state, amount, nil, aToB, isInput)
+	if err != nil {
+		return nil, err
+	}
+	if !isInput && result.AmountOut < amount {
+		return nil, fmt.Errorf("pool can only provide %d of the %d requested", result.AmountOut, amount)
+	}
+
+	q := &SwapQuote{
+		InputMint:         inputMint,
+		OutputMint:        state.Whirlpool.TokenMintB,
+		AToB:              aToB,
+		IsInput:           isInput,
+		AmountIn:          result.AmountIn,
+		AmountOut:         result.AmountOut,
+		Fee:               result.FeeAmount,
+		PriceImpact:       priceImpact(state.Whirlpool.SqrtPrice.BigInt(), result, aToB),
+		SqrtPriceX64After: result.SqrtPriceX64,
+		TickAfter:         result.TickCurrent,
+		TicksCrossed:      result.TicksCrossed,
+		SlippageBps:       slippageBps,
+	}
+	if !aToB {
+		q.OutputMint = state.Whirlpool.TokenMintA
+	}
+	if isInput {
+		q.OtherAmountThreshold = utils.ApplySlippageDown(result.AmountOut, slippageBps)
+	} else {
+		q.OtherAmountThreshold = utils.ApplySlippageUp(result.AmountIn, slippageBps)
+	}
+
+	startIndexes := SwapTickArrayStartIndexes(state.Whirlpool.TickCurrentIndex, state.Whirlpool.TickSpacing, aToB)
+	for k, startIndex := range startIndexes {
+		if q.TickArrays[k], err = FindTickArrayAddress(pool.ProgramID, pool.Address, startIndex); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// SpotPrice returns the price of one whole token A in token B
+func (p *Pool) SpotPrice() float64 {
+	return SqrtPriceX64ToPrice(p.State.SqrtPrice.BigInt(), p.DecimalsA, p.DecimalsB)
+}
+
+func swapDirection(whirlpool *Whirlpool, inputMint solana.PublicKey) (bool, error) {
+	switch {
+	case inputMint.Equals(whirlpool.TokenMintA):
+		return true, nil
+	case inputMint.Equals(whirlpool.TokenMintB):
+		return false, nil
+	}
+	return false, fmt.Errorf("mint %s is not traded by the pool", inputMint)
+}
+
+// priceImpact compares the execution price, fees excluded, with the spot price before the swap
+func priceImpact(sqrtPriceX64 *big.Int, result *SwapResult, aToB bool) float64 {
+	amountIn := result.AmountIn - result.FeeAmount
+	if amountIn == 0 || result.AmountOut == 0 {
+		return 0
+	}
+
+	// Spot price of A in B, in base units
+	sqrtPrice, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX64), new(big.Float).SetInt(q64)).Float64()
+	spot := sqrtPrice * sqrtPrice
+	execution := float64(result.AmountOut) / float64(amountIn)
+	if !aToB {
+		spot = 1 / spot
+	}
+	impact := 1 - execution/spot
+	if impact < 0 {
+		return 0
+	}
+	return impact
+}
@@ -0,0 +1,264 @@
+package orca
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrTickArraySequence is returned when a swap reaches past the tick arrays it was given. The program fails
+// the same way, so the amount has to be split or the price limited.
+var ErrTickArraySequence = errors.New("swap crosses the end of the tick array sequence")
+
+// SwapState is what a swap reads on-chain: the pool and, in traversal order, the tick arrays starting at
+// SwapTickArrayStartIndexes. A nil array ends the sequence.
+type SwapState struct {
+	Whirlpool  *Whirlpool
+	TickArrays []*TickArray
+}
+
+// SwapResult is the outcome of a simulated swap
+type SwapResult struct {
+	AmountA      uint64 // Token A paid in (A to B) or out (B to A)
+	AmountB      uint64 // Token B paid out (A to B) or in (B to A)
+	AmountIn     uint64 // Input consumed, including the trade fee
+	AmountOut    uint64
+	FeeAmount    uint64 // Total trade fee, in input token units
+	ProtocolFee  uint64 // Part of FeeAmount kept by the protocol
+	SqrtPriceX64 *big.Int
+	TickCurrent  int32
+	Liquidity    *big.Int
+	TicksCrossed int
+}
+
+// SimulateSwap replays swap_manager::swap of the Whirlpool program against state without modifying it.
+// With isInput, amount is the exact input, otherwise the exact output. A nil or zero sqrtPriceLimitX64
+// lets the price move to the end of the curve.
+func SimulateSwap(state *SwapState, amount uint64, sqrtPriceLimitX64 *big.Int, aToB, isInput bool) (*SwapResult, error) {
+	if state == nil || state.Whirlpool == nil {
+		return nil, fmt.Errorf("incomplete swap state")
+	}
+	if amount == 0 {
+		return nil, fmt.Errorf("swap amount must be greater than zero")
+	}
+	pool := state.Whirlpool
+
+	sequence := newTickSequence(state.TickArrays, pool.TickSpacing)
+	if len(sequence.arrays) == 0 {
+		return nil, fmt.Errorf("no tick array loaded")
+	}
+
+	sqrtPrice := pool.SqrtPrice.BigInt()
+	limit, err := sqrtPriceLimit(sqrtPriceLimitX64, sqrtPrice, aToB)
+	if err != nil {
+		return nil, err
+	}
+
+	amountRemaining := new(big.Int).SetUint64(amount)
+	amountCalculated := new(big.Int)
+	feeTotal := new(big.Int)
+	protocolFee := new(big.Int)
+	liquidity := pool.Liquidity.BigInt()
+	tickCurrent := pool.TickCurrentIndex
+	arrayIndex := 0
+	ticksCrossed := 0
+
+	for amountRemaining.Sign() > 0 && sqrtPrice.Cmp(limit) != 0 {
+		nextArrayIndex, nextTick, err := sequence.nextInitializedTick(tickCurrent, aToB, arrayIndex)
+		if err != nil {
+			return nil, err
+		}
+		nextTickSqrtPrice, err := SqrtPriceAtTick(clampTick(nextTick))
+		if err != nil {
+			return nil, err
+		}
+		target := nextTickSqrtPrice
+		if (aToB && nextTickSqrtPrice.Cmp(limit) < 0) || (!aToB && nextTickSqrtPrice.Cmp(limit) > 0) {
+			target = limit
+		}
+
+		step, err := computeSwapStep(amountRemaining, pool.FeeRate, liquidity, sqrtPrice, target, isInput, aToB)
+		if err != nil {
+			return nil, err
+		}
+		if isInput {
+			amountRemaining.Sub(amountRemaining, step.amountIn).Sub(amountRemaining, step.feeAmount)
+			amountCalculated.Add(amountCalculated, step.amountOut)
+		} else {
+			amountRemaining.Sub(amountRemaining, step.amountOut)
+			amountCalculated.Add(amountCalculated, step.amountIn).Add(amountCalculated, step.feeAmount)
+		}
+		feeTotal.Add(feeTotal, step.feeAmount)
+		protocolFee.Add(protocolFee, mulDivFloor(step.feeAmount, big.NewInt(int64(pool.ProtocolFeeRate)), big.NewInt(ProtocolFeeRateDenominator)))
+
+		if step.nextSqrtPrice.Cmp(nextTickSqrtPrice) == 0 {
+			if tick := sequence.tick(nextArrayIndex, nextTick); tick != nil && tick.Initialized {
+				net := tick.LiquidityNet.BigInt()
+				if aToB {
+					net.Neg(net)
+				}
+				liquidity = new(big.Int).Add(liquidity, net)
+				if liquidity.Sign() < 0 {
+					return nil, fmt.Errorf("liquidity underflow crossing tick %d", nextTick)
+				}
+				ticksCrossed++
+			}
+			if aToB {
+				tickCurrent = nextTick - 1
+			} else {
+				tickCurrent = nextTick
+			}
+		} else if step.nextSqrtPrice.Cmp(sqrtPrice) != 0 {
+			if tickCurrent, err = TickAtSqrtPrice(step.nextSqrtPrice); err != nil {
+				return nil, err
+			}
+		}
+		sqrtPrice = step.nextSqrtPrice
+		arrayIndex = nextArrayIndex
+	}
+
+	if !amountCalculated.IsUint64() {
+		return nil, errAmountExceedsMax
+	}
+	used := new(big.Int).Sub(new(big.Int).SetUint64(amount), amountRemaining).Uint64()
+	result := &SwapResult{
+		FeeAmount:    feeTotal.Uint64(),
+		ProtocolFee:  protocolFee.Uint64(),
+		SqrtPriceX64: sqrtPrice,
+		TickCurrent:  tickCurrent,
+		Liquidity:    liquidity,
+		TicksCrossed: ticksCrossed,
+	}
+	if isInput {
+		result.AmountIn, result.AmountOut = used, amountCalculated.Uint64()
+	} else {
+		result.AmountIn, result.AmountOut = amountCalculated.Uint64(), used
+	}
+	if aToB {
+		result.AmountA, result.AmountB = result.AmountIn, result.AmountOut
+	} else {
+		result.AmountA, result.AmountB = result.AmountOut, result.AmountIn
+	}
+	return result, nil
+}
+
+// sqrtPriceLimit resolves the price limit of a swap, defaulting to the end of the curve in its direction
+func sqrtPriceLimit(limit, current *big.Int, aToB bool) (*big.Int, error) {
+	if limit == nil || limit.Sign() == 0 {
+		if aToB {
+			return MinSqrtPriceX64, nil
+		}
+		return MaxSqrtPriceX64, nil
+	}
+	if limit.Cmp(MinSqrtPriceX64) < 0 || limit.Cmp(MaxSqrtPriceX64) > 0 {
+		return nil, fmt.Errorf("sqrt price limit %s out of bounds", limit)
+	}
+	if (aToB && limit.Cmp(current) > 0) || (!aToB && limit.Cmp(current) < 0) {
+		return nil, fmt.Errorf("sqrt price limit %s is on the wrong side of the current price %s", limit, current)
+	}
+	return limit, nil
+}
+
+// clampTick keeps the boundary ticks of the outermost tick arrays within the tick range
+func clampTick(tick int32) int32 {
+	if tick < MinTick {
+		return MinTick
+	}
+	if tick > MaxTick {
+		return MaxTick
+	}
+	return tick
+}
+
+// tickSequence is the swap_tick_sequence of the program: the tick arrays a swap may traverse, in order
+type tickSequence struct {
+	arrays      []*TickArray
+	tickSpacing int32
+}
+
+func newTickSequence(arrays []*TickArray, tickSpacing uint16) *tickSequence {
+	sequence := &tickSequence{tickSpacing: int32(tickSpacing)}
+	for _, array := range arrays {
+		if array == nil {
+			break
+		}
+		sequence.arrays = append(sequence.arrays, array)
+	}
+	return sequence
+}
+
+// nextInitializedTick returns the next initialized tick in the swap direction, starting the search in array
+// arrayIndex. When the remaining arrays hold none, it returns the boundary of the last array.
+func (s *tickSequence) nextInitializedTick(tick int32, aToB bool, arrayIndex int) (int, int32, error) {
+	ticksInArray := s.tickSpacing * TickArraySize
+	for ; arrayIndex < len(s.arrays); arrayIndex++ {
+		array := s.arrays[arrayIndex]
+		next, found, err := s.nextInitializedTickInArray(array, tick, aToB)
+		if err != nil {
+			return 0, 0, err
+		}
+		if found {
+			return arrayIndex, next, nil
+		}
+		if arrayIndex+1 == len(s.arrays) {
+			if aToB {
+				return arrayIndex, array.StartTickIndex, nil
+			}
+			return arrayIndex, array.StartTickIndex + ticksInArray - 1, nil
+		}
+		if aToB {
+			tick = array.StartTickIndex - 1
+		} else {
+			tick = array.StartTickIndex + ticksInArray - 1
+		}
+	}
+	return 0, 0, ErrTickArraySequence
+}
+
+// nextInitializedTickInArray searches one array. Swaps from A to B may stop at the tick they are on; swaps
+// from B to A start one tick spacing higher.
+func (s *tickSequence) nextInitializedTickInArray(array *TickArray, tick int32, aToB bool) (int32, bool, error) {
+	lower := array.StartTickIndex
+	upper := array.StartTickIndex + s.tickSpacing*TickArraySize
+	if !aToB {
+		lower -= s.tickSpacing
+		upper -= s.tickSpacing
+	}
+	if tick < lower || tick >= upper {
+		return 0, false, ErrTickArraySequence
+	}
+
+	offset := floorDiv(tick-array.StartTickIndex, s.tickSpacing)
+	if !aToB {
+		offset++
+	}
+	for offset >= 0 && offset < TickArraySize {
+		if array.Ticks[offset].Initialized {
+			return array.StartTickIndex + offset*s.tickSpacing, true, nil
+		}
+		if aToB {
+			offset--
+		} else {
+			offset++
+		}
+	}
+	return 0, false, nil
+}
+
+// tick returns the tick at index in array arrayIndex, or nil for ticks off the tick spacing
+func (s *tickSequence) tick(arrayIndex int, index int32) *Tick {
+	array := s.arrays[arrayIndex]
+	offset := index - array.StartTickIndex
+	if offset < 0 || offset%s.tickSpacing != 0 || offset/s.tickSpacing >= TickArraySize {
+		return nil
+	}
+	return &array.Ticks[offset/s.tickSpacing]
+}
+
+func floorDiv(a, b int32) int32 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
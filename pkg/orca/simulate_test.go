@@ -0,0 +1,200 @@
+package orca
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"corvus_bot/pkg/idl/bindings/whirlpool"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTickSpacing  = 64
+	testNarrowLiq    = 1_000_000_000_000  // Position over [-128, 128)
+	testWideLiq      = 10_000_000_000_000 // Position over [-11264, 11264)
+	testFeeRate      = 3000
+	testProtocolRate = 1300
+)
+
+// newTestPool returns a 0.3% pool at price 1 with a narrow and a wide position around the price, and the
+// tick arrays holding their bounds
+func newTestPool() (*Pool, map[int32]*TickArray) {
+	address := solana.NewWallet().PublicKey()
+	state := &Whirlpool{
+		TickSpacing:      testTickSpacing,
+		FeeRate:          testFeeRate,
+		ProtocolFeeRate:  testProtocolRate,
+		Liquidity:        toU128(big.NewInt(testNarrowLiq + testWideLiq)),
+		SqrtPrice:        toU128(q64),
+		TickCurrentIndex: 0,
+		TokenMintA:       solana.NewWallet().PublicKey(),
+		TokenVaultA:      solana.NewWallet().PublicKey(),
+		TokenMintB:       solana.NewWallet().PublicKey(),
+		TokenVaultB:      solana.NewWallet().PublicKey(),
+	}
+	state.RewardInfos[0].Vault = solana.NewWallet().PublicKey()
+
+	arrays := make(map[int32]*TickArray)
+	for _, start := range []int32{-16896, -11264, -5632, 0, 5632, 11264} {
+		arrays[start] = &TickArray{StartTickIndex: start, Whirlpool: address}
+	}
+	setTick := func(tick int32, net int64) {
+		start := tickArrayStartIndex(tick, testTickSpacing)
+		entry := &arrays[start].Ticks[(tick-start)/testTickSpacing]
+		entry.Initialized = true
+		entry.LiquidityNet = bin.Int128(toU128(new(big.Int).And(big.NewInt(net), maxU128)))
+	}
+	setTick(-128, testNarrowLiq)
+	setTick(128, -testNarrowLiq)
+	setTick(-11264, testWideLiq)
+	setTick(11264, -testWideLiq)
+
+	pool := &Pool{
+		Address:       address,
+		ProgramID:     solana.MustPublicKeyFromBase58(DefaultProgramID),
+		State:         state,
+		DecimalsA:     9,
+		DecimalsB:     6,
+		TokenProgramA: solana.TokenProgramID,
+		TokenProgramB: solana.Token2022ProgramID,
+	}
+	return pool, arrays
+}
+
+func swapState(pool *Pool, arrays map[int32]*TickArray, aToB bool) *SwapState {
+	state := &SwapState{Whirlpool: pool.State}
+	for _, start := range SwapTickArrayStartIndexes(pool.State.TickCurrentIndex, pool.State.TickSpacing, aToB) {
+		state.TickArrays = append(state.TickArrays, arrays[start])
+	}
+	return state
+}
+
+func TestSimulateSwapWithinRange(t *testing.T) {
+	pool, arrays := newTestPool()
+
+	result, err := SimulateSwap(swapState(pool, arrays, true), 1_000_000_000, nil, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000_000_000), result.AmountIn)
+	assert.Equal(t, uint64(3_000_000), result.FeeAmount)
+	assert.Equal(t, uint64(390_000), result.ProtocolFee)
+	assert.Equal(t, 0, result.TicksCrossed)
+	assert.Equal(t, result.AmountIn, result.AmountA)
+	assert.Equal(t, result.AmountOut, result.AmountB)
+
+	// Near price 1 the output is the input after fees, less the price impact of the trade
+	assert.Less(t, result.AmountOut, uint64(997_000_000))
+	assert.Greater(t, result.AmountOut, uint64(996_900_000))
+	assert.Equal(t, int32(-2), result.TickCurrent)
+	assert.Equal(t, big.NewInt(testNarrowLiq+testWideLiq), result.Liquidity)
+
+	expectedOut, err := AmountDeltaB(q64, result.SqrtPriceX64, result.Liquidity, false)
+	require.NoError(t, err)
+	assert.Equal(t, expectedOut.Uint64(), result.AmountOut)
+}
+
+func TestSimulateSwapCrossesTicks(t *testing.T) {
+	pool, arrays := newTestPool()
+
+	result, err := SimulateSwap(swapState(pool, arrays, true), 100_000_000_000, nil, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TicksCrossed)
+	assert.Equal(t, big.NewInt(testWideLiq), result.Liquidity, "the narrow position is out of range")
+	assert.Less(t, result.TickCurrent, int32(-128))
+
+	result, err = SimulateSwap(swapState(pool, arrays, false), 100_000_000_000, nil, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TicksCrossed)
+	assert.Equal(t, big.NewInt(testWideLiq), result.Liquidity)
+	assert.GreaterOrEqual(t, result.TickCurrent, int32(128))
+	assert.Equal(t, result.AmountIn, result.AmountB)
+
+	// Past the last position the swap runs out of the three tick arrays
+	_, err = SimulateSwap(swapState(pool, arrays, true), 10_000_000_000_000_000, nil, true, true)
+	assert.ErrorIs(t, err, ErrTickArraySequence)
+}
+
+func TestSimulateSwapPriceLimit(t *testing.T) {
+	pool, arrays := newTestPool()
+	limit, err := SqrtPriceAtTick(-64)
+	require.NoError(t, err)
+
+	result, err := SimulateSwap(swapState(pool, arrays, true), 100_000_000_000, limit, true, true)
+	require.NoError(t, err)
+	assert.Equal(t, limit, result.SqrtPriceX64)
+	assert.Less(t, result.AmountIn, uint64(100_000_000_000), "the limit leaves part of the input unused")
+
+	_, err = SimulateSwap(swapState(pool, arrays, true), 1_000, limit, false, true)
+	assert.Error(t, err, "limit below the price for a swap from B to A")
+}
+
+func TestSimulateSwapExactOutput(t *testing.T) {
+	pool, arrays := newTestPool()
+
+	exactIn, err := SimulateSwap(swapState(pool, arrays, true), 50_000_000_000, nil, true, true)
+	require.NoError(t, err)
+	exactOut, err := SimulateSwap(swapState(pool, arrays, true), exactIn.AmountOut, nil, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, exactIn.AmountOut, exactOut.AmountOut)
+	assert.InDelta(t, float64(exactIn.AmountIn), float64(exactOut.AmountIn), 2)
+	assert.GreaterOrEqual(t, exactOut.AmountIn+1, exactIn.AmountIn)
+}
+
+func TestQuote(t *testing.T) {
+	pool, arrays := newTestPool()
+
+	accounts := map[solana.PublicKey][]byte{pool.Address: encodeAccount(t, whirlpool.WhirlpoolDiscriminator, pool.State)}
+	for start, array := range arrays {
+		address, err := FindTickArrayAddress(pool.ProgramID, pool.Address, start)
+		require.NoError(t, err)
+		accounts[address] = encodeAccount(t, whirlpool.TickArrayDiscriminator, array)
+	}
+	client := &utils.MockRPCClient{
+		MockGetMultipleAccounts: func(ctx context.Context, keys []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+			result := &rpc.GetMultipleAccountsResult{Value: make([]*rpc.Account, len(keys))}
+			for k, key := range keys {
+				if data, ok := accounts[key]; ok {
+					result.Value[k] = &rpc.Account{Owner: pool.ProgramID, Data: rpc.DataBytesOrJSONFromBytes(data)}
+				}
+			}
+			return result, nil
+		},
+	}
+
+	quote, err := Quote(context.Background(), client, pool, pool.State.TokenMintB, 1_000_000_000, 100)
+	require.NoError(t, err)
+	assert.False(t, quote.AToB)
+	assert.True(t, quote.IsInput)
+	assert.Equal(t, pool.State.TokenMintA, quote.OutputMint)
+	assert.Equal(t, uint64(3_000_000), quote.Fee)
+	assert.Equal(t, quote.AmountOut*9900/10000, quote.OtherAmountThreshold)
+	assert.Greater(t, quote.PriceImpact, 0.0)
+	assert.Less(t, quote.PriceImpact, 0.001)
+	for k, start := range []int32{0, 5632, 11264} {
+		address, err := FindTickArrayAddress(pool.ProgramID, pool.Address, start)
+		require.NoError(t, err)
+		assert.Equal(t, address, quote.TickArrays[k])
+	}
+
+	exactOut, err := QuoteExactOut(context.Background(), client, pool, pool.State.TokenMintB, quote.AmountOut, 100)
+	require.NoError(t, err)
+	assert.False(t, exactOut.IsInput)
+	assert.Equal(t, quote.AmountOut, exactOut.AmountOut)
+	assert.Greater(t, exactOut.OtherAmountThreshold, exactOut.AmountIn)
+
+	_, err = Quote(context.Background(), client, pool, solana.NewWallet().PublicKey(), 1_000, 100)
+	assert.Error(t, err, "mint not traded by the pool")
+}
+
+func encodeAccount(t *testing.T, discriminator []byte, account interface{}) []byte {
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(account))
+	return buf.Bytes()
+}
@@ -0,0 +1,214 @@
+package orca
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// FeeRateDenominator is the denominator of Whirlpool.FeeRate: a fee rate of 3000 is 0.3%
+	FeeRateDenominator = 1_000_000
+
+	// ProtocolFeeRateDenominator is the denominator of Whirlpool.ProtocolFeeRate, a share of the trade fee
+	ProtocolFeeRateDenominator = 10_000
+)
+
+// errAmountExceedsMax reports an amount delta that does not fit the program's u64
+var errAmountExceedsMax = errors.New("token amount exceeds u64")
+
+// swapStep is the result of swapping within a single price range of constant liquidity
+type swapStep struct {
+	amountIn      *big.Int // Excludes the fee
+	amountOut     *big.Int
+	nextSqrtPrice *big.Int
+	feeAmount     *big.Int
+}
+
+// computeSwapStep moves the price from sqrtPriceCurrent towards sqrtPriceTarget, consuming at most
+// amountRemaining, exactly like swap_math::compute_swap_step of the program. The fixed delta is the
+// amount of the specified token (input for exact input swaps, output otherwise); the unfixed delta is
+// the amount of the other one.
+func computeSwapStep(amountRemaining *big.Int, feeRate uint16, liquidity, sqrtPriceCurrent, sqrtPriceTarget *big.Int, isInput, aToB bool) (*swapStep, error) {
+	initialFixedDelta, fixedErr := amountFixedDelta(sqrtPriceCurrent, sqrtPriceTarget, liquidity, isInput, aToB)
+	if fixedErr != nil && !errors.Is(fixedErr, errAmountExceedsMax) {
+		return nil, fixedErr
+	}
+
+	amountCalc := amountRemaining
+	if isInput {
+		amountCalc = mulDivFloor(amountRemaining, big.NewInt(FeeRateDenominator-int64(feeRate)), big.NewInt(FeeRateDenominator))
+	}
+
+	nextSqrtPrice := sqrtPriceTarget
+	if fixedErr != nil || initialFixedDelta.Cmp(amountCalc) > 0 {
+		var err error
+		if nextSqrtPrice, err = getNextSqrtPrice(sqrtPriceCurrent, liquidity, amountCalc, isInput, aToB); err != nil {
+			return nil, err
+		}
+	}
+	isMaxSwap := nextSqrtPrice.Cmp(sqrtPriceTarget) == 0
+
+	unfixedDelta, err := amountUnfixedDelta(sqrtPriceCurrent, nextSqrtPrice, liquidity, isInput, aToB)
+	if err != nil {
+		return nil, err
+	}
+	fixedDelta := initialFixedDelta
+	if !isMaxSwap || fixedErr != nil {
+		if fixedDelta, err = amountFixedDelta(sqrtPriceCurrent, nextSqrtPrice, liquidity, isInput, aToB); err != nil {
+			return nil, err
+		}
+	}
+
+	step := &swapStep{nextSqrtPrice: nextSqrtPrice}
+	if isInput {
+		step.amountIn, step.amountOut = fixedDelta, unfixedDelta
+	} else {
+		step.amountIn, step.amountOut = unfixedDelta, fixedDelta
+		if step.amountOut.Cmp(amountRemaining) > 0 {
+			step.amountOut = new(big.Int).Set(amountRemaining)
+		}
+	}
+
+	if isInput && !isMaxSwap {
+		step.feeAmount = new(big.Int).Sub(amountRemaining, step.amountIn)
+	} else {
+		step.feeAmount = mulDivCeil(step.amountIn, big.NewInt(int64(feeRate)), big.NewInt(FeeRateDenominator-int64(feeRate)))
+	}
+	return step, nil
+}
+
+// amountFixedDelta is the amount of the specified token between two prices, rounded up for inputs
+func amountFixedDelta(sqrtPriceCurrent, sqrtPriceTarget, liquidity *big.Int, isInput, aToB bool) (*big.Int, error) {
+	if aToB == isInput {
+		return AmountDeltaA(sqrtPriceCurrent, sqrtPriceTarget, liquidity, isInput)
+	}
+	return AmountDeltaB(sqrtPriceCurrent, sqrtPriceTarget, liquidity, isInput)
+}
+
+// amountUnfixedDelta is the amount of the other token between two prices, rounded up for inputs
+func amountUnfixedDelta(sqrtPriceCurrent, sqrtPriceTarget, liquidity *big.Int, isInput, aToB bool) (*big.Int, error) {
+	if aToB == isInput {
+		return AmountDeltaB(sqrtPriceCurrent, sqrtPriceTarget, liquidity, !isInput)
+	}
+	return AmountDeltaA(sqrtPriceCurrent, sqrtPriceTarget, liquidity, !isInput)
+}
+
+// AmountDeltaA returns the amount of token A between two sqrt prices for the given liquidity:
+// liquidity * (upper - lower) / (upper * lower), with Q64.64 prices and a single rounding
+func AmountDeltaA(sqrtPriceAX64, sqrtPriceBX64, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	lower, upper := sortPrices(sqrtPriceAX64, sqrtPriceBX64)
+	numerator := new(big.Int).Sub(upper, lower)
+	numerator.Mul(numerator, liquidity).Lsh(numerator, 64)
+	denominator := new(big.Int).Mul(upper, lower)
+
+	var delta *big.Int
+	if roundUp {
+		delta = ceilDiv(numerator, denominator)
+	} else {
+		delta = numerator.Quo(numerator, denominator)
+	}
+	if delta.Cmp(maxU64) > 0 {
+		return nil, errAmountExceedsMax
+	}
+	return delta, nil
+}
+
+// AmountDeltaB returns the amount of token B between two sqrt prices for the given liquidity:
+// liquidity * (upper - lower), with Q64.64 prices
+func AmountDeltaB(sqrtPriceAX64, sqrtPriceBX64, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	lower, upper := sortPrices(sqrtPriceAX64, sqrtPriceBX64)
+	product := new(big.Int).Sub(upper, lower)
+	product.Mul(product, liquidity)
+	// The program multiplies in u128
+	if product.Cmp(maxU128) > 0 {
+		return nil, errAmountExceedsMax
+	}
+
+	delta := new(big.Int).Rsh(product, 64)
+	if roundUp && new(big.Int).And(product, maxU64).Sign() != 0 {
+		delta.Add(delta, bigOne)
+	}
+	if delta.Cmp(maxU64) > 0 {
+		return nil, errAmountExceedsMax
+	}
+	return delta, nil
+}
+
+// getNextSqrtPrice returns the price after adding (for inputs) or removing (for outputs) amount of the
+// specified token
+func getNextSqrtPrice(sqrtPrice, liquidity, amount *big.Int, isInput, aToB bool) (*big.Int, error) {
+	if isInput == aToB {
+		return nextSqrtPriceFromARoundUp(sqrtPrice, liquidity, amount, isInput)
+	}
+	return nextSqrtPriceFromBRoundDown(sqrtPrice, liquidity, amount, isInput)
+}
+
+// nextSqrtPriceFromARoundUp computes liquidity * sqrtP / (liquidity ± amount * sqrtP), rounded up
+func nextSqrtPriceFromARoundUp(sqrtPrice, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return sqrtPrice, nil
+	}
+
+	product := new(big.Int).Mul(sqrtPrice, amount)
+	numerator := new(big.Int).Mul(liquidity, sqrtPrice)
+	numerator.Lsh(numerator, 64)
+	denominator := new(big.Int).Lsh(liquidity, 64)
+	if add {
+		denominator.Add(denominator, product)
+	} else {
+		if denominator.Cmp(product) <= 0 {
+			return nil, fmt.Errorf("insufficient liquidity to remove %s of token A", amount)
+		}
+		denominator.Sub(denominator, product)
+	}
+	return checkSqrtPrice(ceilDiv(numerator, denominator))
+}
+
+// nextSqrtPriceFromBRoundDown computes sqrtP ± amount / liquidity, rounding the price down
+func nextSqrtPriceFromBRoundDown(sqrtPrice, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	shifted := new(big.Int).Lsh(amount, 64)
+	if add {
+		delta := shifted.Quo(shifted, liquidity)
+		return checkSqrtPrice(delta.Add(sqrtPrice, delta))
+	}
+
+	delta := ceilDiv(shifted, liquidity)
+	if sqrtPrice.Cmp(delta) < 0 {
+		return nil, fmt.Errorf("insufficient liquidity to remove %s of token B", amount)
+	}
+	return checkSqrtPrice(delta.Sub(sqrtPrice, delta))
+}
+
+// checkSqrtPrice rejects prices outside of the tick range
+func checkSqrtPrice(sqrtPriceX64 *big.Int) (*big.Int, error) {
+	if sqrtPriceX64.Cmp(MinSqrtPriceX64) < 0 || sqrtPriceX64.Cmp(MaxSqrtPriceX64) > 0 {
+		return nil, fmt.Errorf("sqrt price %s out of bounds", sqrtPriceX64)
+	}
+	return sqrtPriceX64, nil
+}
+
+func sortPrices(a, b *big.Int) (*big.Int, *big.Int) {
+	if a.Cmp(b) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+func mulDivFloor(a, b, denominator *big.Int) *big.Int {
+	v := new(big.Int).Mul(a, b)
+	return v.Quo(v, denominator)
+}
+
+func mulDivCeil(a, b, denominator *big.Int) *big.Int {
+	return ceilDiv(new(big.Int).Mul(a, b), denominator)
+}
+
+// ceilDiv divides non-negative integers, rounding up
+func ceilDiv(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		quotient.Add(quotient, bigOne)
+	}
+	return quotient
+}
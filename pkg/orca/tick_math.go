@@ -0,0 +1,163 @@
+package orca
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const (
+	// MinTick is the lowest tick a Whirlpool can reach
+	MinTick int32 = -443636
+
+	// MaxTick is the highest tick a Whirlpool can reach
+	MaxTick int32 = -MinTick
+
+	// tickPrecisionBits is the number of log2 fraction bits computed by TickAtSqrtPrice, as in the program
+	tickPrecisionBits = 14
+)
+
+var (
+	// MinSqrtPriceX64 is the sqrt price of MinTick
+	MinSqrtPriceX64 = mustBigInt("4295048016")
+
+	// MaxSqrtPriceX64 is the sqrt price of MaxTick. It differs from the Raydium CLMM bound because the
+	// program computes positive ticks from their own Q96 factors instead of inverting the negative ones.
+	MaxSqrtPriceX64 = mustBigInt("79226673515401279992447579055")
+
+	q64      = new(big.Int).Lsh(big.NewInt(1), 64)
+	maxU64   = new(big.Int).SetUint64(^uint64(0))
+	maxU128  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	bigOne   = big.NewInt(1)
+	logScale = big.NewInt(59543866431248) // log_sqrt(1.0001)(2) in Q32.32
+
+	tickLowErrorX64  = mustBigInt("184467440737095516")
+	tickHighErrorX64 = mustBigInt("15793534762490258745")
+
+	// positiveTickFactors[i] is sqrt(1.0001)^(2^i) in Q96, copied from the program's tick_math
+	positiveTickFactors = [...]string{
+		"79232123823359799118286999567", "79236085330515764027303304731", "79244008939048815603706035061",
+		"79259858533276714757314932305", "79291567232598584799939703904", "79355022692464371645785046466",
+		"79482085999252804386437311141", "79736823300114093921829183326", "80248749790819932309965073892",
+		"81282483887344747381513967011", "83390072131320151908154831281", "87770609709833776024991924138",
+		"97234110755111693312479820773", "119332217159966728226237229890", "179736315981702064433883588727",
+		"407748233172238350107850275304", "2098478828474011932436660412517", "55581415166113811149459800483533",
+		"38992368544603139932233054999993551",
+	}
+
+	// negativeTickFactors[i] is 1 / sqrt(1.0001)^(2^i) in Q64.64, copied from the program's tick_math
+	negativeTickFactors = [...]uint64{
+		18445821805675392311, 18444899583751176498, 18443055278223354162, 18439367220385604838,
+		18431993317065449817, 18417254355718160513, 18387811781193591352, 18329067761203520168,
+		18212142134806087854, 17980523815641551639, 17526086738831147013, 16651378430235024244,
+		15030750278693429944, 12247334978882834399, 8131365268884726200, 3584323654723342297,
+		696457651847595233, 26294789957452057, 37481735321082,
+	}
+
+	positiveTickFactorsBig = func() []*big.Int {
+		factors := make([]*big.Int, len(positiveTickFactors))
+		for k, factor := range positiveTickFactors {
+			factors[k] = mustBigInt(factor)
+		}
+		return factors
+	}()
+)
+
+// SqrtPriceAtTick returns sqrt(1.0001^tick) in Q64.64, rounded exactly like the program
+func SqrtPriceAtTick(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, fmt.Errorf("tick %d out of range [%d, %d]", tick, MinTick, MaxTick)
+	}
+
+	if tick >= 0 {
+		ratio := new(big.Int).Lsh(bigOne, 96)
+		if tick&1 != 0 {
+			ratio.Set(positiveTickFactorsBig[0])
+		}
+		for bit := 1; bit < len(positiveTickFactorsBig); bit++ {
+			if tick&(1<<bit) != 0 {
+				ratio.Mul(ratio, positiveTickFactorsBig[bit]).Rsh(ratio, 96)
+			}
+		}
+		return ratio.Rsh(ratio, 32), nil
+	}
+
+	absTick := -tick
+	ratio := new(big.Int).Set(q64)
+	if absTick&1 != 0 {
+		ratio.SetUint64(negativeTickFactors[0])
+	}
+	factor := new(big.Int)
+	for bit := 1; bit < len(negativeTickFactors); bit++ {
+		if absTick&(1<<bit) != 0 {
+			ratio.Mul(ratio, factor.SetUint64(negativeTickFactors[bit])).Rsh(ratio, 64)
+		}
+	}
+	return ratio, nil
+}
+
+// TickAtSqrtPrice returns the greatest tick whose sqrt price is at most sqrtPriceX64
+func TickAtSqrtPrice(sqrtPriceX64 *big.Int) (int32, error) {
+	if sqrtPriceX64.Cmp(MinSqrtPriceX64) < 0 || sqrtPriceX64.Cmp(MaxSqrtPriceX64) > 0 {
+		return 0, fmt.Errorf("sqrt price %s out of range", sqrtPriceX64)
+	}
+
+	// Integer part of log2(sqrtPrice) in Q32.32, then the fraction bit by bit by squaring
+	msb := sqrtPriceX64.BitLen() - 1
+	log2X32 := big.NewInt(int64(msb-64) << 32)
+
+	r := new(big.Int)
+	if msb >= 64 {
+		r.Rsh(sqrtPriceX64, uint(msb-63))
+	} else {
+		r.Lsh(sqrtPriceX64, uint(63-msb))
+	}
+	fractionX64 := new(big.Int)
+	bit := new(big.Int).Lsh(bigOne, 63)
+	for precision := 0; precision < tickPrecisionBits; precision++ {
+		r.Mul(r, r)
+		moreThanTwo := r.Bit(127)
+		r.Rsh(r, 63+moreThanTwo)
+		if moreThanTwo == 1 {
+			fractionX64.Add(fractionX64, bit)
+		}
+		bit.Rsh(bit, 1)
+	}
+	log2X32.Add(log2X32, fractionX64.Rsh(fractionX64, 32))
+
+	logSqrtX64 := new(big.Int).Mul(log2X32, logScale)
+	tickLow := int32(new(big.Int).Rsh(new(big.Int).Sub(logSqrtX64, tickLowErrorX64), 64).Int64())
+	tickHigh := int32(new(big.Int).Rsh(new(big.Int).Add(logSqrtX64, tickHighErrorX64), 64).Int64())
+	if tickLow == tickHigh {
+		return tickLow, nil
+	}
+
+	sqrtPriceHigh, err := SqrtPriceAtTick(tickHigh)
+	if err != nil {
+		return 0, err
+	}
+	if sqrtPriceHigh.Cmp(sqrtPriceX64) <= 0 {
+		return tickHigh, nil
+	}
+	return tickLow, nil
+}
+
+// SqrtPriceX64ToPrice converts a Q64.64 sqrt price to the price of one whole token A in token B
+func SqrtPriceX64ToPrice(sqrtPriceX64 *big.Int, decimalsA, decimalsB uint8) float64 {
+	sqrtPrice, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX64), new(big.Float).SetInt(q64)).Float64()
+	price := sqrtPrice * sqrtPrice
+	for k := uint8(0); k < decimalsA; k++ {
+		price *= 10
+	}
+	for k := uint8(0); k < decimalsB; k++ {
+		price /= 10
+	}
+	return price
+}
+
+func mustBigInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big integer " + s)
+	}
+	return v
+}
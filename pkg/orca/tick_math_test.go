@@ -0,0 +1,99 @@
+package orca
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqrtPriceAtTickBounds(t *testing.T) {
+	price, err := SqrtPriceAtTick(MinTick)
+	require.NoError(t, err)
+	assert.Equal(t, MinSqrtPriceX64, price)
+
+	price, err = SqrtPriceAtTick(MaxTick)
+	require.NoError(t, err)
+	assert.Equal(t, MaxSqrtPriceX64, price)
+
+	price, err = SqrtPriceAtTick(0)
+	require.NoError(t, err)
+	assert.Equal(t, q64, price)
+
+	_, err = SqrtPriceAtTick(MinTick - 1)
+	assert.Error(t, err)
+	_, err = SqrtPriceAtTick(MaxTick + 1)
+	assert.Error(t, err)
+}
+
+func TestSqrtPriceAtTickMatchesFloat(t *testing.T) {
+	for _, tick := range []int32{-300000, -18973, -64, -1, 1, 64, 18973, 300000} {
+		price, err := SqrtPriceAtTick(tick)
+		require.NoError(t, err)
+
+		got, _ := new(big.Float).Quo(new(big.Float).SetInt(price), new(big.Float).SetInt(q64)).Float64()
+		// The float64 error of 1.0001 grows with the exponent, so the tolerance covers the reference too
+		want := math.Pow(1.0001, float64(tick)/2)
+		assert.InEpsilon(t, want, got, 1e-10, "tick %d", tick)
+	}
+}
+
+func TestTickAtSqrtPriceRoundTrip(t *testing.T) {
+	ticks := []int32{MinTick, MinTick + 1, -443000, -200000, -18973, -64, -1, 0, 1, 63, 64, 18973, 200000, 443000, MaxTick - 1}
+	for _, tick := range ticks {
+		price, err := SqrtPriceAtTick(tick)
+		require.NoError(t, err)
+
+		got, err := TickAtSqrtPrice(price)
+		require.NoError(t, err)
+		assert.Equal(t, tick, got, "exact price of tick %d", tick)
+
+		nextPrice, err := SqrtPriceAtTick(tick + 1)
+		require.NoError(t, err)
+		got, err = TickAtSqrtPrice(new(big.Int).Sub(nextPrice, bigOne))
+		require.NoError(t, err)
+		assert.Equal(t, tick, got, "price just below tick %d", tick+1)
+	}
+
+	got, err := TickAtSqrtPrice(MaxSqrtPriceX64)
+	require.NoError(t, err)
+	assert.Equal(t, MaxTick, got)
+
+	_, err = TickAtSqrtPrice(new(big.Int).Sub(MinSqrtPriceX64, bigOne))
+	assert.Error(t, err)
+	_, err = TickAtSqrtPrice(new(big.Int).Add(MaxSqrtPriceX64, bigOne))
+	assert.Error(t, err)
+}
+
+func TestAmountDeltas(t *testing.T) {
+	liquidity := big.NewInt(1_000_000_000)
+	lower, err := SqrtPriceAtTick(-100)
+	require.NoError(t, err)
+	upper, err := SqrtPriceAtTick(100)
+	require.NoError(t, err)
+
+	down, err := AmountDeltaA(lower, upper, liquidity, false)
+	require.NoError(t, err)
+	up, err := AmountDeltaA(upper, lower, liquidity, true)
+	require.NoError(t, err)
+	assert.Equal(t, new(big.Int).Add(down, bigOne), up, "order of the prices does not matter, rounding does")
+	assert.InDelta(t, 1e9*(math.Pow(1.0001, 50)-math.Pow(1.0001, -50)), float64(down.Int64()), 1)
+
+	down, err = AmountDeltaB(lower, upper, liquidity, false)
+	require.NoError(t, err)
+	assert.InDelta(t, 1e9*(math.Pow(1.0001, 50)-math.Pow(1.0001, -50)), float64(down.Int64()), 1)
+
+	_, err = AmountDeltaB(MinSqrtPriceX64, MaxSqrtPriceX64, maxU128, false)
+	assert.ErrorIs(t, err, errAmountExceedsMax)
+}
+
+func TestSwapTickArrayStartIndexes(t *testing.T) {
+	assert.Equal(t, []int32{0, -5632, -11264}, SwapTickArrayStartIndexes(0, 64, true))
+	assert.Equal(t, []int32{0, 5632, 11264}, SwapTickArrayStartIndexes(0, 64, false))
+	assert.Equal(t, []int32{-5632, -11264, -16896}, SwapTickArrayStartIndexes(-1, 64, true))
+	// One tick spacing below the next array, swaps from B to A already search it
+	assert.Equal(t, []int32{5632, 11264, 16896}, SwapTickArrayStartIndexes(5568, 64, false))
+	assert.Equal(t, []int32{0, -5632, -11264}, SwapTickArrayStartIndexes(5568, 64, true))
+}
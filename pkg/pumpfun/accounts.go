@@ -0,0 +1,111 @@
+// Package pumpfun reads pump.fun bonding curves, quotes trades against them and builds buy and sell instructions.
+package pumpfun
+
+import (
+	"context"
+	"fmt"
+
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultProgramID is the pump.fun program on mainnet
+	DefaultProgramID = "6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"
+
+	// GlobalSeed is the PDA seed of the Global account
+	GlobalSeed = "global"
+
+	// BondingCurveSeed is the PDA seed of the bonding curve of a mint, followed by the mint
+	BondingCurveSeed = "bonding-curve"
+
+	// EventAuthoritySeed is the PDA seed of the account Anchor's emit_cpi! signs with
+	EventAuthoritySeed = "__event_authority"
+
+	// MigrationAccount is the pump.fun wallet that creates the Raydium pool of every completed bonding curve
+	MigrationAccount = "39azUYFWPz3VHgKCf3VChUwbpURdCHRxjWVowf5jUJjg"
+
+	// TokenDecimals is the number of decimals of every mint created by pump.fun
+	TokenDecimals = 6
+)
+
+// Global holds the fee settings and the initial reserves of new bonding curves
+type Global = pumpidl.Global
+
+// BondingCurve holds the virtual and real reserves of one token. Trades are priced on the virtual reserves,
+// the real ones bound what can actually be bought and sold.
+type BondingCurve = pumpidl.BondingCurve
+
+// DecodeGlobal decodes the data of the Global account. Fields appended by later program versions are ignored.
+func DecodeGlobal(data []byte) (*Global, error) {
+	return pumpidl.DecodeGlobal(data)
+}
+
+// DecodeBondingCurve decodes the data of a BondingCurve account. Fields appended by later program versions
+// are ignored.
+func DecodeBondingCurve(data []byte) (*BondingCurve, error) {
+	return pumpidl.DecodeBondingCurve(data)
+}
+
+// FindGlobalAddress derives the Global account of the program
+func FindGlobalAddress(programID solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(GlobalSeed)}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive global address: %w", err)
+	}
+	return address, nil
+}
+
+// FindBondingCurveAddress derives the bonding curve account of a mint
+func FindBondingCurveAddress(programID, mint solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(BondingCurveSeed), mint.Bytes()}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive bonding curve address for mint %s: %w", mint, err)
+	}
+	return address, nil
+}
+
+// FindEventAuthorityAddress derives the event authority account passed to every trade
+func FindEventAuthorityAddress(programID solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(EventAuthoritySeed)}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive event authority address: %w", err)
+	}
+	return address, nil
+}
+
+// FetchGlobal reads the Global account of the program
+func FetchGlobal(ctx context.Context, client utils.RPCClientInterface, programID solana.PublicKey) (*Global, error) {
+	address, err := FindGlobalAddress(programID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := utils.FetchAccount(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+	global, err := DecodeGlobal(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode global account %s: %w", address, err)
+	}
+	return global, nil
+}
+
+// FetchBondingCurve reads the bonding curve of a mint
+func FetchBondingCurve(ctx context.Context, client utils.RPCClientInterface, programID, mint solana.PublicKey) (*BondingCurve, error) {
+	address, err := FindBondingCurveAddress(programID, mint)
+	if err != nil {
+		return nil, err
+	}
+	account, err := utils.FetchAccount(ctx, client, address)
+	if err != nil {
+		return nil, err
+	}
+	curve, err := DecodeBondingCurve(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bonding curve %s: %w", address, err)
+	}
+	return curve, nil
+}
@@ -0,0 +1,80 @@
+package pumpfun
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+type (
+	// CreateEvent announces a new token and its bonding curve
+	CreateEvent = pumpidl.CreateEvent
+
+	// TradeEvent reports a buy or sell and the virtual reserves after it
+	TradeEvent = pumpidl.TradeEvent
+
+	// CompleteEvent reports that a bonding curve sold out its real reserves and is ready to migrate
+	CompleteEvent = pumpidl.CompleteEvent
+)
+
+// Event is an event emitted by the pump.fun program. Event holds *CreateEvent, *TradeEvent or *CompleteEvent.
+type Event struct {
+	Signature string
+	Slot      uint64
+	Name      string
+	Event     interface{}
+}
+
+// DecodeEvent decodes the base64 payload of a "Program data:" line by its discriminator.
+// The returned name is empty when the discriminator is not a create, trade or complete event.
+func DecodeEvent(encoded string) (string, interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode program data base64: %w", err)
+	}
+
+	switch {
+	case bytes.HasPrefix(data, pumpidl.CreateEventDiscriminator):
+		event, err := pumpidl.DecodeCreateEvent(data)
+		return "CreateEvent", event, err
+	case bytes.HasPrefix(data, pumpidl.TradeEventDiscriminator):
+		event, err := pumpidl.DecodeTradeEvent(data)
+		return "TradeEvent", event, err
+	case bytes.HasPrefix(data, pumpidl.CompleteEventDiscriminator):
+		event, err := pumpidl.DecodeCompleteEvent(data)
+		return "CompleteEvent", event, err
+	}
+	return "", nil, nil
+}
+
+// ParseEvents decodes the events the pump.fun program emitted in a log notification, in log order.
+// "Program data:" lines are attributed to the program on top of the invoke stack, so events of other
+// programs in the same transaction are skipped.
+func ParseEvents(logMsg *ws.LogResult, programID solana.PublicKey) ([]*Event, error) {
+	signature := logMsg.Value.Signature.String()
+
+	var events []*Event
+	for _, data := range utils.ProgramData(logMsg.Value.Logs, programID.String()) {
+		name, event, err := DecodeEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", signature, err)
+		}
+		if name == "" {
+			continue
+		}
+		events = append(events, &Event{
+			Signature: signature,
+			Slot:      logMsg.Context.Slot,
+			Name:      name,
+			Event:     event,
+		})
+	}
+	return events, nil
+}
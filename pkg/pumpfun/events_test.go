@@ -0,0 +1,83 @@
+package pumpfun
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeCurve(t *testing.T, curve *BondingCurve) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(curve))
+	return buf.Bytes()
+}
+
+// programData encodes an event the way emit! logs it
+func programData(t *testing.T, discriminator []byte, event interface{}) string {
+	t.Helper()
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(event))
+	return utils.ProgramDataPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestParseEvents(t *testing.T) {
+	program := DefaultProgramID
+	mint := solana.NewWallet().PublicKey()
+	user := solana.NewWallet().PublicKey()
+
+	created := CreateEvent{Name: "Corvus", Symbol: "CRV", Uri: "https://example.com/crv.json", Mint: mint, User: user}
+	trade := TradeEvent{Mint: mint, SolAmount: 1_000, TokenAmount: 34_000, IsBuy: true, User: user, Timestamp: 1732752205}
+	complete := CompleteEvent{User: user, Mint: mint, Timestamp: 1732752205}
+
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = 304027100
+	logMsg.Value.Signature = solana.Signature{7}
+	logMsg.Value.Logs = []string{
+		"Program " + program + " invoke [1]",
+		"Program log: Instruction: Create",
+		programData(t, pumpidl.CreateEventDiscriminator, created),
+		"Program " + program + " success",
+		"Program " + program + " invoke [1]",
+		"Program log: Instruction: Buy",
+		// A program called by pump.fun logs its own data, which is not a pump.fun event
+		"Program 11111111111111111111111111111111 invoke [2]",
+		programData(t, pumpidl.TradeEventDiscriminator, trade),
+		"Program 11111111111111111111111111111111 success",
+		programData(t, pumpidl.TradeEventDiscriminator, trade),
+		programData(t, pumpidl.CompleteEventDiscriminator, complete),
+		"Program data: AAAAAAAAAAA=",
+		"Program " + program + " success",
+	}
+
+	events, err := ParseEvents(logMsg, solana.MustPublicKeyFromBase58(program))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, "CreateEvent", events[0].Name)
+	assert.Equal(t, &created, events[0].Event)
+	assert.Equal(t, "TradeEvent", events[1].Name)
+	assert.Equal(t, &trade, events[1].Event)
+	assert.Equal(t, "CompleteEvent", events[2].Name)
+	assert.Equal(t, &complete, events[2].Event)
+	assert.Equal(t, solana.Signature{7}.String(), events[2].Signature)
+	assert.Equal(t, uint64(304027100), events[2].Slot)
+}
+
+func TestDecodeEventErrors(t *testing.T) {
+	_, _, err := DecodeEvent("not base64!")
+	assert.Error(t, err)
+
+	// A known discriminator with a truncated body
+	_, _, err = DecodeEvent(base64.StdEncoding.EncodeToString(pumpidl.TradeEventDiscriminator))
+	assert.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package pumpfun
+
+import (
+	"fmt"
+
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// tradeAccounts holds the accounts shared by buy and sell
+type tradeAccounts struct {
+	global                 solana.PublicKey
+	bondingCurve           solana.PublicKey
+	associatedBondingCurve solana.PublicKey
+	associatedUser         solana.PublicKey
+	eventAuthority         solana.PublicKey
+}
+
+// NewBuyInstruction builds a buy of tokenAmount tokens of mint paying at most maxSolCost lamports, fee included.
+// feeRecipient is Global.FeeRecipient. The user's associated token account for the mint must exist.
+func NewBuyInstruction(programID, feeRecipient, mint, user solana.PublicKey, tokenAmount, maxSolCost uint64) (solana.Instruction, error) {
+	accounts, err := deriveTradeAccounts(programID, mint, user)
+	if err != nil {
+		return nil, err
+	}
+	return pumpidl.NewBuyInstruction(programID,
+		pumpidl.BuyArgs{Amount: tokenAmount, MaxSolCost: maxSolCost},
+		pumpidl.BuyAccounts{
+			Global:                 accounts.global,
+			FeeRecipient:           feeRecipient,
+			Mint:                   mint,
+			BondingCurve:           accounts.bondingCurve,
+			AssociatedBondingCurve: accounts.associatedBondingCurve,
+			AssociatedUser:         accounts.associatedUser,
+			User:                   user,
+			SystemProgram:          solana.SystemProgramID,
+			TokenProgram:           solana.TokenProgramID,
+			Rent:                   solana.SysVarRentPubkey,
+			EventAuthority:         accounts.eventAuthority,
+			Program:                programID,
+		})
+}
+
+// NewSellInstruction builds a sale of tokenAmount tokens of mint for at least minSolOutput lamports, fee deducted.
+// feeRecipient is Global.FeeRecipient.
+func NewSellInstruction(programID, feeRecipient, mint, user solana.PublicKey, tokenAmount, minSolOutput uint64) (solana.Instruction, error) {
+	accounts, err := deriveTradeAccounts(programID, mint, user)
+	if err != nil {
+		return nil, err
+	}
+	return pumpidl.NewSellInstruction(programID,
+		pumpidl.SellArgs{Amount: tokenAmount, MinSolOutput: minSolOutput},
+		pumpidl.SellAccounts{
+			Global:                 accounts.global,
+			FeeRecipient:           feeRecipient,
+			Mint:                   mint,
+			BondingCurve:           accounts.bondingCurve,
+			AssociatedBondingCurve: accounts.associatedBondingCurve,
+			AssociatedUser:         accounts.associatedUser,
+			User:                   user,
+			SystemProgram:          solana.SystemProgramID,
+			AssociatedTokenProgram: solana.SPLAssociatedTokenAccountProgramID,
+			TokenProgram:           solana.TokenProgramID,
+			EventAuthority:         accounts.eventAuthority,
+			Program:                programID,
+		})
+}
+
+// NewQuotedInstruction builds the buy or sell matching a quote, bounded by its slippage tolerance
+func NewQuotedInstruction(programID, feeRecipient, mint, user solana.PublicKey, quote *Quote) (solana.Instruction, error) {
+	if quote.IsBuy {
+		return NewBuyInstruction(programID, feeRecipient, mint, user, quote.TokenAmount, quote.MaxSolCost)
+	}
+	return NewSellInstruction(programID, feeRecipient, mint, user, quote.TokenAmount, quote.MinSolOutput)
+}
+
+func deriveTradeAccounts(programID, mint, user solana.PublicKey) (*tradeAccounts, error) {
+	global, err := FindGlobalAddress(programID)
+	if err != nil {
+		return nil, err
+	}
+	bondingCurve, err := FindBondingCurveAddress(programID, mint)
+	if err != nil {
+		return nil, err
+	}
+	associatedBondingCurve, _, err := solana.FindAssociatedTokenAddress(bondingCurve, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive bonding curve token account: %w", err)
+	}
+	associatedUser, _, err := solana.FindAssociatedTokenAddress(user, mint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive user token account: %w", err)
+	}
+	eventAuthority, err := FindEventAuthorityAddress(programID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tradeAccounts{
+		global:                 global,
+		bondingCurve:           bondingCurve,
+		associatedBondingCurve: associatedBondingCurve,
+		associatedUser:         associatedUser,
+		eventAuthority:         eventAuthority,
+	}, nil
+}
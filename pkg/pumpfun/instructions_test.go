@@ -0,0 +1,97 @@
+package pumpfun
+
+import (
+	"context"
+	"testing"
+
+	pumpidl "corvus_bot/pkg/idl/bindings/pumpfun"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBuyInstruction(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	feeRecipient := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	user := solana.NewWallet().PublicKey()
+
+	instruction, err := NewBuyInstruction(programID, feeRecipient, mint, user, 1_000, 2_000)
+	require.NoError(t, err)
+	assert.Equal(t, programID, instruction.ProgramID())
+
+	data, err := instruction.Data()
+	require.NoError(t, err)
+	args, err := pumpidl.DecodeBuyArgs(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1_000), args.Amount)
+	assert.Equal(t, uint64(2_000), args.MaxSolCost)
+
+	keys := make([]solana.PublicKey, 0, len(instruction.Accounts()))
+	for _, account := range instruction.Accounts() {
+		keys = append(keys, account.PublicKey)
+	}
+	accounts, err := pumpidl.DecodeBuyAccounts(keys)
+	require.NoError(t, err)
+
+	bondingCurve, err := FindBondingCurveAddress(programID, mint)
+	require.NoError(t, err)
+	associatedUser, _, err := solana.FindAssociatedTokenAddress(user, mint)
+	require.NoError(t, err)
+	assert.Equal(t, solana.MustPublicKeyFromBase58("4wTV1YmiEkRvAtNtsSGPtUrqRYQMe5SKy2uB4Jjaxnjf"), accounts.Global)
+	assert.Equal(t, solana.MustPublicKeyFromBase58("Ce6TQqeHC9p8KetsN6JsjHK7UTZk7nasjjnr7XxXp9F1"), accounts.EventAuthority)
+	assert.Equal(t, feeRecipient, accounts.FeeRecipient)
+	assert.Equal(t, bondingCurve, accounts.BondingCurve)
+	assert.Equal(t, associatedUser, accounts.AssociatedUser)
+	assert.True(t, instruction.Accounts()[6].IsSigner, "the user signs")
+}
+
+func TestNewQuotedInstruction(t *testing.T) {
+	global, curve := newTestCurve()
+	curve.RealSolReserves = 5_000_000_000
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+
+	quote, err := QuoteSell(global, curve, 1_000_000_000_000, 100)
+	require.NoError(t, err)
+	instruction, err := NewQuotedInstruction(programID, global.FeeRecipient, solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), quote)
+	require.NoError(t, err)
+
+	data, err := instruction.Data()
+	require.NoError(t, err)
+	args, err := pumpidl.DecodeSellArgs(data)
+	require.NoError(t, err)
+	assert.Equal(t, quote.TokenAmount, args.Amount)
+	assert.Equal(t, quote.MinSolOutput, args.MinSolOutput)
+	assert.Equal(t, solana.SPLAssociatedTokenAccountProgramID, instruction.Accounts()[8].PublicKey)
+}
+
+func TestFetchBondingCurve(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58(DefaultProgramID)
+	mint := solana.NewWallet().PublicKey()
+	_, curve := newTestCurve()
+	address, err := FindBondingCurveAddress(programID, mint)
+	require.NoError(t, err)
+
+	data := append(append([]byte(nil), pumpidl.BondingCurveDiscriminator...), encodeCurve(t, curve)...)
+	// Later program versions append fields, e.g. the creator
+	data = append(data, solana.NewWallet().PublicKey().Bytes()...)
+
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			if !account.Equals(address) {
+				return &rpc.GetAccountInfoResult{}, nil
+			}
+			return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: programID, Data: rpc.DataBytesOrJSONFromBytes(data)}}, nil
+		},
+	}
+
+	fetched, err := FetchBondingCurve(context.Background(), client, programID, mint)
+	require.NoError(t, err)
+	assert.Equal(t, curve, fetched)
+
+	_, err = FetchGlobal(context.Background(), client, programID)
+	assert.ErrorContains(t, err, "no data found")
+}
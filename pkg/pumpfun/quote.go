@@ -0,0 +1,150 @@
+package pumpfun
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"corvus_bot/pkg/utils"
+)
+
+// ErrCurveComplete is returned when quoting a bonding curve that has migrated and no longer trades
+var ErrCurveComplete = errors.New("bonding curve is complete")
+
+// Quote is the expected result of a trade against a bonding curve
+type Quote struct {
+	IsBuy        bool
+	TokenAmount  uint64  // Tokens bought or sold
+	SolAmount    uint64  // Lamports paid into or out of the curve, fee excluded
+	Fee          uint64  // Fee paid to the fee recipient, in lamports
+	MaxSolCost   uint64  // Buy: SolAmount plus Fee, increased by the slippage tolerance
+	MinSolOutput uint64  // Sell: SolAmount minus Fee, reduced by the slippage tolerance
+	PriceImpact  float64 // Fraction of the spot price lost to the trade size, e.g. 0.01 for 1%
+	SlippageBps  uint64
+}
+
+// QuoteBuy returns the cost of buying tokenAmount tokens, as computed by the buy instruction. The amount is
+// capped at the real token reserves, like the program does.
+func QuoteBuy(global *Global, curve *BondingCurve, tokenAmount, slippageBps uint64) (*Quote, error) {
+	if err := checkTrade(curve, slippageBps); err != nil {
+		return nil, err
+	}
+	if tokenAmount > curve.RealTokenReserves {
+		tokenAmount = curve.RealTokenReserves
+	}
+	if tokenAmount == 0 {
+		return nil, fmt.Errorf("nothing to buy: requested 0 of %d real tokens", curve.RealTokenReserves)
+	}
+	if tokenAmount >= curve.VirtualTokenReserves {
+		return nil, fmt.Errorf("buy of %d tokens exceeds virtual reserve %d", tokenAmount, curve.VirtualTokenReserves)
+	}
+
+	// cost = virtualSol * virtualToken / (virtualToken - amount) + 1 - virtualSol
+	product := new(big.Int).Mul(u(curve.VirtualSolReserves), u(curve.VirtualTokenReserves))
+	newSol := product.Quo(product, u(curve.VirtualTokenReserves-tokenAmount))
+	newSol.Add(newSol, big.NewInt(1))
+	cost := newSol.Sub(newSol, u(curve.VirtualSolReserves))
+	if !cost.IsUint64() {
+		return nil, fmt.Errorf("buy cost overflows u64")
+	}
+
+	solAmount := cost.Uint64()
+	fee := feeOf(solAmount, global.FeeBasisPoints)
+	return &Quote{
+		IsBuy:       true,
+		TokenAmount: tokenAmount,
+		SolAmount:   solAmount,
+		Fee:         fee,
+		MaxSolCost:  utils.ApplySlippageUp(solAmount+fee, slippageBps),
+		PriceImpact: priceImpact(curve.VirtualSolReserves, solAmount),
+		SlippageBps: slippageBps,
+	}, nil
+}
+
+// QuoteBuyWithSol returns the tokens bought by spending solAmount lamports, fee included
+func QuoteBuyWithSol(global *Global, curve *BondingCurve, solAmount, slippageBps uint64) (*Quote, error) {
+	if err := checkTrade(curve, slippageBps); err != nil {
+		return nil, err
+	}
+
+	// Take the fee out of the budget, then tokens = virtualToken - virtualSol * virtualToken / (virtualSol + sol) - 1
+	budget := new(big.Int).Mul(u(solAmount), big.NewInt(utils.BpsDenominator))
+	budget.Quo(budget, u(utils.BpsDenominator+global.FeeBasisPoints))
+	product := new(big.Int).Mul(u(curve.VirtualSolReserves), u(curve.VirtualTokenReserves))
+	newToken := product.Quo(product, budget.Add(budget, u(curve.VirtualSolReserves)))
+	newToken.Add(newToken, big.NewInt(1))
+	if newToken.Cmp(u(curve.VirtualTokenReserves)) >= 0 {
+		return nil, fmt.Errorf("%d lamports buy no tokens", solAmount)
+	}
+
+	return QuoteBuy(global, curve, curve.VirtualTokenReserves-newToken.Uint64(), slippageBps)
+}
+
+// QuoteSell returns the lamports received for selling tokenAmount tokens, as computed by the sell instruction
+func QuoteSell(global *Global, curve *BondingCurve, tokenAmount, slippageBps uint64) (*Quote, error) {
+	if err := checkTrade(curve, slippageBps); err != nil {
+		return nil, err
+	}
+	if tokenAmount == 0 {
+		return nil, fmt.Errorf("nothing to sell")
+	}
+
+	// sol = amount * virtualSol / (virtualToken + amount)
+	sol := new(big.Int).Mul(u(tokenAmount), u(curve.VirtualSolReserves))
+	sol.Quo(sol, new(big.Int).Add(u(curve.VirtualTokenReserves), u(tokenAmount)))
+	solAmount := sol.Uint64()
+	if solAmount > curve.RealSolReserves {
+		return nil, fmt.Errorf("sell output %d exceeds real sol reserve %d", solAmount, curve.RealSolReserves)
+	}
+
+	fee := feeOf(solAmount, global.FeeBasisPoints)
+	return &Quote{
+		TokenAmount:  tokenAmount,
+		SolAmount:    solAmount,
+		Fee:          fee,
+		MinSolOutput: utils.ApplySlippageDown(solAmount-fee, slippageBps),
+		PriceImpact:  priceImpact(curve.VirtualTokenReserves, tokenAmount),
+		SlippageBps:  slippageBps,
+	}, nil
+}
+
+// SpotPrice returns the marginal price of one token in SOL, both sides in UI units
+func SpotPrice(curve *BondingCurve) float64 {
+	if curve.VirtualTokenReserves == 0 {
+		return 0
+	}
+	sol := float64(curve.VirtualSolReserves) / 1e9
+	tokens := float64(curve.VirtualTokenReserves) / 1e6
+	return sol / tokens
+}
+
+func checkTrade(curve *BondingCurve, slippageBps uint64) error {
+	if curve.Complete {
+		return ErrCurveComplete
+	}
+	if slippageBps > utils.BpsDenominator {
+		return fmt.Errorf("invalid slippage: %d bps", slippageBps)
+	}
+	if curve.VirtualSolReserves == 0 || curve.VirtualTokenReserves == 0 {
+		return fmt.Errorf("empty bonding curve reserves: sol=%d, token=%d", curve.VirtualSolReserves, curve.VirtualTokenReserves)
+	}
+	return nil
+}
+
+// feeOf is the fee the program charges on a lamport amount, rounded down
+func feeOf(amount, feeBps uint64) uint64 {
+	v := new(big.Int).Mul(u(amount), u(feeBps))
+	return v.Quo(v, big.NewInt(utils.BpsDenominator)).Uint64()
+}
+
+// priceImpact is the relative move of the spot price caused by adding amountIn to reserveIn
+func priceImpact(reserveIn, amountIn uint64) float64 {
+	if amountIn == 0 {
+		return 0
+	}
+	return float64(amountIn) / (float64(reserveIn) + float64(amountIn))
+}
+
+func u(v uint64) *big.Int {
+	return new(big.Int).SetUint64(v)
+}
@@ -0,0 +1,97 @@
+package pumpfun
+
+import (
+	"testing"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCurve returns a fresh curve with the mainnet launch parameters
+func newTestCurve() (*Global, *BondingCurve) {
+	global := &Global{
+		Initialized:                 true,
+		InitialVirtualTokenReserves: 1_073_000_000_000_000,
+		InitialVirtualSolReserves:   30_000_000_000,
+		InitialRealTokenReserves:    793_100_000_000_000,
+		TokenTotalSupply:            1_000_000_000_000_000,
+		FeeBasisPoints:              100,
+	}
+	curve := &BondingCurve{
+		VirtualTokenReserves: global.InitialVirtualTokenReserves,
+		VirtualSolReserves:   global.InitialVirtualSolReserves,
+		RealTokenReserves:    global.InitialRealTokenReserves,
+		TokenTotalSupply:     global.TokenTotalSupply,
+	}
+	return global, curve
+}
+
+func TestQuoteBuy(t *testing.T) {
+	global, curve := newTestCurve()
+
+	quote, err := QuoteBuy(global, curve, 1_000_000_000_000, 100)
+	require.NoError(t, err)
+	assert.True(t, quote.IsBuy)
+	assert.Equal(t, uint64(1_000_000_000_000), quote.TokenAmount)
+	assert.Equal(t, uint64(27_985_075), quote.SolAmount)
+	assert.Equal(t, uint64(279_850), quote.Fee)
+	assert.Equal(t, uint64(28_547_575), quote.MaxSolCost, "cost plus fee, 1% up")
+	assert.InDelta(t, 0.00093, quote.PriceImpact, 0.00001)
+
+	// Buying more than the curve holds is capped at its real reserves
+	quote, err = QuoteBuy(global, curve, curve.RealTokenReserves+1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, curve.RealTokenReserves, quote.TokenAmount)
+}
+
+func TestQuoteBuyWithSol(t *testing.T) {
+	global, curve := newTestCurve()
+
+	quote, err := QuoteBuyWithSol(global, curve, 1_000_000_000, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(34_281_150_129_545), quote.TokenAmount)
+	assert.Equal(t, uint64(990_099_009), quote.SolAmount)
+	assert.Equal(t, uint64(9_900_990), quote.Fee)
+	assert.LessOrEqual(t, quote.SolAmount+quote.Fee, uint64(1_000_000_000), "the budget covers the fee")
+	assert.Equal(t, quote.SolAmount+quote.Fee, quote.MaxSolCost)
+}
+
+func TestQuoteSell(t *testing.T) {
+	global, curve := newTestCurve()
+	curve.RealSolReserves = 5_000_000_000
+
+	quote, err := QuoteSell(global, curve, 1_000_000_000_000, 100)
+	require.NoError(t, err)
+	assert.False(t, quote.IsBuy)
+	assert.Equal(t, uint64(27_932_960), quote.SolAmount)
+	assert.Equal(t, uint64(279_329), quote.Fee)
+	assert.Equal(t, uint64(27_377_094), quote.MinSolOutput, "output after fee, 1% down")
+
+	curve.RealSolReserves = 1
+	_, err = QuoteSell(global, curve, 1_000_000_000_000, 100)
+	assert.ErrorContains(t, err, "exceeds real sol reserve")
+}
+
+func TestQuoteRejectsInvalidTrades(t *testing.T) {
+	global, curve := newTestCurve()
+
+	_, err := QuoteBuy(global, curve, 1, utils.BpsDenominator+1)
+	assert.ErrorContains(t, err, "invalid slippage")
+
+	_, err = QuoteSell(global, curve, 0, 0)
+	assert.Error(t, err)
+
+	curve.Complete = true
+	_, err = QuoteBuy(global, curve, 1, 0)
+	assert.ErrorIs(t, err, ErrCurveComplete)
+	_, err = QuoteSell(global, curve, 1, 0)
+	assert.ErrorIs(t, err, ErrCurveComplete)
+}
+
+func TestSpotPrice(t *testing.T) {
+	_, curve := newTestCurve()
+	// 30 SOL against 1.073B tokens
+	assert.InDelta(t, 2.796e-8, SpotPrice(curve), 1e-11)
+}
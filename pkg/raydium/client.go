@@ -8,6 +8,7 @@ import (
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 
+	"corvus_bot/pkg/config"
 	"corvus_bot/pkg/raydium/pool/amm"
 	"corvus_bot/pkg/raydium/pool/clmm"
 )
@@ -21,6 +22,46 @@ type RaydiumClient struct {
 	CLMMDataPath  string
 }
 
+// SwapMode selects how PerformSwap interprets its amounts.
+type SwapMode int
+
+const (
+	// SwapModeExactIn spends exactly amountIn and receives at least minAmountOut (swapBaseIn).
+	SwapModeExactIn SwapMode = iota
+	// SwapModeExactOut receives exactly minAmountOut and spends at most amountIn (swapBaseOut).
+	SwapModeExactOut
+)
+
+// SwapOption customizes a PerformSwap call.
+type SwapOption func(*swapOptions)
+
+type swapOptions struct {
+	mode        SwapMode
+	slippageBps uint64
+}
+
+func newSwapOptions(opts []SwapOption) swapOptions {
+	options := swapOptions{mode: SwapModeExactIn, slippageBps: amm.DefaultSlippageBps}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// WithSwapMode selects exact-input or exact-output semantics for the swap.
+func WithSwapMode(mode SwapMode) SwapOption {
+	return func(o *swapOptions) {
+		o.mode = mode
+	}
+}
+
+// WithSlippageBps sets the slippage tolerance used when the minimum output is quoted automatically.
+func WithSlippageBps(bps uint64) SwapOption {
+	return func(o *swapOptions) {
+		o.slippageBps = bps
+	}
+}
+
 // NewRaydiumClient creates a new RaydiumClient instance.
 func NewRaydiumClient(rpcConnection, ammProgramID, clmmProgramID, ammDataPath, clmmDataPath string) *RaydiumClient {
 	return &RaydiumClient{
@@ -32,6 +73,17 @@ func NewRaydiumClient(rpcConnection, ammProgramID, clmmProgramID, ammDataPath, c
 	}
 }
 
+// NewRaydiumClientFromConfig creates a RaydiumClient for the active config profile.
+func NewRaydiumClientFromConfig(cfg *config.Config) *RaydiumClient {
+	return NewRaydiumClient(
+		cfg.RPCConnection,
+		cfg.RaydiumAMMProgramID,
+		cfg.RaydiumCLMMProgramID,
+		cfg.AMMPoolsPath,
+		cfg.CLMMPoolsPath,
+	)
+}
+
 // FetchPoolData fetches the pool data for a given token address and pool type.
 // It first attempts to fetch from JSON storage, then falls back to network fetch if needed.
 func (rc *RaydiumClient) FetchPoolData(ctx context.Context, poolType, tokenAddress string) (interface{}, error) {
@@ -88,6 +140,8 @@ func (rc *RaydiumClient) FetchPoolData(ctx context.Context, poolType, tokenAddre
 }
 
 // PerformSwap executes a token swap using the specified pool type and data.
+// In SwapModeExactOut, amountIn is the maximum input the caller is willing to spend
+// and minAmountOut is the exact amount to receive.
 func (rc *RaydiumClient) PerformSwap(
 	ctx context.Context,
 	wallet *solana.Wallet,
@@ -95,15 +149,30 @@ func (rc *RaydiumClient) PerformSwap(
 	poolData interface{},
 	amountIn uint64,
 	minAmountOut uint64,
+	opts ...SwapOption,
 ) (solana.Signature, error) {
 	client := rpc.New(rc.RPCConnection)
 
+	options := newSwapOptions(opts)
+
 	switch poolType {
 	case "AMM":
 		ammPool, ok := poolData.(*amm.RaydiumAmmPool)
 		if !ok {
 			return solana.Signature{}, fmt.Errorf("invalid pool data for AMM pool")
 		}
+		if options.mode == SwapModeExactOut {
+			return amm.SwapTokensExactOut(
+				ctx,
+				client,
+				wallet,
+				*ammPool,
+				solana.MustPublicKeyFromBase58(ammPool.BaseMint),
+				solana.MustPublicKeyFromBase58(ammPool.QuoteMint),
+				amountIn,
+				minAmountOut,
+			)
+		}
 		return amm.SwapTokens(
 			ctx,
 			client,
@@ -120,14 +189,25 @@ func (rc *RaydiumClient) PerformSwap(
 		if !ok {
 			return solana.Signature{}, fmt.Errorf("invalid pool data for CLMM pool")
 		}
+		if options.mode == SwapModeExactOut {
+			return clmm.SwapTokensExactOut(
+				ctx,
+				client,
+				wallet,
+				clmmPool,
+				solana.MustPublicKeyFromBase58(clmmPool.MintA),
+				amountIn,
+				minAmountOut,
+			)
+		}
 		return clmm.SwapTokens(
 			ctx,
 			client,
 			wallet,
 			clmmPool,
+			solana.MustPublicKeyFromBase58(clmmPool.MintA),
 			amountIn,
 			minAmountOut,
-			nil,
 		)
 
 	default:
@@ -136,16 +216,38 @@ func (rc *RaydiumClient) PerformSwap(
 }
 
 // ValidateAndPerformSwap orchestrates the entire swap process.
+// For exact-input AMM swaps a minAmountOut of zero is replaced by a quote from the live pool
+// state, reduced by the slippage tolerance (see WithSlippageBps).
 func (rc *RaydiumClient) ValidateAndPerformSwap(
 	ctx context.Context,
 	wallet *solana.Wallet,
 	poolType, tokenAddress string,
 	amountIn, minAmountOut uint64,
+	opts ...SwapOption,
 ) (solana.Signature, error) {
 	poolData, err := rc.FetchPoolData(ctx, poolType, tokenAddress)
 	if err != nil {
 		return solana.Signature{}, fmt.Errorf("failed to fetch pool data: %w", err)
 	}
 
-	return rc.PerformSwap(ctx, wallet, poolType, poolData, amountIn, minAmountOut)
+	options := newSwapOptions(opts)
+	if ammPool, ok := poolData.(*amm.RaydiumAmmPool); ok && minAmountOut == 0 && options.mode == SwapModeExactIn {
+		quote, err := amm.Quote(
+			ctx,
+			rpc.New(rc.RPCConnection),
+			*ammPool,
+			solana.MustPublicKeyFromBase58(ammPool.BaseMint),
+			amountIn,
+			options.slippageBps,
+		)
+		if err != nil {
+			return solana.Signature{}, fmt.Errorf("failed to quote swap: %w", err)
+		}
+
+		log.Printf("Quoted %d -> %d (min %d, fee %d, impact %.4f%%)",
+			quote.AmountIn, quote.AmountOut, quote.MinAmountOut, quote.Fee, quote.PriceImpact*100)
+		minAmountOut = quote.MinAmountOut
+	}
+
+	return rc.PerformSwap(ctx, wallet, poolType, poolData, amountIn, minAmountOut, opts...)
 }
@@ -3,47 +3,30 @@
 package parse
 
 import (
-	"encoding/base64"
-	"encoding/binary"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
+
+	"corvus_bot/pkg/idl/bindings/raydiumamm"
+	"corvus_bot/pkg/utils"
 
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/gagliardetto/solana-go/rpc/ws"
 )
 
-// AMMInitInstructionData represents the IDL structure for initialize2
-type AMMInitInstructionData struct {
-	Nonce          uint8
-	OpenTime       uint64
-	InitPcAmount   uint64
-	InitCoinAmount uint64
-}
-
-// AMMPoolAccounts represents the account structure from the IDL
-type AMMPoolAccounts struct {
-	TokenProgram    solana.PublicKey // account[0]
-	AssociatedToken solana.PublicKey // account[1]
-	SystemProgram   solana.PublicKey // account[2]
-	Rent            solana.PublicKey // account[3]
-	Pool            solana.PublicKey // account[4]  - Main pool account
-	Authority       solana.PublicKey // account[5]
-	OpenOrders      solana.PublicKey // account[6]
-	LPMint          solana.PublicKey // account[7]
-	CoinMint        solana.PublicKey // account[8]  - Base token
-	PCMint          solana.PublicKey // account[9]  - Quote token
-	CoinVault       solana.PublicKey // account[10] - Base vault
-	PCVault         solana.PublicKey // account[11] - Quote vault
-	WithdrawQueue   solana.PublicKey // account[12]
-	TargetOrders    solana.PublicKey // account[13]
-	LPTokenAccount  solana.PublicKey // account[14]
-}
+// ErrNoPoolInit is returned for log notifications of transactions that do not create a pool
+var ErrNoPoolInit = errors.New("no initialize2 instruction found in logs")
 
 // ParsedAMMPool represents the final parsed pool data
 type ParsedAMMPool struct {
 	ID            string
 	ProgramID     string
+	Signature     string
+	Slot          uint64
 	Version       uint8
 	BaseMint      string
 	QuoteMint     string
@@ -51,6 +34,7 @@ type ParsedAMMPool struct {
 	BaseVault     string
 	QuoteVault    string
 	Authority     string
+	Creator       string // The wallet that signed initialize2
 	OpenOrders    string
 	TargetOrders  string
 	WithdrawQueue string
@@ -59,69 +43,43 @@ type ParsedAMMPool struct {
 	LPDecimals    uint8
 	InitialBase   uint64
 	InitialQuote  uint64
+	OpenTime      uint64
 }
 
 // AMMParser handles parsing of AMM initialization instructions
 type AMMParser struct {
-	programID solana.PublicKey
+	programID     solana.PublicKey
+	client        utils.RPCClientInterface
+	fetchAttempts int
+	fetchDelay    time.Duration
 }
 
-// NewAMMParser creates a new parser instance
-func NewAMMParser(programID string) (*AMMParser, error) {
+// NewAMMParser creates a new parser instance fetching transactions through the client
+func NewAMMParser(programID string, client utils.RPCClientInterface) (*AMMParser, error) {
 	pid, err := solana.PublicKeyFromBase58(programID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid program ID: %w", err)
 	}
-	return &AMMParser{programID: pid}, nil
+	return &AMMParser{
+		programID:     pid,
+		client:        client,
+		fetchAttempts: utils.DefaultFetchAttempts,
+		fetchDelay:    utils.DefaultFetchDelay,
+	}, nil
 }
 
-// ParsePoolInit parses pool initialization from transaction logs
-func (p *AMMParser) ParsePoolInit(logMsg *ws.LogResult) (*ParsedAMMPool, error) {
-	// First validate we have an initialize2 instruction
-	var initLog string
-	for _, log := range logMsg.Logs {
-		if strings.Contains(log, "initialize2") {
-			initLog = log
-			break
-		}
-	}
-	if initLog == "" {
-		return nil, fmt.Errorf("no initialize2 instruction found in logs")
-	}
-
-	// Parse instruction data from the log
-	instructionData, err := p.parseInstructionData(initLog)
+// ParsePoolInit parses the pool created by the transaction behind a log notification.
+// Notifications without an initialize2 log return ErrNoPoolInit without any RPC call.
+func (p *AMMParser) ParsePoolInit(ctx context.Context, logMsg *ws.LogResult) (*ParsedAMMPool, error) {
+	result, err := p.FetchPoolInit(ctx, logMsg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse instruction data: %w", err)
+		return nil, err
 	}
-
-	// Parse accounts from the transaction
-	accounts, err := p.parseAccounts(logMsg)
+	pool, err := p.ParseTransaction(ctx, result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse accounts: %w", err)
-	}
-
-	// Construct the parsed pool data
-	pool := &ParsedAMMPool{
-		ID:            accounts.Pool.String(),
-		ProgramID:     p.programID.String(),
-		Version:       1, // Initialize2 is v1
-		BaseMint:      accounts.CoinMint.String(),
-		QuoteMint:     accounts.PCMint.String(),
-		LPMint:        accounts.LPMint.String(),
-		BaseVault:     accounts.CoinVault.String(),
-		QuoteVault:    accounts.PCVault.String(),
-		Authority:     accounts.Authority.String(),
-		OpenOrders:    accounts.OpenOrders.String(),
-		TargetOrders:  accounts.TargetOrders.String(),
-		WithdrawQueue: accounts.WithdrawQueue.String(),
-		InitialBase:   instructionData.InitCoinAmount,
-		InitialQuote:  instructionData.InitPcAmount,
-	}
-
-	if err := p.validatePoolData(pool); err != nil {
-		return nil, fmt.Errorf("pool validation failed: %w", err)
+		return nil, fmt.Errorf("failed to parse transaction %s: %w", logMsg.Value.Signature, err)
 	}
+	pool.Signature = logMsg.Value.Signature.String()
 
 	log.Printf("Successfully parsed AMM pool initialization: ID=%s, Base=%s, Quote=%s",
 		pool.ID, pool.BaseMint, pool.QuoteMint)
@@ -129,81 +87,102 @@ func (p *AMMParser) ParsePoolInit(logMsg *ws.LogResult) (*ParsedAMMPool, error)
 	return pool, nil
 }
 
-// parseInstructionData extracts initialize2 parameters from the log
-func (p *AMMParser) parseInstructionData(log string) (*AMMInitInstructionData, error) {
-	// Extract base64 data from log if present
-	parts := strings.Split(log, "data: ")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid instruction log format")
+// FetchPoolInit fetches the transaction behind a log notification of a pool initialization.
+// Notifications without an initialize2 log return ErrNoPoolInit without any RPC call.
+func (p *AMMParser) FetchPoolInit(ctx context.Context, logMsg *ws.LogResult) (*rpc.GetTransactionResult, error) {
+	if logMsg == nil || !hasInitLog(logMsg.Value.Logs) {
+		return nil, ErrNoPoolInit
 	}
+	if logMsg.Value.Err != nil {
+		return nil, fmt.Errorf("pool initialization transaction %s failed: %v", logMsg.Value.Signature, logMsg.Value.Err)
+	}
+	return utils.FetchTransaction(ctx, p.client, logMsg.Value.Signature, p.fetchAttempts, p.fetchDelay)
+}
 
-	data, err := base64.StdEncoding.DecodeString(parts[1])
+// ParseTransaction parses the pool created by a fetched transaction, whether initialize2 is called
+// directly or through CPI
+func (p *AMMParser) ParseTransaction(ctx context.Context, result *rpc.GetTransactionResult) (*ParsedAMMPool, error) {
+	tx, err := utils.DecodeTransaction(result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode instruction data: %w", err)
+		return nil, err
 	}
 
-	if len(data) < 25 { // 1 (nonce) + 8 (openTime) + 8 (pcAmount) + 8 (coinAmount)
-		return nil, fmt.Errorf("insufficient instruction data length")
+	keys, err := utils.TransactionKeys(ctx, p.client, tx, result.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account keys: %w", err)
 	}
 
-	return &AMMInitInstructionData{
-		Nonce:          data[0],
-		OpenTime:       binary.LittleEndian.Uint64(data[1:9]),
-		InitPcAmount:   binary.LittleEndian.Uint64(data[9:17]),
-		InitCoinAmount: binary.LittleEndian.Uint64(data[17:25]),
-	}, nil
-}
+	instruction := utils.FindInstruction(tx, result.Meta, keys, p.programID, raydiumamm.Initialize2InstructionDiscriminator)
+	if instruction == nil {
+		return nil, ErrNoPoolInit
+	}
 
-// parseAccounts extracts and validates account addresses
-func (p *AMMParser) parseAccounts(logMsg *ws.LogResult) (*AMMPoolAccounts, error) {
-	if len(logMsg.Messages) < 15 {
-		return nil, fmt.Errorf("insufficient account keys: got %d, need 15", len(logMsg.Messages))
+	args, err := raydiumamm.DecodeInitialize2Args(instruction.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse instruction data: %w", err)
+	}
+	instructionKeys, err := utils.InstructionAccounts(instruction, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse accounts: %w", err)
+	}
+	accounts, err := raydiumamm.DecodeInitialize2Accounts(instructionKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse accounts: %w", err)
 	}
 
-	accounts := &AMMPoolAccounts{}
-	// Map accounts based on IDL order
-	keys := logMsg.Messages[:15] // Take first 15 accounts as per IDL
-	for i, key := range keys {
-		pubKey, err := solana.PublicKeyFromBase58(key)
-		if err != nil {
-			return nil, fmt.Errorf("invalid public key at index %d: %w", i, err)
+	// Construct the parsed pool data
+	pool := &ParsedAMMPool{
+		ID:            accounts.Amm.String(),
+		ProgramID:     p.programID.String(),
+		Slot:          result.Slot,
+		Version:       1, // Initialize2 is v1
+		BaseMint:      accounts.CoinMint.String(),
+		QuoteMint:     accounts.PcMint.String(),
+		LPMint:        accounts.LpMint.String(),
+		BaseVault:     accounts.PoolCoinTokenAccount.String(),
+		QuoteVault:    accounts.PoolPcTokenAccount.String(),
+		Authority:     accounts.AmmAuthority.String(),
+		Creator:       accounts.UserWallet.String(),
+		OpenOrders:    accounts.AmmOpenOrders.String(),
+		TargetOrders:  accounts.AmmTargetOrders.String(),
+		WithdrawQueue: accounts.PoolWithdrawQueue.String(),
+		InitialBase:   args.InitCoinAmount,
+		InitialQuote:  args.InitPcAmount,
+		OpenTime:      args.OpenTime,
+	}
+	if len(tx.Signatures) > 0 {
+		pool.Signature = tx.Signatures[0].String()
+	}
+
+	// The vaults and the creator's LP account show up in the token balances with their mint decimals
+	for _, balance := range result.Meta.PostTokenBalances {
+		if balance.UiTokenAmount == nil {
+			continue
 		}
-
-		switch i {
-		case 0:
-			accounts.TokenProgram = pubKey
-		case 1:
-			accounts.AssociatedToken = pubKey
-		case 2:
-			accounts.SystemProgram = pubKey
-		case 3:
-			accounts.Rent = pubKey
-		case 4:
-			accounts.Pool = pubKey
-		case 5:
-			accounts.Authority = pubKey
-		case 6:
-			accounts.OpenOrders = pubKey
-		case 7:
-			accounts.LPMint = pubKey
-		case 8:
-			accounts.CoinMint = pubKey
-		case 9:
-			accounts.PCMint = pubKey
-		case 10:
-			accounts.CoinVault = pubKey
-		case 11:
-			accounts.PCVault = pubKey
-		case 12:
-			accounts.WithdrawQueue = pubKey
-		case 13:
-			accounts.TargetOrders = pubKey
-		case 14:
-			accounts.LPTokenAccount = pubKey
+		switch balance.Mint {
+		case accounts.CoinMint:
+			pool.BaseDecimals = balance.UiTokenAmount.Decimals
+		case accounts.PcMint:
+			pool.QuoteDecimals = balance.UiTokenAmount.Decimals
+		case accounts.LpMint:
+			pool.LPDecimals = balance.UiTokenAmount.Decimals
 		}
 	}
 
-	return accounts, nil
+	if err := p.validatePoolData(pool); err != nil {
+		return nil, fmt.Errorf("pool validation failed: %w", err)
+	}
+	return pool, nil
+}
+
+// hasInitLog reports whether the logs contain the "initialize2: InitializeInstruction2 { .. }" line of the AMM
+func hasInitLog(logs []string) bool {
+	for _, line := range logs {
+		if strings.Contains(line, "initialize2") {
+			return true
+		}
+	}
+	return false
 }
 
 // validatePoolData performs comprehensive validation of parsed pool data
@@ -3,11 +3,21 @@
 package parse
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"os"
 	"testing"
 
+	"corvus_bot/pkg/idl/bindings/raydiumamm"
+	"corvus_bot/pkg/utils"
+
 	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/gagliardetto/solana-go/rpc/ws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,49 +25,164 @@ import (
 
 const testProgramID = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
 
+// filteredLogSample is a pool creation notification recorded by AMMPoolListener
+type filteredLogSample struct {
+	Signature  string   `json:"signature"`
+	Slot       uint64   `json:"slot"`
+	Logs       []string `json:"logs"`
+	InitParams struct {
+		Nonce          uint8  `json:"nonce"`
+		OpenTime       uint64 `json:"open_time"`
+		InitPcAmount   uint64 `json:"init_pc_amount"`
+		InitCoinAmount uint64 `json:"init_coin_amount"`
+	} `json:"init_params"`
+}
+
+// initTxOptions shapes the transaction built around the sample
+type initTxOptions struct {
+	cpi          bool // initialize2 is invoked by another program
+	lookupTable  bool // pool accounts are loaded from an address lookup table
+	omitLoadedIn bool // the node does not report the loaded addresses in the metadata
+}
+
 func TestAMMParser(t *testing.T) {
-	// Create parser instance
-	parser, err := NewAMMParser(testProgramID)
-	require.NoError(t, err)
+	sample, logMsg := loadFilteredLogSample(t)
+	result, accounts, _ := buildInitTransaction(t, sample, initTxOptions{})
 
-	// Load test data
-	logData, err := loadTestLogData(t)
-	require.NoError(t, err)
+	client := &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			assert.Equal(t, logMsg.Value.Signature, signature)
+			require.NotNil(t, opts.MaxSupportedTransactionVersion)
+			assert.Equal(t, uint64(0), *opts.MaxSupportedTransactionVersion)
+			assert.Equal(t, rpc.CommitmentConfirmed, opts.Commitment)
+			return result, nil
+		},
+	}
+	parser := newTestParser(t, client)
 
-	// Parse pool initialization
-	pool, err := parser.ParsePoolInit(logData)
+	pool, err := parser.ParsePoolInit(context.Background(), logMsg)
 	require.NoError(t, err)
 
-	// Verify parsed data
-	assert.NotEmpty(t, pool.ID)
+	assert.Equal(t, accounts.Amm.String(), pool.ID)
 	assert.Equal(t, testProgramID, pool.ProgramID)
-	assert.NotEmpty(t, pool.BaseMint)
-	assert.NotEmpty(t, pool.QuoteMint)
-	assert.NotEmpty(t, pool.BaseVault)
-	assert.NotEmpty(t, pool.QuoteVault)
-
-	// Verify amounts
-	assert.Greater(t, pool.InitialBase, uint64(0))
-	assert.Greater(t, pool.InitialQuote, uint64(0))
+	assert.Equal(t, sample.Signature, pool.Signature)
+	assert.Equal(t, sample.Slot, pool.Slot)
+	assert.Equal(t, accounts.CoinMint.String(), pool.BaseMint)
+	assert.Equal(t, accounts.PcMint.String(), pool.QuoteMint)
+	assert.Equal(t, accounts.LpMint.String(), pool.LPMint)
+	assert.Equal(t, accounts.PoolCoinTokenAccount.String(), pool.BaseVault)
+	assert.Equal(t, accounts.PoolPcTokenAccount.String(), pool.QuoteVault)
+	assert.Equal(t, accounts.AmmAuthority.String(), pool.Authority)
+	assert.Equal(t, accounts.UserWallet.String(), pool.Creator)
+	assert.Equal(t, accounts.AmmOpenOrders.String(), pool.OpenOrders)
+	assert.Equal(t, accounts.AmmTargetOrders.String(), pool.TargetOrders)
+	assert.Equal(t, accounts.PoolWithdrawQueue.String(), pool.WithdrawQueue)
+
+	// Amounts come from the instruction data and match what the program logged
+	assert.Equal(t, sample.InitParams.InitCoinAmount, pool.InitialBase)
+	assert.Equal(t, sample.InitParams.InitPcAmount, pool.InitialQuote)
+	assert.Equal(t, sample.InitParams.OpenTime, pool.OpenTime)
+
+	// Decimals come from the post token balances
+	assert.Equal(t, uint8(6), pool.BaseDecimals)
+	assert.Equal(t, uint8(9), pool.QuoteDecimals)
+	assert.Equal(t, uint8(9), pool.LPDecimals)
 }
 
-func TestParseInvalidLog(t *testing.T) {
-	parser, err := NewAMMParser(testProgramID)
+func TestParsePoolInitThroughCPIWithLookupTable(t *testing.T) {
+	sample, logMsg := loadFilteredLogSample(t)
+
+	for name, opts := range map[string]initTxOptions{
+		"loaded addresses in metadata": {cpi: true, lookupTable: true},
+		"lookup table fetched":         {cpi: true, lookupTable: true, omitLoadedIn: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, accounts, tables := buildInitTransaction(t, sample, opts)
+
+			client := &utils.MockRPCClient{
+				MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+					return result, nil
+				},
+				MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+					addresses, ok := tables[account]
+					require.True(t, ok, "unexpected account %s", account)
+					return lookupTableAccount(addresses), nil
+				},
+			}
+			parser := newTestParser(t, client)
+
+			pool, err := parser.ParsePoolInit(context.Background(), logMsg)
+			require.NoError(t, err)
+			assert.Equal(t, accounts.Amm.String(), pool.ID)
+			assert.Equal(t, accounts.CoinMint.String(), pool.BaseMint)
+			assert.Equal(t, accounts.PoolPcTokenAccount.String(), pool.QuoteVault)
+			assert.Equal(t, sample.InitParams.InitPcAmount, pool.InitialQuote)
+		})
+	}
+}
+
+func TestParsePoolInitRetriesUntilConfirmed(t *testing.T) {
+	sample, logMsg := loadFilteredLogSample(t)
+	result, _, _ := buildInitTransaction(t, sample, initTxOptions{})
+
+	calls := 0
+	client := &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			calls++
+			if calls < 3 {
+				return nil, rpc.ErrNotFound
+			}
+			return result, nil
+		},
+	}
+	parser := newTestParser(t, client)
+
+	_, err := parser.ParsePoolInit(context.Background(), logMsg)
 	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
 
-	// Test with invalid log data
-	invalidLog := &ws.LogResult{
-		Signature: solana.Signature{},
-		Logs:      []string{"some random log"},
+	// Other RPC errors are not retried
+	calls = 0
+	client.MockGetTransaction = func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+		calls++
+		return nil, errors.New("rate limited")
+	}
+	_, err = parser.ParsePoolInit(context.Background(), logMsg)
+	assert.ErrorContains(t, err, "rate limited")
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseInvalidLog(t *testing.T) {
+	client := &utils.MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			t.Fatal("transactions without initialize2 logs must not be fetched")
+			return nil, nil
+		},
 	}
+	parser := newTestParser(t, client)
 
-	_, err = parser.ParsePoolInit(invalidLog)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "no initialize2 instruction found")
+	// Test with invalid log data
+	invalidLog := &ws.LogResult{}
+	invalidLog.Value.Logs = []string{"some random log"}
+
+	_, err := parser.ParsePoolInit(context.Background(), invalidLog)
+	assert.ErrorIs(t, err, ErrNoPoolInit)
+}
+
+func TestParseTransactionWithoutInitialize2(t *testing.T) {
+	sample, _ := loadFilteredLogSample(t)
+	result, _, _ := buildInitTransaction(t, sample, initTxOptions{})
+
+	// A parser for another program finds no pool creation in the same transaction
+	parser, err := NewAMMParser(solana.NewWallet().PublicKey().String(), &utils.MockRPCClient{})
+	require.NoError(t, err)
+
+	_, err = parser.ParseTransaction(context.Background(), result)
+	assert.ErrorIs(t, err, ErrNoPoolInit)
 }
 
 func TestValidatePoolData(t *testing.T) {
-	parser, err := NewAMMParser(testProgramID)
+	parser, err := NewAMMParser(testProgramID, &utils.MockRPCClient{})
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -104,15 +229,155 @@ func TestValidatePoolData(t *testing.T) {
 	}
 }
 
-// loadTestLogData loads test data from file
-func loadTestLogData(t *testing.T) (*ws.LogResult, error) {
-	// Read test data file
-	data, err := os.ReadFile("testdata/raydium_init_log.json")
+func newTestParser(t *testing.T, client *utils.MockRPCClient) *AMMParser {
+	t.Helper()
+	parser, err := NewAMMParser(testProgramID, client)
+	require.NoError(t, err)
+	parser.fetchDelay = 0
+	return parser
+}
+
+// loadFilteredLogSample loads the recorded pool creation notification
+func loadFilteredLogSample(t *testing.T) (*filteredLogSample, *ws.LogResult) {
+	t.Helper()
+	data, err := os.ReadFile("../../listeners/raydium_filtered_logs.json")
+	require.NoError(t, err)
+
+	var sample filteredLogSample
+	require.NoError(t, json.Unmarshal(data, &sample))
+
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = sample.Slot
+	logMsg.Value.Signature = solana.MustSignatureFromBase58(sample.Signature)
+	logMsg.Value.Logs = sample.Logs
+	return &sample, logMsg
+}
+
+// buildInitTransaction builds the getTransaction result of a pool creation carrying the sample's
+// initialize2 arguments. The recorded sample only holds the logs, so the accounts are generated.
+func buildInitTransaction(t *testing.T, sample *filteredLogSample, opts initTxOptions) (*rpc.GetTransactionResult, raydiumamm.Initialize2Accounts, map[solana.PublicKey]solana.PublicKeySlice) {
+	t.Helper()
+	programID := solana.MustPublicKeyFromBase58(testProgramID)
+	payer := solana.NewWallet().PublicKey()
+
+	accounts := raydiumamm.Initialize2Accounts{
+		TokenProgram:              solana.TokenProgramID,
+		SplAssociatedTokenAccount: solana.SPLAssociatedTokenAccountProgramID,
+		SystemProgram:             solana.SystemProgramID,
+		Rent:                      solana.SysVarRentPubkey,
+		Amm:                       solana.NewWallet().PublicKey(),
+		AmmAuthority:              solana.MustPublicKeyFromBase58("5Q544fKrFoe6tsEbD7S8EmxGTJYAKtTVhAW5Q5pge4j1"),
+		AmmOpenOrders:             solana.NewWallet().PublicKey(),
+		LpMint:                    solana.NewWallet().PublicKey(),
+		CoinMint:                  solana.NewWallet().PublicKey(),
+		PcMint:                    solana.WrappedSol,
+		PoolCoinTokenAccount:      solana.NewWallet().PublicKey(),
+		PoolPcTokenAccount:        solana.NewWallet().PublicKey(),
+		PoolWithdrawQueue:         solana.NewWallet().PublicKey(),
+		AmmTargetOrders:           solana.NewWallet().PublicKey(),
+		PoolTempLp:                solana.NewWallet().PublicKey(),
+		SerumProgram:              solana.MustPublicKeyFromBase58("srmqPvymJeFKQ4zGQed1GFppgkRHL9kaELCbyksJtPX"),
+		SerumMarket:               solana.NewWallet().PublicKey(),
+		UserWallet:                payer,
+		UserTokenCoin:             solana.NewWallet().PublicKey(),
+		UserTokenPc:               solana.NewWallet().PublicKey(),
+		UserLpTokenAccount:        solana.NewWallet().PublicKey(),
+	}
+	args := raydiumamm.Initialize2Args{
+		Nonce:          sample.InitParams.Nonce,
+		OpenTime:       sample.InitParams.OpenTime,
+		InitPcAmount:   sample.InitParams.InitPcAmount,
+		InitCoinAmount: sample.InitParams.InitCoinAmount,
+	}
+	initIx, err := raydiumamm.NewInitialize2Instruction(programID, args, accounts)
+	require.NoError(t, err)
+	initData, err := initIx.Data()
 	require.NoError(t, err)
 
-	var logResult ws.LogResult
-	err = json.Unmarshal(data, &logResult)
+	topLevel := initIx
+	if opts.cpi {
+		// A launcher program that forwards the pool accounts and invokes the AMM
+		router := solana.NewWallet().PublicKey()
+		metas := append(initIx.Accounts(), solana.NewAccountMeta(programID, false, false))
+		topLevel = solana.NewInstruction(router, metas, []byte{42})
+	}
+
+	var txOpts []solana.TransactionOption
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice)
+	if opts.lookupTable {
+		tables[solana.NewWallet().PublicKey()] = solana.PublicKeySlice{
+			accounts.Amm, accounts.AmmOpenOrders, accounts.LpMint, accounts.CoinMint, accounts.PcMint,
+			accounts.PoolCoinTokenAccount, accounts.PoolPcTokenAccount, accounts.SerumMarket,
+		}
+		txOpts = append(txOpts, solana.TransactionAddressTables(tables))
+	}
+	tx, err := solana.NewTransaction([]solana.Instruction{topLevel}, solana.Hash{}, append(txOpts, solana.TransactionPayer(payer))...)
 	require.NoError(t, err)
 
-	return &logResult, nil
+	// Round trip through the wire format, as getTransaction returns it
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	decoded, err := solana.TransactionFromBytes(raw)
+	require.NoError(t, err)
+
+	meta := &rpc.TransactionMeta{}
+	keys := append(solana.PublicKeySlice{}, decoded.Message.AccountKeys...)
+	for _, lookup := range decoded.Message.AddressTableLookups {
+		for _, index := range lookup.WritableIndexes {
+			meta.LoadedAddresses.Writable = append(meta.LoadedAddresses.Writable, tables[lookup.AccountKey][index])
+		}
+		for _, index := range lookup.ReadonlyIndexes {
+			meta.LoadedAddresses.ReadOnly = append(meta.LoadedAddresses.ReadOnly, tables[lookup.AccountKey][index])
+		}
+	}
+	keys = append(keys, meta.LoadedAddresses.Writable...)
+	keys = append(keys, meta.LoadedAddresses.ReadOnly...)
+	if opts.lookupTable {
+		require.NotEmpty(t, decoded.Message.AddressTableLookups)
+	}
+	if opts.omitLoadedIn {
+		meta.LoadedAddresses = rpc.LoadedAddresses{}
+	}
+
+	indexOf := func(key solana.PublicKey) uint16 {
+		for k, candidate := range keys {
+			if candidate.Equals(key) {
+				return uint16(k)
+			}
+		}
+		t.Fatalf("key %s is not in the transaction", key)
+		return 0
+	}
+	if opts.cpi {
+		inner := solana.CompiledInstruction{ProgramIDIndex: indexOf(programID), Data: initData}
+		for _, account := range initIx.Accounts() {
+			inner.Accounts = append(inner.Accounts, indexOf(account.PublicKey))
+		}
+		meta.InnerInstructions = []rpc.InnerInstruction{{Index: 0, Instructions: []solana.CompiledInstruction{inner}}}
+	}
+
+	meta.PostTokenBalances = []rpc.TokenBalance{
+		{AccountIndex: indexOf(accounts.PoolCoinTokenAccount), Mint: accounts.CoinMint, UiTokenAmount: &rpc.UiTokenAmount{Decimals: 6}},
+		{AccountIndex: indexOf(accounts.PoolPcTokenAccount), Mint: accounts.PcMint, UiTokenAmount: &rpc.UiTokenAmount{Decimals: 9}},
+		{AccountIndex: indexOf(accounts.UserLpTokenAccount), Mint: accounts.LpMint, UiTokenAmount: &rpc.UiTokenAmount{Decimals: 9}},
+	}
+
+	var envelope rpc.TransactionResultEnvelope
+	encoded := fmt.Sprintf("[%q, \"base64\"]", base64.StdEncoding.EncodeToString(raw))
+	require.NoError(t, json.Unmarshal([]byte(encoded), &envelope))
+
+	return &rpc.GetTransactionResult{Slot: sample.Slot, Transaction: &envelope, Meta: meta}, accounts, tables
+}
+
+// lookupTableAccount serializes an address lookup table account holding the addresses
+func lookupTableAccount(addresses solana.PublicKeySlice) *rpc.GetAccountInfoResult {
+	data := make([]byte, 56, 56+32*len(addresses))
+	binary.LittleEndian.PutUint32(data[0:4], 1)               // lookup table type
+	binary.LittleEndian.PutUint64(data[4:12], math.MaxUint64) // never deactivated
+	for _, address := range addresses {
+		data = append(data, address[:]...)
+	}
+	return &rpc.GetAccountInfoResult{
+		Value: &rpc.Account{Data: rpc.DataBytesOrJSONFromBytes(data)},
+	}
 }
@@ -0,0 +1,131 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"corvus_bot/pkg/idl/bindings/raydiumclmm"
+	"corvus_bot/pkg/raydium/pool/clmm"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// CLMMEvent is an Anchor event emitted by the Raydium CLMM program. Event holds the generated
+// raydiumclmm type, e.g. *raydiumclmm.SwapEvent or *raydiumclmm.PoolCreatedEvent.
+type CLMMEvent struct {
+	Signature string
+	Slot      uint64
+	Name      string
+	Event     interface{}
+}
+
+// clmmEventDecoder decodes one CLMM event type from its discriminator-prefixed bytes
+type clmmEventDecoder struct {
+	name          string
+	discriminator []byte
+	decode        func(data []byte) (interface{}, error)
+}
+
+var clmmEventDecoders = []clmmEventDecoder{
+	{"ConfigChangeEvent", raydiumclmm.ConfigChangeEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeConfigChangeEvent(data)
+	}},
+	{"CreatePersonalPositionEvent", raydiumclmm.CreatePersonalPositionEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeCreatePersonalPositionEvent(data)
+	}},
+	{"IncreaseLiquidityEvent", raydiumclmm.IncreaseLiquidityEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeIncreaseLiquidityEvent(data)
+	}},
+	{"DecreaseLiquidityEvent", raydiumclmm.DecreaseLiquidityEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeDecreaseLiquidityEvent(data)
+	}},
+	{"LiquidityCalculateEvent", raydiumclmm.LiquidityCalculateEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeLiquidityCalculateEvent(data)
+	}},
+	{"CollectPersonalFeeEvent", raydiumclmm.CollectPersonalFeeEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeCollectPersonalFeeEvent(data)
+	}},
+	{"UpdateRewardInfosEvent", raydiumclmm.UpdateRewardInfosEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeUpdateRewardInfosEvent(data)
+	}},
+	{"PoolCreatedEvent", raydiumclmm.PoolCreatedEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodePoolCreatedEvent(data)
+	}},
+	{"CollectProtocolFeeEvent", raydiumclmm.CollectProtocolFeeEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeCollectProtocolFeeEvent(data)
+	}},
+	{"SwapEvent", raydiumclmm.SwapEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeSwapEvent(data)
+	}},
+	{"LiquidityChangeEvent", raydiumclmm.LiquidityChangeEventDiscriminator, func(data []byte) (interface{}, error) {
+		return raydiumclmm.DecodeLiquidityChangeEvent(data)
+	}},
+}
+
+// DecodeCLMMEvent decodes the base64 payload of a "Program data:" line by its discriminator.
+// The returned name is empty when the discriminator is not a CLMM event.
+func DecodeCLMMEvent(encoded string) (string, interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode program data base64: %w", err)
+	}
+	for _, decoder := range clmmEventDecoders {
+		if !bytes.HasPrefix(data, decoder.discriminator) {
+			continue
+		}
+		event, err := decoder.decode(data)
+		if err != nil {
+			return "", nil, err
+		}
+		return decoder.name, event, nil
+	}
+	return "", nil, nil
+}
+
+// ParseCLMMEvents decodes the events the CLMM program emitted in a log notification, in log order.
+// "Program data:" lines are attributed to the program on top of the invoke stack, so events of other
+// programs in the same transaction are skipped.
+func ParseCLMMEvents(logMsg *ws.LogResult, programID solana.PublicKey) ([]*CLMMEvent, error) {
+	signature := logMsg.Value.Signature.String()
+	slot := logMsg.Context.Slot
+
+	var events []*CLMMEvent
+	for _, data := range utils.ProgramData(logMsg.Value.Logs, programID.String()) {
+		name, event, err := DecodeCLMMEvent(data)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", signature, err)
+		}
+		if name == "" {
+			continue
+		}
+		events = append(events, &CLMMEvent{
+			Signature: signature,
+			Slot:      slot,
+			Name:      name,
+			Event:     event,
+		})
+	}
+	return events, nil
+}
+
+// PoolFromCreatedEvent builds the pool announced by a PoolCreatedEvent. Only the fields carried by the event
+// are set: clmm.FetchClmmPoolByID resolves the config, decimals and mint programs once the account is readable.
+func PoolFromCreatedEvent(event *raydiumclmm.PoolCreatedEvent, programID string) *clmm.RaydiumClmmPool {
+	return &clmm.RaydiumClmmPool{
+		ID:        event.PoolState.String(),
+		ProgramID: programID,
+		MintA:     event.TokenMint0.String(),
+		MintB:     event.TokenMint1.String(),
+		VaultA:    event.TokenVault0.String(),
+		VaultB:    event.TokenVault1.String(),
+		AmmConfig: clmm.ApiClmmConfigurationItem{
+			TickSpacing: int(event.TickSpacing),
+		},
+		SqrtPriceX64: event.SqrtPriceX64,
+		TickCurrent:  event.Tick,
+	}
+}
@@ -0,0 +1,110 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"corvus_bot/pkg/idl/bindings/raydiumclmm"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clmmProgram = "CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK"
+
+// encodeProgramData renders an event the way Anchor's emit! logs it
+func encodeProgramData(t *testing.T, discriminator []byte, event interface{}) string {
+	t.Helper()
+	buf := bytes.NewBuffer(append([]byte(nil), discriminator...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(event))
+	return utils.ProgramDataPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestParseCLMMEvents(t *testing.T) {
+	pool := solana.NewWallet().PublicKey()
+	created := raydiumclmm.PoolCreatedEvent{
+		TokenMint0:   solana.SolMint,
+		TokenMint1:   solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"),
+		TickSpacing:  60,
+		PoolState:    pool,
+		SqrtPriceX64: bin.Uint128{Lo: 7_136_148_772_669_066_240, Hi: 0},
+		Tick:         -18_420,
+		TokenVault0:  solana.NewWallet().PublicKey(),
+		TokenVault1:  solana.NewWallet().PublicKey(),
+	}
+	swap := raydiumclmm.SwapEvent{
+		PoolState:    pool,
+		Sender:       solana.NewWallet().PublicKey(),
+		Amount0:      1_000_000_000,
+		Amount1:      150_000_000,
+		ZeroForOne:   true,
+		SqrtPriceX64: bin.Uint128{Lo: 7_100_000_000_000_000_000},
+		Liquidity:    bin.Uint128{Lo: 5_000_000_000},
+		Tick:         -18_430,
+	}
+
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = 304027200
+	logMsg.Value.Signature = solana.Signature{2}
+	logMsg.Value.Logs = []string{
+		"Program " + clmmProgram + " invoke [1]",
+		"Program log: Instruction: CreatePool",
+		"Program 11111111111111111111111111111111 invoke [2]",
+		"Program 11111111111111111111111111111111 success",
+		encodeProgramData(t, raydiumclmm.PoolCreatedEventDiscriminator, created),
+		"Program " + clmmProgram + " success",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 invoke [1]",
+		// Another program emitting a CLMM shaped event must not be attributed to the CLMM
+		encodeProgramData(t, raydiumclmm.SwapEventDiscriminator, swap),
+		"Program " + clmmProgram + " invoke [2]",
+		"Program log: Instruction: SwapV2",
+		encodeProgramData(t, raydiumclmm.SwapEventDiscriminator, swap),
+		"Program " + clmmProgram + " consumed 61234 of 1400000 compute units",
+		"Program " + clmmProgram + " success",
+		"Program JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4 success",
+	}
+
+	events, err := ParseCLMMEvents(logMsg, solana.MustPublicKeyFromBase58(clmmProgram))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "PoolCreatedEvent", events[0].Name)
+	assert.Equal(t, solana.Signature{2}.String(), events[0].Signature)
+	assert.Equal(t, uint64(304027200), events[0].Slot)
+	require.IsType(t, &raydiumclmm.PoolCreatedEvent{}, events[0].Event)
+	assert.Equal(t, created, *events[0].Event.(*raydiumclmm.PoolCreatedEvent))
+
+	assert.Equal(t, "SwapEvent", events[1].Name)
+	require.IsType(t, &raydiumclmm.SwapEvent{}, events[1].Event)
+	assert.Equal(t, swap, *events[1].Event.(*raydiumclmm.SwapEvent))
+
+	entry := PoolFromCreatedEvent(events[0].Event.(*raydiumclmm.PoolCreatedEvent), clmmProgram)
+	assert.Equal(t, pool.String(), entry.ID)
+	assert.Equal(t, clmmProgram, entry.ProgramID)
+	assert.Equal(t, solana.SolMint.String(), entry.MintA)
+	assert.Equal(t, created.TokenVault1.String(), entry.VaultB)
+	assert.Equal(t, 60, entry.AmmConfig.TickSpacing)
+	assert.Equal(t, created.SqrtPriceX64, entry.SqrtPriceX64)
+	assert.Equal(t, int32(-18_420), entry.TickCurrent)
+}
+
+func TestDecodeCLMMEventErrors(t *testing.T) {
+	_, _, err := DecodeCLMMEvent("not base64!")
+	assert.Error(t, err)
+
+	// Unknown discriminators belong to events this decoder does not know about
+	name, event, err := DecodeCLMMEvent(base64.StdEncoding.EncodeToString([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}))
+	require.NoError(t, err)
+	assert.Empty(t, name)
+	assert.Nil(t, event)
+
+	// Truncated swap event
+	truncated := append(append([]byte(nil), raydiumclmm.SwapEventDiscriminator...), 1, 2, 3)
+	_, _, err = DecodeCLMMEvent(base64.StdEncoding.EncodeToString(truncated))
+	assert.Error(t, err)
+}
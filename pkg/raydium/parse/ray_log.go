@@ -0,0 +1,272 @@
+package parse
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// rayLogPrefix introduces the base64 records the AMM v4 program logs for every pool operation
+const rayLogPrefix = "ray_log: "
+
+// RayLogType identifies a ray_log record by its first byte
+type RayLogType uint8
+
+const (
+	RayLogInit RayLogType = iota
+	RayLogDeposit
+	RayLogWithdraw
+	RayLogSwapBaseIn
+	RayLogSwapBaseOut
+)
+
+// SwapDirection is the direction logged by swaps, as defined by the AMM program
+type SwapDirection uint64
+
+const (
+	// SwapPcToCoin sells the quote token for the base token
+	SwapPcToCoin SwapDirection = 1
+
+	// SwapCoinToPc sells the base token for the quote token
+	SwapCoinToPc SwapDirection = 2
+)
+
+// InitLog is logged by initialize2
+type InitLog struct {
+	LogType      uint8
+	Time         uint64
+	PcDecimals   uint8
+	CoinDecimals uint8
+	PcLotSize    uint64
+	CoinLotSize  uint64
+	PcAmount     uint64
+	CoinAmount   uint64
+	Market       solana.PublicKey
+}
+
+// DepositLog is logged by deposit. Pool amounts are the reserves before the deposit, without pending PnL.
+type DepositLog struct {
+	LogType    uint8
+	MaxCoin    uint64
+	MaxPc      uint64
+	Base       uint64
+	PoolCoin   uint64
+	PoolPc     uint64
+	PoolLp     uint64
+	CalcPnlX   bin.Uint128
+	CalcPnlY   bin.Uint128
+	DeductCoin uint64
+	DeductPc   uint64
+	MintLp     uint64
+}
+
+// WithdrawLog is logged by withdraw. Pool amounts are the reserves before the withdrawal, without pending PnL.
+type WithdrawLog struct {
+	LogType    uint8
+	WithdrawLp uint64
+	UserLp     uint64
+	PoolCoin   uint64
+	PoolPc     uint64
+	PoolLp     uint64
+	CalcPnlX   bin.Uint128
+	CalcPnlY   bin.Uint128
+	OutCoin    uint64
+	OutPc      uint64
+}
+
+// SwapBaseInLog is logged by swapBaseIn. Pool amounts are the reserves before the swap, without pending PnL.
+type SwapBaseInLog struct {
+	LogType    uint8
+	AmountIn   uint64
+	MinimumOut uint64
+	Direction  uint64
+	UserSource uint64
+	PoolCoin   uint64
+	PoolPc     uint64
+	OutAmount  uint64
+}
+
+// SwapBaseOutLog is logged by swapBaseOut. Pool amounts are the reserves before the swap, without pending PnL.
+type SwapBaseOutLog struct {
+	LogType    uint8
+	MaxIn      uint64
+	AmountOut  uint64
+	Direction  uint64
+	UserSource uint64
+	PoolCoin   uint64
+	PoolPc     uint64
+	DeductIn   uint64
+}
+
+// SwapEvent is a swap on an AMM v4 pool. ray_log records do not name the pool: consumers that need it
+// resolve the transaction from the signature.
+type SwapEvent struct {
+	Signature string
+	Slot      uint64
+	ExactIn   bool // swapBaseIn, otherwise swapBaseOut
+	Direction SwapDirection
+	AmountIn  uint64
+	AmountOut uint64
+	PoolCoin  uint64 // Base reserve before the swap
+	PoolPc    uint64 // Quote reserve before the swap
+}
+
+// ReservesAfter returns the base and quote reserves once the swap is applied; fees stay in the pool
+func (e *SwapEvent) ReservesAfter() (coin, pc uint64) {
+	if e.Direction == SwapPcToCoin {
+		return e.PoolCoin - e.AmountOut, e.PoolPc + e.AmountIn
+	}
+	return e.PoolCoin + e.AmountIn, e.PoolPc - e.AmountOut
+}
+
+// Price returns the raw quote per base price after the swap, before decimal adjustment
+func (e *SwapEvent) Price() float64 {
+	coin, pc := e.ReservesAfter()
+	if coin == 0 {
+		return 0
+	}
+	return float64(pc) / float64(coin)
+}
+
+// LiquidityEventType tells deposits and withdrawals apart
+type LiquidityEventType string
+
+const (
+	LiquidityDeposit  LiquidityEventType = "deposit"
+	LiquidityWithdraw LiquidityEventType = "withdraw"
+)
+
+// LiquidityEvent is a deposit into or a withdrawal from an AMM v4 pool
+type LiquidityEvent struct {
+	Signature  string
+	Slot       uint64
+	Type       LiquidityEventType
+	CoinAmount uint64
+	PcAmount   uint64
+	LpAmount   uint64 // LP tokens minted or burned
+	PoolCoin   uint64 // Base reserve before the operation
+	PoolPc     uint64 // Quote reserve before the operation
+	PoolLp     uint64 // LP supply before the operation
+}
+
+// RayLogEvents holds the events decoded from the ray_log lines of one transaction, in log order
+type RayLogEvents struct {
+	Inits     []*InitLog
+	Swaps     []*SwapEvent
+	Liquidity []*LiquidityEvent
+}
+
+// Empty reports whether no ray_log line was found
+func (e *RayLogEvents) Empty() bool {
+	return len(e.Inits) == 0 && len(e.Swaps) == 0 && len(e.Liquidity) == 0
+}
+
+// DecodeRayLog decodes the base64 payload of a ray_log line into an *InitLog, *DepositLog, *WithdrawLog,
+// *SwapBaseInLog or *SwapBaseOutLog
+func DecodeRayLog(encoded string) (interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ray_log base64: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty ray_log")
+	}
+
+	var record interface{}
+	switch RayLogType(data[0]) {
+	case RayLogInit:
+		record = new(InitLog)
+	case RayLogDeposit:
+		record = new(DepositLog)
+	case RayLogWithdraw:
+		record = new(WithdrawLog)
+	case RayLogSwapBaseIn:
+		record = new(SwapBaseInLog)
+	case RayLogSwapBaseOut:
+		record = new(SwapBaseOutLog)
+	default:
+		return nil, fmt.Errorf("unknown ray_log type %d", data[0])
+	}
+
+	decoder := bin.NewBorshDecoder(data)
+	if err := decoder.Decode(record); err != nil {
+		return nil, fmt.Errorf("failed to decode ray_log type %d: %w", data[0], err)
+	}
+	if decoder.Remaining() != 0 {
+		return nil, fmt.Errorf("ray_log type %d has %d trailing bytes", data[0], decoder.Remaining())
+	}
+	return record, nil
+}
+
+// ParseRayLogs decodes every ray_log line of a log notification into typed events
+func ParseRayLogs(logMsg *ws.LogResult) (*RayLogEvents, error) {
+	signature := logMsg.Value.Signature.String()
+	slot := logMsg.Context.Slot
+
+	events := &RayLogEvents{}
+	for _, line := range logMsg.Value.Logs {
+		index := strings.Index(line, rayLogPrefix)
+		if index < 0 {
+			continue
+		}
+		record, err := DecodeRayLog(line[index+len(rayLogPrefix):])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", signature, err)
+		}
+
+		switch r := record.(type) {
+		case *InitLog:
+			events.Inits = append(events.Inits, r)
+		case *SwapBaseInLog:
+			events.Swaps = append(events.Swaps, &SwapEvent{
+				Signature: signature,
+				Slot:      slot,
+				ExactIn:   true,
+				Direction: SwapDirection(r.Direction),
+				AmountIn:  r.AmountIn,
+				AmountOut: r.OutAmount,
+				PoolCoin:  r.PoolCoin,
+				PoolPc:    r.PoolPc,
+			})
+		case *SwapBaseOutLog:
+			events.Swaps = append(events.Swaps, &SwapEvent{
+				Signature: signature,
+				Slot:      slot,
+				Direction: SwapDirection(r.Direction),
+				AmountIn:  r.DeductIn,
+				AmountOut: r.AmountOut,
+				PoolCoin:  r.PoolCoin,
+				PoolPc:    r.PoolPc,
+			})
+		case *DepositLog:
+			events.Liquidity = append(events.Liquidity, &LiquidityEvent{
+				Signature:  signature,
+				Slot:       slot,
+				Type:       LiquidityDeposit,
+				CoinAmount: r.DeductCoin,
+				PcAmount:   r.DeductPc,
+				LpAmount:   r.MintLp,
+				PoolCoin:   r.PoolCoin,
+				PoolPc:     r.PoolPc,
+				PoolLp:     r.PoolLp,
+			})
+		case *WithdrawLog:
+			events.Liquidity = append(events.Liquidity, &LiquidityEvent{
+				Signature:  signature,
+				Slot:       slot,
+				Type:       LiquidityWithdraw,
+				CoinAmount: r.OutCoin,
+				PcAmount:   r.OutPc,
+				LpAmount:   r.WithdrawLp,
+				PoolCoin:   r.PoolCoin,
+				PoolPc:     r.PoolPc,
+				PoolLp:     r.PoolLp,
+			})
+		}
+	}
+	return events, nil
+}
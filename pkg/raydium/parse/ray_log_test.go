@@ -0,0 +1,129 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeRayLog renders a record the way the AMM program logs it
+func encodeRayLog(t *testing.T, record interface{}) string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(record))
+	return "Program log: " + rayLogPrefix + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecodeRayLogInitFromSample(t *testing.T) {
+	sample, logMsg := loadFilteredLogSample(t)
+
+	events, err := ParseRayLogs(logMsg)
+	require.NoError(t, err)
+	require.Len(t, events.Inits, 1)
+	assert.Empty(t, events.Swaps)
+
+	initLog := events.Inits[0]
+	assert.Equal(t, uint8(RayLogInit), initLog.LogType)
+	assert.Equal(t, sample.InitParams.InitPcAmount, initLog.PcAmount)
+	assert.Equal(t, sample.InitParams.InitCoinAmount, initLog.CoinAmount)
+	assert.Equal(t, uint8(6), initLog.PcDecimals)
+	assert.Equal(t, uint8(9), initLog.CoinDecimals)
+	assert.False(t, initLog.Market.IsZero())
+}
+
+func TestParseRayLogSwaps(t *testing.T) {
+	logMsg := &ws.LogResult{}
+	logMsg.Context.Slot = 304027100
+	logMsg.Value.Signature = solana.Signature{1}
+	logMsg.Value.Logs = []string{
+		"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 invoke [1]",
+		encodeRayLog(t, SwapBaseInLog{
+			LogType: uint8(RayLogSwapBaseIn), AmountIn: 1_000_000_000, MinimumOut: 140_000_000, Direction: uint64(SwapCoinToPc),
+			PoolCoin: 10_000_000_000_000, PoolPc: 1_500_000_000_000, OutAmount: 149_835_000,
+		}),
+		"Program 675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8 success",
+		encodeRayLog(t, SwapBaseOutLog{
+			LogType: uint8(RayLogSwapBaseOut), MaxIn: 160_000_000, AmountOut: 1_000_000_000, Direction: uint64(SwapPcToCoin),
+			PoolCoin: 10_000_000_000_000, PoolPc: 1_500_000_000_000, DeductIn: 150_390_000,
+		}),
+	}
+
+	events, err := ParseRayLogs(logMsg)
+	require.NoError(t, err)
+	require.Len(t, events.Swaps, 2)
+
+	exactIn := events.Swaps[0]
+	assert.True(t, exactIn.ExactIn)
+	assert.Equal(t, SwapCoinToPc, exactIn.Direction)
+	assert.Equal(t, uint64(1_000_000_000), exactIn.AmountIn)
+	assert.Equal(t, uint64(149_835_000), exactIn.AmountOut)
+	assert.Equal(t, solana.Signature{1}.String(), exactIn.Signature)
+	assert.Equal(t, uint64(304027100), exactIn.Slot)
+
+	coin, pc := exactIn.ReservesAfter()
+	assert.Equal(t, uint64(10_001_000_000_000), coin)
+	assert.Equal(t, uint64(1_499_850_165_000), pc)
+	assert.Less(t, exactIn.Price(), 0.15, "selling base lowers the price")
+
+	exactOut := events.Swaps[1]
+	assert.False(t, exactOut.ExactIn)
+	assert.Equal(t, SwapPcToCoin, exactOut.Direction)
+	assert.Equal(t, uint64(150_390_000), exactOut.AmountIn)
+	assert.Equal(t, uint64(1_000_000_000), exactOut.AmountOut)
+	assert.Greater(t, exactOut.Price(), 0.15, "buying base raises the price")
+}
+
+func TestParseRayLogLiquidity(t *testing.T) {
+	logMsg := &ws.LogResult{}
+	logMsg.Value.Logs = []string{
+		encodeRayLog(t, DepositLog{
+			LogType: uint8(RayLogDeposit), MaxCoin: 2_000_000, MaxPc: 300_000, Base: 0,
+			PoolCoin: 10_000_000, PoolPc: 1_500_000, PoolLp: 3_000_000,
+			CalcPnlX: bin.Uint128{Lo: 7}, DeductCoin: 2_000_000, DeductPc: 300_000, MintLp: 600_000,
+		}),
+		encodeRayLog(t, WithdrawLog{
+			LogType: uint8(RayLogWithdraw), WithdrawLp: 600_000, UserLp: 600_000,
+			PoolCoin: 12_000_000, PoolPc: 1_800_000, PoolLp: 3_600_000, OutCoin: 2_000_000, OutPc: 300_000,
+		}),
+	}
+
+	events, err := ParseRayLogs(logMsg)
+	require.NoError(t, err)
+	require.Len(t, events.Liquidity, 2)
+
+	deposit := events.Liquidity[0]
+	assert.Equal(t, LiquidityDeposit, deposit.Type)
+	assert.Equal(t, uint64(2_000_000), deposit.CoinAmount)
+	assert.Equal(t, uint64(300_000), deposit.PcAmount)
+	assert.Equal(t, uint64(600_000), deposit.LpAmount)
+	assert.Equal(t, uint64(3_000_000), deposit.PoolLp)
+
+	withdraw := events.Liquidity[1]
+	assert.Equal(t, LiquidityWithdraw, withdraw.Type)
+	assert.Equal(t, uint64(600_000), withdraw.LpAmount)
+	assert.Equal(t, uint64(1_800_000), withdraw.PoolPc)
+}
+
+func TestDecodeRayLogErrors(t *testing.T) {
+	_, err := DecodeRayLog("not base64!")
+	assert.Error(t, err)
+
+	_, err = DecodeRayLog(base64.StdEncoding.EncodeToString([]byte{9, 1, 2}))
+	assert.ErrorContains(t, err, "unknown ray_log type 9")
+
+	// Truncated swap record
+	_, err = DecodeRayLog(base64.StdEncoding.EncodeToString([]byte{3, 1, 2, 3}))
+	assert.Error(t, err)
+
+	logMsg := &ws.LogResult{}
+	logMsg.Value.Logs = []string{"Program log: some random log"}
+	events, err := ParseRayLogs(logMsg)
+	require.NoError(t, err)
+	assert.True(t, events.Empty())
+}
@@ -0,0 +1,98 @@
+package amm
+
+import (
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// AmmInfo mirrors the AmmInfo account of the Raydium AMM IDL, field for field
+type AmmInfo struct {
+	Status             uint64
+	Nonce              uint64
+	OrderNum           uint64
+	Depth              uint64
+	CoinDecimals       uint64
+	PcDecimals         uint64
+	State              uint64
+	ResetFlag          uint64
+	MinSize            uint64
+	VolMaxCutRatio     uint64
+	AmountWave         uint64
+	CoinLotSize        uint64
+	PcLotSize          uint64
+	MinPriceMultiplier uint64
+	MaxPriceMultiplier uint64
+	SysDecimalValue    uint64
+	Fees               Fees
+	OutPut             OutPutData
+	TokenCoin          solana.PublicKey // Base vault
+	TokenPc            solana.PublicKey // Quote vault
+	CoinMint           solana.PublicKey
+	PcMint             solana.PublicKey
+	LpMint             solana.PublicKey
+	OpenOrders         solana.PublicKey
+	Market             solana.PublicKey
+	SerumDex           solana.PublicKey // OpenBook program of the market
+	TargetOrders       solana.PublicKey
+	WithdrawQueue      solana.PublicKey
+	TokenTempLp        solana.PublicKey
+	AmmOwner           solana.PublicKey
+	LpAmount           uint64
+	ClientOrderID      uint64
+	Padding            [2]uint64
+}
+
+// Fees mirrors the Fees type of the Raydium AMM IDL
+type Fees struct {
+	MinSeparateNumerator   uint64
+	MinSeparateDenominator uint64
+	TradeFeeNumerator      uint64
+	TradeFeeDenominator    uint64
+	PnlNumerator           uint64
+	PnlDenominator         uint64
+	SwapFeeNumerator       uint64
+	SwapFeeDenominator     uint64
+}
+
+// OutPutData mirrors the OutPutData type of the Raydium AMM IDL
+type OutPutData struct {
+	NeedTakePnlCoin     uint64
+	NeedTakePnlPc       uint64
+	TotalPnlPc          uint64
+	TotalPnlCoin        uint64
+	PoolOpenTime        uint64
+	PunishPcAmount      uint64
+	PunishCoinAmount    uint64
+	OrderbookToInitTime uint64
+	SwapCoinInAmount    bin.Uint128
+	SwapPcOutAmount     bin.Uint128
+	SwapTakePcFee       uint64
+	SwapPcInAmount      bin.Uint128
+	SwapCoinOutAmount   bin.Uint128
+	SwapTakeCoinFee     uint64
+}
+
+// DecodeAmmInfo decodes the raw data of an AMM v4 pool account
+func DecodeAmmInfo(data []byte) (*AmmInfo, error) {
+	if len(data) != AmmInfoSize {
+		return nil, fmt.Errorf("invalid AmmInfo data length: got %d, expected %d", len(data), AmmInfoSize)
+	}
+
+	var info AmmInfo
+	if err := bin.NewBorshDecoder(data).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode AmmInfo: %w", err)
+	}
+
+	return &info, nil
+}
+
+// FindAmmAuthority derives the program authority that owns the vaults of every pool of the AMM program
+func FindAmmAuthority(programID solana.PublicKey) (solana.PublicKey, error) {
+	authority, _, err := solana.FindProgramAddress([][]byte{[]byte(AmmAuthoritySeed)}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive AMM authority: %w", err)
+	}
+	return authority, nil
+}
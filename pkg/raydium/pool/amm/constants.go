@@ -1,18 +1,45 @@
 package amm
 
+// Sizes of the AmmInfo account layout in raydium_amm_idl.json
 const (
-	// ExpectedAccountDataLength is the expected length of account data for a Raydium AMM pool
-	ExpectedAccountDataLength = 516 // Full account data length
+	// ammInfoParamsSize covers the sixteen u64 fields from status to sysDecimalValue
+	ammInfoParamsSize = 16 * 8
+
+	// FeesSize is the size of the Fees struct: eight u64 numerators and denominators
+	FeesSize = 8 * 8
+
+	// OutPutDataSize is the size of the OutPutData struct: ten u64 and four u128 fields
+	OutPutDataSize = 10*8 + 4*16
 
-	// MinAccountDataLength is the minimum required length for parsing essential pool data
-	MinAccountDataLength = 327
+	// ammInfoKeysSize covers the twelve pubkeys from tokenCoin to ammOwner
+	ammInfoKeysSize = 12 * 32
+
+	// ammInfoTrailerSize covers lpAmount, clientOrderId and the two u64 padding words
+	ammInfoTrailerSize = 4 * 8
+
+	// AmmInfoSize is the total length of an AmmInfo account (752 bytes)
+	AmmInfoSize = ammInfoParamsSize + FeesSize + OutPutDataSize + ammInfoKeysSize + ammInfoTrailerSize
+)
 
-	// ExpectedLength is the standard length for basic pool information
-	ExpectedLength = 327
+const (
+	// ExpectedAccountDataLength is the expected length of account data for a Raydium AMM pool
+	ExpectedAccountDataLength = AmmInfoSize
 
 	// Default Raydium AMM Program ID
 	DefaultAMMProgramID = "675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"
 
+	// AmmAuthoritySeed is the PDA seed of the AMM program authority
+	AmmAuthoritySeed = "amm authority"
+
+	// AmmVersion is the version reported for pools of the AMM v4 program
+	AmmVersion = 4
+
 	// WSOL mint address
 	WSOLMint = "So11111111111111111111111111111111111111112"
+
+	// SwapBaseInInstruction is the AMM v4 instruction tag for swapBaseIn (its index in the IDL)
+	SwapBaseInInstruction uint8 = 9
+
+	// SwapBaseOutInstruction is the AMM v4 instruction tag for swapBaseOut (its index in the IDL)
+	SwapBaseOutInstruction uint8 = 11
 )
@@ -53,6 +53,11 @@ func FetchAmmPoolFromJSONOrNetwork(ctx context.Context, client *rpc.Client, base
 	log.Printf("Successfully parsed pool data. ID: %s, Base: %s, Quote: %s",
 		pool.ID, pool.BaseMint, pool.QuoteMint)
 
+	// The AMM account only references the market, the swap accounts come from the keys API
+	if err := FetchAmmPoolKeysFromAPI(pool); err != nil {
+		return nil, fmt.Errorf("failed to fetch pool market keys: %w", err)
+	}
+
 	// Store the data
 	err = StorePoolData(pool, filePath)
 	if err != nil {
@@ -92,3 +97,53 @@ func FetchAmmPoolIDFromAPI(baseMint, quoteMint string) (string, string, error) {
 		poolData.ID, poolData.ProgramID)
 	return poolData.ID, poolData.ProgramID, nil
 }
+
+// FetchAmmPoolKeysFromAPI fills in the authority and OpenBook market accounts of the pool
+// from the Raydium v3 pool keys endpoint.
+func FetchAmmPoolKeysFromAPI(pool *RaydiumAmmPool) error {
+	url := fmt.Sprintf("https://api-v3.raydium.io/pools/key/ids?ids=%s", pool.ID)
+
+	log.Printf("Fetching pool keys from API: %s", url)
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("API request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var keysResp RaydiumPoolKeysResponse
+	if err := json.Unmarshal(body, &keysResp); err != nil {
+		return fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	if !keysResp.Success || len(keysResp.Data) == 0 || keysResp.Data[0].ID != pool.ID {
+		return fmt.Errorf("no pool keys found for pool ID: %s", pool.ID)
+	}
+
+	keys := keysResp.Data[0]
+	if keys.Authority != "" {
+		pool.Authority = keys.Authority
+	}
+	if keys.OpenOrders != "" {
+		pool.OpenOrders = keys.OpenOrders
+	}
+	if keys.TargetOrders != "" {
+		pool.TargetOrders = keys.TargetOrders
+	}
+	pool.MarketProgramID = keys.MarketProgramID
+	pool.MarketID = keys.MarketID
+	pool.MarketAuthority = keys.MarketAuthority
+	pool.MarketBaseVault = keys.MarketBaseVault
+	pool.MarketQuoteVault = keys.MarketQuoteVault
+	pool.MarketBids = keys.MarketBids
+	pool.MarketAsks = keys.MarketAsks
+	pool.MarketEventQueue = keys.MarketEventQueue
+	pool.LookupTableAccount = keys.LookupTableAccount
+
+	log.Printf("Resolved market %s for pool %s", pool.MarketID, pool.ID)
+	return nil
+}
@@ -0,0 +1,76 @@
+package amm
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const (
+	// DefaultSwapFeeNumerator and DefaultSwapFeeDenominator are the standard 0.25% AMM v4 swap fee
+	DefaultSwapFeeNumerator   = 25
+	DefaultSwapFeeDenominator = 10000
+)
+
+// ComputeAmountOut returns the output of a swapBaseIn of amountIn against the given reserves,
+// rounding the same way as the on-chain program: the fee is rounded up, the output down.
+func ComputeAmountOut(amountIn, reserveIn, reserveOut, feeNumerator, feeDenominator uint64) (amountOut, fee uint64, err error) {
+	if err := checkSwapInputs(reserveIn, reserveOut, feeNumerator, feeDenominator); err != nil {
+		return 0, 0, err
+	}
+
+	in := new(big.Int).SetUint64(amountIn)
+	feeAmount := ceilDiv(new(big.Int).Mul(in, new(big.Int).SetUint64(feeNumerator)), new(big.Int).SetUint64(feeDenominator))
+	inAfterFee := new(big.Int).Sub(in, feeAmount)
+
+	// out = reserveOut * inAfterFee / (reserveIn + inAfterFee)
+	numerator := new(big.Int).Mul(new(big.Int).SetUint64(reserveOut), inAfterFee)
+	denominator := new(big.Int).Add(new(big.Int).SetUint64(reserveIn), inAfterFee)
+	out := new(big.Int).Quo(numerator, denominator)
+
+	return out.Uint64(), feeAmount.Uint64(), nil
+}
+
+// ComputeAmountIn returns the input a swapBaseOut needs to receive exactly amountOut from the given reserves,
+// rounding up like the on-chain program. The returned fee is included in amountIn.
+func ComputeAmountIn(amountOut, reserveIn, reserveOut, feeNumerator, feeDenominator uint64) (amountIn, fee uint64, err error) {
+	if err := checkSwapInputs(reserveIn, reserveOut, feeNumerator, feeDenominator); err != nil {
+		return 0, 0, err
+	}
+	if amountOut >= reserveOut {
+		return 0, 0, fmt.Errorf("amount out %d exceeds pool reserve %d", amountOut, reserveOut)
+	}
+
+	// inBeforeFee = ceil(reserveIn * amountOut / (reserveOut - amountOut))
+	numerator := new(big.Int).Mul(new(big.Int).SetUint64(reserveIn), new(big.Int).SetUint64(amountOut))
+	denominator := new(big.Int).SetUint64(reserveOut - amountOut)
+	inBeforeFee := ceilDiv(numerator, denominator)
+
+	// inAfterFee = ceil(inBeforeFee * feeDenominator / (feeDenominator - feeNumerator))
+	in := ceilDiv(
+		new(big.Int).Mul(inBeforeFee, new(big.Int).SetUint64(feeDenominator)),
+		new(big.Int).SetUint64(feeDenominator-feeNumerator),
+	)
+	if !in.IsUint64() {
+		return 0, 0, fmt.Errorf("required input overflows u64")
+	}
+
+	return in.Uint64(), new(big.Int).Sub(in, inBeforeFee).Uint64(), nil
+}
+
+func checkSwapInputs(reserveIn, reserveOut, feeNumerator, feeDenominator uint64) error {
+	if reserveIn == 0 || reserveOut == 0 {
+		return fmt.Errorf("empty pool reserves: in=%d, out=%d", reserveIn, reserveOut)
+	}
+	if feeDenominator == 0 || feeNumerator >= feeDenominator {
+		return fmt.Errorf("invalid swap fee: %d/%d", feeNumerator, feeDenominator)
+	}
+	return nil
+}
+
+func ceilDiv(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
@@ -0,0 +1,34 @@
+package amm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeAmountOut(t *testing.T) {
+	// 1000 in, 0.25% fee rounded up to 3, 997 swapped against 1_000_000/2_000_000
+	out, fee, err := ComputeAmountOut(1000, 1_000_000, 2_000_000, DefaultSwapFeeNumerator, DefaultSwapFeeDenominator)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), fee)
+	assert.Equal(t, uint64(1992), out) // 2_000_000 * 997 / 1_000_997
+
+	_, _, err = ComputeAmountOut(1000, 0, 2_000_000, DefaultSwapFeeNumerator, DefaultSwapFeeDenominator)
+	assert.Error(t, err)
+}
+
+func TestComputeAmountIn(t *testing.T) {
+	in, fee, err := ComputeAmountIn(1992, 1_000_000, 2_000_000, DefaultSwapFeeNumerator, DefaultSwapFeeDenominator)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(997), in-fee) // ceil(1_000_000 * 1992 / 1_998_008)
+	assert.Equal(t, uint64(1000), in)    // ceil(997 * 10000 / 9975)
+
+	// Spending the computed input must yield at least the requested output
+	out, _, err := ComputeAmountOut(in, 1_000_000, 2_000_000, DefaultSwapFeeNumerator, DefaultSwapFeeDenominator)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, out, uint64(1992))
+
+	_, _, err = ComputeAmountIn(2_000_000, 1_000_000, 2_000_000, DefaultSwapFeeNumerator, DefaultSwapFeeDenominator)
+	assert.Error(t, err)
+}
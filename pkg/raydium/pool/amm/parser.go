@@ -7,42 +7,51 @@ import (
 	"github.com/gagliardetto/solana-go"
 )
 
+// parseAmmAccountData decodes an AmmInfo account into the pool representation used for storage and swaps.
+// Market vault, bids, asks and event queue accounts are not part of AmmInfo and are left for FetchAmmPoolKeysFromAPI.
 func parseAmmAccountData(data []byte, programID string, poolID string) (*RaydiumAmmPool, error) {
-	if len(data) < ExpectedLength {
-		return nil, fmt.Errorf("insufficient data length for parsing: got %d, expected at least %d",
-			len(data), ExpectedLength)
+	log.Printf("Parsing AMM account data: pool ID %s, data length %d", poolID, len(data))
+
+	info, err := DecodeAmmInfo(data)
+	if err != nil {
+		return nil, err
 	}
 
-	log.Printf("Parsing AMM account data: pool ID %s, data length %d", poolID, len(data))
+	programKey, err := solana.PublicKeyFromBase58(programID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid program ID %s: %w", programID, err)
+	}
+	authority, err := FindAmmAuthority(programKey)
+	if err != nil {
+		return nil, err
+	}
 
-	// The correct offsets based on Raydium AMM program account layout
-	// Reference: https://api-v3.raydium.io/docs/#/amm/get_amm_pool
 	pool := &RaydiumAmmPool{
-		ID:            poolID,
-		ProgramID:     programID,
-		BaseMint:      solana.PublicKeyFromBytes(data[0:32]).String(),
-		QuoteMint:     solana.PublicKeyFromBytes(data[32:64]).String(),
-		LpMint:        solana.PublicKeyFromBytes(data[64:96]).String(),
-		BaseVault:     solana.PublicKeyFromBytes(data[96:128]).String(),
-		QuoteVault:    solana.PublicKeyFromBytes(data[128:160]).String(),
-		LpVault:       solana.PublicKeyFromBytes(data[160:192]).String(),
-		Authority:     solana.PublicKeyFromBytes(data[192:224]).String(),
-		OpenOrders:    solana.PublicKeyFromBytes(data[224:256]).String(),
-		TargetOrders:  solana.PublicKeyFromBytes(data[256:288]).String(),
-		WithdrawQueue: solana.PublicKeyFromBytes(data[288:320]).String(),
-		// Updated offsets for version and decimals
-		Version:       uint8(data[321]), // Changed from 320 to 321
-		BaseDecimals:  uint8(data[322]), // Changed from 324 to 322
-		QuoteDecimals: uint8(data[323]), // Changed from 325 to 323
-		LpDecimals:    uint8(data[324]), // Changed from 326 to 324
+		ID:              poolID,
+		ProgramID:       programID,
+		BaseMint:        info.CoinMint.String(),
+		QuoteMint:       info.PcMint.String(),
+		LpMint:          info.LpMint.String(),
+		BaseVault:       info.TokenCoin.String(),
+		QuoteVault:      info.TokenPc.String(),
+		LpVault:         info.TokenTempLp.String(),
+		Authority:       authority.String(),
+		OpenOrders:      info.OpenOrders.String(),
+		TargetOrders:    info.TargetOrders.String(),
+		WithdrawQueue:   info.WithdrawQueue.String(),
+		Version:         AmmVersion,
+		BaseDecimals:    uint8(info.CoinDecimals),
+		QuoteDecimals:   uint8(info.PcDecimals),
+		LpDecimals:      uint8(info.CoinDecimals), // LP mints are created with the coin decimals
+		MarketProgramID: info.SerumDex.String(),
+		MarketID:        info.Market.String(),
 	}
 
-	// Changed to uint8 instead of int for proper byte reading
 	log.Printf("Parsed pool data: Base Mint: %s, Quote Mint: %s", pool.BaseMint, pool.QuoteMint)
 	log.Printf("Vaults - Base: %s, Quote: %s", pool.BaseVault, pool.QuoteVault)
 	log.Printf("Decimals - Base: %d, Quote: %d, LP: %d",
 		pool.BaseDecimals, pool.QuoteDecimals, pool.LpDecimals)
-	log.Printf("Version: %d", pool.Version)
+	log.Printf("Status: %d, Market: %s", info.Status, pool.MarketID)
 
 	// Validate parsed data
 	if err := validatePoolData(pool); err != nil {
@@ -0,0 +1,204 @@
+package amm
+
+import (
+	"context"
+	"fmt"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultSlippageBps is the slippage applied when the caller does not provide a minimum output
+	DefaultSlippageBps = 100
+)
+
+// PoolReserves is the swappable state of an AMM pool at a point in time
+type PoolReserves struct {
+	BaseReserve  uint64 // Base vault balance minus pnl owed to the protocol
+	QuoteReserve uint64 // Quote vault balance minus pnl owed to the protocol
+	Fees         Fees
+}
+
+// SwapQuote is the expected result of a swap against the current pool state
+type SwapQuote struct {
+	InputMint    string
+	OutputMint   string
+	AmountIn     uint64  // Input amount, including the fee
+	AmountOut    uint64  // Expected output amount
+	MinAmountOut uint64  // AmountOut reduced by the slippage tolerance
+	MaxAmountIn  uint64  // AmountIn increased by the slippage tolerance
+	Fee          uint64  // Swap fee paid, in input token units
+	PriceImpact  float64 // Fraction of the spot price lost to the trade size, e.g. 0.01 for 1%
+	ReserveIn    uint64
+	ReserveOut   uint64
+	SlippageBps  uint64
+}
+
+// Quote returns the expected output of swapping amountIn of inputMint, using live vault balances and pool fees.
+func Quote(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	pool RaydiumAmmPool,
+	inputMint solana.PublicKey,
+	amountIn uint64,
+	slippageBps uint64,
+) (*SwapQuote, error) {
+	reserves, err := FetchPoolReserves(ctx, client, pool)
+	if err != nil {
+		return nil, err
+	}
+	return QuoteWithReserves(pool, *reserves, inputMint, amountIn, slippageBps)
+}
+
+// QuoteExactOut returns the input needed to receive exactly amountOut, using live vault balances and pool fees.
+func QuoteExactOut(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	pool RaydiumAmmPool,
+	inputMint solana.PublicKey,
+	amountOut uint64,
+	slippageBps uint64,
+) (*SwapQuote, error) {
+	reserves, err := FetchPoolReserves(ctx, client, pool)
+	if err != nil {
+		return nil, err
+	}
+	return QuoteExactOutWithReserves(pool, *reserves, inputMint, amountOut, slippageBps)
+}
+
+// QuoteWithReserves computes a swapBaseIn quote from already known reserves without any network access.
+func QuoteWithReserves(
+	pool RaydiumAmmPool,
+	reserves PoolReserves,
+	inputMint solana.PublicKey,
+	amountIn uint64,
+	slippageBps uint64,
+) (*SwapQuote, error) {
+	quote, err := newQuote(pool, reserves, inputMint, slippageBps)
+	if err != nil {
+		return nil, err
+	}
+
+	amountOut, fee, err := ComputeAmountOut(amountIn, quote.ReserveIn, quote.ReserveOut,
+		reserves.Fees.SwapFeeNumerator, reserves.Fees.SwapFeeDenominator)
+	if err != nil {
+		return nil, err
+	}
+
+	quote.AmountIn = amountIn
+	quote.AmountOut = amountOut
+	quote.Fee = fee
+	quote.MinAmountOut = utils.ApplySlippageDown(amountOut, slippageBps)
+	quote.MaxAmountIn = amountIn
+	quote.PriceImpact = priceImpact(quote.ReserveIn, amountIn-fee)
+	return quote, nil
+}
+
+// QuoteExactOutWithReserves computes a swapBaseOut quote from already known reserves without any network access.
+func QuoteExactOutWithReserves(
+	pool RaydiumAmmPool,
+	reserves PoolReserves,
+	inputMint solana.PublicKey,
+	amountOut uint64,
+	slippageBps uint64,
+) (*SwapQuote, error) {
+	quote, err := newQuote(pool, reserves, inputMint, slippageBps)
+	if err != nil {
+		return nil, err
+	}
+
+	amountIn, fee, err := ComputeAmountIn(amountOut, quote.ReserveIn, quote.ReserveOut,
+		reserves.Fees.SwapFeeNumerator, reserves.Fees.SwapFeeDenominator)
+	if err != nil {
+		return nil, err
+	}
+
+	quote.AmountIn = amountIn
+	quote.AmountOut = amountOut
+	quote.Fee = fee
+	quote.MinAmountOut = amountOut
+	quote.MaxAmountIn = utils.ApplySlippageUp(amountIn, slippageBps)
+	quote.PriceImpact = priceImpact(quote.ReserveIn, amountIn-fee)
+	return quote, nil
+}
+
+// FetchPoolReserves reads the vault balances and the AmmInfo fees and pnl state of the pool.
+func FetchPoolReserves(ctx context.Context, client utils.RPCClientInterface, pool RaydiumAmmPool) (*PoolReserves, error) {
+	poolID, err := solana.PublicKeyFromBase58(pool.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool ID %s: %w", pool.ID, err)
+	}
+
+	accountInfo, err := client.GetAccountInfo(ctx, poolID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pool account: %w", err)
+	}
+	if accountInfo == nil || accountInfo.Value == nil {
+		return nil, fmt.Errorf("no account data found for pool ID: %s", pool.ID)
+	}
+
+	info, err := DecodeAmmInfo(accountInfo.Value.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pool state: %w", err)
+	}
+
+	baseBalance, quoteBalance, err := fetchVaultReserves(ctx, client, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPoolReserves(baseBalance, quoteBalance, info.Fees, info.OutPut), nil
+}
+
+// ReservesFromState computes the swappable reserves from vault balances and the decoded pool account, for
+// callers that track those accounts themselves
+func ReservesFromState(baseBalance, quoteBalance uint64, info *AmmInfo) *PoolReserves {
+	return newPoolReserves(baseBalance, quoteBalance, info.Fees, info.OutPut)
+}
+
+// newPoolReserves removes the pnl owed to the protocol from the vault balances, as the program does before swapping
+func newPoolReserves(baseBalance, quoteBalance uint64, fees Fees, output OutPutData) *PoolReserves {
+	return &PoolReserves{
+		BaseReserve:  saturatingSub(baseBalance, output.NeedTakePnlCoin),
+		QuoteReserve: saturatingSub(quoteBalance, output.NeedTakePnlPc),
+		Fees:         fees,
+	}
+}
+
+func newQuote(pool RaydiumAmmPool, reserves PoolReserves, inputMint solana.PublicKey, slippageBps uint64) (*SwapQuote, error) {
+	if slippageBps > utils.BpsDenominator {
+		return nil, fmt.Errorf("invalid slippage: %d bps", slippageBps)
+	}
+
+	quote := &SwapQuote{InputMint: inputMint.String(), SlippageBps: slippageBps}
+	switch quote.InputMint {
+	case pool.BaseMint:
+		quote.OutputMint = pool.QuoteMint
+		quote.ReserveIn, quote.ReserveOut = reserves.BaseReserve, reserves.QuoteReserve
+	case pool.QuoteMint:
+		quote.OutputMint = pool.BaseMint
+		quote.ReserveIn, quote.ReserveOut = reserves.QuoteReserve, reserves.BaseReserve
+	default:
+		return nil, fmt.Errorf("mint %s is not part of pool %s", quote.InputMint, pool.ID)
+	}
+
+	return quote, nil
+}
+
+// priceImpact is the relative move of the spot price caused by adding amountIn to reserveIn
+func priceImpact(reserveIn, amountInAfterFee uint64) float64 {
+	if amountInAfterFee == 0 {
+		return 0
+	}
+	total := float64(reserveIn) + float64(amountInAfterFee)
+	return float64(amountInAfterFee) / total
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
@@ -0,0 +1,55 @@
+package amm
+
+import (
+	"context"
+	"testing"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuote(t *testing.T) {
+	pool := newTestPool()
+	client := &utils.MockRPCClient{}
+	// 10 base and 20 quote units are owed to the protocol and not swappable
+	mockPoolState(t, client, pool, 1_000_010, 2_000_020, OutPutData{NeedTakePnlCoin: 10, NeedTakePnlPc: 20})
+
+	quote, err := Quote(context.Background(), client, pool, solana.MustPublicKeyFromBase58(pool.BaseMint), 1000, 100)
+	require.NoError(t, err)
+
+	assert.Equal(t, pool.QuoteMint, quote.OutputMint)
+	assert.Equal(t, uint64(1_000_000), quote.ReserveIn)
+	assert.Equal(t, uint64(2_000_000), quote.ReserveOut)
+	assert.Equal(t, uint64(1992), quote.AmountOut)
+	assert.Equal(t, uint64(3), quote.Fee)
+	assert.Equal(t, uint64(1972), quote.MinAmountOut) // 1992 * 0.99
+	assert.InDelta(t, 997.0/1_000_997.0, quote.PriceImpact, 1e-12)
+
+	// Reverse direction uses the quote reserve as input
+	quote, err = Quote(context.Background(), client, pool, solana.MustPublicKeyFromBase58(pool.QuoteMint), 2000, 0)
+	require.NoError(t, err)
+	assert.Equal(t, pool.BaseMint, quote.OutputMint)
+	assert.Equal(t, uint64(2_000_000), quote.ReserveIn)
+	assert.Equal(t, quote.AmountOut, quote.MinAmountOut)
+}
+
+func TestQuoteExactOut(t *testing.T) {
+	pool := newTestPool()
+	reserves := PoolReserves{
+		BaseReserve:  1_000_000,
+		QuoteReserve: 2_000_000,
+		Fees:         Fees{SwapFeeNumerator: DefaultSwapFeeNumerator, SwapFeeDenominator: DefaultSwapFeeDenominator},
+	}
+
+	quote, err := QuoteExactOutWithReserves(pool, reserves, solana.MustPublicKeyFromBase58(pool.BaseMint), 1992, 50)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), quote.AmountIn)
+	assert.Equal(t, uint64(1005), quote.MaxAmountIn) // 1000 * 1.005
+	assert.Equal(t, uint64(1992), quote.MinAmountOut)
+
+	_, err = QuoteWithReserves(pool, reserves, solana.NewWallet().PublicKey(), 1000, 50)
+	assert.Error(t, err)
+}
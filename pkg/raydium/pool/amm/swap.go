@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"strconv"
 
 	"corvus_bot/pkg/utils"
 
@@ -12,6 +13,7 @@ import (
 )
 
 // SwapTokens performs a token swap within the Raydium AMM pool.
+// It sends a swapBaseIn instruction spending inputAmount of inputMint from the wallet's associated token account.
 func SwapTokens(
 	ctx context.Context,
 	client utils.RPCClientInterface, // Use RPCClientInterface instead of *config.Config
@@ -20,47 +22,250 @@ func SwapTokens(
 	inputMint, outputMint solana.PublicKey,
 	inputAmount, minOutputAmount uint64,
 ) (solana.Signature, error) {
+	// Resolve the wallet's token accounts for both sides of the swap
+	userSource, userDestination, err := userTokenAccounts(pool, wallet.PublicKey(), inputMint, outputMint)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+
+	// Construct the instruction
+	swapInstruction, err := NewSwapBaseInInstruction(
+		pool,
+		userSource,
+		userDestination,
+		wallet.PublicKey(),
+		inputAmount,
+		minOutputAmount,
+	)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build swap instruction: %w", err)
+	}
+
+	return sendSwapTransaction(ctx, client, wallet, swapInstruction)
+}
+
+// SwapTokensExactOut buys exactly amountOut of outputMint, spending at most maxAmountIn of inputMint.
+// The required input is quoted locally from the current pool state and the swap is refused
+// before sending when it does not fit within maxAmountIn. The program enforces the same bound on-chain.
+func SwapTokensExactOut(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool RaydiumAmmPool,
+	inputMint, outputMint solana.PublicKey,
+	maxAmountIn, amountOut uint64,
+) (solana.Signature, error) {
+	userSource, userDestination, err := userTokenAccounts(pool, wallet.PublicKey(), inputMint, outputMint)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+
+	quote, err := QuoteExactOut(ctx, client, pool, inputMint, amountOut, 0)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to compute required input: %w", err)
+	}
+	if quote.AmountIn > maxAmountIn {
+		return solana.Signature{}, fmt.Errorf("required input %d exceeds max amount in %d", quote.AmountIn, maxAmountIn)
+	}
+
+	swapInstruction, err := NewSwapBaseOutInstruction(
+		pool,
+		userSource,
+		userDestination,
+		wallet.PublicKey(),
+		maxAmountIn,
+		amountOut,
+	)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build swap instruction: %w", err)
+	}
+
+	return sendSwapTransaction(ctx, client, wallet, swapInstruction)
+}
+
+// NewSwapBaseInInstruction builds the AMM v4 swapBaseIn instruction as defined in the Raydium AMM IDL.
+func NewSwapBaseInInstruction(
+	pool RaydiumAmmPool,
+	userSource, userDestination, userOwner solana.PublicKey,
+	amountIn, minimumAmountOut uint64,
+) (solana.Instruction, error) {
+	programID, accounts, err := swapAccounts(pool, userSource, userDestination, userOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tag byte followed by the SwapInstructionBaseIn arguments
+	data := make([]byte, 17)
+	data[0] = SwapBaseInInstruction
+	binary.LittleEndian.PutUint64(data[1:], amountIn)         // Input amount
+	binary.LittleEndian.PutUint64(data[9:], minimumAmountOut) // Minimum output amount
+
+	return solana.NewInstruction(programID, accounts, data), nil
+}
+
+// NewSwapBaseOutInstruction builds the AMM v4 swapBaseOut instruction as defined in the Raydium AMM IDL.
+func NewSwapBaseOutInstruction(
+	pool RaydiumAmmPool,
+	userSource, userDestination, userOwner solana.PublicKey,
+	maxAmountIn, amountOut uint64,
+) (solana.Instruction, error) {
+	programID, accounts, err := swapAccounts(pool, userSource, userDestination, userOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tag byte followed by the SwapInstructionBaseOut arguments
+	data := make([]byte, 17)
+	data[0] = SwapBaseOutInstruction
+	binary.LittleEndian.PutUint64(data[1:], maxAmountIn) // Maximum input amount
+	binary.LittleEndian.PutUint64(data[9:], amountOut)   // Exact output amount
+
+	return solana.NewInstruction(programID, accounts, data), nil
+}
+
+// swapAccounts returns the program ID and the account list shared by swapBaseIn and swapBaseOut, in IDL order.
+func swapAccounts(
+	pool RaydiumAmmPool,
+	userSource, userDestination, userOwner solana.PublicKey,
+) (solana.PublicKey, solana.AccountMetaSlice, error) {
+	var (
+		programID, amm, authority, openOrders, targetOrders  solana.PublicKey
+		baseVault, quoteVault                                solana.PublicKey
+		marketProgram, market, bids, asks, eventQueue        solana.PublicKey
+		marketBaseVault, marketQuoteVault, marketVaultSigner solana.PublicKey
+	)
+
 	// Convert string-based public keys in the pool to solana.PublicKey
-	baseVault, err := solana.PublicKeyFromBase58(pool.BaseVault)
+	keys := []struct {
+		name  string
+		value string
+		dest  *solana.PublicKey
+	}{
+		{"ProgramID", pool.ProgramID, &programID},
+		{"ID", pool.ID, &amm},
+		{"Authority", pool.Authority, &authority},
+		{"OpenOrders", pool.OpenOrders, &openOrders},
+		{"TargetOrders", pool.TargetOrders, &targetOrders},
+		{"BaseVault", pool.BaseVault, &baseVault},
+		{"QuoteVault", pool.QuoteVault, &quoteVault},
+		{"MarketProgramID", pool.MarketProgramID, &marketProgram},
+		{"MarketID", pool.MarketID, &market},
+		{"MarketBids", pool.MarketBids, &bids},
+		{"MarketAsks", pool.MarketAsks, &asks},
+		{"MarketEventQueue", pool.MarketEventQueue, &eventQueue},
+		{"MarketBaseVault", pool.MarketBaseVault, &marketBaseVault},
+		{"MarketQuoteVault", pool.MarketQuoteVault, &marketQuoteVault},
+		{"MarketAuthority", pool.MarketAuthority, &marketVaultSigner},
+	}
+	for _, key := range keys {
+		pubKey, err := solana.PublicKeyFromBase58(key.value)
+		if err != nil {
+			return solana.PublicKey{}, nil, fmt.Errorf("invalid %s public key: %w", key.name, err)
+		}
+		*key.dest = pubKey
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(solana.TokenProgramID, false, false), // tokenProgram
+		solana.NewAccountMeta(amm, true, false),                    // amm
+		solana.NewAccountMeta(authority, false, false),             // ammAuthority
+		solana.NewAccountMeta(openOrders, true, false),             // ammOpenOrders
+		solana.NewAccountMeta(targetOrders, true, false),           // ammTargetOrders
+		solana.NewAccountMeta(baseVault, true, false),              // poolCoinTokenAccount
+		solana.NewAccountMeta(quoteVault, true, false),             // poolPcTokenAccount
+		solana.NewAccountMeta(marketProgram, false, false),         // serumProgram
+		solana.NewAccountMeta(market, true, false),                 // serumMarket
+		solana.NewAccountMeta(bids, true, false),                   // serumBids
+		solana.NewAccountMeta(asks, true, false),                   // serumAsks
+		solana.NewAccountMeta(eventQueue, true, false),             // serumEventQueue
+		solana.NewAccountMeta(marketBaseVault, true, false),        // serumCoinVaultAccount
+		solana.NewAccountMeta(marketQuoteVault, true, false),       // serumPcVaultAccount
+		solana.NewAccountMeta(marketVaultSigner, false, false),     // serumVaultSigner
+		solana.NewAccountMeta(userSource, true, false),             // userSourceTokenAccount
+		solana.NewAccountMeta(userDestination, true, false),        // userDestinationTokenAccount
+		solana.NewAccountMeta(userOwner, false, true),              // userSourceOwner
+	}
+
+	return programID, accounts, nil
+}
+
+// userTokenAccounts derives the owner's associated token accounts for the input and output mints,
+// checking that the mints are the two sides of the pool.
+func userTokenAccounts(
+	pool RaydiumAmmPool,
+	owner, inputMint, outputMint solana.PublicKey,
+) (solana.PublicKey, solana.PublicKey, error) {
+	input, output := inputMint.String(), outputMint.String()
+	if !(input == pool.BaseMint && output == pool.QuoteMint) && !(input == pool.QuoteMint && output == pool.BaseMint) {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf(
+			"mints %s -> %s do not match pool %s (%s/%s)", input, output, pool.ID, pool.BaseMint, pool.QuoteMint)
+	}
+
+	userSource, _, err := solana.FindAssociatedTokenAddress(owner, inputMint)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("failed to derive source token account: %w", err)
+	}
+
+	userDestination, _, err := solana.FindAssociatedTokenAddress(owner, outputMint)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("failed to derive destination token account: %w", err)
+	}
+
+	return userSource, userDestination, nil
+}
+
+// fetchVaultReserves reads the current base and quote vault balances of the pool.
+func fetchVaultReserves(ctx context.Context, client utils.RPCClientInterface, pool RaydiumAmmPool) (uint64, uint64, error) {
+	baseReserve, err := fetchTokenBalance(ctx, client, pool.BaseVault)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch base vault balance: %w", err)
+	}
+
+	quoteReserve, err := fetchTokenBalance(ctx, client, pool.QuoteVault)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch quote vault balance: %w", err)
+	}
+
+	return baseReserve, quoteReserve, nil
+}
+
+func fetchTokenBalance(ctx context.Context, client utils.RPCClientInterface, account string) (uint64, error) {
+	pubKey, err := solana.PublicKeyFromBase58(account)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("invalid BaseVault public key: %w", err)
+		return 0, fmt.Errorf("invalid token account %s: %w", account, err)
 	}
 
-	quoteVault, err := solana.PublicKeyFromBase58(pool.QuoteVault)
+	result, err := client.GetTokenAccountBalance(ctx, pubKey, rpc.CommitmentProcessed)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("invalid QuoteVault public key: %w", err)
+		return 0, err
+	}
+	if result == nil || result.Value == nil {
+		return 0, fmt.Errorf("no balance returned for token account %s", account)
 	}
 
-	programID, err := solana.PublicKeyFromBase58(pool.ProgramID)
+	amount, err := strconv.ParseUint(result.Value.Amount, 10, 64)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("invalid ProgramID public key: %w", err)
+		return 0, fmt.Errorf("invalid balance %q for token account %s: %w", result.Value.Amount, account, err)
 	}
+	return amount, nil
+}
 
+// sendSwapTransaction wraps the instruction in a transaction paid and signed by the wallet and sends it.
+func sendSwapTransaction(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	instruction solana.Instruction,
+) (solana.Signature, error) {
 	// Fetch the latest blockhash
 	blockhashResult, err := client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
 	if err != nil {
 		return solana.Signature{}, fmt.Errorf("failed to fetch latest blockhash: %w", err)
 	}
 
-	// Construct the data payload for the Raydium swap instruction
-	data := make([]byte, 16)
-	binary.LittleEndian.PutUint64(data[0:], inputAmount)     // Input amount
-	binary.LittleEndian.PutUint64(data[8:], minOutputAmount) // Minimum output amount
-
-	// Construct the instruction
-	swapInstruction := solana.NewInstruction(
-		programID,
-		solana.AccountMetaSlice{
-			solana.NewAccountMeta(baseVault, true, false),          // Base token vault
-			solana.NewAccountMeta(quoteVault, true, false),         // Quote token vault
-			solana.NewAccountMeta(wallet.PublicKey(), false, true), // Payer wallet
-		},
-		data,
-	)
-
 	// Create the transaction
 	tx, err := solana.NewTransaction(
-		[]solana.Instruction{swapInstruction},
+		[]solana.Instruction{instruction},
 		blockhashResult.Value.Blockhash,
 		solana.TransactionPayer(wallet.PublicKey()),
 	)
@@ -1,15 +1,20 @@
 package amm
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"strconv"
 	"testing"
 
 	"corvus_bot/pkg/config"
 	"corvus_bot/pkg/utils"
 
+	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSwapTokens(t *testing.T) {
@@ -18,6 +23,8 @@ func TestSwapTokens(t *testing.T) {
 		Testing: true,
 	}
 
+	var sentTx *solana.Transaction
+
 	// Mock RPC client
 	utils.SetMockRPCClient(&utils.MockRPCClient{
 		MockGetLatestBlockhash: func(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error) {
@@ -29,6 +36,7 @@ func TestSwapTokens(t *testing.T) {
 			}, nil
 		},
 		MockSendTransaction: func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+			sentTx = tx
 			return solana.MustSignatureFromBase58("67ZwYesdsTXe9noVdf8Z1DqMvXPErsWL2y3QiGM3Lg9t1S63bzBTxkkieUWbCg1iCjscy1Lk1TH8uYpMNbcBaHdA"), nil
 		},
 	})
@@ -40,11 +48,7 @@ func TestSwapTokens(t *testing.T) {
 	}
 
 	// Create a mock pool
-	pool := RaydiumAmmPool{
-		BaseVault:  "8A8R5PA2mNe5dcdbMtGncd2KrCqAEogKcs39XhDhMdSA", // Valid base58
-		QuoteVault: "G5oAZj84EXR7TPxY7wAsEoA7R9ZqrStESp3Dz8cHkSo3", // Valid base58
-		ProgramID:  "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg9ZpTTDdmz8", // Valid base58
-	}
+	pool := newTestPool()
 
 	// Define input and output parameters
 	inputAmount := uint64(1000)
@@ -59,8 +63,8 @@ func TestSwapTokens(t *testing.T) {
 		client, // Pass the RPC client instead of cfg
 		wallet,
 		pool,
-		solana.MustPublicKeyFromBase58(pool.BaseVault),
-		solana.MustPublicKeyFromBase58(pool.QuoteVault),
+		solana.MustPublicKeyFromBase58(pool.BaseMint),
+		solana.MustPublicKeyFromBase58(pool.QuoteMint),
 		inputAmount,
 		minOutputAmount,
 	)
@@ -68,4 +72,152 @@ func TestSwapTokens(t *testing.T) {
 	// Assertions to verify correctness
 	assert.NoError(t, err, "expected no error during token swap")
 	assert.Equal(t, solana.MustSignatureFromBase58("67ZwYesdsTXe9noVdf8Z1DqMvXPErsWL2y3QiGM3Lg9t1S63bzBTxkkieUWbCg1iCjscy1Lk1TH8uYpMNbcBaHdA"), sig, "unexpected signature returned")
+
+	// The sent instruction must be a swapBaseIn with the full IDL account list
+	require.NotNil(t, sentTx)
+	require.Len(t, sentTx.Message.Instructions, 1)
+	compiled := sentTx.Message.Instructions[0]
+	assert.Len(t, compiled.Accounts, 18)
+	assert.Equal(t, SwapBaseInInstruction, compiled.Data[0])
+	assert.Equal(t, inputAmount, binary.LittleEndian.Uint64(compiled.Data[1:9]))
+	assert.Equal(t, minOutputAmount, binary.LittleEndian.Uint64(compiled.Data[9:17]))
+}
+
+func TestNewSwapBaseInInstruction(t *testing.T) {
+	pool := newTestPool()
+	owner := solana.NewWallet().PublicKey()
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+
+	ix, err := NewSwapBaseInInstruction(pool, source, destination, owner, 1000, 900)
+	require.NoError(t, err)
+
+	assert.Equal(t, solana.MustPublicKeyFromBase58(pool.ProgramID), ix.ProgramID())
+
+	accounts := ix.Accounts()
+	require.Len(t, accounts, 18)
+	assert.Equal(t, solana.TokenProgramID, accounts[0].PublicKey)
+	assert.Equal(t, pool.ID, accounts[1].PublicKey.String())
+	assert.Equal(t, pool.BaseVault, accounts[5].PublicKey.String())
+	assert.Equal(t, pool.QuoteVault, accounts[6].PublicKey.String())
+	assert.Equal(t, pool.MarketID, accounts[8].PublicKey.String())
+	assert.Equal(t, pool.MarketAuthority, accounts[14].PublicKey.String())
+	assert.Equal(t, source, accounts[15].PublicKey)
+	assert.Equal(t, destination, accounts[16].PublicKey)
+	assert.True(t, accounts[17].IsSigner)
+	assert.False(t, accounts[17].IsWritable)
+
+	data, err := ix.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{9, 0xe8, 0x03, 0, 0, 0, 0, 0, 0, 0x84, 0x03, 0, 0, 0, 0, 0, 0}, data)
+}
+
+func TestSwapTokensRejectsForeignMint(t *testing.T) {
+	pool := newTestPool()
+	wallet := solana.NewWallet()
+
+	_, err := SwapTokens(
+		context.Background(),
+		&utils.MockRPCClient{},
+		wallet,
+		pool,
+		solana.NewWallet().PublicKey(),
+		solana.MustPublicKeyFromBase58(pool.QuoteMint),
+		1000,
+		900,
+	)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "do not match pool")
+}
+
+func TestSwapTokensExactOut(t *testing.T) {
+	pool := newTestPool()
+	wallet := solana.NewWallet()
+
+	var sentTx *solana.Transaction
+	client := &utils.MockRPCClient{
+		MockGetLatestBlockhash: func(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error) {
+			return &rpc.GetLatestBlockhashResult{
+				Value: &rpc.LatestBlockhashResult{Blockhash: solana.MustHashFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")},
+			}, nil
+		},
+		MockSendTransaction: func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+			sentTx = tx
+			return solana.Signature{}, nil
+		},
+	}
+	mockPoolState(t, client, pool, 1_000_000, 2_000_000, OutPutData{})
+
+	baseMint := solana.MustPublicKeyFromBase58(pool.BaseMint)
+	quoteMint := solana.MustPublicKeyFromBase58(pool.QuoteMint)
+
+	// Buying 1992 quote needs 1000 base, which is outside a budget of 999
+	_, err := SwapTokensExactOut(context.Background(), client, wallet, pool, baseMint, quoteMint, 999, 1992)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds max amount in")
+	assert.Nil(t, sentTx)
+
+	_, err = SwapTokensExactOut(context.Background(), client, wallet, pool, baseMint, quoteMint, 1010, 1992)
+	require.NoError(t, err)
+	require.NotNil(t, sentTx)
+
+	compiled := sentTx.Message.Instructions[0]
+	assert.Len(t, compiled.Accounts, 18)
+	assert.Equal(t, SwapBaseOutInstruction, compiled.Data[0])
+	assert.Equal(t, uint64(1010), binary.LittleEndian.Uint64(compiled.Data[1:9]))
+	assert.Equal(t, uint64(1992), binary.LittleEndian.Uint64(compiled.Data[9:17]))
+}
+
+// mockPoolState serves the given vault balances and a standard AmmInfo account for the pool
+func mockPoolState(t *testing.T, client *utils.MockRPCClient, pool RaydiumAmmPool, baseBalance, quoteBalance uint64, output OutPutData) {
+	info := AmmInfo{
+		Status: 6,
+		Fees: Fees{
+			TradeFeeNumerator:   25,
+			TradeFeeDenominator: 10000,
+			SwapFeeNumerator:    DefaultSwapFeeNumerator,
+			SwapFeeDenominator:  DefaultSwapFeeDenominator,
+		},
+		OutPut: output,
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(info))
+	data := buf.Bytes()
+
+	client.MockGetAccountInfo = func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+		return &rpc.GetAccountInfoResult{
+			Value: &rpc.Account{Data: rpc.DataBytesOrJSONFromBytes(data)},
+		}, nil
+	}
+	client.MockGetTokenAccountBalance = func(ctx context.Context, account solana.PublicKey, commitment rpc.CommitmentType) (*rpc.GetTokenAccountBalanceResult, error) {
+		amount := strconv.FormatUint(quoteBalance, 10)
+		if account.String() == pool.BaseVault {
+			amount = strconv.FormatUint(baseBalance, 10)
+		}
+		return &rpc.GetTokenAccountBalanceResult{Value: &rpc.UiTokenAmount{Amount: amount}}, nil
+	}
+}
+
+// newTestPool returns an AMM pool with every swap account set to a random valid key
+func newTestPool() RaydiumAmmPool {
+	key := func() string { return solana.NewWallet().PublicKey().String() }
+	return RaydiumAmmPool{
+		ID:               key(),
+		ProgramID:        DefaultAMMProgramID,
+		BaseMint:         key(),
+		QuoteMint:        WSOLMint,
+		BaseVault:        key(),
+		QuoteVault:       key(),
+		Authority:        key(),
+		OpenOrders:       key(),
+		TargetOrders:     key(),
+		MarketProgramID:  key(),
+		MarketID:         key(),
+		MarketAuthority:  key(),
+		MarketBaseVault:  key(),
+		MarketQuoteVault: key(),
+		MarketBids:       key(),
+		MarketAsks:       key(),
+		MarketEventQueue: key(),
+	}
 }
@@ -18,6 +18,39 @@ type RaydiumAmmPool struct {
 	BaseDecimals  uint8  `json:"baseDecimals"`  // Changed from int to uint8
 	QuoteDecimals uint8  `json:"quoteDecimals"` // Changed from int to uint8
 	LpDecimals    uint8  `json:"lpDecimals"`    // Changed from int to uint8
+
+	// OpenBook (Serum) market accounts required by the swap instructions
+	MarketVersion      int    `json:"marketVersion"`
+	MarketProgramID    string `json:"marketProgramId"`    // OpenBook program
+	MarketID           string `json:"marketId"`           // Market account
+	MarketAuthority    string `json:"marketAuthority"`    // Market vault signer
+	MarketBaseVault    string `json:"marketBaseVault"`    // Market coin vault
+	MarketQuoteVault   string `json:"marketQuoteVault"`   // Market pc vault
+	MarketBids         string `json:"marketBids"`         // Market bids
+	MarketAsks         string `json:"marketAsks"`         // Market asks
+	MarketEventQueue   string `json:"marketEventQueue"`   // Market event queue
+	LookupTableAccount string `json:"lookupTableAccount"` // Address lookup table, if any
+}
+
+// RaydiumPoolKeysResponse represents the Raydium v3 /pools/key/ids response for AMM pools
+type RaydiumPoolKeysResponse struct {
+	Success bool `json:"success"`
+	Data    []struct {
+		ID                 string `json:"id"`
+		ProgramID          string `json:"programId"`
+		Authority          string `json:"authority"`
+		OpenOrders         string `json:"openOrders"`
+		TargetOrders       string `json:"targetOrders"`
+		MarketProgramID    string `json:"marketProgramId"`
+		MarketID           string `json:"marketId"`
+		MarketAuthority    string `json:"marketAuthority"`
+		MarketBaseVault    string `json:"marketBaseVault"`
+		MarketQuoteVault   string `json:"marketQuoteVault"`
+		MarketBids         string `json:"marketBids"`
+		MarketAsks         string `json:"marketAsks"`
+		MarketEventQueue   string `json:"marketEventQueue"`
+		LookupTableAccount string `json:"lookupTableAccount"`
+	} `json:"data"`
 }
 
 // RaydiumAPIResponse represents the structure of the Raydium v3 API response
@@ -5,24 +5,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+
+	"corvus_bot/pkg/utils"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 )
 
-// Constants for expected data length
-const expectedAccountDataLength = 500 // Replace with the actual expected size of the account data
-
 // FetchClmmPoolFromJSONOrNetwork fetches a CLMM pool from a JSON file or directly via the Solana RPC network.
 func FetchClmmPoolFromJSONOrNetwork(
 	ctx context.Context,
-	client *rpc.Client,
+	client utils.RPCClientInterface,
 	tokenAddress, programID, filePath string,
 ) (*RaydiumClmmPool, error) {
 	// Try to fetch the pool from the JSON file
 	pool, err := FetchClmmPoolFromJSON(tokenAddress, filePath)
 	if err == nil {
 		fmt.Println("CLMM pool data retrieved from JSON file.")
+		// Entries cached before the program ID was stored still need it to derive tick arrays
+		if pool.ProgramID == "" {
+			pool.ProgramID = programID
+		}
 		return pool, nil
 	}
 
@@ -66,67 +70,143 @@ func FetchClmmPoolFromJSON(tokenAddress, filePath string) (*RaydiumClmmPool, err
 }
 
 // FetchClmmPoolByID fetches a CLMM pool dynamically from the Solana RPC network.
-func FetchClmmPoolByID(ctx context.Context, client *rpc.Client, tokenAddress, programID string) (*RaydiumClmmPool, error) {
-	accountInfo, err := client.GetAccountInfo(ctx, solana.MustPublicKeyFromBase58(tokenAddress))
+// The PoolState account is decoded and its AmmConfig and mint owner programs are resolved.
+func FetchClmmPoolByID(ctx context.Context, client utils.RPCClientInterface, poolID, programID string) (*RaydiumClmmPool, error) {
+	accountInfo, err := fetchAccount(ctx, client, poolID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch account info: %w", err)
+		return nil, err
 	}
-
-	// Extract the binary data from accountInfo.Value.Data.Content
-	if accountInfo.Value == nil || accountInfo.Value.Data == nil || len(accountInfo.Value.Data.GetBinary()) == 0 {
-		return nil, fmt.Errorf("no data found in the account info")
+	if accountInfo.Owner.String() != programID {
+		return nil, fmt.Errorf("account %s is owned by %s, not by CLMM program %s", poolID, accountInfo.Owner, programID)
 	}
-	rawData := accountInfo.Value.Data.GetBinary()
 
 	// Parse the account data to extract pool details
-	pool, err := parseClmmAccountData(rawData, programID)
+	pool, err := parseClmmAccountData(accountInfo.Data.GetBinary(), poolID, programID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse CLMM account data: %w", err)
 	}
 
+	ammConfig, err := FetchAmmConfig(ctx, client, pool.AmmConfig.ID)
+	if err != nil {
+		return nil, err
+	}
+	pool.AmmConfig = *ammConfig
+
+	// Token-2022 mints are owned by a different program, which swaps must pass along
+	if pool.MintProgramIDA, err = fetchAccountOwner(ctx, client, pool.MintA); err != nil {
+		return nil, err
+	}
+	if pool.MintProgramIDB, err = fetchAccountOwner(ctx, client, pool.MintB); err != nil {
+		return nil, err
+	}
+	for i := range pool.RewardInfos {
+		if pool.RewardInfos[i].ProgramID, err = fetchAccountOwner(ctx, client, pool.RewardInfos[i].Mint); err != nil {
+			return nil, err
+		}
+	}
+
 	return pool, nil
 }
 
-// parseClmmAccountData parses the raw account data to extract the pool details.
-func parseClmmAccountData(accountData []byte, programID string) (*RaydiumClmmPool, error) {
-	if len(accountData) < expectedAccountDataLength {
-		return nil, fmt.Errorf("account data too short, expected at least %d bytes", expectedAccountDataLength)
+// FetchAmmConfig fetches and decodes the AmmConfig account holding the fee rates and tick spacing of a pool.
+func FetchAmmConfig(ctx context.Context, client utils.RPCClientInterface, configID string) (*ApiClmmConfigurationItem, error) {
+	accountInfo, err := fetchAccount(ctx, client, configID)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := DecodeAmmConfig(accountInfo.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse AmmConfig %s: %w", configID, err)
+	}
+
+	return &ApiClmmConfigurationItem{
+		ID:              configID,
+		Index:           int(config.Index),
+		ProtocolFeeRate: int(config.ProtocolFeeRate),
+		TradeFeeRate:    int(config.TradeFeeRate),
+		TickSpacing:     int(config.TickSpacing),
+		FundFeeRate:     int(config.FundFeeRate),
+		FundOwner:       config.FundOwner.String(),
+	}, nil
+}
+
+// parseClmmAccountData parses the raw PoolState account data to extract the pool details.
+// Only the AmmConfig ID is set; fee rates, tick spacing and mint programs are resolved by FetchClmmPoolByID.
+func parseClmmAccountData(accountData []byte, poolID, programID string) (*RaydiumClmmPool, error) {
+	state, err := DecodePoolState(accountData)
+	if err != nil {
+		return nil, err
 	}
 
-	// Simulate parsed pool (replace with real decoding logic)
 	pool := &RaydiumClmmPool{
-		ID:             programID,
-		MintProgramIDA: "RealMintProgramA", // Replace with parsed data
-		MintProgramIDB: "RealMintProgramB",
-		MintA:          "RealMintA",
-		MintB:          "RealMintB",
-		VaultA:         "RealVaultA",
-		VaultB:         "RealVaultB",
-		MintDecimalsA:  6,
-		MintDecimalsB:  6,
+		ID:            poolID,
+		ProgramID:     programID,
+		MintA:         state.TokenMint0.String(),
+		MintB:         state.TokenMint1.String(),
+		VaultA:        state.TokenVault0.String(),
+		VaultB:        state.TokenVault1.String(),
+		MintDecimalsA: int(state.MintDecimals0),
+		MintDecimalsB: int(state.MintDecimals1),
 		AmmConfig: ApiClmmConfigurationItem{
-			ID:              "AmmConfigID",
-			Index:           1,
-			ProtocolFeeRate: 0,
-			TradeFeeRate:    25,
-			TickSpacing:     64,
-			FundFeeRate:     5,
-			FundOwner:       "FundOwnerAccount",
-		},
-		RewardInfos: []RewardInfo{
-			{ID: 1, Mint: "RewardMint1", ProgramID: "RewardProgramID1"},
-			{ID: 2, Mint: "RewardMint2", ProgramID: "RewardProgramID2"},
+			ID:          state.AmmConfig.String(),
+			TickSpacing: int(state.TickSpacing),
 		},
-		TVL:                123456,
-		Day:                &ApiClmmPoolsItemStatistic{Volume: 100000, FeeApr: 15},
-		LookupTableAccount: "LookupTableAccount",
+		ObservationID:       state.ObservationKey.String(),
+		SqrtPriceX64:        state.SqrtPriceX64,
+		TickCurrent:         state.TickCurrent,
+		Liquidity:           state.Liquidity,
+		FeeGrowthGlobal0X64: state.FeeGrowthGlobal0X64,
+		FeeGrowthGlobal1X64: state.FeeGrowthGlobal1X64,
+		TickArrayBitmap:     state.TickArrayBitmap,
+		Status:              state.Status,
+		OpenTime:            state.OpenTime,
+	}
+
+	for i, reward := range state.RewardInfos {
+		// Unused reward slots are left zeroed by the program
+		if reward.TokenMint.IsZero() {
+			continue
+		}
+		pool.RewardInfos = append(pool.RewardInfos, RewardInfo{
+			ID:                    i,
+			Mint:                  reward.TokenMint.String(),
+			Vault:                 reward.TokenVault.String(),
+			RewardState:           reward.RewardState,
+			OpenTime:              reward.OpenTime,
+			EndTime:               reward.EndTime,
+			LastUpdateTime:        reward.LastUpdateTime,
+			EmissionsPerSecondX64: reward.EmissionsPerSecondX64,
+			RewardGrowthGlobalX64: reward.RewardGrowthGlobalX64,
+		})
 	}
 
 	return pool, nil
 }
 
-// appendToJSON appends a new pool to an existing JSON file.
+// fetchAccount fetches the account at a base58 address, see utils.FetchAccount
+func fetchAccount(ctx context.Context, client utils.RPCClientInterface, address string) (*rpc.Account, error) {
+	pubkey, err := solana.PublicKeyFromBase58(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account address %s: %w", address, err)
+	}
+	return utils.FetchAccount(ctx, client, pubkey)
+}
+
+// fetchAccountOwner returns the program owning an account, e.g. the token program of a mint
+func fetchAccountOwner(ctx context.Context, client utils.RPCClientInterface, address string) (string, error) {
+	account, err := fetchAccount(ctx, client, address)
+	if err != nil {
+		return "", err
+	}
+	return account.Owner.String(), nil
+}
+
+// appendToJSON appends a new pool to a JSON file, creating the file and its directory on first use.
 func appendToJSON(filePath string, pool *RaydiumClmmPool) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
 	file, err := os.OpenFile(filePath, os.O_RDWR|os.O_CREATE, 0755)
 	if err != nil {
 		return fmt.Errorf("failed to open JSON file: %w", err)
@@ -0,0 +1,22 @@
+package clmm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendToJSONCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "devnet", "clmm_pools.json")
+
+	_, err := FetchClmmPoolFromJSON("pool", path)
+	assert.Error(t, err, "nothing is cached yet")
+
+	require.NoError(t, appendToJSON(path, &RaydiumClmmPool{ID: "pool"}))
+	require.NoError(t, appendToJSON(path, &RaydiumClmmPool{ID: "other"}))
+	pool, err := FetchClmmPoolFromJSON("pool", path)
+	require.NoError(t, err)
+	assert.Equal(t, "pool", pool.ID)
+}
@@ -0,0 +1,516 @@
+package clmm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+var (
+	// OpenPositionV2Discriminator is the Anchor discriminator of the openPositionV2 instruction
+	OpenPositionV2Discriminator = instructionDiscriminator("open_position_v2")
+
+	// IncreaseLiquidityV2Discriminator is the Anchor discriminator of the increaseLiquidityV2 instruction
+	IncreaseLiquidityV2Discriminator = instructionDiscriminator("increase_liquidity_v2")
+
+	// DecreaseLiquidityV2Discriminator is the Anchor discriminator of the decreaseLiquidityV2 instruction
+	DecreaseLiquidityV2Discriminator = instructionDiscriminator("decrease_liquidity_v2")
+
+	// ClosePositionDiscriminator is the Anchor discriminator of the closePosition instruction
+	ClosePositionDiscriminator = instructionDiscriminator("close_position")
+)
+
+// OpenPositionParams holds the openPositionV2 arguments
+type OpenPositionParams struct {
+	TickLower    int32
+	TickUpper    int32
+	Liquidity    *big.Int // Ignored by the program when BaseFlag is set
+	Amount0Max   uint64
+	Amount1Max   uint64
+	WithMetadata bool  // Create Metaplex metadata for the position NFT
+	BaseFlag     *bool // When set, liquidity is derived from Amount0Max (true) or Amount1Max (false)
+}
+
+// LiquidityParams holds the increaseLiquidityV2 and decreaseLiquidityV2 arguments.
+// Amounts are maximums when increasing and minimums when decreasing.
+type LiquidityParams struct {
+	Liquidity *big.Int
+	Amount0   uint64
+	Amount1   uint64
+	BaseFlag  *bool // Increase only: derive liquidity from Amount0 (true) or Amount1 (false)
+}
+
+type openPositionV2Args struct {
+	TickLowerIndex           int32
+	TickUpperIndex           int32
+	TickArrayLowerStartIndex int32
+	TickArrayUpperStartIndex int32
+	Liquidity                bin.Uint128
+	Amount0Max               uint64
+	Amount1Max               uint64
+	WithMetadata             bool
+	BaseFlag                 *bool `bin:"optional"`
+}
+
+type increaseLiquidityV2Args struct {
+	Liquidity  bin.Uint128
+	Amount0Max uint64
+	Amount1Max uint64
+	BaseFlag   *bool `bin:"optional"`
+}
+
+type decreaseLiquidityV2Args struct {
+	Liquidity  bin.Uint128
+	Amount0Min uint64
+	Amount1Min uint64
+}
+
+// OpenPosition mints a new position NFT to the wallet and deposits liquidity between the params ticks.
+// It returns the transaction signature and the position NFT mint.
+func OpenPosition(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool *RaydiumClmmPool,
+	params OpenPositionParams,
+) (solana.Signature, solana.PublicKey, error) {
+	if pool == nil {
+		return solana.Signature{}, solana.PublicKey{}, fmt.Errorf("pool data is nil")
+	}
+
+	nftMint := solana.NewWallet()
+	instruction, err := NewOpenPositionV2Instruction(*pool, wallet.PublicKey(), nftMint.PublicKey(), params)
+	if err != nil {
+		return solana.Signature{}, solana.PublicKey{}, fmt.Errorf("failed to build open position instruction: %w", err)
+	}
+
+	signature, err := sendTransaction(ctx, client, wallet, []solana.Instruction{instruction}, nftMint.PrivateKey)
+	if err != nil {
+		return solana.Signature{}, solana.PublicKey{}, err
+	}
+	return signature, nftMint.PublicKey(), nil
+}
+
+// IncreaseLiquidity deposits more liquidity into a position owned by the wallet
+func IncreaseLiquidity(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool *RaydiumClmmPool,
+	position *Position,
+	params LiquidityParams,
+) (solana.Signature, error) {
+	if pool == nil || position == nil {
+		return solana.Signature{}, fmt.Errorf("pool or position data is nil")
+	}
+
+	instruction, err := NewIncreaseLiquidityV2Instruction(*pool, wallet.PublicKey(), position, params)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build increase liquidity instruction: %w", err)
+	}
+	return sendTransaction(ctx, client, wallet, []solana.Instruction{instruction})
+}
+
+// DecreaseLiquidity withdraws liquidity from a position owned by the wallet, collecting its fees and rewards.
+// Decreasing by zero liquidity only collects.
+func DecreaseLiquidity(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool *RaydiumClmmPool,
+	position *Position,
+	params LiquidityParams,
+) (solana.Signature, error) {
+	if pool == nil || position == nil {
+		return solana.Signature{}, fmt.Errorf("pool or position data is nil")
+	}
+
+	instruction, err := NewDecreaseLiquidityV2Instruction(*pool, wallet.PublicKey(), position, params)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build decrease liquidity instruction: %w", err)
+	}
+	return sendTransaction(ctx, client, wallet, []solana.Instruction{instruction})
+}
+
+// ClosePosition burns the position NFT and closes the position. The position must hold no liquidity;
+// fees and rewards still owed are collected in the same transaction.
+func ClosePosition(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool *RaydiumClmmPool,
+	position *Position,
+) (solana.Signature, error) {
+	if pool == nil || position == nil {
+		return solana.Signature{}, fmt.Errorf("pool or position data is nil")
+	}
+	if position.State.Liquidity.BigInt().Sign() != 0 {
+		return solana.Signature{}, fmt.Errorf("position %s still holds liquidity, decrease it to zero first", position.Address)
+	}
+
+	var instructions []solana.Instruction
+	if position.hasUncollected() {
+		collect, err := NewDecreaseLiquidityV2Instruction(*pool, wallet.PublicKey(), position, LiquidityParams{})
+		if err != nil {
+			return solana.Signature{}, fmt.Errorf("failed to build collect instruction: %w", err)
+		}
+		instructions = append(instructions, collect)
+	}
+
+	closeInstruction, err := NewClosePositionInstruction(*pool, wallet.PublicKey(), position)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build close position instruction: %w", err)
+	}
+	instructions = append(instructions, closeInstruction)
+
+	return sendTransaction(ctx, client, wallet, instructions)
+}
+
+// NewOpenPositionV2Instruction builds the CLMM openPositionV2 instruction as defined in the Raydium CLMM IDL.
+// The position NFT is minted with the token program to the owner, who deposits from its associated token accounts.
+func NewOpenPositionV2Instruction(
+	pool RaydiumClmmPool,
+	owner, nftMint solana.PublicKey,
+	params OpenPositionParams,
+) (solana.Instruction, error) {
+	keys, err := newPositionKeys(pool, owner, params.TickLower, params.TickUpper)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := toLiquidityArg(params.Liquidity)
+	if err != nil {
+		return nil, err
+	}
+
+	personalPosition, err := FindPersonalPositionAddress(keys.programID, nftMint)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := FindMetadataAddress(nftMint)
+	if err != nil {
+		return nil, err
+	}
+	nftAccount, err := associatedTokenAddress(owner, nftMint.String(), solana.TokenProgramID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(owner, true, true),                                       // payer
+		solana.NewAccountMeta(owner, false, false),                                     // positionNftOwner
+		solana.NewAccountMeta(nftMint, true, true),                                     // positionNftMint
+		solana.NewAccountMeta(nftAccount, true, false),                                 // positionNftAccount
+		solana.NewAccountMeta(metadata, true, false),                                   // metadataAccount
+		solana.NewAccountMeta(keys.poolID, true, false),                                // poolState
+		solana.NewAccountMeta(keys.protocolPosition, true, false),                      // protocolPosition
+		solana.NewAccountMeta(keys.tickArrayLower, true, false),                        // tickArrayLower
+		solana.NewAccountMeta(keys.tickArrayUpper, true, false),                        // tickArrayUpper
+		solana.NewAccountMeta(personalPosition, true, false),                           // personalPosition
+		solana.NewAccountMeta(keys.tokenAccount0, true, false),                         // tokenAccount0
+		solana.NewAccountMeta(keys.tokenAccount1, true, false),                         // tokenAccount1
+		solana.NewAccountMeta(keys.vault0, true, false),                                // tokenVault0
+		solana.NewAccountMeta(keys.vault1, true, false),                                // tokenVault1
+		solana.NewAccountMeta(solana.SysVarRentPubkey, false, false),                   // rent
+		solana.NewAccountMeta(solana.SystemProgramID, false, false),                    // systemProgram
+		solana.NewAccountMeta(solana.TokenProgramID, false, false),                     // tokenProgram
+		solana.NewAccountMeta(solana.SPLAssociatedTokenAccountProgramID, false, false), // associatedTokenProgram
+		solana.NewAccountMeta(MetadataProgramID, false, false),                         // metadataProgram
+		solana.NewAccountMeta(solana.Token2022ProgramID, false, false),                 // tokenProgram2022
+		solana.NewAccountMeta(keys.mint0, false, false),                                // vault0Mint
+		solana.NewAccountMeta(keys.mint1, false, false),                                // vault1Mint
+	}
+	accounts = keys.appendBitmapExtension(accounts)
+
+	data, err := encodeInstructionData(OpenPositionV2Discriminator, openPositionV2Args{
+		TickLowerIndex:           params.TickLower,
+		TickUpperIndex:           params.TickUpper,
+		TickArrayLowerStartIndex: keys.tickArrayLowerStart,
+		TickArrayUpperStartIndex: keys.tickArrayUpperStart,
+		Liquidity:                liquidity,
+		Amount0Max:               params.Amount0Max,
+		Amount1Max:               params.Amount1Max,
+		WithMetadata:             params.WithMetadata,
+		BaseFlag:                 params.BaseFlag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.NewInstruction(keys.programID, accounts, data), nil
+}
+
+// NewIncreaseLiquidityV2Instruction builds the CLMM increaseLiquidityV2 instruction as defined in the Raydium CLMM IDL
+func NewIncreaseLiquidityV2Instruction(
+	pool RaydiumClmmPool,
+	owner solana.PublicKey,
+	position *Position,
+	params LiquidityParams,
+) (solana.Instruction, error) {
+	keys, err := newPositionKeys(pool, owner, position.State.TickLowerIndex, position.State.TickUpperIndex)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := toLiquidityArg(params.Liquidity)
+	if err != nil {
+		return nil, err
+	}
+	nftAccount, err := position.nftAccount(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(owner, false, true),                      // nftOwner
+		solana.NewAccountMeta(nftAccount, false, false),                // nftAccount
+		solana.NewAccountMeta(keys.poolID, true, false),                // poolState
+		solana.NewAccountMeta(keys.protocolPosition, true, false),      // protocolPosition
+		solana.NewAccountMeta(position.Address, true, false),           // personalPosition
+		solana.NewAccountMeta(keys.tickArrayLower, true, false),        // tickArrayLower
+		solana.NewAccountMeta(keys.tickArrayUpper, true, false),        // tickArrayUpper
+		solana.NewAccountMeta(keys.tokenAccount0, true, false),         // tokenAccount0
+		solana.NewAccountMeta(keys.tokenAccount1, true, false),         // tokenAccount1
+		solana.NewAccountMeta(keys.vault0, true, false),                // tokenVault0
+		solana.NewAccountMeta(keys.vault1, true, false),                // tokenVault1
+		solana.NewAccountMeta(solana.TokenProgramID, false, false),     // tokenProgram
+		solana.NewAccountMeta(solana.Token2022ProgramID, false, false), // tokenProgram2022
+		solana.NewAccountMeta(keys.mint0, false, false),                // vault0Mint
+		solana.NewAccountMeta(keys.mint1, false, false),                // vault1Mint
+	}
+	accounts = keys.appendBitmapExtension(accounts)
+
+	data, err := encodeInstructionData(IncreaseLiquidityV2Discriminator, increaseLiquidityV2Args{
+		Liquidity:  liquidity,
+		Amount0Max: params.Amount0,
+		Amount1Max: params.Amount1,
+		BaseFlag:   params.BaseFlag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.NewInstruction(keys.programID, accounts, data), nil
+}
+
+// NewDecreaseLiquidityV2Instruction builds the CLMM decreaseLiquidityV2 instruction as defined in the Raydium CLMM IDL.
+// Each pool reward is collected into the owner's associated token account, passed as remaining accounts.
+func NewDecreaseLiquidityV2Instruction(
+	pool RaydiumClmmPool,
+	owner solana.PublicKey,
+	position *Position,
+	params LiquidityParams,
+) (solana.Instruction, error) {
+	keys, err := newPositionKeys(pool, owner, position.State.TickLowerIndex, position.State.TickUpperIndex)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := toLiquidityArg(params.Liquidity)
+	if err != nil {
+		return nil, err
+	}
+	nftAccount, err := position.nftAccount(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(owner, false, true),                      // nftOwner
+		solana.NewAccountMeta(nftAccount, false, false),                // nftAccount
+		solana.NewAccountMeta(position.Address, true, false),           // personalPosition
+		solana.NewAccountMeta(keys.poolID, true, false),                // poolState
+		solana.NewAccountMeta(keys.protocolPosition, true, false),      // protocolPosition
+		solana.NewAccountMeta(keys.vault0, true, false),                // tokenVault0
+		solana.NewAccountMeta(keys.vault1, true, false),                // tokenVault1
+		solana.NewAccountMeta(keys.tickArrayLower, true, false),        // tickArrayLower
+		solana.NewAccountMeta(keys.tickArrayUpper, true, false),        // tickArrayUpper
+		solana.NewAccountMeta(keys.tokenAccount0, true, false),         // recipientTokenAccount0
+		solana.NewAccountMeta(keys.tokenAccount1, true, false),         // recipientTokenAccount1
+		solana.NewAccountMeta(solana.TokenProgramID, false, false),     // tokenProgram
+		solana.NewAccountMeta(solana.Token2022ProgramID, false, false), // tokenProgram2022
+		solana.NewAccountMeta(solana.MemoProgramID, false, false),      // memoProgram
+		solana.NewAccountMeta(keys.mint0, false, false),                // vault0Mint
+		solana.NewAccountMeta(keys.mint1, false, false),                // vault1Mint
+	}
+	accounts = keys.appendBitmapExtension(accounts)
+
+	// The program expects a (reward vault, recipient, reward mint) triple for every initialized reward
+	for _, reward := range pool.RewardInfos {
+		vault, err := solana.PublicKeyFromBase58(reward.Vault)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vault of reward %s: %w", reward.Mint, err)
+		}
+		mint, err := solana.PublicKeyFromBase58(reward.Mint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reward mint %s: %w", reward.Mint, err)
+		}
+		recipient, err := associatedTokenAddress(owner, reward.Mint, reward.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts,
+			solana.NewAccountMeta(vault, true, false),
+			solana.NewAccountMeta(recipient, true, false),
+			solana.NewAccountMeta(mint, false, false),
+		)
+	}
+
+	data, err := encodeInstructionData(DecreaseLiquidityV2Discriminator, decreaseLiquidityV2Args{
+		Liquidity:  liquidity,
+		Amount0Min: params.Amount0,
+		Amount1Min: params.Amount1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.NewInstruction(keys.programID, accounts, data), nil
+}
+
+// NewClosePositionInstruction builds the CLMM closePosition instruction as defined in the Raydium CLMM IDL
+func NewClosePositionInstruction(pool RaydiumClmmPool, owner solana.PublicKey, position *Position) (solana.Instruction, error) {
+	programID, err := solana.PublicKeyFromBase58(pool.ProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ProgramID public key: %w", err)
+	}
+	nftAccount, err := position.nftAccount(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(owner, true, true),                        // nftOwner
+		solana.NewAccountMeta(position.State.NftMint, true, false),      // positionNftMint
+		solana.NewAccountMeta(nftAccount, true, false),                  // positionNftAccount
+		solana.NewAccountMeta(position.Address, true, false),            // personalPosition
+		solana.NewAccountMeta(solana.SystemProgramID, false, false),     // systemProgram
+		solana.NewAccountMeta(position.nftTokenProgram(), false, false), // tokenProgram
+	}
+
+	return solana.NewInstruction(programID, accounts, ClosePositionDiscriminator[:]), nil
+}
+
+// positionKeys holds the pool and user accounts shared by the position instructions
+type positionKeys struct {
+	programID, poolID            solana.PublicKey
+	vault0, vault1, mint0, mint1 solana.PublicKey
+	tokenAccount0, tokenAccount1 solana.PublicKey
+	protocolPosition             solana.PublicKey
+	tickArrayLower               solana.PublicKey
+	tickArrayUpper               solana.PublicKey
+	tickArrayLowerStart          int32
+	tickArrayUpperStart          int32
+	bitmapExtension              solana.PublicKey // Zero unless a tick array lies outside the PoolState bitmap
+}
+
+func newPositionKeys(pool RaydiumClmmPool, owner solana.PublicKey, tickLower, tickUpper int32) (*positionKeys, error) {
+	tickSpacing := uint16(pool.AmmConfig.TickSpacing)
+	if err := checkTickRange(tickLower, tickUpper, tickSpacing); err != nil {
+		return nil, err
+	}
+
+	keys := &positionKeys{
+		tickArrayLowerStart: GetTickArrayStartIndex(tickLower, tickSpacing),
+		tickArrayUpperStart: GetTickArrayStartIndex(tickUpper, tickSpacing),
+	}
+	for _, key := range []struct {
+		name  string
+		value string
+		dest  *solana.PublicKey
+	}{
+		{"ProgramID", pool.ProgramID, &keys.programID},
+		{"ID", pool.ID, &keys.poolID},
+		{"VaultA", pool.VaultA, &keys.vault0},
+		{"VaultB", pool.VaultB, &keys.vault1},
+		{"MintA", pool.MintA, &keys.mint0},
+		{"MintB", pool.MintB, &keys.mint1},
+	} {
+		pubKey, err := solana.PublicKeyFromBase58(key.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s public key: %w", key.name, err)
+		}
+		*key.dest = pubKey
+	}
+
+	var err error
+	if keys.tokenAccount0, keys.tokenAccount1, err = userTokenAccounts(pool, owner, true); err != nil {
+		return nil, err
+	}
+	if keys.protocolPosition, err = FindProtocolPositionAddress(keys.programID, keys.poolID, tickLower, tickUpper); err != nil {
+		return nil, err
+	}
+	if keys.tickArrayLower, err = FindTickArrayAddress(keys.programID, keys.poolID, keys.tickArrayLowerStart); err != nil {
+		return nil, err
+	}
+	if keys.tickArrayUpper, err = FindTickArrayAddress(keys.programID, keys.poolID, keys.tickArrayUpperStart); err != nil {
+		return nil, err
+	}
+
+	if isOverflowDefaultTickArrayBitmap(tickLower, tickSpacing) || isOverflowDefaultTickArrayBitmap(tickUpper, tickSpacing) {
+		if keys.bitmapExtension, err = FindTickArrayBitmapExtensionAddress(keys.programID, keys.poolID); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// appendBitmapExtension adds the bitmap extension as the first remaining account when the position needs it
+func (k *positionKeys) appendBitmapExtension(accounts solana.AccountMetaSlice) solana.AccountMetaSlice {
+	if k.bitmapExtension.IsZero() {
+		return accounts
+	}
+	return append(accounts, solana.NewAccountMeta(k.bitmapExtension, true, false))
+}
+
+// nftAccount derives the owner's associated token account holding the position NFT
+func (p *Position) nftAccount(owner solana.PublicKey) (solana.PublicKey, error) {
+	return associatedTokenAddress(owner, p.State.NftMint.String(), p.nftTokenProgram().String())
+}
+
+func (p *Position) nftTokenProgram() solana.PublicKey {
+	if p.NftTokenProgram.IsZero() {
+		return solana.TokenProgramID
+	}
+	return p.NftTokenProgram
+}
+
+// hasUncollected reports whether the position still has fees or rewards to collect
+func (p *Position) hasUncollected() bool {
+	if p.FeesOwed0 != 0 || p.FeesOwed1 != 0 {
+		return true
+	}
+	for _, reward := range p.RewardsOwed {
+		if reward != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func toLiquidityArg(liquidity *big.Int) (bin.Uint128, error) {
+	if liquidity == nil {
+		return bin.Uint128{}, nil
+	}
+	if liquidity.Sign() < 0 || liquidity.Cmp(maxU128) > 0 {
+		return bin.Uint128{}, fmt.Errorf("liquidity %s does not fit in u128", liquidity)
+	}
+	return bin.Uint128{
+		Lo: new(big.Int).And(liquidity, maxU64).Uint64(),
+		Hi: new(big.Int).Rsh(liquidity, 64).Uint64(),
+	}, nil
+}
+
+// encodeInstructionData Borsh-encodes the instruction arguments after the discriminator
+func encodeInstructionData(discriminator [DiscriminatorSize]byte, args interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.Write(discriminator[:])
+	if err := bin.NewBorshEncoder(buf).Encode(args); err != nil {
+		return nil, fmt.Errorf("failed to encode instruction data: %w", err)
+	}
+	return buf.Bytes(), nil
+}
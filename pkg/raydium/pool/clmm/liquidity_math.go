@@ -0,0 +1,208 @@
+package clmm
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// PriceToSqrtPriceX64 converts a UI price, in token 1 per token 0, into the Q64.64 square root price
+// of raw token amounts
+func PriceToSqrtPriceX64(price float64, decimals0, decimals1 int) (*big.Int, error) {
+	if price <= 0 || math.IsInf(price, 0) || math.IsNaN(price) {
+		return nil, fmt.Errorf("invalid price %f", price)
+	}
+
+	rawPrice := new(big.Float).SetPrec(256).SetFloat64(price)
+	scale := new(big.Float).SetPrec(256).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(decimals1-decimals0))), nil))
+	if decimals1 >= decimals0 {
+		rawPrice.Mul(rawPrice, scale)
+	} else {
+		rawPrice.Quo(rawPrice, scale)
+	}
+
+	sqrtPrice := new(big.Float).SetPrec(256).Sqrt(rawPrice)
+	sqrtPrice.Mul(sqrtPrice, new(big.Float).SetInt(q64))
+	sqrtPriceX64, _ := sqrtPrice.Int(nil)
+	return sqrtPriceX64, nil
+}
+
+// SqrtPriceX64ToPrice converts a Q64.64 square root price into a UI price in token 1 per token 0
+func SqrtPriceX64ToPrice(sqrtPriceX64 *big.Int, decimals0, decimals1 int) float64 {
+	sqrtPrice, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX64), new(big.Float).SetInt(q64)).Float64()
+	return sqrtPrice * sqrtPrice * math.Pow10(decimals0-decimals1)
+}
+
+// PriceToTick returns the tick holding a UI price, in token 1 per token 0
+func PriceToTick(price float64, decimals0, decimals1 int) (int32, error) {
+	sqrtPriceX64, err := PriceToSqrtPriceX64(price, decimals0, decimals1)
+	if err != nil {
+		return 0, err
+	}
+	if sqrtPriceX64.Cmp(MinSqrtPriceX64) < 0 {
+		return MinTick, nil
+	}
+	if sqrtPriceX64.Cmp(MaxSqrtPriceX64) >= 0 {
+		return MaxTick, nil
+	}
+	return GetTickAtSqrtPrice(sqrtPriceX64)
+}
+
+// TickToPrice returns the UI price, in token 1 per token 0, at a tick
+func TickToPrice(tick int32, decimals0, decimals1 int) (float64, error) {
+	sqrtPriceX64, err := GetSqrtPriceAtTick(tick)
+	if err != nil {
+		return 0, err
+	}
+	return SqrtPriceX64ToPrice(sqrtPriceX64, decimals0, decimals1), nil
+}
+
+// AlignTick rounds tick to a multiple of tickSpacing, up or down, staying within the usable tick range
+func AlignTick(tick int32, tickSpacing uint16, roundUp bool) int32 {
+	spacing := int32(tickSpacing)
+	aligned := tick / spacing * spacing
+	if aligned > tick {
+		aligned -= spacing
+	}
+	if roundUp && aligned < tick {
+		aligned += spacing
+	}
+
+	minTick, maxTick := usableTickRange(tickSpacing)
+	if aligned < minTick {
+		return minTick
+	}
+	if aligned > maxTick {
+		return maxTick
+	}
+	return aligned
+}
+
+// PriceRangeToTicks converts a UI price range, in token 1 per token 0, into position ticks aligned to tickSpacing.
+// The range is widened outwards so it always covers the requested prices.
+func PriceRangeToTicks(priceLower, priceUpper float64, decimals0, decimals1 int, tickSpacing uint16) (int32, int32, error) {
+	if tickSpacing == 0 {
+		return 0, 0, fmt.Errorf("tick spacing is zero")
+	}
+	if priceLower >= priceUpper {
+		return 0, 0, fmt.Errorf("lower price %f is not below upper price %f", priceLower, priceUpper)
+	}
+
+	tickLower, err := PriceToTick(priceLower, decimals0, decimals1)
+	if err != nil {
+		return 0, 0, err
+	}
+	tickUpper, err := PriceToTick(priceUpper, decimals0, decimals1)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tickLower = AlignTick(tickLower, tickSpacing, false)
+	tickUpper = AlignTick(tickUpper, tickSpacing, true)
+	if tickLower == tickUpper {
+		// Both prices fall inside the same spacing interval
+		tickUpper += int32(tickSpacing)
+	}
+	if err := checkTickRange(tickLower, tickUpper, tickSpacing); err != nil {
+		return 0, 0, err
+	}
+	return tickLower, tickUpper, nil
+}
+
+// GetAmountsForLiquidity returns the token amounts backing liquidity between tickLower and tickUpper
+// for a pool at tickCurrent and sqrtPriceX64, following the program's add_liquidity rules.
+// Amounts are rounded up when depositing and down when withdrawing.
+func GetAmountsForLiquidity(tickCurrent int32, sqrtPriceX64 *big.Int, tickLower, tickUpper int32, liquidity *big.Int, roundUp bool) (*big.Int, *big.Int, error) {
+	sqrtPriceLowerX64, err := GetSqrtPriceAtTick(tickLower)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqrtPriceUpperX64, err := GetSqrtPriceAtTick(tickUpper)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case tickCurrent < tickLower:
+		// The range is above the price and only holds token 0
+		return GetAmount0Delta(sqrtPriceLowerX64, sqrtPriceUpperX64, liquidity, roundUp), new(big.Int), nil
+	case tickCurrent < tickUpper:
+		amount0 := GetAmount0Delta(sqrtPriceX64, sqrtPriceUpperX64, liquidity, roundUp)
+		amount1 := GetAmount1Delta(sqrtPriceLowerX64, sqrtPriceX64, liquidity, roundUp)
+		return amount0, amount1, nil
+	default:
+		// The range is below the price and only holds token 1
+		return new(big.Int), GetAmount1Delta(sqrtPriceLowerX64, sqrtPriceUpperX64, liquidity, roundUp), nil
+	}
+}
+
+// GetLiquidityForAmounts returns the largest liquidity between tickLower and tickUpper that amount0 and amount1
+// can fund at sqrtPriceX64
+func GetLiquidityForAmounts(sqrtPriceX64 *big.Int, tickLower, tickUpper int32, amount0, amount1 uint64) (*big.Int, error) {
+	sqrtPriceLowerX64, err := GetSqrtPriceAtTick(tickLower)
+	if err != nil {
+		return nil, err
+	}
+	sqrtPriceUpperX64, err := GetSqrtPriceAtTick(tickUpper)
+	if err != nil {
+		return nil, err
+	}
+
+	a0, a1 := new(big.Int).SetUint64(amount0), new(big.Int).SetUint64(amount1)
+	switch {
+	case sqrtPriceX64.Cmp(sqrtPriceLowerX64) <= 0:
+		return getLiquidityFromAmount0(sqrtPriceLowerX64, sqrtPriceUpperX64, a0), nil
+	case sqrtPriceX64.Cmp(sqrtPriceUpperX64) < 0:
+		liquidity0 := getLiquidityFromAmount0(sqrtPriceX64, sqrtPriceUpperX64, a0)
+		liquidity1 := getLiquidityFromAmount1(sqrtPriceLowerX64, sqrtPriceX64, a1)
+		if liquidity0.Cmp(liquidity1) < 0 {
+			return liquidity0, nil
+		}
+		return liquidity1, nil
+	default:
+		return getLiquidityFromAmount1(sqrtPriceLowerX64, sqrtPriceUpperX64, a1), nil
+	}
+}
+
+// getLiquidityFromAmount0 computes amount0 * (sqrtA * sqrtB) / (sqrtB - sqrtA), rounded down
+func getLiquidityFromAmount0(sqrtPriceAX64, sqrtPriceBX64, amount0 *big.Int) *big.Int {
+	sqrtPriceAX64, sqrtPriceBX64 = sortPrices(sqrtPriceAX64, sqrtPriceBX64)
+	intermediate := mulDivFloor(sqrtPriceAX64, sqrtPriceBX64, q64)
+	return mulDivFloor(amount0, intermediate, new(big.Int).Sub(sqrtPriceBX64, sqrtPriceAX64))
+}
+
+// getLiquidityFromAmount1 computes amount1 / (sqrtB - sqrtA), rounded down
+func getLiquidityFromAmount1(sqrtPriceAX64, sqrtPriceBX64, amount1 *big.Int) *big.Int {
+	sqrtPriceAX64, sqrtPriceBX64 = sortPrices(sqrtPriceAX64, sqrtPriceBX64)
+	return mulDivFloor(amount1, q64, new(big.Int).Sub(sqrtPriceBX64, sqrtPriceAX64))
+}
+
+// usableTickRange returns the lowest and highest ticks aligned to tickSpacing
+func usableTickRange(tickSpacing uint16) (int32, int32) {
+	spacing := int32(tickSpacing)
+	return -(-MinTick / spacing * spacing), MaxTick / spacing * spacing
+}
+
+// checkTickRange validates position ticks the way the program does before opening a position
+func checkTickRange(tickLower, tickUpper int32, tickSpacing uint16) error {
+	if tickSpacing == 0 {
+		return fmt.Errorf("tick spacing is zero")
+	}
+	if tickLower >= tickUpper {
+		return fmt.Errorf("lower tick %d is not below upper tick %d", tickLower, tickUpper)
+	}
+	if tickLower < MinTick || tickUpper > MaxTick {
+		return fmt.Errorf("ticks %d..%d are out of range", tickLower, tickUpper)
+	}
+	if tickLower%int32(tickSpacing) != 0 || tickUpper%int32(tickSpacing) != 0 {
+		return fmt.Errorf("ticks %d..%d are not multiples of tick spacing %d", tickLower, tickUpper, tickSpacing)
+	}
+	return nil
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+package clmm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceToTick(t *testing.T) {
+	// 150 USDC per SOL is 0.15 raw units of USDC (6 decimals) per raw unit of SOL (9 decimals)
+	tick, err := PriceToTick(150, 9, 6)
+	require.NoError(t, err)
+	assert.Equal(t, int32(-18973), tick)
+
+	lower, err := TickToPrice(tick, 9, 6)
+	require.NoError(t, err)
+	upper, err := TickToPrice(tick+1, 9, 6)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, lower, 150.0)
+	assert.Greater(t, upper, 150.0)
+
+	_, err = PriceToTick(0, 9, 6)
+	assert.Error(t, err)
+}
+
+func TestAlignTick(t *testing.T) {
+	assert.Equal(t, int32(-19008), AlignTick(-18973, 64, false))
+	assert.Equal(t, int32(-18944), AlignTick(-18973, 64, true))
+	assert.Equal(t, int32(128), AlignTick(128, 64, false))
+	assert.Equal(t, int32(128), AlignTick(128, 64, true))
+	assert.Equal(t, int32(-10), AlignTick(-5, 10, false))
+	assert.Equal(t, int32(0), AlignTick(-5, 10, true))
+
+	// Ticks beyond the usable range are clamped to the last aligned tick
+	assert.Equal(t, int32(-443580), AlignTick(MinTick, 60, false))
+	assert.Equal(t, int32(443580), AlignTick(MaxTick, 60, true))
+}
+
+func TestPriceRangeToTicks(t *testing.T) {
+	lower, upper, err := PriceRangeToTicks(120, 180, 9, 6, 64)
+	require.NoError(t, err)
+	assert.Zero(t, lower%64)
+	assert.Zero(t, upper%64)
+
+	// The aligned range covers the requested prices
+	lowerPrice, err := TickToPrice(lower, 9, 6)
+	require.NoError(t, err)
+	upperPrice, err := TickToPrice(upper, 9, 6)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, lowerPrice, 120.0)
+	assert.GreaterOrEqual(t, upperPrice, 180.0)
+
+	// Prices inside a single spacing interval still give a valid range
+	lower, upper, err = PriceRangeToTicks(150, 150.01, 9, 6, 64)
+	require.NoError(t, err)
+	assert.Equal(t, int32(64), upper-lower)
+
+	_, _, err = PriceRangeToTicks(180, 120, 9, 6, 64)
+	assert.Error(t, err)
+}
+
+func TestGetAmountsForLiquidity(t *testing.T) {
+	liquidity := big.NewInt(1_000_000_000)
+	sqrtPrice, err := GetSqrtPriceAtTick(0)
+	require.NoError(t, err)
+
+	// Range above the price holds only token 0, below only token 1
+	amount0, amount1, err := GetAmountsForLiquidity(0, sqrtPrice, 100, 200, liquidity, false)
+	require.NoError(t, err)
+	assert.Positive(t, amount0.Sign())
+	assert.Zero(t, amount1.Sign())
+
+	amount0, amount1, err = GetAmountsForLiquidity(0, sqrtPrice, -200, -100, liquidity, false)
+	require.NoError(t, err)
+	assert.Zero(t, amount0.Sign())
+	assert.Positive(t, amount1.Sign())
+
+	// A range centered on tick 0 holds equal amounts of both
+	amount0, amount1, err = GetAmountsForLiquidity(0, sqrtPrice, -100, 100, liquidity, false)
+	require.NoError(t, err)
+	assert.InDelta(t, amount0.Int64(), amount1.Int64(), 2)
+}
+
+func TestGetLiquidityForAmounts(t *testing.T) {
+	sqrtPrice, err := GetSqrtPriceAtTick(-18973)
+	require.NoError(t, err)
+
+	liquidity, err := GetLiquidityForAmounts(sqrtPrice, -19008, -18944, 1_000_000_000, 150_000_000)
+	require.NoError(t, err)
+	require.Positive(t, liquidity.Sign())
+
+	// Depositing that liquidity never needs more than the amounts it was computed from
+	amount0, amount1, err := GetAmountsForLiquidity(-18973, sqrtPrice, -19008, -18944, liquidity, true)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, amount0.Uint64(), uint64(1_000_000_000))
+	assert.LessOrEqual(t, amount1.Uint64(), uint64(150_000_000))
+}
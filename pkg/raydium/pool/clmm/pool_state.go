@@ -0,0 +1,145 @@
+package clmm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DiscriminatorSize is the length of the Anchor discriminator that prefixes every account
+	DiscriminatorSize = 8
+
+	// RewardCount is the number of reward slots of a pool
+	RewardCount = 3
+
+	// TickArrayBitmapWords is the number of u64 words of the default tick array bitmap in PoolState
+	TickArrayBitmapWords = 16
+
+	// PoolStateSize is the size of a PoolState account, discriminator included
+	PoolStateSize = DiscriminatorSize + 1536
+
+	// AmmConfigSize is the size of an AmmConfig account, discriminator included
+	AmmConfigSize = DiscriminatorSize + 109
+)
+
+var (
+	// PoolStateDiscriminator prefixes every PoolState account
+	PoolStateDiscriminator = accountDiscriminator("PoolState")
+
+	// AmmConfigDiscriminator prefixes every AmmConfig account
+	AmmConfigDiscriminator = accountDiscriminator("AmmConfig")
+)
+
+// PoolState mirrors the PoolState account of the Raydium CLMM IDL, without the discriminator
+type PoolState struct {
+	Bump                   [1]uint8
+	AmmConfig              solana.PublicKey
+	Owner                  solana.PublicKey
+	TokenMint0             solana.PublicKey
+	TokenMint1             solana.PublicKey
+	TokenVault0            solana.PublicKey
+	TokenVault1            solana.PublicKey
+	ObservationKey         solana.PublicKey
+	MintDecimals0          uint8
+	MintDecimals1          uint8
+	TickSpacing            uint16
+	Liquidity              bin.Uint128
+	SqrtPriceX64           bin.Uint128
+	TickCurrent            int32
+	Padding3               uint16
+	Padding4               uint16
+	FeeGrowthGlobal0X64    bin.Uint128
+	FeeGrowthGlobal1X64    bin.Uint128
+	ProtocolFeesToken0     uint64
+	ProtocolFeesToken1     uint64
+	SwapInAmountToken0     bin.Uint128
+	SwapOutAmountToken1    bin.Uint128
+	SwapInAmountToken1     bin.Uint128
+	SwapOutAmountToken0    bin.Uint128
+	Status                 uint8
+	Padding                [7]uint8
+	RewardInfos            [RewardCount]PoolRewardInfo
+	TickArrayBitmap        [TickArrayBitmapWords]uint64
+	TotalFeesToken0        uint64
+	TotalFeesClaimedToken0 uint64
+	TotalFeesToken1        uint64
+	TotalFeesClaimedToken1 uint64
+	FundFeesToken0         uint64
+	FundFeesToken1         uint64
+	OpenTime               uint64
+	RecentEpoch            uint64
+	Padding1               [24]uint64
+	Padding2               [32]uint64
+}
+
+// PoolRewardInfo mirrors the RewardInfo type of the Raydium CLMM IDL
+type PoolRewardInfo struct {
+	RewardState           uint8
+	OpenTime              uint64
+	EndTime               uint64
+	LastUpdateTime        uint64
+	EmissionsPerSecondX64 bin.Uint128
+	RewardTotalEmissioned uint64
+	RewardClaimed         uint64
+	TokenMint             solana.PublicKey
+	TokenVault            solana.PublicKey
+	Authority             solana.PublicKey
+	RewardGrowthGlobalX64 bin.Uint128
+}
+
+// AmmConfig mirrors the AmmConfig account of the Raydium CLMM IDL, without the discriminator
+type AmmConfig struct {
+	Bump            uint8
+	Index           uint16
+	Owner           solana.PublicKey
+	ProtocolFeeRate uint32
+	TradeFeeRate    uint32 // Hundredths of a bip, e.g. 2500 for 0.25%
+	TickSpacing     uint16
+	FundFeeRate     uint32
+	PaddingU32      uint32
+	FundOwner       solana.PublicKey
+	Padding         [3]uint64
+}
+
+// DecodePoolState decodes the raw data of a CLMM PoolState account
+func DecodePoolState(data []byte) (*PoolState, error) {
+	var state PoolState
+	if err := decodeAnchorAccount(data, "PoolState", PoolStateDiscriminator, PoolStateSize, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// DecodeAmmConfig decodes the raw data of a CLMM AmmConfig account
+func DecodeAmmConfig(data []byte) (*AmmConfig, error) {
+	var config AmmConfig
+	if err := decodeAnchorAccount(data, "AmmConfig", AmmConfigDiscriminator, AmmConfigSize, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func decodeAnchorAccount(data []byte, name string, discriminator [DiscriminatorSize]byte, size int, v interface{}) error {
+	if len(data) != size {
+		return fmt.Errorf("invalid %s data length: got %d, expected %d", name, len(data), size)
+	}
+	if !bytes.Equal(data[:DiscriminatorSize], discriminator[:]) {
+		return fmt.Errorf("account is not a %s: discriminator mismatch", name)
+	}
+	if err := bin.NewBorshDecoder(data[DiscriminatorSize:]).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// accountDiscriminator returns the Anchor discriminator of an account type: sha256("account:<name>")[:8]
+func accountDiscriminator(name string) [DiscriminatorSize]byte {
+	sum := sha256.Sum256([]byte("account:" + name))
+	var discriminator [DiscriminatorSize]byte
+	copy(discriminator[:], sum[:DiscriminatorSize])
+	return discriminator
+}
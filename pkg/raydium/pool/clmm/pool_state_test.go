@@ -0,0 +1,220 @@
+package clmm_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"corvus_bot/pkg/raydium/pool/clmm"
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clmmPoolFixture holds a PoolState account, its AmmConfig account and their expected decoded values. The
+// accounts were encoded from the layout, not read from chain, so they only check that decoding reads back
+// what the layout wrote.
+type clmmPoolFixture struct {
+	Program string `json:"program"`
+	Pool    struct {
+		fixtureAccount
+		Expected struct {
+			AmmConfig           string   `json:"ammConfig"`
+			TokenMint0          string   `json:"tokenMint0"`
+			TokenMint1          string   `json:"tokenMint1"`
+			TokenVault0         string   `json:"tokenVault0"`
+			TokenVault1         string   `json:"tokenVault1"`
+			ObservationKey      string   `json:"observationKey"`
+			MintDecimals0       uint8    `json:"mintDecimals0"`
+			MintDecimals1       uint8    `json:"mintDecimals1"`
+			TickSpacing         uint16   `json:"tickSpacing"`
+			Liquidity           string   `json:"liquidity"`
+			SqrtPriceX64        string   `json:"sqrtPriceX64"`
+			TickCurrent         int32    `json:"tickCurrent"`
+			FeeGrowthGlobal0X64 string   `json:"feeGrowthGlobal0X64"`
+			FeeGrowthGlobal1X64 string   `json:"feeGrowthGlobal1X64"`
+			OpenTime            uint64   `json:"openTime"`
+			TickArrayBitmap     []uint64 `json:"tickArrayBitmap"`
+			RewardInfos         []struct {
+				RewardState           uint8  `json:"rewardState"`
+				EndTime               uint64 `json:"endTime"`
+				EmissionsPerSecondX64 string `json:"emissionsPerSecondX64"`
+				TokenMint             string `json:"tokenMint"`
+				TokenVault            string `json:"tokenVault"`
+			} `json:"rewardInfos"`
+		} `json:"expected"`
+	} `json:"pool"`
+	AmmConfig struct {
+		fixtureAccount
+		Expected struct {
+			Index           uint16 `json:"index"`
+			ProtocolFeeRate uint32 `json:"protocolFeeRate"`
+			TradeFeeRate    uint32 `json:"tradeFeeRate"`
+			TickSpacing     uint16 `json:"tickSpacing"`
+			FundFeeRate     uint32 `json:"fundFeeRate"`
+			FundOwner       string `json:"fundOwner"`
+		} `json:"expected"`
+	} `json:"ammConfig"`
+}
+
+type fixtureAccount struct {
+	Pubkey string `json:"pubkey"`
+	Owner  string `json:"owner"`
+	Data   string `json:"data"`
+}
+
+func TestDecodePoolState(t *testing.T) {
+	fixture := loadClmmPoolFixture(t)
+	want := fixture.Pool.Expected
+
+	state, err := clmm.DecodePoolState(decodeFixtureData(t, fixture.Pool.Data))
+	require.NoError(t, err)
+
+	assert.Equal(t, want.AmmConfig, state.AmmConfig.String())
+	assert.Equal(t, want.TokenMint0, state.TokenMint0.String())
+	assert.Equal(t, want.TokenMint1, state.TokenMint1.String())
+	assert.Equal(t, want.TokenVault0, state.TokenVault0.String())
+	assert.Equal(t, want.TokenVault1, state.TokenVault1.String())
+	assert.Equal(t, want.ObservationKey, state.ObservationKey.String())
+	assert.Equal(t, want.MintDecimals0, state.MintDecimals0)
+	assert.Equal(t, want.MintDecimals1, state.MintDecimals1)
+	assert.Equal(t, want.TickSpacing, state.TickSpacing)
+	assert.Equal(t, want.Liquidity, state.Liquidity.String())
+	assert.Equal(t, want.SqrtPriceX64, state.SqrtPriceX64.String())
+	assert.Equal(t, want.TickCurrent, state.TickCurrent)
+	assert.Equal(t, want.FeeGrowthGlobal0X64, state.FeeGrowthGlobal0X64.String())
+	assert.Equal(t, want.FeeGrowthGlobal1X64, state.FeeGrowthGlobal1X64.String())
+	assert.Equal(t, want.OpenTime, state.OpenTime)
+	assert.Equal(t, want.TickArrayBitmap, state.TickArrayBitmap[:])
+
+	reward := state.RewardInfos[0]
+	assert.Equal(t, want.RewardInfos[0].RewardState, reward.RewardState)
+	assert.Equal(t, want.RewardInfos[0].EndTime, reward.EndTime)
+	assert.Equal(t, want.RewardInfos[0].EmissionsPerSecondX64, reward.EmissionsPerSecondX64.String())
+	assert.Equal(t, want.RewardInfos[0].TokenMint, reward.TokenMint.String())
+	assert.True(t, state.RewardInfos[1].TokenMint.IsZero())
+}
+
+func TestDecodePoolStateRejectsOtherAccounts(t *testing.T) {
+	fixture := loadClmmPoolFixture(t)
+
+	_, err := clmm.DecodePoolState(decodeFixtureData(t, fixture.AmmConfig.Data))
+	assert.Error(t, err, "AmmConfig data must not decode as PoolState")
+
+	data := decodeFixtureData(t, fixture.Pool.Data)
+	data[0] ^= 0xff
+	_, err = clmm.DecodePoolState(data)
+	assert.ErrorContains(t, err, "discriminator mismatch")
+}
+
+func TestDecodeAmmConfig(t *testing.T) {
+	fixture := loadClmmPoolFixture(t)
+	want := fixture.AmmConfig.Expected
+
+	config, err := clmm.DecodeAmmConfig(decodeFixtureData(t, fixture.AmmConfig.Data))
+	require.NoError(t, err)
+
+	assert.Equal(t, want.Index, config.Index)
+	assert.Equal(t, want.ProtocolFeeRate, config.ProtocolFeeRate)
+	assert.Equal(t, want.TradeFeeRate, config.TradeFeeRate)
+	assert.Equal(t, want.TickSpacing, config.TickSpacing)
+	assert.Equal(t, want.FundFeeRate, config.FundFeeRate)
+	assert.Equal(t, want.FundOwner, config.FundOwner.String())
+}
+
+func TestFetchClmmPoolByID(t *testing.T) {
+	fixture := loadClmmPoolFixture(t)
+	want := fixture.Pool.Expected
+
+	accounts := map[string]*rpc.Account{
+		fixture.Pool.Pubkey:      fixtureRPCAccount(t, fixture.Pool.fixtureAccount),
+		fixture.AmmConfig.Pubkey: fixtureRPCAccount(t, fixture.AmmConfig.fixtureAccount),
+	}
+	// Mints only need an owner; the pool's token program is read from it
+	for _, mint := range []string{want.TokenMint0, want.TokenMint1, want.RewardInfos[0].TokenMint} {
+		accounts[mint] = &rpc.Account{
+			Owner: solana.TokenProgramID,
+			Data:  rpc.DataBytesOrJSONFromBytes(make([]byte, 82)),
+		}
+	}
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			return &rpc.GetAccountInfoResult{Value: accounts[account.String()]}, nil
+		},
+	}
+
+	pool, err := clmm.FetchClmmPoolByID(context.Background(), client, fixture.Pool.Pubkey, fixture.Program)
+	require.NoError(t, err)
+
+	assert.Equal(t, fixture.Pool.Pubkey, pool.ID)
+	assert.Equal(t, fixture.Program, pool.ProgramID)
+	assert.Equal(t, want.TokenMint0, pool.MintA)
+	assert.Equal(t, want.TokenMint1, pool.MintB)
+	assert.Equal(t, want.TokenVault0, pool.VaultA)
+	assert.Equal(t, want.TokenVault1, pool.VaultB)
+	assert.Equal(t, 9, pool.MintDecimalsA)
+	assert.Equal(t, 6, pool.MintDecimalsB)
+	assert.Equal(t, solana.TokenProgramID.String(), pool.MintProgramIDA)
+	assert.Equal(t, solana.TokenProgramID.String(), pool.MintProgramIDB)
+	assert.Equal(t, want.SqrtPriceX64, pool.SqrtPriceX64.String())
+	assert.Equal(t, want.TickCurrent, pool.TickCurrent)
+	assert.Equal(t, want.Liquidity, pool.Liquidity.String())
+
+	assert.Equal(t, fixture.AmmConfig.Pubkey, pool.AmmConfig.ID)
+	assert.Equal(t, int(fixture.AmmConfig.Expected.TradeFeeRate), pool.AmmConfig.TradeFeeRate)
+	assert.Equal(t, int(fixture.AmmConfig.Expected.TickSpacing), pool.AmmConfig.TickSpacing)
+
+	require.Len(t, pool.RewardInfos, 1)
+	assert.Equal(t, want.RewardInfos[0].TokenMint, pool.RewardInfos[0].Mint)
+	assert.Equal(t, want.RewardInfos[0].TokenVault, pool.RewardInfos[0].Vault)
+	assert.Equal(t, solana.TokenProgramID.String(), pool.RewardInfos[0].ProgramID)
+
+	// The pool must survive the JSON cache round trip
+	raw, err := json.Marshal(pool)
+	require.NoError(t, err)
+	var cached clmm.RaydiumClmmPool
+	require.NoError(t, json.Unmarshal(raw, &cached))
+	assert.Equal(t, want.SqrtPriceX64, cached.SqrtPriceX64.String())
+	assert.Equal(t, pool.TickArrayBitmap, cached.TickArrayBitmap)
+}
+
+func TestFetchClmmPoolByIDRejectsForeignOwner(t *testing.T) {
+	fixture := loadClmmPoolFixture(t)
+
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			return &rpc.GetAccountInfoResult{Value: fixtureRPCAccount(t, fixture.Pool.fixtureAccount)}, nil
+		},
+	}
+
+	_, err := clmm.FetchClmmPoolByID(context.Background(), client, fixture.Pool.Pubkey, solana.TokenProgramID.String())
+	assert.ErrorContains(t, err, "not by CLMM program")
+}
+
+func loadClmmPoolFixture(t *testing.T) *clmmPoolFixture {
+	raw, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "data", "testdata", "clmm_pool_sol_usdc.json"))
+	require.NoError(t, err)
+
+	var fixture clmmPoolFixture
+	require.NoError(t, json.Unmarshal(raw, &fixture))
+	return &fixture
+}
+
+func decodeFixtureData(t *testing.T, data string) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	require.NoError(t, err)
+	return decoded
+}
+
+func fixtureRPCAccount(t *testing.T, account fixtureAccount) *rpc.Account {
+	return &rpc.Account{
+		Owner: solana.MustPublicKeyFromBase58(account.Owner),
+		Data:  rpc.DataBytesOrJSONFromBytes(decodeFixtureData(t, account.Data)),
+	}
+}
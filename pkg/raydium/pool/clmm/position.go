@@ -0,0 +1,270 @@
+package clmm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// PersonalPositionStateSize is the size of a PersonalPositionState account, discriminator included
+	PersonalPositionStateSize = DiscriminatorSize + 273
+
+	// PositionSeed is the PDA seed of personal and protocol position accounts
+	PositionSeed = "position"
+
+	// MetadataSeed is the PDA seed of Metaplex metadata accounts
+	MetadataSeed = "metadata"
+)
+
+var (
+	// PersonalPositionStateDiscriminator prefixes every PersonalPositionState account
+	PersonalPositionStateDiscriminator = accountDiscriminator("PersonalPositionState")
+
+	// MetadataProgramID is the Metaplex token metadata program holding position NFT metadata
+	MetadataProgramID = solana.MustPublicKeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+)
+
+// PersonalPositionState mirrors the PersonalPositionState account of the Raydium CLMM IDL, without the discriminator.
+// Fees and rewards owed are only brought up to date by the program when the position is modified.
+type PersonalPositionState struct {
+	Bump                    uint8
+	NftMint                 solana.PublicKey
+	PoolID                  solana.PublicKey
+	TickLowerIndex          int32
+	TickUpperIndex          int32
+	Liquidity               bin.Uint128
+	FeeGrowthInside0LastX64 bin.Uint128
+	FeeGrowthInside1LastX64 bin.Uint128
+	TokenFeesOwed0          uint64
+	TokenFeesOwed1          uint64
+	RewardInfos             [RewardCount]PositionRewardInfo
+	RecentEpoch             uint64
+	Padding                 [7]uint64
+}
+
+// PositionRewardInfo mirrors the PositionRewardInfo type of the Raydium CLMM IDL
+type PositionRewardInfo struct {
+	GrowthInsideLastX64 bin.Uint128
+	RewardAmountOwed    uint64
+}
+
+// Position is a decoded personal position with the tokens it holds and what it can collect
+type Position struct {
+	Address         solana.PublicKey
+	State           *PersonalPositionState
+	NftTokenProgram solana.PublicKey // Token program of the position NFT, needed to derive the owner's NFT account
+	Amount0         uint64           // Token 0 withdrawable for the position's liquidity at the current price
+	Amount1         uint64           // Token 1 withdrawable for the position's liquidity at the current price
+	FeesOwed0       uint64
+	FeesOwed1       uint64
+	RewardsOwed     [RewardCount]uint64 // As of the last reward update of the pool
+}
+
+// DecodePersonalPositionState decodes the raw data of a CLMM PersonalPositionState account
+func DecodePersonalPositionState(data []byte) (*PersonalPositionState, error) {
+	var state PersonalPositionState
+	if err := decodeAnchorAccount(data, "PersonalPositionState", PersonalPositionStateDiscriminator, PersonalPositionStateSize, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// FindPersonalPositionAddress derives the address of the personal position of a position NFT
+func FindPersonalPositionAddress(programID, nftMint solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(PositionSeed), nftMint.Bytes()}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive personal position address: %w", err)
+	}
+	return address, nil
+}
+
+// FindProtocolPositionAddress derives the address of the protocol position shared by every position of a tick range
+func FindProtocolPositionAddress(programID, poolID solana.PublicKey, tickLower, tickUpper int32) (solana.PublicKey, error) {
+	lower := make([]byte, 4)
+	upper := make([]byte, 4)
+	binary.BigEndian.PutUint32(lower, uint32(tickLower))
+	binary.BigEndian.PutUint32(upper, uint32(tickUpper))
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(PositionSeed), poolID.Bytes(), lower, upper}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive protocol position address: %w", err)
+	}
+	return address, nil
+}
+
+// FindMetadataAddress derives the Metaplex metadata account of a position NFT
+func FindMetadataAddress(nftMint solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(MetadataSeed), MetadataProgramID.Bytes(), nftMint.Bytes()}, MetadataProgramID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive metadata address: %w", err)
+	}
+	return address, nil
+}
+
+// FetchPosition fetches the personal position of a position NFT, along with its pool and boundary ticks,
+// and computes the tokens it holds and the fees and rewards it can collect.
+func FetchPosition(ctx context.Context, client utils.RPCClientInterface, programID, nftMint solana.PublicKey) (*Position, error) {
+	address, err := FindPersonalPositionAddress(programID, nftMint)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := fetchAccount(ctx, client, address.String())
+	if err != nil {
+		return nil, err
+	}
+	state, err := DecodePersonalPositionState(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse position %s: %w", address, err)
+	}
+
+	account, err = fetchAccount(ctx, client, state.PoolID.String())
+	if err != nil {
+		return nil, err
+	}
+	pool, err := DecodePoolState(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pool %s: %w", state.PoolID, err)
+	}
+
+	tickLower, err := fetchTickState(ctx, client, programID, state.PoolID, state.TickLowerIndex, pool.TickSpacing)
+	if err != nil {
+		return nil, err
+	}
+	tickUpper, err := fetchTickState(ctx, client, programID, state.PoolID, state.TickUpperIndex, pool.TickSpacing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Position NFTs are minted by either token program depending on the instruction that opened them
+	nftTokenProgram, err := fetchAccountOwner(ctx, client, nftMint.String())
+	if err != nil {
+		return nil, err
+	}
+
+	position := &Position{
+		Address:         address,
+		State:           state,
+		NftTokenProgram: solana.MustPublicKeyFromBase58(nftTokenProgram),
+	}
+	if err := position.update(pool, tickLower, tickUpper); err != nil {
+		return nil, err
+	}
+	return position, nil
+}
+
+// update computes the withdrawable amounts and pending fees and rewards of the position
+func (p *Position) update(pool *PoolState, tickLower, tickUpper *TickState) error {
+	amount0, amount1, err := GetAmountsForLiquidity(
+		pool.TickCurrent, pool.SqrtPriceX64.BigInt(),
+		p.State.TickLowerIndex, p.State.TickUpperIndex,
+		p.State.Liquidity.BigInt(), false,
+	)
+	if err != nil {
+		return err
+	}
+	if !amount0.IsUint64() || !amount1.IsUint64() {
+		return fmt.Errorf("position %s amounts overflow u64", p.Address)
+	}
+	p.Amount0, p.Amount1 = amount0.Uint64(), amount1.Uint64()
+
+	p.FeesOwed0, p.FeesOwed1 = p.State.PendingFees(pool, tickLower, tickUpper)
+	p.RewardsOwed = p.State.PendingRewards(pool, tickLower, tickUpper)
+	return nil
+}
+
+// PendingFees returns the fees the position can collect, including those accrued since it was last modified.
+// Amounts beyond u64 saturate at math.MaxUint64.
+func (p *PersonalPositionState) PendingFees(pool *PoolState, tickLower, tickUpper *TickState) (uint64, uint64) {
+	inside0 := growthInside(pool.TickCurrent, pool.FeeGrowthGlobal0X64.BigInt(), tickLower, tickUpper, tickLower.FeeGrowthOutside0X64, tickUpper.FeeGrowthOutside0X64)
+	inside1 := growthInside(pool.TickCurrent, pool.FeeGrowthGlobal1X64.BigInt(), tickLower, tickUpper, tickLower.FeeGrowthOutside1X64, tickUpper.FeeGrowthOutside1X64)
+
+	liquidity := p.Liquidity.BigInt()
+	fees0 := saturatingAdd(p.TokenFeesOwed0, growthToAmount(inside0, p.FeeGrowthInside0LastX64.BigInt(), liquidity))
+	fees1 := saturatingAdd(p.TokenFeesOwed1, growthToAmount(inside1, p.FeeGrowthInside1LastX64.BigInt(), liquidity))
+	return fees0, fees1
+}
+
+// PendingRewards returns the rewards the position can collect in each reward slot of the pool.
+// Pool reward growth only advances when the pool is touched, so emissions since then are not included.
+// Amounts beyond u64 saturate at math.MaxUint64.
+func (p *PersonalPositionState) PendingRewards(pool *PoolState, tickLower, tickUpper *TickState) [RewardCount]uint64 {
+	var rewards [RewardCount]uint64
+	liquidity := p.Liquidity.BigInt()
+	for i, reward := range pool.RewardInfos {
+		rewards[i] = p.RewardInfos[i].RewardAmountOwed
+		if reward.TokenMint.IsZero() {
+			continue
+		}
+		inside := growthInside(pool.TickCurrent, reward.RewardGrowthGlobalX64.BigInt(), tickLower, tickUpper,
+			tickLower.RewardGrowthsOutsideX64[i], tickUpper.RewardGrowthsOutsideX64[i])
+		rewards[i] = saturatingAdd(rewards[i], growthToAmount(inside, p.RewardInfos[i].GrowthInsideLastX64.BigInt(), liquidity))
+	}
+	return rewards
+}
+
+// growthInside computes the growth accumulated inside a tick range from the global growth and
+// the growth recorded outside each boundary, with the program's wrapping u128 arithmetic
+func growthInside(tickCurrent int32, global *big.Int, tickLower, tickUpper *TickState, outsideLower, outsideUpper bin.Uint128) *big.Int {
+	below := outsideLower.BigInt()
+	if tickCurrent < tickLower.Tick {
+		below = wrappingSub128(global, below)
+	}
+	above := outsideUpper.BigInt()
+	if tickCurrent >= tickUpper.Tick {
+		above = wrappingSub128(global, above)
+	}
+	return wrappingSub128(wrappingSub128(global, below), above)
+}
+
+// growthToAmount converts the growth since the last checkpoint into a token amount for liquidity,
+// saturating at math.MaxUint64
+func growthToAmount(inside, last, liquidity *big.Int) uint64 {
+	amount := mulDivFloor(wrappingSub128(inside, last), liquidity, q64)
+	if !amount.IsUint64() {
+		return math.MaxUint64
+	}
+	return amount.Uint64()
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+func wrappingSub128(a, b *big.Int) *big.Int {
+	return new(big.Int).And(new(big.Int).Sub(a, b), maxU128)
+}
+
+// fetchTickState fetches the tick array holding tick and returns the tick's state
+func fetchTickState(ctx context.Context, client utils.RPCClientInterface, programID, poolID solana.PublicKey, tick int32, tickSpacing uint16) (*TickState, error) {
+	if tickSpacing == 0 {
+		return nil, fmt.Errorf("pool %s has no tick spacing", poolID)
+	}
+
+	start := GetTickArrayStartIndex(tick, tickSpacing)
+	address, err := FindTickArrayAddress(programID, poolID, start)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := fetchAccount(ctx, client, address.String())
+	if err != nil {
+		return nil, err
+	}
+	tickArray, err := DecodeTickArrayState(account.Data.GetBinary())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tick array %d: %w", start, err)
+	}
+
+	return &tickArray.Ticks[(tick-start)/int32(tickSpacing)], nil
+}
@@ -0,0 +1,314 @@
+package clmm
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"math/big"
+	"testing"
+
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPositionPool() RaydiumClmmPool {
+	pool := newTestSwapPool()
+	pool.AmmConfig.TickSpacing = 10
+	return pool
+}
+
+// newTestPositionState builds a pool with one position between -500 and 300, where 5 units of token 0 fees
+// per unit of liquidity (Q64.64) were earned inside the range, 4 of them since the position was last touched
+func newTestPositionState(t *testing.T, pool RaydiumClmmPool, nftMint solana.PublicKey) (*SwapState, *PersonalPositionState) {
+	state := newTestSwapState(t, 10, 100, []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	})
+	state.Pool.FeeGrowthGlobal0X64 = toUint128(new(big.Int).Lsh(big.NewInt(10), 64))
+	state.TickArrays[-600].Ticks[10].FeeGrowthOutside0X64 = toUint128(new(big.Int).Lsh(big.NewInt(2), 64))
+	state.TickArrays[0].Ticks[30].FeeGrowthOutside0X64 = toUint128(new(big.Int).Lsh(big.NewInt(3), 64))
+
+	// Half a reward token per unit of liquidity was emitted since the pool opened
+	state.Pool.RewardInfos[0].TokenMint = solana.MustPublicKeyFromBase58("4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R")
+	state.Pool.RewardInfos[0].RewardGrowthGlobalX64 = toUint128(new(big.Int).Lsh(big.NewInt(1), 63))
+
+	position := &PersonalPositionState{
+		NftMint:                 nftMint,
+		PoolID:                  solana.MustPublicKeyFromBase58(pool.ID),
+		TickLowerIndex:          -500,
+		TickUpperIndex:          300,
+		Liquidity:               toUint128(big.NewInt(200_000_000)),
+		FeeGrowthInside0LastX64: toUint128(new(big.Int).Lsh(big.NewInt(1), 64)),
+		TokenFeesOwed0:          7,
+	}
+	position.RewardInfos[0].RewardAmountOwed = 3
+	return state, position
+}
+
+func TestDecodePersonalPositionState(t *testing.T) {
+	nftMint := solana.NewWallet().PublicKey()
+	_, position := newTestPositionState(t, newTestPositionPool(), nftMint)
+
+	data := encodeAnchorAccount(t, PersonalPositionStateDiscriminator, position)
+	require.Len(t, data, PersonalPositionStateSize)
+
+	decoded, err := DecodePersonalPositionState(data)
+	require.NoError(t, err)
+	assert.Equal(t, position, decoded)
+
+	_, err = DecodePersonalPositionState(data[:PersonalPositionStateSize-1])
+	assert.Error(t, err)
+}
+
+func TestPersonalPositionPendingFeesAndRewards(t *testing.T) {
+	state, position := newTestPositionState(t, newTestPositionPool(), solana.NewWallet().PublicKey())
+	tickLower := &state.TickArrays[-600].Ticks[10]
+	tickUpper := &state.TickArrays[0].Ticks[30]
+
+	fees0, fees1 := position.PendingFees(state.Pool, tickLower, tickUpper)
+	assert.Equal(t, uint64(7+4*200_000_000), fees0)
+	assert.Zero(t, fees1)
+
+	rewards := position.PendingRewards(state.Pool, tickLower, tickUpper)
+	assert.Equal(t, [RewardCount]uint64{3 + 100_000_000, 0, 0}, rewards)
+
+	// Crossing the upper tick flips its outside growth to global - outside, which keeps the growth inside unchanged
+	state.Pool.TickCurrent = 400
+	state.TickArrays[0].Ticks[30].FeeGrowthOutside0X64 = toUint128(new(big.Int).Lsh(big.NewInt(7), 64))
+	fees0, _ = position.PendingFees(state.Pool, tickLower, tickUpper)
+	assert.Equal(t, uint64(7+4*200_000_000), fees0)
+
+	// Amounts beyond u64 saturate instead of wrapping
+	position.TokenFeesOwed0 = math.MaxUint64 - 1
+	fees0, _ = position.PendingFees(state.Pool, tickLower, tickUpper)
+	assert.Equal(t, uint64(math.MaxUint64), fees0)
+	huge := new(big.Int).Lsh(big.NewInt(1), 100)
+	assert.Equal(t, uint64(math.MaxUint64), growthToAmount(huge, big.NewInt(0), huge))
+
+	// Withdrawable amounts beyond u64 are an error, the program could not transfer them
+	position.Liquidity = bin.Uint128{Lo: math.MaxUint64, Hi: math.MaxUint64}
+	assert.ErrorContains(t, (&Position{State: position}).update(state.Pool, tickLower, tickUpper), "overflow u64")
+}
+
+func TestFetchPosition(t *testing.T) {
+	pool := newTestPositionPool()
+	nftMint := solana.NewWallet().PublicKey()
+	state, positionState := newTestPositionState(t, pool, nftMint)
+
+	position, err := FetchPosition(context.Background(), &utils.MockRPCClient{
+		MockGetAccountInfo: mockPositionAccounts(t, pool, state, positionState),
+	}, solana.MustPublicKeyFromBase58(pool.ProgramID), nftMint)
+	require.NoError(t, err)
+
+	expectedAddress, err := FindPersonalPositionAddress(solana.MustPublicKeyFromBase58(pool.ProgramID), nftMint)
+	require.NoError(t, err)
+	assert.Equal(t, expectedAddress, position.Address)
+	assert.Equal(t, solana.TokenProgramID, position.NftTokenProgram)
+	assert.Equal(t, uint64(7+4*200_000_000), position.FeesOwed0)
+	assert.Equal(t, uint64(3+100_000_000), position.RewardsOwed[0])
+
+	// The price is inside the range, so the position holds both tokens
+	assert.Positive(t, position.Amount0)
+	assert.Positive(t, position.Amount1)
+}
+
+func TestNewOpenPositionV2Instruction(t *testing.T) {
+	pool := newTestPositionPool()
+	owner, nftMint := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	ix, err := NewOpenPositionV2Instruction(pool, owner, nftMint, OpenPositionParams{
+		TickLower:  -500,
+		TickUpper:  300,
+		Liquidity:  big.NewInt(200_000_000),
+		Amount0Max: 1_000,
+		Amount1Max: 2_000,
+	})
+	require.NoError(t, err)
+
+	data, err := ix.Data()
+	require.NoError(t, err)
+	require.Len(t, data, 58)
+	assert.Equal(t, []byte{77, 184, 74, 214, 112, 86, 241, 199}, data[:8], "open_position_v2 discriminator")
+	assert.Equal(t, int32(-500), int32(binary.LittleEndian.Uint32(data[8:])))
+	assert.Equal(t, int32(300), int32(binary.LittleEndian.Uint32(data[12:])))
+	assert.Equal(t, int32(-600), int32(binary.LittleEndian.Uint32(data[16:])), "tick array of the lower tick")
+	assert.Equal(t, int32(0), int32(binary.LittleEndian.Uint32(data[20:])), "tick array of the upper tick")
+	assert.Equal(t, uint64(200_000_000), binary.LittleEndian.Uint64(data[24:]))
+	assert.Equal(t, uint64(0), binary.LittleEndian.Uint64(data[32:]))
+	assert.Equal(t, uint64(1_000), binary.LittleEndian.Uint64(data[40:]))
+	assert.Equal(t, uint64(2_000), binary.LittleEndian.Uint64(data[48:]))
+	assert.Equal(t, []byte{0, 0}, data[56:], "no metadata, no base flag")
+
+	programID := solana.MustPublicKeyFromBase58(pool.ProgramID)
+	poolID := solana.MustPublicKeyFromBase58(pool.ID)
+	protocolPosition, err := FindProtocolPositionAddress(programID, poolID, -500, 300)
+	require.NoError(t, err)
+	personalPosition, err := FindPersonalPositionAddress(programID, nftMint)
+	require.NoError(t, err)
+	tickArrayLower, err := FindTickArrayAddress(programID, poolID, -600)
+	require.NoError(t, err)
+
+	accounts := ix.Accounts()
+	require.Len(t, accounts, 22)
+	assert.True(t, accounts[0].IsSigner)
+	assert.Equal(t, nftMint, accounts[2].PublicKey)
+	assert.True(t, accounts[2].IsSigner, "the new NFT mint signs its creation")
+	assert.Equal(t, pool.ID, accounts[5].PublicKey.String())
+	assert.Equal(t, protocolPosition, accounts[6].PublicKey)
+	assert.Equal(t, tickArrayLower, accounts[7].PublicKey)
+	assert.Equal(t, personalPosition, accounts[9].PublicKey)
+	assert.Equal(t, pool.VaultA, accounts[12].PublicKey.String())
+	assert.Equal(t, MetadataProgramID, accounts[18].PublicKey)
+	assert.Equal(t, pool.MintB, accounts[21].PublicKey.String())
+
+	// Liquidity from token 0, and a range reaching past the PoolState bitmap, which needs the extension
+	baseFlag := true
+	ix, err = NewOpenPositionV2Instruction(pool, owner, nftMint, OpenPositionParams{TickLower: -500, TickUpper: 400_000, Amount0Max: 1_000, BaseFlag: &baseFlag})
+	require.NoError(t, err)
+	data, err = ix.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 1}, data[56:])
+
+	extension, err := FindTickArrayBitmapExtensionAddress(programID, poolID)
+	require.NoError(t, err)
+	require.Len(t, ix.Accounts(), 23)
+	assert.Equal(t, extension, ix.Accounts()[22].PublicKey)
+
+	_, err = NewOpenPositionV2Instruction(pool, owner, nftMint, OpenPositionParams{TickLower: -505, TickUpper: 300})
+	assert.Error(t, err, "ticks must be aligned to the tick spacing")
+}
+
+func TestLiquidityInstructions(t *testing.T) {
+	pool := newTestPositionPool()
+	pool.RewardInfos = []RewardInfo{{
+		Mint:      "4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R",
+		Vault:     "6n9VcZMZJxkYk5HnFxMJcvQ6ULLRbqiYQ3MvwWjeRV5s",
+		ProgramID: solana.TokenProgramID.String(),
+	}}
+	owner := solana.NewWallet().PublicKey()
+	_, positionState := newTestPositionState(t, pool, solana.NewWallet().PublicKey())
+	position := &Position{Address: solana.NewWallet().PublicKey(), State: positionState}
+
+	nftAccount, err := associatedTokenAddress(owner, positionState.NftMint.String(), solana.TokenProgramID.String())
+	require.NoError(t, err)
+
+	increase, err := NewIncreaseLiquidityV2Instruction(pool, owner, position, LiquidityParams{Liquidity: big.NewInt(5), Amount0: 10, Amount1: 20})
+	require.NoError(t, err)
+	data, err := increase.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{133, 29, 89, 223, 69, 238, 176, 10}, data[:8], "increase_liquidity_v2 discriminator")
+	assert.Len(t, data, 8+16+8+8+1)
+	require.Len(t, increase.Accounts(), 15)
+	assert.True(t, increase.Accounts()[0].IsSigner)
+	assert.Equal(t, nftAccount, increase.Accounts()[1].PublicKey)
+	assert.Equal(t, position.Address, increase.Accounts()[4].PublicKey)
+
+	decrease, err := NewDecreaseLiquidityV2Instruction(pool, owner, position, LiquidityParams{Liquidity: big.NewInt(5), Amount0: 1, Amount1: 2})
+	require.NoError(t, err)
+	data, err = decrease.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{58, 127, 188, 62, 79, 82, 196, 96}, data[:8], "decrease_liquidity_v2 discriminator")
+	assert.Len(t, data, 8+16+8+8)
+	assert.Equal(t, uint64(1), binary.LittleEndian.Uint64(data[24:]))
+
+	// The reward vault, the owner's reward account and the reward mint follow the 16 fixed accounts
+	accounts := decrease.Accounts()
+	require.Len(t, accounts, 19)
+	assert.Equal(t, position.Address, accounts[2].PublicKey)
+	assert.Equal(t, solana.MemoProgramID, accounts[13].PublicKey)
+	assert.Equal(t, pool.RewardInfos[0].Vault, accounts[16].PublicKey.String())
+	assert.True(t, accounts[17].IsWritable)
+	assert.Equal(t, pool.RewardInfos[0].Mint, accounts[18].PublicKey.String())
+
+	closeIx, err := NewClosePositionInstruction(pool, owner, position)
+	require.NoError(t, err)
+	data, err = closeIx.Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{123, 134, 81, 0, 49, 68, 98, 98}, data)
+	require.Len(t, closeIx.Accounts(), 6)
+	assert.Equal(t, positionState.NftMint, closeIx.Accounts()[1].PublicKey)
+	assert.Equal(t, nftAccount, closeIx.Accounts()[2].PublicKey)
+}
+
+func TestClosePosition(t *testing.T) {
+	pool := newTestPositionPool()
+	wallet := solana.NewWallet()
+
+	var sentTx *solana.Transaction
+	client := &utils.MockRPCClient{
+		MockGetLatestBlockhash: mockBlockhash,
+		MockSendTransaction: func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+			sentTx = tx
+			return tx.Signatures[0], nil
+		},
+	}
+
+	_, positionState := newTestPositionState(t, pool, solana.NewWallet().PublicKey())
+	position := &Position{Address: solana.NewWallet().PublicKey(), State: positionState, FeesOwed0: 7}
+
+	_, err := ClosePosition(context.Background(), client, wallet, &pool, position)
+	assert.ErrorContains(t, err, "still holds liquidity")
+	assert.Nil(t, sentTx)
+
+	// Fees still owed are collected by a zero liquidity decrease before the position is closed
+	positionState.Liquidity = bin.Uint128{}
+	_, err = ClosePosition(context.Background(), client, wallet, &pool, position)
+	require.NoError(t, err)
+	require.NotNil(t, sentTx)
+	require.Len(t, sentTx.Message.Instructions, 2)
+	assert.Equal(t, DecreaseLiquidityV2Discriminator[:], []byte(sentTx.Message.Instructions[0].Data[:8]))
+	assert.Equal(t, ClosePositionDiscriminator[:], []byte(sentTx.Message.Instructions[1].Data))
+}
+
+func TestOpenPosition(t *testing.T) {
+	pool := newTestPositionPool()
+	wallet := solana.NewWallet()
+
+	var sentTx *solana.Transaction
+	client := &utils.MockRPCClient{
+		MockGetLatestBlockhash: mockBlockhash,
+		MockSendTransaction: func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+			sentTx = tx
+			return tx.Signatures[0], nil
+		},
+	}
+
+	_, nftMint, err := OpenPosition(context.Background(), client, wallet, &pool, OpenPositionParams{
+		TickLower:  -500,
+		TickUpper:  300,
+		Liquidity:  big.NewInt(1_000),
+		Amount0Max: 1_000,
+		Amount1Max: 1_000,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, sentTx)
+
+	// Both the wallet and the new NFT mint signed
+	require.Len(t, sentTx.Signatures, 2)
+	assert.NoError(t, sentTx.VerifySignatures())
+	assert.Contains(t, sentTx.Message.AccountKeys, nftMint)
+}
+
+// mockPositionAccounts serves the swap state accounts along with the position and its NFT mint
+func mockPositionAccounts(t *testing.T, pool RaydiumClmmPool, state *SwapState, position *PersonalPositionState) func(context.Context, solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+	programID := solana.MustPublicKeyFromBase58(pool.ProgramID)
+	address, err := FindPersonalPositionAddress(programID, position.NftMint)
+	require.NoError(t, err)
+	positionData := encodeAnchorAccount(t, PersonalPositionStateDiscriminator, position)
+	stateAccounts := mockSwapStateAccounts(t, pool, state)
+
+	return func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+		switch account {
+		case address:
+			return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: programID, Data: rpc.DataBytesOrJSONFromBytes(positionData)}}, nil
+		case position.NftMint:
+			return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes(make([]byte, 82))}}, nil
+		}
+		return stateAccounts(ctx, account)
+	}
+}
@@ -0,0 +1,307 @@
+package clmm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"corvus_bot/pkg/utils"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// DefaultSlippageBps is the slippage applied when the caller does not provide a threshold
+	DefaultSlippageBps = 100
+
+	// SwapTickArrayCount is the number of initialized tick arrays loaded in the swap direction
+	SwapTickArrayCount = 5
+)
+
+// SwapQuote is the expected result of a swap against the current pool state
+type SwapQuote struct {
+	InputMint            string
+	OutputMint           string
+	ZeroForOne           bool    // True when swapping token 0 (MintA) for token 1 (MintB)
+	IsBaseInput          bool    // True for exact input swaps
+	AmountIn             uint64  // Input amount, including the fee
+	AmountOut            uint64  // Expected output amount
+	OtherAmountThreshold uint64  // Minimum output for exact input swaps, maximum input for exact output swaps
+	Fee                  uint64  // Trade fee paid, in input token units
+	PriceImpact          float64 // Fraction of the spot price lost to the trade size, e.g. 0.01 for 1%
+	SqrtPriceX64After    *big.Int
+	TickAfter            int32
+	TicksCrossed         int
+	SlippageBps          uint64
+
+	// Accounts the swap reads besides the pool: the bitmap extension (zero when the pool has none)
+	// and the tick arrays in traversal order, to be passed as remaining accounts
+	BitmapExtension solana.PublicKey
+	TickArrays      []solana.PublicKey
+}
+
+// Quote returns the expected output of swapping exactly amountIn of inputMint, using the live pool state
+func Quote(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	pool RaydiumClmmPool,
+	inputMint solana.PublicKey,
+	amountIn uint64,
+	slippageBps uint64,
+) (*SwapQuote, error) {
+	return quote(ctx, client, pool, inputMint, amountIn, slippageBps, true)
+}
+
+// QuoteExactOut returns the input needed to receive exactly amountOut, using the live pool state
+func QuoteExactOut(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	pool RaydiumClmmPool,
+	inputMint solana.PublicKey,
+	amountOut uint64,
+	slippageBps uint64,
+) (*SwapQuote, error) {
+	return quote(ctx, client, pool, inputMint, amountOut, slippageBps, false)
+}
+
+func quote(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	pool RaydiumClmmPool,
+	inputMint solana.PublicKey,
+	amount uint64,
+	slippageBps uint64,
+	isBaseInput bool,
+) (*SwapQuote, error) {
+	zeroForOne, err := swapDirection(pool, inputMint)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := FetchSwapState(ctx, client, pool, zeroForOne)
+	if err != nil {
+		return nil, err
+	}
+	return QuoteWithState(pool, state, inputMint, amount, slippageBps, isBaseInput)
+}
+
+// QuoteWithState computes a quote from an already loaded SwapState without any network access
+func QuoteWithState(
+	pool RaydiumClmmPool,
+	state *SwapState,
+	inputMint solana.PublicKey,
+	amount uint64,
+	slippageBps uint64,
+	isBaseInput bool,
+) (*SwapQuote, error) {
+	if slippageBps > utils.BpsDenominator {
+		return nil, fmt.Errorf("invalid slippage: %d bps", slippageBps)
+	}
+	zeroForOne, err := swapDirection(pool, inputMint)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := SimulateSwap(state, amount, nil, zeroForOne, isBaseInput)
+	if err != nil {
+		var notLoaded *TickArrayNotLoadedError
+		if errors.As(err, &notLoaded) {
+			return nil, fmt.Errorf("swap crosses more tick arrays than loaded: %w", err)
+		}
+		return nil, fmt.Errorf("failed to simulate swap: %w", err)
+	}
+	if isBaseInput && result.AmountIn < amount {
+		return nil, fmt.Errorf("%w: only %d of %d input can be swapped", ErrInsufficientLiquidity, result.AmountIn, amount)
+	}
+	if !isBaseInput && result.AmountOut < amount {
+		return nil, fmt.Errorf("%w: only %d of %d output available", ErrInsufficientLiquidity, result.AmountOut, amount)
+	}
+
+	q := &SwapQuote{
+		InputMint:         inputMint.String(),
+		ZeroForOne:        zeroForOne,
+		IsBaseInput:       isBaseInput,
+		AmountIn:          result.AmountIn,
+		AmountOut:         result.AmountOut,
+		Fee:               result.FeeAmount,
+		SqrtPriceX64After: result.SqrtPriceX64,
+		TickAfter:         result.TickCurrent,
+		TicksCrossed:      result.TicksCrossed,
+		SlippageBps:       slippageBps,
+		PriceImpact:       priceImpact(state.Pool.SqrtPriceX64.BigInt(), result, zeroForOne),
+	}
+	if zeroForOne {
+		q.OutputMint = pool.MintB
+	} else {
+		q.OutputMint = pool.MintA
+	}
+	if isBaseInput {
+		q.OtherAmountThreshold = utils.ApplySlippageDown(result.AmountOut, slippageBps)
+	} else {
+		q.OtherAmountThreshold = utils.ApplySlippageUp(result.AmountIn, slippageBps)
+	}
+
+	if err := q.setAccounts(pool, state, result.TickArrayStartIndexes); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// FetchSwapState loads the pool, its AmmConfig, the bitmap extension and up to SwapTickArrayCount
+// initialized tick arrays in the swap direction
+func FetchSwapState(ctx context.Context, client utils.RPCClientInterface, pool RaydiumClmmPool, zeroForOne bool) (*SwapState, error) {
+	programID, poolID, err := poolKeys(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := fetchAccount(ctx, client, pool.ID)
+	if err != nil {
+		return nil, err
+	}
+	poolState, err := DecodePoolState(account.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+
+	account, err = fetchAccount(ctx, client, poolState.AmmConfig.String())
+	if err != nil {
+		return nil, err
+	}
+	config, err := DecodeAmmConfig(account.Data.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+
+	state := &SwapState{Pool: poolState, Config: config, TickArrays: make(map[int32]*TickArrayState)}
+
+	// The extension only exists for pools whose liquidity reaches far from the initial price
+	extensionAddress, err := FindTickArrayBitmapExtensionAddress(programID, poolID)
+	if err != nil {
+		return nil, err
+	}
+	account, err = utils.FetchAccount(ctx, client, extensionAddress)
+	switch {
+	case errors.Is(err, utils.ErrAccountNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to fetch tick array bitmap extension: %w", err)
+	default:
+		if state.Extension, err = DecodeTickArrayBitmapExtension(account.Data.GetBinary()); err != nil {
+			return nil, err
+		}
+	}
+
+	startIndexes, err := initializedTickArrayStartIndexes(state, zeroForOne, SwapTickArrayCount)
+	if err != nil {
+		return nil, err
+	}
+	for _, startIndex := range startIndexes {
+		address, err := FindTickArrayAddress(programID, poolID, startIndex)
+		if err != nil {
+			return nil, err
+		}
+		account, err := fetchAccount(ctx, client, address.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tick array %d: %w", startIndex, err)
+		}
+		tickArray, err := DecodeTickArrayState(account.Data.GetBinary())
+		if err != nil {
+			return nil, err
+		}
+		state.TickArrays[startIndex] = tickArray
+	}
+
+	return state, nil
+}
+
+// initializedTickArrayStartIndexes lists up to count initialized tick arrays a swap in the given direction
+// can reach, starting with the one the swap starts in
+func initializedTickArrayStartIndexes(state *SwapState, zeroForOne bool, count int) ([]int32, error) {
+	_, startIndex, err := firstInitializedTickArray(state.Pool, state.Extension, zeroForOne)
+	if err != nil {
+		return nil, err
+	}
+
+	startIndexes := []int32{startIndex}
+	for len(startIndexes) < count {
+		next, found, err := nextInitializedTickArrayStartIndex(state.Pool, state.Extension, startIndex, zeroForOne)
+		// Running out of arrays or of bitmap coverage only limits how far the swap can go
+		if errors.Is(err, ErrMissingBitmapExtension) || (err == nil && !found) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		startIndexes = append(startIndexes, next)
+		startIndex = next
+	}
+	return startIndexes, nil
+}
+
+// setAccounts fills in the bitmap extension and tick array addresses the swap instruction needs
+func (q *SwapQuote) setAccounts(pool RaydiumClmmPool, state *SwapState, startIndexes []int32) error {
+	programID, poolID, err := poolKeys(pool)
+	if err != nil {
+		return err
+	}
+
+	if state.Extension != nil {
+		if q.BitmapExtension, err = FindTickArrayBitmapExtensionAddress(programID, poolID); err != nil {
+			return err
+		}
+	}
+	for _, startIndex := range startIndexes {
+		address, err := FindTickArrayAddress(programID, poolID, startIndex)
+		if err != nil {
+			return err
+		}
+		q.TickArrays = append(q.TickArrays, address)
+	}
+	return nil
+}
+
+// swapDirection reports whether swapping inputMint moves token 0 into the pool
+func swapDirection(pool RaydiumClmmPool, inputMint solana.PublicKey) (bool, error) {
+	switch inputMint.String() {
+	case pool.MintA:
+		return true, nil
+	case pool.MintB:
+		return false, nil
+	default:
+		return false, fmt.Errorf("mint %s is not part of pool %s", inputMint, pool.ID)
+	}
+}
+
+func poolKeys(pool RaydiumClmmPool) (solana.PublicKey, solana.PublicKey, error) {
+	programID, err := solana.PublicKeyFromBase58(pool.ProgramID)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("invalid program ID %q for pool %s: %w", pool.ProgramID, pool.ID, err)
+	}
+	poolID, err := solana.PublicKeyFromBase58(pool.ID)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, fmt.Errorf("invalid pool ID %s: %w", pool.ID, err)
+	}
+	return programID, poolID, nil
+}
+
+// priceImpact compares the execution price, fee excluded, with the spot price before the swap
+func priceImpact(sqrtPriceX64 *big.Int, result *SwapResult, zeroForOne bool) float64 {
+	amountInAfterFee := result.AmountIn - result.FeeAmount
+	if amountInAfterFee == 0 || result.AmountOut == 0 {
+		return 0
+	}
+
+	sqrtPrice := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX64), new(big.Float).SetInt(q64))
+	spot := new(big.Float).Mul(sqrtPrice, sqrtPrice) // Token 1 per token 0
+	if !zeroForOne {
+		spot.Quo(big.NewFloat(1), spot)
+	}
+
+	execution := new(big.Float).Quo(new(big.Float).SetUint64(result.AmountOut), new(big.Float).SetUint64(amountInAfterFee))
+	ratio, _ := new(big.Float).Quo(execution, spot).Float64()
+	if ratio >= 1 {
+		return 0
+	}
+	return 1 - ratio
+}
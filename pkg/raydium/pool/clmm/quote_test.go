@@ -0,0 +1,54 @@
+package clmm
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQuotePool() RaydiumClmmPool {
+	return RaydiumClmmPool{
+		ID:        "8sLbNZoA1cfnvMJLPfp98ZLAnFSYCFApfJKMbiXNLwxj",
+		ProgramID: "CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK",
+		MintA:     "So11111111111111111111111111111111111111112",
+		MintB:     "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
+	}
+}
+
+func TestQuoteWithState(t *testing.T) {
+	pool := newTestQuotePool()
+	state := newTestSwapState(t, 10, 100, []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	})
+
+	quote, err := QuoteWithState(pool, state, solana.MustPublicKeyFromBase58(pool.MintA), 1_000_000, 100, true)
+	require.NoError(t, err)
+
+	assert.True(t, quote.ZeroForOne)
+	assert.Equal(t, pool.MintB, quote.OutputMint)
+	assert.Equal(t, uint64(1_000_000), quote.AmountIn)
+	assert.Equal(t, quote.AmountOut*9900/10000, quote.OtherAmountThreshold)
+	assert.Greater(t, quote.PriceImpact, 0.0)
+	assert.Less(t, quote.PriceImpact, 0.01)
+	assert.True(t, quote.BitmapExtension.IsZero())
+
+	// Tick arrays are returned as the PDAs the swap instruction expects
+	programID := solana.MustPublicKeyFromBase58(pool.ProgramID)
+	poolID := solana.MustPublicKeyFromBase58(pool.ID)
+	first, err := FindTickArrayAddress(programID, poolID, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, quote.TickArrays)
+	assert.Equal(t, first, quote.TickArrays[0])
+
+	exactOut, err := QuoteWithState(pool, state, solana.MustPublicKeyFromBase58(pool.MintB), 500_000, 100, false)
+	require.NoError(t, err)
+	assert.False(t, exactOut.ZeroForOne)
+	assert.Equal(t, uint64(500_000), exactOut.AmountOut)
+	assert.GreaterOrEqual(t, exactOut.OtherAmountThreshold, exactOut.AmountIn)
+
+	_, err = QuoteWithState(pool, state, solana.TokenProgramID, 1_000, 100, true)
+	assert.Error(t, err)
+}
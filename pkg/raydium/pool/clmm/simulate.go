@@ -0,0 +1,239 @@
+package clmm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInsufficientLiquidity is returned when no initialized tick array is left in the swap direction
+	ErrInsufficientLiquidity = errors.New("insufficient liquidity for swap direction")
+
+	// ErrMissingBitmapExtension is returned when the swap reaches tick arrays tracked by the bitmap extension
+	// but no extension was provided
+	ErrMissingBitmapExtension = errors.New("tick array bitmap extension required")
+)
+
+// TickArrayNotLoadedError is returned when the swap reaches a tick array that is not part of the SwapState
+type TickArrayNotLoadedError struct {
+	StartTickIndex int32
+}
+
+func (e *TickArrayNotLoadedError) Error() string {
+	return fmt.Sprintf("tick array starting at tick %d is not loaded", e.StartTickIndex)
+}
+
+// SwapState is everything a swap reads on-chain: the pool, its config, the optional bitmap extension
+// and the tick arrays around the current price keyed by their start tick index
+type SwapState struct {
+	Pool       *PoolState
+	Config     *AmmConfig
+	Extension  *TickArrayBitmapExtension
+	TickArrays map[int32]*TickArrayState
+}
+
+// SwapResult is the outcome of a simulated swap
+type SwapResult struct {
+	AmountIn              uint64   // Input consumed, including the trade fee
+	AmountOut             uint64   // Output paid out
+	FeeAmount             uint64   // Total trade fee, in input token units
+	ProtocolFee           uint64   // Part of FeeAmount kept by the protocol
+	FundFee               uint64   // Part of FeeAmount sent to the fund
+	SqrtPriceX64          *big.Int // Pool sqrt price after the swap
+	TickCurrent           int32    // Pool tick after the swap
+	Liquidity             *big.Int // Active liquidity after the swap
+	TicksCrossed          int      // Initialized ticks crossed, each one changing the active liquidity
+	TickArrayStartIndexes []int32  // Tick arrays read by the swap, in traversal order
+}
+
+// SimulateSwap replays swap_internal of the CLMM program against state without modifying it.
+// zeroForOne swaps token 0 for token 1. With isBaseInput, amount is the exact input, otherwise the exact output.
+// A nil or zero sqrtPriceLimitX64 lets the price move to the end of the curve, like the program does.
+func SimulateSwap(state *SwapState, amount uint64, sqrtPriceLimitX64 *big.Int, zeroForOne, isBaseInput bool) (*SwapResult, error) {
+	if state == nil || state.Pool == nil || state.Config == nil {
+		return nil, fmt.Errorf("incomplete swap state")
+	}
+	if amount == 0 {
+		return nil, fmt.Errorf("swap amount must be greater than zero")
+	}
+	pool, config := state.Pool, state.Config
+	if config.TradeFeeRate >= FeeRateDenominator {
+		return nil, fmt.Errorf("invalid trade fee rate %d", config.TradeFeeRate)
+	}
+
+	sqrtPriceX64 := pool.SqrtPriceX64.BigInt()
+	limit, err := sqrtPriceLimit(sqrtPriceLimitX64, sqrtPriceX64, zeroForOne)
+	if err != nil {
+		return nil, err
+	}
+
+	isMatchCurrentTickArray, startIndex, err := firstInitializedTickArray(pool, state.Extension, zeroForOne)
+	if err != nil {
+		return nil, err
+	}
+	tickArray, err := state.tickArray(startIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SwapResult{TickArrayStartIndexes: []int32{startIndex}}
+	remaining := new(big.Int).SetUint64(amount)
+	calculated := new(big.Int)
+	feeTotal, protocolFee, fundFee := new(big.Int), new(big.Int), new(big.Int)
+	liquidity := pool.Liquidity.BigInt()
+	tick := pool.TickCurrent
+
+	for remaining.Sign() != 0 && sqrtPriceX64.Cmp(limit) != 0 && tick < MaxTick && tick > MinTick {
+		sqrtPriceStartX64 := sqrtPriceX64
+
+		next := tickArray.nextInitializedTick(tick, pool.TickSpacing, zeroForOne)
+		if next == nil && !isMatchCurrentTickArray {
+			// The current tick lies outside the first array, so its first tick in the swap direction is next
+			isMatchCurrentTickArray = true
+			if next, err = tickArray.firstInitializedTick(zeroForOne); err != nil {
+				return nil, err
+			}
+		}
+		if next == nil {
+			if startIndex, err = nextTickArrayStartIndex(state, startIndex, zeroForOne); err != nil {
+				return nil, err
+			}
+			if tickArray, err = state.tickArray(startIndex); err != nil {
+				return nil, err
+			}
+			result.TickArrayStartIndexes = append(result.TickArrayStartIndexes, startIndex)
+			if next, err = tickArray.firstInitializedTick(zeroForOne); err != nil {
+				return nil, err
+			}
+		}
+
+		tickNext := next.Tick
+		if tickNext < MinTick {
+			tickNext = MinTick
+		} else if tickNext > MaxTick {
+			tickNext = MaxTick
+		}
+		sqrtPriceNextX64, err := GetSqrtPriceAtTick(tickNext)
+		if err != nil {
+			return nil, err
+		}
+
+		target := sqrtPriceNextX64
+		if (zeroForOne && sqrtPriceNextX64.Cmp(limit) < 0) || (!zeroForOne && sqrtPriceNextX64.Cmp(limit) > 0) {
+			target = limit
+		}
+
+		step, err := computeSwapStep(sqrtPriceX64, target, liquidity, remaining, config.TradeFeeRate, isBaseInput, zeroForOne)
+		if err != nil {
+			return nil, err
+		}
+		sqrtPriceX64 = step.sqrtPriceNextX64
+
+		if isBaseInput {
+			remaining.Sub(remaining, step.amountIn).Sub(remaining, step.feeAmount)
+			calculated.Add(calculated, step.amountOut)
+		} else {
+			remaining.Sub(remaining, step.amountOut)
+			calculated.Add(calculated, step.amountIn).Add(calculated, step.feeAmount)
+		}
+		if remaining.Sign() < 0 {
+			return nil, fmt.Errorf("swap step consumed more than the remaining amount")
+		}
+
+		feeTotal.Add(feeTotal, step.feeAmount)
+		if config.ProtocolFeeRate > 0 {
+			protocolFee.Add(protocolFee, mulDivFloor(step.feeAmount, big.NewInt(int64(config.ProtocolFeeRate)), big.NewInt(FeeRateDenominator)))
+		}
+		if config.FundFeeRate > 0 {
+			fundFee.Add(fundFee, mulDivFloor(step.feeAmount, big.NewInt(int64(config.FundFeeRate)), big.NewInt(FeeRateDenominator)))
+		}
+
+		if sqrtPriceX64.Cmp(sqrtPriceNextX64) == 0 {
+			// Crossing an initialized tick adds or removes the liquidity of the positions bounded by it
+			if next.IsInitialized() {
+				liquidityNet := next.LiquidityNet.BigInt()
+				if zeroForOne {
+					liquidityNet.Neg(liquidityNet)
+				}
+				liquidity = new(big.Int).Add(liquidity, liquidityNet)
+				if liquidity.Sign() < 0 {
+					return nil, fmt.Errorf("liquidity underflow crossing tick %d", next.Tick)
+				}
+				result.TicksCrossed++
+			}
+			if zeroForOne {
+				tick = tickNext - 1
+			} else {
+				tick = tickNext
+			}
+		} else if sqrtPriceX64.Cmp(sqrtPriceStartX64) != 0 {
+			if tick, err = GetTickAtSqrtPrice(sqrtPriceX64); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var amountIn, amountOut *big.Int
+	if isBaseInput {
+		amountIn, amountOut = new(big.Int).Sub(new(big.Int).SetUint64(amount), remaining), calculated
+	} else {
+		amountIn, amountOut = calculated, new(big.Int).Sub(new(big.Int).SetUint64(amount), remaining)
+	}
+	for _, v := range []*big.Int{amountIn, amountOut, feeTotal} {
+		if !v.IsUint64() {
+			return nil, fmt.Errorf("swap amount overflows u64")
+		}
+	}
+
+	result.AmountIn = amountIn.Uint64()
+	result.AmountOut = amountOut.Uint64()
+	result.FeeAmount = feeTotal.Uint64()
+	result.ProtocolFee = protocolFee.Uint64()
+	result.FundFee = fundFee.Uint64()
+	result.SqrtPriceX64 = sqrtPriceX64
+	result.TickCurrent = tick
+	result.Liquidity = liquidity
+	return result, nil
+}
+
+// sqrtPriceLimit applies the program's default limit and checks that the limit lies in the swap direction
+func sqrtPriceLimit(limit, current *big.Int, zeroForOne bool) (*big.Int, error) {
+	if limit == nil || limit.Sign() == 0 {
+		if zeroForOne {
+			return new(big.Int).Add(MinSqrtPriceX64, bigOne), nil
+		}
+		return new(big.Int).Sub(MaxSqrtPriceX64, bigOne), nil
+	}
+
+	if zeroForOne && (limit.Cmp(current) >= 0 || limit.Cmp(MinSqrtPriceX64) <= 0) {
+		return nil, fmt.Errorf("sqrt price limit %s must be below the current price %s", limit, current)
+	}
+	if !zeroForOne && (limit.Cmp(current) <= 0 || limit.Cmp(MaxSqrtPriceX64) >= 0) {
+		return nil, fmt.Errorf("sqrt price limit %s must be above the current price %s", limit, current)
+	}
+	return limit, nil
+}
+
+// nextTickArrayStartIndex returns the next initialized tick array after startIndex in the swap direction
+func nextTickArrayStartIndex(state *SwapState, startIndex int32, zeroForOne bool) (int32, error) {
+	next, found, err := nextInitializedTickArrayStartIndex(state.Pool, state.Extension, startIndex, zeroForOne)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrInsufficientLiquidity
+	}
+	return next, nil
+}
+
+func (s *SwapState) tickArray(startIndex int32) (*TickArrayState, error) {
+	tickArray, ok := s.TickArrays[startIndex]
+	if !ok {
+		return nil, &TickArrayNotLoadedError{StartTickIndex: startIndex}
+	}
+	if tickArray.StartTickIndex != startIndex {
+		return nil, fmt.Errorf("tick array keyed %d starts at %d", startIndex, tickArray.StartTickIndex)
+	}
+	return tickArray, nil
+}
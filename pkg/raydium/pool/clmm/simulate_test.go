@@ -0,0 +1,227 @@
+package clmm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPosition is a liquidity range used to build a synthetic pool
+type testPosition struct {
+	lower, upper int32
+	liquidity    int64
+}
+
+// newTestSwapState builds a pool at currentTick with the given positions, filling the tick arrays,
+// the pool bitmap and the active liquidity the way the program would
+func newTestSwapState(t *testing.T, tickSpacing uint16, currentTick int32, positions []testPosition) *SwapState {
+	t.Helper()
+
+	sqrtPrice, err := GetSqrtPriceAtTick(currentTick)
+	require.NoError(t, err)
+
+	state := &SwapState{
+		Pool: &PoolState{
+			TickSpacing:  tickSpacing,
+			TickCurrent:  currentTick,
+			SqrtPriceX64: toUint128(sqrtPrice),
+		},
+		Config:     &AmmConfig{TradeFeeRate: 2500, ProtocolFeeRate: 120000, FundFeeRate: 40000, TickSpacing: tickSpacing},
+		TickArrays: make(map[int32]*TickArrayState),
+	}
+
+	net := make(map[int32]*big.Int)
+	gross := make(map[int32]*big.Int)
+	active := new(big.Int)
+	for _, p := range positions {
+		l := big.NewInt(p.liquidity)
+		for tick, sign := range map[int32]int64{p.lower: 1, p.upper: -1} {
+			if net[tick] == nil {
+				net[tick], gross[tick] = new(big.Int), new(big.Int)
+			}
+			net[tick].Add(net[tick], new(big.Int).Mul(l, big.NewInt(sign)))
+			gross[tick].Add(gross[tick], l)
+		}
+		if p.lower <= currentTick && currentTick < p.upper {
+			active.Add(active, l)
+		}
+	}
+	state.Pool.Liquidity = toUint128(active)
+
+	bitmap := wordsToBig(state.Pool.TickArrayBitmap[:])
+	for tick := range net {
+		start := GetTickArrayStartIndex(tick, tickSpacing)
+		array, ok := state.TickArrays[start]
+		if !ok {
+			array = &TickArrayState{StartTickIndex: start}
+			state.TickArrays[start] = array
+			bitmap.SetBit(bitmap, int(compressedBitmapPosition(start, tickSpacing)), 1)
+		}
+		tickState := &array.Ticks[(tick-start)/int32(tickSpacing)]
+		tickState.Tick = tick
+		tickState.LiquidityNet = bin.Int128(toUint128(new(big.Int).And(net[tick], maxU128)))
+		tickState.LiquidityGross = toUint128(gross[tick])
+	}
+	for i := range state.Pool.TickArrayBitmap {
+		state.Pool.TickArrayBitmap[i] = new(big.Int).Rsh(bitmap, uint(64*i)).Uint64()
+	}
+
+	return state
+}
+
+func toUint128(v *big.Int) bin.Uint128 {
+	lo := new(big.Int).And(v, maxU64).Uint64()
+	hi := new(big.Int).Rsh(v, 64).Uint64()
+	return bin.Uint128{Lo: lo, Hi: hi}
+}
+
+func TestSimulateSwapWithinSingleRange(t *testing.T) {
+	state := newTestSwapState(t, 10, 5, []testPosition{{lower: -600, upper: 600, liquidity: 1_000_000_000_000}})
+
+	result, err := SimulateSwap(state, 1_000_000, nil, true, true)
+	require.NoError(t, err)
+
+	// One step inside the range: the output follows the constant liquidity formulas directly
+	sqrtPrice := state.Pool.SqrtPriceX64.BigInt()
+	liquidity := state.Pool.Liquidity.BigInt()
+	amountLessFee := big.NewInt(1_000_000 * (FeeRateDenominator - 2500) / FeeRateDenominator)
+	nextPrice, err := getNextSqrtPriceFromInput(sqrtPrice, liquidity, amountLessFee, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1_000_000), result.AmountIn)
+	assert.Equal(t, GetAmount1Delta(nextPrice, sqrtPrice, liquidity, false).Uint64(), result.AmountOut)
+	assert.Equal(t, nextPrice, result.SqrtPriceX64)
+	assert.Equal(t, uint64(2500), result.FeeAmount)
+	assert.Equal(t, uint64(300), result.ProtocolFee)
+	assert.Equal(t, uint64(100), result.FundFee)
+	assert.Equal(t, 0, result.TicksCrossed)
+	// The array holding the current tick has no initialized tick, so the swap starts in the one below
+	assert.Equal(t, []int32{-600}, result.TickArrayStartIndexes)
+	assert.Equal(t, liquidity, result.Liquidity)
+
+	tick, err := GetTickAtSqrtPrice(nextPrice)
+	require.NoError(t, err)
+	assert.Equal(t, tick, result.TickCurrent)
+}
+
+func TestSimulateSwapCrossesTicksAndArrays(t *testing.T) {
+	// Tick arrays of spacing 10 span 600 ticks, so -1300 lies in the array starting at -1800
+	positions := []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	}
+
+	t.Run("zero for one", func(t *testing.T) {
+		state := newTestSwapState(t, 10, 100, positions)
+		result, err := SimulateSwap(state, 8_000_000, nil, true, true)
+		require.NoError(t, err)
+
+		// The swap leaves the narrow position at -500, then keeps going inside the wide one
+		assert.Equal(t, 1, result.TicksCrossed)
+		assert.Equal(t, big.NewInt(50_000_000), result.Liquidity)
+		assert.Less(t, result.TickCurrent, int32(-500))
+		assert.Greater(t, result.TickCurrent, int32(-1300))
+		assert.Equal(t, []int32{0, -600, -1800}, result.TickArrayStartIndexes)
+		assert.Equal(t, uint64(8_000_000), result.AmountIn)
+	})
+
+	t.Run("one for zero", func(t *testing.T) {
+		state := newTestSwapState(t, 10, 100, positions)
+		result, err := SimulateSwap(state, 3_000_000, nil, false, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.TicksCrossed)
+		assert.Greater(t, result.TickCurrent, int32(300))
+		assert.Equal(t, big.NewInt(50_000_000), result.Liquidity)
+		// The next boundary after 300 is 700, in the array starting at 600
+		assert.Equal(t, []int32{0, 600}, result.TickArrayStartIndexes)
+	})
+
+	t.Run("runs out of liquidity", func(t *testing.T) {
+		state := newTestSwapState(t, 10, 100, positions)
+		_, err := SimulateSwap(state, 100_000_000, nil, true, true)
+		assert.ErrorIs(t, err, ErrMissingBitmapExtension, "the program needs the extension to search past the pool bitmap")
+
+		state.Extension = &TickArrayBitmapExtension{}
+		_, err = SimulateSwap(state, 100_000_000, nil, true, true)
+		assert.ErrorIs(t, err, ErrInsufficientLiquidity)
+	})
+}
+
+func TestSimulateSwapExactOutMatchesExactIn(t *testing.T) {
+	positions := []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	}
+	state := newTestSwapState(t, 10, 100, positions)
+
+	exactIn, err := SimulateSwap(state, 8_000_000, nil, true, true)
+	require.NoError(t, err)
+
+	exactOut, err := SimulateSwap(state, exactIn.AmountOut, nil, true, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, exactIn.AmountOut, exactOut.AmountOut)
+	// Rounding favours the pool in both directions, so buying the same output never costs more
+	assert.LessOrEqual(t, exactOut.AmountIn, exactIn.AmountIn)
+	assert.InDelta(t, exactIn.AmountIn, exactOut.AmountIn, 10)
+	assert.Equal(t, exactIn.TicksCrossed, exactOut.TicksCrossed)
+}
+
+func TestSimulateSwapPriceLimit(t *testing.T) {
+	state := newTestSwapState(t, 10, 100, []testPosition{{lower: -1300, upper: 700, liquidity: 50_000_000}})
+	limit, err := GetSqrtPriceAtTick(-200)
+	require.NoError(t, err)
+
+	result, err := SimulateSwap(state, 1_000_000_000, limit, true, true)
+	require.NoError(t, err)
+
+	// The swap stops at the limit with only part of the input consumed
+	assert.Equal(t, limit, result.SqrtPriceX64)
+	assert.Less(t, result.AmountIn, uint64(1_000_000_000))
+
+	_, err = SimulateSwap(state, 1_000, limit, false, true)
+	assert.Error(t, err, "a limit below the price is invalid when the price moves up")
+}
+
+func TestSimulateSwapRequiresLoadedTickArrays(t *testing.T) {
+	state := newTestSwapState(t, 10, 100, []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	})
+	delete(state.TickArrays, -1800)
+
+	_, err := SimulateSwap(state, 8_000_000, nil, true, true)
+	var notLoaded *TickArrayNotLoadedError
+	require.True(t, errors.As(err, &notLoaded), "unexpected error: %v", err)
+	assert.Equal(t, int32(-1800), notLoaded.StartTickIndex)
+}
+
+func TestInitializedTickArrayStartIndexes(t *testing.T) {
+	state := newTestSwapState(t, 10, 100, []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	})
+
+	down, err := initializedTickArrayStartIndexes(state, true, SwapTickArrayCount)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{0, -600, -1800}, down)
+
+	up, err := initializedTickArrayStartIndexes(state, false, SwapTickArrayCount)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{0, 600}, up)
+}
+
+func TestGetTickArrayStartIndex(t *testing.T) {
+	assert.Equal(t, int32(0), GetTickArrayStartIndex(0, 10))
+	assert.Equal(t, int32(0), GetTickArrayStartIndex(599, 10))
+	assert.Equal(t, int32(600), GetTickArrayStartIndex(600, 10))
+	assert.Equal(t, int32(-600), GetTickArrayStartIndex(-1, 10))
+	assert.Equal(t, int32(-600), GetTickArrayStartIndex(-600, 10))
+	assert.Equal(t, int32(-1200), GetTickArrayStartIndex(-601, 10))
+	assert.Equal(t, int32(-19200), GetTickArrayStartIndex(-18973, 64))
+}
@@ -0,0 +1,116 @@
+package clmm
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// GetAmount0Delta returns the amount of token 0 between two sqrt prices for the given liquidity:
+// liquidity * (sqrtB - sqrtA) / (sqrtA * sqrtB), with Q64.64 prices
+func GetAmount0Delta(sqrtPriceAX64, sqrtPriceBX64, liquidity *big.Int, roundUp bool) *big.Int {
+	sqrtA, sqrtB := sortPrices(sqrtPriceAX64, sqrtPriceBX64)
+	numerator1 := new(big.Int).Lsh(liquidity, 64)
+	numerator2 := new(big.Int).Sub(sqrtB, sqrtA)
+
+	if roundUp {
+		return ceilDiv(mulDivCeil(numerator1, numerator2, sqrtB), sqrtA)
+	}
+	v := mulDivFloor(numerator1, numerator2, sqrtB)
+	return v.Quo(v, sqrtA)
+}
+
+// GetAmount1Delta returns the amount of token 1 between two sqrt prices for the given liquidity:
+// liquidity * (sqrtB - sqrtA), with Q64.64 prices
+func GetAmount1Delta(sqrtPriceAX64, sqrtPriceBX64, liquidity *big.Int, roundUp bool) *big.Int {
+	sqrtA, sqrtB := sortPrices(sqrtPriceAX64, sqrtPriceBX64)
+	diff := new(big.Int).Sub(sqrtB, sqrtA)
+
+	if roundUp {
+		return mulDivCeil(liquidity, diff, q64)
+	}
+	return mulDivFloor(liquidity, diff, q64)
+}
+
+// getNextSqrtPriceFromInput returns the sqrt price after adding amountIn of the input token
+func getNextSqrtPriceFromInput(sqrtPriceX64, liquidity, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount0RoundingUp(sqrtPriceX64, liquidity, amountIn, true)
+	}
+	return getNextSqrtPriceFromAmount1RoundingDown(sqrtPriceX64, liquidity, amountIn, true)
+}
+
+// getNextSqrtPriceFromOutput returns the sqrt price after removing amountOut of the output token
+func getNextSqrtPriceFromOutput(sqrtPriceX64, liquidity, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount1RoundingDown(sqrtPriceX64, liquidity, amountOut, false)
+	}
+	return getNextSqrtPriceFromAmount0RoundingUp(sqrtPriceX64, liquidity, amountOut, false)
+}
+
+// getNextSqrtPriceFromAmount0RoundingUp computes liquidity * sqrtP / (liquidity ± amount * sqrtP), rounded up
+func getNextSqrtPriceFromAmount0RoundingUp(sqrtPriceX64, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return new(big.Int).Set(sqrtPriceX64), nil
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, 64)
+	product := new(big.Int).Mul(amount, sqrtPriceX64)
+	denominator := new(big.Int)
+	if add {
+		denominator.Add(numerator1, product)
+	} else {
+		if numerator1.Cmp(product) <= 0 {
+			return nil, fmt.Errorf("insufficient liquidity to remove %s of token 0", amount)
+		}
+		denominator.Sub(numerator1, product)
+	}
+
+	return checkSqrtPrice(mulDivCeil(numerator1, sqrtPriceX64, denominator))
+}
+
+// getNextSqrtPriceFromAmount1RoundingDown computes sqrtP ± amount / liquidity, rounded down
+func getNextSqrtPriceFromAmount1RoundingDown(sqrtPriceX64, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	shifted := new(big.Int).Lsh(amount, 64)
+	if add {
+		return checkSqrtPrice(shifted.Quo(shifted, liquidity).Add(shifted, sqrtPriceX64))
+	}
+
+	quotient := ceilDiv(shifted, liquidity)
+	if sqrtPriceX64.Cmp(quotient) <= 0 {
+		return nil, fmt.Errorf("insufficient liquidity to remove %s of token 1", amount)
+	}
+	return quotient.Sub(sqrtPriceX64, quotient), nil
+}
+
+// checkSqrtPrice rejects results that do not fit the program's u128 sqrt price
+func checkSqrtPrice(sqrtPriceX64 *big.Int) (*big.Int, error) {
+	if sqrtPriceX64.Cmp(maxU128) > 0 {
+		return nil, fmt.Errorf("sqrt price overflow")
+	}
+	return sqrtPriceX64, nil
+}
+
+func sortPrices(a, b *big.Int) (*big.Int, *big.Int) {
+	if a.Cmp(b) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+func mulDivFloor(a, b, denominator *big.Int) *big.Int {
+	v := new(big.Int).Mul(a, b)
+	return v.Quo(v, denominator)
+}
+
+func mulDivCeil(a, b, denominator *big.Int) *big.Int {
+	return ceilDiv(new(big.Int).Mul(a, b), denominator)
+}
+
+// ceilDiv divides non-negative integers, rounding up
+func ceilDiv(numerator, denominator *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		quotient.Add(quotient, bigOne)
+	}
+	return quotient
+}
@@ -1,62 +1,268 @@
 package clmm
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 
-	"corvus_bot/pkg/config"
 	"corvus_bot/pkg/utils"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 )
 
-// SwapTokens performs a token swap within the Raydium CLMM pool.
+// SwapV2Discriminator is the Anchor discriminator of the swapV2 instruction
+var SwapV2Discriminator = instructionDiscriminator("swap_v2")
+
+// SwapV2Params holds the swapV2 arguments and the remaining accounts the swap reads
+type SwapV2Params struct {
+	Amount               uint64           // Exact input when IsBaseInput, exact output otherwise
+	OtherAmountThreshold uint64           // Minimum output when IsBaseInput, maximum input otherwise
+	SqrtPriceLimitX64    *big.Int         // Nil or zero to let the price move freely
+	IsBaseInput          bool             // Exact input swap
+	ZeroForOne           bool             // Swap token 0 (MintA) for token 1 (MintB)
+	BitmapExtension      solana.PublicKey // Zero when the pool has no TickArrayBitmapExtension
+	TickArrays           []solana.PublicKey
+}
+
+// SwapV2Params returns the instruction parameters matching the quote
+func (q *SwapQuote) SwapV2Params() SwapV2Params {
+	params := SwapV2Params{
+		OtherAmountThreshold: q.OtherAmountThreshold,
+		IsBaseInput:          q.IsBaseInput,
+		ZeroForOne:           q.ZeroForOne,
+		BitmapExtension:      q.BitmapExtension,
+		TickArrays:           q.TickArrays,
+	}
+	if q.IsBaseInput {
+		params.Amount = q.AmountIn
+	} else {
+		params.Amount = q.AmountOut
+	}
+	return params
+}
+
+// SwapTokens performs an exact input swap of amountIn of inputMint within the Raydium CLMM pool.
+// The live pool state is quoted to find the tick arrays the swap crosses; a minAmountOut of zero
+// is replaced by the quoted output reduced by DefaultSlippageBps.
 func SwapTokens(
 	ctx context.Context,
-	client utils.RPCClientInterface, // Use the interface for RPC client
+	client utils.RPCClientInterface,
 	wallet *solana.Wallet,
 	pool *RaydiumClmmPool,
+	inputMint solana.PublicKey,
 	amountIn uint64,
 	minAmountOut uint64,
-	cfg *config.Config,
 ) (solana.Signature, error) {
 	if pool == nil {
 		return solana.Signature{}, fmt.Errorf("pool data is nil")
 	}
 
-	// Validate pool data
-	if pool.VaultA == "" || pool.VaultB == "" || pool.MintProgramIDA == "" || pool.MintProgramIDB == "" {
-		return solana.Signature{}, fmt.Errorf("invalid CLMM pool data")
+	quote, err := Quote(ctx, client, *pool, inputMint, amountIn, DefaultSlippageBps)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to quote swap: %w", err)
+	}
+	if minAmountOut != 0 {
+		if quote.AmountOut < minAmountOut {
+			return solana.Signature{}, fmt.Errorf("quoted output %d is below min amount out %d", quote.AmountOut, minAmountOut)
+		}
+		quote.OtherAmountThreshold = minAmountOut
+	}
+
+	return swapWithQuote(ctx, client, wallet, *pool, quote)
+}
+
+// SwapTokensExactOut buys exactly amountOut of the other side of the pool, spending at most maxAmountIn of inputMint.
+func SwapTokensExactOut(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool *RaydiumClmmPool,
+	inputMint solana.PublicKey,
+	maxAmountIn uint64,
+	amountOut uint64,
+) (solana.Signature, error) {
+	if pool == nil {
+		return solana.Signature{}, fmt.Errorf("pool data is nil")
+	}
+
+	quote, err := QuoteExactOut(ctx, client, *pool, inputMint, amountOut, 0)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to compute required input: %w", err)
+	}
+	if quote.AmountIn > maxAmountIn {
+		return solana.Signature{}, fmt.Errorf("required input %d exceeds max amount in %d", quote.AmountIn, maxAmountIn)
+	}
+	quote.OtherAmountThreshold = maxAmountIn
+
+	return swapWithQuote(ctx, client, wallet, *pool, quote)
+}
+
+func swapWithQuote(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	pool RaydiumClmmPool,
+	quote *SwapQuote,
+) (solana.Signature, error) {
+	inputTokenAccount, outputTokenAccount, err := userTokenAccounts(pool, wallet.PublicKey(), quote.ZeroForOne)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+
+	swapInstruction, err := NewSwapV2Instruction(pool, wallet.PublicKey(), inputTokenAccount, outputTokenAccount, quote.SwapV2Params())
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to build swap instruction: %w", err)
+	}
+
+	return sendTransaction(ctx, client, wallet, []solana.Instruction{swapInstruction})
+}
+
+// NewSwapV2Instruction builds the CLMM swapV2 instruction as defined in the Raydium CLMM IDL.
+// The bitmap extension, when set, and the tick arrays are appended as remaining accounts.
+func NewSwapV2Instruction(
+	pool RaydiumClmmPool,
+	payer, inputTokenAccount, outputTokenAccount solana.PublicKey,
+	params SwapV2Params,
+) (solana.Instruction, error) {
+	if len(params.TickArrays) == 0 {
+		return nil, fmt.Errorf("swap needs at least one tick array")
+	}
+
+	var (
+		programID, poolID, ammConfig, observation solana.PublicKey
+		vaultA, vaultB, mintA, mintB              solana.PublicKey
+	)
+	keys := []struct {
+		name  string
+		value string
+		dest  *solana.PublicKey
+	}{
+		{"ProgramID", pool.ProgramID, &programID},
+		{"ID", pool.ID, &poolID},
+		{"AmmConfig", pool.AmmConfig.ID, &ammConfig},
+		{"ObservationID", pool.ObservationID, &observation},
+		{"VaultA", pool.VaultA, &vaultA},
+		{"VaultB", pool.VaultB, &vaultB},
+		{"MintA", pool.MintA, &mintA},
+		{"MintB", pool.MintB, &mintB},
+	}
+	for _, key := range keys {
+		pubKey, err := solana.PublicKeyFromBase58(key.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s public key: %w", key.name, err)
+		}
+		*key.dest = pubKey
+	}
+
+	inputVault, outputVault, inputMint, outputMint := vaultB, vaultA, mintB, mintA
+	if params.ZeroForOne {
+		inputVault, outputVault, inputMint, outputMint = vaultA, vaultB, mintA, mintB
+	}
+
+	accounts := solana.AccountMetaSlice{
+		solana.NewAccountMeta(payer, false, true),                      // payer
+		solana.NewAccountMeta(ammConfig, false, false),                 // ammConfig
+		solana.NewAccountMeta(poolID, true, false),                     // poolState
+		solana.NewAccountMeta(inputTokenAccount, true, false),          // inputTokenAccount
+		solana.NewAccountMeta(outputTokenAccount, true, false),         // outputTokenAccount
+		solana.NewAccountMeta(inputVault, true, false),                 // inputVault
+		solana.NewAccountMeta(outputVault, true, false),                // outputVault
+		solana.NewAccountMeta(observation, true, false),                // observationState
+		solana.NewAccountMeta(solana.TokenProgramID, false, false),     // tokenProgram
+		solana.NewAccountMeta(solana.Token2022ProgramID, false, false), // tokenProgram2022
+		solana.NewAccountMeta(solana.MemoProgramID, false, false),      // memoProgram
+		solana.NewAccountMeta(inputMint, false, false),                 // inputVaultMint
+		solana.NewAccountMeta(outputMint, false, false),                // outputVaultMint
+	}
+	if !params.BitmapExtension.IsZero() {
+		accounts = append(accounts, solana.NewAccountMeta(params.BitmapExtension, true, false))
 	}
+	for _, tickArray := range params.TickArrays {
+		accounts = append(accounts, solana.NewAccountMeta(tickArray, true, false))
+	}
+
+	data, err := encodeSwapV2Data(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return solana.NewInstruction(programID, accounts, data), nil
+}
+
+// encodeSwapV2Data encodes the discriminator followed by amount, otherAmountThreshold, sqrtPriceLimitX64 and isBaseInput
+func encodeSwapV2Data(params SwapV2Params) ([]byte, error) {
+	data := make([]byte, 41)
+	copy(data, SwapV2Discriminator[:])
+	binary.LittleEndian.PutUint64(data[8:], params.Amount)
+	binary.LittleEndian.PutUint64(data[16:], params.OtherAmountThreshold)
 
-	// Parse account public keys
-	vaultA := solana.MustPublicKeyFromBase58(pool.VaultA)
-	vaultB := solana.MustPublicKeyFromBase58(pool.VaultB)
-	owner := wallet.PublicKey()
+	if limit := params.SqrtPriceLimitX64; limit != nil {
+		if limit.Sign() < 0 || limit.Cmp(maxU128) > 0 {
+			return nil, fmt.Errorf("sqrt price limit %s does not fit in u128", limit)
+		}
+		binary.LittleEndian.PutUint64(data[24:], new(big.Int).And(limit, maxU64).Uint64())
+		binary.LittleEndian.PutUint64(data[32:], new(big.Int).Rsh(limit, 64).Uint64())
+	}
 
-	// Retrieve the CLMM program ID from the configuration
-	clmmProgramID := solana.MustPublicKeyFromBase58(cfg.RaydiumCLMMProgramID)
+	if params.IsBaseInput {
+		data[40] = 1
+	}
+	return data, nil
+}
 
-	// Encode instruction data for the swap
-	instructionData, err := encodeSwapInstructionData(amountIn, minAmountOut)
+// userTokenAccounts derives the owner's associated token accounts for the input and output side of the swap,
+// using the token program of each mint so Token-2022 mints resolve to the right address
+func userTokenAccounts(pool RaydiumClmmPool, owner solana.PublicKey, zeroForOne bool) (solana.PublicKey, solana.PublicKey, error) {
+	accountA, err := associatedTokenAddress(owner, pool.MintA, pool.MintProgramIDA)
 	if err != nil {
-		return solana.Signature{}, fmt.Errorf("failed to encode instruction data: %w", err)
-	}
-
-	// Construct the swap instruction
-	swapInstruction := solana.NewInstruction(
-		clmmProgramID,
-		solana.AccountMetaSlice{
-			{PublicKey: vaultA, IsWritable: true, IsSigner: false},
-			{PublicKey: vaultB, IsWritable: true, IsSigner: false},
-			{PublicKey: owner, IsWritable: false, IsSigner: true},
-		},
-		instructionData,
+		return solana.PublicKey{}, solana.PublicKey{}, err
+	}
+	accountB, err := associatedTokenAddress(owner, pool.MintB, pool.MintProgramIDB)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, err
+	}
+
+	if zeroForOne {
+		return accountA, accountB, nil
+	}
+	return accountB, accountA, nil
+}
+
+func associatedTokenAddress(owner solana.PublicKey, mint, tokenProgram string) (solana.PublicKey, error) {
+	mintKey, err := solana.PublicKeyFromBase58(mint)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("invalid mint %s: %w", mint, err)
+	}
+
+	// Pools cached before mint programs were resolved hold no program, which means the original token program
+	programKey := solana.TokenProgramID
+	if tokenProgram != "" {
+		if programKey, err = solana.PublicKeyFromBase58(tokenProgram); err != nil {
+			return solana.PublicKey{}, fmt.Errorf("invalid token program %s: %w", tokenProgram, err)
+		}
+	}
+
+	address, _, err := solana.FindProgramAddress(
+		[][]byte{owner[:], programKey[:], mintKey[:]},
+		solana.SPLAssociatedTokenAccountProgramID,
 	)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive token account for mint %s: %w", mint, err)
+	}
+	return address, nil
+}
 
+// sendTransaction signs the instructions with the wallet, which pays the fees, and any extra signers, then submits them.
+func sendTransaction(
+	ctx context.Context,
+	client utils.RPCClientInterface,
+	wallet *solana.Wallet,
+	instructions []solana.Instruction,
+	signers ...solana.PrivateKey,
+) (solana.Signature, error) {
 	// Fetch the latest blockhash
 	latestBlockhash, err := client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
 	if err != nil {
@@ -65,7 +271,7 @@ func SwapTokens(
 
 	// Create the transaction
 	tx, err := solana.NewTransaction(
-		[]solana.Instruction{swapInstruction},
+		instructions,
 		latestBlockhash.Value.Blockhash,
 		solana.TransactionPayer(wallet.PublicKey()),
 	)
@@ -74,9 +280,12 @@ func SwapTokens(
 	}
 
 	// Sign the transaction
+	signers = append(signers, wallet.PrivateKey)
 	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
-		if key.Equals(wallet.PublicKey()) {
-			return &wallet.PrivateKey
+		for i := range signers {
+			if key.Equals(signers[i].PublicKey()) {
+				return &signers[i]
+			}
 		}
 		return nil
 	})
@@ -93,22 +302,10 @@ func SwapTokens(
 	return signature, nil
 }
 
-// encodeSwapInstructionData encodes the swap parameters into instruction data.
-func encodeSwapInstructionData(amountIn, minAmountOut uint64) ([]byte, error) {
-	var buf bytes.Buffer
-
-	discriminator := uint8(1) // Replace with actual opcode for "Swap"
-	if err := binary.Write(&buf, binary.LittleEndian, discriminator); err != nil {
-		return nil, fmt.Errorf("failed to write discriminator: %w", err)
-	}
-
-	if err := binary.Write(&buf, binary.LittleEndian, amountIn); err != nil {
-		return nil, fmt.Errorf("failed to write amountIn: %w", err)
-	}
-
-	if err := binary.Write(&buf, binary.LittleEndian, minAmountOut); err != nil {
-		return nil, fmt.Errorf("failed to write minAmountOut: %w", err)
-	}
-
-	return buf.Bytes(), nil
+// instructionDiscriminator returns the Anchor discriminator of an instruction: sha256("global:<name>")[:8]
+func instructionDiscriminator(name string) [DiscriminatorSize]byte {
+	sum := sha256.Sum256([]byte("global:" + name))
+	var discriminator [DiscriminatorSize]byte
+	copy(discriminator[:], sum[:DiscriminatorSize])
+	return discriminator
 }
@@ -0,0 +1,83 @@
+package clmm
+
+import "math/big"
+
+// FeeRateDenominator is the denominator of the AmmConfig fee rates: a trade fee rate of 2500 is 0.25%
+const FeeRateDenominator = 1_000_000
+
+// swapStep is the result of swapping within a single price range of constant liquidity
+type swapStep struct {
+	sqrtPriceNextX64 *big.Int
+	amountIn         *big.Int // Excludes the fee
+	amountOut        *big.Int
+	feeAmount        *big.Int
+}
+
+// computeSwapStep moves the price from sqrtPriceCurrentX64 towards sqrtPriceTargetX64, consuming at most
+// amountRemaining, exactly like swap_math::compute_swap_step of the program
+func computeSwapStep(
+	sqrtPriceCurrentX64, sqrtPriceTargetX64, liquidity, amountRemaining *big.Int,
+	feeRate uint32,
+	isBaseInput, zeroForOne bool,
+) (*swapStep, error) {
+	step := &swapStep{amountIn: new(big.Int), amountOut: new(big.Int)}
+	feeRateBig := big.NewInt(int64(feeRate))
+	feeComplement := big.NewInt(FeeRateDenominator - int64(feeRate))
+
+	var err error
+	if isBaseInput {
+		amountRemainingLessFee := mulDivFloor(amountRemaining, feeComplement, big.NewInt(FeeRateDenominator))
+		if zeroForOne {
+			step.amountIn = GetAmount0Delta(sqrtPriceTargetX64, sqrtPriceCurrentX64, liquidity, true)
+		} else {
+			step.amountIn = GetAmount1Delta(sqrtPriceCurrentX64, sqrtPriceTargetX64, liquidity, true)
+		}
+		if amountRemainingLessFee.Cmp(step.amountIn) >= 0 {
+			step.sqrtPriceNextX64 = new(big.Int).Set(sqrtPriceTargetX64)
+		} else if step.sqrtPriceNextX64, err = getNextSqrtPriceFromInput(sqrtPriceCurrentX64, liquidity, amountRemainingLessFee, zeroForOne); err != nil {
+			return nil, err
+		}
+	} else {
+		if zeroForOne {
+			step.amountOut = GetAmount1Delta(sqrtPriceTargetX64, sqrtPriceCurrentX64, liquidity, false)
+		} else {
+			step.amountOut = GetAmount0Delta(sqrtPriceCurrentX64, sqrtPriceTargetX64, liquidity, false)
+		}
+		if amountRemaining.Cmp(step.amountOut) >= 0 {
+			step.sqrtPriceNextX64 = new(big.Int).Set(sqrtPriceTargetX64)
+		} else if step.sqrtPriceNextX64, err = getNextSqrtPriceFromOutput(sqrtPriceCurrentX64, liquidity, amountRemaining, zeroForOne); err != nil {
+			return nil, err
+		}
+	}
+
+	reachedTarget := step.sqrtPriceNextX64.Cmp(sqrtPriceTargetX64) == 0
+	if zeroForOne {
+		if !(reachedTarget && isBaseInput) {
+			step.amountIn = GetAmount0Delta(step.sqrtPriceNextX64, sqrtPriceCurrentX64, liquidity, true)
+		}
+		if !(reachedTarget && !isBaseInput) {
+			step.amountOut = GetAmount1Delta(step.sqrtPriceNextX64, sqrtPriceCurrentX64, liquidity, false)
+		}
+	} else {
+		if !(reachedTarget && isBaseInput) {
+			step.amountIn = GetAmount1Delta(sqrtPriceCurrentX64, step.sqrtPriceNextX64, liquidity, true)
+		}
+		if !(reachedTarget && !isBaseInput) {
+			step.amountOut = GetAmount0Delta(sqrtPriceCurrentX64, step.sqrtPriceNextX64, liquidity, false)
+		}
+	}
+
+	// Exact output swaps never pay out more than requested
+	if !isBaseInput && step.amountOut.Cmp(amountRemaining) > 0 {
+		step.amountOut = new(big.Int).Set(amountRemaining)
+	}
+
+	if isBaseInput && !reachedTarget {
+		// The whole remaining input is consumed; whatever did not move the price is the fee
+		step.feeAmount = new(big.Int).Sub(amountRemaining, step.amountIn)
+	} else {
+		step.feeAmount = mulDivCeil(step.amountIn, feeRateBig, feeComplement)
+	}
+
+	return step, nil
+}
@@ -1,56 +1,221 @@
-package clmm_test
+package clmm
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
+	"math/big"
 	"testing"
 
-	"corvus_bot/pkg/config"
-	"corvus_bot/pkg/raydium/pool/clmm"
 	"corvus_bot/pkg/utils"
 
+	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func newTestSwapPool() RaydiumClmmPool {
+	pool := newTestQuotePool()
+	pool.VaultA = "5ZrXUACAbF3bsxRmwAVmsA8AadVZEs5HcVSqrEL9ukXR"
+	pool.VaultB = "3eA1N7VTJcv2k8NhEA6LjcQGksLRUBhHEnRYBL4U1waK"
+	pool.ObservationID = "4NDj5HjVUN9f8ZMWCcUJ6TAqZTayDQgBV9ZcyYvFF1RU"
+	pool.AmmConfig.ID = "GDdR1ZhWQUwUSL69TsvZjWg7FgL1hsA2azXwvNbx3pE8"
+	pool.MintProgramIDA = solana.TokenProgramID.String()
+	pool.MintProgramIDB = solana.TokenProgramID.String()
+	return pool
+}
+
+func TestNewSwapV2Instruction(t *testing.T) {
+	pool := newTestSwapPool()
+	payer := solana.NewWallet().PublicKey()
+	input, output := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	extension, tickArray := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	limit := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(3), 64), big.NewInt(7))
+
+	ix, err := NewSwapV2Instruction(pool, payer, input, output, SwapV2Params{
+		Amount:               1_000,
+		OtherAmountThreshold: 900,
+		SqrtPriceLimitX64:    limit,
+		IsBaseInput:          true,
+		ZeroForOne:           false,
+		BitmapExtension:      extension,
+		TickArrays:           []solana.PublicKey{tickArray},
+	})
+	require.NoError(t, err)
+
+	data, err := ix.Data()
+	require.NoError(t, err)
+	require.Len(t, data, 41)
+	assert.Equal(t, []byte{43, 4, 237, 11, 26, 201, 30, 98}, data[:8], "swap_v2 discriminator")
+	assert.Equal(t, uint64(1_000), binary.LittleEndian.Uint64(data[8:]))
+	assert.Equal(t, uint64(900), binary.LittleEndian.Uint64(data[16:]))
+	assert.Equal(t, uint64(7), binary.LittleEndian.Uint64(data[24:]))
+	assert.Equal(t, uint64(3), binary.LittleEndian.Uint64(data[32:]))
+	assert.Equal(t, byte(1), data[40])
+
+	accounts := ix.Accounts()
+	require.Len(t, accounts, 15)
+	assert.Equal(t, payer, accounts[0].PublicKey)
+	assert.True(t, accounts[0].IsSigner)
+	assert.Equal(t, pool.AmmConfig.ID, accounts[1].PublicKey.String())
+	assert.Equal(t, pool.ID, accounts[2].PublicKey.String())
+	assert.Equal(t, input, accounts[3].PublicKey)
+	assert.Equal(t, output, accounts[4].PublicKey)
+	// Swapping token 1 for token 0 takes the input from vault B
+	assert.Equal(t, pool.VaultB, accounts[5].PublicKey.String())
+	assert.Equal(t, pool.VaultA, accounts[6].PublicKey.String())
+	assert.Equal(t, pool.ObservationID, accounts[7].PublicKey.String())
+	assert.Equal(t, solana.TokenProgramID, accounts[8].PublicKey)
+	assert.Equal(t, solana.Token2022ProgramID, accounts[9].PublicKey)
+	assert.Equal(t, solana.MemoProgramID, accounts[10].PublicKey)
+	assert.Equal(t, pool.MintB, accounts[11].PublicKey.String())
+	assert.Equal(t, pool.MintA, accounts[12].PublicKey.String())
+	assert.Equal(t, extension, accounts[13].PublicKey)
+	assert.Equal(t, tickArray, accounts[14].PublicKey)
+	assert.True(t, accounts[14].IsWritable)
+
+	_, err = NewSwapV2Instruction(pool, payer, input, output, SwapV2Params{Amount: 1})
+	assert.Error(t, err, "tick arrays are required")
+}
+
 func TestSwapTokens(t *testing.T) {
-	// Set a mock RPC client globally
-	utils.SetMockRPCClient(&utils.MockRPCClient{
-		MockGetLatestBlockhash: func(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error) {
-			return &rpc.GetLatestBlockhashResult{
-				Value: &rpc.LatestBlockhashResult{
-					Blockhash:            solana.MustHashFromBase58("5NKsd8FNdL3jkGjBmHNL6QQFrdkW7ZytnkZ1WFAj3YP9"), // Valid Base58
-					LastValidBlockHeight: 123456,
-				},
-			}, nil
+	pool := newTestSwapPool()
+	state := newTestSwapState(t, 10, 100, []testPosition{
+		{lower: -1300, upper: 700, liquidity: 50_000_000},
+		{lower: -500, upper: 300, liquidity: 200_000_000},
+	})
+
+	var sentTx *solana.Transaction
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo:     mockSwapStateAccounts(t, pool, state),
+		MockGetLatestBlockhash: mockBlockhash,
+		MockSendTransaction: func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+			sentTx = tx
+			return tx.Signatures[0], nil
 		},
+	}
+	wallet := solana.NewWallet()
+
+	signature, err := SwapTokens(context.Background(), client, wallet, &pool, solana.MustPublicKeyFromBase58(pool.MintA), 8_000_000, 0)
+	require.NoError(t, err)
+	require.NotNil(t, sentTx)
+	assert.Equal(t, sentTx.Signatures[0], signature)
+
+	require.Len(t, sentTx.Message.Instructions, 1)
+	compiled := sentTx.Message.Instructions[0]
+	assert.Equal(t, []byte{43, 4, 237, 11, 26, 201, 30, 98}, []byte(compiled.Data[:8]))
+	assert.Equal(t, uint64(8_000_000), binary.LittleEndian.Uint64(compiled.Data[8:]))
+	assert.Equal(t, byte(1), compiled.Data[40])
+
+	// The swap crosses three tick arrays, passed after the 13 fixed accounts
+	require.Len(t, compiled.Accounts, 16)
+	programID := solana.MustPublicKeyFromBase58(pool.ProgramID)
+	poolID := solana.MustPublicKeyFromBase58(pool.ID)
+	for i, start := range []int32{0, -600, -1800} {
+		expected, err := FindTickArrayAddress(programID, poolID, start)
+		require.NoError(t, err)
+		assert.Equal(t, expected, sentTx.Message.AccountKeys[compiled.Accounts[13+i]])
+	}
+
+	_, err = SwapTokens(context.Background(), client, wallet, &pool, solana.MustPublicKeyFromBase58(pool.MintA), 8_000_000, 1<<40)
+	assert.ErrorContains(t, err, "below min amount out")
+}
+
+func TestSwapTokensExactOut(t *testing.T) {
+	pool := newTestSwapPool()
+	state := newTestSwapState(t, 10, 100, []testPosition{{lower: -1300, upper: 700, liquidity: 50_000_000}})
+
+	var sentTx *solana.Transaction
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo:     mockSwapStateAccounts(t, pool, state),
+		MockGetLatestBlockhash: mockBlockhash,
 		MockSendTransaction: func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
-			return solana.MustSignatureFromBase58("1111111111111111111111111115KfvzcywpQZLKjb8QUGF2vhHXM8FhxzBc3Umi87SsynoE4DntWu"), nil
+			sentTx = tx
+			return tx.Signatures[0], nil
 		},
-	})
+	}
+	wallet := solana.NewWallet()
+	inputMint := solana.MustPublicKeyFromBase58(pool.MintB)
+
+	_, err := SwapTokensExactOut(context.Background(), client, wallet, &pool, inputMint, 1_000, 100_000)
+	assert.ErrorContains(t, err, "exceeds max amount in")
+	assert.Nil(t, sentTx)
+
+	_, err = SwapTokensExactOut(context.Background(), client, wallet, &pool, inputMint, 200_000, 100_000)
+	require.NoError(t, err)
+	require.NotNil(t, sentTx)
 
-	// Initialize test data
-	ctx := context.Background()
-	privateKey, _ := solana.NewRandomPrivateKey()
-	wallet := &solana.Wallet{PrivateKey: privateKey}
-	pool := &clmm.RaydiumClmmPool{
-		VaultA:         "5ZrXUACAbF3bsxRmwAVmsA8AadVZEs5HcVSqrEL9ukXR", // Valid Base58
-		VaultB:         "3eA1N7VTJcv2k8NhEA6LjcQGksLRUBhHEnRYBL4U1waK", // Valid Base58
-		MintProgramIDA: "4NDj5HjVUN9f8ZMWCcUJ6TAqZTayDQgBV9ZcyYvFF1RU", // Valid Base58
-		MintProgramIDB: "GDdR1ZhWQUwUSL69TsvZjWg7FgL1hsA2azXwvNbx3pE8", // Valid Base58
+	data := sentTx.Message.Instructions[0].Data
+	assert.Equal(t, uint64(100_000), binary.LittleEndian.Uint64(data[8:]))
+	assert.Equal(t, uint64(200_000), binary.LittleEndian.Uint64(data[16:]))
+	assert.Equal(t, byte(0), data[40])
+}
+
+// mockSwapStateAccounts serves the encoded pool, config and tick array accounts of state
+func TestFetchSwapStateExtensionErrors(t *testing.T) {
+	pool := newTestSwapPool()
+	state := newTestSwapState(t, 10, 100, []testPosition{{lower: -1300, upper: 700, liquidity: 50_000_000}})
+	accounts := mockSwapStateAccounts(t, pool, state)
+	extension, err := FindTickArrayBitmapExtensionAddress(solana.MustPublicKeyFromBase58(pool.ProgramID), solana.MustPublicKeyFromBase58(pool.ID))
+	require.NoError(t, err)
+
+	// A pool without an extension account quotes from its own bitmap
+	loaded, err := FetchSwapState(context.Background(), &utils.MockRPCClient{MockGetAccountInfo: accounts}, pool, true)
+	require.NoError(t, err)
+	assert.Nil(t, loaded.Extension)
+
+	// Any other failure must not pass for a missing extension
+	client := &utils.MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			if account.Equals(extension) {
+				return nil, errors.New("429 Too Many Requests")
+			}
+			return accounts(ctx, account)
+		},
 	}
-	cfg := &config.Config{
-		Testing:              true,
-		RaydiumCLMMProgramID: "4NDj5HjVUN9f8ZMWCcUJ6TAqZTayDQgBV9ZcyYvFF1RU", // Valid Base58
-		RPCConnection:        "https://api.mainnet-beta.solana.com",          // Valid RPC URL
+	_, err = FetchSwapState(context.Background(), client, pool, true)
+	assert.ErrorContains(t, err, "429")
+}
+
+func mockSwapStateAccounts(t *testing.T, pool RaydiumClmmPool, state *SwapState) func(context.Context, solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+	programID := solana.MustPublicKeyFromBase58(pool.ProgramID)
+	poolID := solana.MustPublicKeyFromBase58(pool.ID)
+	state.Pool.AmmConfig = solana.MustPublicKeyFromBase58(pool.AmmConfig.ID)
+
+	accounts := map[solana.PublicKey][]byte{
+		poolID:               encodeAnchorAccount(t, PoolStateDiscriminator, state.Pool),
+		state.Pool.AmmConfig: encodeAnchorAccount(t, AmmConfigDiscriminator, state.Config),
+	}
+	for start, tickArray := range state.TickArrays {
+		address, err := FindTickArrayAddress(programID, poolID, start)
+		require.NoError(t, err)
+		accounts[address] = encodeAnchorAccount(t, TickArrayStateDiscriminator, tickArray)
 	}
-	amountIn := uint64(1000)
-	minAmountOut := uint64(900)
 
-	// Call SwapTokens
-	signature, err := clmm.SwapTokens(ctx, utils.GetRPCClient(cfg), wallet, pool, amountIn, minAmountOut, cfg)
+	return func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+		data, ok := accounts[account]
+		if !ok {
+			return &rpc.GetAccountInfoResult{}, nil
+		}
+		return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: programID, Data: rpc.DataBytesOrJSONFromBytes(data)}}, nil
+	}
+}
+
+func encodeAnchorAccount(t *testing.T, discriminator [DiscriminatorSize]byte, v interface{}) []byte {
+	buf := new(bytes.Buffer)
+	buf.Write(discriminator[:])
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(v))
+	return buf.Bytes()
+}
 
-	// Assertions
-	assert.NoError(t, err, "expected no error during token swap")
-	assert.Equal(t, solana.MustSignatureFromBase58("1111111111111111111111111115KfvzcywpQZLKjb8QUGF2vhHXM8FhxzBc3Umi87SsynoE4DntWu"), signature, "unexpected signature returned")
+func mockBlockhash(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error) {
+	return &rpc.GetLatestBlockhashResult{
+		Value: &rpc.LatestBlockhashResult{
+			Blockhash:            solana.MustHashFromBase58("5NKsd8FNdL3jkGjBmHNL6QQFrdkW7ZytnkZ1WFAj3YP9"),
+			LastValidBlockHeight: 123456,
+		},
+	}, nil
 }
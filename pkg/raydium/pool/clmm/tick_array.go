@@ -0,0 +1,435 @@
+package clmm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+const (
+	// TickArraySize is the number of ticks stored in one TickArrayState account
+	TickArraySize = 60
+
+	// TickArrayBitmapSize is the number of tick arrays on each side of zero tracked by one bitmap
+	TickArrayBitmapSize = 512
+
+	// ExtensionTickArrayBitmapSize is the number of bitmaps on each side of the TickArrayBitmapExtension
+	ExtensionTickArrayBitmapSize = 14
+
+	// TickArrayStateSize is the size of a TickArrayState account, discriminator included
+	TickArrayStateSize = DiscriminatorSize + 10232
+
+	// TickArrayBitmapExtensionSize is the size of a TickArrayBitmapExtension account, discriminator included
+	TickArrayBitmapExtensionSize = DiscriminatorSize + 1824
+
+	// TickArraySeed is the PDA seed of TickArrayState accounts
+	TickArraySeed = "tick_array"
+
+	// TickArrayBitmapExtensionSeed is the PDA seed of the TickArrayBitmapExtension account of a pool
+	TickArrayBitmapExtensionSeed = "pool_tick_array_bitmap_extension"
+)
+
+var (
+	// TickArrayStateDiscriminator prefixes every TickArrayState account
+	TickArrayStateDiscriminator = accountDiscriminator("TickArrayState")
+
+	// TickArrayBitmapExtensionDiscriminator prefixes every TickArrayBitmapExtension account
+	TickArrayBitmapExtensionDiscriminator = accountDiscriminator("TickArrayBitmapExtension")
+)
+
+// TickArrayState mirrors the TickArrayState account of the Raydium CLMM IDL, without the discriminator
+type TickArrayState struct {
+	PoolID               solana.PublicKey
+	StartTickIndex       int32
+	Ticks                [TickArraySize]TickState
+	InitializedTickCount uint8
+	RecentEpoch          uint64
+	Padding              [107]uint8
+}
+
+// TickState mirrors the TickState type of the Raydium CLMM IDL
+type TickState struct {
+	Tick                    int32
+	LiquidityNet            bin.Int128
+	LiquidityGross          bin.Uint128
+	FeeGrowthOutside0X64    bin.Uint128
+	FeeGrowthOutside1X64    bin.Uint128
+	RewardGrowthsOutsideX64 [RewardCount]bin.Uint128
+	Padding                 [13]uint32
+}
+
+// TickArrayBitmapExtension mirrors the TickArrayBitmapExtension account of the Raydium CLMM IDL,
+// which tracks the tick arrays beyond the range of the bitmap stored in PoolState
+type TickArrayBitmapExtension struct {
+	PoolID                  solana.PublicKey
+	PositiveTickArrayBitmap [ExtensionTickArrayBitmapSize][8]uint64
+	NegativeTickArrayBitmap [ExtensionTickArrayBitmapSize][8]uint64
+}
+
+// DecodeTickArrayState decodes the raw data of a CLMM TickArrayState account
+func DecodeTickArrayState(data []byte) (*TickArrayState, error) {
+	var state TickArrayState
+	if err := decodeAnchorAccount(data, "TickArrayState", TickArrayStateDiscriminator, TickArrayStateSize, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// DecodeTickArrayBitmapExtension decodes the raw data of a CLMM TickArrayBitmapExtension account
+func DecodeTickArrayBitmapExtension(data []byte) (*TickArrayBitmapExtension, error) {
+	var extension TickArrayBitmapExtension
+	if err := decodeAnchorAccount(data, "TickArrayBitmapExtension", TickArrayBitmapExtensionDiscriminator, TickArrayBitmapExtensionSize, &extension); err != nil {
+		return nil, err
+	}
+	return &extension, nil
+}
+
+// FindTickArrayAddress derives the address of the tick array of a pool starting at startTickIndex
+func FindTickArrayAddress(programID, poolID solana.PublicKey, startTickIndex int32) (solana.PublicKey, error) {
+	index := make([]byte, 4)
+	binary.BigEndian.PutUint32(index, uint32(startTickIndex))
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(TickArraySeed), poolID.Bytes(), index}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive tick array address: %w", err)
+	}
+	return address, nil
+}
+
+// FindTickArrayBitmapExtensionAddress derives the address of the TickArrayBitmapExtension of a pool
+func FindTickArrayBitmapExtensionAddress(programID, poolID solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress([][]byte{[]byte(TickArrayBitmapExtensionSeed), poolID.Bytes()}, programID)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive tick array bitmap extension address: %w", err)
+	}
+	return address, nil
+}
+
+// IsInitialized reports whether any position uses the tick as a boundary
+func (t *TickState) IsInitialized() bool {
+	return t.LiquidityGross.Lo != 0 || t.LiquidityGross.Hi != 0
+}
+
+// TickCount returns the number of ticks covered by one tick array
+func TickCount(tickSpacing uint16) int32 {
+	return TickArraySize * int32(tickSpacing)
+}
+
+// GetTickArrayStartIndex returns the start index of the tick array containing tick
+func GetTickArrayStartIndex(tick int32, tickSpacing uint16) int32 {
+	ticksInArray := TickCount(tickSpacing)
+	start := tick / ticksInArray
+	if tick < 0 && tick%ticksInArray != 0 {
+		start--
+	}
+	return start * ticksInArray
+}
+
+// nextInitializedTick returns the next initialized tick of the array in the swap direction, strictly after
+// currentTick when moving up and at or before it when moving down; nil when the array holds none
+func (a *TickArrayState) nextInitializedTick(currentTick int32, tickSpacing uint16, zeroForOne bool) *TickState {
+	if GetTickArrayStartIndex(currentTick, tickSpacing) != a.StartTickIndex {
+		return nil
+	}
+
+	offset := int((currentTick - a.StartTickIndex) / int32(tickSpacing))
+	if zeroForOne {
+		for ; offset >= 0; offset-- {
+			if a.Ticks[offset].IsInitialized() {
+				return &a.Ticks[offset]
+			}
+		}
+		return nil
+	}
+
+	for offset++; offset < TickArraySize; offset++ {
+		if a.Ticks[offset].IsInitialized() {
+			return &a.Ticks[offset]
+		}
+	}
+	return nil
+}
+
+// firstInitializedTick returns the first initialized tick of the array in the swap direction
+func (a *TickArrayState) firstInitializedTick(zeroForOne bool) (*TickState, error) {
+	if zeroForOne {
+		for i := TickArraySize - 1; i >= 0; i-- {
+			if a.Ticks[i].IsInitialized() {
+				return &a.Ticks[i], nil
+			}
+		}
+	} else {
+		for i := 0; i < TickArraySize; i++ {
+			if a.Ticks[i].IsInitialized() {
+				return &a.Ticks[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("tick array %d has no initialized tick", a.StartTickIndex)
+}
+
+// maxTickInTickArrayBitmap is the tick boundary covered by one 1024 bit bitmap
+func maxTickInTickArrayBitmap(tickSpacing uint16) int32 {
+	return TickCount(tickSpacing) * TickArrayBitmapSize
+}
+
+// tickArrayStartIndexRange is the range of tick array start indexes covered by the PoolState bitmap
+func tickArrayStartIndexRange(tickSpacing uint16) (int32, int32) {
+	maxBoundary := maxTickInTickArrayBitmap(tickSpacing)
+	minBoundary := -maxBoundary
+	if maxBoundary > MaxTick {
+		maxBoundary = GetTickArrayStartIndex(MaxTick, tickSpacing) + TickCount(tickSpacing)
+	}
+	if minBoundary < MinTick {
+		minBoundary = GetTickArrayStartIndex(MinTick, tickSpacing)
+	}
+	return minBoundary, maxBoundary
+}
+
+// isOverflowDefaultTickArrayBitmap reports whether the tick array of tick is tracked by the extension
+func isOverflowDefaultTickArrayBitmap(tick int32, tickSpacing uint16) bool {
+	minBoundary, maxBoundary := tickArrayStartIndexRange(tickSpacing)
+	start := GetTickArrayStartIndex(tick, tickSpacing)
+	return start >= maxBoundary || start < minBoundary
+}
+
+// getBitmapTickBoundary returns the tick range covered by the extension bitmap holding tickArrayStartIndex
+func getBitmapTickBoundary(tickArrayStartIndex int32, tickSpacing uint16) (int32, int32) {
+	ticksInOneBitmap := maxTickInTickArrayBitmap(tickSpacing)
+	absIndex := abs32(tickArrayStartIndex)
+	m := absIndex / ticksInOneBitmap
+	if tickArrayStartIndex < 0 && absIndex%ticksInOneBitmap != 0 {
+		m++
+	}
+	minValue := ticksInOneBitmap * m
+	if tickArrayStartIndex < 0 {
+		return -minValue, -minValue + ticksInOneBitmap
+	}
+	return minValue, minValue + ticksInOneBitmap
+}
+
+// wordsToBig converts little-endian u64 words into an unsigned integer
+func wordsToBig(words []uint64) *big.Int {
+	v := new(big.Int)
+	word := new(big.Int)
+	for i := len(words) - 1; i >= 0; i-- {
+		v.Lsh(v, 64)
+		v.Or(v, word.SetUint64(words[i]))
+	}
+	return v
+}
+
+// truncate keeps the low bits of v, emulating a fixed width integer after a left shift
+func truncate(v *big.Int, bits uint) *big.Int {
+	mask := new(big.Int).Sub(new(big.Int).Lsh(bigOne, bits), bigOne)
+	return v.And(v, mask)
+}
+
+// leadingZeros counts leading zero bits of a non-zero value of the given width
+func leadingZeros(v *big.Int, bits int) int32 {
+	return int32(bits - v.BitLen())
+}
+
+// compressedBitmapPosition returns the bit of the PoolState bitmap tracking the tick array containing tick
+func compressedBitmapPosition(tick int32, tickSpacing uint16) int32 {
+	multiplier := TickCount(tickSpacing)
+	compressed := tick/multiplier + TickArrayBitmapSize
+	if tick < 0 && tick%multiplier != 0 {
+		compressed--
+	}
+	return compressed
+}
+
+// checkCurrentTickArrayIsInitialized looks up the tick array containing tickCurrent in the PoolState bitmap
+func checkCurrentTickArrayIsInitialized(bitmap *big.Int, tickCurrent int32, tickSpacing uint16) (bool, int32, error) {
+	if tickCurrent < MinTick || tickCurrent > MaxTick {
+		return false, 0, fmt.Errorf("tick %d out of range", tickCurrent)
+	}
+	compressed := compressedBitmapPosition(tickCurrent, tickSpacing)
+	start := (compressed - TickArrayBitmapSize) * TickCount(tickSpacing)
+	return bitmap.Bit(int(abs32(compressed))) == 1, start, nil
+}
+
+// nextInitializedTickArrayStartIndexInPoolBitmap searches the PoolState bitmap for the next initialized tick array
+// after lastTickArrayStartIndex. When none is found it returns the boundary of the bitmap in the swap direction.
+func nextInitializedTickArrayStartIndexInPoolBitmap(bitmap *big.Int, lastTickArrayStartIndex int32, tickSpacing uint16, zeroForOne bool) (bool, int32) {
+	tickBoundary := maxTickInTickArrayBitmap(tickSpacing)
+	next := lastTickArrayStartIndex + TickCount(tickSpacing)
+	if zeroForOne {
+		next = lastTickArrayStartIndex - TickCount(tickSpacing)
+	}
+	if next < -tickBoundary || next >= tickBoundary {
+		return false, lastTickArrayStartIndex
+	}
+
+	multiplier := TickCount(tickSpacing)
+	bitPos := abs32(compressedBitmapPosition(next, tickSpacing))
+	if zeroForOne {
+		// Drop the bits above bitPos, then the highest remaining bit is the closest array below
+		shifted := truncate(new(big.Int).Lsh(bitmap, uint(2*TickArrayBitmapSize-bitPos-1)), 2*TickArrayBitmapSize)
+		if shifted.Sign() == 0 {
+			return false, -tickBoundary
+		}
+		nextBit := leadingZeros(shifted, 2*TickArrayBitmapSize)
+		return true, (bitPos - nextBit - TickArrayBitmapSize) * multiplier
+	}
+
+	shifted := new(big.Int).Rsh(bitmap, uint(bitPos))
+	if shifted.Sign() == 0 {
+		return false, tickBoundary - TickCount(tickSpacing)
+	}
+	nextBit := int32(shifted.TrailingZeroBits())
+	return true, (bitPos + nextBit - TickArrayBitmapSize) * multiplier
+}
+
+// bitmapOffset returns the index of the extension bitmap holding tickIndex
+func (e *TickArrayBitmapExtension) bitmapOffset(tickIndex int32, tickSpacing uint16) (int, error) {
+	ticksInOneBitmap := maxTickInTickArrayBitmap(tickSpacing)
+	if tickIndex >= -ticksInOneBitmap && tickIndex < ticksInOneBitmap {
+		return 0, fmt.Errorf("tick %d is covered by the pool bitmap, not the extension", tickIndex)
+	}
+
+	offset := abs32(tickIndex)/ticksInOneBitmap - 1
+	if tickIndex < 0 && abs32(tickIndex)%ticksInOneBitmap == 0 {
+		offset--
+	}
+	if offset < 0 || offset >= ExtensionTickArrayBitmapSize {
+		return 0, fmt.Errorf("tick %d is beyond the tick array bitmap extension", tickIndex)
+	}
+	return int(offset), nil
+}
+
+// bitmap returns the 512 bit extension bitmap holding tickIndex
+func (e *TickArrayBitmapExtension) bitmap(tickIndex int32, tickSpacing uint16) (*big.Int, error) {
+	offset, err := e.bitmapOffset(tickIndex, tickSpacing)
+	if err != nil {
+		return nil, err
+	}
+	if tickIndex < 0 {
+		return wordsToBig(e.NegativeTickArrayBitmap[offset][:]), nil
+	}
+	return wordsToBig(e.PositiveTickArrayBitmap[offset][:]), nil
+}
+
+// tickArrayOffsetInBitmap returns the bit of an extension bitmap tracking tickArrayStartIndex
+func tickArrayOffsetInBitmap(tickArrayStartIndex int32, tickSpacing uint16) int32 {
+	m := abs32(tickArrayStartIndex) % maxTickInTickArrayBitmap(tickSpacing)
+	offset := m / TickCount(tickSpacing)
+	if tickArrayStartIndex < 0 && m != 0 {
+		offset = TickArrayBitmapSize - offset
+	}
+	return offset
+}
+
+// checkTickArrayIsInitialized looks up a tick array beyond the pool bitmap in the extension
+func (e *TickArrayBitmapExtension) checkTickArrayIsInitialized(tickArrayStartIndex int32, tickSpacing uint16) (bool, int32, error) {
+	bitmap, err := e.bitmap(tickArrayStartIndex, tickSpacing)
+	if err != nil {
+		return false, 0, err
+	}
+	offset := tickArrayOffsetInBitmap(tickArrayStartIndex, tickSpacing)
+	return bitmap.Bit(int(offset)) == 1, tickArrayStartIndex, nil
+}
+
+// nextInitializedTickArrayFromOneBitmap searches the extension bitmap following lastTickArrayStartIndex
+func (e *TickArrayBitmapExtension) nextInitializedTickArrayFromOneBitmap(lastTickArrayStartIndex int32, tickSpacing uint16, zeroForOne bool) (bool, int32, error) {
+	tickCount := TickCount(tickSpacing)
+	next := lastTickArrayStartIndex + tickCount
+	if zeroForOne {
+		next = lastTickArrayStartIndex - tickCount
+	}
+	if next < GetTickArrayStartIndex(MinTick, tickSpacing) || next > GetTickArrayStartIndex(MaxTick, tickSpacing) {
+		return false, next, nil
+	}
+
+	bitmap, err := e.bitmap(next, tickSpacing)
+	if err != nil {
+		return false, 0, err
+	}
+
+	minBoundary, maxBoundary := getBitmapTickBoundary(next, tickSpacing)
+	offset := tickArrayOffsetInBitmap(next, tickSpacing)
+	if zeroForOne {
+		shifted := truncate(new(big.Int).Lsh(bitmap, uint(TickArrayBitmapSize-1-offset)), TickArrayBitmapSize)
+		if shifted.Sign() == 0 {
+			return false, minBoundary, nil
+		}
+		return true, next - leadingZeros(shifted, TickArrayBitmapSize)*tickCount, nil
+	}
+
+	shifted := new(big.Int).Rsh(bitmap, uint(offset))
+	if shifted.Sign() == 0 {
+		return false, maxBoundary - tickCount, nil
+	}
+	return true, next + int32(shifted.TrailingZeroBits())*tickCount, nil
+}
+
+// firstInitializedTickArray returns the tick array a swap starts in and whether it contains the current tick
+func firstInitializedTickArray(pool *PoolState, extension *TickArrayBitmapExtension, zeroForOne bool) (bool, int32, error) {
+	var (
+		initialized bool
+		start       int32
+		err         error
+	)
+	if isOverflowDefaultTickArrayBitmap(pool.TickCurrent, pool.TickSpacing) {
+		if extension == nil {
+			return false, 0, ErrMissingBitmapExtension
+		}
+		initialized, start, err = extension.checkTickArrayIsInitialized(GetTickArrayStartIndex(pool.TickCurrent, pool.TickSpacing), pool.TickSpacing)
+	} else {
+		initialized, start, err = checkCurrentTickArrayIsInitialized(wordsToBig(pool.TickArrayBitmap[:]), pool.TickCurrent, pool.TickSpacing)
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	if initialized {
+		return true, start, nil
+	}
+
+	next, found, err := nextInitializedTickArrayStartIndex(pool, extension, GetTickArrayStartIndex(pool.TickCurrent, pool.TickSpacing), zeroForOne)
+	if err != nil {
+		return false, 0, err
+	}
+	if !found {
+		return false, 0, ErrInsufficientLiquidity
+	}
+	return false, next, nil
+}
+
+// nextInitializedTickArrayStartIndex walks the pool bitmap and the extension for the next initialized tick array
+func nextInitializedTickArrayStartIndex(pool *PoolState, extension *TickArrayBitmapExtension, lastTickArrayStartIndex int32, zeroForOne bool) (int32, bool, error) {
+	bitmap := wordsToBig(pool.TickArrayBitmap[:])
+	last := GetTickArrayStartIndex(lastTickArrayStartIndex, pool.TickSpacing)
+	for {
+		found, start := nextInitializedTickArrayStartIndexInPoolBitmap(bitmap, last, pool.TickSpacing, zeroForOne)
+		if found {
+			return start, true, nil
+		}
+		last = start
+
+		if extension == nil {
+			return 0, false, ErrMissingBitmapExtension
+		}
+		found, start, err := extension.nextInitializedTickArrayFromOneBitmap(last, pool.TickSpacing, zeroForOne)
+		if err != nil {
+			return 0, false, err
+		}
+		if found {
+			return start, true, nil
+		}
+		last = start
+
+		if last < MinTick || last > MaxTick {
+			return 0, false, nil
+		}
+	}
+}
+
+func abs32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,128 @@
+package clmm
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const (
+	// MinTick is the lowest tick a CLMM pool can reach
+	MinTick int32 = -443636
+
+	// MaxTick is the highest tick a CLMM pool can reach
+	MaxTick int32 = -MinTick
+
+	// tickPrecisionBits is the number of log2 fraction bits computed by GetTickAtSqrtPrice
+	tickPrecisionBits = 16
+)
+
+var (
+	// MinSqrtPriceX64 is the sqrt price of MinTick
+	MinSqrtPriceX64 = mustBigInt("4295048016")
+
+	// MaxSqrtPriceX64 is the sqrt price of MaxTick
+	MaxSqrtPriceX64 = mustBigInt("79226673521066979257578248091")
+
+	q64      = new(big.Int).Lsh(big.NewInt(1), 64)
+	maxU128  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxU64   = new(big.Int).SetUint64(^uint64(0))
+	bigOne   = big.NewInt(1)
+	bigZero  = big.NewInt(0)
+	logScale = big.NewInt(59543866431248) // log_sqrt(1.0001)(2) in Q32.32, as used by the program
+
+	tickLowErrorX64  = mustBigInt("184467440737095516")
+	tickHighErrorX64 = mustBigInt("15793534762490258745")
+
+	// sqrtRatioFactors[i] is 2^64 / sqrt(1.0001)^(2^i) in Q64.64, copied from the program's tick_math
+	sqrtRatioFactors = [...]uint64{
+		0xfffcb933bd6fb800, 0xfff97272373d4000, 0xfff2e50f5f657000, 0xffe5caca7e10f000,
+		0xffcb9843d60f7000, 0xff973b41fa98e800, 0xff2ea16466c9b000, 0xfe5dee046a9a3800,
+		0xfcbe86c7900bb000, 0xf987a7253ac65800, 0xf3392b0822bb6000, 0xe7159475a2caf000,
+		0xd097f3bdfd2f2000, 0xa9f746462d9f8000, 0x70d869a156f31c00, 0x31be135f97ed3200,
+		0x9aa508b5b85a500, 0x5d6af8dedc582c, 0x2216e584f5fa,
+	}
+)
+
+// GetSqrtPriceAtTick returns sqrt(1.0001^tick) in Q64.64, rounded exactly like the program
+func GetSqrtPriceAtTick(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, fmt.Errorf("tick %d out of range [%d, %d]", tick, MinTick, MaxTick)
+	}
+
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+
+	ratio := new(big.Int).Set(q64)
+	if absTick&1 != 0 {
+		ratio.SetUint64(sqrtRatioFactors[0])
+	}
+	factor := new(big.Int)
+	for i := 1; i < len(sqrtRatioFactors); i++ {
+		if absTick&(1<<i) != 0 {
+			ratio.Mul(ratio, factor.SetUint64(sqrtRatioFactors[i]))
+			ratio.Rsh(ratio, 64)
+		}
+	}
+
+	if tick > 0 {
+		ratio.Quo(maxU128, ratio)
+	}
+	return ratio, nil
+}
+
+// GetTickAtSqrtPrice returns the greatest tick whose sqrt price is at most sqrtPriceX64
+func GetTickAtSqrtPrice(sqrtPriceX64 *big.Int) (int32, error) {
+	if sqrtPriceX64.Cmp(MinSqrtPriceX64) < 0 || sqrtPriceX64.Cmp(MaxSqrtPriceX64) >= 0 {
+		return 0, fmt.Errorf("sqrt price %s out of range", sqrtPriceX64)
+	}
+
+	// Integer part of log2(price), in Q32.32
+	msb := sqrtPriceX64.BitLen() - 1
+	log2pX32 := new(big.Int).Lsh(big.NewInt(int64(msb-64)), 32)
+
+	// Normalize into [2^63, 2^64) and compute the fraction bits by repeated squaring
+	r := new(big.Int)
+	if msb >= 64 {
+		r.Rsh(sqrtPriceX64, uint(msb-63))
+	} else {
+		r.Lsh(sqrtPriceX64, uint(63-msb))
+	}
+	fractionX64 := new(big.Int)
+	bit := new(big.Int).Lsh(bigOne, 63)
+	for precision := 0; bit.Sign() > 0 && precision < tickPrecisionBits; precision++ {
+		r.Mul(r, r)
+		moreThanTwo := r.Bit(127)
+		r.Rsh(r, 63+moreThanTwo)
+		if moreThanTwo == 1 {
+			fractionX64.Add(fractionX64, bit)
+		}
+		bit.Rsh(bit, 1)
+	}
+	log2pX32.Add(log2pX32, fractionX64.Rsh(fractionX64, 32))
+
+	logSqrt10001X64 := new(big.Int).Mul(log2pX32, logScale)
+	tickLow := int32(new(big.Int).Rsh(new(big.Int).Sub(logSqrt10001X64, tickLowErrorX64), 64).Int64())
+	tickHigh := int32(new(big.Int).Rsh(new(big.Int).Add(logSqrt10001X64, tickHighErrorX64), 64).Int64())
+
+	if tickLow == tickHigh {
+		return tickLow, nil
+	}
+	highPrice, err := GetSqrtPriceAtTick(tickHigh)
+	if err != nil {
+		return 0, err
+	}
+	if highPrice.Cmp(sqrtPriceX64) <= 0 {
+		return tickHigh, nil
+	}
+	return tickLow, nil
+}
+
+func mustBigInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer constant " + s)
+	}
+	return v
+}
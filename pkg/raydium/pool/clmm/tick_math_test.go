@@ -0,0 +1,112 @@
+package clmm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSqrtPriceAtTickBounds(t *testing.T) {
+	price, err := GetSqrtPriceAtTick(MinTick)
+	require.NoError(t, err)
+	assert.Equal(t, MinSqrtPriceX64, price)
+
+	price, err = GetSqrtPriceAtTick(MaxTick)
+	require.NoError(t, err)
+	assert.Equal(t, MaxSqrtPriceX64, price)
+
+	price, err = GetSqrtPriceAtTick(0)
+	require.NoError(t, err)
+	assert.Equal(t, q64, price)
+
+	_, err = GetSqrtPriceAtTick(MinTick - 1)
+	assert.Error(t, err)
+	_, err = GetSqrtPriceAtTick(MaxTick + 1)
+	assert.Error(t, err)
+}
+
+func TestGetTickAtSqrtPriceRoundTrip(t *testing.T) {
+	ticks := []int32{MinTick, MinTick + 1, -443000, -200000, -18973, -60, -1, 0, 1, 59, 60, 18973, 200000, 443000, MaxTick - 1}
+	for _, tick := range ticks {
+		price, err := GetSqrtPriceAtTick(tick)
+		require.NoError(t, err)
+
+		got, err := GetTickAtSqrtPrice(price)
+		require.NoError(t, err)
+		assert.Equal(t, tick, got, "exact price of tick %d", tick)
+
+		// Any price below the next tick still belongs to tick
+		nextPrice, err := GetSqrtPriceAtTick(tick + 1)
+		require.NoError(t, err)
+		got, err = GetTickAtSqrtPrice(new(big.Int).Sub(nextPrice, bigOne))
+		require.NoError(t, err)
+		assert.Equal(t, tick, got, "price just below tick %d", tick+1)
+	}
+
+	_, err := GetTickAtSqrtPrice(new(big.Int).Sub(MinSqrtPriceX64, bigOne))
+	assert.Error(t, err)
+	_, err = GetTickAtSqrtPrice(MaxSqrtPriceX64)
+	assert.Error(t, err)
+}
+
+func TestGetAmountDeltas(t *testing.T) {
+	liquidity := big.NewInt(1_000_000_000)
+	lower, err := GetSqrtPriceAtTick(-100)
+	require.NoError(t, err)
+	upper, err := GetSqrtPriceAtTick(100)
+	require.NoError(t, err)
+
+	// Rounding up never yields less than rounding down, and the order of the prices does not matter
+	for _, delta := range []func(a, b, l *big.Int, roundUp bool) *big.Int{GetAmount0Delta, GetAmount1Delta} {
+		down := delta(lower, upper, liquidity, false)
+		up := delta(upper, lower, liquidity, true)
+		assert.True(t, up.Cmp(down) >= 0)
+		assert.True(t, new(big.Int).Sub(up, down).Cmp(big.NewInt(2)) <= 0)
+	}
+
+	// Around tick 0 both tokens are worth the same, so a symmetric range holds equal amounts
+	amount0 := GetAmount0Delta(lower, upper, liquidity, false)
+	amount1 := GetAmount1Delta(lower, upper, liquidity, false)
+	assert.InDelta(t, amount0.Int64(), amount1.Int64(), 2)
+	// 1e9 * (1.0001^50 - 1.0001^-50) = 9999541.69...
+	assert.Equal(t, int64(9999541), amount1.Int64())
+}
+
+func TestComputeSwapStepExactIn(t *testing.T) {
+	current := q64
+	target, err := GetSqrtPriceAtTick(-1000)
+	require.NoError(t, err)
+	liquidity := big.NewInt(10_000_000_000)
+	amount := big.NewInt(1_000_000)
+
+	step, err := computeSwapStep(current, target, liquidity, amount, 2500, true, true)
+	require.NoError(t, err)
+
+	// The range is not exhausted, so all input is consumed and the remainder after the deltas is the fee
+	assert.NotEqual(t, target, step.sqrtPriceNextX64)
+	assert.Equal(t, amount, new(big.Int).Add(step.amountIn, step.feeAmount))
+	assert.Equal(t, int64(2500), step.feeAmount.Int64())
+
+	// Input after fee moves the price to L*P/(L+x*P), rounded up
+	amountLessFee := big.NewInt(997_500)
+	numerator := new(big.Int).Lsh(liquidity, 64)
+	expectedPrice := mulDivCeil(numerator, current, new(big.Int).Add(numerator, new(big.Int).Mul(amountLessFee, current)))
+	assert.Equal(t, expectedPrice, step.sqrtPriceNextX64)
+	assert.Equal(t, GetAmount1Delta(step.sqrtPriceNextX64, current, liquidity, false), step.amountOut)
+}
+
+func TestComputeSwapStepReachesTarget(t *testing.T) {
+	current := q64
+	target, err := GetSqrtPriceAtTick(10)
+	require.NoError(t, err)
+	liquidity := big.NewInt(1_000_000)
+
+	step, err := computeSwapStep(current, target, liquidity, big.NewInt(1_000_000_000), 2500, true, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, target, step.sqrtPriceNextX64)
+	assert.Equal(t, GetAmount1Delta(current, target, liquidity, true), step.amountIn)
+	assert.Equal(t, mulDivCeil(step.amountIn, big.NewInt(2500), big.NewInt(FeeRateDenominator-2500)), step.feeAmount)
+}
@@ -1,8 +1,12 @@
 package clmm
 
+import bin "github.com/gagliardetto/binary"
+
 // RaydiumClmmPool represents the structure of a CLMM pool.
+// The on-chain state fields are only filled when the pool was fetched from the network.
 type RaydiumClmmPool struct {
 	ID                 string                     `json:"id"`
+	ProgramID          string                     `json:"programId"`
 	MintProgramIDA     string                     `json:"mintProgramIdA"`
 	MintProgramIDB     string                     `json:"mintProgramIdB"`
 	MintA              string                     `json:"mintA"`
@@ -18,6 +22,16 @@ type RaydiumClmmPool struct {
 	Week               *ApiClmmPoolsItemStatistic `json:"week,omitempty"`
 	Month              *ApiClmmPoolsItemStatistic `json:"month,omitempty"`
 	LookupTableAccount string                     `json:"lookupTableAccount"`
+
+	ObservationID       string                       `json:"observationId"`
+	SqrtPriceX64        bin.Uint128                  `json:"sqrtPriceX64"`
+	TickCurrent         int32                        `json:"tickCurrent"`
+	Liquidity           bin.Uint128                  `json:"liquidity"`
+	FeeGrowthGlobal0X64 bin.Uint128                  `json:"feeGrowthGlobal0X64"`
+	FeeGrowthGlobal1X64 bin.Uint128                  `json:"feeGrowthGlobal1X64"`
+	TickArrayBitmap     [TickArrayBitmapWords]uint64 `json:"tickArrayBitmap"`
+	Status              uint8                        `json:"status"`
+	OpenTime            uint64                       `json:"openTime"`
 }
 
 // ApiClmmConfigurationItem represents configuration details of a CLMM pool.
@@ -52,4 +66,12 @@ type RewardInfo struct {
 	ID        int    `json:"id"`
 	Mint      string `json:"mint"`
 	ProgramID string `json:"programId"`
+
+	Vault                 string      `json:"vault,omitempty"`
+	RewardState           uint8       `json:"rewardState"`
+	OpenTime              uint64      `json:"openTime"`
+	EndTime               uint64      `json:"endTime"`
+	LastUpdateTime        uint64      `json:"lastUpdateTime"`
+	EmissionsPerSecondX64 bin.Uint128 `json:"emissionsPerSecondX64"`
+	RewardGrowthGlobalX64 bin.Uint128 `json:"rewardGrowthGlobalX64"`
 }
@@ -0,0 +1,155 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+)
+
+// Run keeps the tracked accounts up to date until the context is cancelled. It follows them with
+// accountSubscribe and falls back to polling with getMultipleAccounts while the WebSocket is unavailable,
+// trying to subscribe again every retry interval.
+func (t *Tracker) Run(ctx context.Context) error {
+	go t.resyncLoop(ctx)
+
+	for {
+		if t.wsURL != "" {
+			err := t.subscribeSession(ctx)
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("Pool tracker: account subscriptions unavailable (%v), polling every %s", err, t.pollInterval)
+		}
+
+		if done := t.pollUntil(ctx, time.Now().Add(t.retryInterval)); done {
+			return nil
+		}
+	}
+}
+
+// pollUntil polls the tracked accounts until the deadline and reports whether the context is done
+func (t *Tracker) pollUntil(ctx context.Context, deadline time.Time) bool {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := t.PollOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Pool tracker: poll failed: %v", err)
+		}
+		// Without WebSocket the tracker polls for good
+		if t.wsURL != "" && !time.Now().Before(deadline) {
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribeSession subscribes to every tracked account on one connection and applies the notifications
+// until the connection fails
+func (t *Tracker) subscribeSession(ctx context.Context) error {
+	sessionCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	wsClient, err := ws.Connect(sessionCtx, t.wsURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to WebSocket: %w", err)
+	}
+	defer wsClient.Close()
+
+	failed := make(chan error, 1)
+	subscriptions := make(map[solana.PublicKey]*ws.AccountSubscription)
+	defer func() {
+		for _, sub := range subscriptions {
+			sub.Unsubscribe()
+		}
+	}()
+
+	resubscribe := func() error {
+		tracked := make(map[solana.PublicKey]bool)
+		for _, account := range t.trackedAccounts() {
+			tracked[account] = true
+			if _, ok := subscriptions[account]; ok {
+				continue
+			}
+			sub, err := wsClient.AccountSubscribeWithOpts(account, rpc.CommitmentProcessed, solana.EncodingBase64)
+			if err != nil {
+				return fmt.Errorf("failed to subscribe to account %s: %w", account, err)
+			}
+			subscriptions[account] = sub
+			go t.receive(sessionCtx, account, sub, failed)
+		}
+		for account, sub := range subscriptions {
+			if !tracked[account] {
+				sub.Unsubscribe()
+				delete(subscriptions, account)
+			}
+		}
+		return nil
+	}
+
+	if err := resubscribe(); err != nil {
+		return err
+	}
+	// Catch up with the changes made before the subscriptions were active
+	if err := t.PollOnce(sessionCtx); err != nil {
+		log.Printf("Pool tracker: poll failed: %v", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-failed:
+			return err
+		case <-t.changed:
+			if err := resubscribe(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// receive applies the notifications of one account subscription
+func (t *Tracker) receive(ctx context.Context, account solana.PublicKey, sub *ws.AccountSubscription, failed chan<- error) {
+	for {
+		result, err := sub.Recv(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				select {
+				case failed <- fmt.Errorf("subscription to %s failed: %w", account, err):
+				default:
+				}
+			}
+			return
+		}
+		if result == nil || result.Value.Data == nil {
+			continue
+		}
+		t.apply(account, result.Context.Slot, result.Value.Data.GetBinary())
+	}
+}
+
+// resyncLoop reloads the tick arrays of CLMM pools whose price moved outside the tracked ones
+func (t *Tracker) resyncLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case poolID := <-t.resync:
+			if err := t.loadSwapState(ctx, poolID); err != nil {
+				log.Printf("Pool tracker: failed to reload tick arrays of %s: %v", poolID, err)
+				continue
+			}
+			t.notifyChanged()
+		}
+	}
+}
@@ -0,0 +1,561 @@
+// Package tracker keeps the live state of watched AMM and CLMM pools in memory. Pool, vault and tick array
+// accounts are followed with accountSubscribe, or polled in batches with getMultipleAccounts while
+// subscriptions are unavailable, so quotes can be computed without a network round trip.
+package tracker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"corvus_bot/pkg/raydium/pool/amm"
+	"corvus_bot/pkg/raydium/pool/clmm"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ErrNotWatched is returned when reading a pool the tracker does not follow
+var ErrNotWatched = errors.New("pool is not watched")
+
+const (
+	// defaultPollInterval is the pause between two polls while subscriptions are unavailable
+	defaultPollInterval = 2 * time.Second
+
+	// defaultRetryInterval is how long the tracker polls before trying to subscribe again
+	defaultRetryInterval = 30 * time.Second
+
+	// maxAccountsPerRequest is the getMultipleAccounts limit
+	maxAccountsPerRequest = 100
+
+	// tokenAmountOffset is the offset of the amount in an SPL token account
+	tokenAmountOffset = 64
+)
+
+// accountRole tells how an account update is applied to its pool
+type accountRole int
+
+const (
+	roleAMMInfo accountRole = iota
+	roleAMMBaseVault
+	roleAMMQuoteVault
+	roleCLMMPool
+	roleCLMMVaultA
+	roleCLMMVaultB
+	roleCLMMTickArray
+)
+
+// trackedAccount links an account to the pool it belongs to
+type trackedAccount struct {
+	poolID     string
+	role       accountRole
+	startIndex int32  // Tick array start index, for roleCLMMTickArray
+	slot       uint64 // Slot of the last applied update
+}
+
+// AMMSnapshot is the tracked state of an AMM v4 pool
+type AMMSnapshot struct {
+	PoolID            string
+	Slot              uint64 // Highest slot among the applied account updates
+	BaseVaultBalance  uint64
+	QuoteVaultBalance uint64
+	Reserves          amm.PoolReserves
+}
+
+// CLMMSnapshot is the tracked state of a CLMM pool
+type CLMMSnapshot struct {
+	PoolID        string
+	Slot          uint64 // Highest slot among the applied account updates
+	SqrtPriceX64  bin.Uint128
+	Liquidity     bin.Uint128
+	TickCurrent   int32
+	VaultABalance uint64
+	VaultBBalance uint64
+}
+
+type ammEntry struct {
+	pool         amm.RaydiumAmmPool
+	info         *amm.AmmInfo
+	baseBalance  uint64
+	quoteBalance uint64
+	slot         uint64
+}
+
+type clmmEntry struct {
+	pool          clmm.RaydiumClmmPool
+	state         *clmm.SwapState
+	vaultABalance uint64
+	vaultBBalance uint64
+	slot          uint64
+	tickArrays    map[int32]solana.PublicKey // Subscribed tick array accounts by start index
+}
+
+// Tracker follows the accounts of the watched pools and keeps a snapshot of each pool
+type Tracker struct {
+	client        utils.RPCClientInterface
+	wsURL         string
+	pollInterval  time.Duration
+	retryInterval time.Duration
+
+	mu       sync.RWMutex
+	accounts map[solana.PublicKey]*trackedAccount
+	amm      map[string]*ammEntry
+	clmm     map[string]*clmmEntry
+
+	// changed wakes the subscription session when accounts are added or removed
+	changed chan struct{}
+	// resync receives CLMM pools whose price left the loaded tick arrays
+	resync chan string
+}
+
+// NewTracker creates a tracker reading accounts through the client. Subscriptions use wsURL; with an
+// empty wsURL the tracker only polls.
+func NewTracker(client utils.RPCClientInterface, wsURL string) *Tracker {
+	return &Tracker{
+		client:        client,
+		wsURL:         wsURL,
+		pollInterval:  defaultPollInterval,
+		retryInterval: defaultRetryInterval,
+		accounts:      make(map[solana.PublicKey]*trackedAccount),
+		amm:           make(map[string]*ammEntry),
+		clmm:          make(map[string]*clmmEntry),
+		changed:       make(chan struct{}, 1),
+		resync:        make(chan string, 64),
+	}
+}
+
+// WatchAMM loads the pool account and vaults of an AMM pool and follows them from then on
+func (t *Tracker) WatchAMM(ctx context.Context, pool amm.RaydiumAmmPool) error {
+	keys, err := publicKeys(pool.ID, pool.BaseVault, pool.QuoteVault)
+	if err != nil {
+		return fmt.Errorf("invalid AMM pool %s: %w", pool.ID, err)
+	}
+
+	t.mu.Lock()
+	t.amm[pool.ID] = &ammEntry{pool: pool}
+	t.accounts[keys[0]] = &trackedAccount{poolID: pool.ID, role: roleAMMInfo}
+	t.accounts[keys[1]] = &trackedAccount{poolID: pool.ID, role: roleAMMBaseVault}
+	t.accounts[keys[2]] = &trackedAccount{poolID: pool.ID, role: roleAMMQuoteVault}
+	t.mu.Unlock()
+
+	if err := t.load(ctx, keys); err != nil {
+		t.Unwatch(pool.ID)
+		return err
+	}
+	t.notifyChanged()
+	return nil
+}
+
+// WatchCLMM loads a CLMM pool with its vaults and the tick arrays swaps can reach in both directions,
+// and follows them from then on
+func (t *Tracker) WatchCLMM(ctx context.Context, pool clmm.RaydiumClmmPool) error {
+	keys, err := publicKeys(pool.ID, pool.VaultA, pool.VaultB)
+	if err != nil {
+		return fmt.Errorf("invalid CLMM pool %s: %w", pool.ID, err)
+	}
+
+	t.mu.Lock()
+	t.clmm[pool.ID] = &clmmEntry{pool: pool, tickArrays: make(map[int32]solana.PublicKey)}
+	t.accounts[keys[0]] = &trackedAccount{poolID: pool.ID, role: roleCLMMPool}
+	t.accounts[keys[1]] = &trackedAccount{poolID: pool.ID, role: roleCLMMVaultA}
+	t.accounts[keys[2]] = &trackedAccount{poolID: pool.ID, role: roleCLMMVaultB}
+	t.mu.Unlock()
+
+	if err := t.loadSwapState(ctx, pool.ID); err != nil {
+		t.Unwatch(pool.ID)
+		return err
+	}
+	if err := t.load(ctx, keys[1:]); err != nil {
+		t.Unwatch(pool.ID)
+		return err
+	}
+	t.notifyChanged()
+	return nil
+}
+
+// Unwatch stops following a pool
+func (t *Tracker) Unwatch(poolID string) {
+	t.mu.Lock()
+	delete(t.amm, poolID)
+	delete(t.clmm, poolID)
+	for account, tracked := range t.accounts {
+		if tracked.poolID == poolID {
+			delete(t.accounts, account)
+		}
+	}
+	t.mu.Unlock()
+	t.notifyChanged()
+}
+
+// AMM returns the snapshot of a watched AMM pool
+func (t *Tracker) AMM(poolID string) (*AMMSnapshot, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	entry, ok := t.amm[poolID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotWatched, poolID)
+	}
+	if entry.info == nil {
+		return nil, fmt.Errorf("pool %s is not loaded yet", poolID)
+	}
+	return &AMMSnapshot{
+		PoolID:            poolID,
+		Slot:              entry.slot,
+		BaseVaultBalance:  entry.baseBalance,
+		QuoteVaultBalance: entry.quoteBalance,
+		Reserves:          *amm.ReservesFromState(entry.baseBalance, entry.quoteBalance, entry.info),
+	}, nil
+}
+
+// CLMM returns the snapshot of a watched CLMM pool
+func (t *Tracker) CLMM(poolID string) (*CLMMSnapshot, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	entry, ok := t.clmm[poolID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotWatched, poolID)
+	}
+	if entry.state == nil {
+		return nil, fmt.Errorf("pool %s is not loaded yet", poolID)
+	}
+	return &CLMMSnapshot{
+		PoolID:        poolID,
+		Slot:          entry.slot,
+		SqrtPriceX64:  entry.state.Pool.SqrtPriceX64,
+		Liquidity:     entry.state.Pool.Liquidity,
+		TickCurrent:   entry.state.Pool.TickCurrent,
+		VaultABalance: entry.vaultABalance,
+		VaultBBalance: entry.vaultBBalance,
+	}, nil
+}
+
+// QuoteAMM quotes a swapBaseIn against the tracked reserves of a watched pool
+func (t *Tracker) QuoteAMM(poolID string, inputMint solana.PublicKey, amountIn, slippageBps uint64) (*amm.SwapQuote, error) {
+	snapshot, pool, err := t.ammState(poolID)
+	if err != nil {
+		return nil, err
+	}
+	return amm.QuoteWithReserves(pool, snapshot.Reserves, inputMint, amountIn, slippageBps)
+}
+
+// QuoteAMMExactOut quotes a swapBaseOut against the tracked reserves of a watched pool
+func (t *Tracker) QuoteAMMExactOut(poolID string, inputMint solana.PublicKey, amountOut, slippageBps uint64) (*amm.SwapQuote, error) {
+	snapshot, pool, err := t.ammState(poolID)
+	if err != nil {
+		return nil, err
+	}
+	return amm.QuoteExactOutWithReserves(pool, snapshot.Reserves, inputMint, amountOut, slippageBps)
+}
+
+// QuoteCLMM quotes a swap against the tracked state of a watched CLMM pool. Swaps crossing more tick arrays
+// than tracked fail the same way clmm.QuoteWithState does.
+func (t *Tracker) QuoteCLMM(poolID string, inputMint solana.PublicKey, amount, slippageBps uint64, isBaseInput bool) (*clmm.SwapQuote, error) {
+	t.mu.RLock()
+	entry, ok := t.clmm[poolID]
+	if !ok {
+		t.mu.RUnlock()
+		return nil, fmt.Errorf("%w: %s", ErrNotWatched, poolID)
+	}
+	if entry.state == nil {
+		t.mu.RUnlock()
+		return nil, fmt.Errorf("pool %s is not loaded yet", poolID)
+	}
+	pool := entry.pool
+	// Updates replace the decoded accounts instead of modifying them, so a shallow copy is a consistent view
+	state := &clmm.SwapState{
+		Pool:       entry.state.Pool,
+		Config:     entry.state.Config,
+		Extension:  entry.state.Extension,
+		TickArrays: make(map[int32]*clmm.TickArrayState, len(entry.state.TickArrays)),
+	}
+	for startIndex, tickArray := range entry.state.TickArrays {
+		state.TickArrays[startIndex] = tickArray
+	}
+	t.mu.RUnlock()
+
+	return clmm.QuoteWithState(pool, state, inputMint, amount, slippageBps, isBaseInput)
+}
+
+func (t *Tracker) ammState(poolID string) (*AMMSnapshot, amm.RaydiumAmmPool, error) {
+	snapshot, err := t.AMM(poolID)
+	if err != nil {
+		return nil, amm.RaydiumAmmPool{}, err
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return snapshot, t.amm[poolID].pool, nil
+}
+
+// PollOnce fetches every tracked account with getMultipleAccounts and applies the results
+func (t *Tracker) PollOnce(ctx context.Context) error {
+	return t.load(ctx, t.trackedAccounts())
+}
+
+// load fetches the accounts in batches and applies them at the slot of each response
+func (t *Tracker) load(ctx context.Context, accounts []solana.PublicKey) error {
+	opts := &rpc.GetMultipleAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentProcessed,
+	}
+	for start := 0; start < len(accounts); start += maxAccountsPerRequest {
+		end := start + maxAccountsPerRequest
+		if end > len(accounts) {
+			end = len(accounts)
+		}
+		batch := accounts[start:end]
+
+		result, err := t.client.GetMultipleAccountsWithOpts(ctx, batch, opts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch accounts: %w", err)
+		}
+		if result == nil || len(result.Value) != len(batch) {
+			return fmt.Errorf("failed to fetch accounts: expected %d results", len(batch))
+		}
+		for k, account := range result.Value {
+			if account == nil || account.Data == nil {
+				log.Printf("Pool tracker: account %s does not exist", batch[k])
+				continue
+			}
+			t.apply(batch[k], result.Context.Slot, account.Data.GetBinary())
+		}
+	}
+	return nil
+}
+
+// apply decodes an account update into the snapshot of its pool. Updates older than the last applied one
+// for the same account are ignored.
+func (t *Tracker) apply(account solana.PublicKey, slot uint64, data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.accounts[account]
+	if !ok || slot < tracked.slot {
+		return
+	}
+
+	if err := t.applyLocked(tracked, slot, data); err != nil {
+		log.Printf("Pool tracker: failed to apply update of %s for pool %s: %v", account, tracked.poolID, err)
+		return
+	}
+	tracked.slot = slot
+}
+
+func (t *Tracker) applyLocked(tracked *trackedAccount, slot uint64, data []byte) error {
+	switch tracked.role {
+	case roleAMMInfo, roleAMMBaseVault, roleAMMQuoteVault:
+		entry, ok := t.amm[tracked.poolID]
+		if !ok {
+			return nil
+		}
+		switch tracked.role {
+		case roleAMMInfo:
+			info, err := amm.DecodeAmmInfo(data)
+			if err != nil {
+				return err
+			}
+			entry.info = info
+		case roleAMMBaseVault:
+			balance, err := tokenAmount(data)
+			if err != nil {
+				return err
+			}
+			entry.baseBalance = balance
+		case roleAMMQuoteVault:
+			balance, err := tokenAmount(data)
+			if err != nil {
+				return err
+			}
+			entry.quoteBalance = balance
+		}
+		entry.slot = maxSlot(entry.slot, slot)
+
+	default:
+		entry, ok := t.clmm[tracked.poolID]
+		if !ok {
+			return nil
+		}
+		switch tracked.role {
+		case roleCLMMPool:
+			state, err := clmm.DecodePoolState(data)
+			if err != nil {
+				return err
+			}
+			if entry.state == nil {
+				return nil
+			}
+			entry.state.Pool = state
+			if !entry.covers(state.TickCurrent) {
+				select {
+				case t.resync <- tracked.poolID:
+				default:
+				}
+			}
+		case roleCLMMVaultA:
+			balance, err := tokenAmount(data)
+			if err != nil {
+				return err
+			}
+			entry.vaultABalance = balance
+		case roleCLMMVaultB:
+			balance, err := tokenAmount(data)
+			if err != nil {
+				return err
+			}
+			entry.vaultBBalance = balance
+		case roleCLMMTickArray:
+			tickArray, err := clmm.DecodeTickArrayState(data)
+			if err != nil {
+				return err
+			}
+			if entry.state != nil {
+				entry.state.TickArrays[tracked.startIndex] = tickArray
+			}
+		}
+		entry.slot = maxSlot(entry.slot, slot)
+	}
+	return nil
+}
+
+// covers reports whether the tick array holding tick lies within the loaded ones, so swaps starting there
+// can be simulated
+func (e *clmmEntry) covers(tick int32) bool {
+	if len(e.tickArrays) == 0 {
+		return true
+	}
+	start := clmm.GetTickArrayStartIndex(tick, e.state.Pool.TickSpacing)
+	lowest, highest := start, start
+	first := true
+	for startIndex := range e.tickArrays {
+		if first || startIndex < lowest {
+			lowest = startIndex
+		}
+		if first || startIndex > highest {
+			highest = startIndex
+		}
+		first = false
+	}
+	return start >= lowest && start <= highest
+}
+
+// loadSwapState loads the pool, its config and the tick arrays reachable in both directions. It follows the
+// tick arrays that were not followed yet and stops following those swaps can no longer reach.
+func (t *Tracker) loadSwapState(ctx context.Context, poolID string) error {
+	t.mu.RLock()
+	entry, ok := t.clmm[poolID]
+	var pool clmm.RaydiumClmmPool
+	if ok {
+		pool = entry.pool
+	}
+	t.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotWatched, poolID)
+	}
+
+	up, err := clmm.FetchSwapState(ctx, t.client, pool, false)
+	if err != nil {
+		return fmt.Errorf("failed to load CLMM pool %s: %w", poolID, err)
+	}
+	down, err := clmm.FetchSwapState(ctx, t.client, pool, true)
+	if err != nil {
+		return fmt.Errorf("failed to load CLMM pool %s: %w", poolID, err)
+	}
+	for startIndex, tickArray := range down.TickArrays {
+		up.TickArrays[startIndex] = tickArray
+	}
+
+	programID, poolKey, err := clmmKeys(pool)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[int32]solana.PublicKey, len(up.TickArrays))
+	for startIndex := range up.TickArrays {
+		address, err := clmm.FindTickArrayAddress(programID, poolKey, startIndex)
+		if err != nil {
+			return err
+		}
+		wanted[startIndex] = address
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, ok = t.clmm[poolID]
+	if !ok {
+		return nil
+	}
+	entry.state = up
+	for startIndex, address := range entry.tickArrays {
+		if _, ok := wanted[startIndex]; !ok {
+			delete(entry.tickArrays, startIndex)
+			delete(t.accounts, address)
+		}
+	}
+	for startIndex, address := range wanted {
+		if _, ok := entry.tickArrays[startIndex]; ok {
+			continue
+		}
+		entry.tickArrays[startIndex] = address
+		t.accounts[address] = &trackedAccount{poolID: poolID, role: roleCLMMTickArray, startIndex: startIndex}
+	}
+	return nil
+}
+
+// trackedAccounts lists every followed account
+func (t *Tracker) trackedAccounts() []solana.PublicKey {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	accounts := make([]solana.PublicKey, 0, len(t.accounts))
+	for account := range t.accounts {
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
+
+func (t *Tracker) notifyChanged() {
+	select {
+	case t.changed <- struct{}{}:
+	default:
+	}
+}
+
+// tokenAmount reads the amount of an SPL token or Token-2022 account
+func tokenAmount(data []byte) (uint64, error) {
+	if len(data) < tokenAmountOffset+8 {
+		return 0, fmt.Errorf("token account data too short: %d bytes", len(data))
+	}
+	return binary.LittleEndian.Uint64(data[tokenAmountOffset:]), nil
+}
+
+func publicKeys(addresses ...string) ([]solana.PublicKey, error) {
+	keys := make([]solana.PublicKey, len(addresses))
+	for k, address := range addresses {
+		key, err := solana.PublicKeyFromBase58(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", address, err)
+		}
+		keys[k] = key
+	}
+	return keys, nil
+}
+
+func clmmKeys(pool clmm.RaydiumClmmPool) (solana.PublicKey, solana.PublicKey, error) {
+	keys, err := publicKeys(pool.ProgramID, pool.ID)
+	if err != nil {
+		return solana.PublicKey{}, solana.PublicKey{}, err
+	}
+	return keys[0], keys[1], nil
+}
+
+func maxSlot(a, b uint64) uint64 {
+	if b > a {
+		return b
+	}
+	return a
+}
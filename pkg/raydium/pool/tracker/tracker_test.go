@@ -0,0 +1,260 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"corvus_bot/pkg/raydium/pool/amm"
+	"corvus_bot/pkg/raydium/pool/clmm"
+	"corvus_bot/pkg/utils"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chain serves account data at a slot through the mock RPC client
+type chain struct {
+	mu       sync.Mutex
+	slot     uint64
+	owner    solana.PublicKey
+	accounts map[solana.PublicKey][]byte
+}
+
+func newChain(owner solana.PublicKey) *chain {
+	return &chain{slot: 1000, owner: owner, accounts: make(map[solana.PublicKey][]byte)}
+}
+
+func (c *chain) set(account solana.PublicKey, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slot++
+	c.accounts[account] = data
+}
+
+func (c *chain) client() *utils.MockRPCClient {
+	return &utils.MockRPCClient{
+		MockGetMultipleAccounts: func(ctx context.Context, accounts []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			result := &rpc.GetMultipleAccountsResult{Value: make([]*rpc.Account, len(accounts))}
+			result.Context.Slot = c.slot
+			for k, account := range accounts {
+				if data, ok := c.accounts[account]; ok {
+					result.Value[k] = &rpc.Account{Owner: c.owner, Data: rpc.DataBytesOrJSONFromBytes(data)}
+				}
+			}
+			return result, nil
+		},
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			data, ok := c.accounts[account]
+			if !ok {
+				return &rpc.GetAccountInfoResult{}, nil
+			}
+			return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: c.owner, Data: rpc.DataBytesOrJSONFromBytes(data)}}, nil
+		},
+	}
+}
+
+func tokenAccount(amount uint64) []byte {
+	data := make([]byte, 165)
+	binary.LittleEndian.PutUint64(data[tokenAmountOffset:], amount)
+	return data
+}
+
+func encode(t *testing.T, prefix []byte, v interface{}) []byte {
+	t.Helper()
+	buf := bytes.NewBuffer(append([]byte(nil), prefix...))
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(v))
+	return buf.Bytes()
+}
+
+func newTestAMMPool() amm.RaydiumAmmPool {
+	return amm.RaydiumAmmPool{
+		ID:         solana.NewWallet().PublicKey().String(),
+		BaseMint:   solana.NewWallet().PublicKey().String(),
+		QuoteMint:  solana.SolMint.String(),
+		BaseVault:  solana.NewWallet().PublicKey().String(),
+		QuoteVault: solana.NewWallet().PublicKey().String(),
+	}
+}
+
+func setAMMState(t *testing.T, c *chain, pool amm.RaydiumAmmPool, base, quote, pnlCoin uint64) {
+	info := amm.AmmInfo{
+		Fees:   amm.Fees{SwapFeeNumerator: 25, SwapFeeDenominator: 10000},
+		OutPut: amm.OutPutData{NeedTakePnlCoin: pnlCoin},
+	}
+	c.set(solana.MustPublicKeyFromBase58(pool.ID), encode(t, nil, info))
+	c.set(solana.MustPublicKeyFromBase58(pool.BaseVault), tokenAccount(base))
+	c.set(solana.MustPublicKeyFromBase58(pool.QuoteVault), tokenAccount(quote))
+}
+
+func TestTrackerAMM(t *testing.T) {
+	c := newChain(solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"))
+	pool := newTestAMMPool()
+	setAMMState(t, c, pool, 5_000_000_000, 700_000_000, 1_000)
+
+	tracker := NewTracker(c.client(), "")
+	require.NoError(t, tracker.WatchAMM(context.Background(), pool))
+
+	snapshot, err := tracker.AMM(pool.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1003), snapshot.Slot)
+	assert.Equal(t, uint64(5_000_000_000), snapshot.BaseVaultBalance)
+	assert.Equal(t, uint64(4_999_999_000), snapshot.Reserves.BaseReserve, "pnl owed to the protocol is not swappable")
+	assert.Equal(t, uint64(700_000_000), snapshot.Reserves.QuoteReserve)
+
+	quote, err := tracker.QuoteAMM(pool.ID, solana.SolMint, 1_000_000, 50)
+	require.NoError(t, err)
+	expected, err := amm.QuoteWithReserves(pool, snapshot.Reserves, solana.SolMint, 1_000_000, 50)
+	require.NoError(t, err)
+	assert.Equal(t, expected, quote)
+
+	// A newer poll moves the reserves, an older update is ignored
+	c.set(solana.MustPublicKeyFromBase58(pool.QuoteVault), tokenAccount(701_000_000))
+	require.NoError(t, tracker.PollOnce(context.Background()))
+	tracker.apply(solana.MustPublicKeyFromBase58(pool.QuoteVault), 1000, tokenAccount(1))
+
+	snapshot, err = tracker.AMM(pool.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1004), snapshot.Slot)
+	assert.Equal(t, uint64(701_000_000), snapshot.QuoteVaultBalance)
+
+	tracker.Unwatch(pool.ID)
+	_, err = tracker.AMM(pool.ID)
+	assert.ErrorIs(t, err, ErrNotWatched)
+	assert.Empty(t, tracker.trackedAccounts())
+}
+
+func TestTrackerCLMM(t *testing.T) {
+	programID := solana.MustPublicKeyFromBase58("CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK")
+	c := newChain(programID)
+
+	pool := clmm.RaydiumClmmPool{
+		ID:        solana.NewWallet().PublicKey().String(),
+		ProgramID: programID.String(),
+		MintA:     solana.SolMint.String(),
+		MintB:     solana.NewWallet().PublicKey().String(),
+		VaultA:    solana.NewWallet().PublicKey().String(),
+		VaultB:    solana.NewWallet().PublicKey().String(),
+	}
+	poolID := solana.MustPublicKeyFromBase58(pool.ID)
+	configID := solana.NewWallet().PublicKey()
+
+	// One position over [-600, 600) with tick spacing 10 spans the tick arrays starting at -600 and 0
+	const liquidity = 1_000_000_000
+	sqrtPrice, err := clmm.GetSqrtPriceAtTick(5)
+	require.NoError(t, err)
+	state := &clmm.PoolState{
+		AmmConfig:    configID,
+		TokenVault0:  solana.MustPublicKeyFromBase58(pool.VaultA),
+		TokenVault1:  solana.MustPublicKeyFromBase58(pool.VaultB),
+		TickSpacing:  10,
+		TickCurrent:  5,
+		SqrtPriceX64: bin.Uint128{Lo: sqrtPrice.Uint64(), Hi: new(big.Int).Rsh(sqrtPrice, 64).Uint64()},
+		Liquidity:    bin.Uint128{Lo: liquidity},
+	}
+	state.TickArrayBitmap[7] = 1 << 63 // Array starting at -600
+	state.TickArrayBitmap[8] = 1       // Array starting at 0
+
+	lower := &clmm.TickArrayState{PoolID: poolID, StartTickIndex: -600}
+	lower.Ticks[0] = clmm.TickState{Tick: -600, LiquidityNet: bin.Int128{Lo: liquidity}, LiquidityGross: bin.Uint128{Lo: liquidity}}
+	upper := &clmm.TickArrayState{PoolID: poolID, StartTickIndex: 600}
+	upper.Ticks[0] = clmm.TickState{Tick: 600, LiquidityNet: bin.Int128{Lo: ^uint64(liquidity) + 1, Hi: ^uint64(0)}, LiquidityGross: bin.Uint128{Lo: liquidity}}
+	state.TickArrayBitmap[8] |= 1 << 1 // Array starting at 600
+	zero := &clmm.TickArrayState{PoolID: poolID, StartTickIndex: 0}
+
+	c.set(poolID, encode(t, clmm.PoolStateDiscriminator[:], state))
+	c.set(configID, encode(t, clmm.AmmConfigDiscriminator[:], clmm.AmmConfig{TradeFeeRate: 2500, TickSpacing: 10}))
+	for _, tickArray := range []*clmm.TickArrayState{lower, zero, upper} {
+		address, err := clmm.FindTickArrayAddress(programID, poolID, tickArray.StartTickIndex)
+		require.NoError(t, err)
+		c.set(address, encode(t, clmm.TickArrayStateDiscriminator[:], tickArray))
+	}
+	c.set(solana.MustPublicKeyFromBase58(pool.VaultA), tokenAccount(40_000_000))
+	c.set(solana.MustPublicKeyFromBase58(pool.VaultB), tokenAccount(90_000_000))
+
+	tracker := NewTracker(c.client(), "")
+	require.NoError(t, tracker.WatchCLMM(context.Background(), pool))
+
+	snapshot, err := tracker.CLMM(pool.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), snapshot.TickCurrent)
+	assert.Equal(t, state.SqrtPriceX64, snapshot.SqrtPriceX64)
+	assert.Equal(t, uint64(liquidity), snapshot.Liquidity.Lo)
+	assert.Equal(t, uint64(40_000_000), snapshot.VaultABalance)
+	assert.Equal(t, uint64(90_000_000), snapshot.VaultBBalance)
+	assert.Len(t, tracker.trackedAccounts(), 6, "pool, vaults and three tick arrays")
+
+	quote, err := tracker.QuoteCLMM(pool.ID, solana.SolMint, 100_000, 100, true)
+	require.NoError(t, err)
+	assert.True(t, quote.ZeroForOne)
+	assert.Equal(t, uint64(100_000), quote.AmountIn)
+	assert.Positive(t, quote.AmountOut)
+
+	// A swap moving the price out of the tracked arrays asks for a reload
+	moved := *state
+	moved.TickCurrent = 1300
+	tracker.apply(poolID, 2000, encode(t, clmm.PoolStateDiscriminator[:], &moved))
+	snapshot, err = tracker.CLMM(pool.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1300), snapshot.TickCurrent)
+	assert.Equal(t, uint64(2000), snapshot.Slot)
+	select {
+	case reload := <-tracker.resync:
+		assert.Equal(t, pool.ID, reload)
+	default:
+		t.Fatal("no tick array reload requested")
+	}
+
+	// The position below the price was closed: the reload stops following its tick array
+	lowerAddress, err := clmm.FindTickArrayAddress(programID, poolID, -600)
+	require.NoError(t, err)
+	closed := *state
+	closed.TickArrayBitmap[7] = 0
+	c.set(poolID, encode(t, clmm.PoolStateDiscriminator[:], &closed))
+	require.NoError(t, tracker.loadSwapState(context.Background(), pool.ID))
+	assert.Len(t, tracker.trackedAccounts(), 5, "pool, vaults and the two remaining tick arrays")
+	assert.NotContains(t, tracker.trackedAccounts(), lowerAddress)
+	tracker.mu.RLock()
+	assert.NotContains(t, tracker.clmm[pool.ID].tickArrays, int32(-600))
+	tracker.mu.RUnlock()
+
+	// Late notifications of the tick array are ignored
+	tracker.apply(lowerAddress, 3000, encode(t, clmm.TickArrayStateDiscriminator[:], lower))
+	tracker.mu.RLock()
+	assert.NotContains(t, tracker.clmm[pool.ID].state.TickArrays, int32(-600))
+	tracker.mu.RUnlock()
+}
+
+func TestTrackerRunPollsWithoutWebSocket(t *testing.T) {
+	c := newChain(solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8"))
+	pool := newTestAMMPool()
+	setAMMState(t, c, pool, 10, 20, 0)
+
+	tracker := NewTracker(c.client(), "")
+	tracker.pollInterval = time.Millisecond
+	require.NoError(t, tracker.WatchAMM(context.Background(), pool))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- tracker.Run(ctx) }()
+
+	c.set(solana.MustPublicKeyFromBase58(pool.BaseVault), tokenAccount(11))
+	assert.Eventually(t, func() bool {
+		snapshot, err := tracker.AMM(pool.ID)
+		return err == nil && snapshot.BaseVaultBalance == 11
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
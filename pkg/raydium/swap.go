@@ -33,7 +33,7 @@ func Swap(
 
 	switch poolType {
 	case "AMM":
-		pool, fetchErr := amm.FetchAmmPoolFromJSON(poolID, poolID, "./data/testdata/amm_pools.json")
+		pool, fetchErr := amm.FetchAmmPoolFromJSON(poolID, poolID, cfg.AMMPoolsPath)
 		if fetchErr != nil {
 			return solana.Signature{}, fmt.Errorf("failed to fetch AMM pool: %w", fetchErr)
 		}
@@ -50,7 +50,7 @@ func Swap(
 		)
 
 	case "CLMM":
-		pool, fetchErr := clmm.FetchClmmPoolFromJSON(poolID, "./data/testdata/clmm_pools.json")
+		pool, fetchErr := clmm.FetchClmmPoolFromJSON(poolID, cfg.CLMMPoolsPath)
 		if fetchErr != nil {
 			return solana.Signature{}, fmt.Errorf("failed to fetch CLMM pool: %w", fetchErr)
 		}
@@ -60,9 +60,9 @@ func Swap(
 			client,
 			wallet,
 			pool,
+			solana.MustPublicKeyFromBase58(pool.MintA),
 			amountIn,
 			minAmountOut,
-			cfg,
 		)
 	}
 
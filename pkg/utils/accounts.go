@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// ErrAccountNotFound is returned for accounts that do not exist or hold no data
+var ErrAccountNotFound = errors.New("account not found")
+
+// FetchAccount fetches an account and fails with ErrAccountNotFound when it does not exist or holds no data
+func FetchAccount(ctx context.Context, client RPCClientInterface, address solana.PublicKey) (*rpc.Account, error) {
+	accountInfo, err := client.GetAccountInfo(ctx, address)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account info for %s: %w", address, err)
+	}
+	if accountInfo == nil || accountInfo.Value == nil || accountInfo.Value.Data == nil || len(accountInfo.Value.Data.GetBinary()) == 0 {
+		return nil, fmt.Errorf("%w: no data found in the account info for %s", ErrAccountNotFound, address)
+	}
+	return accountInfo.Value, nil
+}
+
+// FetchAccounts fetches accounts in one request, with nil for the ones that do not exist
+func FetchAccounts(ctx context.Context, client RPCClientInterface, addresses []solana.PublicKey) ([]*rpc.Account, error) {
+	result, err := client.GetMultipleAccountsWithOpts(ctx, addresses, &rpc.GetMultipleAccountsOpts{
+		Encoding:   solana.EncodingBase64,
+		Commitment: rpc.CommitmentConfirmed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch accounts: %w", err)
+	}
+	if result == nil || len(result.Value) != len(addresses) {
+		return nil, fmt.Errorf("failed to fetch accounts: expected %d results", len(addresses))
+	}
+	accounts := make([]*rpc.Account, len(addresses))
+	for k, account := range result.Value {
+		if account != nil && account.Data != nil && len(account.Data.GetBinary()) > 0 {
+			accounts[k] = account
+		}
+	}
+	return accounts, nil
+}
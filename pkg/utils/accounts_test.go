@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAccount(t *testing.T) {
+	found := solana.NewWallet().PublicKey()
+	empty := solana.NewWallet().PublicKey()
+	client := &MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			switch account {
+			case found:
+				return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: solana.TokenProgramID, Data: rpc.DataBytesOrJSONFromBytes([]byte{1, 2})}}, nil
+			case empty:
+				return &rpc.GetAccountInfoResult{Value: &rpc.Account{Data: rpc.DataBytesOrJSONFromBytes(nil)}}, nil
+			}
+			return nil, rpc.ErrNotFound
+		},
+	}
+
+	account, err := FetchAccount(context.Background(), client, found)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, account.Data.GetBinary())
+
+	_, err = FetchAccount(context.Background(), client, empty)
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+	_, err = FetchAccount(context.Background(), client, solana.NewWallet().PublicKey())
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	client.MockGetAccountInfo = func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+		return nil, errors.New("429 Too Many Requests")
+	}
+	_, err = FetchAccount(context.Background(), client, found)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAccountNotFound, "transport errors are not a missing account")
+}
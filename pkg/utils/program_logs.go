@@ -0,0 +1,47 @@
+package utils
+
+import "strings"
+
+// ProgramDataPrefix introduces the base64 Anchor events a program emits with emit!
+const ProgramDataPrefix = "Program data: "
+
+// ProgramData returns the base64 payloads of the "Program data:" lines emitted by the program, in log order.
+// Lines are attributed to the program on top of the invoke stack, so data logged by the programs it calls,
+// or by other programs of the same transaction, is skipped.
+func ProgramData(logs []string, programID string) []string {
+	var stack []string
+	var data []string
+	for _, line := range logs {
+		if invoked, ok := invokedProgram(line); ok {
+			stack = append(stack, invoked)
+			continue
+		}
+		if returnedProgram(line) {
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ProgramDataPrefix) && len(stack) > 0 && stack[len(stack)-1] == programID {
+			data = append(data, line[len(ProgramDataPrefix):])
+		}
+	}
+	return data
+}
+
+// invokedProgram returns the program of a "Program <id> invoke [n]" line
+func invokedProgram(line string) (string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 4 && fields[0] == "Program" && fields[2] == "invoke" {
+		return fields[1], true
+	}
+	return "", false
+}
+
+// returnedProgram reports whether the line is the "Program <id> success" or "Program <id> failed: .." line
+// closing an invocation
+func returnedProgram(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) >= 3 && fields[0] == "Program" &&
+		(fields[2] == "success" || strings.HasPrefix(fields[2], "failed"))
+}
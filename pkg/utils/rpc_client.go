@@ -12,6 +12,11 @@ import (
 type RPCClientInterface interface {
 	GetLatestBlockhash(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error)
 	SendTransaction(ctx context.Context, tx *solana.Transaction) (solana.Signature, error)
+	GetTokenAccountBalance(ctx context.Context, account solana.PublicKey, commitment rpc.CommitmentType) (*rpc.GetTokenAccountBalanceResult, error)
+	GetAccountInfo(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error)
+	GetTransaction(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error)
+	GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error)
+	GetMultipleAccountsWithOpts(ctx context.Context, accounts []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error)
 }
 
 // RealRPCClient is the real implementation of the RPCClientInterface.
@@ -29,10 +34,40 @@ func (r *RealRPCClient) SendTransaction(ctx context.Context, tx *solana.Transact
 	return r.Client.SendTransaction(ctx, tx)
 }
 
+// GetTokenAccountBalance fetches the balance of an SPL token account.
+func (r *RealRPCClient) GetTokenAccountBalance(ctx context.Context, account solana.PublicKey, commitment rpc.CommitmentType) (*rpc.GetTokenAccountBalanceResult, error) {
+	return r.Client.GetTokenAccountBalance(ctx, account, commitment)
+}
+
+// GetAccountInfo fetches the raw account data.
+func (r *RealRPCClient) GetAccountInfo(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+	return r.Client.GetAccountInfo(ctx, account)
+}
+
+// GetTransaction fetches a confirmed transaction with its metadata.
+func (r *RealRPCClient) GetTransaction(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	return r.Client.GetTransaction(ctx, signature, opts)
+}
+
+// GetSignaturesForAddressWithOpts fetches the signatures of the transactions involving an account, newest first.
+func (r *RealRPCClient) GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	return r.Client.GetSignaturesForAddressWithOpts(ctx, account, opts)
+}
+
+// GetMultipleAccountsWithOpts fetches the raw data of up to 100 accounts in one request.
+func (r *RealRPCClient) GetMultipleAccountsWithOpts(ctx context.Context, accounts []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+	return r.Client.GetMultipleAccountsWithOpts(ctx, accounts, opts)
+}
+
 // MockRPCClient is a mock implementation of the RPCClientInterface for testing.
 type MockRPCClient struct {
-	MockGetLatestBlockhash func(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error)
-	MockSendTransaction    func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error)
+	MockGetLatestBlockhash      func(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error)
+	MockSendTransaction         func(ctx context.Context, tx *solana.Transaction) (solana.Signature, error)
+	MockGetTokenAccountBalance  func(ctx context.Context, account solana.PublicKey, commitment rpc.CommitmentType) (*rpc.GetTokenAccountBalanceResult, error)
+	MockGetAccountInfo          func(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error)
+	MockGetTransaction          func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error)
+	MockGetSignaturesForAddress func(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error)
+	MockGetMultipleAccounts     func(ctx context.Context, accounts []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error)
 }
 
 // GetLatestBlockhash mocks the GetLatestBlockhash method.
@@ -52,6 +87,46 @@ func (m *MockRPCClient) SendTransaction(ctx context.Context, tx *solana.Transact
 	return solana.Signature{}, nil
 }
 
+// GetTokenAccountBalance mocks the GetTokenAccountBalance method.
+func (m *MockRPCClient) GetTokenAccountBalance(ctx context.Context, account solana.PublicKey, commitment rpc.CommitmentType) (*rpc.GetTokenAccountBalanceResult, error) {
+	if m.MockGetTokenAccountBalance != nil {
+		return m.MockGetTokenAccountBalance(ctx, account, commitment)
+	}
+	return nil, nil
+}
+
+// GetAccountInfo mocks the GetAccountInfo method.
+func (m *MockRPCClient) GetAccountInfo(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+	if m.MockGetAccountInfo != nil {
+		return m.MockGetAccountInfo(ctx, account)
+	}
+	return nil, nil
+}
+
+// GetTransaction mocks the GetTransaction method.
+func (m *MockRPCClient) GetTransaction(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	if m.MockGetTransaction != nil {
+		return m.MockGetTransaction(ctx, signature, opts)
+	}
+	return nil, rpc.ErrNotFound
+}
+
+// GetSignaturesForAddressWithOpts mocks the GetSignaturesForAddressWithOpts method.
+func (m *MockRPCClient) GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	if m.MockGetSignaturesForAddress != nil {
+		return m.MockGetSignaturesForAddress(ctx, account, opts)
+	}
+	return nil, nil
+}
+
+// GetMultipleAccountsWithOpts mocks the GetMultipleAccountsWithOpts method.
+func (m *MockRPCClient) GetMultipleAccountsWithOpts(ctx context.Context, accounts []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+	if m.MockGetMultipleAccounts != nil {
+		return m.MockGetMultipleAccounts(ctx, accounts, opts)
+	}
+	return nil, nil
+}
+
 var mockRPCClient *MockRPCClient
 
 // SetMockRPCClient allows test code to set a mock RPC client globally.
@@ -0,0 +1,27 @@
+package utils
+
+import "math/big"
+
+// BpsDenominator is the denominator for basis point values
+const BpsDenominator = 10000
+
+// ApplySlippageDown lowers amount by slippageBps, rounding down: the minimum output a swap accepts
+func ApplySlippageDown(amount, slippageBps uint64) uint64 {
+	if slippageBps >= BpsDenominator {
+		return 0
+	}
+	v := new(big.Int).Mul(new(big.Int).SetUint64(amount), new(big.Int).SetUint64(BpsDenominator-slippageBps))
+	return v.Quo(v, big.NewInt(BpsDenominator)).Uint64()
+}
+
+// ApplySlippageUp raises amount by slippageBps, rounding up: the maximum input a swap accepts.
+// The result saturates at the largest u64.
+func ApplySlippageUp(amount, slippageBps uint64) uint64 {
+	v := new(big.Int).Mul(new(big.Int).SetUint64(amount), new(big.Int).SetUint64(BpsDenominator+slippageBps))
+	v.Add(v, big.NewInt(BpsDenominator-1))
+	v.Quo(v, big.NewInt(BpsDenominator))
+	if !v.IsUint64() {
+		return ^uint64(0)
+	}
+	return v.Uint64()
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySlippage(t *testing.T) {
+	assert.Equal(t, uint64(990), ApplySlippageDown(1000, 100))
+	assert.Equal(t, uint64(999), ApplySlippageDown(1001, 10), "rounds down")
+	assert.Equal(t, uint64(0), ApplySlippageDown(1000, BpsDenominator))
+
+	assert.Equal(t, uint64(1010), ApplySlippageUp(1000, 100))
+	assert.Equal(t, uint64(1003), ApplySlippageUp(1001, 10), "rounds up")
+	assert.Equal(t, ^uint64(0), ApplySlippageUp(^uint64(0), 1), "saturates")
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// Snapshot is a set of accounts read at one slot, with optionally a transaction, as captured from an RPC
+// node by cmd/snapshot
+type Snapshot struct {
+	Slot        uint64                    `json:"slot"`             // Context slot the accounts were read at
+	Accounts    []SnapshotAccount         `json:"accounts"`         // Accounts as of the end of Slot
+	Transaction *rpc.GetTransactionResult `json:"transaction"`      // nil when the snapshot holds no transaction
+	Source      string                    `json:"source,omitempty"` // Command line that captured the snapshot
+}
+
+// SnapshotAccount is an account of a Snapshot
+type SnapshotAccount struct {
+	Address  solana.PublicKey `json:"address"`
+	Owner    solana.PublicKey `json:"owner"`
+	Lamports uint64           `json:"lamports"`
+	Data     []byte           `json:"data"`
+}
+
+// LoadSnapshot reads a snapshot written by Save
+func LoadSnapshot(path string) (*Snapshot, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// Save writes the snapshot as indented JSON
+func (r *Snapshot) Save(path string) error {
+	raw, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return os.WriteFile(path, append(raw, '\n'), 0o644)
+}
+
+// Account returns the account at address, or nil when the snapshot does not hold it
+func (r *Snapshot) Account(address solana.PublicKey) *SnapshotAccount {
+	for k := range r.Accounts {
+		if r.Accounts[k].Address.Equals(address) {
+			return &r.Accounts[k]
+		}
+	}
+	return nil
+}
+
+// MockClient returns a client serving the accounts and the transaction of the snapshot. Accounts it does not
+// hold do not exist for it.
+func (r *Snapshot) MockClient() *MockRPCClient {
+	account := func(address solana.PublicKey) *rpc.Account {
+		held := r.Account(address)
+		if held == nil {
+			return nil
+		}
+		return &rpc.Account{
+			Owner:    held.Owner,
+			Lamports: held.Lamports,
+			Data:     rpc.DataBytesOrJSONFromBytes(held.Data),
+		}
+	}
+	return &MockRPCClient{
+		MockGetAccountInfo: func(ctx context.Context, address solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+			value := account(address)
+			if value == nil {
+				return nil, rpc.ErrNotFound
+			}
+			return &rpc.GetAccountInfoResult{RPCContext: rpc.RPCContext{Context: rpc.Context{Slot: r.Slot}}, Value: value}, nil
+		},
+		MockGetMultipleAccounts: func(ctx context.Context, addresses []solana.PublicKey, opts *rpc.GetMultipleAccountsOpts) (*rpc.GetMultipleAccountsResult, error) {
+			result := &rpc.GetMultipleAccountsResult{RPCContext: rpc.RPCContext{Context: rpc.Context{Slot: r.Slot}}}
+			for _, address := range addresses {
+				result.Value = append(result.Value, account(address))
+			}
+			return result, nil
+		},
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			if r.Transaction == nil || r.Transaction.Transaction == nil {
+				return nil, rpc.ErrNotFound
+			}
+			tx, err := r.Transaction.Transaction.GetTransaction()
+			if err != nil || len(tx.Signatures) == 0 || tx.Signatures[0] != signature {
+				return nil, rpc.ErrNotFound
+			}
+			return r.Transaction, nil
+		},
+	}
+}
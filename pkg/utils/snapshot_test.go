@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	payer := solana.NewWallet()
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{system.NewTransferInstruction(1, payer.PublicKey(), solana.NewWallet().PublicKey()).Build()},
+		solana.Hash{}, solana.TransactionPayer(payer.PublicKey()),
+	)
+	require.NoError(t, err)
+	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey { return &payer.PrivateKey })
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	var envelope rpc.TransactionResultEnvelope
+	require.NoError(t, json.Unmarshal([]byte(`["`+base64.StdEncoding.EncodeToString(raw)+`", "base64"]`), &envelope))
+
+	account := solana.NewWallet().PublicKey()
+	snapshot := &Snapshot{
+		Slot:        300,
+		Accounts:    []SnapshotAccount{{Address: account, Owner: solana.TokenProgramID, Lamports: 5, Data: []byte{1, 2, 3}}},
+		Transaction: &rpc.GetTransactionResult{Slot: 301, Transaction: &envelope, Meta: &rpc.TransactionMeta{Fee: 5000}},
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, snapshot.Save(path))
+
+	loaded, err := LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(300), loaded.Slot)
+	assert.Equal(t, snapshot.Accounts, loaded.Accounts)
+
+	client := loaded.MockClient()
+	fetched, err := FetchAccount(context.Background(), client, account)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, fetched.Data.GetBinary())
+	_, err = FetchAccount(context.Background(), client, solana.NewWallet().PublicKey())
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	result, err := FetchTransaction(context.Background(), client, tx.Signatures[0], 1, 0)
+	require.NoError(t, err)
+	decoded, err := DecodeTransaction(result)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Signatures, decoded.Signatures)
+	assert.Equal(t, uint64(5000), result.Meta.Fee)
+}
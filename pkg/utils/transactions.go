@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+const (
+	// DefaultFetchAttempts bounds the getTransaction calls for a signature: listeners are notified at
+	// processed commitment, before the transaction can be fetched at confirmed
+	DefaultFetchAttempts = 5
+
+	// DefaultFetchDelay is the pause between two getTransaction attempts
+	DefaultFetchDelay = 500 * time.Millisecond
+)
+
+// ExecutedInstruction is an instruction of a transaction, top level or invoked through CPI
+type ExecutedInstruction struct {
+	TopLevel    int  // Index of the top level instruction, or of the one that invoked it
+	Inner       bool // Whether the instruction was invoked by another one
+	Instruction *solana.CompiledInstruction
+}
+
+// FetchTransaction fetches a confirmed transaction, retrying up to attempts times while the node has not
+// seen it yet. Other RPC errors are returned at once.
+func FetchTransaction(ctx context.Context, client RPCClientInterface, signature solana.Signature, attempts int, delay time.Duration) (*rpc.GetTransactionResult, error) {
+	maxVersion := uint64(0)
+	opts := &rpc.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentConfirmed,
+		MaxSupportedTransactionVersion: &maxVersion,
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		result, err := client.GetTransaction(ctx, signature, opts)
+		if err == nil && result != nil {
+			return result, nil
+		}
+		if err == nil {
+			err = rpc.ErrNotFound
+		}
+		if !errors.Is(err, rpc.ErrNotFound) {
+			return nil, fmt.Errorf("failed to fetch transaction %s: %w", signature, err)
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("failed to fetch transaction %s after %d attempts: %w", signature, attempts, lastErr)
+}
+
+// DecodeTransaction checks that a fetched transaction succeeded and decodes it
+func DecodeTransaction(result *rpc.GetTransactionResult) (*solana.Transaction, error) {
+	if result == nil || result.Transaction == nil || result.Meta == nil {
+		return nil, fmt.Errorf("transaction or its metadata is missing")
+	}
+	if result.Meta.Err != nil {
+		return nil, fmt.Errorf("transaction failed: %v", result.Meta.Err)
+	}
+	tx, err := result.Transaction.GetTransaction()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// TransactionKeys returns the static account keys followed by the keys loaded from address lookup tables,
+// in the order instructions index them. Lookup tables the node did not resolve in the metadata are read
+// through client, which may be nil when the caller only handles resolved transactions.
+func TransactionKeys(ctx context.Context, client RPCClientInterface, tx *solana.Transaction, meta *rpc.TransactionMeta) (solana.PublicKeySlice, error) {
+	keys := append(solana.PublicKeySlice{}, tx.Message.AccountKeys...)
+	if len(tx.Message.AddressTableLookups) == 0 {
+		return keys, nil
+	}
+
+	loaded := meta.LoadedAddresses
+	if len(loaded.Writable)+len(loaded.ReadOnly) > 0 {
+		keys = append(keys, loaded.Writable...)
+		return append(keys, loaded.ReadOnly...), nil
+	}
+	if client == nil {
+		return nil, fmt.Errorf("transaction uses address lookup tables the metadata does not resolve")
+	}
+
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice)
+	for _, lookup := range tx.Message.AddressTableLookups {
+		account, err := FetchAccount(ctx, client, lookup.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch address lookup table %s: %w", lookup.AccountKey, err)
+		}
+		state, err := addresslookuptable.DecodeAddressLookupTableState(account.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode address lookup table %s: %w", lookup.AccountKey, err)
+		}
+		tables[lookup.AccountKey] = state.Addresses
+	}
+	if err := tx.Message.SetAddressTables(tables); err != nil {
+		return nil, err
+	}
+	return tx.Message.GetAllKeys()
+}
+
+// ExecutedInstructions lists the instructions of a transaction in execution order: each top level
+// instruction followed by the instructions it invoked
+func ExecutedInstructions(tx *solana.Transaction, meta *rpc.TransactionMeta) []ExecutedInstruction {
+	inner := make(map[uint16][]solana.CompiledInstruction)
+	for _, set := range meta.InnerInstructions {
+		inner[set.Index] = append(inner[set.Index], set.Instructions...)
+	}
+
+	var instructions []ExecutedInstruction
+	for k := range tx.Message.Instructions {
+		instructions = append(instructions, ExecutedInstruction{TopLevel: k, Instruction: &tx.Message.Instructions[k]})
+		invoked := inner[uint16(k)]
+		for j := range invoked {
+			instructions = append(instructions, ExecutedInstruction{TopLevel: k, Inner: true, Instruction: &invoked[j]})
+		}
+	}
+	return instructions
+}
+
+// FindInstruction returns the first instruction of programID, top level or invoked, whose data starts with
+// discriminator, or nil when the transaction has none
+func FindInstruction(tx *solana.Transaction, meta *rpc.TransactionMeta, keys solana.PublicKeySlice, programID solana.PublicKey, discriminator []byte) *solana.CompiledInstruction {
+	for _, executed := range ExecutedInstructions(tx, meta) {
+		instruction := executed.Instruction
+		if int(instruction.ProgramIDIndex) < len(keys) &&
+			keys[instruction.ProgramIDIndex].Equals(programID) &&
+			len(instruction.Data) >= len(discriminator) &&
+			string(instruction.Data[:len(discriminator)]) == string(discriminator) {
+			return instruction
+		}
+	}
+	return nil
+}
+
+// InstructionAccounts maps the account indexes of an instruction to keys
+func InstructionAccounts(instruction *solana.CompiledInstruction, keys solana.PublicKeySlice) ([]solana.PublicKey, error) {
+	accounts := make([]solana.PublicKey, len(instruction.Accounts))
+	for k, index := range instruction.Accounts {
+		if int(index) >= len(keys) {
+			return nil, fmt.Errorf("account index %d out of range of %d keys", index, len(keys))
+		}
+		accounts[k] = keys[index]
+	}
+	return accounts, nil
+}
+
+// TokenBalanceDeltas returns the change of the balance of every token account the metadata reports, by
+// address. Balances are u64, so the deltas are big integers.
+func TokenBalanceDeltas(meta *rpc.TransactionMeta, keys solana.PublicKeySlice) (map[solana.PublicKey]*big.Int, error) {
+	deltas := make(map[solana.PublicKey]*big.Int)
+	for k, balances := range [][]rpc.TokenBalance{meta.PreTokenBalances, meta.PostTokenBalances} {
+		for _, balance := range balances {
+			if balance.UiTokenAmount == nil {
+				continue
+			}
+			if int(balance.AccountIndex) >= len(keys) {
+				return nil, fmt.Errorf("token balance index %d out of range of %d keys", balance.AccountIndex, len(keys))
+			}
+			amount, err := strconv.ParseUint(balance.UiTokenAmount.Amount, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid token amount %q: %w", balance.UiTokenAmount.Amount, err)
+			}
+			key := keys[balance.AccountIndex]
+			if deltas[key] == nil {
+				deltas[key] = new(big.Int)
+			}
+			value := new(big.Int).SetUint64(amount)
+			if k == 1 {
+				deltas[key].Add(deltas[key], value)
+			} else {
+				deltas[key].Sub(deltas[key], value)
+			}
+		}
+	}
+	return deltas, nil
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchTransactionRetriesUntilConfirmed(t *testing.T) {
+	calls := 0
+	client := &MockRPCClient{
+		MockGetTransaction: func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+			calls++
+			assert.Equal(t, rpc.CommitmentConfirmed, opts.Commitment)
+			if calls < 3 {
+				return nil, rpc.ErrNotFound
+			}
+			return &rpc.GetTransactionResult{Slot: 42}, nil
+		},
+	}
+
+	result, err := FetchTransaction(context.Background(), client, solana.Signature{}, 5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), result.Slot)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = FetchTransaction(context.Background(), &MockRPCClient{}, solana.Signature{}, 2, 0)
+	assert.ErrorIs(t, err, rpc.ErrNotFound)
+
+	client.MockGetTransaction = func(ctx context.Context, signature solana.Signature, opts *rpc.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+		calls++
+		return nil, errors.New("429 Too Many Requests")
+	}
+	_, err = FetchTransaction(context.Background(), client, solana.Signature{}, 5, 0)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "only a transaction the node has not seen yet is retried")
+}
+
+func TestFindInstruction(t *testing.T) {
+	program := solana.NewWallet().PublicKey()
+	other := solana.NewWallet().PublicKey()
+	account := solana.NewWallet().PublicKey()
+	loaded := solana.NewWallet().PublicKey()
+	discriminator := []byte{7, 7}
+
+	tx := &solana.Transaction{Message: solana.Message{
+		AccountKeys:         solana.PublicKeySlice{account, program, other},
+		AddressTableLookups: solana.MessageAddressTableLookupSlice{{AccountKey: solana.NewWallet().PublicKey(), ReadonlyIndexes: []uint8{0}}},
+		Instructions: []solana.CompiledInstruction{
+			{ProgramIDIndex: 1, Data: []byte{1}},
+			{ProgramIDIndex: 2, Data: []byte{7, 7}},
+		},
+	}}
+	meta := &rpc.TransactionMeta{
+		LoadedAddresses: rpc.LoadedAddresses{ReadOnly: solana.PublicKeySlice{loaded}},
+		InnerInstructions: []rpc.InnerInstruction{{
+			Index:        1,
+			Instructions: []solana.CompiledInstruction{{ProgramIDIndex: 1, Accounts: []uint16{0, 3}, Data: []byte{7, 7, 9}}},
+		}},
+	}
+
+	keys, err := TransactionKeys(context.Background(), nil, tx, meta)
+	require.NoError(t, err)
+	assert.Equal(t, solana.PublicKeySlice{account, program, other, loaded}, keys)
+
+	executed := ExecutedInstructions(tx, meta)
+	require.Len(t, executed, 3)
+	assert.Equal(t, 1, executed[2].TopLevel)
+	assert.True(t, executed[2].Inner)
+
+	instruction := FindInstruction(tx, meta, keys, program, discriminator)
+	require.NotNil(t, instruction, "the instruction invoked through CPI is found")
+	accounts, err := InstructionAccounts(instruction, keys)
+	require.NoError(t, err)
+	assert.Equal(t, []solana.PublicKey{account, loaded}, accounts)
+
+	assert.Nil(t, FindInstruction(tx, meta, keys, program, []byte{8}))
+	_, err = InstructionAccounts(&solana.CompiledInstruction{Accounts: []uint16{4}}, keys)
+	assert.Error(t, err)
+
+	meta.LoadedAddresses = rpc.LoadedAddresses{}
+	_, err = TransactionKeys(context.Background(), nil, tx, meta)
+	assert.Error(t, err, "lookup tables the metadata does not resolve need a client")
+}
+
+func TestTokenBalanceDeltas(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	user := solana.NewWallet().PublicKey()
+	keys := solana.PublicKeySlice{user, vault}
+	meta := &rpc.TransactionMeta{
+		PreTokenBalances: []rpc.TokenBalance{
+			{AccountIndex: 1, UiTokenAmount: &rpc.UiTokenAmount{Amount: "18446744073709551615"}},
+		},
+		PostTokenBalances: []rpc.TokenBalance{
+			{AccountIndex: 0, UiTokenAmount: &rpc.UiTokenAmount{Amount: "18446744073709551000"}},
+			{AccountIndex: 1, UiTokenAmount: &rpc.UiTokenAmount{Amount: "615"}},
+		},
+	}
+
+	deltas, err := TokenBalanceDeltas(meta, keys)
+	require.NoError(t, err)
+	assert.Equal(t, "-18446744073709551000", deltas[vault].String(), "balances above the int64 range are supported")
+	assert.Equal(t, "18446744073709551000", deltas[user].String(), "an account created by the transaction starts from zero")
+
+	meta.PostTokenBalances[0].AccountIndex = 2
+	_, err = TokenBalanceDeltas(meta, keys)
+	assert.Error(t, err)
+}